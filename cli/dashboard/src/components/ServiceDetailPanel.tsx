@@ -26,6 +26,7 @@ import type { Service, HealthCheckResult } from '@/types'
 import { 
   formatUptime, 
   formatResponseTime,
+  formatPorts,
   getCheckTypeDisplay,
   getEffectiveStatus as getEffectiveStatusFromUtils,
   getStatusDisplay,
@@ -281,6 +282,9 @@ function OverviewTab({ service, healthStatus, operationState }: OverviewTabProps
           {!isProcess && service.local?.port && service.local.port > 0 && (
             <InfoRow label="Port" value={service.local.port} />
           )}
+          {!isProcess && service.local?.ports && service.local.ports.length > 1 && (
+            <InfoRow label="Ports" value={formatPorts(service.local.ports)} />
+          )}
         </div>
       </SectionCard>
 
@@ -388,6 +392,9 @@ function LocalTab({ service, healthStatus, copiedField, onCopy, operationState }
           {service.local?.port && service.local.port > 0 && (
             <InfoRow label="Port" value={service.local.port} />
           )}
+          {service.local?.ports && service.local.ports.length > 1 && (
+            <InfoRow label="Ports" value={formatPorts(service.local.ports)} />
+          )}
           {localUrl && (
             <InfoRow 
               label="URL" 
@@ -7,6 +7,7 @@ import {
   formatStartTime,
   formatLogTimestamp,
   formatResponseTime,
+  formatPorts,
   formatUptime,
   getCheckTypeDisplay,
   mergeHealthIntoService,
@@ -307,6 +308,20 @@ describe('service-utils', () => {
     })
   })
 
+  describe('formatPorts', () => {
+    it('should return - for undefined', () => {
+      expect(formatPorts(undefined)).toBe('-')
+    })
+
+    it('should format mappings, protocols and names', () => {
+      expect(formatPorts([
+        { hostPort: 10250, containerPort: 8081, name: 'gateway' },
+        { hostPort: 10251, containerPort: 10251, protocol: 'udp' },
+        { hostPort: 10252, protocol: 'tcp' },
+      ])).toBe('10250→8081 (gateway), 10251/udp, 10252')
+    })
+  })
+
   describe('formatUptime', () => {
     it('should return - for undefined', () => {
       expect(formatUptime(undefined)).toBe('-')
@@ -1,5 +1,5 @@
 import { CheckCircle, XCircle, Clock, AlertCircle, StopCircle, CircleDot, Circle, AlertTriangle, Eye, Hammer, type LucideIcon } from 'lucide-react'
-import type { Service, HealthCheckResult, HealthStatus, HealthSummary, ServiceType, ServiceMode, LifecycleState } from '@/types'
+import type { Service, HealthCheckResult, HealthStatus, HealthSummary, ServiceType, ServiceMode, LifecycleState, PortInfo } from '@/types'
 
 // Re-export OperationState from context for backward compatibility
 export type { OperationState } from '@/contexts/ServiceOperationsContext'
@@ -646,6 +646,21 @@ export function formatResponseTime(nanos?: number): string {
   return `${(ms / 1000).toFixed(1)}s`
 }
 
+/**
+ * Format port mappings as "host→container/protocol (name)" separated by commas,
+ * omitting the container port when it matches and the default tcp protocol
+ */
+export function formatPorts(ports?: PortInfo[]): string {
+  if (!ports || ports.length === 0) return '-'
+  return ports.map(p => {
+    let text = String(p.hostPort)
+    if (p.containerPort && p.containerPort !== p.hostPort) text += `→${p.containerPort}`
+    if (p.protocol && p.protocol !== 'tcp') text += `/${p.protocol}`
+    if (p.name) text += ` (${p.name})`
+    return text
+  }).join(', ')
+}
+
 /**
  * Format uptime from nanoseconds to human-readable string
  */
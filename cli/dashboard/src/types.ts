@@ -90,6 +90,16 @@ export interface LocalServiceInfo {
   healthDetails?: HealthDetails
   serviceType?: ServiceType
   serviceMode?: ServiceMode
+  /** All port mappings of the service; the first is the primary port */
+  ports?: PortInfo[]
+}
+
+/** A host port of a service and the port it maps to */
+export interface PortInfo {
+  name?: string
+  hostPort: number
+  containerPort?: number
+  protocol?: string
 }
 
 export interface AzureServiceInfo {
@@ -6,6 +6,7 @@ require (
 	github.com/azure/azure-dev/cli/azd v0.0.0-20251125180657-0bdb540fa966
 	github.com/cenkalti/backoff/v4 v4.3.0
 	github.com/coder/websocket v1.8.14
+	github.com/fsnotify/fsnotify v1.10.1
 	github.com/magefile/mage v1.15.0
 	github.com/mark3labs/mcp-go v0.41.1
 	github.com/patrickmn/go-cache v2.1.0+incompatible
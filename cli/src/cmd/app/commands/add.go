@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -265,6 +266,13 @@ func serviceExistsInYaml(path string, serviceName string) (bool, error) {
 }
 
 func addServiceToYaml(path string, serviceName string, def *wellknown.ServiceDefinition) error {
+	return insertServiceIntoYaml(path, serviceName, buildServiceNode(def))
+}
+
+// insertServiceIntoYaml appends a service to the services section of azure.yaml, creating the
+// section if needed. The file is edited as a YAML node tree so comments and key order are kept.
+// Returns an error if a service with the same name already exists.
+func insertServiceIntoYaml(path string, serviceName string, serviceConfig *yaml.Node) error {
 	// Read file
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -307,10 +315,21 @@ func addServiceToYaml(path string, serviceName string, def *wellknown.ServiceDef
 			Content: []*yaml.Node{},
 		}
 		root.Content = append(root.Content, keyNode, servicesNode)
+	} else if servicesNode.Kind != yaml.MappingNode {
+		// An empty "services:" key decodes as a null scalar
+		if servicesNode.Kind != yaml.ScalarNode || servicesNode.Tag != "!!null" {
+			return fmt.Errorf("azure.yaml services must be a mapping")
+		}
+		servicesNode.Kind = yaml.MappingNode
+		servicesNode.Tag = ""
+		servicesNode.Value = ""
 	}
 
-	// Build service config node
-	serviceConfig := buildServiceNode(def)
+	for i := 0; i < len(servicesNode.Content)-1; i += 2 {
+		if servicesNode.Content[i].Value == serviceName {
+			return fmt.Errorf("service %q already exists in azure.yaml", serviceName)
+		}
+	}
 
 	// Add service name and config to services
 	nameNode := &yaml.Node{
@@ -320,14 +339,19 @@ func addServiceToYaml(path string, serviceName string, def *wellknown.ServiceDef
 	}
 	servicesNode.Content = append(servicesNode.Content, nameNode, serviceConfig)
 
-	// Write back
-	yamlOutput, err := yaml.Marshal(&doc)
-	if err != nil {
+	// Write back with the 2-space indentation azure.yaml files use
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
 		return err
 	}
 
 	// #nosec G306 -- azure.yaml needs to be readable
-	return os.WriteFile(path, yamlOutput, 0644)
+	return os.WriteFile(path, buf.Bytes(), 0644)
 }
 
 func buildServiceNode(def *wellknown.ServiceDefinition) *yaml.Node {
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// configPath is the azure.yaml selected with the global --config flag, or "" when commands
+// search for the nearest one.
+var configPath string
+
+// UseAzureYaml makes every command use the azure.yaml at path (the global --config flag)
+// instead of the nearest one above the current directory, by running from its directory.
+// path may also be a directory containing azure.yaml.
+func UseAzureYaml(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("invalid --config: %w", err)
+	}
+
+	azureYamlPath := path
+	if info.IsDir() {
+		azureYamlPath = filepath.Join(path, "azure.yaml")
+		if _, err := os.Stat(azureYamlPath); err != nil {
+			return fmt.Errorf("invalid --config: no azure.yaml in %s", path)
+		}
+	} else if filepath.Base(path) != "azure.yaml" {
+		return fmt.Errorf("invalid --config: %s is not an azure.yaml file", path)
+	}
+
+	azureYamlPath, err = filepath.Abs(azureYamlPath)
+	if err != nil {
+		return fmt.Errorf("invalid --config: %w", err)
+	}
+	if err := os.Chdir(filepath.Dir(azureYamlPath)); err != nil {
+		return fmt.Errorf("failed to change to the directory of %s: %w", azureYamlPath, err)
+	}
+	configPath = azureYamlPath
+	return nil
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUseAzureYaml(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"apps/api/azure.yaml": "name: api\n",
+		"apps/web/README.md":  "# web",
+	})
+	apiDir, err := filepath.EvalSymlinks(filepath.Join(root, "apps", "api"))
+	if err != nil {
+		t.Fatalf("failed to resolve directory: %v", err)
+	}
+	defer func() { configPath = "" }()
+
+	for _, path := range []string{filepath.Join(root, "apps", "api", "azure.yaml"), filepath.Join(root, "apps", "api")} {
+		t.Chdir(root)
+		if err := UseAzureYaml(path); err != nil {
+			t.Fatalf("UseAzureYaml(%q) error = %v", path, err)
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("failed to get current directory: %v", err)
+		}
+		if cwd, _ = filepath.EvalSymlinks(cwd); cwd != apiDir {
+			t.Errorf("UseAzureYaml(%q) changed to %s, want %s", path, cwd, apiDir)
+		}
+		if filepath.Base(configPath) != "azure.yaml" || !filepath.IsAbs(configPath) {
+			t.Errorf("configPath = %q, want the absolute path of azure.yaml", configPath)
+		}
+	}
+
+	t.Chdir(root)
+	for path, want := range map[string]string{
+		filepath.Join(root, "missing", "azure.yaml"):    "invalid --config",
+		filepath.Join(root, "apps", "web"):              "no azure.yaml in",
+		filepath.Join(root, "apps", "web", "README.md"): "is not an azure.yaml file",
+	} {
+		if err := UseAzureYaml(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("UseAzureYaml(%q) error = %v, want it to contain %q", path, err, want)
+		}
+	}
+}
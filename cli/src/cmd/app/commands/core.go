@@ -26,10 +26,25 @@ type ReqsResult struct {
 
 // DepsResult represents the JSON output structure for deps command.
 type DepsResult struct {
-	Success  bool            `json:"success"`
-	Projects []InstallResult `json:"projects"`
-	Message  string          `json:"message,omitempty"`
-	Error    string          `json:"error,omitempty"`
+	Success     bool            `json:"success"`
+	Projects    []InstallResult `json:"projects"`
+	WouldRemove []CleanTarget   `json:"wouldRemove,omitempty"` // Set by --dry-run with --clean or --force
+	DiskUsage   []DiskUsage     `json:"diskUsage,omitempty"`   // Set by --report-size
+	Message     string          `json:"message,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// CleanTarget is a dependency directory that --clean would remove.
+type CleanTarget struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"` // Total size of the files in the directory, in bytes
+}
+
+// DiskUsage is the space a project's dependency directories use before and after deps runs.
+type DiskUsage struct {
+	Project string `json:"project"` // Project directory
+	Before  int64  `json:"before"`  // Bytes before cleaning and installing
+	After   int64  `json:"after"`   // Bytes after installing
 }
 
 // CleanDependenciesError represents an error during dependency cleaning with details.
@@ -131,8 +146,8 @@ func executeReqs() error {
 
 	// If no reqs section exists, skip checks gracefully
 	if len(effectiveReqs) == 0 {
-		if output.IsJSON() {
-			return output.PrintJSON(ReqsResult{
+		if output.IsStructured() {
+			return output.PrintStructured(ReqsResult{
 				Satisfied: true,
 				Reqs:      []ReqResult{},
 			})
@@ -146,12 +161,18 @@ func executeReqs() error {
 	// Check requirements (with caching)
 	results, allSatisfied := checkRequirementsWithCache(effectiveReqs, azureYamlPath, cacheManager)
 
-	// JSON output
-	if output.IsJSON() {
-		return output.PrintJSON(ReqsResult{
+	// JSON or YAML output; a missing requirement still fails the command after printing
+	if output.IsStructured() {
+		if err := output.PrintStructured(ReqsResult{
 			Satisfied: allSatisfied,
 			Reqs:      results,
-		})
+		}); err != nil {
+			return err
+		}
+		if !allSatisfied {
+			return fmt.Errorf("requirement check failed")
+		}
+		return nil
 	}
 
 	// Default output
@@ -2,13 +2,18 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/jongio/azd-app/cli/src/internal/detector"
 	"github.com/jongio/azd-app/cli/src/internal/installer"
 	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/testing"
 	"github.com/jongio/azd-app/cli/src/internal/types"
 	"github.com/jongio/azd-app/cli/src/internal/workspace"
 )
@@ -19,6 +24,11 @@ type DependencyInstaller struct {
 	nodeProjects   []types.NodeProject   // Pre-filtered Node.js projects (optional)
 	pythonProjects []types.PythonProject // Pre-filtered Python projects (optional)
 	dotnetProjects []types.DotnetProject // Pre-filtered .NET projects (optional)
+	failFast       bool                  // Skip the remaining installs after the first failure (--fail-fast)
+
+	// ctx is cancelled by the first failure while InstallAllFiltered runs with failFast
+	ctx             context.Context
+	cancelRemaining context.CancelCauseFunc
 }
 
 // NewDependencyInstaller creates a new dependency installer.
@@ -36,6 +46,14 @@ type InstallResult struct {
 	Manager string `json:"manager,omitempty"`
 	Success bool   `json:"success"`
 	Error   string `json:"error,omitempty"`
+
+	// Skipped is set for projects --fail-fast didn't install after another project failed
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Set by --dry-run for Node.js projects: the install scripts that would run,
+	// and whether --ignore-scripts skips them
+	Scripts        []string `json:"scripts,omitempty"`
+	ScriptsSkipped bool     `json:"scriptsSkipped,omitempty"`
 }
 
 // InstallAll installs dependencies for all detected project types.
@@ -81,10 +99,11 @@ func (di *DependencyInstaller) InstallAll() ([]InstallResult, error) {
 // Use this when projects have already been detected and filtered (e.g., by service name).
 func (di *DependencyInstaller) InstallAllFiltered() ([]InstallResult, error) {
 	var results []InstallResult
+	defer di.startFailFast()()
 
-	// Install Node.js dependencies from pre-filtered list
+	// Install Node.js dependencies from pre-filtered list, local packages before their dependents
 	if len(di.nodeProjects) > 0 {
-		nodeResults := di.installNodeProjectList(di.nodeProjects)
+		nodeResults := di.installNodeProjectList(workspace.OrderNodeProjects(di.nodeProjects))
 		results = append(results, nodeResults...)
 	}
 
@@ -103,6 +122,20 @@ func (di *DependencyInstaller) InstallAllFiltered() ([]InstallResult, error) {
 	return results, nil
 }
 
+// startFailFast makes the first failed install skip the remaining ones when failFast is set.
+// The returned function ends it.
+func (di *DependencyInstaller) startFailFast() func() {
+	if !di.failFast {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	di.ctx, di.cancelRemaining = ctx, cancel
+	return func() {
+		cancel(nil)
+		di.ctx, di.cancelRemaining = nil, nil
+	}
+}
+
 // installNodeProjectList installs dependencies for a list of Node.js projects.
 func (di *DependencyInstaller) installNodeProjectList(nodeProjects []types.NodeProject) []InstallResult {
 	var results []InstallResult
@@ -149,7 +182,7 @@ func (di *DependencyInstaller) installNodeProjects() ([]InstallResult, error) {
 		return nil, err
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Step("📦", "Found %s Node.js project(s)", output.Count(len(nodeProjects)))
 	}
 
@@ -161,7 +194,7 @@ func (di *DependencyInstaller) installNodeProjects() ([]InstallResult, error) {
 		results = append(results, result)
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 	}
 
@@ -175,7 +208,7 @@ func (di *DependencyInstaller) installPythonProjects() ([]InstallResult, error)
 		return nil, err
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Step("🐍", "Found %s Python project(s)", output.Count(len(pythonProjects)))
 	}
 
@@ -187,7 +220,7 @@ func (di *DependencyInstaller) installPythonProjects() ([]InstallResult, error)
 		results = append(results, result)
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 	}
 
@@ -201,7 +234,7 @@ func (di *DependencyInstaller) installDotnetProjects() ([]InstallResult, error)
 		return nil, err
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Step("🔷", "Found %s .NET project(s)", output.Count(len(dotnetProjects)))
 	}
 
@@ -212,7 +245,7 @@ func (di *DependencyInstaller) installDotnetProjects() ([]InstallResult, error)
 			Path: dotnetProject.Path,
 		}
 		if err := installer.RestoreDotnetProject(dotnetProject); err != nil {
-			if !output.IsJSON() {
+			if !output.IsStructured() {
 				output.ItemWarning("Failed to restore %s: %v", dotnetProject.Path, err)
 			}
 			result.Success = false
@@ -223,14 +256,15 @@ func (di *DependencyInstaller) installDotnetProjects() ([]InstallResult, error)
 		results = append(results, result)
 	}
 
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 	}
 
 	return results, nil
 }
 
-// installProject installs dependencies for a single project.
+// installProject installs dependencies for a single project. Once a failure has cancelled
+// the remaining installs (--fail-fast), the project is recorded as skipped instead.
 func (di *DependencyInstaller) installProject(projectType, dir, manager string, installFunc func() error) InstallResult {
 	result := InstallResult{
 		Type:    projectType,
@@ -238,8 +272,14 @@ func (di *DependencyInstaller) installProject(projectType, dir, manager string,
 		Manager: manager,
 	}
 
+	if di.ctx != nil && di.ctx.Err() != nil {
+		result.Skipped = true
+		result.Error = context.Cause(di.ctx).Error()
+		return result
+	}
+
 	// Show which project we're installing
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		relDir := dir
 		if rel, err := filepath.Rel(di.searchRoot, dir); err == nil && rel != "." {
 			relDir = rel
@@ -248,11 +288,14 @@ func (di *DependencyInstaller) installProject(projectType, dir, manager string,
 	}
 
 	if err := installFunc(); err != nil {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.ItemWarning("Failed to install for %s: %v", dir, err)
 		}
 		result.Success = false
 		result.Error = err.Error()
+		if di.cancelRemaining != nil {
+			di.cancelRemaining(installer.ErrInstallSkipped)
+		}
 	} else {
 		result.Success = true
 	}
@@ -283,21 +326,22 @@ func filterProjectsByService(
 
 	azureYamlDir := filepath.Dir(azureYamlPath)
 
-	// Build map of service name to absolute path
+	// Build map of service name to absolute path, keyed by service.PathKey so
+	// ".\api" and "./api" match the same projects on Windows and Unix
 	for name, svc := range azureYaml.Services {
 		// Check if this service is in the filter list
 		for _, filterName := range services {
 			if name == filterName {
-				svcPath := filepath.Join(azureYamlDir, svc.Project)
+				svcPath := service.ResolveProjectPath(azureYamlDir, svc.Project)
 				absPath, err := filepath.Abs(svcPath)
 				if err != nil {
 					// Log warning but continue processing other services
-					if !output.IsJSON() {
+					if !output.IsStructured() {
 						output.Warning("Failed to resolve absolute path for service %s: %v", name, err)
 					}
 					continue
 				}
-				servicePaths[absPath] = true
+				servicePaths[service.PathKey(absPath)] = true
 				break
 			}
 		}
@@ -307,7 +351,7 @@ func filterProjectsByService(
 	var filteredNode []types.NodeProject
 	for _, p := range nodeProjects {
 		absDir, _ := filepath.Abs(p.Dir)
-		if servicePaths[absDir] || isSubdirectory(absDir, servicePaths) {
+		if servicePaths[service.PathKey(absDir)] || isSubdirectory(absDir, servicePaths) {
 			filteredNode = append(filteredNode, p)
 		}
 	}
@@ -316,7 +360,7 @@ func filterProjectsByService(
 	var filteredPython []types.PythonProject
 	for _, p := range pythonProjects {
 		absDir, _ := filepath.Abs(p.Dir)
-		if servicePaths[absDir] || isSubdirectory(absDir, servicePaths) {
+		if servicePaths[service.PathKey(absDir)] || isSubdirectory(absDir, servicePaths) {
 			filteredPython = append(filteredPython, p)
 		}
 	}
@@ -326,7 +370,7 @@ func filterProjectsByService(
 	for _, p := range dotnetProjects {
 		absPath, _ := filepath.Abs(p.Path)
 		absDir := filepath.Dir(absPath)
-		if servicePaths[absDir] || isSubdirectory(absDir, servicePaths) {
+		if servicePaths[service.PathKey(absDir)] || isSubdirectory(absDir, servicePaths) {
 			filteredDotnet = append(filteredDotnet, p)
 		}
 	}
@@ -334,13 +378,110 @@ func filterProjectsByService(
 	return filteredNode, filteredPython, filteredDotnet
 }
 
+// applyInstallHooks attaches the preinstall and postinstall hooks of each service in azure.yaml
+// to the project in the service's project directory. Projects without a service, or whose
+// service has no hooks, are left as they are, as are all projects without an azure.yaml.
+func applyInstallHooks(
+	nodeProjects []types.NodeProject,
+	pythonProjects []types.PythonProject,
+	dotnetProjects []types.DotnetProject,
+	searchRoot string,
+) {
+	azureYamlPath, err := detector.FindAzureYaml(searchRoot)
+	if err != nil || azureYamlPath == "" {
+		return
+	}
+	azureYaml, err := parseAzureYaml(azureYamlPath)
+	if err != nil {
+		return
+	}
+
+	// Keyed by service.PathKey of the service's absolute project directory
+	hooksByDir := make(map[string]*types.InstallHooks)
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	for _, svc := range azureYaml.Services {
+		if svc.Hooks == nil || len(svc.Hooks.Preinstall)+len(svc.Hooks.Postinstall) == 0 {
+			continue
+		}
+		absDir, err := filepath.Abs(service.ResolveProjectPath(azureYamlDir, svc.Project))
+		if err != nil {
+			continue
+		}
+		hooksByDir[service.PathKey(absDir)] = newInstallHooks(svc, absDir)
+	}
+	if len(hooksByDir) == 0 {
+		return
+	}
+
+	hooksFor := func(dir string) *types.InstallHooks {
+		absDir, _ := filepath.Abs(dir)
+		return hooksByDir[service.PathKey(absDir)]
+	}
+	for i := range nodeProjects {
+		nodeProjects[i].Hooks = hooksFor(nodeProjects[i].Dir)
+	}
+	for i := range pythonProjects {
+		pythonProjects[i].Hooks = hooksFor(pythonProjects[i].Dir)
+	}
+	for i := range dotnetProjects {
+		dotnetProjects[i].Hooks = hooksFor(filepath.Dir(dotnetProjects[i].Path))
+	}
+}
+
+// newInstallHooks splits a service's hook commands with the test runner's command parser and
+// gives them the service's environment on top of the current one.
+func newInstallHooks(svc service.Service, dir string) *types.InstallHooks {
+	hooks := &types.InstallHooks{Dir: dir, Env: os.Environ()}
+	for _, command := range svc.Hooks.Preinstall {
+		hooks.Preinstall = append(hooks.Preinstall, testing.SplitCommand(command))
+	}
+	for _, command := range svc.Hooks.Postinstall {
+		hooks.Postinstall = append(hooks.Postinstall, testing.SplitCommand(command))
+	}
+
+	names := make([]string, 0, len(svc.Environment))
+	for name := range svc.Environment {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		hooks.Env = append(hooks.Env, name+"="+svc.Environment[name])
+	}
+	return hooks
+}
+
+// expandServicesWithDeps expands the service names to include their dependency closure from azure.yaml.
+// Returns the original names unchanged if azure.yaml cannot be found or parsed. Names that are not
+// azure.yaml services are kept, so they still filter (and report no match) rather than dropping out
+// and leaving an empty filter that selects every project.
+func expandServicesWithDeps(services []string, searchRoot string) []string {
+	azureYamlPath, err := detector.FindAzureYaml(searchRoot)
+	if err != nil || azureYamlPath == "" {
+		return services
+	}
+
+	azureYaml, err := parseAzureYaml(azureYamlPath)
+	if err != nil {
+		return services
+	}
+
+	expanded := service.ExpandDependencyClosure(azureYaml.Services, services)
+	for _, name := range services {
+		if _, ok := azureYaml.Services[name]; !ok {
+			expanded = append(expanded, name)
+		}
+	}
+	return expanded
+}
+
 // isSubdirectory checks if path is a subdirectory of any path in the set.
-// Uses filepath.Rel for cross-platform path comparison.
+// Paths are compared by service.PathKey, so either separator works and, on Windows,
+// case is ignored. Uses filepath.Rel for cross-platform path comparison.
 func isSubdirectory(path string, parentPaths map[string]bool) bool {
 	// Normalize the path
-	path = filepath.Clean(path)
+	path = service.PathKey(path)
 	for parent := range parentPaths {
-		parent = filepath.Clean(parent)
+		parent = service.PathKey(parent)
 		// Skip if path equals parent (we want strict subdirectory)
 		if path == parent {
 			continue
@@ -359,10 +500,13 @@ func isSubdirectory(path string, parentPaths map[string]bool) bool {
 	return false
 }
 
-// runParallelInstallation runs the parallel installer for non-JSON mode.
-func runParallelInstallation(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject, verbose bool) error {
+// runParallelInstallation runs the parallel installer for default (human-readable) mode.
+// With failFast, the first failure cancels the installs that haven't started yet.
+func runParallelInstallation(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject, verbose bool, concurrencyPerLanguage map[string]int, failFast bool) error {
 	parallelInstaller := installer.NewParallelInstaller()
 	parallelInstaller.Verbose = verbose
+	parallelInstaller.ConcurrencyPerLanguage = concurrencyPerLanguage
+	parallelInstaller.FailFast = failFast
 
 	// Handle npm/yarn/pnpm workspace scenarios using workspace handler
 	// When a workspace root exists, only install at the root level to avoid race conditions
@@ -388,6 +532,9 @@ func runParallelInstallation(nodeProjects []types.NodeProject, pythonProjects []
 	// Check for failures
 	if parallelInstaller.HasFailures() {
 		failedProjects := parallelInstaller.FailedProjects()
+		if skipped := parallelInstaller.SkippedProjects(); len(failedProjects) > 0 && len(skipped) > 0 {
+			return fmt.Errorf("failed to install %d of %d projects: %v (--fail-fast skipped %d)", len(failedProjects), parallelInstaller.TotalProjects(), failedProjects, len(skipped))
+		}
 		if len(failedProjects) > 0 {
 			return fmt.Errorf("failed to install %d of %d projects: %v", len(failedProjects), parallelInstaller.TotalProjects(), failedProjects)
 		}
@@ -397,21 +544,30 @@ func runParallelInstallation(nodeProjects []types.NodeProject, pythonProjects []
 	return nil
 }
 
-// runJSONInstallation runs installation in JSON mode with sequential output.
-func runJSONInstallation(searchRoot string, nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) error {
+// runJSONInstallation runs installation in JSON or YAML mode with sequential output.
+// sizeReport is nil unless --report-size is set. With failFast, the projects after the
+// first failure are reported as skipped.
+func runJSONInstallation(searchRoot string, nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject, sizeReport *diskUsageReport, failFast bool) error {
 	depInstaller := NewDependencyInstaller(searchRoot)
 	depInstaller.nodeProjects = nodeProjects
 	depInstaller.pythonProjects = pythonProjects
 	depInstaller.dotnetProjects = dotnetProjects
+	depInstaller.failFast = failFast
 
 	results, err := depInstaller.InstallAllFiltered()
 	if err != nil {
 		return err
 	}
 
+	usage, err := sizeReport.measure()
+	if err != nil {
+		return err
+	}
+
 	allSuccess := checkAllSuccess(results)
-	return output.PrintJSON(DepsResult{
-		Success:  allSuccess,
-		Projects: results,
+	return output.PrintStructured(DepsResult{
+		Success:   allSuccess,
+		Projects:  results,
+		DiskUsage: usage,
 	})
 }
@@ -3,11 +3,14 @@ package commands
 
 import (
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 
 	"github.com/jongio/azd-app/cli/src/internal/cache"
 	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/installer"
 	"github.com/jongio/azd-app/cli/src/internal/output"
 	"github.com/jongio/azd-app/cli/src/internal/security"
 	"github.com/jongio/azd-app/cli/src/internal/service"
@@ -24,7 +27,7 @@ func createCacheManager(enabled bool) *cache.CacheManager {
 	})
 	if err != nil {
 		// If cache fails to initialize, proceed without caching (fallback)
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Warning("Cache initialization failed, proceeding without cache: %v", err)
 		}
 		// Return disabled cache manager (won't fail)
@@ -33,6 +36,20 @@ func createCacheManager(enabled bool) *cache.CacheManager {
 	return cacheManager
 }
 
+// openRuntimeCache opens the service runtime cache for the project, or returns nil (no caching)
+// when noCache is set or the cache can't be opened.
+func openRuntimeCache(azureYamlPath string, noCache bool) *service.RuntimeCache {
+	if noCache {
+		return nil
+	}
+	cache, err := service.OpenRuntimeCache(azureYamlPath)
+	if err != nil {
+		slog.Debug("runtime cache unavailable, detecting services", "error", err)
+		return nil
+	}
+	return cache
+}
+
 // loadAzureYaml loads and validates the azure.yaml file.
 func loadAzureYaml() (string, *AzureYaml, error) {
 	// Get current working directory
@@ -73,17 +90,17 @@ func loadAzureYaml() (string, *AzureYaml, error) {
 
 	var azureYaml AzureYaml
 	if err := yaml.Unmarshal(data, &azureYaml); err != nil {
-		return "", nil, fmt.Errorf("failed to parse azure.yaml: %w", err)
+		return "", nil, fmt.Errorf("failed to parse %s: %w", azureYamlPath, err)
 	}
 
 	return azureYamlPath, &azureYaml, nil
 }
 
-// handleDepsError returns an error with JSON output if in JSON mode.
+// handleDepsError returns an error with structured output if in JSON or YAML mode.
 func handleDepsError(err error, message string) error {
 	fullErr := fmt.Errorf("%s: %w", message, err)
-	if output.IsJSON() {
-		return output.PrintJSON(DepsResult{Error: fullErr.Error()})
+	if output.IsStructured() {
+		return output.PrintStructured(DepsResult{Error: fullErr.Error()})
 	}
 	return fullErr
 }
@@ -141,7 +158,7 @@ func tryGetCachedResults(azureYamlPath string, cacheManager *cache.CacheManager)
 	cachedResults, valid, err := cacheManager.GetCachedResults(azureYamlPath)
 	if err != nil {
 		// Log cache read errors in both JSON and non-JSON modes for visibility
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Warning("Failed to read cache: %v", err)
 		}
 		// In JSON mode, error is still visible in debug/log output but doesn't affect user output
@@ -152,7 +169,7 @@ func tryGetCachedResults(azureYamlPath string, cacheManager *cache.CacheManager)
 	}
 
 	// Cache hit
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Info("Using cached reqs check results...")
 	}
 
@@ -160,7 +177,7 @@ func tryGetCachedResults(azureYamlPath string, cacheManager *cache.CacheManager)
 	results := convertCachedResults(cachedResults.Results)
 
 	// Print cached results
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		formatter := NewResultFormatter()
 		formatter.PrintAll(results)
 	}
@@ -202,7 +219,7 @@ func saveToCache(azureYamlPath string, results []ReqResult, allSatisfied bool, c
 		}
 	}
 
-	if err := cacheManager.SaveResults(azureYamlPath, cacheResults, allSatisfied); err != nil && !output.IsJSON() {
+	if err := cacheManager.SaveResults(azureYamlPath, cacheResults, allSatisfied); err != nil && !output.IsStructured() {
 		output.Warning("Failed to save cache: %v", err)
 	}
 }
@@ -272,7 +289,7 @@ func (rf *ResultFormatter) PrintAll(results []ReqResult) {
 
 // cleanDependencies removes existing dependency directories for all detected projects.
 func cleanDependencies(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) error {
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 		output.Section("🧹", "Cleaning Dependencies")
 		output.Newline()
@@ -280,34 +297,13 @@ func cleanDependencies(nodeProjects []types.NodeProject, pythonProjects []types.
 
 	var errors []error
 
-	// Clean Node.js projects
-	for _, project := range nodeProjects {
-		nodeModulesPath := filepath.Join(project.Dir, "node_modules")
-		if err := cleanDirectory(nodeModulesPath); err != nil {
+	for _, dirPath := range dependencyCleanTargets(nodeProjects, pythonProjects, dotnetProjects) {
+		if err := cleanDirectory(dirPath); err != nil {
 			errors = append(errors, err)
 		}
 	}
 
-	// Clean Python projects
-	for _, project := range pythonProjects {
-		venvPath := filepath.Join(project.Dir, ".venv")
-		if err := cleanDirectory(venvPath); err != nil {
-			errors = append(errors, err)
-		}
-	}
-
-	// Clean .NET projects (obj and bin directories)
-	for _, project := range dotnetProjects {
-		projectDir := filepath.Dir(project.Path)
-		for _, dir := range []string{"obj", "bin"} {
-			dirPath := filepath.Join(projectDir, dir)
-			if err := cleanDirectory(dirPath); err != nil {
-				errors = append(errors, err)
-			}
-		}
-	}
-
-	if !output.IsJSON() && len(errors) == 0 {
+	if !output.IsStructured() && len(errors) == 0 {
 		output.Newline()
 		output.Success("Dependencies cleaned successfully")
 	}
@@ -327,6 +323,186 @@ func cleanDependencies(nodeProjects []types.NodeProject, pythonProjects []types.
 	return nil
 }
 
+// dependencyCleanTargets returns the dependency directories that --clean removes for the given
+// projects: node_modules for Node.js, .venv for Python, and obj and bin for .NET.
+// Directories that don't exist are included; cleanDirectory skips them.
+func dependencyCleanTargets(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) []string {
+	var targets []string
+	for _, project := range projectDependencyDirs(nodeProjects, pythonProjects, dotnetProjects) {
+		targets = append(targets, project.dirs...)
+	}
+	return targets
+}
+
+// projectDeps is a project directory with the dependency directories below it.
+type projectDeps struct {
+	project string
+	dirs    []string
+}
+
+// projectDependencyDirs returns each project's dependency directories, as listed by dependencyCleanTargets.
+func projectDependencyDirs(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) []projectDeps {
+	var projects []projectDeps
+	for _, project := range nodeProjects {
+		projects = append(projects, projectDeps{project.Dir, []string{filepath.Join(project.Dir, "node_modules")}})
+	}
+	for _, project := range pythonProjects {
+		projects = append(projects, projectDeps{project.Dir, []string{filepath.Join(project.Dir, ".venv")}})
+	}
+	for _, project := range dotnetProjects {
+		projectDir := filepath.Dir(project.Path)
+		projects = append(projects, projectDeps{projectDir, []string{filepath.Join(projectDir, "obj"), filepath.Join(projectDir, "bin")}})
+	}
+	return projects
+}
+
+// diskUsageReport measures the projects' dependency directories before and after deps runs (--report-size).
+type diskUsageReport struct {
+	projects []projectDeps
+	before   []int64
+}
+
+// newDiskUsageReport records the current size of each project's dependency directories.
+func newDiskUsageReport(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) (*diskUsageReport, error) {
+	report := &diskUsageReport{projects: projectDependencyDirs(nodeProjects, pythonProjects, dotnetProjects)}
+	for _, project := range report.projects {
+		size, err := dependencyDirsSize(project.dirs)
+		if err != nil {
+			return nil, err
+		}
+		report.before = append(report.before, size)
+	}
+	return report, nil
+}
+
+// measure returns each project's disk usage before the report was created and now.
+// A nil report returns nil.
+func (r *diskUsageReport) measure() ([]DiskUsage, error) {
+	if r == nil {
+		return nil, nil
+	}
+	usage := make([]DiskUsage, 0, len(r.projects))
+	for i, project := range r.projects {
+		size, err := dependencyDirsSize(project.dirs)
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, DiskUsage{Project: project.project, Before: r.before[i], After: size})
+	}
+	return usage, nil
+}
+
+// dependencyDirsSize returns the total size of the given directories; missing ones count as empty.
+func dependencyDirsSize(dirs []string) (int64, error) {
+	var total int64
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		size, err := directorySize(dir)
+		if err != nil {
+			return 0, fmt.Errorf("failed to measure %s: %w", dir, err)
+		}
+		total += size
+	}
+	return total, nil
+}
+
+// plannedCleanTargets returns the dependency directories that --clean would remove right now,
+// with their sizes. Unlike dependencyCleanTargets, directories that don't exist are left out.
+func plannedCleanTargets(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject) ([]CleanTarget, error) {
+	var planned []CleanTarget
+	for _, dirPath := range dependencyCleanTargets(nodeProjects, pythonProjects, dotnetProjects) {
+		info, err := os.Stat(dirPath)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		size, err := directorySize(dirPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure %s: %w", dirPath, err)
+		}
+		planned = append(planned, CleanTarget{Path: dirPath, Size: size})
+	}
+	return planned, nil
+}
+
+// directorySize returns the total size in bytes of the regular files below path.
+// Symlinks are not followed, matching what os.RemoveAll deletes.
+func directorySize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// formatByteSize formats a byte count for display, e.g. "512 B" or "12.3 MB".
+func formatByteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// cleanableDirNames are the only directory names cleanDirectory and removeDependencyDirs
+// remove, to prevent accidental deletion of important files.
+var cleanableDirNames = map[string]bool{
+	"node_modules":  true,
+	".venv":         true,
+	"obj":           true,
+	"bin":           true,
+	"__pycache__":   true,
+	".pytest_cache": true,
+}
+
+// checkCleanableDir returns an error unless path is an expected dependency directory.
+func checkCleanableDir(path string) error {
+	if !cleanableDirNames[filepath.Base(path)] {
+		return fmt.Errorf("refusing to clean unexpected directory: %s (only dependency directories are allowed)", path)
+	}
+	return nil
+}
+
+// removeDependencyDirs removes the dependency directories among dirs that exist, without
+// printing anything (the MCP server's stdout carries the protocol). It returns the removed
+// directories and an error for each one that could not be removed.
+func removeDependencyDirs(dirs []string) ([]string, []error) {
+	removed := []string{}
+	var errs []error
+	for _, dirPath := range dirs {
+		if _, err := os.Stat(dirPath); err != nil {
+			continue
+		}
+		if err := checkCleanableDir(dirPath); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.RemoveAll(dirPath); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", dirPath, err))
+			continue
+		}
+		removed = append(removed, dirPath)
+	}
+	return removed, errs
+}
+
 // cleanDirectory removes a directory if it exists and logs the operation.
 // Returns an error if removal fails.
 func cleanDirectory(path string) error {
@@ -334,32 +510,20 @@ func cleanDirectory(path string) error {
 		return nil // Directory doesn't exist, nothing to clean
 	}
 
-	// Validate that we're only cleaning expected dependency directories
-	// to prevent accidental deletion of important files
-	dirName := filepath.Base(path)
-	validDirs := map[string]bool{
-		"node_modules":  true,
-		".venv":         true,
-		"obj":           true,
-		"bin":           true,
-		"__pycache__":   true,
-		".pytest_cache": true,
+	if err := checkCleanableDir(path); err != nil {
+		return err
 	}
 
-	if !validDirs[dirName] {
-		return fmt.Errorf("refusing to clean unexpected directory: %s (only dependency directories are allowed)", path)
-	}
-
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Item("Removing %s", path)
 	}
 	if err := os.RemoveAll(path); err != nil {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.ItemError("Failed: %v", err)
 		}
 		return fmt.Errorf("failed to remove %s: %w", path, err)
 	}
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.ItemSuccess("Removed successfully")
 	}
 	return nil
@@ -376,25 +540,15 @@ func unmarshalYaml(data []byte, v interface{}) error {
 	return yaml.Unmarshal(data, v)
 }
 
-// detectAllProjects detects all project types in the given directory.
+// detectAllProjects detects all project types in the given directory with a single
+// parallel walk (see detector.FindAllProjects).
 // This is a convenience wrapper for testing and backward compatibility.
 func detectAllProjects(searchRoot string) ([]types.NodeProject, []types.PythonProject, []types.DotnetProject, error) {
-	nodeProjects, err := detector.FindNodeProjects(searchRoot)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to detect Node.js projects: %w", err)
-	}
-
-	pythonProjects, err := detector.FindPythonProjects(searchRoot)
+	projects, err := detector.FindAllProjects(searchRoot)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to detect Python projects: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to detect projects: %w", err)
 	}
-
-	dotnetProjects, err := detector.FindDotnetProjects(searchRoot)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to detect .NET projects: %w", err)
-	}
-
-	return nodeProjects, pythonProjects, dotnetProjects, nil
+	return projects.Node, projects.Python, projects.Dotnet, nil
 }
 
 // parseAzureYaml parses the azure.yaml file.
@@ -405,22 +559,35 @@ func parseAzureYaml(azureYamlPath string) (*service.AzureYaml, error) {
 	}
 	var azureYaml service.AzureYaml
 	if err := unmarshalYaml(data, &azureYaml); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse %s: %w", azureYamlPath, err)
 	}
 	return &azureYaml, nil
 }
 
 // showDryRunSummary displays what would be installed without actually installing.
-func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject, searchRoot string) error {
-	if output.IsJSON() {
+// With clean set (--clean or --force), it also lists the dependency directories that would be removed.
+func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.PythonProject, dotnetProjects []types.DotnetProject, searchRoot string, clean bool) error {
+	var wouldRemove []CleanTarget
+	if clean {
+		var err error
+		wouldRemove, err = plannedCleanTargets(nodeProjects, pythonProjects, dotnetProjects)
+		if err != nil {
+			return err
+		}
+	}
+
+	if output.IsStructured() {
 		// Build dry-run results
 		var results []InstallResult
 		for _, p := range nodeProjects {
+			scripts := dryRunNodeScripts(p)
 			results = append(results, InstallResult{
-				Type:    "node",
-				Dir:     p.Dir,
-				Manager: p.PackageManager,
-				Success: true, // Would succeed (dry-run)
+				Type:           "node",
+				Dir:            p.Dir,
+				Manager:        p.PackageManager,
+				Success:        true, // Would succeed (dry-run)
+				Scripts:        scripts,
+				ScriptsSkipped: p.IgnoreScripts && len(scripts) > 0,
 			})
 		}
 		for _, p := range pythonProjects {
@@ -438,10 +605,11 @@ func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.
 				Success: true,
 			})
 		}
-		return output.PrintJSON(DepsResult{
-			Success:  true,
-			Projects: results,
-			Message:  "dry-run: no changes made",
+		return output.PrintStructured(DepsResult{
+			Success:     true,
+			Projects:    results,
+			WouldRemove: wouldRemove,
+			Message:     "dry-run: no changes made",
 		})
 	}
 
@@ -457,6 +625,13 @@ func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.
 				relDir = rel
 			}
 			output.Item("%s (%s)", relDir, p.PackageManager)
+			for _, script := range dryRunNodeScripts(p) {
+				if p.IgnoreScripts {
+					output.Item("  skipped (--ignore-scripts): %s", script)
+				} else {
+					output.Item("  would run: %s", script)
+				}
+			}
 		}
 		output.Newline()
 	}
@@ -485,6 +660,10 @@ func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.
 		output.Newline()
 	}
 
+	if clean {
+		showPlannedCleanTargets(wouldRemove, searchRoot)
+	}
+
 	total := len(nodeProjects) + len(pythonProjects) + len(dotnetProjects)
 	output.Info("Total: %d project(s) would be installed", total)
 	output.Info("Run without --dry-run to install dependencies")
@@ -492,13 +671,23 @@ func showDryRunSummary(nodeProjects []types.NodeProject, pythonProjects []types.
 	return nil
 }
 
+// dryRunNodeScripts returns the install scripts a Node.js project would run.
+// Unreadable package files are logged and treated as having no scripts.
+func dryRunNodeScripts(project types.NodeProject) []string {
+	scripts, err := installer.NodeLifecycleScripts(project)
+	if err != nil {
+		slog.Warn("failed to list install scripts", "project", project.Dir, "error", err)
+	}
+	return scripts
+}
+
 // handleNoProjectsCase handles the case when no projects are detected.
 func handleNoProjectsCase(searchRoot string, serviceFilter []string) error {
 	// If user specified services but none matched, show a helpful message
 	if len(serviceFilter) > 0 {
 		msg := fmt.Sprintf("No projects found matching services: %v", serviceFilter)
-		if output.IsJSON() {
-			return output.PrintJSON(DepsResult{
+		if output.IsStructured() {
+			return output.PrintStructured(DepsResult{
 				Success:  true,
 				Projects: []InstallResult{},
 				Message:  msg,
@@ -521,8 +710,8 @@ func handleNoProjectsCase(searchRoot string, serviceFilter []string) error {
 		}
 	}
 
-	if output.IsJSON() {
-		return output.PrintJSON(DepsResult{
+	if output.IsStructured() {
+		return output.PrintStructured(DepsResult{
 			Success:  true,
 			Projects: []InstallResult{},
 			Message:  msgNoProjectsDetected,
@@ -535,3 +724,47 @@ func handleNoProjectsCase(searchRoot string, serviceFilter []string) error {
 	}
 	return nil
 }
+
+// showDiskUsage prints each project's dependency disk usage before and after, with totals.
+// With --clean, everything used before was reclaimed.
+func showDiskUsage(usage []DiskUsage, searchRoot string, cleaned bool) {
+	output.Newline()
+	output.Section("💾", "Dependency Disk Usage")
+
+	var before, after int64
+	for _, project := range usage {
+		relPath := project.Project
+		if rel, err := filepath.Rel(searchRoot, project.Project); err == nil {
+			relPath = rel
+		}
+		output.Item("%s: %s → %s", relPath, formatByteSize(project.Before), formatByteSize(project.After))
+		before += project.Before
+		after += project.After
+	}
+	output.Item("Total: %s → %s", formatByteSize(before), formatByteSize(after))
+	if cleaned {
+		output.Item("Reclaimed by --clean: %s", formatByteSize(before))
+	}
+}
+
+// showPlannedCleanTargets lists the dependency directories that --clean would remove.
+func showPlannedCleanTargets(targets []CleanTarget, searchRoot string) {
+	output.Step("🧹", "Dependency directories that would be removed (%d)", len(targets))
+	if len(targets) == 0 {
+		output.Item("None - no dependencies are installed yet")
+		output.Newline()
+		return
+	}
+
+	var total int64
+	for _, target := range targets {
+		relPath := target.Path
+		if rel, err := filepath.Rel(searchRoot, target.Path); err == nil {
+			relPath = rel
+		}
+		output.Item("%s (%s)", relPath, formatByteSize(target.Size))
+		total += target.Size
+	}
+	output.Item("Total: %s", formatByteSize(total))
+	output.Newline()
+}
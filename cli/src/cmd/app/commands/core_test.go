@@ -36,6 +36,9 @@ func TestSetCacheEnabled(t *testing.T) {
 }
 
 func TestCreateCacheManager(t *testing.T) {
+	// An enabled cache manager creates .azure/cache in the working directory
+	t.Chdir(t.TempDir())
+
 	tests := []struct {
 		name    string
 		enabled bool
@@ -377,7 +380,8 @@ func TestSaveToCache(t *testing.T) {
 
 	// Create cache manager
 	cacheManager, err := cache.NewCacheManagerWithOptions(cache.CacheOptions{
-		Enabled: true,
+		Enabled:  true,
+		CacheDir: filepath.Join(tmpDir, ".azure", "cache"),
 	})
 	if err != nil {
 		t.Fatalf("failed to create cache manager: %v", err)
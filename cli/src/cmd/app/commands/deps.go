@@ -4,11 +4,16 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/installer"
 	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
 	"github.com/jongio/azd-app/cli/src/internal/types"
+	"github.com/jongio/azd-app/cli/src/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +26,26 @@ type DepsOptions struct {
 	Force    bool
 	DryRun   bool     // Show what would be installed without installing
 	Services []string // Filter to specific services by name
+	WithDeps bool     // Expand the service filter to include dependencies (via 'uses')
+	Graph    string   // Print the Node.js install order graph ("dot" or "json") instead of installing
+
+	// ReportSize reports the disk space used by each project's dependency directories before and after
+	ReportSize bool
+
+	// IgnoreScripts installs Node.js dependencies without running lifecycle scripts
+	IgnoreScripts bool
+
+	// ConcurrencyPerLanguage caps parallel installs per language (e.g. node=4)
+	ConcurrencyPerLanguage map[string]int
+
+	// FailFast stops starting new installs after the first failure
+	FailFast bool
+
+	// Frozen installs exactly what the lock files pin and fails if they are missing or out of date
+	Frozen bool
+
+	// MaxDepth limits project detection to this many directory levels below the project; 0 means no limit
+	MaxDepth int
 }
 
 // depsExecutor encapsulates the deps command execution with injectable dependencies.
@@ -39,11 +64,18 @@ type depsExecutor struct {
 
 // newDepsExecutor creates a depsExecutor with production dependencies.
 func newDepsExecutor(opts *DepsOptions) *depsExecutor {
+	searchOpts := detector.SearchOptions{MaxDepth: opts.MaxDepth}
 	return &depsExecutor{
-		getWorkingDir:   os.Getwd,
-		detectNode:      detector.FindNodeProjects,
-		detectPython:    detector.FindPythonProjects,
-		detectDotnet:    detector.FindDotnetProjects,
+		getWorkingDir: os.Getwd,
+		detectNode: func(root string) ([]types.NodeProject, error) {
+			return detector.FindNodeProjectsWithOptions(root, searchOpts)
+		},
+		detectPython: func(root string) ([]types.PythonProject, error) {
+			return detector.FindPythonProjectsWithOptions(root, searchOpts)
+		},
+		detectDotnet: func(root string) ([]types.DotnetProject, error) {
+			return detector.FindDotnetProjectsWithOptions(root, searchOpts)
+		},
 		detectFunctions: detector.FindFunctionApps,
 		opts:            opts,
 	}
@@ -71,6 +103,29 @@ func (e *depsExecutor) execute() error {
 			nodeProjects, pythonProjects, dotnetProjects, searchRoot)
 	}
 
+	// Skip Node.js lifecycle scripts (postinstall etc.) if requested
+	if e.opts.IgnoreScripts {
+		for i := range nodeProjects {
+			nodeProjects[i].IgnoreScripts = true
+		}
+	}
+
+	// Run the services' preinstall and postinstall hooks around their installs
+	applyInstallHooks(nodeProjects, pythonProjects, dotnetProjects, searchRoot)
+
+	// Verify installs against the committed lock files if requested
+	if e.opts.Frozen {
+		for i := range nodeProjects {
+			nodeProjects[i].Frozen = true
+		}
+		for i := range pythonProjects {
+			pythonProjects[i].Frozen = true
+		}
+		for i := range dotnetProjects {
+			dotnetProjects[i].Frozen = true
+		}
+	}
+
 	totalProjects := len(nodeProjects) + len(pythonProjects) + len(dotnetProjects)
 
 	// Handle no projects case
@@ -78,9 +133,22 @@ func (e *depsExecutor) execute() error {
 		return e.handleNoProjectsCase(searchRoot)
 	}
 
+	// Graph mode: show the install order and exit
+	if e.opts.Graph != "" {
+		return showInstallGraph(nodeProjects, e.opts.Graph)
+	}
+
 	// Dry-run mode: show what would be installed and exit
 	if e.opts.DryRun {
-		return showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, searchRoot)
+		return showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, searchRoot, e.opts.Clean)
+	}
+
+	// Measure dependency directories before they are cleaned or reinstalled (--report-size)
+	var sizeReport *diskUsageReport
+	if e.opts.ReportSize {
+		if sizeReport, err = newDiskUsageReport(nodeProjects, pythonProjects, dotnetProjects); err != nil {
+			return err
+		}
 	}
 
 	// Clean dependencies if requested
@@ -90,13 +158,31 @@ func (e *depsExecutor) execute() error {
 		}
 	}
 
+	// A forced reinstall can change lock files and package managers, so the next command
+	// detects service runtimes from scratch instead of using .azure/app-cache
+	if e.opts.Force {
+		if err := service.ClearRuntimeCache(searchRoot); err != nil {
+			output.Warning("%v", err)
+		}
+	}
+
 	// Use parallel installer for concurrent installation with progress bars
-	if !output.IsJSON() {
-		return runParallelInstallation(nodeProjects, pythonProjects, dotnetProjects, e.opts.Verbose)
+	if !output.IsStructured() {
+		installErr := runParallelInstallation(nodeProjects, pythonProjects, dotnetProjects, e.opts.Verbose, e.opts.ConcurrencyPerLanguage, e.opts.FailFast)
+		if sizeReport != nil {
+			// Partial installs still changed disk usage, so report it even when some failed
+			usage, err := sizeReport.measure()
+			if err != nil {
+				output.Warning("Failed to measure dependency disk usage: %v", err)
+			} else {
+				showDiskUsage(usage, searchRoot, e.opts.Clean)
+			}
+		}
+		return installErr
 	}
 
-	// JSON mode: use sequential installer
-	return runJSONInstallation(searchRoot, nodeProjects, pythonProjects, dotnetProjects)
+	// JSON/YAML mode: use sequential installer
+	return runJSONInstallation(searchRoot, nodeProjects, pythonProjects, dotnetProjects, sizeReport, e.opts.FailFast)
 }
 
 // detectAllProjects detects Node.js, Python, and .NET projects in the search root.
@@ -126,7 +212,11 @@ func (e *depsExecutor) filterProjectsByService(
 	dotnetProjects []types.DotnetProject,
 	searchRoot string,
 ) ([]types.NodeProject, []types.PythonProject, []types.DotnetProject) {
-	return filterProjectsByService(nodeProjects, pythonProjects, dotnetProjects, e.opts.Services, searchRoot)
+	services := e.opts.Services
+	if e.opts.WithDeps {
+		services = expandServicesWithDeps(services, searchRoot)
+	}
+	return filterProjectsByService(nodeProjects, pythonProjects, dotnetProjects, services, searchRoot)
 }
 
 // handleNoProjectsCase handles the case when no projects are detected.
@@ -134,8 +224,8 @@ func (e *depsExecutor) handleNoProjectsCase(searchRoot string) error {
 	// If user specified services but none matched, show a helpful message
 	if len(e.opts.Services) > 0 {
 		msg := fmt.Sprintf("No projects found matching services: %v", e.opts.Services)
-		if output.IsJSON() {
-			return output.PrintJSON(DepsResult{
+		if output.IsStructured() {
+			return output.PrintStructured(DepsResult{
 				Success:  true,
 				Projects: []InstallResult{},
 				Message:  msg,
@@ -158,8 +248,8 @@ func (e *depsExecutor) handleNoProjectsCase(searchRoot string) error {
 		}
 	}
 
-	if output.IsJSON() {
-		return output.PrintJSON(DepsResult{
+	if output.IsStructured() {
+		return output.PrintStructured(DepsResult{
 			Success:  true,
 			Projects: []InstallResult{},
 			Message:  msgNoProjectsDetected,
@@ -192,6 +282,16 @@ func GetDepsOptions() *DepsOptions {
 		Force:    globalDepsOptions.Force,
 		DryRun:   globalDepsOptions.DryRun,
 		Services: servicesCopy,
+		WithDeps: globalDepsOptions.WithDeps,
+		Graph:    globalDepsOptions.Graph,
+
+		ReportSize:    globalDepsOptions.ReportSize,
+		IgnoreScripts: globalDepsOptions.IgnoreScripts,
+
+		ConcurrencyPerLanguage: copyConcurrencyLimits(globalDepsOptions.ConcurrencyPerLanguage),
+		FailFast:               globalDepsOptions.FailFast,
+		Frozen:                 globalDepsOptions.Frozen,
+		MaxDepth:               globalDepsOptions.MaxDepth,
 	}
 }
 
@@ -224,13 +324,37 @@ func setDepsOptions(opts *DepsOptions) {
 		Force:    opts.Force,
 		DryRun:   opts.DryRun,
 		Services: servicesCopy,
+		WithDeps: opts.WithDeps,
+		Graph:    opts.Graph,
+
+		ReportSize:    opts.ReportSize,
+		IgnoreScripts: opts.IgnoreScripts,
+
+		ConcurrencyPerLanguage: copyConcurrencyLimits(opts.ConcurrencyPerLanguage),
+		FailFast:               opts.FailFast,
+		Frozen:                 opts.Frozen,
+		MaxDepth:               opts.MaxDepth,
 	}
 }
 
+// copyConcurrencyLimits returns a copy of the per-language concurrency limits.
+func copyConcurrencyLimits(limits map[string]int) map[string]int {
+	if limits == nil {
+		return nil
+	}
+	limitsCopy := make(map[string]int, len(limits))
+	for language, limit := range limits {
+		limitsCopy[language] = limit
+	}
+	return limitsCopy
+}
+
 // NewDepsCommand creates the deps command.
 func NewDepsCommand() *cobra.Command {
 	// Create options for this command invocation
 	opts := &DepsOptions{}
+	var query string
+	var allConfigs bool
 
 	cmd := &cobra.Command{
 		Use:          "deps",
@@ -251,6 +375,26 @@ func NewDepsCommand() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateGraphFormat(opts.Graph); err != nil {
+				return err
+			}
+			if err := installer.ValidateConcurrencyPerLanguage(opts.ConcurrencyPerLanguage); err != nil {
+				return fmt.Errorf("invalid --concurrency-per-language: %w", err)
+			}
+			if opts.MaxDepth < 0 {
+				return fmt.Errorf("invalid --max-depth value: %d (must be 0 or more)", opts.MaxDepth)
+			}
+			if query != "" && !opts.DryRun {
+				return fmt.Errorf("--query can only be used with --dry-run")
+			}
+			if err := setOutputQuery(query); err != nil {
+				return err
+			}
+			defer output.SetQuery(nil)
+			if err := validateAllConfigs(allConfigs); err != nil {
+				return err
+			}
+
 			// Handle --force flag (combines --clean and --no-cache)
 			if opts.Force {
 				opts.Clean = true
@@ -264,6 +408,9 @@ func NewDepsCommand() *cobra.Command {
 			if opts.NoCache {
 				SetCacheEnabled(false)
 			}
+			if allConfigs {
+				return runDepsForAllConfigs()
+			}
 			// Use orchestrator to run deps (which will automatically run reqs first)
 			return cmdOrchestrator.Run("deps")
 		},
@@ -275,6 +422,98 @@ func NewDepsCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Force clean reinstall (combines --clean and --no-cache)")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be installed without actually installing")
 	cmd.Flags().StringSliceVarP(&opts.Services, "service", "s", nil, "Install dependencies only for specific services (can be specified multiple times)")
+	cmd.Flags().BoolVar(&opts.WithDeps, "with-deps", false, "Also install dependencies for the services that --service targets depend on (via 'uses')")
+	cmd.Flags().StringVar(&opts.Graph, "graph", "", "Print the Node.js package install order and dependency cycles without installing (dot, json)")
+	cmd.Flags().BoolVar(&opts.ReportSize, "report-size", false, "Report the disk space used by each project's dependency directories before and after (and reclaimed by --clean)")
+	cmd.Flags().BoolVar(&opts.IgnoreScripts, "ignore-scripts", false, "Install Node.js dependencies without running lifecycle scripts such as postinstall (npm/pnpm/yarn --ignore-scripts)")
+	cmd.Flags().StringToIntVar(&opts.ConcurrencyPerLanguage, "concurrency-per-language", nil, "Limit parallel installs per language, e.g. node=4,python=2 (node, python, dotnet; default: unlimited)")
+	cmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "Stop starting new installs after the first failure; installs already running finish and the rest are reported as skipped")
+	cmd.Flags().BoolVar(&opts.Frozen, "frozen", false, "Install exactly what the lock files pin and fail if one is missing or out of date (npm ci, pnpm/yarn --frozen-lockfile, uv sync --locked, poetry check --lock, pip --require-hashes, dotnet restore --locked-mode)")
+	cmd.Flags().IntVar(&opts.MaxDepth, "max-depth", 0, "Search for projects at most this many directory levels below the project (default: no limit); directories in .azdappignore are always skipped")
+	cmd.Flags().StringVar(&query, "query", "", queryFlagUsage+" (with --dry-run)")
+	cmd.Flags().BoolVar(&allConfigs, "all-configs", false, "Install dependencies for every azure.yaml at or below the current directory, one after another (for monorepos)")
 
 	return cmd
 }
+
+// validateAllConfigs checks that --all-configs isn't combined with a single --config or with
+// structured output, which would print one document per azure.yaml.
+func validateAllConfigs(allConfigs bool) error {
+	if !allConfigs {
+		return nil
+	}
+	if configPath != "" {
+		return fmt.Errorf("--all-configs and --config cannot be used together")
+	}
+	if output.IsStructured() {
+		return fmt.Errorf("--all-configs does not support --output %s; use --config to select each azure.yaml instead", output.GetFormat())
+	}
+	return nil
+}
+
+// runDepsForAllConfigs runs deps, with its reqs check, once for every azure.yaml at or below
+// the current directory (deps --all-configs), from the directory of each. A failure doesn't
+// stop the others; the azure.yaml files that failed are reported at the end.
+func runDepsForAllConfigs() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	paths, err := detector.FindAllAzureYaml(cwd)
+	if err != nil {
+		return fmt.Errorf("error searching for azure.yaml files: %w", err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no azure.yaml found in %s or its subdirectories", cwd)
+	}
+	defer func() {
+		if err := os.Chdir(cwd); err != nil {
+			output.Warning("Failed to return to %s: %v", cwd, err)
+		}
+	}()
+
+	var failed []string
+	for _, path := range paths {
+		name := path
+		if rel, err := filepath.Rel(cwd, path); err == nil {
+			name = rel
+		}
+		output.Section("📁", name)
+		if err := os.Chdir(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to change to the directory of %s: %w", path, err)
+		}
+
+		// Each azure.yaml gets its own reqs and deps run
+		cmdOrchestrator.Reset()
+		if err := cmdOrchestrator.Run("deps"); err != nil {
+			output.Error("%s: %v", name, err)
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("deps failed for %d of %d azure.yaml files: %s", len(failed), len(paths), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// validateGraphFormat validates the --graph flag value.
+func validateGraphFormat(format string) error {
+	switch format {
+	case "", "dot", "json":
+		return nil
+	default:
+		return fmt.Errorf("invalid --graph value: %s (must be 'dot' or 'json')", format)
+	}
+}
+
+// showInstallGraph prints the order in which Node.js projects are installed, with the
+// local package dependencies that determine it and any dependency cycles.
+func showInstallGraph(nodeProjects []types.NodeProject, format string) error {
+	graph := workspace.BuildInstallGraph(nodeProjects)
+	if format == "json" {
+		return output.PrintJSON(graph)
+	}
+	fmt.Print(graph.DOT())
+	return nil
+}
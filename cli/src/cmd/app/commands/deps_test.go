@@ -1,8 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -615,7 +620,7 @@ func TestShowDryRunSummary_TextMode(t *testing.T) {
 	}
 
 	// showDryRunSummary should not return an error
-	err := showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, tmpDir)
+	err := showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, tmpDir, false)
 	if err != nil {
 		t.Errorf("showDryRunSummary returned error: %v", err)
 	}
@@ -628,7 +633,7 @@ func TestShowDryRunSummary_EmptyProjects(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Empty projects
-	err := showDryRunSummary(nil, nil, nil, tmpDir)
+	err := showDryRunSummary(nil, nil, nil, tmpDir, false)
 	if err != nil {
 		t.Errorf("showDryRunSummary with empty projects returned error: %v", err)
 	}
@@ -714,6 +719,8 @@ func TestParseAzureYaml_InvalidYaml(t *testing.T) {
 	_, err := parseAzureYaml(azureYamlPath)
 	if err == nil {
 		t.Error("parseAzureYaml should return error for invalid YAML")
+	} else if !strings.Contains(err.Error(), azureYamlPath) {
+		t.Errorf("parseAzureYaml error = %v, want it to name %s", err, azureYamlPath)
 	}
 }
 
@@ -1178,7 +1185,7 @@ func TestShowDryRunSummary_OnlyNodeProjects(t *testing.T) {
 		{Dir: filepath.Join(tmpDir, "web2"), PackageManager: "pnpm"},
 	}
 
-	err := showDryRunSummary(nodeProjects, nil, nil, tmpDir)
+	err := showDryRunSummary(nodeProjects, nil, nil, tmpDir, false)
 	if err != nil {
 		t.Errorf("showDryRunSummary returned error: %v", err)
 	}
@@ -1193,7 +1200,7 @@ func TestShowDryRunSummary_OnlyPythonProjects(t *testing.T) {
 		{Dir: filepath.Join(tmpDir, "api2"), PackageManager: "poetry"},
 	}
 
-	err := showDryRunSummary(nil, pythonProjects, nil, tmpDir)
+	err := showDryRunSummary(nil, pythonProjects, nil, tmpDir, false)
 	if err != nil {
 		t.Errorf("showDryRunSummary returned error: %v", err)
 	}
@@ -1208,7 +1215,7 @@ func TestShowDryRunSummary_OnlyDotnetProjects(t *testing.T) {
 		{Path: filepath.Join(tmpDir, "backend2", "project2.csproj")},
 	}
 
-	err := showDryRunSummary(nil, nil, dotnetProjects, tmpDir)
+	err := showDryRunSummary(nil, nil, dotnetProjects, tmpDir, false)
 	if err != nil {
 		t.Errorf("showDryRunSummary returned error: %v", err)
 	}
@@ -1815,6 +1822,45 @@ func TestInstallAllFiltered_EmptyProjects(t *testing.T) {
 	}
 }
 
+func TestDependencyInstaller_FailFast(t *testing.T) {
+	for _, failFast := range []bool{false, true} {
+		t.Run(fmt.Sprintf("failFast=%v", failFast), func(t *testing.T) {
+			di := NewDependencyInstaller(t.TempDir())
+			di.failFast = failFast
+			defer di.startFailFast()()
+
+			var installed []string
+			install := func(name string, err error) InstallResult {
+				return di.installProject("node", name, "npm", func() error {
+					installed = append(installed, name)
+					return err
+				})
+			}
+			results := []InstallResult{
+				install("web", nil),
+				install("api", errors.New("npm install failed")),
+				install("worker", nil),
+			}
+
+			if results[1].Success || results[1].Skipped || results[1].Error != "npm install failed" {
+				t.Errorf("api result = %+v, want the install failure", results[1])
+			}
+			if failFast {
+				if !reflect.DeepEqual(installed, []string{"web", "api"}) {
+					t.Errorf("installed %v, want [web api]", installed)
+				}
+				if worker := results[2]; worker.Success || !worker.Skipped || worker.Error == "" {
+					t.Errorf("worker result = %+v, want it skipped", worker)
+				}
+				return
+			}
+			if !reflect.DeepEqual(installed, []string{"web", "api", "worker"}) || !results[2].Success {
+				t.Errorf("installed %v (worker %+v), want every project installed", installed, results[2])
+			}
+		})
+	}
+}
+
 // Test showDryRunSummary JSON mode
 func TestShowDryRunSummary_JSONMode(t *testing.T) {
 	// Set JSON mode
@@ -1834,7 +1880,7 @@ func TestShowDryRunSummary_JSONMode(t *testing.T) {
 	}
 
 	// showDryRunSummary should return nil for JSON output
-	err := showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, tmpDir)
+	err := showDryRunSummary(nodeProjects, pythonProjects, dotnetProjects, tmpDir, false)
 	// In JSON mode it prints JSON and returns nil
 	if err != nil {
 		t.Logf("showDryRunSummary returned: %v (may be expected for JSON output)", err)
@@ -2116,6 +2162,93 @@ func TestIsSubdirectory_WindowsPaths(t *testing.T) {
 	}
 }
 
+// Test isSubdirectory with backslash-separated paths, which must match exactly like
+// the filepath.Join paths in TestIsSubdirectory_WindowsPaths on every platform
+func TestIsSubdirectory_BackslashPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		parentPaths map[string]bool
+		want        bool
+	}{
+		{
+			name:        "immediate subdirectory",
+			path:        `workspace\api\src`,
+			parentPaths: map[string]bool{`workspace\api`: true},
+			want:        true,
+		},
+		{
+			name:        "same directory",
+			path:        `workspace\api`,
+			parentPaths: map[string]bool{`workspace\api`: true},
+			want:        false,
+		},
+		{
+			name:        "mixed separators",
+			path:        `workspace/api\src`,
+			parentPaths: map[string]bool{filepath.Join("workspace", "api"): true},
+			want:        true,
+		},
+		{
+			name:        "sibling with shared prefix",
+			path:        `workspace\api2\src`,
+			parentPaths: map[string]bool{`workspace\api`: true},
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isSubdirectory(tt.path, tt.parentPaths)
+			if got != tt.want {
+				t.Errorf("isSubdirectory(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// Test filterProjectsByService with backslash project paths in azure.yaml
+func TestFilterProjectsByService_BackslashProjectPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	azureYamlContent := `name: test-app
+services:
+  api:
+    project: .\services\api
+  web:
+    project: ./services/web
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYamlContent), 0600); err != nil {
+		t.Fatalf("Failed to create azure.yaml: %v", err)
+	}
+
+	apiDir := filepath.Join(tmpDir, "services", "api")
+	webDir := filepath.Join(tmpDir, "services", "web")
+	nodeProjects := []types.NodeProject{
+		{Dir: apiDir, PackageManager: "npm"},
+		{Dir: filepath.Join(apiDir, "client"), PackageManager: "npm"},
+		{Dir: webDir, PackageManager: "npm"},
+	}
+
+	for _, tt := range []struct {
+		service string
+		want    []string
+	}{
+		{"api", []string{apiDir, filepath.Join(apiDir, "client")}},
+		{"web", []string{webDir}},
+	} {
+		filteredNode, _, _ := filterProjectsByService(nodeProjects, nil, nil, []string{tt.service}, tmpDir)
+		if len(filteredNode) != len(tt.want) {
+			t.Fatalf("service %s: expected %d node projects, got %d", tt.service, len(tt.want), len(filteredNode))
+		}
+		for i, p := range filteredNode {
+			if p.Dir != tt.want[i] {
+				t.Errorf("service %s: project %d = %s, want %s", tt.service, i, p.Dir, tt.want[i])
+			}
+		}
+	}
+}
+
 // Test cleanDependencies with directories that don't exist
 func TestCleanDependencies_NonExistentDirectories(t *testing.T) {
 	_ = output.SetFormat("text")
@@ -2379,6 +2512,7 @@ func TestInstallProject_RelativePath(t *testing.T) {
 
 // Test createCacheManager
 func TestCreateCacheManager_Enabled(t *testing.T) {
+	t.Chdir(t.TempDir())
 	cm := createCacheManager(true)
 	if cm == nil {
 		t.Error("createCacheManager should not return nil")
@@ -2984,3 +3118,432 @@ version = "1.0.0"
 		t.Errorf("Expected 1 python project, got %d", len(pythonProjects))
 	}
 }
+
+func TestValidateGraphFormat(t *testing.T) {
+	for _, format := range []string{"", "dot", "json"} {
+		if err := validateGraphFormat(format); err != nil {
+			t.Errorf("validateGraphFormat(%q) error = %v, want nil", format, err)
+		}
+	}
+	if err := validateGraphFormat("svg"); err == nil {
+		t.Error("validateGraphFormat(svg) error = nil, want error")
+	}
+}
+
+func TestDepsExecutor_GraphSkipsInstall(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(`{"name": "web"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := &depsExecutor{
+		getWorkingDir: func() (string, error) { return tmpDir, nil },
+		detectNode: func(root string) ([]types.NodeProject, error) {
+			return []types.NodeProject{{Dir: tmpDir, PackageManager: "npm"}}, nil
+		},
+		detectPython:    func(root string) ([]types.PythonProject, error) { return nil, nil },
+		detectDotnet:    func(root string) ([]types.DotnetProject, error) { return nil, nil },
+		detectFunctions: func(root string) ([]types.FunctionAppProject, error) { return nil, nil },
+		opts:            &DepsOptions{Graph: "dot"},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := executor.execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+	if !strings.Contains(string(out), `"web" [label="1. web"];`) {
+		t.Errorf("graph output should list the project, got:\n%s", out)
+	}
+	if _, statErr := os.Stat(filepath.Join(tmpDir, "node_modules")); !os.IsNotExist(statErr) {
+		t.Error("--graph should not install dependencies")
+	}
+}
+
+func TestGetDepsOptions_CopiesConcurrencyPerLanguage(t *testing.T) {
+	ResetDepsOptions()
+	defer ResetDepsOptions()
+
+	limits := map[string]int{"node": 4}
+	setDepsOptions(&DepsOptions{ConcurrencyPerLanguage: limits})
+	limits["node"] = 1
+
+	opts := GetDepsOptions()
+	if opts.ConcurrencyPerLanguage["node"] != 4 {
+		t.Errorf("ConcurrencyPerLanguage[node] = %d, want 4", opts.ConcurrencyPerLanguage["node"])
+	}
+	opts.ConcurrencyPerLanguage["node"] = 2
+	if got := GetDepsOptions().ConcurrencyPerLanguage["node"]; got != 4 {
+		t.Errorf("ConcurrencyPerLanguage[node] after mutating copy = %d, want 4", got)
+	}
+}
+
+// writeDepsFile creates a file of the given size, creating its parent directories.
+func writeDepsFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlannedCleanTargets(t *testing.T) {
+	tmpDir := t.TempDir()
+	webDir := filepath.Join(tmpDir, "web")
+	apiDir := filepath.Join(tmpDir, "api")
+	backendDir := filepath.Join(tmpDir, "backend")
+
+	writeDepsFile(t, filepath.Join(webDir, "node_modules", "react", "index.js"), 1000)
+	writeDepsFile(t, filepath.Join(webDir, "node_modules", "react", "package.json"), 24)
+	writeDepsFile(t, filepath.Join(backendDir, "obj", "project.assets.json"), 300)
+	// api has no .venv and backend has no bin: neither is listed
+
+	planned, err := plannedCleanTargets(
+		[]types.NodeProject{{Dir: webDir}},
+		[]types.PythonProject{{Dir: apiDir}},
+		[]types.DotnetProject{{Path: filepath.Join(backendDir, "backend.csproj")}},
+	)
+	if err != nil {
+		t.Fatalf("plannedCleanTargets() error = %v", err)
+	}
+
+	want := []CleanTarget{
+		{Path: filepath.Join(webDir, "node_modules"), Size: 1024},
+		{Path: filepath.Join(backendDir, "obj"), Size: 300},
+	}
+	if len(planned) != len(want) {
+		t.Fatalf("plannedCleanTargets() = %+v, want %+v", planned, want)
+	}
+	for i := range want {
+		if planned[i] != want[i] {
+			t.Errorf("plannedCleanTargets()[%d] = %+v, want %+v", i, planned[i], want[i])
+		}
+	}
+}
+
+func TestDiskUsageReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	webDir := filepath.Join(tmpDir, "web")
+	apiDir := filepath.Join(tmpDir, "api")
+	backendDir := filepath.Join(tmpDir, "backend")
+
+	writeDepsFile(t, filepath.Join(webDir, "node_modules", "react", "index.js"), 4096)
+	writeDepsFile(t, filepath.Join(backendDir, "obj", "project.assets.json"), 300)
+	writeDepsFile(t, filepath.Join(backendDir, "bin", "Debug", "backend.dll"), 700)
+	// api has no .venv yet
+
+	report, err := newDiskUsageReport(
+		[]types.NodeProject{{Dir: webDir}},
+		[]types.PythonProject{{Dir: apiDir}},
+		[]types.DotnetProject{{Path: filepath.Join(backendDir, "backend.csproj")}},
+	)
+	if err != nil {
+		t.Fatalf("newDiskUsageReport() error = %v", err)
+	}
+
+	// Simulate a clean reinstall: web is cleaned and reinstalled smaller, api gets a .venv
+	if err := os.RemoveAll(filepath.Join(webDir, "node_modules")); err != nil {
+		t.Fatal(err)
+	}
+	writeDepsFile(t, filepath.Join(webDir, "node_modules", "react", "index.js"), 2048)
+	writeDepsFile(t, filepath.Join(apiDir, ".venv", "lib", "site.py"), 1500)
+	writeDepsFile(t, filepath.Join(apiDir, ".venv", "pyvenv.cfg"), 100)
+
+	usage, err := report.measure()
+	if err != nil {
+		t.Fatalf("measure() error = %v", err)
+	}
+
+	want := []DiskUsage{
+		{Project: webDir, Before: 4096, After: 2048},
+		{Project: apiDir, Before: 0, After: 1600},
+		{Project: backendDir, Before: 1000, After: 1000},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("measure() = %+v, want %+v", usage, want)
+	}
+	for i := range want {
+		if usage[i] != want[i] {
+			t.Errorf("measure()[%d] = %+v, want %+v", i, usage[i], want[i])
+		}
+	}
+
+	var nilReport *diskUsageReport
+	if usage, err := nilReport.measure(); usage != nil || err != nil {
+		t.Errorf("nil report measure() = %v, %v, want nil, nil", usage, err)
+	}
+}
+
+func TestDepsExecutor_DryRunCleanKeepsDependencies(t *testing.T) {
+	_ = output.SetFormat("json")
+	defer func() { _ = output.SetFormat("text") }()
+
+	tmpDir := t.TempDir()
+	nodeModules := filepath.Join(tmpDir, "node_modules")
+	writeDepsFile(t, filepath.Join(nodeModules, "lib.js"), 2048)
+
+	executor := &depsExecutor{
+		getWorkingDir: func() (string, error) { return tmpDir, nil },
+		detectNode: func(root string) ([]types.NodeProject, error) {
+			return []types.NodeProject{{Dir: tmpDir, PackageManager: "npm"}}, nil
+		},
+		detectPython:    func(root string) ([]types.PythonProject, error) { return nil, nil },
+		detectDotnet:    func(root string) ([]types.DotnetProject, error) { return nil, nil },
+		detectFunctions: func(root string) ([]types.FunctionAppProject, error) { return nil, nil },
+		opts:            &DepsOptions{DryRun: true, Clean: true},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := executor.execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+
+	var result DepsResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("output is not a DepsResult: %v\n%s", err, out)
+	}
+	if len(result.WouldRemove) != 1 || result.WouldRemove[0].Path != nodeModules || result.WouldRemove[0].Size != 2048 {
+		t.Errorf("WouldRemove = %+v, want node_modules with 2048 bytes", result.WouldRemove)
+	}
+	if _, statErr := os.Stat(filepath.Join(nodeModules, "lib.js")); statErr != nil {
+		t.Errorf("--dry-run --clean should not remove dependencies: %v", statErr)
+	}
+}
+
+func TestDepsExecutor_DryRunIgnoreScripts(t *testing.T) {
+	_ = output.SetFormat("json")
+	defer func() { _ = output.SetFormat("text") }()
+
+	tmpDir := t.TempDir()
+	packageJSON := `{"name": "web", "scripts": {"postinstall": "node setup.js"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(packageJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := &depsExecutor{
+		getWorkingDir: func() (string, error) { return tmpDir, nil },
+		detectNode: func(root string) ([]types.NodeProject, error) {
+			return []types.NodeProject{{Dir: tmpDir, PackageManager: "npm"}}, nil
+		},
+		detectPython:    func(root string) ([]types.PythonProject, error) { return nil, nil },
+		detectDotnet:    func(root string) ([]types.DotnetProject, error) { return nil, nil },
+		detectFunctions: func(root string) ([]types.FunctionAppProject, error) { return nil, nil },
+		opts:            &DepsOptions{DryRun: true, IgnoreScripts: true},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := executor.execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+
+	var result DepsResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("output is not a DepsResult: %v\n%s", err, out)
+	}
+	if len(result.Projects) != 1 {
+		t.Fatalf("Projects = %+v, want one node project", result.Projects)
+	}
+	project := result.Projects[0]
+	if len(project.Scripts) != 1 || project.Scripts[0] != "postinstall: node setup.js" || !project.ScriptsSkipped {
+		t.Errorf("Scripts = %v, ScriptsSkipped = %v, want the postinstall script marked as skipped", project.Scripts, project.ScriptsSkipped)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		0:                      "0 B",
+		1023:                   "1023 B",
+		1024:                   "1.0 KB",
+		1536:                   "1.5 KB",
+		12*1024*1024 + 307200:  "12.3 MB",
+		3 * 1024 * 1024 * 1024: "3.0 GB",
+	}
+	for size, want := range tests {
+		if got := formatByteSize(size); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestApplyInstallHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYamlContent := `name: test-app
+services:
+  web:
+    project: ./web
+    language: js
+    environment:
+      API_URL: http://localhost:5000
+    hooks:
+      preinstall:
+        - node scripts/codegen.js --out "src/generated client"
+      postinstall:
+        - npm run build
+      prepackage:
+        run: echo packaging
+  api:
+    project: ./api
+    language: python
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYamlContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	webDir := filepath.Join(tmpDir, "web")
+	apiDir := filepath.Join(tmpDir, "api")
+
+	nodeProjects := []types.NodeProject{{Dir: webDir, PackageManager: "npm"}}
+	pythonProjects := []types.PythonProject{{Dir: apiDir, PackageManager: "pip"}}
+	applyInstallHooks(nodeProjects, pythonProjects, nil, tmpDir)
+
+	hooks := nodeProjects[0].Hooks
+	if hooks == nil {
+		t.Fatal("web project has no hooks")
+	}
+	wantPre := [][]string{{"node", "scripts/codegen.js", "--out", "src/generated client"}}
+	if !reflect.DeepEqual(hooks.Preinstall, wantPre) {
+		t.Errorf("Preinstall = %q, want %q", hooks.Preinstall, wantPre)
+	}
+	if wantPost := [][]string{{"npm", "run", "build"}}; !reflect.DeepEqual(hooks.Postinstall, wantPost) {
+		t.Errorf("Postinstall = %q, want %q", hooks.Postinstall, wantPost)
+	}
+	if abs, _ := filepath.Abs(webDir); hooks.Dir != abs {
+		t.Errorf("Dir = %s, want %s", hooks.Dir, abs)
+	}
+	if len(hooks.Env) == 0 || hooks.Env[len(hooks.Env)-1] != "API_URL=http://localhost:5000" {
+		t.Errorf("Env should end with the service's environment, got %v", hooks.Env)
+	}
+
+	if pythonProjects[0].Hooks != nil {
+		t.Errorf("api project has hooks %+v, want none", pythonProjects[0].Hooks)
+	}
+}
+
+func TestNewDepsExecutor_MaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeDepsFile(t, filepath.Join(root, "package.json"), 2)
+	writeDepsFile(t, filepath.Join(root, "web", "package.json"), 2)
+	writeDepsFile(t, filepath.Join(root, "examples", "demo", "package.json"), 2)
+
+	tests := []struct {
+		maxDepth int
+		want     int
+	}{
+		{maxDepth: 0, want: 3},
+		{maxDepth: 1, want: 2},
+	}
+	for _, tt := range tests {
+		projects, err := newDepsExecutor(&DepsOptions{MaxDepth: tt.maxDepth}).detectNode(root)
+		if err != nil {
+			t.Fatalf("detectNode() error = %v", err)
+		}
+		if len(projects) != tt.want {
+			t.Errorf("max depth %d: found %d Node.js projects, want %d", tt.maxDepth, len(projects), tt.want)
+		}
+	}
+}
+
+func TestNewDepsCommand_InvalidMaxDepth(t *testing.T) {
+	cmd := NewDepsCommand()
+	if err := cmd.Flags().Set("max-depth", "-1"); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.RunE(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--max-depth") {
+		t.Errorf("RunE() error = %v, want an invalid --max-depth error", err)
+	}
+}
+
+func TestValidateAllConfigs(t *testing.T) {
+	defer func() {
+		configPath = ""
+		_ = output.SetFormat("default")
+	}()
+	if err := output.SetFormat("default"); err != nil {
+		t.Fatalf("SetFormat() error = %v", err)
+	}
+
+	if err := validateAllConfigs(false); err != nil {
+		t.Errorf("validateAllConfigs(false) error = %v", err)
+	}
+	if err := validateAllConfigs(true); err != nil {
+		t.Errorf("validateAllConfigs(true) error = %v", err)
+	}
+
+	configPath = filepath.Join(t.TempDir(), "azure.yaml")
+	if err := validateAllConfigs(true); err == nil {
+		t.Error("validateAllConfigs(true) with --config should return an error")
+	}
+	configPath = ""
+
+	if err := output.SetFormat("json"); err != nil {
+		t.Fatalf("SetFormat() error = %v", err)
+	}
+	if err := validateAllConfigs(true); err == nil {
+		t.Error("validateAllConfigs(true) with --output json should return an error")
+	}
+}
+
+func TestRunDepsForAllConfigs(t *testing.T) {
+	root := t.TempDir()
+	t.Chdir(root)
+	wantDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+
+	if err := runDepsForAllConfigs(); err == nil || !strings.Contains(err.Error(), "no azure.yaml found") {
+		t.Errorf("runDepsForAllConfigs() without azure.yaml error = %v, want 'no azure.yaml found'", err)
+	}
+
+	// Each azure.yaml is checked and installed from its own directory
+	writeTestFiles(t, root, map[string]string{
+		"apps/api/azure.yaml": "name: api\n",
+		"apps/web/azure.yaml": "name: web\nreqs:\n  - name: azd-app-test-missing-tool\n    minVersion: \"1.0.0\"\n",
+	})
+	defer cmdOrchestrator.Reset()
+	err = runDepsForAllConfigs()
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") || !strings.Contains(err.Error(), filepath.Join("apps", "web", "azure.yaml")) {
+		t.Errorf("runDepsForAllConfigs() error = %v, want only apps/web/azure.yaml reported as failed", err)
+	}
+	if cwd, _ := os.Getwd(); cwd != wantDir {
+		t.Errorf("runDepsForAllConfigs() left the current directory at %s, want %s", cwd, wantDir)
+	}
+}
+
+func TestExpandServicesWithDeps(t *testing.T) {
+	dir := t.TempDir()
+	content := "name: test\nservices:\n  web:\n    project: ./web\n    uses: [api]\n  api:\n    project: ./api\n"
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	if got, want := expandServicesWithDeps([]string{"web"}, dir), []string{"api", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expandServicesWithDeps(web) = %v, want %v", got, want)
+	}
+	// An unknown name must not leave an empty filter, which would select every project
+	if got, want := expandServicesWithDeps([]string{"typo"}, dir), []string{"typo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expandServicesWithDeps(typo) = %v, want %v", got, want)
+	}
+}
@@ -7,18 +7,33 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/dashboard"
+	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
 	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/registry"
+	"github.com/jongio/azd-app/cli/src/internal/service"
 	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	infoAll bool
+	infoAll         bool
+	infoReapOrphans bool
+	infoGraph       bool
+	infoFormat      string
+	infoEffective   bool
+	infoHealth      bool
+	infoHealthPath  string
+	infoHealthPort  int
+	infoQuery       string
 )
 
 // NewInfoCommand creates the info command.
@@ -32,12 +47,50 @@ func NewInfoCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&infoAll, "all", false, "Show services from all projects on this machine")
+	cmd.Flags().BoolVar(&infoReapOrphans, "reap-orphans", false, reapOrphansFlagUsage)
+	cmd.Flags().BoolVar(&infoGraph, "graph", false, "Print the services and their 'uses' dependencies as a graph instead of service information")
+	cmd.Flags().StringVar(&infoFormat, "format", "", "Print a 'markdown' table of services, or with --graph, the graph as 'dot' (Graphviz, default) or 'json' (adjacency list)")
+	cmd.Flags().BoolVar(&infoEffective, "effective-config", false, "Print azure.yaml as azd app uses it (normalized, with sidecars expanded) instead of service information")
+	cmd.Flags().BoolVar(&infoHealth, "health", false, "Probe the named service's HTTP health endpoint once instead of showing service information")
+	cmd.Flags().StringVar(&infoHealthPath, "path", "", "With --health, probe this path instead of the service's configured health path")
+	cmd.Flags().IntVar(&infoHealthPort, "port", 0, "With --health, probe this port instead of the service's port")
+	cmd.Flags().StringVar(&infoQuery, "query", "", queryFlagUsage)
 
 	return cmd
 }
 
 // runInfo executes the info command.
 func runInfo(cmd *cobra.Command, args []string) error {
+	if err := validateInfoFormat(infoGraph, infoFormat); err != nil {
+		return err
+	}
+	if infoEffective && (infoGraph || infoFormat != "") {
+		return fmt.Errorf("--effective-config cannot be combined with --graph or --format")
+	}
+	if err := validateInfoHealth(infoHealth, infoHealthPath, infoHealthPort, args); err != nil {
+		return err
+	}
+	if infoHealth && (infoGraph || infoFormat != "" || infoEffective) {
+		return fmt.Errorf("--health cannot be combined with --graph, --format or --effective-config")
+	}
+	if err := setOutputQuery(infoQuery); err != nil {
+		return err
+	}
+	defer output.SetQuery(nil)
+	if infoHealth {
+		return showHealthProbe(args[0], healthcheck.EndpointOverride{Path: infoHealthPath, Port: infoHealthPort})
+	}
+	if infoEffective {
+		return showEffectiveConfig()
+	}
+	// Graph mode: print only the graph so it can be piped to Graphviz
+	if infoGraph {
+		return showServiceGraph(infoFormat)
+	}
+	if infoFormat == infoFormatMarkdown {
+		return showServicesMarkdown()
+	}
+
 	output.CommandHeader("info", "Show information about services")
 	// Get current working directory (may be set by --cwd flag)
 	cwd, err := os.Getwd()
@@ -45,6 +98,18 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	// Report (or reap) processes orphaned by a run that was killed, and find
+	// projects on disk that azure.yaml does not declare as services yet
+	var undeclared []UndeclaredProject
+	if azureYamlPath, findErr := detector.FindAzureYaml(cwd); findErr == nil && azureYamlPath != "" {
+		checkOrphanedProcesses(filepath.Dir(azureYamlPath), infoReapOrphans, service.SystemProcessTable)
+
+		undeclared, err = findUndeclaredProjects(azureYamlPath)
+		if err != nil && !output.IsStructured() {
+			output.Warning("Failed to detect undeclared projects: %v", err)
+		}
+	}
+
 	ctx := context.Background()
 
 	// Try to get services from dashboard API first (live state)
@@ -53,11 +118,11 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	if err == nil {
 		// Dashboard is running, get live state from it
 		allServices, err = dashboardClient.GetServices(ctx)
-		if err != nil && !output.IsJSON() {
+		if err != nil && !output.IsStructured() {
 			output.Warning("Failed to get services from dashboard: %v", err)
 			// Fall back to azure.yaml only
 			allServices, err = serviceinfo.GetServiceInfo(cwd)
-			if err != nil && !output.IsJSON() {
+			if err != nil && !output.IsStructured() {
 				output.Warning("Failed to get service info: %v", err)
 			}
 		}
@@ -65,7 +130,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		// Dashboard not running - get service definitions from azure.yaml only
 		// Note: Runtime state (running, ports, PIDs) will not be available
 		allServices, err = serviceinfo.GetServiceInfo(cwd)
-		if err != nil && !output.IsJSON() {
+		if err != nil && !output.IsStructured() {
 			output.Warning("Failed to get service info: %v", err)
 		}
 	}
@@ -73,18 +138,190 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	// Get Azure environment values for environment variable display
 	azureEnv := getAzureEnvironmentValues(ctx)
 
-	// For JSON output
-	if output.IsJSON() {
-		return printInfoJSON(cwd, allServices, azureEnv)
+	// For JSON or YAML output
+	if output.IsStructured() {
+		return printInfoJSON(cwd, allServices, azureEnv, undeclared)
+	}
+
+	if output.IsTable() {
+		return printInfoTable(allServices)
 	}
 
 	// Default output
 	printInfoDefault(cwd, allServices, azureEnv)
+	printUndeclaredProjects(undeclared)
 	return nil
 }
 
-// printInfoJSON outputs service information in JSON format.
-func printInfoJSON(projectDir string, services []*serviceinfo.ServiceInfo, azureEnv map[string]string) error {
+// validateInfoFormat validates the --format flag value: markdown on its own, dot and json
+// only with --graph.
+func validateInfoFormat(graph bool, format string) error {
+	switch format {
+	case "":
+	case "dot", "json":
+		if !graph {
+			return fmt.Errorf("--format %s requires --graph", format)
+		}
+	case infoFormatMarkdown:
+		if graph {
+			return fmt.Errorf("--format %s cannot be combined with --graph (use 'dot' or 'json')", format)
+		}
+	default:
+		return fmt.Errorf("invalid --format value: %s (must be '%s', or 'dot' or 'json' with --graph)", format, infoFormatMarkdown)
+	}
+	return nil
+}
+
+// UndeclaredProject is a project detected on disk that no azure.yaml service points to.
+type UndeclaredProject struct {
+	Language string `json:"language"`
+	Path     string `json:"path"` // Relative to the azure.yaml directory
+}
+
+// findUndeclaredProjects runs project detection from the azure.yaml directory and returns the
+// projects that are not covered by a declared service. A project counts as declared when it is
+// a service's project directory, lives inside one, or contains one (e.g. a workspace root).
+func findUndeclaredProjects(azureYamlPath string) ([]UndeclaredProject, error) {
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	azureYaml, err := service.ParseAzureYaml(azureYamlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceDirs := make([]string, 0, len(azureYaml.Services))
+	for _, svc := range azureYaml.Services {
+		if svc.Project != "" {
+			serviceDirs = append(serviceDirs, filepath.Clean(svc.Project))
+		}
+	}
+
+	nodeProjects, pythonProjects, dotnetProjects, err := detectAllProjects(azureYamlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var undeclared []UndeclaredProject
+	add := func(language, dir string) {
+		dir = filepath.Clean(dir)
+		for _, serviceDir := range serviceDirs {
+			if isSameOrNestedDir(dir, serviceDir) || isSameOrNestedDir(serviceDir, dir) {
+				return
+			}
+		}
+		rel, relErr := filepath.Rel(azureYamlDir, dir)
+		if relErr != nil {
+			rel = dir
+		}
+		undeclared = append(undeclared, UndeclaredProject{Language: language, Path: filepath.ToSlash(rel)})
+	}
+	for _, p := range nodeProjects {
+		add("node", p.Dir)
+	}
+	for _, p := range pythonProjects {
+		add("python", p.Dir)
+	}
+	for _, p := range dotnetProjects {
+		add("dotnet", filepath.Dir(p.Path))
+	}
+
+	sort.Slice(undeclared, func(i, j int) bool {
+		if undeclared[i].Path != undeclared[j].Path {
+			return undeclared[i].Path < undeclared[j].Path
+		}
+		return undeclared[i].Language < undeclared[j].Language
+	})
+	return undeclared, nil
+}
+
+// isSameOrNestedDir returns true if dir is parent or a directory below it.
+func isSameOrNestedDir(dir, parent string) bool {
+	rel, err := filepath.Rel(parent, dir)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// printUndeclaredProjects lists detected projects that azure.yaml does not declare.
+func printUndeclaredProjects(projects []UndeclaredProject) {
+	if len(projects) == 0 {
+		return
+	}
+	output.Warning("Detected but not declared in azure.yaml:")
+	for _, p := range projects {
+		output.Item("%s (%s)", p.Path, p.Language)
+	}
+	output.Hint("Add them to the services section of azure.yaml to run them with azd app")
+	output.Newline()
+}
+
+// printInfoTable outputs one row per service with aligned columns.
+func printInfoTable(services []*serviceinfo.ServiceInfo) error {
+	if len(services) == 0 {
+		output.Info("No services defined in azure.yaml")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(services))
+	for _, svc := range services {
+		port, healthType := "-", "-"
+		if svc.Local != nil {
+			if len(svc.Local.Ports) > 1 {
+				hostPorts := make([]string, len(svc.Local.Ports))
+				for i, p := range svc.Local.Ports {
+					hostPorts[i] = strconv.Itoa(p.HostPort)
+				}
+				port = strings.Join(hostPorts, ",")
+			} else if svc.Local.Port > 0 {
+				port = fmt.Sprintf("%d", svc.Local.Port)
+			}
+			if svc.Local.ServiceType != "" {
+				healthType = svc.Local.ServiceType
+			}
+		}
+		rows = append(rows, []string{
+			svc.Name,
+			valueOrDash(svc.Language),
+			valueOrDash(svc.Framework),
+			port,
+			healthType,
+		})
+	}
+
+	return output.PrintTable([]string{"SERVICE", "LANGUAGE", "FRAMEWORK", "PORT", "HEALTH TYPE"}, rows)
+}
+
+// formatPorts lists port mappings as "10250→8081 (gateway), 10251/udp": the host port, the
+// container port where it differs, the protocol unless TCP and the name if there is one.
+func formatPorts(ports []registry.PortInfo) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		part := strconv.Itoa(p.HostPort)
+		if p.ContainerPort != 0 && p.ContainerPort != p.HostPort {
+			part += fmt.Sprintf("→%d", p.ContainerPort)
+		}
+		if p.Protocol != "" && p.Protocol != "tcp" {
+			part += "/" + p.Protocol
+		}
+		if p.Name != "" {
+			part += fmt.Sprintf(" (%s)", p.Name)
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ", ")
+}
+
+// valueOrDash returns value, or "-" when it is empty, so table columns never look shifted.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// printInfoJSON outputs service information in JSON or YAML format.
+// Undeclared projects are included only when there are any.
+func printInfoJSON(projectDir string, services []*serviceinfo.ServiceInfo, azureEnv map[string]string, undeclared []UndeclaredProject) error {
 	// Use serviceinfo.ServiceInfo directly - same schema as /api/services
 	outputServices := make([]serviceinfo.ServiceInfo, 0, len(services))
 	for _, svc := range services {
@@ -109,10 +346,14 @@ func printInfoJSON(projectDir string, services []*serviceinfo.ServiceInfo, azure
 		outputServices = append(outputServices, *svc) // Dereference pointer
 	}
 
-	return output.PrintJSON(map[string]interface{}{
+	result := map[string]interface{}{
 		"project":  projectDir,
 		"services": outputServices,
-	})
+	}
+	if len(undeclared) > 0 {
+		result["undeclaredProjects"] = undeclared
+	}
+	return output.PrintStructured(result)
 }
 
 // printInfoDefault outputs service information in default format.
@@ -179,7 +420,9 @@ func printInfoDefault(projectDir string, services []*serviceinfo.ServiceInfo, az
 
 		// Runtime info (only if service is running)
 		if svc.Local != nil && svc.Local.Status == "running" {
-			if svc.Local.Port > 0 {
+			if len(svc.Local.Ports) > 1 {
+				output.Label("  Ports", formatPorts(svc.Local.Ports))
+			} else if svc.Local.Port > 0 {
 				output.Label("  Port", fmt.Sprintf("%d", svc.Local.Port))
 			}
 			if svc.Local.PID > 0 {
@@ -359,7 +602,7 @@ func getAzureEnvironmentValues(ctx context.Context) map[string]string {
 	if err != nil {
 		// Log error but don't fail - environment values are optional
 		// This can happen if azd is not installed, not logged in, or no environment is active
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			// Only log in non-JSON mode to avoid polluting JSON output
 			var exitErr *exec.ExitError
 			if errors.As(err, &exitErr) {
@@ -375,7 +618,7 @@ func getAzureEnvironmentValues(ctx context.Context) map[string]string {
 
 	var envVars map[string]string
 	if err := json.Unmarshal(cmdOutput, &envVars); err != nil {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Warning("Failed to parse Azure environment values: %v", err)
 		}
 		return allEnvVars
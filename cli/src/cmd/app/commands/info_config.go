@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+
+	"gopkg.in/yaml.v3"
+)
+
+// showEffectiveConfig prints azure.yaml as azd app uses it, after normalization and sidecar
+// expansion: as YAML by default, or as JSON with --output json.
+func showEffectiveConfig() error {
+	azureYamlPath, err := findAzureYaml()
+	if err != nil {
+		return err
+	}
+
+	config, err := service.LoadEffectiveConfig(filepath.Dir(azureYamlPath))
+	if err != nil {
+		return err
+	}
+
+	// Marshal through the azure.yaml model so keys match the file
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return fmt.Errorf("failed to encode effective config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode effective config: %w", err)
+	}
+
+	if output.IsJSON() {
+		var doc map[string]any
+		if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+			return fmt.Errorf("failed to convert effective config to JSON: %w", err)
+		}
+		return output.PrintJSON(doc)
+	}
+	fmt.Print(buf.String())
+	return nil
+}
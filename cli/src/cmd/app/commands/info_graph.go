@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// showServiceGraph prints the services and resources in azure.yaml and the 'uses' dependencies
+// between them, as Graphviz DOT or as a JSON adjacency list. Dependency cycles are annotated.
+func showServiceGraph(format string) error {
+	azureYamlPath, err := findAzureYaml()
+	if err != nil {
+		return err
+	}
+
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	azureYaml, err := service.ParseAzureYaml(azureYamlDir)
+	if err != nil {
+		return err
+	}
+
+	graph := service.BuildServiceGraph(azureYaml, azureYamlDir)
+	if format == "json" {
+		return output.PrintJSON(graph)
+	}
+	fmt.Print(graph.DOT())
+	return nil
+}
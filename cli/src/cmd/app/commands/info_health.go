@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
+	"github.com/jongio/azd-app/cli/src/internal/output"
+)
+
+// validateInfoHealth validates the --health, --path and --port flags: --path and --port
+// only apply to --health, which probes exactly one service and is its own output mode.
+func validateInfoHealth(health bool, path string, port int, args []string) error {
+	if !health {
+		if path != "" || port != 0 {
+			return fmt.Errorf("--path and --port require --health")
+		}
+		return nil
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("--health requires exactly one service name (e.g. azd app info --health api)")
+	}
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("invalid --port %d (must be between 1 and 65535)", port)
+	}
+	return nil
+}
+
+// showHealthProbe probes one service's health endpoint once (azd app info --health <service>).
+// --path and --port replace the endpoint from azure.yaml and the service registry, so a
+// different endpoint can be tried without editing azure.yaml. Returns an error when the
+// service is unhealthy.
+func showHealthProbe(serviceName string, override healthcheck.EndpointOverride) error {
+	azureYamlPath, err := findAzureYaml()
+	if err != nil {
+		return err
+	}
+
+	monitor, err := healthcheck.NewHealthMonitor(healthcheck.MonitorConfig{
+		ProjectDir:      filepath.Dir(azureYamlPath),
+		DefaultEndpoint: defaultHealthEndpoint,
+		Timeout:         defaultHealthTimeout,
+		LogLevel:        "warn",
+		LogFormat:       "pretty",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create health monitor: %w", err)
+	}
+
+	result, err := monitor.ProbeService(context.Background(), serviceName, override)
+	if err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printHealthProbe(result)
+	}
+
+	if result.Status == healthcheck.HealthStatusUnhealthy {
+		return fmt.Errorf("service '%s' is unhealthy", serviceName)
+	}
+	return nil
+}
+
+// printHealthProbe prints the result of a single health probe.
+func printHealthProbe(result healthcheck.HealthCheckResult) {
+	summary := fmt.Sprintf("%s%s%s %s → %s", output.Cyan, result.ServiceName, output.Reset, result.Status, result.Endpoint)
+	switch result.Status {
+	case healthcheck.HealthStatusHealthy:
+		output.ItemSuccess("%s", summary)
+	case healthcheck.HealthStatusDegraded:
+		output.ItemWarning("%s", summary)
+	default:
+		output.ItemError("%s", summary)
+	}
+
+	if result.StatusCode > 0 {
+		output.Item("  Status code: %d", result.StatusCode)
+	}
+	output.Item("  Response time: %dms", result.ResponseTime.Milliseconds())
+	if result.Error != "" {
+		output.Item("  Error: %s", result.Error)
+	}
+}
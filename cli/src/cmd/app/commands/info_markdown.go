@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// infoFormatMarkdown is the --format value that prints a Markdown table of services.
+const infoFormatMarkdown = "markdown"
+
+// showServicesMarkdown prints the services in azure.yaml as a Markdown table for docs.
+func showServicesMarkdown() error {
+	azureYamlPath, err := findAzureYaml()
+	if err != nil {
+		return err
+	}
+
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	azureYaml, err := service.ParseAzureYaml(azureYamlDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(servicesMarkdown(azureYaml, azureYamlDir))
+	return nil
+}
+
+// servicesMarkdown renders a header with the app name and a table of services sorted by name,
+// so the output diffs cleanly when committed. Language, framework and port are described as
+// in the service graph; project paths are relative to the azure.yaml directory.
+func servicesMarkdown(azureYaml *service.AzureYaml, azureYamlDir string) string {
+	nodes := make(map[string]service.ServiceGraphNode)
+	for _, node := range service.BuildServiceGraph(azureYaml, azureYamlDir).Nodes {
+		if !node.Resource {
+			nodes[node.Name] = node
+		}
+	}
+
+	var b strings.Builder
+	name := azureYaml.Name
+	if name == "" {
+		name = filepath.Base(azureYamlDir)
+	}
+	fmt.Fprintf(&b, "# %s\n\n", name)
+	b.WriteString("Services defined in azure.yaml (generated by `azd app info --format markdown`).\n\n")
+	b.WriteString("| Service | Language | Framework | Port | Health check | Project |\n")
+	b.WriteString("|---------|----------|-----------|------|--------------|---------|\n")
+
+	for _, svcName := range slices.Sorted(maps.Keys(azureYaml.Services)) {
+		svc := azureYaml.Services[svcName]
+		node := nodes[svcName]
+
+		port := ""
+		if node.Port > 0 {
+			port = strconv.Itoa(node.Port)
+		}
+		project := ""
+		if svc.Project != "" {
+			project = svc.Project
+			if rel, err := filepath.Rel(azureYamlDir, svc.Project); err == nil {
+				project = rel
+			}
+			project = "`" + filepath.ToSlash(project) + "`"
+		}
+
+		cells := []string{svcName, node.Language, node.Framework, port, svc.GetHealthCheckType(), project}
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(valueOrDash(cell), "|", `\|`)
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+	}
+	return b.String()
+}
@@ -1,12 +1,21 @@
 package commands
 
 import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
 	"github.com/jongio/azd-app/cli/src/internal/registry"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
 )
 
 func TestFormatStatus(t *testing.T) {
@@ -606,3 +615,288 @@ func TestFormatInfoDurationEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintInfoTable(t *testing.T) {
+	services := []*serviceinfo.ServiceInfo{
+		{
+			Name:      "api",
+			Language:  "python",
+			Framework: "fastapi",
+			Local:     &serviceinfo.LocalServiceInfo{Port: 8000, ServiceType: "http"},
+		},
+		{
+			Name:     "cosmos",
+			Language: "container",
+			Local: &serviceinfo.LocalServiceInfo{Port: 8081, ServiceType: "container", Ports: []registry.PortInfo{
+				{HostPort: 8081, ContainerPort: 8081},
+				{HostPort: 10251, ContainerPort: 10251},
+			}},
+		},
+		{
+			Name:     "worker",
+			Language: "go",
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	printErr := printInfoTable(services)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if printErr != nil {
+		t.Fatalf("printInfoTable() error = %v", printErr)
+	}
+
+	// stdout is a pipe, so no colors are written
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := []string{
+		"SERVICE  LANGUAGE   FRAMEWORK  PORT        HEALTH TYPE",
+		"api      python     fastapi    8000        http",
+		"cosmos   container  -          8081,10251  container",
+		"worker   go         -          -           -",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("printInfoTable() lines = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestFormatPorts(t *testing.T) {
+	ports := []registry.PortInfo{
+		{Name: "gateway", HostPort: 10250, ContainerPort: 8081, Protocol: "tcp"},
+		{HostPort: 10251, ContainerPort: 10251, Protocol: "udp"},
+		{HostPort: 10252},
+	}
+	want := "10250→8081 (gateway), 10251/udp, 10252"
+	if got := formatPorts(ports); got != want {
+		t.Errorf("formatPorts() = %q, want %q", got, want)
+	}
+}
+
+func TestFindUndeclaredProjects(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"azure.yaml": "name: demo\nservices:\n  api:\n    project: ./api\n    language: js\n    host: containerapp\n",
+		// Workspace root containing the api service
+		"package.json":     `{"name": "demo", "private": true}`,
+		"api/package.json": `{"name": "api"}`,
+		// Nested inside the declared api service
+		"api/tools/package.json": `{"name": "api-tools"}`,
+		// Not referenced by any service
+		"worker/requirements.txt": "fastapi\n",
+		"admin/package.json":      `{"name": "admin"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	undeclared, err := findUndeclaredProjects(filepath.Join(root, "azure.yaml"))
+	if err != nil {
+		t.Fatalf("findUndeclaredProjects() error = %v", err)
+	}
+
+	want := []UndeclaredProject{
+		{Language: "node", Path: "admin"},
+		{Language: "python", Path: "worker"},
+	}
+	if len(undeclared) != len(want) {
+		t.Fatalf("findUndeclaredProjects() = %+v, want %+v", undeclared, want)
+	}
+	for i := range want {
+		if undeclared[i] != want[i] {
+			t.Errorf("undeclared[%d] = %+v, want %+v", i, undeclared[i], want[i])
+		}
+	}
+
+	// Structured output reports them alongside the declared services
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	printErr := printInfoJSON(root, nil, nil, undeclared)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if printErr != nil {
+		t.Fatalf("printInfoJSON() error = %v", printErr)
+	}
+	for _, s := range []string{`"undeclaredProjects"`, `"path": "worker"`, `"language": "python"`} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("printInfoJSON() output missing %s:\n%s", s, buf.String())
+		}
+	}
+}
+
+func TestIsSameOrNestedDir(t *testing.T) {
+	parent := filepath.Join("repo", "api")
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{filepath.Join("repo", "api"), true},
+		{filepath.Join("repo", "api", "tools"), true},
+		{filepath.Join("repo", "api-admin"), false},
+		{filepath.Join("repo", "web"), false},
+		{"repo", false},
+	}
+	for _, tt := range tests {
+		if got := isSameOrNestedDir(tt.dir, parent); got != tt.want {
+			t.Errorf("isSameOrNestedDir(%q, %q) = %v, want %v", tt.dir, parent, got, tt.want)
+		}
+	}
+}
+
+func TestValidateInfoFormat(t *testing.T) {
+	tests := []struct {
+		graph   bool
+		format  string
+		wantErr string
+	}{
+		{graph: false, format: ""},
+		{graph: true, format: ""},
+		{graph: true, format: "dot"},
+		{graph: true, format: "json"},
+		{graph: false, format: "markdown"},
+		{graph: false, format: "json", wantErr: "--format json requires --graph"},
+		{graph: true, format: "markdown", wantErr: "cannot be combined with --graph"},
+		{graph: true, format: "svg", wantErr: "invalid --format value"},
+	}
+
+	for _, tt := range tests {
+		err := validateInfoFormat(tt.graph, tt.format)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateInfoFormat(%v, %q) error = %v", tt.graph, tt.format, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateInfoFormat(%v, %q) error = %v, want %q", tt.graph, tt.format, err, tt.wantErr)
+		}
+	}
+}
+
+func TestServicesMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	azureYaml := &service.AzureYaml{
+		Name: "shop",
+		Services: map[string]service.Service{
+			"web":    {Language: "ts", Project: filepath.Join(dir, "src", "web"), Ports: []string{"3000"}},
+			"api":    {Language: "go", Project: filepath.Join(dir, "api"), Ports: []string{"8080"}, Healthcheck: &service.HealthcheckConfig{Type: "tcp"}},
+			"cache":  {Image: "redis:7", Ports: []string{"6379:6379"}},
+			"worker": {Language: "python", Project: filepath.Join(dir, "worker")},
+		},
+	}
+
+	want := "# shop\n\n" +
+		"Services defined in azure.yaml (generated by `azd app info --format markdown`).\n\n" +
+		"| Service | Language | Framework | Port | Health check | Project |\n" +
+		"|---------|----------|-----------|------|--------------|---------|\n" +
+		"| api | Go | - | 8080 | tcp | `api` |\n" +
+		"| cache | container | redis:7 | 6379 | http | - |\n" +
+		"| web | TypeScript | Node.js | 3000 | http | `src/web` |\n" +
+		"| worker | Python | - | - | process | `worker` |\n"
+
+	for i := 0; i < 3; i++ {
+		if got := servicesMarkdown(azureYaml, dir); got != want {
+			t.Fatalf("servicesMarkdown() =\n%s\nwant\n%s", got, want)
+		}
+	}
+}
+
+func TestValidateInfoHealth(t *testing.T) {
+	tests := []struct {
+		health  bool
+		path    string
+		port    int
+		args    []string
+		wantErr string
+	}{
+		{health: false},
+		{health: true, args: []string{"api"}},
+		{health: true, path: "/ready", port: 8080, args: []string{"api"}},
+		{health: false, path: "/ready", wantErr: "--path and --port require --health"},
+		{health: false, port: 8080, wantErr: "--path and --port require --health"},
+		{health: true, wantErr: "exactly one service"},
+		{health: true, args: []string{"api", "web"}, wantErr: "exactly one service"},
+		{health: true, port: 70000, args: []string{"api"}, wantErr: "invalid --port"},
+	}
+
+	for _, tt := range tests {
+		err := validateInfoHealth(tt.health, tt.path, tt.port, tt.args)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateInfoHealth(%v, %q, %d, %v) error = %v", tt.health, tt.path, tt.port, tt.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateInfoHealth(%v, %q, %d, %v) error = %v, want %q", tt.health, tt.path, tt.port, tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestShowHealthProbe_UsesOverride(t *testing.T) {
+	var probed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probed = append(probed, r.URL.Path)
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	// azure.yaml declares a different port and path, which the override replaces
+	tmpDir := t.TempDir()
+	azureYaml := "name: test\nservices:\n  api:\n    language: python\n    project: ./api\n    ports:\n      - \"1\"\n    healthcheck:\n      path: /health\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := showHealthProbe("api", healthcheck.EndpointOverride{Path: "/ready", Port: port}); err != nil {
+		t.Fatalf("showHealthProbe() error = %v", err)
+	}
+	if len(probed) != 1 || probed[0] != "/ready" {
+		t.Errorf("probed paths = %v, want [/ready]", probed)
+	}
+
+	err = showHealthProbe("api", healthcheck.EndpointOverride{Path: "/live", Port: port})
+	if err == nil || !strings.Contains(err.Error(), "unhealthy") {
+		t.Errorf("showHealthProbe() error = %v, want unhealthy", err)
+	}
+	if len(probed) != 2 || probed[1] != "/live" {
+		t.Errorf("probed paths = %v, want [/ready /live]", probed)
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// ServiceInventoryItem describes a service declared in azure.yaml.
+type ServiceInventoryItem struct {
+	Name     string `json:"name"`
+	Language string `json:"language,omitempty"`
+	Project  string `json:"project,omitempty"`
+	Image    string `json:"image,omitempty"`
+	Host     string `json:"host,omitempty"`
+}
+
+// ServiceInventory is the list-services result.
+type ServiceInventory struct {
+	Services []ServiceInventoryItem `json:"services"`
+}
+
+// NewListServicesCommand creates the list-services command.
+func NewListServicesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-services",
+		Short: "List services declared in azure.yaml",
+		Long: `Lists the services declared in azure.yaml with their language and project path.
+
+Only azure.yaml is parsed: no detection runs and nothing is started, so this is fast
+enough for tooling that just needs the service inventory. Use --output json for machine-readable output.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			inventory, err := listServices(cwd)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				return output.PrintJSON(inventory)
+			}
+
+			printServiceInventory(inventory)
+			return nil
+		},
+	}
+}
+
+// listServices parses azure.yaml and returns the declared services sorted by name.
+func listServices(workingDir string) (*ServiceInventory, error) {
+	azureYaml, err := service.ParseAzureYaml(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ServiceInventoryItem, 0, len(azureYaml.Services))
+	for name, svc := range azureYaml.Services {
+		items = append(items, ServiceInventoryItem{
+			Name:     name,
+			Language: svc.Language,
+			Project:  svc.Project,
+			Image:    svc.Image,
+			Host:     svc.Host,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	return &ServiceInventory{Services: items}, nil
+}
+
+// printServiceInventory prints the inventory in default format.
+func printServiceInventory(inventory *ServiceInventory) {
+	if len(inventory.Services) == 0 {
+		output.Info("No services defined in azure.yaml")
+		return
+	}
+
+	for _, svc := range inventory.Services {
+		output.Bullet("%s%s%s", output.Bold, svc.Name, output.Reset)
+		if svc.Language != "" {
+			output.Label("Language", svc.Language)
+		}
+		if svc.Project != "" {
+			output.Label("Project", svc.Project)
+		}
+		if svc.Image != "" {
+			output.Label("Image", svc.Image)
+		}
+	}
+}
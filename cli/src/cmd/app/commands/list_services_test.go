@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+)
+
+func TestListServices(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYaml := `name: inventory-test
+services:
+  web:
+    language: ts
+    project: ./src/web
+    host: containerapp
+  api:
+    language: python
+    project: ./src/api
+    host: containerapp
+  cache:
+    image: redis:7
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	inventory, err := listServices(tmpDir)
+	if err != nil {
+		t.Fatalf("listServices() error = %v", err)
+	}
+
+	want := []ServiceInventoryItem{
+		{Name: "api", Language: "python", Project: filepath.Join(tmpDir, "src", "api"), Host: "containerapp"},
+		{Name: "cache", Image: "redis:7"},
+		{Name: "web", Language: "ts", Project: filepath.Join(tmpDir, "src", "web"), Host: "containerapp"},
+	}
+	if !reflect.DeepEqual(inventory.Services, want) {
+		t.Errorf("listServices() = %+v, want %+v", inventory.Services, want)
+	}
+}
+
+func TestListServicesJSONOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYaml := `name: inventory-test
+services:
+  api:
+    language: python
+    project: ./api
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	_ = output.SetFormat("json")
+	defer func() { _ = output.SetFormat("default") }()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	runErr := NewListServicesCommand().RunE(nil, nil)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if runErr != nil {
+		t.Fatalf("list-services error = %v", runErr)
+	}
+
+	var inventory ServiceInventory
+	if err := json.Unmarshal(buf.Bytes(), &inventory); err != nil {
+		t.Fatalf("output should be valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(inventory.Services) != 1 || inventory.Services[0].Name != "api" || inventory.Services[0].Language != "python" {
+		t.Errorf("inventory = %+v, want single python api service", inventory.Services)
+	}
+}
+
+func TestListServicesMissingAzureYaml(t *testing.T) {
+	if _, err := listServices(t.TempDir()); err == nil {
+		t.Error("listServices() error = nil, want error when azure.yaml is missing")
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
+	"github.com/jongio/azd-app/cli/src/internal/constants"
 	"github.com/jongio/azd-app/cli/src/internal/dashboard"
 	"github.com/jongio/azd-app/cli/src/internal/output"
 	"github.com/jongio/azd-app/cli/src/internal/security"
@@ -36,12 +44,15 @@ const (
 	// Capped to prevent excessive memory usage (10K lines ≈ 1-2MB).
 	maxTailLines = 10000
 
-	// maxLogLineSize is the maximum size of a single log line (1MB).
-	// This handles extremely long log lines from stack traces or JSON dumps.
-	maxLogLineSize = 1 * 1024 * 1024
+	// tailAllLines is the --tail value that means --tail-all.
+	tailAllLines = -1
 
-	// scannerInitialBufferSize is the initial buffer for the log file scanner.
-	// 64KB handles most log lines without reallocation.
+	// maxLogLineSize is the default maximum size of a single log line (1MB), set with
+	// --max-line-size. Longer lines, such as JSON dumps, are truncated.
+	maxLogLineSize = service.MaxLogLineSize
+
+	// scannerInitialBufferSize is the read buffer for log files.
+	// 64KB handles most log lines in a single read.
 	scannerInitialBufferSize = 64 * 1024
 
 	// dashboardOperationTimeout is the timeout for dashboard operations.
@@ -53,6 +64,43 @@ const (
 	filterCapacityEstimate = 4
 )
 
+// Output formats for the logs command. json and ndjson both write one object per line;
+// ndjson also flushes buffered writers after every line.
+const (
+	logsFormatText   = "text"
+	logsFormatJSON   = "json"
+	logsFormatNDJSON = "ndjson"
+)
+
+// Reconnect backoff for following logs when the dashboard connection drops
+// (e.g., while the dashboard restarts). Variables so tests can shorten them.
+var (
+	logsReconnectInitialDelay = 500 * time.Millisecond
+	logsReconnectMaxDelay     = 10 * time.Second
+)
+
+// Connecting to the dashboard is retried briefly before concluding nothing is running, as
+// it may still be starting when 'azd app logs' runs right after 'azd app run' (3 attempts
+// over about 2s). Variables so tests can shorten them.
+var (
+	logsConnectAttempts   = 3
+	logsConnectRetryDelay = 700 * time.Millisecond
+)
+
+// logsFollowNewInterval is how often --follow-new asks the dashboard for services that
+// started after following began. A variable so tests can shorten it.
+var logsFollowNewInterval = 2 * time.Second
+
+// errNewServicesStarted ends a dashboard log stream so it is reopened with the services
+// --follow-new found.
+var errNewServicesStarted = errors.New("new services started")
+
+// maxConcurrentLogReads bounds how many services' logs are read at once without --follow.
+// Reading is mostly file I/O, so a few readers keep the disk busy without opening a file
+// per service on projects with many services. A variable so benchmarks can compare it
+// with reading one service at a time.
+var maxConcurrentLogReads = 8
+
 // DashboardClient defines the interface for dashboard operations needed by logs.
 // This interface enables testing by allowing mock implementations.
 type DashboardClient interface {
@@ -71,12 +119,14 @@ type LogManagerInterface interface {
 // LogEntryWithContext represents a log entry with surrounding context lines.
 // Used when --context flag is specified to include lines before/after matches.
 type LogEntryWithContext struct {
-	Service   string      `json:"service"`
-	Message   string      `json:"message"`
-	Level     string      `json:"level"`
-	Timestamp time.Time   `json:"timestamp"`
-	IsStderr  bool        `json:"isStderr,omitempty"`
-	Context   *LogContext `json:"context,omitempty"`
+	Service   string         `json:"service"`
+	Message   string         `json:"message"`
+	Level     string         `json:"level"`
+	Timestamp time.Time      `json:"timestamp"`
+	IsStderr  bool           `json:"isStderr,omitempty"`
+	Fields    map[string]any `json:"fields,omitempty"` // Parsed fields for structured (JSON) log lines
+	Truncated bool           `json:"truncated,omitempty"`
+	Context   *LogContext    `json:"context,omitempty"`
 }
 
 // LogContext contains log lines before and after a matching entry.
@@ -89,17 +139,32 @@ type LogContext struct {
 // Using a struct avoids global state pollution between command invocations.
 type logsOptions struct {
 	follow       bool
+	followNew    bool // With --follow, also stream services that start later
 	service      string
 	tail         int
+	tailSet      bool // Whether --tail was given explicitly
+	tailAll      bool // Show every buffered line (--tail-all or --tail -1), uncapped with --follow
 	since        string
+	until        string // Only show logs up to this duration ago or RFC3339 timestamp
 	timestamps   bool
+	timeFormat   string // Timestamp format in text output: rfc3339, short, unix or a Go layout
 	noColor      bool
 	level        string
 	format       string
 	file         string
+	include      string // Regexes of which lines must match at least one (comma-separated)
 	exclude      string
 	noBuiltins   bool
-	contextLines int // Number of context lines before/after matching entries (0-10)
+	contextLines int      // Number of context lines before/after matching entries (0-10)
+	fields       []string // Structured field filters in key=value form (JSON logs only)
+	grep         string   // Regex that lines must match to be shown
+	highlight    []string // Regexes whose matches are colorized in text output (repeatable)
+	passthrough  bool     // Emit structured (JSON) log lines as-is with a "service" field in JSON output
+	noPrefix     bool     // Omit the [service] prefix in text output
+	noPrefixSet  bool     // Whether --no-prefix was given explicitly (otherwise it follows the service selection)
+	redact       []string // Additional regexes whose matches are masked (repeatable)
+	noRedact     bool     // Disable secret redaction
+	maxLineSize  string   // Size above which lines are truncated, e.g. 64KB (default 1MB)
 }
 
 // logsExecutor encapsulates the logs command execution with injectable dependencies.
@@ -114,6 +179,38 @@ type logsExecutor struct {
 
 	// Configuration options (stored directly to avoid duplication)
 	opts *logsOptions
+
+	// fieldFilters holds the parsed --field filters (key -> expected value)
+	fieldFilters map[string]string
+
+	// includePatterns holds the compiled --include patterns (empty when not set)
+	includePatterns []*regexp.Regexp
+
+	// grepPattern holds the compiled --grep pattern (nil when not set)
+	grepPattern *regexp.Regexp
+
+	// highlightPatterns holds the compiled --highlight patterns
+	highlightPatterns []*regexp.Regexp
+
+	// hidePrefix is the resolved --no-prefix setting
+	hidePrefix bool
+
+	// timeLayout is the resolved --time-format layout (see resolveTimeFormat)
+	timeLayout string
+
+	// redactor masks secrets in entries before they are filtered or displayed (nil with --no-redact)
+	redactor *service.LogRedactor
+
+	// maxLineSize is the resolved --max-line-size in bytes (0 for maxLogLineSize)
+	maxLineSize int
+
+	// followContext collects before-context for --context while following (nil without --context)
+	followContext *followContext
+
+	// startedServices holds the services already started, so --follow-new can tell which
+	// ones start later (guarded by startedServicesMu)
+	startedServices   map[string]bool
+	startedServicesMu sync.Mutex
 }
 
 // newLogsExecutor creates a logsExecutor with production dependencies.
@@ -167,6 +264,12 @@ Examples:
   # Follow logs in real-time (like tail -f)
   azd app logs -f
 
+  # Replay every buffered line, then follow new ones
+  azd app logs -f --tail-all
+
+  # Keep following as dependent services start after their dependencies are healthy
+  azd app logs -f --follow-new
+
   # View logs from a specific service
   azd app logs api
 
@@ -179,6 +282,12 @@ Examples:
   # View logs from the last 5 minutes
   azd app logs --since 5m
 
+  # View logs from between 2 hours and 1 hour ago
+  azd app logs --since 2h --until 1h
+
+  # Show RFC 3339 timestamps for machine parsing
+  azd app logs --time-format rfc3339
+
   # Export logs to a file
   azd app logs --file logs.txt
 
@@ -186,25 +295,75 @@ Examples:
   azd app logs --format json
 
   # Output errors as JSON with context
-  azd app logs --level error --context 3 --format json`,
+  azd app logs --level error --context 3 --format json
+
+  # Stream newline-delimited JSON into jq as entries arrive
+  azd app logs -f --format ndjson | jq -c 'select(.level == 2)'
+
+  # Output services' own JSON log objects, tagged with the service name
+  azd app logs --format json --json-logs-passthrough
+
+  # Filter structured (JSON) logs by field value
+  azd app logs --field userId=42 --field route=/api/orders
+
+  # Keep the service prefix when viewing a single service
+  azd app logs api --no-prefix=false
+
+  # Show api lines except health checks (allowlist, then denylist)
+  azd app logs --include 'api\.' --exclude health
+
+  # Only show lines matching a pattern and highlight request IDs
+  azd app logs --grep "orders" --highlight "req-[0-9a-f]+"
+
+  # Also mask values matching a custom pattern
+  azd app logs --redact "ghp_[A-Za-z0-9]+"
+
+  # Summarize log volume and errors per service
+  azd app logs stats --since 1h
+
+Secrets (Azure connection string keys, AZURE_* keys and secrets, bearer tokens,
+password= values and AWS keys) are replaced with *** before any output; use
+--no-redact to show them when debugging locally.
+
+Filters apply in order: --include keeps lines matching any of its patterns,
+--exclude removes lines, --grep keeps matching lines, then --highlight colorizes
+matches in what remains (text output only). --include and --exclude patterns are
+case-insensitive.`,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.noPrefixSet = cmd.Flags().Changed("no-prefix")
+			opts.tailSet = cmd.Flags().Changed("tail")
 			return runLogsWithOptions(opts, args)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Follow log output (tail -f behavior)")
+	cmd.Flags().BoolVar(&opts.followNew, "follow-new", false, "With --follow, also stream services that start after following begins (respects --service)")
 	cmd.Flags().StringVarP(&opts.service, "service", "s", "", "Filter by service name(s) (comma-separated)")
-	cmd.Flags().IntVarP(&opts.tail, "tail", "n", defaultTailLines, "Number of lines to show from the end")
-	cmd.Flags().StringVar(&opts.since, "since", "", "Show logs since duration (e.g., 5m, 1h)")
+	cmd.Flags().IntVarP(&opts.tail, "tail", "n", defaultTailLines, "Number of lines to show from the end (-1 for all, like --tail-all)")
+	cmd.Flags().BoolVar(&opts.tailAll, "tail-all", false, fmt.Sprintf("Show all buffered lines: with --follow, replay everything before streaming; otherwise up to %d lines", maxTailLines))
+	cmd.Flags().StringVar(&opts.since, "since", "", "Show logs since duration (e.g., 5m, 1h) or RFC3339 timestamp")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Show logs up to duration ago (e.g., 30m) or RFC3339 timestamp, to bracket a window with --since")
 	cmd.Flags().BoolVar(&opts.timestamps, "timestamps", true, "Show timestamps with each log entry")
+	cmd.Flags().StringVar(&opts.timeFormat, "time-format", "", "Timestamp format in text output: rfc3339, short, unix or a Go time layout (default 15:04:05.000)")
 	cmd.Flags().BoolVar(&opts.noColor, "no-color", false, "Disable colored output")
 	cmd.Flags().StringVar(&opts.level, "level", "all", "Filter by log level (info, warn, error, debug, all)")
-	cmd.Flags().StringVar(&opts.format, "format", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&opts.format, "format", logsFormatText, "Output format (text, json, ndjson)")
 	cmd.Flags().StringVar(&opts.file, "file", "", "Write logs to file instead of stdout")
+	cmd.Flags().StringVar(&opts.include, "include", "", "Only show lines matching at least one of these regex patterns (comma-separated, applied before --exclude)")
 	cmd.Flags().StringVarP(&opts.exclude, "exclude", "e", "", "Regex patterns to exclude (comma-separated)")
 	cmd.Flags().BoolVar(&opts.noBuiltins, "no-builtins", false, "Disable built-in filter patterns")
 	cmd.Flags().IntVar(&opts.contextLines, "context", 0, "Number of context lines before/after matching entries (0-10, requires --level)")
+	cmd.Flags().StringArrayVar(&opts.fields, "field", nil, "Filter structured (JSON) logs by field value, as key=value (repeatable)")
+	cmd.Flags().StringVar(&opts.grep, "grep", "", "Only show lines matching this regex (applied after --exclude)")
+	cmd.Flags().StringArrayVar(&opts.highlight, "highlight", nil, "Highlight regex matches in text output without filtering (repeatable)")
+	cmd.Flags().BoolVar(&opts.noPrefix, "no-prefix", false, "Omit the service-name prefix in text output (default when a single service is selected)")
+	cmd.Flags().BoolVar(&opts.passthrough, "json-logs-passthrough", false, "With --format json or ndjson, output structured (JSON) log lines as the original object plus a \"service\" field")
+	cmd.Flags().StringArrayVar(&opts.redact, "redact", nil, "Mask matches of this regex with *** in addition to the built-in secret patterns (repeatable)")
+	cmd.Flags().BoolVar(&opts.noRedact, "no-redact", false, "Show secrets in log output instead of masking them (for local debugging)")
+	cmd.Flags().StringVar(&opts.maxLineSize, "max-line-size", "1MB", "Truncate log lines longer than this size (e.g. 64KB), marking them with …[truncated N bytes]")
+
+	cmd.AddCommand(newLogsStatsCmd())
 
 	return cmd
 }
@@ -223,12 +382,76 @@ func runLogsWithOptions(opts *logsOptions, args []string) error {
 	return executor.execute(context.Background(), args)
 }
 
+// collectedLogs holds the state resolved while collecting historical logs.
+// Shared by the logs display, follow mode and the stats subcommand so filters behave identically.
+type collectedLogs struct {
+	cwd             string
+	serviceFilter   []string
+	logManager      LogManagerInterface
+	dashboardClient DashboardClient
+	levelFilter     service.LogLevel
+	logFilter       *service.LogFilter
+	logs            []service.LogEntry // Sorted, with --exclude, --grep and --field applied
+}
+
 // execute runs the logs command with the configured dependencies and options.
 func (e *logsExecutor) execute(ctx context.Context, args []string) error {
+	collected, err := e.collect(ctx, args)
+	if err != nil || collected == nil {
+		return err
+	}
+	logs := collected.logs
+	levelFilter := collected.levelFilter
+
+	// Setup output writer
+	outputWriter, cleanup, err := e.setupOutputWriter()
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	// Handle context mode vs regular mode
+	if e.opts.contextLines > 0 && levelFilter != LogLevelAll {
+		// Context mode: extract matching entries with surrounding context
+		logsWithContext := e.extractLogsWithContext(logs, levelFilter, e.opts.contextLines)
+
+		// Apply tail limit to the number of matching entries
+		if e.opts.tail > 0 && len(logsWithContext) > e.opts.tail {
+			logsWithContext = logsWithContext[len(logsWithContext)-e.opts.tail:]
+		}
+
+		// Display logs with context
+		e.displayLogsWithContext(logsWithContext, outputWriter)
+	} else {
+		// Regular mode: filter by level and display
+		logs = filterLogsByLevel(logs, levelFilter)
+
+		// Apply final tail limit after all filtering (for multi-service view)
+		if e.opts.tail > 0 && len(logs) > e.opts.tail {
+			logs = logs[len(logs)-e.opts.tail:]
+		}
+
+		// Display initial logs
+		e.displayLogs(logs, outputWriter)
+	}
+
+	// Follow mode - subscribe to live logs
+	if e.opts.follow {
+		return e.followLogs(ctx, collected.cwd, collected.logManager, collected.dashboardClient, collected.serviceFilter, levelFilter, collected.logFilter, outputWriter)
+	}
+
+	return nil
+}
+
+// collect resolves the running services, validates filters and gathers historical logs.
+// Returns nil without an error when no services are running (a message has been shown).
+func (e *logsExecutor) collect(ctx context.Context, args []string) (*collectedLogs, error) {
 	// Get current working directory
 	cwd, err := e.getWorkingDir()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Determine service filter
@@ -237,37 +460,22 @@ func (e *logsExecutor) execute(ctx context.Context, args []string) error {
 	// Get log manager for in-memory buffers (may be empty if called from subprocess)
 	logManager := e.logManagerFactory(cwd)
 
-	// Add timeout for dashboard operations to prevent hanging
-	dashCtx, dashCancel := context.WithTimeout(ctx, dashboardOperationTimeout)
-	defer dashCancel()
-
 	// Get running services via dashboard client (works across processes)
-	dashboardClient, err := e.dashboardClientFactory(dashCtx, cwd)
+	dashboardClient, err := e.connectDashboardWithRetry(ctx, cwd)
 	if err != nil {
-		// Debug: log actual error for troubleshooting
-		if os.Getenv("AZD_APP_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Dashboard client creation failed: %v\n", err)
-		}
 		output.Info("No services are currently running")
 		output.Item("Run 'azd app run' to start services")
-		return nil
+		return nil, nil
 	}
 
-	// Check if dashboard is actually responding
-	if pingErr := dashboardClient.Ping(dashCtx); pingErr != nil {
-		// Debug: log actual error for troubleshooting
-		if os.Getenv("AZD_APP_DEBUG") == "true" {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Dashboard ping failed: %v\n", pingErr)
-		}
-		output.Info("No services are currently running")
-		output.Item("Run 'azd app run' to start services")
-		return nil
-	}
+	// Add timeout for dashboard operations to prevent hanging
+	dashCtx, dashCancel := context.WithTimeout(ctx, dashboardOperationTimeout)
+	defer dashCancel()
 
 	// Get service list from dashboard
 	services, err := dashboardClient.GetServices(dashCtx)
 	if err != nil {
-		return fmt.Errorf("failed to get services from dashboard: %w", err)
+		return nil, fmt.Errorf("failed to get services from dashboard: %w", err)
 	}
 
 	// Build list of service names
@@ -276,40 +484,71 @@ func (e *logsExecutor) execute(ctx context.Context, args []string) error {
 		serviceNames = append(serviceNames, svc.Name)
 	}
 
+	e.startedServices = startedServiceNames(services)
+
 	// Check if any services exist
 	if len(serviceNames) == 0 {
 		output.Info("No services are currently running")
 		output.Item("Run 'azd app run' to start services")
-		return nil
+		return nil, nil
 	}
 
 	// Validate service filter
 	if valErr := e.validateServiceFilter(serviceFilter, serviceNames); valErr != nil {
-		return valErr
+		return nil, valErr
 	}
 
+	e.hidePrefix = resolveNoPrefix(e.opts, serviceFilter)
+
 	// Parse log level filter
 	levelFilter := parseLogLevel(e.opts.level)
 
 	// Build log filter from flags and azure.yaml
 	logFilter, err := e.buildLogFilterInternal(cwd)
 	if err != nil {
-		return fmt.Errorf("failed to build log filter: %w", err)
+		return nil, fmt.Errorf("failed to build log filter: %w", err)
 	}
 
-	// Parse since duration (returns error instead of silently failing)
-	sinceTime, err := e.parseSinceTime()
+	// Parse structured field filters
+	e.fieldFilters, err = parseFieldFilters(e.opts.fields)
 	if err != nil {
-		return fmt.Errorf("invalid since duration: %w", err)
+		return nil, err
 	}
 
-	// Setup output writer
-	outputWriter, cleanup, err := e.setupOutputWriter()
+	e.timeLayout, err = resolveTimeFormat(e.opts.timeFormat)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if cleanup != nil {
-		defer cleanup()
+
+	// Compile include, grep and highlight patterns
+	e.includePatterns, err = compileIncludePatterns(e.opts.include)
+	if err != nil {
+		return nil, err
+	}
+	e.grepPattern, e.highlightPatterns, err = compileGrepAndHighlight(e.opts.grep, e.opts.highlight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the secret redactor
+	e.redactor, err = buildLogRedactor(e.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	e.maxLineSize, err = parseMaxLineSize(e.opts.maxLineSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse since duration (returns error instead of silently failing)
+	sinceTime, err := e.parseSinceTime()
+	if err != nil {
+		return nil, fmt.Errorf("invalid since duration: %w", err)
+	}
+	untilTime, err := e.parseUntilTime()
+	if err != nil {
+		return nil, fmt.Errorf("invalid until time: %w", err)
 	}
 
 	// Determine which services to get logs for
@@ -318,58 +557,35 @@ func (e *logsExecutor) execute(ctx context.Context, args []string) error {
 		targetServices = serviceNames
 	}
 
-	// Get logs - try in-memory buffers first, fall back to log files
+	// Get logs in timestamp order - try in-memory buffers first, fall back to log files
 	// Pass context to allow cancellation during log collection
-	logs, err := e.collectLogs(ctx, cwd, targetServices, logManager, sinceTime)
+	logs, err := e.collectLogs(ctx, cwd, targetServices, logManager, sinceTime, untilTime)
 	if err != nil {
-		return fmt.Errorf("failed to collect logs: %w", err)
+		return nil, fmt.Errorf("failed to collect logs: %w", err)
 	}
 
-	// Sort logs by timestamp
-	service.SortLogEntries(logs)
+	// Mask secrets before filtering so no output path can reveal them, then truncate long
+	// lines (after masking, so a cut can't leave part of a secret unmatched)
+	logs = e.redactor.RedactEntries(logs)
+	for i := range logs {
+		logs[i] = truncateLogEntry(logs[i], e.lineSizeLimit())
+	}
 
 	// Filter by pattern first (applies to all logs regardless of context mode)
+	logs = filterLogsByInclude(logs, e.includePatterns)
 	logs = service.FilterLogEntries(logs, logFilter)
-
-	// Handle context mode vs regular mode
-	if e.opts.contextLines > 0 && levelFilter != LogLevelAll {
-		// Context mode: extract matching entries with surrounding context
-		logsWithContext := e.extractLogsWithContext(logs, levelFilter, e.opts.contextLines)
-
-		// Apply tail limit to the number of matching entries
-		if e.opts.tail > 0 && len(logsWithContext) > e.opts.tail {
-			logsWithContext = logsWithContext[len(logsWithContext)-e.opts.tail:]
-		}
-
-		// Display logs with context
-		if e.opts.format == "json" {
-			displayLogsWithContextJSON(logsWithContext, outputWriter)
-		} else {
-			displayLogsWithContextText(logsWithContext, outputWriter, e.opts.timestamps, e.opts.noColor)
-		}
-	} else {
-		// Regular mode: filter by level and display
-		logs = filterLogsByLevel(logs, levelFilter)
-
-		// Apply final tail limit after all filtering (for multi-service view)
-		if e.opts.tail > 0 && len(logs) > e.opts.tail {
-			logs = logs[len(logs)-e.opts.tail:]
-		}
-
-		// Display initial logs
-		if e.opts.format == "json" {
-			displayLogsJSON(logs, outputWriter)
-		} else {
-			displayLogsText(logs, outputWriter, e.opts.timestamps, e.opts.noColor)
-		}
-	}
-
-	// Follow mode - subscribe to live logs
-	if e.opts.follow {
-		return e.followLogs(ctx, cwd, logManager, dashboardClient, serviceFilter, levelFilter, logFilter, outputWriter)
-	}
-
-	return nil
+	logs = filterLogsByGrep(logs, e.grepPattern)
+	logs = filterLogsByFields(logs, e.fieldFilters)
+
+	return &collectedLogs{
+		cwd:             cwd,
+		serviceFilter:   serviceFilter,
+		logManager:      logManager,
+		dashboardClient: dashboardClient,
+		levelFilter:     levelFilter,
+		logFilter:       logFilter,
+		logs:            logs,
+	}, nil
 }
 
 // parseServiceFilter parses service names from args and flags.
@@ -388,6 +604,15 @@ func (e *logsExecutor) parseServiceFilter(args []string) []string {
 	return serviceFilter
 }
 
+// resolveNoPrefix decides whether text output omits the [service] prefix. An explicit
+// --no-prefix wins; otherwise the prefix is dropped when exactly one service is selected.
+func resolveNoPrefix(opts *logsOptions, serviceFilter []string) bool {
+	if opts.noPrefixSet {
+		return opts.noPrefix
+	}
+	return len(serviceFilter) == 1
+}
+
 // validateServiceFilter validates that all service names in the filter exist.
 // Optimized with O(n) lookup using a map instead of O(n*m) nested loops.
 func (e *logsExecutor) validateServiceFilter(serviceFilter, serviceNames []string) error {
@@ -414,16 +639,30 @@ func (e *logsExecutor) validateServiceFilter(serviceFilter, serviceNames []strin
 // parseSinceTime parses the since duration and returns the cutoff time.
 // Returns error instead of silently failing when duration is invalid.
 func (e *logsExecutor) parseSinceTime() (time.Time, error) {
-	if e.opts.since == "" {
+	return parseLogTimeBound(e.opts.since, time.Now())
+}
+
+// parseUntilTime parses --until and returns the end of the time window, or the zero time
+// when it isn't set.
+func (e *logsExecutor) parseUntilTime() (time.Time, error) {
+	return parseLogTimeBound(e.opts.until, time.Now())
+}
+
+// parseLogTimeBound parses a --since or --until value: a duration before now (e.g. 5m, 1h)
+// or an RFC3339 timestamp. An empty value yields the zero time.
+func parseLogTimeBound(value string, now time.Time) (time.Time, error) {
+	if value == "" {
 		return time.Time{}, nil
 	}
 
-	duration, err := time.ParseDuration(e.opts.since)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse duration '%s': %w", e.opts.since, err)
+	duration, durationErr := time.ParseDuration(value)
+	if durationErr == nil {
+		return now.Add(-duration), nil
 	}
-
-	return time.Now().Add(-duration), nil
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("failed to parse duration or RFC3339 timestamp '%s': %w", value, durationErr)
 }
 
 // setupOutputWriter creates the output writer, returning a cleanup function if a file was opened.
@@ -461,44 +700,69 @@ func (e *logsExecutor) setupOutputWriter() (io.Writer, func(), error) {
 	return file, cleanup, nil
 }
 
-// collectLogs collects logs from all target services.
-// Now accepts context to allow cancellation during log collection.
-func (e *logsExecutor) collectLogs(ctx context.Context, cwd string, targetServices []string, logManager LogManagerInterface, sinceTime time.Time) ([]service.LogEntry, error) {
-	// Pre-allocate with estimated capacity
-	estimatedCap := len(targetServices) * e.opts.tail
-	logs := make([]service.LogEntry, 0, estimatedCap)
+// collectLogs collects logs from all target services, between sinceTime and untilTime
+// (either may be zero for no bound).
+// Services are read concurrently, at most maxConcurrentLogReads at a time, and their logs
+// are merged into a single stream in timestamp order.
+func (e *logsExecutor) collectLogs(ctx context.Context, cwd string, targetServices []string, logManager LogManagerInterface, sinceTime, untilTime time.Time) ([]service.LogEntry, error) {
+	serviceLogs := make([][]service.LogEntry, len(targetServices))
+	semaphore := make(chan struct{}, max(maxConcurrentLogReads, 1))
+	var wg sync.WaitGroup
 
-	for _, serviceName := range targetServices {
-		// Check for context cancellation
+	for i, serviceName := range targetServices {
+		// Stop starting reads once the context is cancelled
+		if ctx.Err() != nil {
+			break
+		}
 		select {
+		case semaphore <- struct{}{}:
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+		}
+		if ctx.Err() != nil {
+			break
 		}
 
-		var serviceLogs []service.LogEntry
+		wg.Add(1)
+		go func(i int, serviceName string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			serviceLogs[i] = e.collectServiceLogs(cwd, serviceName, logManager, sinceTime, untilTime)
+		}(i, serviceName)
+	}
+	wg.Wait()
 
-		// Try in-memory buffer first
-		buffer, exists := logManager.GetBuffer(serviceName)
-		if exists {
-			if e.opts.since != "" {
-				serviceLogs = buffer.GetSince(sinceTime)
-			} else {
-				serviceLogs = buffer.GetRecent(e.opts.tail)
-			}
-		}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return service.MergeLogEntries(serviceLogs), nil
+}
 
-		// If no logs in memory, try reading from log files
-		if len(serviceLogs) == 0 {
-			fileLogs, err := readLogsFromFile(cwd, serviceName, e.opts.tail, sinceTime)
-			if err == nil {
-				serviceLogs = fileLogs
-			}
+// collectServiceLogs collects the logs of a service, from its in-memory buffer or, when
+// that is empty, from its log files. Entries after untilTime are dropped before --tail is
+// applied, as the most recent lines may all be after the window.
+func (e *logsExecutor) collectServiceLogs(cwd, serviceName string, logManager LogManagerInterface, sinceTime, untilTime time.Time) []service.LogEntry {
+	// Try in-memory buffer first
+	if buffer, exists := logManager.GetBuffer(serviceName); exists {
+		var bufferLogs []service.LogEntry
+		switch {
+		case e.opts.since != "":
+			bufferLogs = filterLogsUntil(buffer.GetSince(sinceTime), untilTime)
+		case !untilTime.IsZero():
+			bufferLogs = tailLogEntries(filterLogsUntil(buffer.GetRecent(0), untilTime), e.opts.tail)
+		default:
+			bufferLogs = buffer.GetRecent(e.opts.tail)
 		}
+		if len(bufferLogs) > 0 {
+			return bufferLogs
+		}
+	}
 
-		logs = append(logs, serviceLogs...)
+	// If no logs in memory, try reading from log files
+	fileLogs, err := readLogsFromFile(cwd, serviceName, e.opts.tail, sinceTime, untilTime, e.lineSizeLimit())
+	if err != nil {
+		return nil
 	}
-	return logs, nil
+	return fileLogs
 }
 
 // extractLogsWithContext finds log entries matching the level filter and extracts
@@ -558,25 +822,56 @@ func (e *logsExecutor) extractLogsWithContext(logs []service.LogEntry, levelFilt
 		// Mark the match itself as used
 		usedIndices[matchIdx] = true
 
-		// Build context only if we have any lines
-		var ctx *LogContext
-		if len(before) > 0 || len(after) > 0 {
-			ctx = &LogContext{
-				Before: before,
-				After:  after,
-			}
+		result = append(result, newLogEntryWithContext(entry, before, after))
+	}
+
+	return result
+}
+
+// newLogEntryWithContext returns entry with the given context lines, omitting the context
+// when there are none.
+func newLogEntryWithContext(entry service.LogEntry, before, after []string) LogEntryWithContext {
+	var ctx *LogContext
+	if len(before) > 0 || len(after) > 0 {
+		ctx = &LogContext{
+			Before: before,
+			After:  after,
 		}
+	}
 
-		result = append(result, LogEntryWithContext{
-			Service:   entry.Service,
-			Message:   entry.Message,
-			Level:     logLevelToString(entry.Level),
-			Timestamp: entry.Timestamp,
-			IsStderr:  entry.IsStderr,
-			Context:   ctx,
-		})
+	return LogEntryWithContext{
+		Service:   entry.Service,
+		Message:   entry.Message,
+		Level:     logLevelToString(entry.Level),
+		Timestamp: entry.Timestamp,
+		IsStderr:  entry.IsStderr,
+		Fields:    entry.Fields,
+		Truncated: entry.Truncated,
+		Context:   ctx,
+	}
+}
+
+// followContext collects lines received while following that are not at the --level, to
+// show as the before-context of the next matching entry. Lines after a match are not known
+// when it arrives, so followed entries have no after-context.
+type followContext struct {
+	lines  int
+	before []string
+}
+
+// add records a non-matching line, keeping only the most recent ones.
+func (c *followContext) add(message string) {
+	c.before = append(c.before, message)
+	if len(c.before) > c.lines {
+		c.before = c.before[len(c.before)-c.lines:]
 	}
+}
 
+// match returns entry with the collected before-context. The context is then reset, so
+// each line is shown at most once, as in extractLogsWithContext.
+func (c *followContext) match(entry service.LogEntry) LogEntryWithContext {
+	result := newLogEntryWithContext(entry, c.before, nil)
+	c.before = nil
 	return result
 }
 
@@ -655,16 +950,60 @@ func (e *logsExecutor) shouldDisplayEntry(entry service.LogEntry, levelFilter se
 		return false
 	}
 
-	// Filter by pattern
+	// Keep only lines matching --include, then drop those matching --exclude
+	if !matchesIncludePatterns(entry.Message, e.includePatterns) {
+		return false
+	}
 	if logFilter != nil && logFilter.ShouldFilter(entry.Message) {
 		return false
 	}
 
+	// Keep only lines matching --grep
+	if e.grepPattern != nil && !e.grepPattern.MatchString(entry.Message) {
+		return false
+	}
+
+	// Filter by structured fields
+	if !matchesFieldFilters(entry, e.fieldFilters) {
+		return false
+	}
+
 	return true
 }
 
+// displayFollowEntry masks secrets in an entry received while following and displays it if
+// it passes the filters. With --context, entries at other levels are kept as context for the
+// next entry at the --level.
+func (e *logsExecutor) displayFollowEntry(entry service.LogEntry, levelFilter service.LogLevel, logFilter *service.LogFilter, w io.Writer) {
+	entry = truncateLogEntry(e.redactor.RedactEntry(entry), e.lineSizeLimit())
+	if e.followContext == nil {
+		if e.shouldDisplayEntry(entry, levelFilter, logFilter) {
+			e.displayLogs([]service.LogEntry{entry}, w)
+		}
+		return
+	}
+
+	if !e.shouldDisplayEntry(entry, LogLevelAll, logFilter) {
+		return
+	}
+	if entry.Level != levelFilter {
+		e.followContext.add(entry.Message)
+		return
+	}
+	e.displayLogsWithContext([]LogEntryWithContext{e.followContext.match(entry)}, w)
+}
+
 // followLogs subscribes to live log streams and displays them.
 func (e *logsExecutor) followLogs(ctx context.Context, projectDir string, logManager LogManagerInterface, dashboardClient DashboardClient, serviceFilter []string, levelFilter service.LogLevel, logFilter *service.LogFilter, outputWriter io.Writer) error {
+	if e.opts.contextLines > 0 && levelFilter != LogLevelAll {
+		e.followContext = &followContext{lines: e.opts.contextLines}
+	}
+
+	// --follow-new streams through the dashboard, which knows when services start
+	if e.opts.followNew {
+		return e.followLogsViaDashboard(ctx, dashboardClient, serviceFilter, levelFilter, logFilter, outputWriter)
+	}
+
 	// Try in-memory subscriptions first
 	subscriptions := make(map[string]chan service.LogEntry)
 
@@ -693,6 +1032,8 @@ func (e *logsExecutor) followLogs(ctx context.Context, projectDir string, logMan
 }
 
 // followLogsViaDashboard connects to the dashboard's WebSocket to stream logs.
+// If the stream drops while following (e.g., the dashboard restarts), it reconnects
+// with backoff and resumes streaming the same services until interrupted.
 func (e *logsExecutor) followLogsViaDashboard(ctx context.Context, dashboardClient DashboardClient, serviceFilter []string, levelFilter service.LogLevel, logFilter *service.LogFilter, outputWriter io.Writer) error {
 	// Check if dashboard is responding
 	if err := dashboardClient.Ping(ctx); err != nil {
@@ -712,24 +1053,63 @@ func (e *logsExecutor) followLogsViaDashboard(ctx context.Context, dashboardClie
 	// Create channel for log entries
 	logs := make(chan service.LogEntry, logChannelBufferSize)
 
-	// Determine service filter (empty string for all)
+	// Determine service filter (empty string for all). --follow-new streams every service and
+	// filters here, as the dashboard only streams services that have started
 	serviceName := ""
-	if len(serviceFilter) == 1 {
+	if len(serviceFilter) == 1 && !e.opts.followNew {
 		serviceName = serviceFilter[0]
 	}
 
+	for {
+		interrupted, streamErr := e.streamDashboardLogs(streamCtx, dashboardClient, serviceName, serviceFilter, levelFilter, logFilter, outputWriter, logs, sigChan)
+		if interrupted || streamCtx.Err() != nil {
+			return nil
+		}
+		if errors.Is(streamErr, errNewServicesStarted) {
+			continue
+		}
+
+		// Without a client factory there is no way to reconnect
+		if e.dashboardClientFactory == nil || e.getWorkingDir == nil {
+			if streamErr != nil {
+				return fmt.Errorf("log stream error: %w", streamErr)
+			}
+			return nil
+		}
+
+		client, ok := e.reconnectDashboard(streamCtx, sigChan, streamErr)
+		if !ok {
+			return nil
+		}
+		dashboardClient = client
+	}
+}
+
+// streamDashboardLogs displays entries from a single dashboard stream until the stream
+// ends or a signal arrives. Returns whether the user interrupted and the stream error.
+func (e *logsExecutor) streamDashboardLogs(ctx context.Context, dashboardClient DashboardClient, serviceName string, serviceFilter []string, levelFilter service.LogLevel, logFilter *service.LogFilter, outputWriter io.Writer, logs chan service.LogEntry, sigChan chan os.Signal) (bool, error) {
+	// Cancel this stream when returning so the StreamLogs goroutine exits
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// Start streaming in background
 	errChan := make(chan error, 1)
 	go func() {
 		errChan <- dashboardClient.StreamLogs(streamCtx, serviceName, logs)
 	}()
 
+	// Watch for services that start later (nil channel without --follow-new)
+	var newServices <-chan []string
+	if e.opts.followNew {
+		newServices = e.watchNewServices(streamCtx, dashboardClient, serviceFilter)
+	}
+
 	// Display logs as they arrive
 	for {
 		select {
 		case entry := <-logs:
-			// Filter by service if multiple specified
-			if len(serviceFilter) > 1 {
+			// Filter by service unless the stream is for a single service
+			if serviceName == "" && len(serviceFilter) > 0 {
 				found := false
 				for _, svc := range serviceFilter {
 					if entry.Service == svc {
@@ -742,29 +1122,190 @@ func (e *logsExecutor) followLogsViaDashboard(ctx context.Context, dashboardClie
 				}
 			}
 
-			// Use extracted filter method
-			if !e.shouldDisplayEntry(entry, levelFilter, logFilter) {
-				continue
-			}
-
-			// Display log entry
-			if e.opts.format == "json" {
-				displayLogsJSON([]service.LogEntry{entry}, outputWriter)
-			} else {
-				displayLogsText([]service.LogEntry{entry}, outputWriter, e.opts.timestamps, e.opts.noColor)
-			}
+			e.displayFollowEntry(entry, levelFilter, logFilter, outputWriter)
 
 		case err := <-errChan:
-			if err != nil && err != context.Canceled {
-				return fmt.Errorf("log stream error: %w", err)
+			if err == context.Canceled {
+				err = nil
 			}
-			return nil
+			return false, err
+
+		case names := <-newServices:
+			e.printFollowNotice(fmt.Sprintf("Following logs for %s", strings.Join(names, ", ")))
+			return false, errNewServicesStarted
 
 		case <-sigChan:
-			cancel()
-			return nil
+			return true, nil
+		}
+	}
+}
+
+// watchNewServices polls the dashboard every logsFollowNewInterval and sends the names of
+// services in serviceFilter (all when empty) that started since following began. It sends
+// at most once, as the stream is then reopened with a new watcher.
+func (e *logsExecutor) watchNewServices(ctx context.Context, dashboardClient DashboardClient, serviceFilter []string) <-chan []string {
+	found := make(chan []string, 1)
+	go func() {
+		ticker := time.NewTicker(logsFollowNewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			dashCtx, dashCancel := context.WithTimeout(ctx, dashboardOperationTimeout)
+			services, err := dashboardClient.GetServices(dashCtx)
+			dashCancel()
+			if err != nil {
+				if os.Getenv("AZD_APP_DEBUG") == "true" {
+					fmt.Fprintf(os.Stderr, "[DEBUG] Checking for new services failed: %v\n", err)
+				}
+				continue
+			}
+			if names := e.markNewServices(services, serviceFilter); len(names) > 0 {
+				found <- names
+				return
+			}
+		}
+	}()
+	return found
+}
+
+// markNewServices returns the sorted names of started services in serviceFilter (all when
+// empty) that are not in startedServices yet, and adds them to it.
+func (e *logsExecutor) markNewServices(services []*serviceinfo.ServiceInfo, serviceFilter []string) []string {
+	e.startedServicesMu.Lock()
+	defer e.startedServicesMu.Unlock()
+	if e.startedServices == nil {
+		e.startedServices = make(map[string]bool)
+	}
+
+	var names []string
+	for name := range startedServiceNames(services) {
+		if e.startedServices[name] {
+			continue
+		}
+		if len(serviceFilter) > 0 && !slices.Contains(serviceFilter, name) {
+			continue
 		}
+		e.startedServices[name] = true
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
+
+// startedServiceNames returns the services azd app run has started, i.e. those the
+// dashboard doesn't report as not running.
+func startedServiceNames(services []*serviceinfo.ServiceInfo) map[string]bool {
+	started := make(map[string]bool, len(services))
+	for _, svc := range services {
+		if svc.Local != nil && svc.Local.Status != constants.StatusNotRunning {
+			started[svc.Name] = true
+		}
+	}
+	return started
+}
+
+// reconnectDashboard waits for the dashboard to come back after the log stream dropped,
+// retrying the client factory and Ping with exponential backoff.
+// Returns false if the user interrupted or the context was cancelled.
+func (e *logsExecutor) reconnectDashboard(ctx context.Context, sigChan chan os.Signal, cause error) (DashboardClient, bool) {
+	if os.Getenv("AZD_APP_DEBUG") == "true" && cause != nil {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Log stream dropped: %v\n", cause)
+	}
+	e.printFollowNotice("Log stream disconnected, reconnecting…")
+
+	delay := logsReconnectInitialDelay
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-sigChan:
+			return nil, false
+		case <-time.After(delay):
+		}
+
+		client, err := e.connectDashboard(ctx)
+		if err == nil {
+			e.printFollowNotice("Reconnected to dashboard")
+			return client, true
+		}
+		if os.Getenv("AZD_APP_DEBUG") == "true" {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Dashboard reconnect failed: %v\n", err)
+		}
+
+		delay = min(delay*2, logsReconnectMaxDelay)
+	}
+}
+
+// connectDashboard creates a dashboard client and verifies it responds.
+func (e *logsExecutor) connectDashboard(ctx context.Context) (DashboardClient, error) {
+	cwd, err := e.getWorkingDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return e.dialDashboard(ctx, cwd)
+}
+
+// connectDashboardWithRetry connects to the dashboard of the project in cwd, making up to
+// logsConnectAttempts attempts with a doubling delay between them.
+func (e *logsExecutor) connectDashboardWithRetry(ctx context.Context, cwd string) (DashboardClient, error) {
+	delay := logsConnectRetryDelay
+	var err error
+	for attempt := 1; attempt <= logsConnectAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		var client DashboardClient
+		client, err = e.dialDashboard(ctx, cwd)
+		if err == nil {
+			return client, nil
+		}
+		// Debug: log actual error for troubleshooting
+		if os.Getenv("AZD_APP_DEBUG") == "true" {
+			fmt.Fprintf(os.Stderr, "[DEBUG] Dashboard connection attempt %d/%d failed: %v\n", attempt, logsConnectAttempts, err)
+		}
+	}
+	return nil, err
+}
+
+// dialDashboard creates a client for the dashboard of the project in cwd and verifies it
+// responds.
+func (e *logsExecutor) dialDashboard(ctx context.Context, cwd string) (DashboardClient, error) {
+	dashCtx, dashCancel := context.WithTimeout(ctx, dashboardOperationTimeout)
+	defer dashCancel()
+
+	client, err := e.dashboardClientFactory(dashCtx, cwd)
+	if err != nil {
+		return nil, fmt.Errorf("dashboard client creation failed: %w", err)
+	}
+	if err := client.Ping(dashCtx); err != nil {
+		return nil, fmt.Errorf("dashboard ping failed: %w", err)
+	}
+	return client, nil
+}
+
+// printFollowNotice prints a dim status notice while following logs.
+// Notices are written with other progress output and suppressed in JSON mode
+// so they never mix with log entries.
+func (e *logsExecutor) printFollowNotice(message string) {
+	if e.opts.format != logsFormatText {
+		return
+	}
+	if e.opts.noColor {
+		fmt.Fprintln(output.ProgressWriter(), message)
+		return
+	}
+	fmt.Fprintf(output.ProgressWriter(), "%s%s%s\n", output.Dim, message, output.Reset)
 }
 
 // followLogsInMemory uses in-memory log buffer subscriptions.
@@ -833,17 +1374,7 @@ func (e *logsExecutor) followLogsInMemory(subscriptions map[string]chan service.
 				return nil
 			}
 
-			// Use extracted filter method
-			if !e.shouldDisplayEntry(entry, levelFilter, logFilter) {
-				continue
-			}
-
-			// Display log entry
-			if e.opts.format == "json" {
-				displayLogsJSON([]service.LogEntry{entry}, outputWriter)
-			} else {
-				displayLogsText([]service.LogEntry{entry}, outputWriter, e.opts.timestamps, e.opts.noColor)
-			}
+			e.displayFollowEntry(entry, levelFilter, logFilter, outputWriter)
 
 		case <-sigChan:
 			cleanup()
@@ -852,44 +1383,47 @@ func (e *logsExecutor) followLogsInMemory(subscriptions map[string]chan service.
 	}
 }
 
-// readLogsFromFile reads logs from the persisted log file for a service.
+// readLogsFromFile reads logs from the persisted log file for a service: its logFile from
+// azure.yaml, or .azure/logs/<service>.log.
 // This is used when the in-memory buffer is empty (e.g., when called from a subprocess).
-// It also reads from rotated backup files (.log.1, .log.2) if needed.
-func readLogsFromFile(projectDir, serviceName string, tail int, sinceTime time.Time) ([]service.LogEntry, error) {
-	logsDir := filepath.Join(projectDir, ".azure", "logs")
-	baseLogFile := filepath.Join(logsDir, serviceName+".log")
+// It also reads from rotated backup files (.log.1, .log.2, ...) so --tail, --since and
+// --until work across rotations. Entries outside sinceTime and untilTime (either may be
+// zero for no bound) are skipped while reading, and at most tail entries are kept.
+func readLogsFromFile(projectDir, serviceName string, tail int, sinceTime, untilTime time.Time, maxLineSize int) ([]service.LogEntry, error) {
+	baseLogFile := service.GetLogManager(projectDir).LogFile(serviceName)
 
 	var allEntries []service.LogEntry
 
-	// Read from rotated files first (oldest to newest: .log.2, .log.1, .log)
-	logFiles := []string{
-		baseLogFile + ".2",
-		baseLogFile + ".1",
-		baseLogFile,
-	}
+	// Read from rotated files first (oldest to newest: ..., .log.2, .log.1, .log)
+	logFiles := append(service.RotatedLogFiles(baseLogFile), baseLogFile)
 
 	for _, logFile := range logFiles {
-		entries, err := readSingleLogFile(logFile, serviceName, sinceTime)
+		entries, err := readSingleLogFile(logFile, serviceName, sinceTime, untilTime, maxLineSize)
 		if err != nil {
 			continue // File may not exist (rotated files are optional)
 		}
-		allEntries = append(allEntries, entries...)
+		allEntries = tailLogEntries(append(allEntries, entries...), tail)
 	}
 
 	if len(allEntries) == 0 {
 		return nil, fmt.Errorf("no log files found for service %s", serviceName)
 	}
 
-	// Apply tail limit
-	if tail > 0 && len(allEntries) > tail {
-		allEntries = allEntries[len(allEntries)-tail:]
-	}
-
 	return allEntries, nil
 }
 
-// readSingleLogFile reads log entries from a single log file.
-func readSingleLogFile(logFile, serviceName string, sinceTime time.Time) ([]service.LogEntry, error) {
+// tailLogEntries returns the last tail entries of logs, or all of them when tail is 0.
+func tailLogEntries(logs []service.LogEntry, tail int) []service.LogEntry {
+	if tail > 0 && len(logs) > tail {
+		return logs[len(logs)-tail:]
+	}
+	return logs
+}
+
+// readSingleLogFile reads log entries from a single log file, skipping those before
+// sinceTime or after untilTime. Lines longer than maxLineSize bytes are truncated (see
+// truncateLogEntry) without reading them into memory whole.
+func readSingleLogFile(logFile, serviceName string, sinceTime, untilTime time.Time, maxLineSize int) ([]service.LogEntry, error) {
 	file, err := os.Open(logFile)
 	if err != nil {
 		return nil, err
@@ -897,30 +1431,79 @@ func readSingleLogFile(logFile, serviceName string, sinceTime time.Time) ([]serv
 	defer file.Close()
 
 	var entries []service.LogEntry
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size to handle long log lines (stack traces, JSON dumps)
-	scanner.Buffer(make([]byte, scannerInitialBufferSize), maxLogLineSize)
+	reader := bufio.NewReaderSize(file, scannerInitialBufferSize)
+
+	for {
+		line, dropped, err := service.ReadLogLine(reader, maxLineSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	for scanner.Scan() {
-		line := scanner.Text()
 		entry, err := parseLogLine(line, serviceName)
 		if err != nil {
 			continue // Skip unparseable lines
 		}
+		if dropped > 0 {
+			entry.Message += service.TruncationMarker(dropped)
+			entry.Truncated = true
+			entry.Fields = nil
+		}
 
-		// Apply since filter
+		// Apply since and until filters
 		if !sinceTime.IsZero() && entry.Timestamp.Before(sinceTime) {
 			continue
 		}
+		if !untilTime.IsZero() && entry.Timestamp.After(untilTime) {
+			continue
+		}
 
 		entries = append(entries, entry)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	return entries, nil
+}
+
+// truncateLogEntry cuts a message longer than maxLineSize bytes at a UTF-8 character
+// boundary and ends it with a "…[truncated N bytes]" marker, setting Truncated. Parsed
+// fields are dropped, as they describe the whole line.
+func truncateLogEntry(entry service.LogEntry, maxLineSize int) service.LogEntry {
+	if len(entry.Message) <= maxLineSize {
+		return entry
+	}
+	cut := maxLineSize
+	for cut > 0 && !utf8.RuneStart(entry.Message[cut]) {
+		cut--
+	}
+	entry.Message = entry.Message[:cut] + service.TruncationMarker(len(entry.Message)-cut)
+	entry.Truncated = true
+	entry.Fields = nil
+	return entry
+}
+
+// parseMaxLineSize parses --max-line-size, returning maxLogLineSize when it is not set.
+func parseMaxLineSize(value string) (int, error) {
+	if value == "" {
+		return maxLogLineSize, nil
 	}
+	size, err := service.ParseByteSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --max-line-size: %w", err)
+	}
+	if size < 1 || size > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid --max-line-size: %s must be between 1 byte and 2GB", value)
+	}
+	return int(size), nil
+}
 
-	return entries, nil
+// lineSizeLimit returns the size above which log lines are truncated.
+func (e *logsExecutor) lineSizeLimit() int {
+	if e.maxLineSize > 0 {
+		return e.maxLineSize
+	}
+	return maxLogLineSize
 }
 
 // parseLogLine parses a log line from the file format:
@@ -979,6 +1562,10 @@ func parseLogLine(line, serviceName string) (service.LogEntry, error) {
 	}
 
 	entry.Message = remaining
+
+	// Structured (JSON) lines carry their own fields, level and message
+	service.ApplyJSONFields(&entry)
+
 	return entry, nil
 }
 
@@ -1003,16 +1590,83 @@ func parseLogLevelFromString(level string) service.LogLevel {
 // Other colors (colorGray, colorRed, colorYellow, colorReset) are in info.go.
 const colorCyan = "\033[36m"
 
+// colorHighlight is the ANSI style used for --highlight matches (bold black on yellow).
+const colorHighlight = "\033[1;30;43m"
+
+// textDisplayOptions controls how log entries are rendered in text mode.
+type textDisplayOptions struct {
+	timestamps bool
+	timeLayout string // Layout from resolveTimeFormat; empty for defaultTimeLayout
+	noColor    bool
+	noPrefix   bool             // Omit the [service] prefix
+	highlights []*regexp.Regexp // Patterns to colorize; ignored when noColor is set
+}
+
+// textDisplayOptions builds the text rendering options from the executor's flags.
+func (e *logsExecutor) textDisplayOptions() textDisplayOptions {
+	return textDisplayOptions{
+		timestamps: e.opts.timestamps,
+		timeLayout: e.timeLayout,
+		noColor:    e.opts.noColor,
+		noPrefix:   e.hidePrefix,
+		highlights: e.highlightPatterns,
+	}
+}
+
+// displayLogs writes log entries in the configured output format.
+func (e *logsExecutor) displayLogs(logs []service.LogEntry, w io.Writer) {
+	switch e.opts.format {
+	case logsFormatJSON, logsFormatNDJSON:
+		if e.opts.passthrough {
+			displayLogsJSONPassthrough(logs, w)
+		} else {
+			displayLogsJSON(logs, w)
+		}
+		e.flushNDJSON(w)
+	default:
+		writeLogsText(logs, w, e.textDisplayOptions())
+	}
+}
+
+// displayLogsWithContext writes log entries with context in the configured output format.
+func (e *logsExecutor) displayLogsWithContext(logs []LogEntryWithContext, w io.Writer) {
+	switch e.opts.format {
+	case logsFormatJSON, logsFormatNDJSON:
+		displayLogsWithContextJSON(logs, w)
+		e.flushNDJSON(w)
+	default:
+		writeLogsWithContextText(logs, w, e.textDisplayOptions())
+	}
+}
+
+// flushNDJSON flushes a buffered writer after NDJSON output, so each line reaches the
+// reader (e.g. a pipe into jq) as soon as the entry arrives.
+func (e *logsExecutor) flushNDJSON(w io.Writer) {
+	if e.opts.format != logsFormatNDJSON {
+		return
+	}
+	if flusher, ok := w.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to flush log output: %v\n", err)
+		}
+	}
+}
+
 // displayLogsText displays logs in text format.
 // Uses io.Writer interface for better testability and flexibility.
 func displayLogsText(logs []service.LogEntry, w io.Writer, showTimestamps, noColor bool) {
+	writeLogsText(logs, w, textDisplayOptions{timestamps: showTimestamps, noColor: noColor})
+}
+
+// writeLogsText renders log entries in text format using the given options.
+func writeLogsText(logs []service.LogEntry, w io.Writer, opts textDisplayOptions) {
 	for _, entry := range logs {
 		var line strings.Builder
 
 		// Timestamp
-		if showTimestamps {
-			timestamp := entry.Timestamp.Format("15:04:05.000")
-			if noColor {
+		if opts.timestamps {
+			timestamp := formatLogTimestamp(entry.Timestamp, opts.timeLayout)
+			if opts.noColor {
 				line.WriteString(fmt.Sprintf("[%s] ", timestamp))
 			} else {
 				line.WriteString(colorGray + "[" + timestamp + "]" + colorReset + " ")
@@ -1020,24 +1674,31 @@ func displayLogsText(logs []service.LogEntry, w io.Writer, showTimestamps, noCol
 		}
 
 		// Service name
-		if noColor {
-			line.WriteString(fmt.Sprintf("[%s] ", entry.Service))
-		} else {
-			line.WriteString(colorCyan + "[" + entry.Service + "]" + colorReset + " ")
+		if !opts.noPrefix {
+			if opts.noColor {
+				line.WriteString(fmt.Sprintf("[%s] ", entry.Service))
+			} else {
+				line.WriteString(colorCyan + "[" + entry.Service + "]" + colorReset + " ")
+			}
 		}
 
 		// Message with color based on stderr/level
-		if noColor {
+		if opts.noColor {
 			line.WriteString(entry.Message)
 		} else {
+			baseColor := ""
 			if entry.IsStderr || entry.Level == service.LogLevelError {
-				line.WriteString(colorRed + entry.Message + colorReset)
+				baseColor = colorRed
 			} else if entry.Level == service.LogLevelWarn {
-				line.WriteString(colorYellow + entry.Message + colorReset)
+				baseColor = colorYellow
 			} else if entry.Level == service.LogLevelDebug {
-				line.WriteString(colorGray + entry.Message + colorReset)
+				baseColor = colorGray
+			}
+			message := highlightMatches(entry.Message, opts.highlights, baseColor)
+			if baseColor != "" {
+				line.WriteString(baseColor + message + colorReset)
 			} else {
-				line.WriteString(entry.Message)
+				line.WriteString(message)
 			}
 		}
 
@@ -1045,6 +1706,52 @@ func displayLogsText(logs []service.LogEntry, w io.Writer, showTimestamps, noCol
 	}
 }
 
+// highlightMatches wraps every match of the given patterns in the highlight color.
+// Overlapping matches are merged. After each match the base color is restored so the
+// rest of the line keeps its level coloring.
+func highlightMatches(message string, patterns []*regexp.Regexp, baseColor string) string {
+	if len(patterns) == 0 || message == "" {
+		return message
+	}
+
+	// Collect match ranges from all patterns
+	var ranges [][2]int
+	for _, re := range patterns {
+		for _, loc := range re.FindAllStringIndex(message, -1) {
+			if loc[0] < loc[1] {
+				ranges = append(ranges, [2]int{loc[0], loc[1]})
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return message
+	}
+
+	// Merge overlapping ranges
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1] {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	var b strings.Builder
+	prev := 0
+	for _, r := range merged {
+		b.WriteString(message[prev:r[0]])
+		b.WriteString(colorHighlight + message[r[0]:r[1]] + colorReset + baseColor)
+		prev = r[1]
+	}
+	b.WriteString(message[prev:])
+	return b.String()
+}
+
 // displayLogsJSON displays logs in JSON format.
 // Uses io.Writer interface for better testability and flexibility.
 func displayLogsJSON(logs []service.LogEntry, w io.Writer) {
@@ -1056,6 +1763,28 @@ func displayLogsJSON(logs []service.LogEntry, w io.Writer) {
 	}
 }
 
+// displayLogsJSONPassthrough displays logs in JSON format, writing structured (JSON) log
+// lines as the service's original object with a "service" field added instead of wrapping
+// them in a LogEntry. A "service" field already in the object is replaced. Plain-text
+// lines are written as LogEntry objects, as in displayLogsJSON.
+func displayLogsJSONPassthrough(logs []service.LogEntry, w io.Writer) {
+	encoder := json.NewEncoder(w)
+	for _, entry := range logs {
+		var value any = entry
+		if entry.Fields != nil {
+			object := make(map[string]any, len(entry.Fields)+1)
+			for key, field := range entry.Fields {
+				object[key] = field
+			}
+			object["service"] = entry.Service
+			value = object
+		}
+		if err := encoder.Encode(value); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to encode log entry: %v\n", err)
+		}
+	}
+}
+
 // displayLogsWithContextJSON displays logs with context in JSON format.
 // Each entry includes optional before/after context lines.
 func displayLogsWithContextJSON(logs []LogEntryWithContext, w io.Writer) {
@@ -1070,6 +1799,11 @@ func displayLogsWithContextJSON(logs []LogEntryWithContext, w io.Writer) {
 // displayLogsWithContextText displays logs with context in text format.
 // Context lines are shown with indentation and separators between entries.
 func displayLogsWithContextText(logs []LogEntryWithContext, w io.Writer, showTimestamps, noColor bool) {
+	writeLogsWithContextText(logs, w, textDisplayOptions{timestamps: showTimestamps, noColor: noColor})
+}
+
+// writeLogsWithContextText renders logs with context in text format using the given options.
+func writeLogsWithContextText(logs []LogEntryWithContext, w io.Writer, opts textDisplayOptions) {
 	for i, entry := range logs {
 		// Add separator between entries (not before first)
 		if i > 0 {
@@ -1079,7 +1813,7 @@ func displayLogsWithContextText(logs []LogEntryWithContext, w io.Writer, showTim
 		// Show before context (if any)
 		if entry.Context != nil && len(entry.Context.Before) > 0 {
 			for _, line := range entry.Context.Before {
-				if noColor {
+				if opts.noColor {
 					fmt.Fprintf(w, "  %s\n", line)
 				} else {
 					fmt.Fprintf(w, "  %s%s%s\n", colorGray, line, colorReset)
@@ -1091,9 +1825,9 @@ func displayLogsWithContextText(logs []LogEntryWithContext, w io.Writer, showTim
 		var line strings.Builder
 
 		// Timestamp
-		if showTimestamps {
-			timestamp := entry.Timestamp.Format("15:04:05.000")
-			if noColor {
+		if opts.timestamps {
+			timestamp := formatLogTimestamp(entry.Timestamp, opts.timeLayout)
+			if opts.noColor {
 				line.WriteString(fmt.Sprintf("[%s] ", timestamp))
 			} else {
 				line.WriteString(colorGray + "[" + timestamp + "]" + colorReset + " ")
@@ -1101,25 +1835,32 @@ func displayLogsWithContextText(logs []LogEntryWithContext, w io.Writer, showTim
 		}
 
 		// Service name
-		if noColor {
-			line.WriteString(fmt.Sprintf("[%s] ", entry.Service))
-		} else {
-			line.WriteString(colorCyan + "[" + entry.Service + "]" + colorReset + " ")
+		if !opts.noPrefix {
+			if opts.noColor {
+				line.WriteString(fmt.Sprintf("[%s] ", entry.Service))
+			} else {
+				line.WriteString(colorCyan + "[" + entry.Service + "]" + colorReset + " ")
+			}
 		}
 
 		// Message with color based on level
-		if noColor {
+		if opts.noColor {
 			line.WriteString(entry.Message)
 		} else {
+			baseColor := ""
 			switch entry.Level {
 			case "error":
-				line.WriteString(colorRed + entry.Message + colorReset)
+				baseColor = colorRed
 			case "warn":
-				line.WriteString(colorYellow + entry.Message + colorReset)
+				baseColor = colorYellow
 			case "debug":
-				line.WriteString(colorGray + entry.Message + colorReset)
-			default:
-				line.WriteString(entry.Message)
+				baseColor = colorGray
+			}
+			message := highlightMatches(entry.Message, opts.highlights, baseColor)
+			if baseColor != "" {
+				line.WriteString(baseColor + message + colorReset)
+			} else {
+				line.WriteString(message)
 			}
 		}
 
@@ -1128,7 +1869,7 @@ func displayLogsWithContextText(logs []LogEntryWithContext, w io.Writer, showTim
 		// Show after context (if any)
 		if entry.Context != nil && len(entry.Context.After) > 0 {
 			for _, contextLine := range entry.Context.After {
-				if noColor {
+				if opts.noColor {
 					fmt.Fprintf(w, "  %s\n", contextLine)
 				} else {
 					fmt.Fprintf(w, "  %s%s%s\n", colorGray, contextLine, colorReset)
@@ -1179,6 +1920,218 @@ func filterLogsByLevel(logs []service.LogEntry, level service.LogLevel) []servic
 	return filtered
 }
 
+// parseFieldFilters parses --field values in key=value form into a lookup map.
+func parseFieldFilters(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	filters := make(map[string]string, len(values))
+	for _, value := range values {
+		key, expected, found := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("--field must be in key=value format, got '%s'", value)
+		}
+		filters[key] = expected
+	}
+	return filters, nil
+}
+
+// matchesFieldFilters reports whether a log entry's structured fields match all filters.
+// Entries without structured fields never match a non-empty filter set.
+func matchesFieldFilters(entry service.LogEntry, filters map[string]string) bool {
+	for key, expected := range filters {
+		actual, ok := entry.FieldString(key)
+		if !ok || actual != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// Named --time-format values.
+const (
+	timeFormatRFC3339 = "rfc3339"
+	timeFormatShort   = "short"
+	timeFormatUnix    = "unix"
+)
+
+// defaultTimeLayout is how timestamps are shown in text output without --time-format.
+const defaultTimeLayout = "15:04:05.000"
+
+// resolveTimeFormat turns a --time-format value into the layout used by formatLogTimestamp:
+// a named format's layout, timeFormatUnix, or the value itself as a Go time layout. A layout
+// without any date or time element is rejected, since it would print the same text for
+// every entry.
+func resolveTimeFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "":
+		return defaultTimeLayout, nil
+	case timeFormatRFC3339:
+		return time.RFC3339, nil
+	case timeFormatShort:
+		return "15:04:05", nil
+	case timeFormatUnix:
+		return timeFormatUnix, nil
+	}
+
+	// A layout element changes the output, so formatting must not reproduce the layout
+	probe := time.Date(2001, time.February, 3, 4, 5, 6, 7, time.UTC)
+	if probe.Format(format) == format {
+		return "", fmt.Errorf("--time-format must be rfc3339, short, unix or a Go time layout (e.g. 2006-01-02 15:04:05), got '%s'", format)
+	}
+	return format, nil
+}
+
+// formatLogTimestamp renders an entry's timestamp with a layout from resolveTimeFormat.
+func formatLogTimestamp(t time.Time, layout string) string {
+	switch layout {
+	case "":
+		return t.Format(defaultTimeLayout)
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// compileIncludePatterns compiles the comma-separated --include patterns. Like --exclude
+// patterns, they match case-insensitively.
+func compileIncludePatterns(include string) ([]*regexp.Regexp, error) {
+	rawPatterns := service.ParseExcludePatterns(include)
+	patterns := make([]*regexp.Regexp, 0, len(rawPatterns))
+	for _, pattern := range rawPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("--include must be valid regex patterns, got '%s': %w", pattern, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// matchesIncludePatterns reports whether a message matches at least one --include pattern.
+// Every message matches when there are none.
+func matchesIncludePatterns(message string, patterns []*regexp.Regexp) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if pattern.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterLogsByInclude keeps only log entries whose message matches at least one --include pattern.
+func filterLogsByInclude(logs []service.LogEntry, patterns []*regexp.Regexp) []service.LogEntry {
+	if len(patterns) == 0 {
+		return logs
+	}
+
+	filtered := make([]service.LogEntry, 0, len(logs)/filterCapacityEstimate)
+	for _, entry := range logs {
+		if matchesIncludePatterns(entry.Message, patterns) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// compileGrepAndHighlight compiles the --grep and --highlight patterns.
+func compileGrepAndHighlight(grep string, highlights []string) (*regexp.Regexp, []*regexp.Regexp, error) {
+	var grepPattern *regexp.Regexp
+	if grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--grep must be a valid regex, got '%s': %w", grep, err)
+		}
+		grepPattern = re
+	}
+
+	highlightPatterns := make([]*regexp.Regexp, 0, len(highlights))
+	for _, pattern := range highlights {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--highlight must be a valid regex, got '%s': %w", pattern, err)
+		}
+		highlightPatterns = append(highlightPatterns, re)
+	}
+
+	return grepPattern, highlightPatterns, nil
+}
+
+// buildLogRedactor creates the secret redactor from the built-in patterns and --redact,
+// or returns nil when --no-redact is set.
+func buildLogRedactor(opts *logsOptions) (*service.LogRedactor, error) {
+	if opts.noRedact {
+		return nil, nil
+	}
+
+	patterns := make([]string, 0, len(opts.redact))
+	for _, pattern := range opts.redact {
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	redactor, err := service.NewLogRedactorWithBuiltins(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("--redact must be a valid regex: %w", err)
+	}
+	return redactor, nil
+}
+
+// filterLogsUntil keeps only log entries at or before until. The zero time keeps every entry.
+func filterLogsUntil(logs []service.LogEntry, until time.Time) []service.LogEntry {
+	if until.IsZero() {
+		return logs
+	}
+
+	filtered := make([]service.LogEntry, 0, len(logs))
+	for _, entry := range logs {
+		if !entry.Timestamp.After(until) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// filterLogsByGrep keeps only log entries whose message matches the pattern.
+func filterLogsByGrep(logs []service.LogEntry, pattern *regexp.Regexp) []service.LogEntry {
+	if pattern == nil {
+		return logs
+	}
+
+	filtered := make([]service.LogEntry, 0, len(logs)/filterCapacityEstimate)
+	for _, entry := range logs {
+		if pattern.MatchString(entry.Message) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// filterLogsByFields filters logs to entries whose structured fields match all filters.
+func filterLogsByFields(logs []service.LogEntry, filters map[string]string) []service.LogEntry {
+	if len(filters) == 0 {
+		return logs
+	}
+
+	filtered := make([]service.LogEntry, 0, len(logs)/filterCapacityEstimate)
+	for _, entry := range logs {
+		if matchesFieldFilters(entry, filters) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
 // buildLogFilter creates a log filter from options and azure.yaml config.
 // This is a test helper function that wraps buildLogFilterInternal.
 // Deprecated: Use executor.buildLogFilterInternal directly in new code.
@@ -1223,9 +2176,23 @@ func getFilterConfig(azureYaml *service.AzureYaml, err error) *service.LogFilter
 
 // validateLogsOptions validates command-line flag values.
 func validateLogsOptions(opts *logsOptions) error {
+	// --tail -1 is --tail-all. Following replays every buffered line; other invocations
+	// still read at most maxTailLines lines
+	if opts.tail == tailAllLines {
+		opts.tailAll = true
+	} else if opts.tailAll && opts.tailSet {
+		return fmt.Errorf("--tail-all cannot be combined with --tail %d", opts.tail)
+	}
+	if opts.tailAll {
+		opts.tail = maxTailLines
+		if opts.follow {
+			opts.tail = 0
+		}
+	}
+
 	// Validate tail is positive
 	if opts.tail < 0 {
-		return fmt.Errorf("--tail must be a positive number, got %d", opts.tail)
+		return fmt.Errorf("--tail must be a positive number or -1 for all lines, got %d", opts.tail)
 	}
 	if opts.tail > maxTailLines {
 		// Log warning before capping
@@ -1235,10 +2202,23 @@ func validateLogsOptions(opts *logsOptions) error {
 
 	// Validate format
 	switch opts.format {
-	case "text", "json":
+	case logsFormatText, logsFormatJSON, logsFormatNDJSON:
 		// Valid formats
 	default:
-		return fmt.Errorf("--format must be 'text' or 'json', got '%s'", opts.format)
+		return fmt.Errorf("--format must be 'text', 'json' or 'ndjson', got '%s'", opts.format)
+	}
+
+	if opts.followNew && !opts.follow {
+		return fmt.Errorf("--follow-new requires --follow")
+	}
+
+	if opts.passthrough {
+		if opts.format == logsFormatText {
+			return fmt.Errorf("--json-logs-passthrough requires --format json or ndjson")
+		}
+		if opts.contextLines > 0 {
+			return fmt.Errorf("--json-logs-passthrough cannot be combined with --context")
+		}
 	}
 
 	// Validate level
@@ -1265,10 +2245,51 @@ func validateLogsOptions(opts *logsOptions) error {
 		opts.contextLines = service.MaxContextLines
 	}
 
-	// Validate since duration if provided
-	if opts.since != "" {
-		if _, err := time.ParseDuration(opts.since); err != nil {
-			return fmt.Errorf("--since must be a valid duration (e.g., 5m, 1h), got '%s': %w", opts.since, err)
+	// Validate field filters
+	if _, err := parseFieldFilters(opts.fields); err != nil {
+		return err
+	}
+
+	if _, err := resolveTimeFormat(opts.timeFormat); err != nil {
+		return err
+	}
+
+	if _, err := parseMaxLineSize(opts.maxLineSize); err != nil {
+		return err
+	}
+
+	// Validate include, grep and highlight patterns
+	if _, err := compileIncludePatterns(opts.include); err != nil {
+		return err
+	}
+	if _, _, err := compileGrepAndHighlight(opts.grep, opts.highlight); err != nil {
+		return err
+	}
+
+	// Validate redaction patterns
+	if opts.noRedact && len(opts.redact) > 0 {
+		return fmt.Errorf("--redact cannot be combined with --no-redact")
+	}
+	if _, err := buildLogRedactor(opts); err != nil {
+		return err
+	}
+
+	// Validate since and until; both are resolved against the same instant so they can be compared
+	now := time.Now()
+	sinceTime, err := parseLogTimeBound(opts.since, now)
+	if err != nil {
+		return fmt.Errorf("--since must be a valid duration (e.g., 5m, 1h) or RFC3339 timestamp, got '%s'", opts.since)
+	}
+	untilTime, err := parseLogTimeBound(opts.until, now)
+	if err != nil {
+		return fmt.Errorf("--until must be a valid duration (e.g., 5m, 1h) or RFC3339 timestamp, got '%s'", opts.until)
+	}
+	if !untilTime.IsZero() {
+		if opts.follow {
+			return fmt.Errorf("--until cannot be combined with --follow")
+		}
+		if untilTime.Before(sinceTime) {
+			return fmt.Errorf("--until (%s) is before --since (%s)", untilTime.Format(time.RFC3339), sinceTime.Format(time.RFC3339))
 		}
 	}
 
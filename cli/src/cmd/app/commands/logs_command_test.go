@@ -20,13 +20,14 @@ func TestValidateLogsOptions(t *testing.T) {
 	}{
 		{"valid defaults", 100, "text", "all", "", 0, false, ""},
 		{"valid json format", 100, "json", "all", "", 0, false, ""},
+		{"valid ndjson format", 100, "ndjson", "all", "", 0, false, ""},
 		{"valid level info", 100, "text", "info", "", 0, false, ""},
 		{"valid level warn", 100, "text", "warn", "", 0, false, ""},
 		{"valid level error", 100, "text", "error", "", 0, false, ""},
 		{"valid level debug", 100, "text", "debug", "", 0, false, ""},
 		{"valid since 5m", 100, "text", "all", "5m", 0, false, ""},
 		{"valid since 1h", 100, "text", "all", "1h", 0, false, ""},
-		{"negative tail", -1, "text", "all", "", 0, true, "--tail must be a positive"},
+		{"negative tail", -2, "text", "all", "", 0, true, "--tail must be a positive"},
 		{"invalid format", 100, "xml", "all", "", 0, true, "--format must be"},
 		{"invalid level", 100, "text", "trace", "", 0, true, "--level must be one of"},
 		{"invalid since", 100, "text", "all", "5x", 0, true, "--since must be a valid duration"},
@@ -65,6 +66,112 @@ func TestValidateLogsOptions(t *testing.T) {
 		})
 	}
 
+	t.Run("include patterns", func(t *testing.T) {
+		valid := &logsOptions{tail: 100, format: "json", level: "error", include: `api\., worker`}
+		if err := validateLogsOptions(valid); err != nil {
+			t.Errorf("validateLogsOptions() unexpected error: %v", err)
+		}
+
+		invalid := &logsOptions{tail: 100, format: "text", level: "all", include: "api,(bad"}
+		if err := validateLogsOptions(invalid); err == nil || !strings.Contains(err.Error(), "--include") {
+			t.Errorf("validateLogsOptions() error = %v, want invalid --include", err)
+		}
+	})
+
+	t.Run("until", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			opts    logsOptions
+			wantErr string
+		}{
+			{"duration window", logsOptions{since: "2h", until: "1h"}, ""},
+			{"timestamp window", logsOptions{since: "2024-01-15T10:00:00Z", until: "2024-01-15T11:00:00Z"}, ""},
+			{"until without since", logsOptions{until: "30m"}, ""},
+			{"since timestamp", logsOptions{since: "2024-01-15T10:00:00+02:00"}, ""},
+			{"until before since", logsOptions{since: "1h", until: "2h"}, "--until ("},
+			{"until timestamp before since", logsOptions{since: "2024-01-15T11:00:00Z", until: "2024-01-15T10:00:00Z"}, "is before --since"},
+			{"invalid until", logsOptions{until: "yesterday"}, "--until must be a valid duration"},
+			{"invalid since timestamp", logsOptions{since: "2024-01-15 10:00"}, "--since must be a valid duration"},
+			{"until with follow", logsOptions{until: "1h", follow: true}, "--until cannot be combined with --follow"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				opts := tt.opts
+				opts.tail = 100
+				opts.format = "text"
+				opts.level = "all"
+				err := validateLogsOptions(&opts)
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("validateLogsOptions() unexpected error: %v", err)
+					}
+					return
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("validateLogsOptions() error = %v, want error containing %q", err, tt.wantErr)
+				}
+			})
+		}
+	})
+
+	t.Run("time format", func(t *testing.T) {
+		valid := &logsOptions{tail: 100, format: "text", level: "all", timeFormat: "2006-01-02T15:04:05.000"}
+		if err := validateLogsOptions(valid); err != nil {
+			t.Errorf("validateLogsOptions() unexpected error: %v", err)
+		}
+
+		invalid := &logsOptions{tail: 100, format: "text", level: "all", timeFormat: "iso"}
+		if err := validateLogsOptions(invalid); err == nil || !strings.Contains(err.Error(), "--time-format") {
+			t.Errorf("validateLogsOptions() error = %v, want invalid --time-format", err)
+		}
+	})
+
+	t.Run("max line size", func(t *testing.T) {
+		for _, size := range []string{"", "64KB", "2048"} {
+			if err := validateLogsOptions(&logsOptions{tail: 100, format: "text", level: "all", maxLineSize: size}); err != nil {
+				t.Errorf("validateLogsOptions() with --max-line-size %q unexpected error: %v", size, err)
+			}
+		}
+		for _, size := range []string{"huge", "0", "-1KB"} {
+			err := validateLogsOptions(&logsOptions{tail: 100, format: "text", level: "all", maxLineSize: size})
+			if err == nil || !strings.Contains(err.Error(), "--max-line-size") {
+				t.Errorf("validateLogsOptions() with --max-line-size %q error = %v, want an invalid size", size, err)
+			}
+		}
+	})
+
+	t.Run("follow new", func(t *testing.T) {
+		if err := validateLogsOptions(&logsOptions{tail: 100, format: "text", level: "all", follow: true, followNew: true}); err != nil {
+			t.Errorf("validateLogsOptions() unexpected error: %v", err)
+		}
+		err := validateLogsOptions(&logsOptions{tail: 100, format: "text", level: "all", followNew: true})
+		if err == nil || !strings.Contains(err.Error(), "--follow-new requires --follow") {
+			t.Errorf("validateLogsOptions() error = %v, want --follow requirement", err)
+		}
+	})
+
+	t.Run("json logs passthrough", func(t *testing.T) {
+		valid := &logsOptions{tail: 100, format: "json", level: "all", passthrough: true}
+		if err := validateLogsOptions(valid); err != nil {
+			t.Errorf("validateLogsOptions() unexpected error: %v", err)
+		}
+
+		ndjson := &logsOptions{tail: 100, format: "ndjson", level: "all", passthrough: true}
+		if err := validateLogsOptions(ndjson); err != nil {
+			t.Errorf("validateLogsOptions() unexpected error with ndjson: %v", err)
+		}
+
+		textFormat := &logsOptions{tail: 100, format: "text", level: "all", passthrough: true}
+		if err := validateLogsOptions(textFormat); err == nil || !strings.Contains(err.Error(), "requires --format json") {
+			t.Errorf("validateLogsOptions() error = %v, want --format json requirement", err)
+		}
+
+		withContext := &logsOptions{tail: 100, format: "json", level: "error", contextLines: 2, passthrough: true}
+		if err := validateLogsOptions(withContext); err == nil || !strings.Contains(err.Error(), "--context") {
+			t.Errorf("validateLogsOptions() error = %v, want --context conflict", err)
+		}
+	})
+
 	t.Run("tail capped value", func(t *testing.T) {
 		opts := &logsOptions{
 			tail:   20000,
@@ -81,6 +188,42 @@ func TestValidateLogsOptions(t *testing.T) {
 		}
 	})
 
+	t.Run("tail all", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			opts     logsOptions
+			wantTail int
+			wantErr  string
+		}{
+			{"--tail-all with --follow is uncapped", logsOptions{tailAll: true, follow: true}, 0, ""},
+			{"--tail -1 with --follow is uncapped", logsOptions{tail: tailAllLines, tailSet: true, follow: true}, 0, ""},
+			{"--tail-all without --follow is capped", logsOptions{tailAll: true, tail: defaultTailLines}, maxTailLines, ""},
+			{"--tail -1 without --follow is capped", logsOptions{tail: tailAllLines, tailSet: true}, maxTailLines, ""},
+			{"--tail-all with --tail", logsOptions{tailAll: true, tail: 50, tailSet: true}, 0, "--tail-all cannot be combined"},
+			{"other negative tail", logsOptions{tail: -2, tailSet: true}, 0, "--tail must be a positive number or -1"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				opts := tt.opts
+				opts.format = "text"
+				opts.level = "all"
+				err := validateLogsOptions(&opts)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Errorf("validateLogsOptions() error = %v, want error containing %q", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("validateLogsOptions() unexpected error: %v", err)
+				}
+				if opts.tail != tt.wantTail || !opts.tailAll {
+					t.Errorf("tail = %d, tailAll = %v, want %d, true", opts.tail, opts.tailAll, tt.wantTail)
+				}
+			})
+		}
+	})
+
 	t.Run("zero tail is valid", func(t *testing.T) {
 		opts := &logsOptions{
 			tail:   0,
@@ -126,6 +269,18 @@ func TestValidateLogsOptions(t *testing.T) {
 		}
 	})
 
+	t.Run("redact patterns", func(t *testing.T) {
+		opts := &logsOptions{tail: 100, format: "text", level: "all", redact: []string{"(bad"}}
+		if err := validateLogsOptions(opts); err == nil || !strings.Contains(err.Error(), "--redact must be a valid regex") {
+			t.Errorf("validateLogsOptions() error = %v, want invalid --redact error", err)
+		}
+
+		opts = &logsOptions{tail: 100, format: "text", level: "all", redact: []string{"secret"}, noRedact: true}
+		if err := validateLogsOptions(opts); err == nil || !strings.Contains(err.Error(), "cannot be combined with --no-redact") {
+			t.Errorf("validateLogsOptions() error = %v, want --redact/--no-redact conflict", err)
+		}
+	})
+
 	t.Run("context negative clamped to zero", func(t *testing.T) {
 		opts := &logsOptions{
 			tail:         100,
@@ -227,8 +382,9 @@ func TestLogsCommandStructure(t *testing.T) {
 
 	t.Run("flags exist", func(t *testing.T) {
 		flags := []string{
-			"follow", "service", "tail", "since", "timestamps",
+			"follow", "service", "tail", "since", "until", "timestamps", "time-format",
 			"no-color", "level", "format", "file", "exclude", "no-builtins", "context",
+			"redact", "no-redact",
 		}
 		for _, flag := range flags {
 			if cmd.Flags().Lookup(flag) == nil {
@@ -3,6 +3,8 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -87,6 +89,66 @@ func TestDisplayLogsText(t *testing.T) {
 	})
 }
 
+func TestDisplayLogsJSONPassthrough(t *testing.T) {
+	original := `{"level":30,"time":1705314645000,"msg":"order placed","orderId":"ord-1","amount":12.50,"user":{"id":42},"tags":["a","b"],"service":"orders-internal"}`
+	logs := []service.LogEntry{
+		service.NewLogEntry("api", original, false),
+		{Service: "web", Level: service.LogLevelInfo, Message: "plain text line", Timestamp: time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)},
+	}
+
+	executor := &logsExecutor{opts: &logsOptions{format: "json", passthrough: true}}
+	var buf bytes.Buffer
+	executor.displayLogs(logs, &buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d: %q", len(lines), buf.String())
+	}
+
+	decode := func(line string) map[string]any {
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.UseNumber()
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			t.Fatalf("Line is not valid JSON: %v (%s)", err, line)
+		}
+		return object
+	}
+
+	// Structured lines keep every original field (numbers unchanged) and gain "service"
+	got := decode(lines[0])
+	want := decode(original)
+	want["service"] = "api"
+	if len(got) != len(want) {
+		t.Errorf("passthrough object has %d fields, want %d: %v", len(got), len(want), got)
+	}
+	for key, value := range want {
+		gotJSON, _ := json.Marshal(got[key])
+		wantJSON, _ := json.Marshal(value)
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("field %q = %s, want %s", key, gotJSON, wantJSON)
+		}
+	}
+	if _, wrapped := got["fields"]; wrapped {
+		t.Error("passthrough object should not be wrapped in a LogEntry")
+	}
+
+	// Plain-text lines are still written as LogEntry objects
+	plain := decode(lines[1])
+	if plain["service"] != "web" || plain["message"] != "plain text line" {
+		t.Errorf("plain-text entry = %v, want LogEntry for web", plain)
+	}
+
+	// Without the flag, structured lines are wrapped as before
+	buf.Reset()
+	executor.opts.passthrough = false
+	executor.displayLogs(logs[:1], &buf)
+	wrapped := decode(strings.TrimSpace(buf.String()))
+	if wrapped["message"] != "order placed" || wrapped["fields"] == nil {
+		t.Errorf("default JSON output = %v, want a wrapped LogEntry", wrapped)
+	}
+}
+
 func TestDisplayLogsJSON(t *testing.T) {
 	now := time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)
 	logs := []service.LogEntry{
@@ -155,6 +217,23 @@ func TestDisplayLogsJSON(t *testing.T) {
 	})
 }
 
+func TestTruncateLogEntry(t *testing.T) {
+	entry := service.LogEntry{Service: "api", Message: "short", Fields: map[string]any{"msg": "short"}}
+	if got := truncateLogEntry(entry, 10); !reflect.DeepEqual(got, entry) {
+		t.Errorf("truncateLogEntry() = %+v, want the entry unchanged", got)
+	}
+
+	entry.Message = "日本語のログ"
+	got := truncateLogEntry(entry, 7)
+	// 7 bytes would cut the third character, so only two are kept
+	if want := "日本…[truncated 12 bytes]"; got.Message != want {
+		t.Errorf("Message = %q, want %q", got.Message, want)
+	}
+	if !got.Truncated || got.Fields != nil {
+		t.Errorf("truncateLogEntry() = %+v, want Truncated and no fields", got)
+	}
+}
+
 func TestColorConstants(t *testing.T) {
 	if colorCyan != "\033[36m" {
 		t.Errorf("colorCyan = %q, expected %q", colorCyan, "\033[36m")
@@ -180,3 +259,96 @@ func BenchmarkDisplayLogsText(b *testing.B) {
 		displayLogsText(logs, &buf, true, false)
 	}
 }
+
+func TestWriteLogsText_Highlight(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)
+	logs := []service.LogEntry{
+		{Service: "api", Level: service.LogLevelInfo, Message: "GET /orders req-abc123 200", Timestamp: now},
+		{Service: "api", Level: service.LogLevelInfo, Message: "no match here", Timestamp: now},
+	}
+	highlights := compileHighlightsForTest(t, `req-[a-z0-9]+`)
+
+	t.Run("wraps matches and keeps non-matching lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		writeLogsText(logs, &buf, textDisplayOptions{highlights: highlights})
+		output := buf.String()
+
+		if !strings.Contains(output, colorHighlight+"req-abc123"+colorReset) {
+			t.Errorf("Output should highlight match, got %q", output)
+		}
+		if !strings.Contains(output, "no match here") {
+			t.Error("Non-matching lines should not be removed")
+		}
+	})
+
+	t.Run("no-color disables highlighting", func(t *testing.T) {
+		var buf bytes.Buffer
+		writeLogsText(logs, &buf, textDisplayOptions{noColor: true, highlights: highlights})
+		if strings.Contains(buf.String(), "\033[") {
+			t.Error("Output should not contain ANSI codes in no-color mode")
+		}
+	})
+}
+
+func TestHighlightMatches(t *testing.T) {
+	patterns := compileHighlightsForTest(t, "foo", "oba")
+
+	// Overlapping matches "foo" and "oba" merge into a single highlighted span
+	got := highlightMatches("xfoobarx", patterns, colorRed)
+	want := "x" + colorHighlight + "fooba" + colorReset + colorRed + "rx"
+	if got != want {
+		t.Errorf("highlightMatches() = %q, want %q", got, want)
+	}
+
+	if got := highlightMatches("nothing", patterns, ""); got != "nothing" {
+		t.Errorf("highlightMatches() without match = %q, want unchanged", got)
+	}
+}
+
+func compileHighlightsForTest(t *testing.T, patterns ...string) []*regexp.Regexp {
+	t.Helper()
+	_, compiled, err := compileGrepAndHighlight("", patterns)
+	if err != nil {
+		t.Fatalf("compileGrepAndHighlight() error = %v", err)
+	}
+	return compiled
+}
+
+func TestWriteLogsText_TimeFormat(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 45, 123000000, time.UTC)
+	logs := []service.LogEntry{{Service: "api", Message: "ready", Timestamp: ts}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "[10:30:45.123] [api] ready"},
+		{"rfc3339", "[2024-01-15T10:30:45Z] [api] ready"},
+		{"RFC3339", "[2024-01-15T10:30:45Z] [api] ready"},
+		{"short", "[10:30:45] [api] ready"},
+		{"unix", "[1705314645] [api] ready"},
+		{"2006-01-02 15:04", "[2024-01-15 10:30] [api] ready"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			layout, err := resolveTimeFormat(tt.format)
+			if err != nil {
+				t.Fatalf("resolveTimeFormat(%q) error = %v", tt.format, err)
+			}
+			var buf bytes.Buffer
+			writeLogsText(logs, &buf, textDisplayOptions{timestamps: true, timeLayout: layout, noColor: true})
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTimeFormat_Invalid(t *testing.T) {
+	for _, format := range []string{"iso", "hh:mm:ss"} {
+		if _, err := resolveTimeFormat(format); err == nil || !strings.Contains(err.Error(), "--time-format must be") {
+			t.Errorf("resolveTimeFormat(%q) error = %v, want invalid format error", format, err)
+		}
+	}
+}
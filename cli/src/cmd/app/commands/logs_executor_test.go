@@ -131,6 +131,34 @@ func TestLogsExecutor_ValidateServiceFilter(t *testing.T) {
 	})
 }
 
+func TestParseLogTimeBound(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"90m", time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), false},
+		{"2024-01-15T09:15:00Z", time.Date(2024, 1, 15, 9, 15, 0, 0, time.UTC), false},
+		{"2024-01-15T10:15:00.5+01:00", time.Date(2024, 1, 15, 9, 15, 0, 500000000, time.UTC), false},
+		{"2024-01-15", time.Time{}, true},
+		{"soon", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLogTimeBound(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLogTimeBound(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseLogTimeBound(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLogsExecutor_ParseSinceTime(t *testing.T) {
 	opts := &logsOptions{}
 	executor := &logsExecutor{opts: opts}
@@ -251,7 +279,7 @@ func TestLogsExecutor_CollectLogs(t *testing.T) {
 		executor := &logsExecutor{opts: opts}
 		mockLM := newMockLogManager()
 
-		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"api"}, mockLM, time.Time{})
+		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"api"}, mockLM, time.Time{}, time.Time{})
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -266,7 +294,7 @@ func TestLogsExecutor_CollectLogs(t *testing.T) {
 		mockLM := newMockLogManager()
 
 		since := time.Date(2024, 1, 15, 10, 30, 45, 150000000, time.UTC)
-		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"api"}, mockLM, since)
+		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"api"}, mockLM, since, time.Time{})
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -275,6 +303,45 @@ func TestLogsExecutor_CollectLogs(t *testing.T) {
 		}
 	})
 
+	t.Run("until reads past tail", func(t *testing.T) {
+		opts := &logsOptions{tail: 1, until: "2024-01-15T10:30:45.150Z"}
+		executor := &logsExecutor{opts: opts}
+		mockLM := newMockLogManager()
+
+		until := time.Date(2024, 1, 15, 10, 30, 45, 150000000, time.UTC)
+		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"api"}, mockLM, time.Time{}, until)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(logs) != 1 || logs[0].Message != "Message 1" {
+			t.Errorf("Expected only Message 1 before until, got %+v", logs)
+		}
+	})
+
+	t.Run("merges services in timestamp order", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(logsDir, "web.log"), []byte(`[2024-01-15 10:30:45.050] [INFO] [OUT] Web 1
+[2024-01-15 10:30:45.150] [INFO] [OUT] Web 2
+[2024-01-15 10:30:45.200] [INFO] [OUT] Web 3
+`), 0644)
+		opts := &logsOptions{tail: 100}
+		executor := &logsExecutor{opts: opts}
+		mockLM := newMockLogManager()
+
+		logs, err := executor.collectLogs(context.Background(), tmpDir, []string{"web", "api", "missing"}, mockLM, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var got []string
+		for _, entry := range logs {
+			got = append(got, entry.Message)
+		}
+		// Equal timestamps keep the order of the target services
+		want := []string{"Web 1", "Message 1", "Web 2", "Web 3", "Message 2"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
 	t.Run("context cancellation", func(t *testing.T) {
 		opts := &logsOptions{tail: 100}
 		executor := &logsExecutor{opts: opts}
@@ -283,7 +350,7 @@ func TestLogsExecutor_CollectLogs(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
-		_, err := executor.collectLogs(ctx, tmpDir, []string{"api"}, mockLM, time.Time{})
+		_, err := executor.collectLogs(ctx, tmpDir, []string{"api"}, mockLM, time.Time{}, time.Time{})
 		if err == nil {
 			t.Error("Expected context cancellation error")
 		}
@@ -328,7 +395,74 @@ func TestLogsExecutor_BuildLogFilterInternal(t *testing.T) {
 
 // ==================== Execute tests ====================
 
+// useFastConnect shortens the retries of the initial dashboard connection for the duration
+// of a test.
+func useFastConnect(t *testing.T) {
+	t.Helper()
+	delay := logsConnectRetryDelay
+	logsConnectRetryDelay = time.Millisecond
+	t.Cleanup(func() {
+		logsConnectRetryDelay = delay
+	})
+}
+
+func TestLogsExecutor_ConnectDashboardWithRetry(t *testing.T) {
+	useFastConnect(t)
+
+	t.Run("connects once the dashboard is up", func(t *testing.T) {
+		attempts := 0
+		executor := newLogsExecutorForTest(
+			func(ctx context.Context, projectDir string) (DashboardClient, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, errors.New("dashboard not running")
+				}
+				if attempts == 2 {
+					return &mockDashboardClient{pingErr: errors.New("connection refused")}, nil
+				}
+				return &mockDashboardClient{}, nil
+			},
+			nil, nil, &bytes.Buffer{}, &logsOptions{},
+		)
+
+		client, err := executor.connectDashboardWithRetry(context.Background(), t.TempDir())
+		if err != nil || client == nil {
+			t.Fatalf("connectDashboardWithRetry() = %v, %v, want a client", client, err)
+		}
+		if attempts != 3 {
+			t.Errorf("attempts = %d, want 3", attempts)
+		}
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		attempts := 0
+		executor := newLogsExecutorForTest(
+			func(ctx context.Context, projectDir string) (DashboardClient, error) {
+				attempts++
+				return nil, errors.New("dashboard not running")
+			},
+			nil, nil, &bytes.Buffer{}, &logsOptions{},
+		)
+
+		var err error
+		stderr := captureStderr(t, func() {
+			t.Setenv("AZD_APP_DEBUG", "true")
+			_, err = executor.connectDashboardWithRetry(context.Background(), t.TempDir())
+		})
+		if err == nil || !strings.Contains(err.Error(), "dashboard not running") {
+			t.Errorf("connectDashboardWithRetry() error = %v, want the last failure", err)
+		}
+		if attempts != logsConnectAttempts {
+			t.Errorf("attempts = %d, want %d", attempts, logsConnectAttempts)
+		}
+		if !strings.Contains(stderr, "[DEBUG] Dashboard connection attempt 3/3 failed") {
+			t.Errorf("stderr = %q, want a debug line per failed attempt", stderr)
+		}
+	})
+}
+
 func TestLogsExecutor_Execute(t *testing.T) {
+	useFastConnect(t)
 	tmpDir, _ := os.MkdirTemp("", "logs_test_*")
 	defer os.RemoveAll(tmpDir)
 
@@ -591,3 +725,217 @@ func TestLogsExecutor_Execute(t *testing.T) {
 		}
 	})
 }
+
+func TestLogsExecutor_NoPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	logsDir := filepath.Join(tmpDir, ".azure", "logs")
+	if err := os.MkdirAll(logsDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"api", "web"} {
+		content := fmt.Sprintf("[2024-01-15 10:30:45.100] [INFO] [OUT] %s message\n", name)
+		if err := os.WriteFile(filepath.Join(logsDir, name+".log"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		noPrefix    bool
+		noPrefixSet bool
+		wantPrefix  bool
+	}{
+		{name: "single service omits prefix", args: []string{"api"}, wantPrefix: false},
+		{name: "multiple services keep prefix", wantPrefix: true},
+		{name: "explicit --no-prefix=false keeps prefix for single service", args: []string{"api"}, noPrefixSet: true, wantPrefix: true},
+		{name: "explicit --no-prefix omits prefix for multiple services", noPrefix: true, noPrefixSet: true, wantPrefix: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := &logsOptions{tail: 100, level: "all", format: "text", noColor: true, noPrefix: tt.noPrefix, noPrefixSet: tt.noPrefixSet}
+			executor := newLogsExecutorForTest(
+				func(ctx context.Context, projectDir string) (DashboardClient, error) {
+					return &mockDashboardClient{
+						services: []*serviceinfo.ServiceInfo{{Name: "api"}, {Name: "web"}},
+					}, nil
+				},
+				func(projectDir string) LogManagerInterface {
+					return newMockLogManager()
+				},
+				func() (string, error) { return tmpDir, nil },
+				&buf,
+				opts,
+			)
+
+			if err := executor.execute(context.Background(), tt.args); err != nil {
+				t.Fatalf("execute() error = %v", err)
+			}
+			output := buf.String()
+			if !strings.Contains(output, "api message") {
+				t.Fatalf("Output should contain the api log line, got: %s", output)
+			}
+			if got := strings.Contains(output, "[api] "); got != tt.wantPrefix {
+				t.Errorf("prefix present = %v, want %v; output: %s", got, tt.wantPrefix, output)
+			}
+		})
+	}
+}
+
+func TestLogsExecutor_MaxLineSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	logsDir := filepath.Join(tmpDir, ".azure", "logs")
+	if err := os.MkdirAll(logsDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	blob := `{"dump":"` + strings.Repeat("x", 200) + `"}`
+	content := "[2024-01-15 10:30:45.100] [INFO] [OUT] " + blob + "\n[2024-01-15 10:30:45.200] [INFO] [OUT] short line\n"
+	if err := os.WriteFile(filepath.Join(logsDir, "api.log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(format string) string {
+		var buf bytes.Buffer
+		opts := &logsOptions{tail: 100, level: "all", format: format, noColor: true, maxLineSize: "100B"}
+		executor := newLogsExecutorForTest(
+			func(ctx context.Context, projectDir string) (DashboardClient, error) {
+				return &mockDashboardClient{services: []*serviceinfo.ServiceInfo{{Name: "api"}}}, nil
+			},
+			func(projectDir string) LogManagerInterface {
+				return newMockLogManager()
+			},
+			func() (string, error) { return tmpDir, nil },
+			&buf,
+			opts,
+		)
+		if err := executor.execute(context.Background(), []string{"api"}); err != nil {
+			t.Fatalf("execute() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	// The file line includes its 39-byte timestamp, level and stream prefix
+	wantMarker := fmt.Sprintf("…[truncated %d bytes]", len(blob)+39-100)
+	if output := run("text"); !strings.Contains(output, wantMarker) || !strings.Contains(output, "short line") {
+		t.Errorf("text output should truncate the long line with %q and keep the short one, got: %s", wantMarker, output)
+	}
+
+	lines := strings.Split(strings.TrimSpace(run("json")), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d", len(lines))
+	}
+	var long, short service.LogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &long); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &short); err != nil {
+		t.Fatal(err)
+	}
+	if !long.Truncated || !strings.HasSuffix(long.Message, wantMarker) {
+		t.Errorf("long entry = %+v, want truncated with %q", long, wantMarker)
+	}
+	if short.Truncated || strings.Contains(lines[1], "truncated") {
+		t.Errorf("short entry JSON = %s, want no truncated field", lines[1])
+	}
+}
+
+func TestLogsExecutor_Redaction(t *testing.T) {
+	tmpDir := t.TempDir()
+	logsDir := filepath.Join(tmpDir, ".azure", "logs")
+	if err := os.MkdirAll(logsDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "[2024-01-15 10:30:45.100] [INFO] [OUT] connecting with Password=hunter2 session=abc123\n"
+	if err := os.WriteFile(filepath.Join(logsDir, "api.log"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		redact   []string
+		noRedact bool
+		want     []string
+		wantNot  []string
+	}{
+		{name: "text output", format: "text", want: []string{"Password=***"}, wantNot: []string{"hunter2"}},
+		{name: "json output", format: "json", want: []string{"Password=***"}, wantNot: []string{"hunter2"}},
+		{name: "custom pattern", format: "text", redact: []string{`session=(\w+)`}, want: []string{"session=***"}, wantNot: []string{"hunter2", "abc123"}},
+		{name: "no-redact", format: "text", noRedact: true, want: []string{"Password=hunter2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := &logsOptions{tail: 100, level: "all", format: tt.format, noColor: true, redact: tt.redact, noRedact: tt.noRedact}
+			executor := newLogsExecutorForTest(
+				func(ctx context.Context, projectDir string) (DashboardClient, error) {
+					return &mockDashboardClient{services: []*serviceinfo.ServiceInfo{{Name: "api"}}}, nil
+				},
+				func(projectDir string) LogManagerInterface {
+					return newMockLogManager()
+				},
+				func() (string, error) { return tmpDir, nil },
+				&buf,
+				opts,
+			)
+
+			if err := executor.execute(context.Background(), nil); err != nil {
+				t.Fatalf("execute() error = %v", err)
+			}
+			output := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+			for _, wantNot := range tt.wantNot {
+				if strings.Contains(output, wantNot) {
+					t.Errorf("Output should not contain %q, got: %s", wantNot, output)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCollectLogs(b *testing.B) {
+	tmpDir := b.TempDir()
+	logsDir := filepath.Join(tmpDir, ".azure", "logs")
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		b.Fatal(err)
+	}
+
+	// 20 services with 5000 lines each, read in full as with --tail-all
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.Local)
+	services := make([]string, 20)
+	for i := range services {
+		services[i] = fmt.Sprintf("svc%02d", i)
+		var content strings.Builder
+		for line := 0; line < 5000; line++ {
+			ts := base.Add(time.Duration(line*len(services)+i) * time.Millisecond)
+			fmt.Fprintf(&content, "[%s] [INFO] [OUT] request %d handled in 12ms\n", ts.Format("2006-01-02 15:04:05.000"), line)
+		}
+		if err := os.WriteFile(filepath.Join(logsDir, services[i]+".log"), []byte(content.String()), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, concurrency := range []int{1, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			original := maxConcurrentLogReads
+			maxConcurrentLogReads = concurrency
+			defer func() { maxConcurrentLogReads = original }()
+
+			executor := &logsExecutor{opts: &logsOptions{tail: 0}}
+			mockLM := newMockLogManager()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := executor.collectLogs(context.Background(), tmpDir, services, mockLM, time.Time{}, time.Time{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
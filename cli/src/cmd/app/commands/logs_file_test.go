@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -33,7 +34,7 @@ func TestReadLogsFromFile(t *testing.T) {
 	}
 
 	t.Run("read all logs with tail", func(t *testing.T) {
-		logs, err := readLogsFromFile(tmpDir, "api", 100, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "api", 100, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("readLogsFromFile() error: %v", err)
 		}
@@ -43,7 +44,7 @@ func TestReadLogsFromFile(t *testing.T) {
 	})
 
 	t.Run("read with tail limit", func(t *testing.T) {
-		logs, err := readLogsFromFile(tmpDir, "api", 3, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "api", 3, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("readLogsFromFile() error: %v", err)
 		}
@@ -57,7 +58,7 @@ func TestReadLogsFromFile(t *testing.T) {
 
 	t.Run("read with since filter", func(t *testing.T) {
 		since := time.Date(2024, 1, 15, 10, 30, 45, 250000000, time.UTC)
-		logs, err := readLogsFromFile(tmpDir, "api", 100, since)
+		logs, err := readLogsFromFile(tmpDir, "api", 100, since, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("readLogsFromFile() error: %v", err)
 		}
@@ -67,14 +68,14 @@ func TestReadLogsFromFile(t *testing.T) {
 	})
 
 	t.Run("nonexistent service", func(t *testing.T) {
-		_, err := readLogsFromFile(tmpDir, "nonexistent", 100, time.Time{})
+		_, err := readLogsFromFile(tmpDir, "nonexistent", 100, time.Time{}, time.Time{}, maxLogLineSize)
 		if err == nil {
 			t.Error("Expected error for nonexistent service")
 		}
 	})
 
 	t.Run("zero tail", func(t *testing.T) {
-		logs, err := readLogsFromFile(tmpDir, "api", 0, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "api", 0, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -85,9 +86,9 @@ func TestReadLogsFromFile(t *testing.T) {
 
 	t.Run("since filters all", func(t *testing.T) {
 		since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-		_, err := readLogsFromFile(tmpDir, "api", 100, since)
+		_, err := readLogsFromFile(tmpDir, "api", 100, since, time.Time{}, maxLogLineSize)
 		if err == nil {
-			logs, _ := readLogsFromFile(tmpDir, "api", 100, since)
+			logs, _ := readLogsFromFile(tmpDir, "api", 100, since, time.Time{}, maxLogLineSize)
 			if len(logs) != 0 {
 				t.Errorf("Expected 0 entries (all filtered by since), got %d", len(logs))
 			}
@@ -95,6 +96,29 @@ func TestReadLogsFromFile(t *testing.T) {
 	})
 }
 
+func TestReadLogsFromFile_ConfiguredLogFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYaml := "name: test\nservices:\n  api:\n    project: .\n    logFile: tail/api.log\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "tail"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	logContent := "[2024-01-15 10:30:45.100] [INFO] [OUT] From the configured file\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "tail", "api.log"), []byte(logContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := readLogsFromFile(tmpDir, "api", 100, time.Time{}, time.Time{}, maxLogLineSize)
+	if err != nil {
+		t.Fatalf("readLogsFromFile() error: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Message != "From the configured file" {
+		t.Errorf("readLogsFromFile() = %+v, want the entry from tail/api.log", logs)
+	}
+}
+
 func TestReadLogsFromRotatedFiles(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "logs_test_*")
 	if err != nil {
@@ -128,7 +152,7 @@ func TestReadLogsFromRotatedFiles(t *testing.T) {
 	}
 
 	t.Run("read from all rotated files", func(t *testing.T) {
-		logs, err := readLogsFromFile(tmpDir, "api", 100, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "api", 100, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("readLogsFromFile() error: %v", err)
 		}
@@ -144,7 +168,7 @@ func TestReadLogsFromRotatedFiles(t *testing.T) {
 	})
 
 	t.Run("tail limit across rotated files", func(t *testing.T) {
-		logs, err := readLogsFromFile(tmpDir, "api", 3, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "api", 3, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("readLogsFromFile() error: %v", err)
 		}
@@ -156,6 +180,54 @@ func TestReadLogsFromRotatedFiles(t *testing.T) {
 		}
 	})
 
+	t.Run("since across the rotation boundary", func(t *testing.T) {
+		since := time.Date(2024, 1, 15, 10, 30, 43, 0, time.UTC)
+		logs, err := readLogsFromFile(tmpDir, "api", 100, since, time.Time{}, maxLogLineSize)
+		if err != nil {
+			t.Fatalf("readLogsFromFile() error: %v", err)
+		}
+		if len(logs) != 3 || !strings.Contains(logs[0].Message, "Middle entry 2") {
+			t.Errorf("Expected Middle entry 2 and both current entries, got %+v", logs)
+		}
+	})
+
+	t.Run("tail applies within until", func(t *testing.T) {
+		until := time.Date(2024, 1, 15, 10, 30, 43, 0, time.UTC)
+		logs, err := readLogsFromFile(tmpDir, "api", 2, time.Time{}, until, maxLogLineSize)
+		if err != nil {
+			t.Fatalf("readLogsFromFile() error: %v", err)
+		}
+		if len(logs) != 2 || !strings.Contains(logs[0].Message, "Middle entry 1") || !strings.Contains(logs[1].Message, "Middle entry 2") {
+			t.Errorf("Expected both middle entries, got %+v", logs)
+		}
+	})
+
+	t.Run("more rotated files than the default", func(t *testing.T) {
+		files := []string{"many.log.3", "many.log.2", "many.log.1", "many.log"}
+		for i, name := range files {
+			line := fmt.Sprintf("[2024-01-15 10:30:%02d.000] [INFO] [OUT] %s\n", 40+i, name)
+			if err := os.WriteFile(filepath.Join(logsDir, name), []byte(line), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// A service whose name looks like a rotated file isn't read as one
+		if err := os.WriteFile(filepath.Join(logsDir, "many.log.backup"), []byte("[2024-01-15 10:30:50.000] [INFO] [OUT] Other\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		logs, err := readLogsFromFile(tmpDir, "many", 100, time.Time{}, time.Time{}, maxLogLineSize)
+		if err != nil {
+			t.Fatalf("readLogsFromFile() error: %v", err)
+		}
+		var messages []string
+		for _, entry := range logs {
+			messages = append(messages, entry.Message)
+		}
+		if !reflect.DeepEqual(messages, files) {
+			t.Errorf("messages = %v, want %v", messages, files)
+		}
+	})
+
 	t.Run("partial rotated files", func(t *testing.T) {
 		content1 := `[2024-01-15 10:30:44.000] [INFO] [OUT] Backup entry
 `
@@ -168,7 +240,7 @@ func TestReadLogsFromRotatedFiles(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		logs, err := readLogsFromFile(tmpDir, "partial", 100, time.Time{})
+		logs, err := readLogsFromFile(tmpDir, "partial", 100, time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -186,7 +258,7 @@ func TestReadSingleLogFile(t *testing.T) {
 	defer os.RemoveAll(tmpDir)
 
 	t.Run("file not found", func(t *testing.T) {
-		_, err := readSingleLogFile(filepath.Join(tmpDir, "nonexistent.log"), "api", time.Time{})
+		_, err := readSingleLogFile(filepath.Join(tmpDir, "nonexistent.log"), "api", time.Time{}, time.Time{}, maxLogLineSize)
 		if err == nil {
 			t.Error("Expected error for nonexistent file")
 		}
@@ -197,7 +269,7 @@ func TestReadSingleLogFile(t *testing.T) {
 		if err := os.WriteFile(emptyFile, []byte(""), 0644); err != nil {
 			t.Fatal(err)
 		}
-		entries, err := readSingleLogFile(emptyFile, "api", time.Time{})
+		entries, err := readSingleLogFile(emptyFile, "api", time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -217,7 +289,7 @@ malformed again
 		if err := os.WriteFile(badFile, []byte(content), 0644); err != nil {
 			t.Fatal(err)
 		}
-		entries, err := readSingleLogFile(badFile, "api", time.Time{})
+		entries, err := readSingleLogFile(badFile, "api", time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -233,7 +305,7 @@ malformed again
 		if err := os.WriteFile(longFile, []byte(content), 0644); err != nil {
 			t.Fatal(err)
 		}
-		entries, err := readSingleLogFile(longFile, "api", time.Time{})
+		entries, err := readSingleLogFile(longFile, "api", time.Time{}, time.Time{}, maxLogLineSize)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -245,16 +317,27 @@ malformed again
 		}
 	})
 
-	t.Run("line exceeds max buffer size causes scanner error", func(t *testing.T) {
+	t.Run("line exceeds max line size is truncated", func(t *testing.T) {
 		hugeFile := filepath.Join(tmpDir, "huge.log")
+		prefix := "[2024-01-15 10:30:45.123] [INFO] [OUT] "
 		hugeMsg := strings.Repeat("x", maxLogLineSize+1000)
-		content := fmt.Sprintf("[2024-01-15 10:30:45.123] [INFO] [OUT] %s", hugeMsg)
+		content := prefix + hugeMsg + "\n" + prefix + "next line\n"
 		if err := os.WriteFile(hugeFile, []byte(content), 0644); err != nil {
 			t.Fatal(err)
 		}
-		_, err := readSingleLogFile(hugeFile, "api", time.Time{})
-		if err == nil {
-			t.Error("Expected error for line exceeding max buffer size")
+		entries, err := readSingleLogFile(hugeFile, "api", time.Time{}, time.Time{}, maxLogLineSize)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 entries, got %d", len(entries))
+		}
+		wantMarker := fmt.Sprintf("…[truncated %d bytes]", len(prefix)+1000)
+		if !entries[0].Truncated || !strings.HasSuffix(entries[0].Message, wantMarker) {
+			t.Errorf("first entry = %q… (truncated %v), want it to end with %q", entries[0].Message[:20], entries[0].Truncated, wantMarker)
+		}
+		if entries[1].Truncated || entries[1].Message != "next line" {
+			t.Errorf("second entry = %+v, want the next line intact", entries[1])
 		}
 	})
 }
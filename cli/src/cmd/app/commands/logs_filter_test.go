@@ -265,3 +265,141 @@ func BenchmarkFilterLogsByLevel(b *testing.B) {
 		_ = filterLogsByLevel(logs, service.LogLevelInfo)
 	}
 }
+
+func TestParseFieldFilters(t *testing.T) {
+	filters, err := parseFieldFilters([]string{"userId=42", "route=/api/orders?x=1"})
+	if err != nil {
+		t.Fatalf("parseFieldFilters() error = %v", err)
+	}
+	if filters["userId"] != "42" || filters["route"] != "/api/orders?x=1" {
+		t.Errorf("parseFieldFilters() = %v", filters)
+	}
+
+	for _, invalid := range []string{"noequals", "=value"} {
+		if _, err := parseFieldFilters([]string{invalid}); err == nil {
+			t.Errorf("parseFieldFilters(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestFilterLogsByFields(t *testing.T) {
+	logs := []service.LogEntry{
+		service.NewLogEntry("api", `{"level":"info","msg":"a","userId":42}`, false),
+		service.NewLogEntry("api", `{"level":"info","msg":"b","userId":7}`, false),
+		service.NewLogEntry("api", "plain text line", false),
+	}
+
+	filtered := filterLogsByFields(logs, map[string]string{"userId": "42"})
+	if len(filtered) != 1 || filtered[0].Message != "a" {
+		t.Errorf("filterLogsByFields() = %v, want only entry 'a'", filtered)
+	}
+
+	if got := filterLogsByFields(logs, nil); len(got) != len(logs) {
+		t.Errorf("filterLogsByFields() with no filters returned %d entries, want %d", len(got), len(logs))
+	}
+}
+
+func TestFilterLogsByGrep(t *testing.T) {
+	logs := []service.LogEntry{
+		{Service: "api", Message: "GET /orders 200"},
+		{Service: "api", Message: "GET /health 200"},
+		{Service: "api", Message: "POST /orders 201"},
+	}
+
+	grep, _, err := compileGrepAndHighlight("orders", nil)
+	if err != nil {
+		t.Fatalf("compileGrepAndHighlight() error = %v", err)
+	}
+
+	filtered := filterLogsByGrep(logs, grep)
+	if len(filtered) != 2 {
+		t.Errorf("filterLogsByGrep() returned %d entries, want 2", len(filtered))
+	}
+
+	if got := filterLogsByGrep(logs, nil); len(got) != len(logs) {
+		t.Errorf("filterLogsByGrep() with nil pattern returned %d entries, want %d", len(got), len(logs))
+	}
+}
+
+func TestFilterLogsByInclude(t *testing.T) {
+	logs := []service.LogEntry{
+		{Service: "api", Message: "api.orders GET /orders 200"},
+		{Service: "api", Message: "API.health GET /health 200"},
+		{Service: "web", Message: "web.render /"},
+		{Service: "worker", Message: "job done"},
+	}
+
+	include, err := compileIncludePatterns(`api\., job`)
+	if err != nil {
+		t.Fatalf("compileIncludePatterns() error = %v", err)
+	}
+
+	filtered := filterLogsByInclude(logs, include)
+	if len(filtered) != 3 || filtered[2].Service != "worker" {
+		t.Errorf("filterLogsByInclude() = %+v, want the api lines (case-insensitive) and the worker line", filtered)
+	}
+
+	if got := filterLogsByInclude(logs, nil); len(got) != len(logs) {
+		t.Errorf("filterLogsByInclude() with no patterns returned %d entries, want %d", len(got), len(logs))
+	}
+
+	if _, err := compileIncludePatterns("api,[unclosed"); err == nil {
+		t.Error("expected error for invalid --include pattern")
+	}
+}
+
+func TestShouldDisplayEntry_IncludeThenExclude(t *testing.T) {
+	e := newLogsExecutor(&logsOptions{})
+	var err error
+	if e.includePatterns, err = compileIncludePatterns(`api\.`); err != nil {
+		t.Fatal(err)
+	}
+	exclude, err := service.NewLogFilter([]string{"health"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		entry service.LogEntry
+		level service.LogLevel
+		want  bool
+	}{
+		{service.LogEntry{Message: "api.orders 200", Level: service.LogLevelInfo}, LogLevelAll, true},
+		{service.LogEntry{Message: "api.health 200", Level: service.LogLevelInfo}, LogLevelAll, false},
+		{service.LogEntry{Message: "web.render", Level: service.LogLevelInfo}, LogLevelAll, false},
+		{service.LogEntry{Message: "api.orders failed", Level: service.LogLevelError}, service.LogLevelError, true},
+		{service.LogEntry{Message: "api.orders 200", Level: service.LogLevelInfo}, service.LogLevelError, false},
+	}
+	for _, tt := range tests {
+		if got := e.shouldDisplayEntry(tt.entry, tt.level, exclude); got != tt.want {
+			t.Errorf("shouldDisplayEntry(%q, level %v) = %v, want %v", tt.entry.Message, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestCompileGrepAndHighlight_InvalidPattern(t *testing.T) {
+	if _, _, err := compileGrepAndHighlight("[unclosed", nil); err == nil {
+		t.Error("expected error for invalid --grep pattern")
+	}
+	if _, _, err := compileGrepAndHighlight("", []string{"(bad"}); err == nil {
+		t.Error("expected error for invalid --highlight pattern")
+	}
+}
+
+func TestBuildLogRedactor(t *testing.T) {
+	redactor, err := buildLogRedactor(&logsOptions{redact: []string{"", `ghp_\w+`}})
+	if err != nil {
+		t.Fatalf("buildLogRedactor() error = %v", err)
+	}
+	if got := redactor.Redact("Password=hunter2 ghp_abc"); got != "Password=*** ***" {
+		t.Errorf("Redact() = %q, want built-in and --redact patterns masked", got)
+	}
+
+	if redactor, err := buildLogRedactor(&logsOptions{noRedact: true}); err != nil || redactor != nil {
+		t.Errorf("buildLogRedactor() with --no-redact = %v, %v; want nil, nil", redactor, err)
+	}
+
+	if _, err := buildLogRedactor(&logsOptions{redact: []string{"(bad"}}); err == nil {
+		t.Error("expected error for invalid --redact pattern")
+	}
+}
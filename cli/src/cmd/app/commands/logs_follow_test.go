@@ -1,14 +1,22 @@
 package commands
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/jongio/azd-app/cli/src/internal/constants"
 	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
 )
 
 // newTestExecutor creates a logsExecutor for testing with the given options.
@@ -74,6 +82,37 @@ func TestLogsExecutor_FollowLogsViaDashboard(t *testing.T) {
 		}
 	})
 
+	t.Run("redacts secrets", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: "json"})
+		redactor, err := service.NewLogRedactorWithBuiltins(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		executor.redactor = redactor
+
+		mockClient := &mockDashboardClient{
+			logEntries: []service.LogEntry{
+				{Service: "api", Level: service.LogLevelInfo, Message: "Authorization: Bearer abc.def", Timestamp: time.Now()},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- executor.followLogsViaDashboard(ctx, mockClient, nil, LogLevelAll, nil, &buf)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		<-done
+
+		output := buf.String()
+		if strings.Contains(output, "abc.def") || !strings.Contains(output, "Bearer ***") {
+			t.Errorf("Streamed logs should be redacted, got: %s", output)
+		}
+	})
+
 	t.Run("filters by level", func(t *testing.T) {
 		var buf bytes.Buffer
 		sigChan := make(chan os.Signal, 1)
@@ -168,6 +207,263 @@ func TestLogsExecutor_FollowLogsViaDashboard(t *testing.T) {
 	})
 }
 
+// droppingDashboardClient streams its entries and then drops the connection.
+type droppingDashboardClient struct {
+	mockDashboardClient
+}
+
+func (m *droppingDashboardClient) StreamLogs(ctx context.Context, serviceName string, logs chan<- service.LogEntry) error {
+	for _, entry := range m.logEntries {
+		select {
+		case logs <- entry:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.New("connection reset")
+}
+
+// useFastReconnect shortens the follow-mode reconnect backoff for the duration of a test.
+func useFastReconnect(t *testing.T) {
+	t.Helper()
+	initial, maxDelay := logsReconnectInitialDelay, logsReconnectMaxDelay
+	logsReconnectInitialDelay, logsReconnectMaxDelay = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() {
+		logsReconnectInitialDelay, logsReconnectMaxDelay = initial, maxDelay
+	})
+}
+
+// captureStderr redirects os.Stderr while fn runs and returns what was written.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	fn()
+
+	w.Close()
+	os.Stderr = oldStderr
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read stderr: %v", err)
+	}
+	return string(data)
+}
+
+func TestLogsExecutor_FollowLogsViaDashboard_Reconnect(t *testing.T) {
+	useFastReconnect(t)
+	now := time.Now()
+
+	t.Run("resumes streaming after the connection drops", func(t *testing.T) {
+		var buf bytes.Buffer
+		sigChan := make(chan os.Signal, 1)
+		executor := newTestExecutor(&buf, sigChan, &logsOptions{format: "text", noColor: true})
+
+		var factoryCalls atomic.Int32
+		reconnected := &mockDashboardClient{
+			logEntries: []service.LogEntry{
+				{Service: "api", Level: service.LogLevelInfo, Message: "after restart", Timestamp: now},
+			},
+		}
+		executor.getWorkingDir = func() (string, error) { return t.TempDir(), nil }
+		executor.dashboardClientFactory = func(ctx context.Context, projectDir string) (DashboardClient, error) {
+			// First attempt fails while the dashboard is still restarting
+			if factoryCalls.Add(1) == 1 {
+				return nil, errors.New("dashboard not running")
+			}
+			return reconnected, nil
+		}
+
+		dropping := &droppingDashboardClient{mockDashboardClient{
+			logEntries: []service.LogEntry{
+				{Service: "api", Level: service.LogLevelInfo, Message: "before restart", Timestamp: now},
+			},
+		}}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var err error
+		stderr := captureStderr(t, func() {
+			done := make(chan error)
+			go func() {
+				done <- executor.followLogsViaDashboard(ctx, dropping, []string{"api"}, LogLevelAll, nil, &buf)
+			}()
+			time.Sleep(200 * time.Millisecond)
+			cancel()
+			err = <-done
+		})
+
+		if err != nil {
+			t.Errorf("Expected nil error, got: %v", err)
+		}
+		output := buf.String()
+		if !strings.Contains(output, "before restart") || !strings.Contains(output, "after restart") {
+			t.Errorf("Should contain logs from before and after reconnect, got: %s", output)
+		}
+		if factoryCalls.Load() < 2 {
+			t.Errorf("Expected factory to be retried, got %d calls", factoryCalls.Load())
+		}
+		if !strings.Contains(stderr, "reconnecting") {
+			t.Errorf("Expected reconnecting notice on stderr, got: %q", stderr)
+		}
+	})
+
+	t.Run("prints nothing in JSON mode", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: "json"})
+		executor.getWorkingDir = func() (string, error) { return t.TempDir(), nil }
+		executor.dashboardClientFactory = func(ctx context.Context, projectDir string) (DashboardClient, error) {
+			return &mockDashboardClient{}, nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stderr := captureStderr(t, func() {
+			done := make(chan error)
+			go func() {
+				done <- executor.followLogsViaDashboard(ctx, &droppingDashboardClient{}, nil, LogLevelAll, nil, &buf)
+			}()
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+			<-done
+		})
+
+		if strings.Contains(stderr, "reconnect") || strings.Contains(buf.String(), "reconnect") {
+			t.Errorf("JSON mode should not print reconnect notices, stderr: %q, output: %q", stderr, buf.String())
+		}
+	})
+
+	t.Run("stops retrying on context cancellation", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: "json"})
+		executor.getWorkingDir = func() (string, error) { return t.TempDir(), nil }
+		executor.dashboardClientFactory = func(ctx context.Context, projectDir string) (DashboardClient, error) {
+			return nil, errors.New("dashboard not running")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- executor.followLogsViaDashboard(ctx, &droppingDashboardClient{}, nil, LogLevelAll, nil, &buf)
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Expected nil error on cancellation, got: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("followLogsViaDashboard() did not stop after context cancellation")
+		}
+	})
+
+	t.Run("returns stream error when reconnect is unavailable", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: "text"})
+
+		err := executor.followLogsViaDashboard(context.Background(), &droppingDashboardClient{}, nil, LogLevelAll, nil, &buf)
+		if err == nil || !strings.Contains(err.Error(), "connection reset") {
+			t.Errorf("Expected stream error, got: %v", err)
+		}
+	})
+}
+
+// lateServiceDashboardClient reports worker as started once workerStarted is set, and
+// streams its logs from then on, like a dependent that starts after its dependencies.
+type lateServiceDashboardClient struct {
+	workerStarted atomic.Bool
+	streams       atomic.Int32
+	streamedName  atomic.Value
+}
+
+func (m *lateServiceDashboardClient) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *lateServiceDashboardClient) GetServices(ctx context.Context) ([]*serviceinfo.ServiceInfo, error) {
+	worker := &serviceinfo.ServiceInfo{Name: "worker", Local: &serviceinfo.LocalServiceInfo{Status: constants.StatusNotRunning}}
+	if m.workerStarted.Load() {
+		worker.Local.Status = constants.StatusStarting
+	}
+	return []*serviceinfo.ServiceInfo{
+		{Name: "api", Local: &serviceinfo.LocalServiceInfo{Status: constants.StatusRunning}},
+		worker,
+	}, nil
+}
+
+func (m *lateServiceDashboardClient) StreamLogs(ctx context.Context, serviceName string, logs chan<- service.LogEntry) error {
+	m.streams.Add(1)
+	m.streamedName.Store(serviceName)
+	if m.workerStarted.Load() {
+		logs <- service.LogEntry{Service: "worker", Level: service.LogLevelInfo, Message: "worker ready", Timestamp: time.Now()}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestLogsExecutor_FollowLogsViaDashboard_FollowNew(t *testing.T) {
+	interval := logsFollowNewInterval
+	logsFollowNewInterval = 5 * time.Millisecond
+	t.Cleanup(func() { logsFollowNewInterval = interval })
+
+	tests := []struct {
+		name          string
+		serviceFilter []string
+		wantWorker    bool
+	}{
+		{name: "streams services that start later", wantWorker: true},
+		{name: "respects the service filter", serviceFilter: []string{"api"}},
+		{name: "filters services that have not started yet", serviceFilter: []string{"worker"}, wantWorker: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: "text", noColor: true, follow: true, followNew: true})
+			executor.startedServices = map[string]bool{"api": true}
+			client := &lateServiceDashboardClient{}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var err error
+			stderr := captureStderr(t, func() {
+				done := make(chan error)
+				go func() {
+					done <- executor.followLogsViaDashboard(ctx, client, tt.serviceFilter, LogLevelAll, nil, &buf)
+				}()
+				time.Sleep(50 * time.Millisecond)
+				client.workerStarted.Store(true)
+				time.Sleep(150 * time.Millisecond)
+				cancel()
+				err = <-done
+			})
+
+			if err != nil {
+				t.Errorf("Expected nil error, got: %v", err)
+			}
+			if got := client.streamedName.Load(); got != "" {
+				t.Errorf("streamed service = %q, want all services with --follow-new", got)
+			}
+			if got := strings.Contains(buf.String(), "worker ready"); got != tt.wantWorker {
+				t.Errorf("output contains worker logs = %v, want %v; output: %s", got, tt.wantWorker, buf.String())
+			}
+			if got := strings.Contains(stderr, "Following logs for worker"); got != tt.wantWorker {
+				t.Errorf("stderr contains new service notice = %v, want %v; stderr: %q", got, tt.wantWorker, stderr)
+			}
+			if wantStreams := map[bool]int32{true: 2, false: 1}[tt.wantWorker]; client.streams.Load() != wantStreams {
+				t.Errorf("streams opened = %d, want %d", client.streams.Load(), wantStreams)
+			}
+		})
+	}
+}
+
 func TestLogsExecutor_FollowLogsInMemory(t *testing.T) {
 	t.Run("signal interrupts streaming", func(t *testing.T) {
 		var buf bytes.Buffer
@@ -321,6 +617,54 @@ func TestLogsExecutor_FollowLogsInMemory(t *testing.T) {
 			t.Errorf("Should contain log message, got: %s", output)
 		}
 	})
+
+	t.Run("NDJSON streams matches with before-context", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		executor := newTestExecutor(&buf, make(chan os.Signal, 1), &logsOptions{format: logsFormatNDJSON, contextLines: 2})
+		executor.followContext = &followContext{lines: 2}
+
+		logChan := make(chan service.LogEntry, 10)
+		subscriptions := map[string]chan service.LogEntry{"api": logChan}
+		mockLM := newMockLogManager()
+		buf5, _ := service.NewLogBuffer("api", 100, false, "")
+		mockLM.buffers["api"] = buf5
+
+		now := time.Now()
+		logChan <- service.LogEntry{Service: "api", Level: service.LogLevelInfo, Message: "connecting", Timestamp: now}
+		logChan <- service.LogEntry{Service: "api", Level: service.LogLevelInfo, Message: "retrying", Timestamp: now}
+		logChan <- service.LogEntry{Service: "api", Level: service.LogLevelWarn, Message: "slow response", Timestamp: now}
+		logChan <- service.LogEntry{Service: "api", Level: service.LogLevelError, Message: `{"msg":"failed","code":500}`, Timestamp: now, Fields: map[string]any{"msg": "failed", "code": float64(500)}}
+		logChan <- service.LogEntry{Service: "api", Level: service.LogLevelError, Message: "failed again", Timestamp: now}
+		close(logChan)
+
+		if err := executor.followLogsInMemory(subscriptions, mockLM, service.LogLevelError, nil, w); err != nil {
+			t.Fatalf("followLogsInMemory() error = %v", err)
+		}
+
+		// Each entry was flushed as it was written, without flushing w here
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Expected one line per matching entry, got %d: %s", len(lines), buf.String())
+		}
+
+		var first, second LogEntryWithContext
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", lines[0], err)
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", lines[1], err)
+		}
+		if first.Context == nil || !reflect.DeepEqual(first.Context.Before, []string{"retrying", "slow response"}) {
+			t.Errorf("First match context = %+v, want the 2 preceding lines", first.Context)
+		}
+		if first.Fields["code"] != float64(500) {
+			t.Errorf("First match fields = %v, want the structured fields", first.Fields)
+		}
+		if second.Context != nil {
+			t.Errorf("Second match context = %+v, want none (lines are shown once)", second.Context)
+		}
+	})
 }
 
 func TestLogsExecutor_FollowLogs(t *testing.T) {
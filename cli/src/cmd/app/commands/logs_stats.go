@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// LogStats holds log volume for a single service.
+type LogStats struct {
+	Info           int        `json:"info"`
+	Warn           int        `json:"warn"`
+	Error          int        `json:"error"`
+	Debug          int        `json:"debug"`
+	Total          int        `json:"total"`
+	FirstTimestamp *time.Time `json:"firstTimestamp,omitempty"`
+	LastTimestamp  *time.Time `json:"lastTimestamp,omitempty"`
+}
+
+// add records a log entry in the stats.
+// Levels are bucketed like logLevelToString, so unknown levels count as info.
+func (s *LogStats) add(entry service.LogEntry) {
+	switch entry.Level {
+	case service.LogLevelWarn:
+		s.Warn++
+	case service.LogLevelError:
+		s.Error++
+	case service.LogLevelDebug:
+		s.Debug++
+	default:
+		s.Info++
+	}
+	s.Total++
+
+	ts := entry.Timestamp
+	if s.FirstTimestamp == nil || ts.Before(*s.FirstTimestamp) {
+		s.FirstTimestamp = &ts
+	}
+	if s.LastTimestamp == nil || ts.After(*s.LastTimestamp) {
+		s.LastTimestamp = &ts
+	}
+}
+
+// newLogsStatsCmd creates the 'logs stats' subcommand.
+func newLogsStatsCmd() *cobra.Command {
+	opts := &logsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats [service-name]",
+		Short: "Summarize log volume and error counts per service",
+		Long: `Show a per-service breakdown of log lines by level (info, warn, error, debug),
+with total lines and the timestamp range covered.
+
+Logs are collected the same way as 'azd app logs' (up to the most recent
+10000 lines per service), so --since, --until, --service and the filter flags behave identically.
+
+Examples:
+  # Summarize all services
+  azd app logs stats
+
+  # Summarize the last hour of the api service
+  azd app logs stats --service api --since 1h
+
+  # Output as JSON (map of service to counts)
+  azd app logs stats --format json`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output.CommandHeader("logs stats", "Summarize logs per service")
+
+			opts.level = "all"
+			opts.tail = maxTailLines
+			if err := validateLogsOptions(opts); err != nil {
+				return err
+			}
+
+			return newLogsExecutor(opts).executeStats(context.Background(), args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.service, "service", "s", "", "Filter by service name(s) (comma-separated)")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only count logs since duration (e.g., 5m, 1h) or RFC3339 timestamp")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only count logs up to duration ago (e.g., 30m) or RFC3339 timestamp")
+	cmd.Flags().StringVar(&opts.format, "format", "text", "Output format (text, json, ndjson)")
+	cmd.Flags().StringVar(&opts.include, "include", "", "Only count lines matching at least one of these regex patterns (comma-separated, applied before --exclude)")
+	cmd.Flags().StringVarP(&opts.exclude, "exclude", "e", "", "Regex patterns to exclude (comma-separated)")
+	cmd.Flags().BoolVar(&opts.noBuiltins, "no-builtins", false, "Disable built-in filter patterns")
+	cmd.Flags().StringArrayVar(&opts.fields, "field", nil, "Only count structured (JSON) logs with this field value, as key=value (repeatable)")
+	cmd.Flags().StringVar(&opts.grep, "grep", "", "Only count lines matching this regex (applied after --exclude)")
+
+	return cmd
+}
+
+// executeStats collects logs like execute and prints per-service counts.
+func (e *logsExecutor) executeStats(ctx context.Context, args []string) error {
+	collected, err := e.collect(ctx, args)
+	if err != nil || collected == nil {
+		return err
+	}
+
+	stats := computeLogStats(collected.logs)
+
+	switch e.opts.format {
+	case logsFormatJSON:
+		return writeLogStatsJSON(stats, e.outputWriter)
+	case logsFormatNDJSON:
+		// A single object on one line
+		return json.NewEncoder(e.outputWriter).Encode(stats)
+	}
+	writeLogStatsText(stats, e.outputWriter)
+	return nil
+}
+
+// computeLogStats groups log entries by service and counts them by level.
+func computeLogStats(logs []service.LogEntry) map[string]*LogStats {
+	stats := make(map[string]*LogStats)
+	for _, entry := range logs {
+		s, exists := stats[entry.Service]
+		if !exists {
+			s = &LogStats{}
+			stats[entry.Service] = s
+		}
+		s.add(entry)
+	}
+	return stats
+}
+
+// writeLogStatsJSON writes the stats as a JSON object keyed by service name.
+func writeLogStatsJSON(stats map[string]*LogStats, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
+
+// writeLogStatsText writes the stats as a table with a total row and the overall timestamp range.
+func writeLogStatsText(stats map[string]*LogStats, w io.Writer) {
+	if len(stats) == 0 {
+		fmt.Fprintln(w, "No logs found")
+		return
+	}
+
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	total := &LogStats{}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERVICE\tINFO\tWARN\tERROR\tDEBUG\tTOTAL")
+	for _, name := range names {
+		s := stats[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\n", name, s.Info, s.Warn, s.Error, s.Debug, s.Total)
+		mergeLogStats(total, s)
+	}
+	fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\n", "TOTAL", total.Info, total.Warn, total.Error, total.Debug, total.Total)
+	_ = tw.Flush()
+
+	if total.FirstTimestamp != nil && total.LastTimestamp != nil {
+		fmt.Fprintf(w, "\nTime range: %s → %s\n",
+			total.FirstTimestamp.Format(time.RFC3339), total.LastTimestamp.Format(time.RFC3339))
+	}
+}
+
+// mergeLogStats adds the counts and timestamp range of src into dst.
+func mergeLogStats(dst, src *LogStats) {
+	dst.Info += src.Info
+	dst.Warn += src.Warn
+	dst.Error += src.Error
+	dst.Debug += src.Debug
+	dst.Total += src.Total
+
+	if src.FirstTimestamp != nil && (dst.FirstTimestamp == nil || src.FirstTimestamp.Before(*dst.FirstTimestamp)) {
+		dst.FirstTimestamp = src.FirstTimestamp
+	}
+	if src.LastTimestamp != nil && (dst.LastTimestamp == nil || src.LastTimestamp.After(*dst.LastTimestamp)) {
+		dst.LastTimestamp = src.LastTimestamp
+	}
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
+)
+
+func TestComputeLogStats(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	logs := []service.LogEntry{
+		{Service: "api", Level: service.LogLevelInfo, Timestamp: base},
+		{Service: "api", Level: service.LogLevelError, Timestamp: base.Add(2 * time.Minute)},
+		{Service: "api", Level: service.LogLevelError, Timestamp: base.Add(time.Minute)},
+		{Service: "web", Level: service.LogLevelWarn, Timestamp: base.Add(3 * time.Minute)},
+		{Service: "web", Level: service.LogLevelDebug, Timestamp: base.Add(4 * time.Minute)},
+	}
+
+	stats := computeLogStats(logs)
+
+	api := stats["api"]
+	if api == nil {
+		t.Fatal("expected stats for api")
+	}
+	if api.Info != 1 || api.Error != 2 || api.Total != 3 {
+		t.Errorf("api stats = %+v, want info=1 error=2 total=3", *api)
+	}
+	if !api.FirstTimestamp.Equal(base) || !api.LastTimestamp.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("api range = %v..%v, want %v..%v", api.FirstTimestamp, api.LastTimestamp, base, base.Add(2*time.Minute))
+	}
+
+	web := stats["web"]
+	if web == nil || web.Warn != 1 || web.Debug != 1 || web.Total != 2 {
+		t.Errorf("web stats = %+v, want warn=1 debug=1 total=2", web)
+	}
+}
+
+func TestWriteLogStatsText(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := computeLogStats([]service.LogEntry{
+		{Service: "web", Level: service.LogLevelWarn, Timestamp: base.Add(time.Minute)},
+		{Service: "api", Level: service.LogLevelError, Timestamp: base},
+	})
+
+	var buf bytes.Buffer
+	writeLogStatsText(stats, &buf)
+	output := buf.String()
+
+	lines := strings.Split(output, "\n")
+	if !strings.HasPrefix(lines[0], "SERVICE") {
+		t.Errorf("first line should be the header, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "api") || !strings.HasPrefix(lines[2], "web") {
+		t.Errorf("services should be sorted, got:\n%s", output)
+	}
+	if fields := strings.Fields(lines[3]); len(fields) != 6 || fields[0] != "TOTAL" || fields[5] != "2" {
+		t.Errorf("total row = %q, want TOTAL with 2 lines", lines[3])
+	}
+	if !strings.Contains(output, "2024-01-01T12:00:00Z → 2024-01-01T12:01:00Z") {
+		t.Errorf("output should contain the time range, got:\n%s", output)
+	}
+
+	buf.Reset()
+	writeLogStatsText(map[string]*LogStats{}, &buf)
+	if !strings.Contains(buf.String(), "No logs found") {
+		t.Errorf("empty stats should say no logs found, got %q", buf.String())
+	}
+}
+
+func TestLogsExecutor_ExecuteStats(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	newStatsExecutor := func(buf *bytes.Buffer, opts *logsOptions) *logsExecutor {
+		apiBuf, _ := service.NewLogBuffer("api", 100, false, "")
+		apiBuf.Add(service.LogEntry{Service: "api", Level: service.LogLevelInfo, Message: "started", Timestamp: time.Now()})
+		apiBuf.Add(service.LogEntry{Service: "api", Level: service.LogLevelError, Message: "request failed", Timestamp: time.Now()})
+		webBuf, _ := service.NewLogBuffer("web", 100, false, "")
+		webBuf.Add(service.LogEntry{Service: "web", Level: service.LogLevelWarn, Message: "slow render", Timestamp: time.Now()})
+
+		logManager := newMockLogManager()
+		logManager.buffers["api"] = apiBuf
+		logManager.buffers["web"] = webBuf
+
+		return newLogsExecutorForTest(
+			func(ctx context.Context, projectDir string) (DashboardClient, error) {
+				return &mockDashboardClient{
+					services: []*serviceinfo.ServiceInfo{{Name: "api"}, {Name: "web"}},
+				}, nil
+			},
+			func(projectDir string) LogManagerInterface { return logManager },
+			func() (string, error) { return tmpDir, nil },
+			buf,
+			opts,
+		)
+	}
+
+	t.Run("JSON map of service to counts", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newStatsExecutor(&buf, &logsOptions{tail: maxTailLines, level: "all", format: "json"})
+
+		if err := executor.executeStats(context.Background(), nil); err != nil {
+			t.Fatalf("executeStats() error = %v", err)
+		}
+
+		var stats map[string]LogStats
+		if err := json.Unmarshal(buf.Bytes(), &stats); err != nil {
+			t.Fatalf("output should be valid JSON: %v\n%s", err, buf.String())
+		}
+		if stats["api"].Info != 1 || stats["api"].Error != 1 || stats["api"].Total != 2 {
+			t.Errorf("api stats = %+v, want info=1 error=1 total=2", stats["api"])
+		}
+		if stats["web"].Warn != 1 {
+			t.Errorf("web stats = %+v, want warn=1", stats["web"])
+		}
+	})
+
+	t.Run("honors service filter and grep", func(t *testing.T) {
+		var buf bytes.Buffer
+		executor := newStatsExecutor(&buf, &logsOptions{tail: maxTailLines, level: "all", format: "json", service: "api", grep: "failed"})
+
+		if err := executor.executeStats(context.Background(), nil); err != nil {
+			t.Fatalf("executeStats() error = %v", err)
+		}
+
+		var stats map[string]LogStats
+		if err := json.Unmarshal(buf.Bytes(), &stats); err != nil {
+			t.Fatalf("output should be valid JSON: %v", err)
+		}
+		if _, exists := stats["web"]; exists {
+			t.Error("web should be excluded by --service api")
+		}
+		if stats["api"].Total != 1 || stats["api"].Error != 1 {
+			t.Errorf("api stats = %+v, want only the grep match", stats["api"])
+		}
+	})
+}
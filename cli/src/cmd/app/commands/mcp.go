@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -30,6 +36,16 @@ const (
 	burstSize             = 10 // Allow burst of 10 calls
 )
 
+// Transport constants
+const (
+	mcpTransportStdio  = "stdio"
+	mcpTransportHTTP   = "http"
+	mcpHTTPHost        = "127.0.0.1" // Only accept local connections
+	mcpHTTPEndpoint    = "/mcp"
+	defaultMCPHTTPPort = 3001
+	mcpShutdownTimeout = 5 * time.Second
+)
+
 // Command constants
 const (
 	azdCommand     = "azd"
@@ -37,12 +53,23 @@ const (
 	jsonOutputFlag = "--output"
 	jsonOutputVal  = "json"
 	cwdFlag        = "--cwd"
+
+	defaultServiceHost = "containerapp" // Host used by add_service when none is given
 )
 
 // Allowed values for validation
 var (
 	allowedLogLevels = map[string]bool{"info": true, "warn": true, "error": true, "debug": true, "all": true}
 	allowedRuntimes  = map[string]bool{"azd": true, "aspire": true, "pnpm": true, "docker-compose": true}
+	allowedTestTypes = map[string]bool{"unit": true, "integration": true, "e2e": true, "all": true}
+	// allowedServiceLanguages and allowedServiceHosts are the azure.yaml values add_service accepts
+	allowedServiceLanguages = map[string]bool{
+		"js": true, "ts": true, "python": true, "dotnet": true, "csharp": true, "fsharp": true,
+		"java": true, "go": true, "rust": true, "php": true,
+	}
+	allowedServiceHosts = map[string]bool{
+		"containerapp": true, "appservice": true, "function": true, "staticwebapp": true, "aks": true, "springapp": true,
+	}
 	// safeNamePattern validates service names and other identifiers to prevent injection
 	safeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
 )
@@ -63,21 +90,141 @@ func NewMCPCommand() *cobra.Command {
 
 // newMCPServeCommand creates the mcp serve subcommand.
 func newMCPServeCommand() *cobra.Command {
-	return &cobra.Command{
+	var transport string
+	var port int
+
+	cmd := &cobra.Command{
 		Use:   "serve",
 		Short: "Start the MCP server",
 		Long:  `Starts the Model Context Protocol server to expose azd app functionality to AI assistants`,
-		RunE:  runMCPServe,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateMCPTransport(transport, port); err != nil {
+				return err
+			}
+			return runMCPServer(cmd.Context(), transport, port)
+		},
 	}
+
+	cmd.Flags().StringVar(&transport, "transport", mcpTransportStdio, "Transport to serve MCP over: stdio or http (streamable HTTP on localhost)")
+	cmd.Flags().IntVar(&port, "port", defaultMCPHTTPPort, "Port for the http transport (bound to 127.0.0.1)")
+
+	return cmd
 }
 
-// runMCPServe starts the MCP server using Go implementation.
-func runMCPServe(cmd *cobra.Command, args []string) error {
-	return runMCPServer(cmd.Context())
+// validateMCPTransport validates the --transport and --port flags.
+func validateMCPTransport(transport string, port int) error {
+	switch transport {
+	case mcpTransportStdio:
+		return nil
+	case mcpTransportHTTP:
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid --port: %d (must be between 1 and 65535)", port)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --transport: %s (must be '%s' or '%s')", transport, mcpTransportStdio, mcpTransportHTTP)
+	}
+}
+
+// runMCPServer starts the MCP server on the given transport and blocks until it stops.
+func runMCPServer(ctx context.Context, transport string, port int) error {
+	s := newMCPServer()
+
+	if transport == mcpTransportHTTP {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		listener, err := net.Listen("tcp", net.JoinHostPort(mcpHTTPHost, strconv.Itoa(port)))
+		if err != nil {
+			return fmt.Errorf("failed to listen on port %d: %w", port, err)
+		}
+		// Report the endpoint on stderr, like the stdio transport's errors
+		fmt.Fprintf(os.Stderr, "MCP server listening on http://%s%s\n", listener.Addr(), mcpHTTPEndpoint)
+		return serveMCPHTTP(ctx, s, listener)
+	}
+
+	// Start the server using stdio transport (handles SIGINT/SIGTERM itself)
+	if err := server.ServeStdio(s); err != nil {
+		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
+		return err
+	}
+
+	return nil
 }
 
-// runMCPServer implements the MCP server logic
-func runMCPServer(ctx context.Context) error {
+// serveMCPHTTP serves the streamable HTTP transport on listener until ctx is cancelled,
+// then shuts down gracefully, giving in-flight requests mcpShutdownTimeout to finish.
+func serveMCPHTTP(ctx context.Context, s *server.MCPServer, listener net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle(mcpHTTPEndpoint, requireLoopbackRequest(server.NewStreamableHTTPServer(s, server.WithEndpointPath(mcpHTTPEndpoint))))
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), mcpShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down MCP server: %w", err)
+	}
+	return nil
+}
+
+// requireLoopbackRequest rejects requests that don't come from a local client.
+// Binding to loopback isn't enough on its own: a web page can rebind its DNS name to
+// 127.0.0.1 and call tools from the browser. Such requests carry the page's Host
+// and Origin, so the Host must be a loopback name and the Origin, if any, too.
+func requireLoopbackRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			http.Error(w, "Forbidden: invalid host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || !isLoopbackHost(u.Host) {
+				http.Error(w, "Forbidden: invalid origin", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether host, with or without a port, is localhost or a loopback IP.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// newMCPServer creates the MCP server with every tool, resource and resource template
+// registered. Both transports serve the same server.
+func newMCPServer() *server.MCPServer {
 	// System instructions to guide AI on how to use the tools
 	// This server is part of the azd extension framework and provides runtime operations
 	instructions := `This MCP server is provided by the azd app extension and focuses on runtime operations for azd projects.
@@ -93,16 +240,20 @@ This server complements azd's core MCP capabilities:
 3. Use get_service_errors FIRST when debugging - it returns only errors with context
 4. Use get_service_logs for full log history when you need more detail
 5. Read azure://project/azure.yaml resource to understand project structure before operations
+6. Read azure://project/logs/{service} for a quick plain-text view of a service's recent logs
 
 **Debugging Workflow:**
 1. get_service_errors: Start here - returns errors with surrounding context for quick diagnosis
 2. get_service_logs: Use if you need full log history or non-error messages
 3. restart_service: After fixing issues, restart the affected service
 
+**Prompts:**
+- troubleshoot_service: Gathers a failing service's configuration, last run command, health and recent errors in one step
+
 **Tool Categories:**
-- Observability: get_services, get_service_errors, get_service_logs, get_project_info
-- Operations: run_services, stop_services, start_service, restart_service, install_dependencies
-- Configuration: check_requirements, get_environment_variables, set_environment_variable
+- Observability: get_services, get_ports, get_health, get_dashboard_url, get_service_errors, get_service_logs, get_project_info
+- Operations: run_services, stop_services, start_service, restart_service, install_dependencies, clean_dependencies, run_tests
+- Configuration: check_requirements, get_environment_variables, set_environment_variable, add_service
 
 **Service Lifecycle:**
 - run_services: Start all services (background process, use get_services to check status)
@@ -129,6 +280,9 @@ This server complements azd's core MCP capabilities:
 	tools := []server.ServerTool{
 		// Observability tools
 		newGetServicesTool(),
+		newGetPortsTool(),
+		newGetHealthTool(),
+		newGetDashboardURLTool(),
 		newGetServiceLogsTool(),
 		newGetServiceErrorsTool(),
 		newGetProjectInfoTool(),
@@ -138,10 +292,13 @@ This server complements azd's core MCP capabilities:
 		newStartServiceTool(),
 		newRestartServiceTool(),
 		newInstallDependenciesTool(),
+		newCleanDependenciesTool(),
+		newRunTestsTool(),
 		newCheckRequirementsTool(),
 		// Configuration tools
 		newGetEnvironmentVariablesTool(),
 		newSetEnvironmentVariableTool(),
+		newAddServiceTool(),
 	}
 
 	s.AddTools(tools...)
@@ -154,13 +311,13 @@ This server complements azd's core MCP capabilities:
 
 	s.AddResources(resources...)
 
-	// Start the server using stdio transport
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
-		return err
-	}
+	// Add resource templates
+	s.AddResourceTemplates(newServiceLogsResource())
 
-	return nil
+	// Add prompts
+	s.AddPrompts(newTroubleshootServicePrompt())
+
+	return s
 }
 
 // executeAzdAppCommand executes an azd app command and returns JSON output
@@ -235,6 +392,12 @@ func getFloat64Param(args map[string]interface{}, key string) (float64, bool) {
 	return 0, false
 }
 
+// getBoolParam safely extracts a bool parameter from request arguments
+func getBoolParam(args map[string]interface{}, key string) bool {
+	val, ok := args[key].(bool)
+	return ok && val
+}
+
 // marshalToolResult marshals data to JSON and returns an MCP tool result
 func marshalToolResult(data interface{}) (*mcp.CallToolResult, error) {
 	jsonBytes, err := json.MarshalIndent(data, "", "  ")
@@ -484,12 +647,58 @@ type ServiceDetails struct {
 	Env       map[string]string `json:"env,omitempty" jsonschema:"description=Environment variables configured for the service"`
 }
 
+// ServicePort represents a single entry returned by the get_ports tool
+type ServicePort struct {
+	Service string `json:"service" jsonschema:"description=Service name"`
+	Port    int    `json:"port" jsonschema:"description=Local port the service is listening on"`
+	Type    string `json:"type,omitempty" jsonschema:"description=Service type (e.g. http, tcp, container)"`
+	URL     string `json:"url,omitempty" jsonschema:"description=Local URL where the service is reachable"`
+}
+
+// ServiceHealth represents a single entry returned by the get_health tool
+type ServiceHealth struct {
+	Service   string `json:"service" jsonschema:"description=Service name"`
+	Type      string `json:"type" jsonschema:"description=Health check type (http, tcp, udp, process or none)"`
+	Healthy   bool   `json:"healthy" jsonschema:"description=Whether the service passed its health check"`
+	LastCheck string `json:"lastCheck,omitempty" jsonschema:"description=When the health check ran (RFC 3339)"`
+	Detail    string `json:"detail,omitempty" jsonschema:"description=Health status with the error or the endpoint that was checked"`
+}
+
+// DashboardStatus represents the output schema for get_dashboard_url tool
+type DashboardStatus struct {
+	Running   bool   `json:"running" jsonschema:"description=Whether an azd app run session has registered a dashboard for the project"`
+	URL       string `json:"url,omitempty" jsonschema:"description=URL of the dashboard"`
+	Reachable bool   `json:"reachable" jsonschema:"description=Whether the dashboard answered a ping just now"`
+}
+
+// CleanDependenciesResult represents the output schema for clean_dependencies tool
+type CleanDependenciesResult struct {
+	Removed []string `json:"removed" jsonschema:"description=Dependency directories that were removed"`
+	Errors  []string `json:"errors,omitempty" jsonschema:"description=Directories that could not be removed, with the reason"`
+}
+
 // ProjectInfo represents the output schema for get_project_info tool
 type ProjectInfo struct {
 	Project  map[string]interface{}  `json:"project" jsonschema:"description=Project metadata"`
 	Services []ProjectServiceSummary `json:"services" jsonschema:"description=Summary of services defined in the project"`
 }
 
+// AddServiceResult represents the output schema for add_service tool
+type AddServiceResult struct {
+	Added     string                  `json:"added" jsonschema:"description=Name of the service that was added"`
+	AzureYaml string                  `json:"azureYaml" jsonschema:"description=Path of the updated azure.yaml"`
+	Services  []AzureYamlServiceEntry `json:"services" jsonschema:"description=All services in azure.yaml after the change, sorted by name"`
+}
+
+// AzureYamlServiceEntry represents a service as declared in azure.yaml
+type AzureYamlServiceEntry struct {
+	Name     string   `json:"name" jsonschema:"description=Service name"`
+	Language string   `json:"language,omitempty" jsonschema:"description=Programming language"`
+	Project  string   `json:"project,omitempty" jsonschema:"description=Project directory path"`
+	Host     string   `json:"host,omitempty" jsonschema:"description=Azure host"`
+	Ports    []string `json:"ports,omitempty" jsonschema:"description=Declared ports"`
+}
+
 // ProjectServiceSummary represents a simplified service summary
 type ProjectServiceSummary struct {
 	Name      string `json:"name" jsonschema:"description=Service name"`
@@ -498,6 +707,37 @@ type ProjectServiceSummary struct {
 	Project   string `json:"project" jsonschema:"description=Project directory path"`
 }
 
+// TestRunSummary represents the output schema for run_tests tool
+type TestRunSummary struct {
+	TestType        string               `json:"testType" jsonschema:"description=Test type that was run (unit, integration, e2e, or all)"`
+	Success         bool                 `json:"success" jsonschema:"description=Whether all tests passed"`
+	Passed          int                  `json:"passed" jsonschema:"description=Total number of passed tests"`
+	Failed          int                  `json:"failed" jsonschema:"description=Total number of failed tests"`
+	Skipped         int                  `json:"skipped" jsonschema:"description=Total number of skipped tests"`
+	Total           int                  `json:"total" jsonschema:"description=Total number of tests"`
+	Duration        float64              `json:"duration" jsonschema:"description=Total test execution time in seconds"`
+	Services        []ServiceTestSummary `json:"services" jsonschema:"description=Test results for each tested service"`
+	SkippedServices []SkippedTestService `json:"skippedServices,omitempty" jsonschema:"description=Services that were not tested and why"`
+	Error           string               `json:"error,omitempty" jsonschema:"description=Error message if test execution failed"`
+}
+
+// ServiceTestSummary represents test results for a single service
+type ServiceTestSummary struct {
+	Service string `json:"service" jsonschema:"description=Service name"`
+	Success bool   `json:"success" jsonschema:"description=Whether all tests for the service passed"`
+	Passed  int    `json:"passed" jsonschema:"description=Number of passed tests"`
+	Failed  int    `json:"failed" jsonschema:"description=Number of failed tests"`
+	Skipped int    `json:"skipped" jsonschema:"description=Number of skipped tests"`
+	Total   int    `json:"total" jsonschema:"description=Total number of tests"`
+	Error   string `json:"error,omitempty" jsonschema:"description=Error message if the service tests could not run"`
+}
+
+// SkippedTestService represents a service that run_tests could not test
+type SkippedTestService struct {
+	Service string `json:"service" jsonschema:"description=Service name"`
+	Reason  string `json:"reason" jsonschema:"description=Why the service was skipped"`
+}
+
 // RequirementsResult represents the output schema for check_requirements tool
 type RequirementsResult struct {
 	Requirements []RequirementStatus `json:"requirements" jsonschema:"description=List of requirements and their status"`
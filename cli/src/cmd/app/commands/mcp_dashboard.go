@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/azdconfig"
+	"github.com/jongio/azd-app/cli/src/internal/constants"
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+const (
+	// maxDashboardResponseSize caps how much of a dashboard API response is read
+	maxDashboardResponseSize = 1 << 20
+	// dashboardRestartTimeout bounds a restart through a dashboard that doesn't report how
+	// long its restarts may take (see dashboardRestartTimeoutFor)
+	dashboardRestartTimeout = 2 * time.Minute
+	// dashboardRestartMargin is added to the restart timeout a dashboard reports, for
+	// starting the new process and the request itself
+	dashboardRestartMargin = 30 * time.Second
+)
+
+// dashboardOperationResponse is the response of the dashboard's /api/services/{start,stop,restart}
+// endpoints: Message for a single service, Services for all services, Error on failure.
+// A restart of a single service also reports its Status and StartupDuration.
+type dashboardOperationResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message"`
+	Error           string `json:"error"`
+	Status          string `json:"status"`
+	StartupDuration string `json:"startupDuration"`
+	Services        []struct {
+		Name     string `json:"name"`
+		Success  bool   `json:"success"`
+		Duration string `json:"duration"`
+		Error    string `json:"error"`
+	} `json:"services"`
+}
+
+// errDashboardNotRegistered is returned when no azd app run session registered a dashboard
+// for the project, meaning nothing is running.
+var errDashboardNotRegistered = errors.New("no running azd app run session found")
+
+// registeredDashboardPort returns the port of the dashboard registered for projectDir in the
+// azd config, or 0 if none is. A variable so tests can replace it.
+var registeredDashboardPort = func(ctx context.Context, projectDir string) (int, error) {
+	client, err := azdconfig.NewClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the azd config: %w", err)
+	}
+	defer client.Close()
+
+	return client.GetDashboardPort(azdconfig.ProjectHash(projectDir))
+}
+
+// findDashboardURL returns the URL of the dashboard of the azd app run session for projectDir.
+// The dashboard registers its port in the azd config while it runs; a registered dashboard
+// that doesn't answer /api/ping is treated as not running.
+func findDashboardURL(ctx context.Context, projectDir string) (string, error) {
+	dashboardURL, err := registeredDashboardURL(ctx, projectDir)
+	if err != nil {
+		return "", err
+	}
+	if err := pingDashboard(ctx, dashboardURL); err != nil {
+		return "", fmt.Errorf("the dashboard of the azd app run session for %s is not responding: %w", projectDir, err)
+	}
+	return dashboardURL, nil
+}
+
+// registeredDashboardURL returns the URL of the dashboard the azd app run session for
+// projectDir registered in the azd config, without checking that it answers. It returns
+// errDashboardNotRegistered if none is registered.
+func registeredDashboardURL(ctx context.Context, projectDir string) (string, error) {
+	port, err := registeredDashboardPort(ctx, projectDir)
+	if err != nil {
+		return "", err
+	}
+	if port <= 0 {
+		return "", fmt.Errorf("%w for %s", errDashboardNotRegistered, projectDir)
+	}
+
+	// The dashboard only listens on the loopback interface
+	return fmt.Sprintf("http://127.0.0.1:%d", port), nil
+}
+
+// dashboardStatus describes the dashboard registered at dashboardURL for get_dashboard_url.
+func dashboardStatus(ctx context.Context, dashboardURL string) DashboardStatus {
+	return DashboardStatus{
+		Running:   true,
+		URL:       dashboardURL,
+		Reachable: pingDashboard(ctx, dashboardURL) == nil,
+	}
+}
+
+// pingDashboard checks that the dashboard at dashboardURL is up.
+func pingDashboard(ctx context.Context, dashboardURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dashboardURL+"/api/ping", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer service.SafeClose(resp.Body, "dashboard ping response body")
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ping returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// dashboardRestartTimeoutFor returns how long to wait for a restart through the dashboard at
+// dashboardURL. A restart stops the service and waits up to the run's --ready-timeout for it,
+// which the dashboard reports as restartTimeout from /api/ping; dashboardRestartTimeout is
+// used when it reports none.
+func dashboardRestartTimeoutFor(ctx context.Context, dashboardURL string) time.Duration {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dashboardURL+"/api/ping", nil)
+	if err != nil {
+		return dashboardRestartTimeout
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return dashboardRestartTimeout
+	}
+	defer service.SafeClose(resp.Body, "dashboard ping response body")
+
+	var ping struct {
+		RestartTimeout string `json:"restartTimeout"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDashboardResponseSize)).Decode(&ping); err != nil {
+		return dashboardRestartTimeout
+	}
+	timeout, err := time.ParseDuration(ping.RestartTimeout)
+	if err != nil || timeout <= 0 {
+		return dashboardRestartTimeout
+	}
+	return timeout + dashboardRestartMargin
+}
+
+// getHealthViaDashboard asks the dashboard at dashboardURL to run the configured health
+// checks of the project's services.
+func getHealthViaDashboard(ctx context.Context, dashboardURL string) (*healthcheck.HealthReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dashboardURL+"/api/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the dashboard: %w", err)
+	}
+	defer service.SafeClose(resp.Body, "dashboard health response body")
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDashboardResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the dashboard response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var response dashboardOperationResponse
+		if err := json.Unmarshal(body, &response); err != nil || response.Error == "" {
+			return nil, fmt.Errorf("health check returned status %d", resp.StatusCode)
+		}
+		return nil, errors.New(response.Error)
+	}
+
+	var report healthcheck.HealthReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, fmt.Errorf("unexpected dashboard response: %w", err)
+	}
+	return &report, nil
+}
+
+// stopServicesViaDashboard asks the dashboard at dashboardURL to stop serviceName, or every
+// running service when serviceName is empty. The dashboard stops them the way azd app run
+// does on shutdown: gracefully, then forcefully after a timeout.
+func stopServicesViaDashboard(ctx context.Context, dashboardURL, serviceName string) (*BulkServiceControlResult, error) {
+	endpoint := dashboardURL + "/api/services/stop"
+	if serviceName != "" {
+		endpoint += "?service=" + url.QueryEscape(serviceName)
+	}
+	response, err := postDashboardOperation(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkServiceControlResult{
+		Success: response.Success,
+		Message: response.Message,
+		Results: []ServiceControlResult{},
+	}
+	if serviceName != "" {
+		result.Results = append(result.Results, ServiceControlResult{
+			ServiceName: serviceName,
+			Success:     response.Success,
+			Message:     response.Message,
+			Status:      constants.StatusStopped,
+		})
+	}
+	for _, svc := range response.Services {
+		svcResult := ServiceControlResult{
+			ServiceName: svc.Name,
+			Success:     svc.Success,
+			Error:       svc.Error,
+			Duration:    svc.Duration,
+		}
+		if svc.Success {
+			svcResult.Message = fmt.Sprintf("Service '%s' stopped", svc.Name)
+			svcResult.Status = constants.StatusStopped
+		}
+		result.Results = append(result.Results, svcResult)
+	}
+	for _, svcResult := range result.Results {
+		if svcResult.Success {
+			result.SuccessCount++
+		} else {
+			result.FailureCount++
+		}
+	}
+	return result, nil
+}
+
+// restartServiceViaDashboard asks the dashboard at dashboardURL to restart serviceName. The
+// dashboard stops the process, starts it again with the same command and environment and
+// waits for its health check, so the result carries the service's new status and how long
+// it took to become healthy.
+func restartServiceViaDashboard(ctx context.Context, dashboardURL, serviceName string) (*ServiceControlResult, error) {
+	response, err := postDashboardOperation(ctx, dashboardURL+"/api/services/restart?service="+url.QueryEscape(serviceName))
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceControlResult{
+		ServiceName:     serviceName,
+		Success:         response.Success,
+		Message:         response.Message,
+		Status:          response.Status,
+		StartupDuration: response.StartupDuration,
+	}, nil
+}
+
+// postDashboardOperation posts a service operation to the dashboard endpoint and decodes the
+// response, turning an error response into an error.
+func postDashboardOperation(ctx context.Context, endpoint string) (*dashboardOperationResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the dashboard: %w", err)
+	}
+	defer service.SafeClose(resp.Body, "dashboard operation response body")
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDashboardResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the dashboard response: %w", err)
+	}
+	var response dashboardOperationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unexpected dashboard response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || response.Error != "" {
+		if response.Error == "" {
+			response.Error = fmt.Sprintf("status %d", resp.StatusCode)
+		}
+		return nil, errors.New(response.Error)
+	}
+	return &response, nil
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
+	"github.com/jongio/azd-app/cli/src/internal/security"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Limits of the context gathered by the troubleshoot_service prompt
+const (
+	troubleshootLogScanLines = 1000 // Most recent log lines searched for errors
+	troubleshootErrorLines   = 50   // Most recent error lines included in the prompt
+)
+
+// newTroubleshootServicePrompt creates the troubleshoot_service prompt, which gathers what is
+// needed to diagnose a service that won't start or is unhealthy: its azure.yaml settings, the
+// command and environment it was last run with, its health and its recent errors.
+func newTroubleshootServicePrompt() server.ServerPrompt {
+	return server.ServerPrompt{
+		Prompt: mcp.NewPrompt(
+			"troubleshoot_service",
+			mcp.WithPromptDescription("Diagnose a service that won't start or is unhealthy. Gathers the service's configuration, the command and environment it was last run with, its current health and its recent error logs."),
+			mcp.WithArgument("service",
+				mcp.ArgumentDescription("Name of the service in azure.yaml"),
+				mcp.RequiredArgument(),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			if !globalRateLimiter.Allow() {
+				logRateLimitEvent("troubleshoot_service")
+				return nil, errors.New("rate limit exceeded, please wait before making more requests")
+			}
+
+			serviceName := request.Params.Arguments["service"]
+			if err := security.ValidateServiceName(serviceName, false); err != nil {
+				return nil, fmt.Errorf("invalid service name: %w", err)
+			}
+
+			validatedDir, err := validateProjectDir(getProjectDir())
+			if err != nil {
+				return nil, fmt.Errorf("invalid project directory: %w", err)
+			}
+
+			text, err := buildTroubleshootPrompt(ctx, validatedDir, serviceName)
+			if err != nil {
+				return nil, err
+			}
+
+			return mcp.NewGetPromptResult(
+				fmt.Sprintf("Troubleshoot service %s", serviceName),
+				[]mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text))},
+			), nil
+		},
+	}
+}
+
+// buildTroubleshootPrompt renders the troubleshooting context of a service in the project
+// at projectDir as markdown, followed by instructions for the model. Parts that can't be
+// gathered, such as the run configuration of a service that was never run, are noted
+// rather than failing the prompt; an unknown service is an error.
+func buildTroubleshootPrompt(ctx context.Context, projectDir, serviceName string) (string, error) {
+	azureYamlPath, err := detector.FindAzureYaml(projectDir)
+	if err != nil || azureYamlPath == "" {
+		return "", fmt.Errorf("azure.yaml not found in %s", projectDir)
+	}
+	azureYaml, err := service.ParseAzureYaml(projectDir)
+	if err != nil {
+		return "", err
+	}
+	svc, ok := azureYaml.Services[serviceName]
+	if !ok {
+		names := make([]string, 0, len(azureYaml.Services))
+		for name := range azureYaml.Services {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return "", fmt.Errorf("service %q not found in azure.yaml (services: %s)", serviceName, strings.Join(names, ", "))
+	}
+	azureYamlDir := filepath.Dir(azureYamlPath)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The service %q of this azd project is failing to start or is unhealthy. Diagnose it from the context below.\n", serviceName)
+
+	b.WriteString("\n## Configuration (azure.yaml)\n\n")
+	writeTroubleshootField(&b, "Language", svc.Language)
+	writeTroubleshootField(&b, "Host", svc.Host)
+	writeTroubleshootField(&b, "Project", svc.Project)
+	writeTroubleshootField(&b, "Image", svc.Image)
+	writeTroubleshootField(&b, "Type", svc.GetServiceType())
+	if svc.GetServiceType() == service.ServiceTypeProcess {
+		writeTroubleshootField(&b, "Mode", svc.GetServiceMode())
+	}
+	writeTroubleshootField(&b, "Ports", strings.Join(svc.Ports, ", "))
+	writeTroubleshootField(&b, "Uses", strings.Join(svc.Uses, ", "))
+	writeTroubleshootField(&b, "Health check", svc.GetHealthCheckType())
+
+	b.WriteString("\n## Last run\n\n")
+	writeTroubleshootRun(&b, azureYamlDir, serviceName)
+
+	b.WriteString("\n## Health\n\n")
+	writeTroubleshootHealth(ctx, &b, azureYamlDir, serviceName)
+
+	b.WriteString("\n## Recent errors\n\n")
+	errorLogs, err := readServiceErrorLogsText(ctx, azureYamlDir, serviceName)
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "Logs could not be read: %v\n", err)
+	case errorLogs == "":
+		fmt.Fprintf(&b, "No error-level lines in the last %d log lines.\n", troubleshootLogScanLines)
+	default:
+		b.WriteString("```\n" + errorLogs + "```\n")
+	}
+
+	b.WriteString(`
+## Instructions
+
+1. Identify the most likely cause from the errors, the command and the health result.
+2. Check for common causes: a missing dependency or environment variable, a port that is in use or differs from the one the service listens on, a service it uses that is not running, or a wrong start command.
+3. Suggest a specific fix, such as a code, azure.yaml or environment change.
+4. If the context is not enough, use get_service_errors or get_service_logs for more, and restart_service to verify the fix.
+`)
+	return b.String(), nil
+}
+
+// writeTroubleshootField writes a markdown list item, skipping empty values.
+func writeTroubleshootField(b *strings.Builder, label, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "- %s: %s\n", label, value)
+	}
+}
+
+// writeTroubleshootRun writes the command, ports, health check and environment variable
+// names the service was last run with, from the run snapshot. Values are left out so no
+// secrets reach the model.
+func writeTroubleshootRun(b *strings.Builder, azureYamlDir, serviceName string) {
+	snapshot, err := service.LoadRunSnapshot(service.SnapshotPath(azureYamlDir))
+	if err != nil {
+		fmt.Fprintf(b, "Not available: %v\n", err)
+		return
+	}
+
+	for _, snap := range snapshot.Services {
+		if snap.Name != serviceName {
+			continue
+		}
+		writeTroubleshootField(b, "Run at", snapshot.CreatedAt.Format(time.RFC3339))
+		writeTroubleshootField(b, "Command", strings.Join(append([]string{snap.Command}, snap.Args...), " "))
+		writeTroubleshootField(b, "Working directory", snap.WorkingDir)
+		writeTroubleshootField(b, "Framework", snap.Framework)
+		if snap.Port > 0 {
+			writeTroubleshootField(b, "Port", fmt.Sprintf("%d", snap.Port))
+		}
+		writeTroubleshootField(b, "Health check", describeHealthCheck(snap.HealthCheck, snap.Port))
+		if len(snap.Env) > 0 {
+			keys := make([]string, 0, len(snap.Env))
+			for key := range snap.Env {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			writeTroubleshootField(b, "Environment variables", strings.Join(keys, ", "))
+		}
+		return
+	}
+	b.WriteString("The service was not part of the last azd app run.\n")
+}
+
+// writeTroubleshootHealth checks the service's health once and writes the result.
+func writeTroubleshootHealth(ctx context.Context, b *strings.Builder, azureYamlDir, serviceName string) {
+	result, err := checkServiceHealthOnce(ctx, azureYamlDir, serviceName)
+	if err != nil {
+		fmt.Fprintf(b, "Health could not be checked: %v\n", err)
+		return
+	}
+
+	writeTroubleshootField(b, "Status", string(result.Status))
+	writeTroubleshootField(b, "Check", string(result.CheckType))
+	writeTroubleshootField(b, "Endpoint", result.Endpoint)
+	if result.StatusCode > 0 {
+		writeTroubleshootField(b, "Status code", fmt.Sprintf("%d", result.StatusCode))
+	}
+	writeTroubleshootField(b, "Error", result.Error)
+}
+
+// checkServiceHealthOnce runs a single health check of a service, as azd app health does.
+func checkServiceHealthOnce(ctx context.Context, azureYamlDir, serviceName string) (healthcheck.HealthCheckResult, error) {
+	monitor, err := healthcheck.NewHealthMonitor(healthcheck.MonitorConfig{
+		ProjectDir:      azureYamlDir,
+		DefaultEndpoint: defaultHealthEndpoint,
+		Timeout:         defaultHealthTimeout,
+		LogLevel:        "error",
+		LogFormat:       "json",
+	})
+	if err != nil {
+		return healthcheck.HealthCheckResult{}, fmt.Errorf("failed to create health monitor: %w", err)
+	}
+
+	report, err := monitor.Check(ctx, []string{serviceName})
+	if err != nil {
+		return healthcheck.HealthCheckResult{}, err
+	}
+	if len(report.Services) == 0 {
+		return healthcheck.HealthCheckResult{}, fmt.Errorf("no health result for service %s", serviceName)
+	}
+	return report.Services[0], nil
+}
+
+// readServiceErrorLogsText renders the most recent error-level lines among the last
+// troubleshootLogScanLines log lines of a service as plain text, with secrets redacted
+// as in azd app logs.
+func readServiceErrorLogsText(ctx context.Context, projectDir, serviceName string) (string, error) {
+	opts := &logsOptions{tail: troubleshootLogScanLines}
+	redactor, err := buildLogRedactor(opts)
+	if err != nil {
+		return "", err
+	}
+	executor := newLogsExecutor(opts)
+	logs, err := executor.collectLogs(ctx, projectDir, []string{serviceName}, executor.logManagerFactory(projectDir), time.Time{}, time.Time{})
+	if err != nil {
+		return "", fmt.Errorf("failed to collect logs for service %s: %w", serviceName, err)
+	}
+	logs = redactor.RedactEntries(logs)
+
+	if len(logs) > troubleshootLogScanLines {
+		logs = logs[len(logs)-troubleshootLogScanLines:]
+	}
+	errorLogs := make([]service.LogEntry, 0, len(logs))
+	for _, entry := range logs {
+		if entry.Level == service.LogLevelError {
+			errorLogs = append(errorLogs, entry)
+		}
+	}
+	if len(errorLogs) > troubleshootErrorLines {
+		errorLogs = errorLogs[len(errorLogs)-troubleshootErrorLines:]
+	}
+
+	var buf bytes.Buffer
+	displayLogsText(errorLogs, &buf, true, true)
+	return buf.String(), nil
+}
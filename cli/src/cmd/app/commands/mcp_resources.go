@@ -1,13 +1,17 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/jongio/azd-app/cli/src/internal/security"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -143,3 +147,88 @@ func newServiceConfigResource() server.ServerResource {
 		},
 	}
 }
+
+// newServiceLogsResource creates a resource template for reading recent logs of a service.
+// The optional tail query parameter (e.g. azure://project/logs/api?tail=500) sets the number
+// of lines, which defaults to defaultTailLines and is capped at maxTailLines.
+func newServiceLogsResource() server.ServerResourceTemplate {
+	return server.ServerResourceTemplate{
+		Template: mcp.NewResourceTemplate(
+			"azure://project/logs/{service}{?tail}",
+			"service-logs",
+			mcp.WithTemplateDescription("The most recent log lines of a service, oldest first. Use the tail query parameter to change how many lines are returned."),
+			mcp.WithTemplateAnnotations([]mcp.Role{mcp.RoleAssistant}, 0.6),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			// Check context
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("request cancelled: %w", err)
+			}
+
+			serviceName := resourceTemplateArg(request.Params.Arguments, "service")
+			if err := security.ValidateServiceName(serviceName, false); err != nil {
+				return nil, fmt.Errorf("invalid service name: %w", err)
+			}
+
+			tail := defaultTailLines
+			if value := resourceTemplateArg(request.Params.Arguments, "tail"); value != "" {
+				n, err := strconv.Atoi(value)
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("invalid tail value %q: must be a positive number", value)
+				}
+				tail = min(n, maxTailLines)
+			}
+
+			validatedDir, err := validateProjectDir(getProjectDir())
+			if err != nil {
+				return nil, fmt.Errorf("invalid project directory: %w", err)
+			}
+
+			text, err := readServiceLogsText(ctx, validatedDir, serviceName, tail)
+			if err != nil {
+				return nil, err
+			}
+
+			return []mcp.ResourceContents{
+				&mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					Text:     text,
+					MIMEType: "text/plain",
+				},
+			}, nil
+		},
+	}
+}
+
+// readServiceLogsText collects the last tail log lines of a service the same way
+// azd app logs does (in-memory buffers, then log files) and renders them as plain text.
+func readServiceLogsText(ctx context.Context, projectDir, serviceName string, tail int) (string, error) {
+	executor := newLogsExecutor(&logsOptions{tail: tail})
+	logs, err := executor.collectLogs(ctx, projectDir, []string{serviceName}, executor.logManagerFactory(projectDir), time.Time{}, time.Time{})
+	if err != nil {
+		return "", fmt.Errorf("failed to collect logs for service %s: %w", serviceName, err)
+	}
+
+	if len(logs) > tail {
+		logs = logs[len(logs)-tail:]
+	}
+
+	var buf bytes.Buffer
+	displayLogsText(logs, &buf, true, true)
+	return buf.String(), nil
+}
+
+// resourceTemplateArg returns a variable matched from a resource URI template.
+// mcp-go passes matched values as string slices; only the first value is used.
+func resourceTemplateArg(args map[string]any, key string) string {
+	switch v := args[key].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
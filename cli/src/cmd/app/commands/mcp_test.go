@@ -1,14 +1,24 @@
 package commands
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/security"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
+	testrunner "github.com/jongio/azd-app/cli/src/internal/testing"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/require"
@@ -64,6 +74,147 @@ func TestNewMCPServeCommand(t *testing.T) {
 	}
 }
 
+func TestNewMCPServeCommandFlags(t *testing.T) {
+	cmd := newMCPServeCommand()
+
+	transport := cmd.Flags().Lookup("transport")
+	require.NotNil(t, transport)
+	require.Equal(t, "stdio", transport.DefValue)
+
+	port := cmd.Flags().Lookup("port")
+	require.NotNil(t, port)
+	require.Equal(t, "3001", port.DefValue)
+}
+
+func TestValidateMCPTransport(t *testing.T) {
+	require.NoError(t, validateMCPTransport("stdio", 0))
+	require.NoError(t, validateMCPTransport("http", 3001))
+
+	require.ErrorContains(t, validateMCPTransport("sse", 3001), "invalid --transport")
+	require.ErrorContains(t, validateMCPTransport("http", 0), "invalid --port")
+	require.ErrorContains(t, validateMCPTransport("http", 70000), "invalid --port")
+}
+
+// postMCP sends a JSON-RPC request to an MCP HTTP endpoint and decodes the response.
+func postMCP(t *testing.T, url, sessionID string, body map[string]interface{}) (map[string]interface{}, string) {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return result, resp.Header.Get("Mcp-Session-Id")
+}
+
+func TestServeMCPHTTP(t *testing.T) {
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(1, time.Minute)))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := newMCPServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveMCPHTTP(ctx, s, listener)
+	}()
+
+	url := "http://" + listener.Addr().String() + mcpHTTPEndpoint
+	_, sessionID := postMCP(t, url, "", map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]interface{}{
+			"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+			"clientInfo":      map[string]interface{}{"name": "test", "version": "1.0"},
+		},
+	})
+	require.NotEmpty(t, sessionID)
+
+	// Every tool registered on the server is listed over HTTP
+	listResp, _ := postMCP(t, url, sessionID, map[string]interface{}{"jsonrpc": "2.0", "id": 2, "method": "tools/list"})
+	var listed []string
+	for _, tool := range listResp["result"].(map[string]interface{})["tools"].([]interface{}) {
+		listed = append(listed, tool.(map[string]interface{})["name"].(string))
+	}
+	var registered []string
+	for name := range s.ListTools() {
+		registered = append(registered, name)
+	}
+	require.ElementsMatch(t, registered, listed)
+
+	callTool := func(id int, arguments map[string]interface{}) string {
+		resp, _ := postMCP(t, url, sessionID, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"method":  "tools/call",
+			"params":  map[string]interface{}{"name": "get_ports", "arguments": arguments},
+		})
+		result := resp["result"].(map[string]interface{})
+		require.Equal(t, true, result["isError"])
+		return result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	}
+
+	// Project directory validation applies to HTTP calls
+	require.Contains(t, callTool(3, map[string]interface{}{"projectDir": "../../etc"}), "Invalid project directory")
+
+	// The single token was spent above, so the rate limiter rejects the next call
+	require.Contains(t, callTool(4, map[string]interface{}{}), "Rate limit exceeded")
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("serveMCPHTTP did not shut down after cancellation")
+	}
+}
+
+func TestRequireLoopbackRequest(t *testing.T) {
+	handler := requireLoopbackRequest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name   string
+		host   string
+		origin string
+		want   int
+	}{
+		{"loopback IP", "127.0.0.1:8765", "", http.StatusOK},
+		{"localhost", "localhost:8765", "", http.StatusOK},
+		{"IPv6 loopback", "[::1]:8765", "", http.StatusOK},
+		{"loopback origin", "127.0.0.1:8765", "http://localhost:3000", http.StatusOK},
+		{"rebound host", "attacker.example:8765", "", http.StatusForbidden},
+		{"foreign origin", "127.0.0.1:8765", "http://attacker.example", http.StatusForbidden},
+		{"null origin", "127.0.0.1:8765", "null", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, mcpHTTPEndpoint, nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
 func TestGetServicesToolDefinition(t *testing.T) {
 	tool := newGetServicesTool()
 
@@ -86,6 +237,347 @@ func TestGetServicesToolDefinition(t *testing.T) {
 	}
 }
 
+func TestGetPortsToolDefinition(t *testing.T) {
+	tool := newGetPortsTool()
+
+	if tool.Tool.Name != "get_ports" {
+		t.Errorf("Expected tool name 'get_ports', got '%s'", tool.Tool.Name)
+	}
+
+	if tool.Handler == nil {
+		t.Error("get_ports tool should have a handler")
+	}
+
+	if tool.Tool.Description == "" {
+		t.Error("get_ports tool should have a description")
+	}
+}
+
+func TestGetPortsToolValidation(t *testing.T) {
+	// Use a fresh limiter so earlier tests cannot exhaust the shared budget
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	tool := newGetPortsTool()
+
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "get_ports",
+			Arguments: map[string]interface{}{"projectDir": "/nonexistent/path/xyz123"},
+		},
+	}
+
+	result, err := tool.Handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Handler returned Go error: %v", err)
+	}
+	if result == nil || !result.IsError {
+		t.Fatal("Expected error result for invalid project directory")
+	}
+	if textContent, ok := result.Content[0].(mcp.TextContent); ok {
+		if !strings.Contains(textContent.Text, "Invalid project directory") {
+			t.Errorf("Expected invalid project directory error, got '%s'", textContent.Text)
+		}
+	}
+}
+
+func TestExtractServicePorts(t *testing.T) {
+	var info map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"project": {"name": "demo"},
+		"services": [
+			{"name": "web", "local": {"status": "running", "port": 5173, "serviceType": "http", "url": "http://localhost:5173"}},
+			{"name": "worker", "local": {"status": "running", "serviceType": "process"}},
+			{"name": "db", "local": {"status": "running", "port": 5432, "serviceType": "container"}},
+			{"name": "docs"}
+		]
+	}`), &info)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	got := extractServicePorts(info)
+	want := []ServicePort{
+		{Service: "web", Port: 5173, Type: "http", URL: "http://localhost:5173"},
+		{Service: "db", Port: 5432, Type: "container"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractServicePorts() = %+v, want %+v", got, want)
+	}
+
+	// No services still yields an empty JSON array rather than null
+	if got := extractServicePorts(map[string]interface{}{}); got == nil || len(got) != 0 {
+		t.Errorf("extractServicePorts() with no services = %v, want empty slice", got)
+	}
+}
+
+func TestGetHealthToolNoServicesRunning(t *testing.T) {
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	tool := newGetHealthTool()
+	require.NotEmpty(t, tool.Tool.Annotations.Title)
+
+	invalid, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "get_health", Arguments: map[string]interface{}{"projectDir": "/nonexistent/path/xyz123"}},
+	})
+	require.NoError(t, err)
+	require.True(t, invalid.IsError, "Expected error result for invalid project directory")
+
+	original := registeredDashboardPort
+	t.Cleanup(func() { registeredDashboardPort = original })
+	call := func(port int, lookupErr error) *mcp.CallToolResult {
+		t.Helper()
+		registeredDashboardPort = func(context.Context, string) (int, error) { return port, lookupErr }
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "get_health", Arguments: map[string]interface{}{}},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// No azd app run session registered a dashboard for the project
+	result := call(0, nil)
+	require.False(t, result.IsError)
+	require.JSONEq(t, "[]", result.Content[0].(mcp.TextContent).Text)
+
+	// Other failures are reported rather than hidden as "nothing running"
+	result = call(0, errors.New("failed to get dashboard port: connection refused"))
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "connection refused")
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+	server.Close()
+	result = call(port, nil)
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "not responding")
+}
+
+func TestGetDashboardURLToolNotRunning(t *testing.T) {
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	tool := newGetDashboardURLTool()
+
+	invalid, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "get_dashboard_url", Arguments: map[string]interface{}{"projectDir": "/nonexistent/path/xyz123"}},
+	})
+	require.NoError(t, err)
+	require.True(t, invalid.IsError, "Expected error result for invalid project directory")
+
+	// No azd app run session is running for the test's working directory
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: "get_dashboard_url", Arguments: map[string]interface{}{}},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.JSONEq(t, `{"running": false, "reachable": false}`, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestCleanDependenciesTool(t *testing.T) {
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	// The tool cleans the current directory when projectDir isn't given
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+
+	azureYaml := "name: test\nservices:\n  web:\n    project: ./web\n    language: js\n  api:\n    project: ./api\n    language: python\n"
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "azure.yaml"), []byte(azureYaml), 0o600))
+	nodeModules := filepath.Join(projectDir, "web", "node_modules")
+	venv := filepath.Join(projectDir, "api", ".venv")
+	require.NoError(t, os.MkdirAll(nodeModules, 0o750))
+	require.NoError(t, os.MkdirAll(venv, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "web", "package.json"), []byte(`{"name":"web"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "api", "requirements.txt"), []byte("flask\n"), 0o600))
+
+	tool := newCleanDependenciesTool()
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "clean_dependencies", Arguments: args},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// Nothing is removed without confirm
+	result := call(map[string]interface{}{})
+	require.True(t, result.IsError)
+	require.DirExists(t, nodeModules)
+	require.DirExists(t, venv)
+
+	result = call(map[string]interface{}{"projectDir": "/nonexistent/path/xyz123", "confirm": true})
+	require.True(t, result.IsError, "Expected error result for invalid project directory")
+	result = call(map[string]interface{}{"services": "missing", "confirm": true})
+	require.True(t, result.IsError, "Expected error result for a service not in azure.yaml")
+	result = call(map[string]interface{}{"services": "../web", "confirm": true})
+	require.True(t, result.IsError, "Expected error result for an invalid service name")
+	require.DirExists(t, nodeModules)
+	require.DirExists(t, venv)
+
+	result = call(map[string]interface{}{"services": "api", "confirm": true})
+	require.False(t, result.IsError)
+	var cleaned CleanDependenciesResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &cleaned))
+	require.Equal(t, []string{venv}, cleaned.Removed)
+	require.Empty(t, cleaned.Errors)
+	require.NoDirExists(t, venv)
+	require.DirExists(t, nodeModules)
+
+	result = call(map[string]interface{}{"confirm": true})
+	require.False(t, result.IsError)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &cleaned))
+	require.Equal(t, []string{nodeModules}, cleaned.Removed)
+	require.NoDirExists(t, nodeModules)
+}
+
+func TestDashboardStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	status := dashboardStatus(context.Background(), server.URL)
+	require.Equal(t, DashboardStatus{Running: true, URL: server.URL, Reachable: true}, status)
+
+	// A registered dashboard that stopped answering is still reported, as unreachable
+	server.Close()
+	status = dashboardStatus(context.Background(), server.URL)
+	require.Equal(t, DashboardStatus{Running: true, URL: server.URL, Reachable: false}, status)
+}
+
+func TestDashboardRestartTimeoutFor(t *testing.T) {
+	ping := `{"status":"ok","restartTimeout":"5m5s"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(ping))
+	}))
+	defer server.Close()
+
+	// The run's restart timeout, which follows --ready-timeout, plus a margin
+	require.Equal(t, 5*time.Minute+5*time.Second+dashboardRestartMargin, dashboardRestartTimeoutFor(context.Background(), server.URL))
+
+	// A dashboard that doesn't report one gets the default
+	ping = `{"status":"ok"}`
+	require.Equal(t, dashboardRestartTimeout, dashboardRestartTimeoutFor(context.Background(), server.URL))
+}
+
+func TestGetHealthViaDashboard(t *testing.T) {
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"services": [
+			{"serviceName": "api", "status": "healthy", "checkType": "http", "endpoint": "http://localhost:3000/health", "statusCode": 200, "timestamp": "2026-01-02T03:04:05Z"},
+			{"serviceName": "db", "status": "unhealthy", "checkType": "tcp", "error": "connection refused", "timestamp": "2026-01-02T03:04:05Z"},
+			{"serviceName": "worker", "status": "degraded", "checkType": "process"}
+		]}`))
+	}))
+	defer server.Close()
+
+	report, err := getHealthViaDashboard(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "GET /api/health", request)
+
+	want := []ServiceHealth{
+		{Service: "api", Type: "http", Healthy: true, LastCheck: "2026-01-02T03:04:05Z", Detail: "healthy (HTTP 200 from http://localhost:3000/health)"},
+		{Service: "db", Type: "tcp", Healthy: false, LastCheck: "2026-01-02T03:04:05Z", Detail: "unhealthy: connection refused"},
+		{Service: "worker", Type: "process", Healthy: false, Detail: "degraded"},
+	}
+	require.Equal(t, want, extractServiceHealth(report))
+}
+
+// infoJSON returns the structured azd app info output for services, as MCP tools receive it.
+func infoJSON(t *testing.T, services []*serviceinfo.ServiceInfo, azureEnv map[string]string) map[string]interface{} {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	printErr := printInfoJSON(t.TempDir(), services, azureEnv, nil)
+
+	w.Close()
+	os.Stdout = oldStdout
+	require.NoError(t, printErr)
+
+	var info map[string]interface{}
+	require.NoError(t, json.NewDecoder(r).Decode(&info))
+	return info
+}
+
+func TestExtractEnvironmentVariables(t *testing.T) {
+	azure := &serviceinfo.AzureServiceInfo{URL: "https://example.azurewebsites.net"}
+	info := infoJSON(t, []*serviceinfo.ServiceInfo{
+		{Name: "api", Azure: azure},
+		{Name: "web", Azure: azure},
+		{Name: "docs"},
+	}, map[string]string{
+		"API_URL":                             "https://api.example.com",
+		"API_OPENAI_KEY":                      "sk-abcdefgh1234",
+		"API_DB_PASSWORD":                     "hunter2",
+		"SERVICE_API_CONNECTION_STRING":       "Endpoint=sb://x;SharedAccessKey=abc",
+		"WEB_SESSION_SECRET":                  "s3cr3t-value-42",
+		"WEB_PUBLIC_KEY":                      "pk-123",
+		"AZURE_SUBSCRIPTION_ID_NOT_A_SERVICE": "00000000",
+	})
+
+	got := extractEnvironmentVariables(info, "", false)
+	want := map[string]interface{}{
+		"api": map[string]interface{}{
+			"API_URL":                       "https://api.example.com",
+			"API_OPENAI_KEY":                "***",
+			"API_DB_PASSWORD":               "***",
+			"SERVICE_API_CONNECTION_STRING": "***",
+		},
+		"web": map[string]interface{}{
+			"WEB_SESSION_SECRET": "***",
+			"WEB_PUBLIC_KEY":     "pk-123",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractEnvironmentVariables() = %+v, want %+v", got, want)
+	}
+
+	// reveal returns the original values, and the service filter still applies
+	got = extractEnvironmentVariables(info, "api", true)
+	if len(got) != 1 || got["api"].(map[string]interface{})["API_OPENAI_KEY"] != "sk-abcdefgh1234" {
+		t.Errorf("extractEnvironmentVariables() with reveal = %+v, want unmasked api values", got)
+	}
+
+	// get_services masks the same values in the full info output
+	maskInfoEnvironment(info)
+	for _, svc := range info["services"].([]interface{}) {
+		env, _ := svc.(map[string]interface{})[infoEnvironmentKey].(map[string]interface{})
+		for key, value := range env {
+			if service.IsSensitiveEnvKey(key) && value != service.RedactedValue {
+				t.Errorf("maskInfoEnvironment() left %s = %v", key, value)
+			}
+		}
+	}
+}
+
+func TestGetEnvironmentVariablesTool_RevealRateLimited(t *testing.T) {
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(1, time.Minute)))
+	tool := newGetEnvironmentVariablesTool()
+
+	call := func() *mcp.CallToolResult {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Name:      "get_environment_variables",
+				Arguments: map[string]interface{}{"reveal": true, "projectDir": "/nonexistent/path/xyz123"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Handler returned Go error: %v", err)
+		}
+		return result
+	}
+
+	// The first reveal spends the only token; the second is rejected before any other work
+	if text := call().Content[0].(mcp.TextContent).Text; strings.Contains(text, "Rate limit exceeded") {
+		t.Fatalf("First reveal should not be rate limited, got %q", text)
+	}
+	if text := call().Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Rate limit exceeded") {
+		t.Errorf("Second reveal should be rate limited, got %q", text)
+	}
+}
+
 func TestGetServiceLogsToolDefinition(t *testing.T) {
 	tool := newGetServiceLogsTool()
 
@@ -365,6 +857,26 @@ func TestServiceConfigResourceDefinition(t *testing.T) {
 	}
 }
 
+func TestServiceLogsResourceDefinition(t *testing.T) {
+	resource := newServiceLogsResource()
+
+	if resource.Template.Name != "service-logs" {
+		t.Errorf("Expected resource name 'service-logs', got '%s'", resource.Template.Name)
+	}
+	if resource.Template.MIMEType != "text/plain" {
+		t.Errorf("Expected MIME type 'text/plain', got '%s'", resource.Template.MIMEType)
+	}
+	if resource.Template.Annotations == nil || len(resource.Template.Annotations.Audience) == 0 {
+		t.Error("service-logs resource should have audience annotations")
+	}
+	if resource.Template.URITemplate.Match("azure://project/logs/api") == nil {
+		t.Error("service-logs template should match azure://project/logs/api")
+	}
+	if resource.Handler == nil {
+		t.Error("service-logs resource should have a handler")
+	}
+}
+
 // Tests for helper functions
 
 func TestGetStringParam(t *testing.T) {
@@ -1097,15 +1609,21 @@ func TestAllToolsHaveTitles(t *testing.T) {
 		expected string
 	}{
 		{"get_services", newGetServicesTool, "Get Running Services"},
+		{"get_ports", newGetPortsTool, "Get Service Ports"},
+		{"get_health", newGetHealthTool, "Get Service Health"},
+		{"get_dashboard_url", newGetDashboardURLTool, "Get Dashboard URL"},
 		{"get_service_logs", newGetServiceLogsTool, "Get Service Logs"},
 		{"get_project_info", newGetProjectInfoTool, "Get Project Information"},
 		{"run_services", newRunServicesTool, "Run Development Services"},
 		{"stop_services", newStopServicesTool, "Stop Running Services"},
 		{"restart_service", newRestartServiceTool, "Restart Service"},
 		{"install_dependencies", newInstallDependenciesTool, "Install Project Dependencies"},
+		{"clean_dependencies", newCleanDependenciesTool, "Clean Project Dependencies"},
+		{"run_tests", newRunTestsTool, "Run Service Tests"},
 		{"check_requirements", newCheckRequirementsTool, "Check Prerequisites"},
 		{"get_environment_variables", newGetEnvironmentVariablesTool, "Get Environment Variables"},
 		{"set_environment_variable", newSetEnvironmentVariableTool, "Set Environment Variable"},
+		{"add_service", newAddServiceTool, "Add Service to azure.yaml"},
 	}
 
 	for _, tt := range tools {
@@ -1533,6 +2051,99 @@ func TestStopServicesToolHandler(t *testing.T) {
 	}
 }
 
+func TestStopServicesViaDashboard(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("service") {
+		case "":
+			_, _ = w.Write([]byte(`{"success":false,"message":"1 service(s) stopped, 1 failed","services":[{"name":"api","success":true,"duration":"1.2s"},{"name":"web","success":false,"error":"process did not exit"}]}`))
+		case "api":
+			_, _ = w.Write([]byte(`{"success":true,"message":"Service 'api' stopped successfully"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Service 'missing' not found"}`))
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	result, err := stopServicesViaDashboard(ctx, server.URL, "")
+	require.NoError(t, err)
+	if result.SuccessCount != 1 || result.FailureCount != 1 || len(result.Results) != 2 {
+		t.Fatalf("Expected one stopped and one failed service, got %+v", result)
+	}
+	if api := result.Results[0]; api.ServiceName != "api" || !api.Success || api.Status != "stopped" {
+		t.Errorf("Expected api to be stopped, got %+v", api)
+	}
+	if web := result.Results[1]; web.ServiceName != "web" || web.Success || web.Error != "process did not exit" {
+		t.Errorf("Expected web to fail, got %+v", web)
+	}
+
+	result, err = stopServicesViaDashboard(ctx, server.URL, "api")
+	require.NoError(t, err)
+	if !result.Success || len(result.Results) != 1 || result.Results[0].ServiceName != "api" {
+		t.Errorf("Expected api to be stopped, got %+v", result)
+	}
+
+	if _, err := stopServicesViaDashboard(ctx, server.URL, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	want := []string{"POST /api/services/stop", "POST /api/services/stop?service=api", "POST /api/services/stop?service=missing"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestRestartServiceViaDashboard(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("service") == "api" {
+			_, _ = w.Write([]byte(`{"success":true,"message":"Service 'api' restarted successfully","status":"running","startupDuration":"2.3s"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"web restarted but did not become ready within 1m0s: health check failed"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	result, err := restartServiceViaDashboard(ctx, server.URL, "api")
+	require.NoError(t, err)
+	if !result.Success || result.ServiceName != "api" || result.Status != "running" || result.StartupDuration != "2.3s" {
+		t.Errorf("Expected api to be running after 2.3s, got %+v", result)
+	}
+
+	if _, err := restartServiceViaDashboard(ctx, server.URL, "web"); err == nil || !strings.Contains(err.Error(), "did not become ready") {
+		t.Errorf("Expected readiness error, got %v", err)
+	}
+
+	want := []string{"POST /api/services/restart?service=api", "POST /api/services/restart?service=web"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestStopServicesToolConfirmValidatesServiceName(t *testing.T) {
+	tool := newStopServicesTool()
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      "stop_services",
+			Arguments: map[string]interface{}{"serviceName": "../api", "confirm": true},
+		},
+	}
+
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	if !result.IsError {
+		t.Error("stop_services should reject an invalid service name before stopping anything")
+	}
+}
+
 // TestCheckRequirementsToolValidation tests validation for check_requirements tool
 func TestCheckRequirementsToolValidation(t *testing.T) {
 	tool := newCheckRequirementsTool()
@@ -1630,6 +2241,255 @@ func TestInstallDependenciesToolValidation(t *testing.T) {
 	}
 }
 
+func TestRunTestsToolValidation(t *testing.T) {
+	// Use a fresh limiter so earlier tests cannot exhaust the shared budget
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	tool := newRunTestsTool()
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		expectErrorMsg string
+	}{
+		{
+			name:           "Invalid test type",
+			args:           map[string]interface{}{"testType": "smoke"},
+			expectErrorMsg: "invalid testtype",
+		},
+		{
+			name:           "Invalid service name",
+			args:           map[string]interface{}{"testType": "unit", "services": "api,../etc"},
+			expectErrorMsg: "invalid",
+		},
+		{
+			name:           "Invalid project dir",
+			args:           map[string]interface{}{"projectDir": "/nonexistent/path/xyz123"},
+			expectErrorMsg: "project directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "run_tests",
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := tool.Handler(ctx, request)
+			if err != nil {
+				t.Fatalf("Handler returned Go error: %v", err)
+			}
+			if result == nil || !result.IsError {
+				t.Fatal("Expected error result")
+			}
+			if textContent, ok := result.Content[0].(mcp.TextContent); ok {
+				if !strings.Contains(strings.ToLower(textContent.Text), tt.expectErrorMsg) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.expectErrorMsg, textContent.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestAddServiceToolValidation(t *testing.T) {
+	// Use a fresh limiter so earlier tests cannot exhaust the shared budget
+	defer SetGlobalRateLimiter(SetGlobalRateLimiter(NewTokenBucket(10, time.Second)))
+
+	tool := newAddServiceTool()
+	require.Equal(t, "add_service", tool.Tool.Name)
+	require.False(t, *tool.Tool.Annotations.ReadOnlyHint)
+	require.ElementsMatch(t, []string{"name", "language", "project"}, tool.Tool.InputSchema.Required)
+
+	valid := map[string]interface{}{"name": "api", "language": "python", "project": "./api"}
+	with := func(key string, value interface{}) map[string]interface{} {
+		args := make(map[string]interface{}, len(valid)+1)
+		for k, v := range valid {
+			args[k] = v
+		}
+		args[key] = value
+		return args
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]interface{}
+		expectErrorMsg string
+	}{
+		{"Missing name", map[string]interface{}{"language": "python", "project": "./api"}, "name"},
+		{"Invalid name", with("name", "../api"), "invalid service name"},
+		{"Invalid language", with("language", "cobol"), "invalid language"},
+		{"Invalid host", with("host", "mainframe"), "invalid host"},
+		{"Invalid ports", with("ports", "3000,99999"), "invalid port"},
+		{"Invalid project dir", with("projectDir", "/nonexistent/path/xyz123"), "project directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      "add_service",
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := tool.Handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("Handler returned Go error: %v", err)
+			}
+			if result == nil || !result.IsError {
+				t.Fatal("Expected error result")
+			}
+			if textContent, ok := result.Content[0].(mcp.TextContent); ok {
+				if !strings.Contains(strings.ToLower(textContent.Text), tt.expectErrorMsg) {
+					t.Errorf("Expected error containing '%s', got '%s'", tt.expectErrorMsg, textContent.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestAddProjectService(t *testing.T) {
+	dir := t.TempDir()
+	azureYamlPath := filepath.Join(dir, "azure.yaml")
+	original := `# yaml-language-server: $schema=https://raw.githubusercontent.com/Azure/azure-dev/main/schemas/v1.0/azure.yaml.json
+name: sample
+
+services:
+  # The public website
+  web:
+    project: ./src/web
+    language: ts
+    host: containerapp
+`
+	require.NoError(t, os.WriteFile(azureYamlPath, []byte(original), 0600))
+
+	result, err := addProjectService(azureYamlPath, "api", "python", "src/api", "appservice", []string{"8000", "8080:80"})
+	require.NoError(t, err)
+	require.Equal(t, "api", result.Added)
+	require.Equal(t, []AzureYamlServiceEntry{
+		{Name: "api", Language: "python", Project: "./src/api", Host: "appservice", Ports: []string{"8000", "8080:80"}},
+		{Name: "web", Language: "ts", Project: "./src/web", Host: "containerapp"},
+	}, result.Services)
+
+	data, err := os.ReadFile(azureYamlPath)
+	require.NoError(t, err)
+	updated := string(data)
+
+	// The existing content, comments and indentation are kept; the new service is appended
+	require.True(t, strings.HasPrefix(updated, "# yaml-language-server:"), updated)
+	require.Contains(t, updated, "  # The public website\n  web:\n    project: ./src/web\n")
+	require.Contains(t, updated, "  api:\n    project: ./src/api\n    language: python\n    host: appservice\n    ports:\n      - \"8000\"\n      - \"8080:80\"\n")
+
+	// Existing services are never overwritten
+	_, err = addProjectService(azureYamlPath, "web", "js", "./other", "containerapp", nil)
+	require.ErrorContains(t, err, `service "web" already exists`)
+	after, err := os.ReadFile(azureYamlPath)
+	require.NoError(t, err)
+	require.Equal(t, updated, string(after))
+}
+
+func TestResolveServiceProjectPath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	tests := []struct {
+		project string
+		want    string
+		wantErr string
+	}{
+		{project: "src/api", want: "./src/api"},
+		{project: "./src/api/", want: "./src/api"},
+		{project: ".", want: "."},
+		{project: "src/../web", want: "./web"},
+		{project: "", wantErr: "required"},
+		{project: "../sibling", wantErr: "within the project"},
+		{project: "src/../../sibling", wantErr: "within the project"},
+		{project: outside, wantErr: "must be relative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			got, err := resolveServiceProjectPath(root, tt.project)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("symlink escaping the project", func(t *testing.T) {
+		if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+		_, err := resolveServiceProjectPath(root, "link")
+		require.ErrorContains(t, err, "within the project")
+	})
+}
+
+func TestParseServicePorts(t *testing.T) {
+	ports, err := parseServicePorts(" 3000, 8080:80 ,")
+	require.NoError(t, err)
+	require.Equal(t, []string{"3000", "8080:80"}, ports)
+
+	for _, invalid := range []string{"0", "65536", "http", "1:2:3", "80:"} {
+		_, err := parseServicePorts(invalid)
+		require.ErrorContains(t, err, "invalid port", invalid)
+	}
+}
+
+func TestSummarizeTestRun(t *testing.T) {
+	result := &testrunner.AggregateResult{
+		Services: []*testrunner.TestResult{
+			{Service: "api", Passed: 10, Failed: 0, Skipped: 1, Total: 11, Success: true},
+			{Service: "web", Passed: 3, Failed: 2, Total: 5, Success: false, Failures: []testrunner.TestFailure{{Name: "renders"}}},
+		},
+		Passed:   13,
+		Failed:   2,
+		Skipped:  1,
+		Total:    16,
+		Duration: 4.5,
+		Success:  false,
+	}
+	validations := []testrunner.ServiceValidation{
+		{Name: "api", CanTest: true},
+		{Name: "web", CanTest: true},
+		{Name: "docs", CanTest: false, SkipReason: "no test files found"},
+	}
+
+	summary := summarizeTestRun("unit", result, validations)
+
+	if summary.TestType != "unit" || summary.Success || summary.Passed != 13 || summary.Failed != 2 || summary.Total != 16 {
+		t.Errorf("unexpected totals: %+v", summary)
+	}
+	want := []ServiceTestSummary{
+		{Service: "api", Success: true, Passed: 10, Skipped: 1, Total: 11},
+		{Service: "web", Success: false, Passed: 3, Failed: 2, Total: 5},
+	}
+	if !reflect.DeepEqual(summary.Services, want) {
+		t.Errorf("Services = %+v, want %+v", summary.Services, want)
+	}
+	if len(summary.SkippedServices) != 1 || summary.SkippedServices[0].Service != "docs" || summary.SkippedServices[0].Reason != "no test files found" {
+		t.Errorf("SkippedServices = %+v, want docs with its reason", summary.SkippedServices)
+	}
+
+	// The summary is what the tool returns, so it must marshal to the documented shape
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, key := range []string{`"testType":"unit"`, `"services":[`, `"skippedServices":[`, `"failed":2`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("summary JSON missing %s: %s", key, data)
+		}
+	}
+}
+
 // TestContextCancellation tests that handlers respect context cancellation
 func TestContextCancellation(t *testing.T) {
 	tool := newGetServiceLogsTool()
@@ -1722,6 +2582,65 @@ services:
 }
 
 // TestAzureYamlResourceHandlerMissingFile tests error handling when azure.yaml is missing
+func TestReadServiceLogsText(t *testing.T) {
+	tempDir := t.TempDir()
+	logsDir := filepath.Join(tempDir, ".azure", "logs")
+	require.NoError(t, os.MkdirAll(logsDir, 0750))
+	logContent := `[2024-01-15 10:00:01.000] [INFO] [OUT] starting api
+[2024-01-15 10:00:02.000] [INFO] [OUT] listening on 8080
+[2024-01-15 10:00:03.000] [ERROR] [ERR] connection refused
+`
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "api.log"), []byte(logContent), 0600))
+
+	text, err := readServiceLogsText(context.Background(), tempDir, "api", 2)
+	require.NoError(t, err)
+	if strings.Contains(text, "starting api") {
+		t.Errorf("tail=2 should drop the oldest line, got:\n%s", text)
+	}
+	for _, want := range []string{"listening on 8080", "connection refused"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected logs to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "\x1b[") {
+		t.Errorf("Expected plain text without colors, got:\n%q", text)
+	}
+
+	text, err = readServiceLogsText(context.Background(), tempDir, "web", 10)
+	require.NoError(t, err)
+	if text != "" {
+		t.Errorf("Expected no logs for a service without log files, got:\n%s", text)
+	}
+}
+
+func TestServiceLogsResourceHandlerValidation(t *testing.T) {
+	resource := newServiceLogsResource()
+	read := func(uri string) error {
+		request := mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{
+				URI:       uri,
+				Arguments: map[string]any{},
+			},
+		}
+		// Populate arguments the way the server does after matching the template
+		for name, value := range resource.Template.URITemplate.Match(uri) {
+			request.Params.Arguments[name] = value.V
+		}
+		_, err := resource.Handler(context.Background(), request)
+		return err
+	}
+
+	if err := read("azure://project/logs/api?tail=abc"); err == nil || !strings.Contains(err.Error(), "invalid tail") {
+		t.Errorf("Expected invalid tail error, got %v", err)
+	}
+	if err := read("azure://project/logs/api?tail=0"); err == nil || !strings.Contains(err.Error(), "invalid tail") {
+		t.Errorf("Expected invalid tail error for zero, got %v", err)
+	}
+	if err := read("azure://project/logs/-api"); err == nil || !strings.Contains(err.Error(), "invalid service name") {
+		t.Errorf("Expected invalid service name error, got %v", err)
+	}
+}
+
 func TestAzureYamlResourceHandlerMissingFile(t *testing.T) {
 	// Create temp directory under the current working directory
 	// This is needed because validateProjectDir requires directories to be under cwd or home
@@ -1976,3 +2895,98 @@ func TestGetProjectDirValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestTroubleshootServicePromptDefinition(t *testing.T) {
+	prompt := newTroubleshootServicePrompt()
+
+	if prompt.Prompt.Name != "troubleshoot_service" {
+		t.Errorf("Expected prompt name 'troubleshoot_service', got '%s'", prompt.Prompt.Name)
+	}
+	if len(prompt.Prompt.Arguments) != 1 || prompt.Prompt.Arguments[0].Name != "service" || !prompt.Prompt.Arguments[0].Required {
+		t.Errorf("Expected a single required 'service' argument, got %+v", prompt.Prompt.Arguments)
+	}
+	if prompt.Handler == nil {
+		t.Error("troubleshoot_service prompt should have a handler")
+	}
+}
+
+func TestTroubleshootServicePromptHandlerValidation(t *testing.T) {
+	oldLimiter := globalRateLimiter
+	defer func() { globalRateLimiter = oldLimiter }()
+	globalRateLimiter = NewTokenBucket(1, time.Minute)
+
+	prompt := newTroubleshootServicePrompt()
+	get := func(serviceName string) error {
+		request := mcp.GetPromptRequest{
+			Params: mcp.GetPromptParams{Name: "troubleshoot_service", Arguments: map[string]string{"service": serviceName}},
+		}
+		_, err := prompt.Handler(context.Background(), request)
+		return err
+	}
+
+	if err := get("-api"); err == nil || !strings.Contains(err.Error(), "invalid service name") {
+		t.Errorf("Expected invalid service name error, got %v", err)
+	}
+	if err := get("api"); err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+}
+
+func TestBuildTroubleshootPrompt(t *testing.T) {
+	tempDir := t.TempDir()
+	azureYaml := `name: test
+services:
+  worker:
+    language: python
+    project: ./worker
+    type: process
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "azure.yaml"), []byte(azureYaml), 0600))
+
+	logsDir := filepath.Join(tempDir, ".azure", "logs")
+	require.NoError(t, os.MkdirAll(logsDir, 0750))
+	logContent := `[2024-01-15 10:00:01.000] [INFO] [OUT] starting worker
+[2024-01-15 10:00:02.000] [ERROR] [ERR] ModuleNotFoundError: No module named 'redis'
+[2024-01-15 10:00:03.000] [ERROR] [ERR] connect failed: host=db user=app password=hunter2
+`
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "worker.log"), []byte(logContent), 0600))
+
+	snapshot := &service.RunSnapshot{
+		CreatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Services: []service.RuntimeSnapshot{{
+			Name:        "worker",
+			Command:     "python",
+			Args:        []string{"main.py"},
+			WorkingDir:  filepath.Join(tempDir, "worker"),
+			Env:         map[string]string{"REDIS_URL": "redis://localhost", "API_KEY": "********"},
+			HealthCheck: service.HealthCheckSnapshot{Type: "process"},
+		}},
+	}
+	require.NoError(t, service.WriteRunSnapshot(tempDir, snapshot))
+
+	text, err := buildTroubleshootPrompt(context.Background(), tempDir, "worker")
+	require.NoError(t, err)
+	for _, want := range []string{
+		"- Language: python",
+		"- Command: python main.py",
+		"- Environment variables: API_KEY, REDIS_URL",
+		"## Health",
+		"No module named 'redis'",
+		"## Instructions",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected prompt to contain %q, got:\n%s", want, text)
+		}
+	}
+	// Error lines are redacted like azd app logs output
+	for _, unwanted := range []string{"starting worker", "redis://localhost", "hunter2"} {
+		if strings.Contains(text, unwanted) {
+			t.Errorf("Expected prompt not to contain %q, got:\n%s", unwanted, text)
+		}
+	}
+
+	_, err = buildTroubleshootPrompt(context.Background(), tempDir, "web")
+	if err == nil || !strings.Contains(err.Error(), `service "web" not found in azure.yaml (services: worker)`) {
+		t.Errorf("Expected unknown service error, got %v", err)
+	}
+}
@@ -7,14 +7,21 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/healthcheck"
 	"github.com/jongio/azd-app/cli/src/internal/security"
 	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/testing"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
 )
 
 // newGetServicesTool creates the get_services tool
@@ -45,11 +52,197 @@ func newGetServicesTool() server.ServerTool {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to get services: %v", err)), nil
 			}
 
+			maskInfoEnvironment(result)
 			return marshalToolResult(result)
 		},
 	}
 }
 
+// newGetPortsTool creates the get_ports tool
+func newGetPortsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_ports",
+			mcp.WithTitleAnnotation("Get Service Ports"),
+			mcp.WithDescription("Get the ports used by running services in the current azd app project. Returns a JSON array of {service, port, type, url} entries, one per service listening on a port."),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if result := checkRateLimitWithName("get_ports"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			cmdArgs, err := extractProjectDirArg(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			result, err := executeAzdAppCommand(ctx, "info", cmdArgs)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get ports: %v", err)), nil
+			}
+
+			return marshalToolResult(extractServicePorts(result))
+		},
+	}
+}
+
+// newGetHealthTool creates the get_health tool
+func newGetHealthTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_health",
+			mcp.WithTitleAnnotation("Get Service Health"),
+			mcp.WithDescription("Check the health of the services of the running azd app run session by running their configured health checks. Returns a JSON array of {service, type, healthy, lastCheck, detail} entries, or an empty array if no services are running."),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if result := checkRateLimitWithName("get_health"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			projectDir, err := extractValidatedProjectDir(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			// Without a running azd app run session there is nothing to check
+			dashboardURL, err := findDashboardURL(ctx, projectDir)
+			if errors.Is(err, errDashboardNotRegistered) {
+				return marshalToolResult([]ServiceHealth{})
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to check health: %v", err)), nil
+			}
+
+			cmdCtx, cancel := context.WithTimeout(ctx, defaultCommandTimeout)
+			defer cancel()
+			report, err := getHealthViaDashboard(cmdCtx, dashboardURL)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to check health: %v", err)), nil
+			}
+
+			return marshalToolResult(extractServiceHealth(report))
+		},
+	}
+}
+
+// newGetDashboardURLTool creates the get_dashboard_url tool
+func newGetDashboardURLTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_dashboard_url",
+			mcp.WithTitleAnnotation("Get Dashboard URL"),
+			mcp.WithDescription("Get the URL of the dashboard of the running azd app run session, to point the user at it, and whether it is reachable right now. Returns {running: false} if no session is running."),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOutputSchema[DashboardStatus](),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if result := checkRateLimitWithName("get_dashboard_url"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			projectDir, err := extractValidatedProjectDir(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			dashboardURL, err := registeredDashboardURL(ctx, projectDir)
+			if err != nil {
+				return marshalToolResult(DashboardStatus{Running: false})
+			}
+			return marshalToolResult(dashboardStatus(ctx, dashboardURL))
+		},
+	}
+}
+
+// extractServiceHealth derives the get_health entries from a health report. Only healthy
+// services count as healthy; degraded ones say so in their detail.
+func extractServiceHealth(report *healthcheck.HealthReport) []ServiceHealth {
+	health := make([]ServiceHealth, 0, len(report.Services))
+	for _, result := range report.Services {
+		detail := string(result.Status)
+		switch {
+		case result.Error != "":
+			detail += ": " + result.Error
+		case result.StatusCode > 0:
+			detail += fmt.Sprintf(" (HTTP %d from %s)", result.StatusCode, result.Endpoint)
+		case result.Endpoint != "":
+			detail += fmt.Sprintf(" (%s)", result.Endpoint)
+		}
+
+		entry := ServiceHealth{
+			Service: result.ServiceName,
+			Type:    string(result.CheckType),
+			Healthy: result.Status == healthcheck.HealthStatusHealthy,
+			Detail:  detail,
+		}
+		if !result.Timestamp.IsZero() {
+			entry.LastCheck = result.Timestamp.Format(time.RFC3339)
+		}
+		health = append(health, entry)
+	}
+	return health
+}
+
+// extractServicePorts derives the port list from azd app info JSON output.
+// Services without a local port (not running, or process services without one) are skipped.
+func extractServicePorts(info map[string]interface{}) []ServicePort {
+	ports := []ServicePort{}
+	services, ok := info["services"].([]interface{})
+	if !ok {
+		return ports
+	}
+
+	for _, svc := range services {
+		svcMap, ok := svc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		local, ok := svcMap["local"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		port, ok := local["port"].(float64)
+		if !ok || port <= 0 {
+			continue
+		}
+
+		name, _ := svcMap["name"].(string)
+		serviceType, _ := local["serviceType"].(string)
+		url, _ := local["url"].(string)
+		ports = append(ports, ServicePort{
+			Service: name,
+			Port:    int(port),
+			Type:    serviceType,
+			URL:     url,
+		})
+	}
+
+	return ports
+}
+
 // newGetServiceLogsTool creates the get_service_logs tool
 func newGetServiceLogsTool() server.ServerTool {
 	return server.ServerTool{
@@ -507,7 +700,7 @@ func newStopServicesTool() server.ServerTool {
 		Tool: mcp.NewTool(
 			"stop_services",
 			mcp.WithTitleAnnotation("Stop Running Services"),
-			mcp.WithDescription("Stop all running development services. This will gracefully shut down services started with run_services."),
+			mcp.WithDescription("Stop all running development services. This will gracefully shut down services started with run_services. Set confirm to true to stop the services of the running azd app run session through its dashboard."),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
@@ -517,6 +710,9 @@ func newStopServicesTool() server.ServerTool {
 			mcp.WithString("serviceName",
 				mcp.Description("Optional specific service to stop. If not provided, stops all running services."),
 			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Set to true to actually stop the services of the running azd app run session, gracefully, the same way Ctrl+C does. Returns the services that were stopped."),
+			),
 		),
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := getArgsMap(request)
@@ -527,6 +723,31 @@ func newStopServicesTool() server.ServerTool {
 				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
 			}
 
+			serviceName, hasServiceName := getStringParam(args, "serviceName")
+			if hasServiceName {
+				if valErr := security.ValidateServiceName(serviceName, false); valErr != nil {
+					return mcp.NewToolResultError(valErr.Error()), nil
+				}
+			}
+
+			// Stopping the services of an azd app run session goes through its dashboard
+			if getBoolParam(args, "confirm") {
+				if result := checkRateLimitWithName("stop_services"); result != nil {
+					return result, nil
+				}
+				dashboardURL, err := findDashboardURL(ctx, projectDir)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to find running services: %v", err)), nil
+				}
+				cmdCtx, cancel := context.WithTimeout(ctx, defaultCommandTimeout)
+				defer cancel()
+				result, err := stopServicesViaDashboard(cmdCtx, dashboardURL, serviceName)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to stop services: %v", err)), nil
+				}
+				return marshalToolResult(result)
+			}
+
 			// Create service controller
 			ctrl, err := NewServiceController(projectDir)
 			if err != nil {
@@ -534,10 +755,7 @@ func newStopServicesTool() server.ServerTool {
 			}
 
 			// Check if a specific service was requested
-			if serviceName, ok := getStringParam(args, "serviceName"); ok {
-				if valErr := security.ValidateServiceName(serviceName, false); valErr != nil {
-					return mcp.NewToolResultError(valErr.Error()), nil
-				}
+			if hasServiceName {
 				result := ctrl.StopService(ctx, serviceName)
 				return marshalToolResult(result)
 			}
@@ -613,7 +831,7 @@ func newRestartServiceTool() server.ServerTool {
 		Tool: mcp.NewTool(
 			"restart_service",
 			mcp.WithTitleAnnotation("Restart Service"),
-			mcp.WithDescription("Restart a specific service. This will stop and start the specified service."),
+			mcp.WithDescription("Restart a specific service of the running azd app run session. Stops the service, starts it again with the same command and environment, and waits for its health check. Returns the service's new status and how long it took to become healthy."),
 			mcp.WithReadOnlyHintAnnotation(false),
 			mcp.WithIdempotentHintAnnotation(false),
 			mcp.WithDestructiveHintAnnotation(false),
@@ -638,19 +856,27 @@ func newRestartServiceTool() server.ServerTool {
 				return mcp.NewToolResultError(valErr.Error()), nil
 			}
 
+			if result := checkRateLimitWithName("restart_service"); result != nil {
+				return result, nil
+			}
+
 			// Get project directory
 			projectDir, err := extractValidatedProjectDir(args)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
 			}
 
-			// Create service controller
-			ctrl, err := NewServiceController(projectDir)
+			// Services run by azd app run are restarted by its supervisor, through the dashboard
+			dashboardURL, err := findDashboardURL(ctx, projectDir)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to initialize service controller: %v", err)), nil
+				return mcp.NewToolResultError(fmt.Sprintf("No services are running, so there is nothing to restart (%v). Start them with run_services or azd app run.", err)), nil
+			}
+			cmdCtx, cancel := context.WithTimeout(ctx, dashboardRestartTimeoutFor(ctx, dashboardURL))
+			defer cancel()
+			result, err := restartServiceViaDashboard(cmdCtx, dashboardURL, serviceName)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to restart service %s: %v", serviceName, err)), nil
 			}
-
-			result := ctrl.RestartService(ctx, serviceName)
 			return marshalToolResult(result)
 		},
 	}
@@ -716,6 +942,214 @@ func newInstallDependenciesTool() server.ServerTool {
 	}
 }
 
+// newCleanDependenciesTool creates the clean_dependencies tool
+func newCleanDependenciesTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"clean_dependencies",
+			mcp.WithTitleAnnotation("Clean Project Dependencies"),
+			mcp.WithDescription("Remove the installed dependency directories of the detected projects (node_modules, .venv, .NET obj and bin), the same directories 'azd app deps --clean' removes. Requires confirm to be true. Returns the directories that were removed."),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithOutputSchema[CleanDependenciesResult](),
+			mcp.WithString("services",
+				mcp.Description("Optional comma-separated list of services whose dependencies to remove. If not provided, cleans all detected projects."),
+			),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Must be true to remove the directories; they have to be reinstalled with install_dependencies afterwards."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// Apply rate limiting to prevent abuse of expensive operations
+			if result := checkRateLimitWithName("clean_dependencies"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			projectDir, err := extractValidatedProjectDir(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			var serviceFilter []string
+			if services, ok := getStringParam(args, "services"); ok {
+				for _, name := range strings.Split(services, ",") {
+					name = strings.TrimSpace(name)
+					if name == "" {
+						continue
+					}
+					if valErr := security.ValidateServiceName(name, false); valErr != nil {
+						return mcp.NewToolResultError(valErr.Error()), nil
+					}
+					serviceFilter = append(serviceFilter, name)
+				}
+			}
+			// Without azure.yaml the filter would match every project, so unknown names are rejected up front
+			if len(serviceFilter) > 0 {
+				azureYamlPath, err := detector.FindAzureYaml(projectDir)
+				if err != nil || azureYamlPath == "" {
+					return mcp.NewToolResultError("azure.yaml not found - services can only be selected in a project with azure.yaml"), nil
+				}
+				azureYaml, err := parseAzureYaml(azureYamlPath)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to parse azure.yaml: %v", err)), nil
+				}
+				for _, name := range serviceFilter {
+					if _, ok := azureYaml.Services[name]; !ok {
+						return mcp.NewToolResultError(fmt.Sprintf("service %q is not defined in azure.yaml", name)), nil
+					}
+				}
+			}
+
+			if !getBoolParam(args, "confirm") {
+				return mcp.NewToolResultError("clean_dependencies deletes dependency directories; call it again with confirm set to true to proceed"), nil
+			}
+
+			nodeProjects, err := detector.FindNodeProjects(projectDir)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to detect Node.js projects: %v", err)), nil
+			}
+			pythonProjects, err := detector.FindPythonProjects(projectDir)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to detect Python projects: %v", err)), nil
+			}
+			dotnetProjects, err := detector.FindDotnetProjects(projectDir)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to detect .NET projects: %v", err)), nil
+			}
+			if len(serviceFilter) > 0 {
+				nodeProjects, pythonProjects, dotnetProjects = filterProjectsByService(nodeProjects, pythonProjects, dotnetProjects, serviceFilter, projectDir)
+			}
+
+			removed, errs := removeDependencyDirs(dependencyCleanTargets(nodeProjects, pythonProjects, dotnetProjects))
+			result := CleanDependenciesResult{Removed: removed}
+			for _, err := range errs {
+				result.Errors = append(result.Errors, err.Error())
+			}
+
+			return marshalToolResult(result)
+		},
+	}
+}
+
+// newRunTestsTool creates the run_tests tool
+func newRunTestsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"run_tests",
+			mcp.WithTitleAnnotation("Run Service Tests"),
+			mcp.WithDescription("Run tests for the services defined in azure.yaml. Services without a detectable test setup are skipped. Returns pass/fail counts per service."),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOutputSchema[TestRunSummary](),
+			mcp.WithString("testType",
+				mcp.Description("Optional test type: 'unit', 'integration', 'e2e', or 'all' (default)."),
+			),
+			mcp.WithString("services",
+				mcp.Description("Optional comma-separated list of services to test. If not provided, tests all services."),
+			),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// Apply rate limiting to prevent abuse of expensive operations
+			if result := checkRateLimitWithName("run_tests"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			projectDir, err := extractValidatedProjectDir(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			testType := "all"
+			if tt, ok := getStringParam(args, "testType"); ok {
+				if valErr := validateEnumParam(tt, allowedTestTypes, "testType"); valErr != nil {
+					return mcp.NewToolResultError(valErr.Error()), nil
+				}
+				testType = tt
+			}
+
+			var serviceFilter []string
+			if services, ok := getStringParam(args, "services"); ok {
+				for _, name := range strings.Split(services, ",") {
+					name = strings.TrimSpace(name)
+					if name == "" {
+						continue
+					}
+					if valErr := security.ValidateServiceName(name, false); valErr != nil {
+						return mcp.NewToolResultError(valErr.Error()), nil
+					}
+					serviceFilter = append(serviceFilter, name)
+				}
+			}
+
+			azureYamlPath, err := detector.FindAzureYaml(projectDir)
+			if err != nil || azureYamlPath == "" {
+				return mcp.NewToolResultError("azure.yaml not found - create one to define services for testing"), nil
+			}
+
+			// Test commands must not write to stdout, which carries the MCP protocol
+			orchestrator := testing.NewTestOrchestrator(&testing.TestConfig{CommandOutput: os.Stderr})
+			if err := orchestrator.LoadServicesFromAzureYaml(azureYamlPath); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to load services: %v", err)), nil
+			}
+
+			result, validations, err := orchestrator.ExecuteTestsWithValidation(testType, serviceFilter)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to run tests: %v", err)), nil
+			}
+
+			return marshalToolResult(summarizeTestRun(testType, result, validations))
+		},
+	}
+}
+
+// summarizeTestRun reduces test results to per-service pass/fail counts for the run_tests tool.
+func summarizeTestRun(testType string, result *testing.AggregateResult, validations []testing.ServiceValidation) TestRunSummary {
+	summary := TestRunSummary{
+		TestType: testType,
+		Success:  result.Success,
+		Passed:   result.Passed,
+		Failed:   result.Failed,
+		Skipped:  result.Skipped,
+		Total:    result.Total,
+		Duration: result.Duration,
+		Services: make([]ServiceTestSummary, 0, len(result.Services)),
+		Error:    result.Error,
+	}
+
+	for _, svc := range result.Services {
+		summary.Services = append(summary.Services, ServiceTestSummary{
+			Service: svc.Service,
+			Success: svc.Success,
+			Passed:  svc.Passed,
+			Failed:  svc.Failed,
+			Skipped: svc.Skipped,
+			Total:   svc.Total,
+			Error:   svc.Error,
+		})
+	}
+
+	for _, v := range testing.GetSkippedServices(validations) {
+		summary.SkippedServices = append(summary.SkippedServices, SkippedTestService{
+			Service: v.Name,
+			Reason:  v.SkipReason,
+		})
+	}
+
+	return summary
+}
+
 // newCheckRequirementsTool creates the check_requirements tool
 func newCheckRequirementsTool() server.ServerTool {
 	return server.ServerTool{
@@ -755,7 +1189,7 @@ func newGetEnvironmentVariablesTool() server.ServerTool {
 		Tool: mcp.NewTool(
 			"get_environment_variables",
 			mcp.WithTitleAnnotation("Get Environment Variables"),
-			mcp.WithDescription("Get environment variables configured for services. Returns all environment variables that services will use."),
+			mcp.WithDescription("Get environment variables configured for services. Returns all environment variables that services will use. Values of sensitive variables (names containing SECRET, PASSWORD, TOKEN, CONNECTION_STRING, or KEY but not PUBLIC) are masked unless reveal is true."),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
@@ -765,10 +1199,21 @@ func newGetEnvironmentVariablesTool() server.ServerTool {
 			mcp.WithString("projectDir",
 				mcp.Description("Optional project directory path. If not provided, uses current directory."),
 			),
+			mcp.WithBoolean("reveal",
+				mcp.Description("Return sensitive values unmasked. Only use when the full value is required; rate limited."),
+			),
 		),
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := getArgsMap(request)
 
+			// Revealing secrets is rate limited
+			reveal := getBoolParam(args, "reveal")
+			if reveal {
+				if result := checkRateLimitWithName("get_environment_variables (reveal)"); result != nil {
+					return result, nil
+				}
+			}
+
 			cmdArgs, err := extractProjectDirArg(args)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
@@ -788,30 +1233,77 @@ func newGetEnvironmentVariablesTool() server.ServerTool {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to get environment variables: %v", err)), nil
 			}
 
-			// Extract environment variables from services
-			envVars := make(map[string]interface{})
-			if services, ok := result["services"].([]interface{}); ok {
-				for _, svc := range services {
-					if svcMap, ok := svc.(map[string]interface{}); ok {
-						svcName, _ := svcMap["name"].(string)
+			return marshalToolResult(extractEnvironmentVariables(result, serviceName, reveal))
+		},
+	}
+}
 
-						// Skip if filtering and name doesn't match
-						if hasFilter && svcName != serviceName {
-							continue
-						}
+// infoEnvironmentKey is the field holding a service's environment variables in azd app info JSON.
+const infoEnvironmentKey = "environmentVariables"
+
+// extractEnvironmentVariables derives each service's environment variables from azd app info
+// JSON output, keyed by service name. serviceName limits the result to one service when set.
+// Sensitive values are masked with maskEnvValues unless reveal is true.
+func extractEnvironmentVariables(info map[string]interface{}, serviceName string, reveal bool) map[string]interface{} {
+	envVars := make(map[string]interface{})
+	services, ok := info["services"].([]interface{})
+	if !ok {
+		return envVars
+	}
 
-						if env, ok := svcMap["env"].(map[string]interface{}); ok {
-							envVars[svcName] = env
-						}
-					}
-				}
-			}
+	for _, svc := range services {
+		svcMap, ok := svc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		svcName, _ := svcMap["name"].(string)
+
+		// Skip if filtering and name doesn't match
+		if serviceName != "" && svcName != serviceName {
+			continue
+		}
+
+		env, ok := svcMap[infoEnvironmentKey].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !reveal {
+			env = maskEnvValues(env)
+		}
+		envVars[svcName] = env
+	}
+	return envVars
+}
 
-			return marshalToolResult(envVars)
-		},
+// maskInfoEnvironment masks sensitive environment variable values of every service in
+// azd app info JSON output, in place.
+func maskInfoEnvironment(info map[string]interface{}) {
+	services, _ := info["services"].([]interface{})
+	for _, svc := range services {
+		svcMap, ok := svc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if env, ok := svcMap[infoEnvironmentKey].(map[string]interface{}); ok {
+			svcMap[infoEnvironmentKey] = maskEnvValues(env)
+		}
 	}
 }
 
+// maskEnvValues returns a copy of env with the values of sensitive variables, as recognized
+// by service.IsSensitiveEnvKey, fully masked.
+func maskEnvValues(env map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(env))
+	for key, value := range env {
+		if _, isString := value.(string); isString && service.IsSensitiveEnvKey(key) {
+			masked[key] = service.RedactedValue
+		} else {
+			masked[key] = value
+		}
+	}
+	return masked
+}
+
 // newSetEnvironmentVariableTool creates the set_environment_variable tool
 func newSetEnvironmentVariableTool() server.ServerTool {
 	return server.ServerTool{
@@ -895,3 +1387,233 @@ After updating, restart services for changes to take effect.`,
 		},
 	}
 }
+
+// newAddServiceTool creates the add_service tool.
+func newAddServiceTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"add_service",
+			mcp.WithTitleAnnotation("Add Service to azure.yaml"),
+			mcp.WithDescription("Add a new service to azure.yaml. Existing services, comments and key order are preserved, and an existing service is never overwritten. Returns the updated list of services."),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOutputSchema[AddServiceResult](),
+			mcp.WithString("name",
+				mcp.Description("Name of the new service"),
+				mcp.Required(),
+			),
+			mcp.WithString("language",
+				mcp.Description("Service language: 'js', 'ts', 'python', 'dotnet', 'csharp', 'fsharp', 'java', 'go', 'rust' or 'php'"),
+				mcp.Required(),
+			),
+			mcp.WithString("project",
+				mcp.Description("Path to the service's source, relative to the directory containing azure.yaml (e.g. './src/api')"),
+				mcp.Required(),
+			),
+			mcp.WithString("host",
+				mcp.Description("Optional Azure host: 'containerapp' (default), 'appservice', 'function', 'staticwebapp', 'aks' or 'springapp'"),
+			),
+			mcp.WithString("ports",
+				mcp.Description("Optional comma-separated ports in Docker Compose style (e.g. '3000' or '8080:80')"),
+			),
+			mcp.WithString("projectDir",
+				mcp.Description("Optional project directory path. If not provided, uses current directory."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if result := checkRateLimitWithName("add_service"); result != nil {
+				return result, nil
+			}
+
+			args := getArgsMap(request)
+
+			name, err := validateRequiredParam(args, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := security.ValidateServiceName(name, false); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			language, err := validateRequiredParam(args, "language")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			language = strings.ToLower(language)
+			if err := validateEnumParam(language, allowedServiceLanguages, "language"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			project, err := validateRequiredParam(args, "project")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			host := defaultServiceHost
+			if h, ok := getStringParam(args, "host"); ok && h != "" {
+				if err := validateEnumParam(h, allowedServiceHosts, "host"); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				host = h
+			}
+
+			var ports []string
+			if p, ok := getStringParam(args, "ports"); ok {
+				if ports, err = parseServicePorts(p); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			projectDir, err := extractValidatedProjectDir(args)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid project directory: %v", err)), nil
+			}
+
+			azureYamlPath, err := detector.FindAzureYaml(projectDir)
+			if err != nil || azureYamlPath == "" {
+				return mcp.NewToolResultError("azure.yaml not found - create one before adding services"), nil
+			}
+
+			result, err := addProjectService(azureYamlPath, name, language, project, host, ports)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to add service: %v", err)), nil
+			}
+
+			return marshalToolResult(result)
+		},
+	}
+}
+
+// addProjectService adds a project-based service to azure.yaml and returns the updated services.
+func addProjectService(azureYamlPath, name, language, project, host string, ports []string) (*AddServiceResult, error) {
+	projectPath, err := resolveServiceProjectPath(filepath.Dir(azureYamlPath), project)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertServiceIntoYaml(azureYamlPath, name, buildProjectServiceNode(projectPath, language, host, ports)); err != nil {
+		return nil, err
+	}
+
+	// Decode directly rather than with service.ParseAzureYaml so project paths stay as declared
+	data, err := os.ReadFile(azureYamlPath)
+	if err != nil {
+		return nil, fmt.Errorf("service added but azure.yaml could not be re-read: %w", err)
+	}
+	var azureYaml struct {
+		Services map[string]service.Service `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &azureYaml); err != nil {
+		return nil, fmt.Errorf("service added but azure.yaml could not be re-read: %w", err)
+	}
+
+	result := &AddServiceResult{
+		Added:     name,
+		AzureYaml: azureYamlPath,
+		Services:  make([]AzureYamlServiceEntry, 0, len(azureYaml.Services)),
+	}
+	for serviceName, svc := range azureYaml.Services {
+		result.Services = append(result.Services, AzureYamlServiceEntry{
+			Name:     serviceName,
+			Language: svc.Language,
+			Project:  svc.Project,
+			Host:     svc.Host,
+			Ports:    svc.Ports,
+		})
+	}
+	sort.Slice(result.Services, func(i, j int) bool { return result.Services[i].Name < result.Services[j].Name })
+	return result, nil
+}
+
+// resolveServiceProjectPath validates that project is a relative path inside projectRoot and
+// returns it in azure.yaml form (e.g. "./src/api"). Symlinks are resolved for existing paths so
+// a link cannot point the service outside the project.
+func resolveServiceProjectPath(projectRoot, project string) (string, error) {
+	project = strings.TrimSpace(project)
+	if project == "" {
+		return "", fmt.Errorf("project path is required")
+	}
+	if filepath.IsAbs(project) || filepath.VolumeName(project) != "" || strings.HasPrefix(project, "/") || strings.HasPrefix(project, "\\") {
+		return "", fmt.Errorf("project path must be relative to the directory containing azure.yaml: %s", project)
+	}
+
+	fullPath := filepath.Join(projectRoot, filepath.FromSlash(project))
+	if !isSameOrNestedDir(fullPath, projectRoot) {
+		return "", fmt.Errorf("project path must stay within the project directory: %s", project)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(fullPath); err == nil {
+		resolvedRoot, rootErr := filepath.EvalSymlinks(projectRoot)
+		if rootErr != nil {
+			resolvedRoot = projectRoot
+		}
+		if !isSameOrNestedDir(resolved, resolvedRoot) {
+			return "", fmt.Errorf("project path must stay within the project directory: %s resolves to %s", project, resolved)
+		}
+	}
+
+	rel, err := filepath.Rel(projectRoot, fullPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid project path %s: %w", project, err)
+	}
+	if rel == "." {
+		return ".", nil
+	}
+	return "./" + filepath.ToSlash(rel), nil
+}
+
+// parseServicePorts splits a comma-separated list of Docker Compose style ports ("3000" or
+// "8080:80") and checks that every port number is between 1 and 65535.
+func parseServicePorts(value string) ([]string, error) {
+	var ports []string
+	for _, spec := range strings.Split(value, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		parts := strings.Split(spec, ":")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid port %q: use 'port' or 'hostPort:containerPort'", spec)
+		}
+		for _, part := range parts {
+			port, err := strconv.Atoi(part)
+			if err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid port %q: ports must be numbers between 1 and 65535", spec)
+			}
+		}
+		ports = append(ports, spec)
+	}
+	return ports, nil
+}
+
+// buildProjectServiceNode builds the azure.yaml entry for a project-based service.
+func buildProjectServiceNode(project, language, host string, ports []string) *yaml.Node {
+	node := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "project", Tag: "!!str"},
+			{Kind: yaml.ScalarNode, Value: project, Tag: "!!str"},
+			{Kind: yaml.ScalarNode, Value: "language", Tag: "!!str"},
+			{Kind: yaml.ScalarNode, Value: language, Tag: "!!str"},
+			{Kind: yaml.ScalarNode, Value: "host", Tag: "!!str"},
+			{Kind: yaml.ScalarNode, Value: host, Tag: "!!str"},
+		},
+	}
+
+	if len(ports) > 0 {
+		portsNode := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{}}
+		for _, port := range ports {
+			// Quote ports so "8080:80" isn't read as a base-60 number by YAML 1.1 parsers
+			portsNode.Content = append(portsNode.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: port, Tag: "!!str", Style: yaml.DoubleQuotedStyle},
+			)
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "ports", Tag: "!!str"},
+			portsNode,
+		)
+	}
+
+	return node
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"log/slog"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// reapOrphansFlagUsage is the help text shared by the run and info --reap-orphans flags.
+const reapOrphansFlagUsage = "Kill service processes left running by a previous azd app run that was killed"
+
+// checkOrphanedProcesses looks for service processes recorded in the project's pid file
+// whose azd app run has exited without stopping them (e.g. after SIGKILL).
+// Orphans are reported, or killed when reap is true.
+// Detection problems are logged rather than returned so they never block the command.
+func checkOrphanedProcesses(projectDir string, reap bool, table service.ProcessTable) {
+	pidFile := service.NewPidFile(projectDir)
+	orphans, err := pidFile.FindOrphans(table)
+	if err != nil {
+		slog.Warn("failed to check for orphaned processes", "pidFile", pidFile.Path(), "error", err)
+		return
+	}
+	if len(orphans) == 0 {
+		return
+	}
+
+	if !reap {
+		if !output.IsStructured() {
+			output.Warning("Found %d orphaned service process(es) from a previous run:", len(orphans))
+			for _, orphan := range orphans {
+				output.Item("%s (pid %d)", orphan.Service, orphan.PID)
+			}
+			output.Hint("Use --reap-orphans to stop them")
+			output.Newline()
+		}
+		return
+	}
+
+	if err := pidFile.ReapOrphans(table, orphans); err != nil {
+		if !output.IsStructured() {
+			output.Warning("Failed to reap some orphaned processes: %v", err)
+		}
+		return
+	}
+
+	if !output.IsStructured() {
+		output.Success("Reaped %d orphaned service process(es)", len(orphans))
+	}
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+)
+
+const queryFlagUsage = "With --output json, print only the values this JSONPath expression selects, e.g. \"$.services[?(@.name=='api')].local.port\""
+
+// setOutputQuery compiles a --query expression and has output.PrintJSON apply it to the
+// command's result. It fails before the command runs when the expression is invalid or
+// the output format isn't JSON. Callers reset the query with output.SetQuery(nil).
+func setOutputQuery(expr string) error {
+	if expr == "" {
+		output.SetQuery(nil)
+		return nil
+	}
+	if !output.IsJSON() {
+		return fmt.Errorf("--query requires --output json")
+	}
+	q, err := output.ParseQuery(expr)
+	if err != nil {
+		return err
+	}
+	output.SetQuery(q)
+	return nil
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+)
+
+func TestSetOutputQuery(t *testing.T) {
+	defer output.SetQuery(nil)
+	defer func() { _ = output.SetFormat("default") }()
+
+	if err := setOutputQuery(""); err != nil {
+		t.Errorf("setOutputQuery(\"\") error = %v, want nil", err)
+	}
+
+	_ = output.SetFormat("default")
+	if err := setOutputQuery("$.services"); err == nil || !strings.Contains(err.Error(), "--output json") {
+		t.Errorf("setOutputQuery() without JSON output error = %v, want --output json error", err)
+	}
+
+	_ = output.SetFormat("json")
+	if err := setOutputQuery("$.services[?(@.name=="); err == nil || !strings.Contains(err.Error(), "invalid query") {
+		t.Errorf("setOutputQuery() with an invalid expression error = %v, want invalid query error", err)
+	}
+	if err := setOutputQuery("$.services[0].name"); err != nil {
+		t.Errorf("setOutputQuery() error = %v, want nil", err)
+	}
+}
+
+func TestDepsQueryRequiresDryRun(t *testing.T) {
+	defer ResetDepsOptions()
+
+	cmd := NewDepsCommand()
+	cmd.SetArgs([]string{"--query", "$.projects[0].dir"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Errorf("deps --query without --dry-run error = %v, want --dry-run error", err)
+	}
+}
@@ -38,12 +38,14 @@ type Prerequisite struct {
 // ReqsService represents a minimal service definition for reqs parsing.
 // Only includes fields needed to detect container services.
 type ReqsService struct {
+	Type   string            `yaml:"type,omitempty"`
 	Image  string            `yaml:"image,omitempty"`
 	Docker *ReqsDockerConfig `yaml:"docker,omitempty"`
 }
 
 // ReqsDockerConfig represents minimal Docker configuration for reqs parsing.
 type ReqsDockerConfig struct {
+	Path  string `yaml:"path,omitempty"`
 	Image string `yaml:"image,omitempty"`
 }
 
@@ -59,7 +61,7 @@ func (a *AzureYaml) hasContainerServices() bool {
 		if svc.Image != "" {
 			return true
 		}
-		if svc.Docker != nil && svc.Docker.Image != "" {
+		if svc.Docker != nil && (svc.Docker.Image != "" || (svc.Type == "container" && svc.Docker.Path != "")) {
 			return true
 		}
 	}
@@ -197,6 +199,16 @@ var toolRegistry = map[string]ToolConfig{
 	},
 }
 
+// defaultMinVersions maps canonical tool names to the minimum version checked when a
+// requirement has no minVersion: older runtimes are out of support and fail later in
+// confusing ways, such as a service that won't start under azd app run.
+var defaultMinVersions = map[string]string{
+	"node":   "18.0.0",
+	"python": "3.9.0",
+	"dotnet": "8.0.0",
+	"go":     "1.21.0",
+}
+
 // toolAliases maps alternative names to canonical tool names.
 var toolAliases = map[string]string{
 	"nodejs":                     "node",
@@ -237,6 +249,7 @@ func NewReqsCommand() *cobra.Command {
 	var noCache bool
 	var clearCache bool
 	var fixMode bool
+	var query string
 
 	cmd := &cobra.Command{
 		Use:          "reqs",
@@ -267,6 +280,11 @@ Use --no-cache to force a fresh check and bypass cached results.`,
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setOutputQuery(query); err != nil {
+				return err
+			}
+			defer output.SetQuery(nil)
+
 			// Handle clear cache flag
 			if clearCache {
 				return runClearCache()
@@ -304,6 +322,7 @@ Use --no-cache to force a fresh check and bypass cached results.`,
 	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Force fresh reqs check and bypass cached results")
 	cmd.Flags().BoolVar(&clearCache, "clear-cache", false, "Clear cached reqs results")
 	cmd.Flags().BoolVar(&fixMode, "fix", false, "Attempt to fix PATH issues for missing tools")
+	cmd.Flags().StringVar(&query, "query", "", queryFlagUsage)
 
 	return cmd
 }
@@ -330,6 +349,7 @@ func NewPrerequisiteChecker() *PrerequisiteChecker {
 // Check checks a prerequisite and returns structured result.
 func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 	installed, version, isPodman := pc.getInstalledVersion(prereq)
+	minVersion := pc.getMinVersion(prereq)
 
 	// Resolve install URL (custom overrides built-in)
 	installUrl := pc.getInstallUrl(prereq)
@@ -338,7 +358,7 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 		Name:       prereq.Name,
 		Installed:  installed,
 		Version:    version,
-		Required:   prereq.MinVersion,
+		Required:   minVersion,
 		Satisfied:  false,
 		IsPodman:   isPodman,
 		InstallUrl: installUrl,
@@ -346,8 +366,8 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 
 	if !installed {
 		result.Message = "Not installed"
-		if !output.IsJSON() {
-			output.ItemError("%s: NOT INSTALLED (required: %s)", prereq.Name, prereq.MinVersion)
+		if !output.IsStructured() {
+			output.ItemError("%s: NOT INSTALLED (required: %s)", prereq.Name, minVersion)
 			if installUrl != "" {
 				output.Item("   Install: %s", installUrl)
 			}
@@ -359,7 +379,7 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 	// Podman uses its own versioning (e.g., 5.7.0) which is not comparable to Docker versions (e.g., 20.10.0).
 	if isPodman && prereq.Name == "docker" {
 		result.Message = "Podman detected (version check skipped)"
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.ItemSuccess("%s: %s via Podman (version check skipped)", prereq.Name, version)
 		}
 		// Continue to check if running if needed, otherwise mark satisfied
@@ -369,24 +389,24 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 		}
 	} else if version == "" {
 		result.Message = "Version unknown"
-		if !output.IsJSON() {
-			output.ItemWarning("%s: INSTALLED (version unknown, required: %s)", prereq.Name, prereq.MinVersion)
+		if !output.IsStructured() {
+			output.ItemWarning("%s: INSTALLED (version unknown, required: %s)", prereq.Name, minVersion)
 		}
 		// Continue to check if it's running if needed
 	} else {
-		versionOk := compareVersions(version, prereq.MinVersion)
+		versionOk := compareVersions(version, minVersion)
 		if !versionOk {
-			result.Message = fmt.Sprintf("Version %s does not meet minimum %s", version, prereq.MinVersion)
-			if !output.IsJSON() {
-				output.ItemError("%s: %s (required: %s)", prereq.Name, version, prereq.MinVersion)
+			result.Message = fmt.Sprintf("%s %s found, requires >= %s", prereq.Name, version, minVersion)
+			if !output.IsStructured() {
+				output.ItemError("%s", result.Message)
 				if installUrl != "" {
 					output.Item("   Install: %s", installUrl)
 				}
 			}
 			return result
 		}
-		if !output.IsJSON() {
-			output.ItemSuccess("%s: %s (required: %s)", prereq.Name, version, prereq.MinVersion)
+		if !output.IsStructured() {
+			output.ItemSuccess("%s: %s (required: %s)", prereq.Name, version, minVersion)
 		}
 	}
 
@@ -397,14 +417,14 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 		result.Running = isRunning
 		if !isRunning {
 			result.Message = "Not running"
-			if !output.IsJSON() {
+			if !output.IsStructured() {
 				output.Item("- %s✗%s NOT RUNNING", output.Red, output.Reset)
 			}
 			return result
 		}
 		result.Satisfied = true
 		result.Message = "Running"
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Item("- %s✓%s RUNNING", output.Green, output.Reset)
 		}
 		return result
@@ -417,6 +437,20 @@ func (pc *PrerequisiteChecker) Check(prereq Prerequisite) ReqResult {
 	return result
 }
 
+// getMinVersion returns the minimum version of a prerequisite: its minVersion, or the
+// default of a built-in tool checked with its registry command.
+func (pc *PrerequisiteChecker) getMinVersion(prereq Prerequisite) string {
+	if prereq.MinVersion != "" || prereq.Command != "" {
+		return prereq.MinVersion
+	}
+
+	tool := prereq.Name
+	if canonical, isAlias := pc.aliases[tool]; isAlias {
+		tool = canonical
+	}
+	return defaultMinVersions[tool]
+}
+
 // getInstallUrl returns the install URL for a prerequisite.
 // Custom InstallUrl in prerequisite takes precedence over built-in registry.
 func (pc *PrerequisiteChecker) getInstallUrl(prereq Prerequisite) string {
@@ -703,8 +737,8 @@ func runClearCache() error {
 		return fmt.Errorf("failed to clear cache: %w", err)
 	}
 
-	if output.IsJSON() {
-		return output.PrintJSON(map[string]interface{}{
+	if output.IsStructured() {
+		return output.PrintStructured(map[string]interface{}{
 			"success": true,
 			"message": "Reqs cache cleared successfully",
 		})
@@ -727,7 +761,7 @@ type FixResult struct {
 // runReqsFix attempts to fix PATH issues for missing tools.
 func runReqsFix() error {
 	output.CommandHeader("reqs --fix", "Fix PATH issues for missing tools")
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Section(output.IconTool, "Attempting to fix requirement issues...")
 	}
 
@@ -752,8 +786,8 @@ func runReqsFix() error {
 	}
 
 	if len(failedReqs) == 0 {
-		if output.IsJSON() {
-			return output.PrintJSON(map[string]interface{}{
+		if output.IsStructured() {
+			return output.PrintStructured(map[string]interface{}{
 				"success": true,
 				"message": "All requirements already satisfied",
 			})
@@ -763,18 +797,18 @@ func runReqsFix() error {
 	}
 
 	// Step 2: Refresh PATH
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 		output.Step(output.IconRefresh, "Refreshing environment PATH...")
 	}
 
 	_, err = pathutil.RefreshPATH()
 	if err != nil {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Warning("Failed to refresh PATH: %v", err)
 		}
 	} else {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.ItemSuccess("PATH refreshed successfully")
 		}
 	}
@@ -784,7 +818,7 @@ func runReqsFix() error {
 	fixedCount := 0
 
 	for _, prereq := range failedReqs {
-		if !output.IsJSON() {
+		if !output.IsStructured() {
 			output.Newline()
 			output.Step(output.IconSearch, "Searching for %s...", prereq.Name)
 		}
@@ -812,13 +846,13 @@ func runReqsFix() error {
 				fixResult.Satisfied = true
 				fixResult.Message = fmt.Sprintf("Found and verified: %s", toolPath)
 				fixedCount++
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					output.ItemSuccess("Found: %s", toolPath)
 					output.ItemSuccess("Version verified successfully")
 				}
 			} else {
 				fixResult.Message = fmt.Sprintf("Found at %s but version check failed: %s", toolPath, result.Message)
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					output.ItemWarning("Found: %s", toolPath)
 					output.ItemWarning("Version check failed: %s", result.Message)
 				}
@@ -830,7 +864,7 @@ func runReqsFix() error {
 				fixResult.Found = true
 				fixResult.Path = toolPath
 				fixResult.Message = fmt.Sprintf("Found at %s but not in PATH - restart terminal may be needed", toolPath)
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					output.ItemWarning("Found: %s", toolPath)
 					output.ItemWarning("Tool is installed but not in current PATH")
 					output.Info("   %s Restart your terminal to update PATH", output.IconBulb)
@@ -839,7 +873,7 @@ func runReqsFix() error {
 				// Not found anywhere
 				suggestion := pathutil.GetInstallSuggestion(toolCommand)
 				fixResult.Message = fmt.Sprintf("Not found - %s", suggestion)
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					output.ItemError("Not found in system PATH")
 					output.Info("   %s %s", output.IconBulb, suggestion)
 				}
@@ -860,7 +894,7 @@ func runReqsFix() error {
 		if err == nil {
 			if err := cacheManager.ClearCache(); err != nil {
 				// Log but don't fail on cache clear error
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					output.Warning("Failed to clear cache: %v", err)
 				}
 			}
@@ -868,7 +902,7 @@ func runReqsFix() error {
 	}
 
 	// Step 5: Re-check all requirements
-	if !output.IsJSON() {
+	if !output.IsStructured() {
 		output.Newline()
 		output.Section(output.IconCheck, "Re-checking requirements...")
 	}
@@ -885,16 +919,22 @@ func runReqsFix() error {
 		}
 	}
 
-	// JSON output
-	if output.IsJSON() {
-		return output.PrintJSON(map[string]interface{}{
+	// JSON or YAML output
+	if output.IsStructured() {
+		if err := output.PrintStructured(map[string]interface{}{
 			"success":      fixedCount > 0,
 			"fixed":        fixedCount,
 			"total":        len(failedReqs),
 			"allSatisfied": allSatisfied,
 			"fixes":        fixResults,
 			"results":      allResults,
-		})
+		}); err != nil {
+			return err
+		}
+		if !allSatisfied {
+			return fmt.Errorf("not all requirements satisfied")
+		}
+		return nil
 	}
 
 	// Default output - summary
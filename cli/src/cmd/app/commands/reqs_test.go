@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -640,6 +643,77 @@ reqs:
 	}
 }
 
+func TestRunReqsJSONOutput(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if chdirErr := os.Chdir(originalDir); chdirErr != nil {
+			t.Logf("Warning: failed to restore directory: %v", chdirErr)
+		}
+	}()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	versionCmd, versionArgs := shellCommand("echo 2.0.0")
+	yamlContent := fmt.Sprintf(`name: test
+reqs:
+  - name: test-tool
+    minVersion: 1.0.0
+    command: %s
+    args: %v
+  - name: missing-tool
+    minVersion: 1.0.0
+    command: azd-app-missing-tool-for-testing
+`, versionCmd, yamlArgsString(versionArgs))
+	if err := os.WriteFile("azure.yaml", []byte(yamlContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetCacheEnabled(false)
+	defer SetCacheEnabled(true)
+	if err := output.SetFormat("json"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = output.SetFormat("default") }()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	runErr := runReqs()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if runErr == nil {
+		t.Error("runReqs() error = nil, want error when a requirement is missing")
+	}
+
+	var result ReqsResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output should be valid JSON: %v\n%s", err, buf.String())
+	}
+	if result.Satisfied {
+		t.Error("satisfied = true, want false")
+	}
+	if len(result.Reqs) != 2 {
+		t.Fatalf("got %d reqs, want 2: %+v", len(result.Reqs), result.Reqs)
+	}
+	if req := result.Reqs[0]; req.Name != "test-tool" || !req.Installed || req.Version != "2.0.0" || req.Required != "1.0.0" || !req.Satisfied {
+		t.Errorf("reqs[0] = %+v, want an installed and satisfied test-tool 2.0.0", req)
+	}
+	if req := result.Reqs[1]; req.Name != "missing-tool" || req.Installed || req.Satisfied {
+		t.Errorf("reqs[1] = %+v, want a missing-tool that is not installed", req)
+	}
+}
+
 func TestCheckPrerequisite(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -668,6 +742,53 @@ func TestCheckPrerequisite(t *testing.T) {
 	}
 }
 
+func TestCheckPrerequisiteBelowMinVersion(t *testing.T) {
+	_ = output.SetFormat("json")
+	defer func() { _ = output.SetFormat("default") }()
+
+	versionCmd, versionArgs := shellCommand("echo v16.4.0")
+	result := NewPrerequisiteChecker().Check(Prerequisite{
+		Name:          "node",
+		MinVersion:    "18.0",
+		Command:       versionCmd,
+		Args:          versionArgs,
+		VersionPrefix: "v",
+	})
+
+	if !result.Installed || result.Satisfied {
+		t.Errorf("result = %+v, want installed and not satisfied", result)
+	}
+	if want := "node 16.4.0 found, requires >= 18.0"; result.Message != want {
+		t.Errorf("Message = %q, want %q", result.Message, want)
+	}
+}
+
+func TestGetMinVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		prereq Prerequisite
+		want   string
+	}{
+		{"configured", Prerequisite{Name: "node", MinVersion: "20.0.0"}, "20.0.0"},
+		{"node default", Prerequisite{Name: "node"}, "18.0.0"},
+		{"alias default", Prerequisite{Name: "nodejs"}, "18.0.0"},
+		{"python default", Prerequisite{Name: "python"}, "3.9.0"},
+		{"dotnet default", Prerequisite{Name: "dotnet"}, "8.0.0"},
+		{"go default", Prerequisite{Name: "go"}, "1.21.0"},
+		{"no default", Prerequisite{Name: "docker"}, ""},
+		{"custom command", Prerequisite{Name: "node", Command: "mynode"}, ""},
+	}
+
+	checker := NewPrerequisiteChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checker.getMinVersion(tt.prereq); got != tt.want {
+				t.Errorf("getMinVersion(%+v) = %q, want %q", tt.prereq, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestToolRegistryCompleteness(t *testing.T) {
 	requiredTools := []string{"node", "pnpm", "python", "dotnet", "aspire", "azd", "az", "func"}
 
@@ -1467,6 +1588,28 @@ func TestRunReqsFix_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestAzureYaml_HasContainerServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		services map[string]ReqsService
+		want     bool
+	}{
+		{"no services", nil, false},
+		{"image", map[string]ReqsService{"db": {Image: "postgres:16"}}, true},
+		{"docker.image", map[string]ReqsService{"db": {Docker: &ReqsDockerConfig{Image: "redis:7"}}}, true},
+		{"docker.path runs natively", map[string]ReqsService{"web": {Docker: &ReqsDockerConfig{Path: "./Dockerfile"}}}, false},
+		{"container type with docker.path", map[string]ReqsService{"web": {Type: "container", Docker: &ReqsDockerConfig{Path: "./Dockerfile"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AzureYaml{Services: tt.services}
+			if got := a.hasContainerServices(); got != tt.want {
+				t.Errorf("hasContainerServices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // yamlArgsString converts args array to YAML string format
 func yamlArgsString(args []string) string {
 	if len(args) == 0 {
@@ -12,6 +12,7 @@ var (
 	restartService string
 	restartAll     bool
 	restartYes     bool
+	restartNoCache bool
 )
 
 // NewRestartCommand creates the restart command.
@@ -47,6 +48,7 @@ Examples:
 	cmd.Flags().StringVarP(&restartService, "service", "s", "", "Service name(s) to restart (comma-separated)")
 	cmd.Flags().BoolVar(&restartAll, "all", false, "Restart all services")
 	cmd.Flags().BoolVarP(&restartYes, "yes", "y", false, "Skip confirmation prompt for --all")
+	cmd.Flags().BoolVar(&restartNoCache, "no-cache", false, "Detect service runtimes again instead of reusing results cached in .azure/app-cache")
 
 	return cmd
 }
@@ -64,6 +66,7 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
+	ctrl.noRuntimeCache = restartNoCache
 
 	// Set up context with signal handling
 	ctx, _, cleanup := setupContextWithSignalHandling()
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -18,6 +22,7 @@ import (
 	"github.com/jongio/azd-app/cli/src/internal/executor"
 	"github.com/jongio/azd-app/cli/src/internal/notifications"
 	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/portmanager"
 	"github.com/jongio/azd-app/cli/src/internal/registry"
 	"github.com/jongio/azd-app/cli/src/internal/service"
 	"github.com/jongio/azd-app/cli/src/internal/yamlutil"
@@ -32,12 +37,44 @@ const (
 
 var (
 	runServiceFilter     string
-	runEnvFile           string
+	runOnly              string
+	runExclude           string
+	runFromSnapshot      bool
+	runEnvFiles          []string
+	runProfile           string
+	runStrictEnv         bool
+	runEnvAllow          []string
+	runEnvDeny           []string
 	runVerbose           bool
+	runNoColor           bool
+	runNoStream          bool
 	runDryRun            bool
+	runNoDeps            bool
 	runRuntime           string
 	runWeb               bool
 	runRestartContainers bool
+	runRebuild           bool
+	runWithDeps          bool
+	runReapOrphans       bool
+	runForwardSignals    []string
+	runForwardSignalsTo  string
+	runWatch             bool
+	runRestartPolicy     string
+	runMaxRestarts       int
+	runAutoPort          bool
+	runAutoPortRange     string
+	runReadyTimeout      time.Duration
+	runShutdownTimeout   time.Duration
+	runFailFast          bool
+	runAttachDebugger    string
+	runProxy             bool
+	runProxyPort         int
+	runNoCache           bool
+	runAttach            bool
+	runPrintEnv          bool
+	runReveal            bool
+	runLogMaxSize        string
+	runLogMaxFiles       int
 )
 
 // NewRunCommand creates the run command.
@@ -54,27 +91,102 @@ func NewRunCommand() *cobra.Command {
 
 	// Add flags for service orchestration
 	cmd.Flags().StringVarP(&runServiceFilter, "service", "s", "", "Run specific service(s) only (comma-separated)")
-	cmd.Flags().StringVar(&runEnvFile, "env-file", "", "Load environment variables from .env file")
+	cmd.Flags().StringVar(&runOnly, "only", "", "Run only these service(s) (comma-separated)")
+	cmd.Flags().StringVar(&runExclude, "exclude", "", "Run every service except these (comma-separated)")
+	cmd.Flags().BoolVar(&runFromSnapshot, "from-snapshot", false, "Re-run the services exactly as the last run resolved them (.azure/app/last-run.json)")
+	cmd.Flags().StringArrayVar(&runEnvFiles, "env-file", nil, "Load environment variables from .env file (repeatable; later files override earlier ones)")
+	cmd.Flags().StringVar(&runProfile, "profile", "", "Overlay .azure/<env>/.env.<profile> on the azd environment's .env")
+	cmd.Flags().BoolVar(&runStrictEnv, "strict-env", false, "Fail when a .env file has an invalid line instead of skipping it with a warning")
+	cmd.Flags().StringSliceVar(&runEnvAllow, "env-allow", nil, "Forward only host environment variables matching these glob patterns to services (comma-separated, e.g. 'PATH,NODE_*')")
+	cmd.Flags().StringSliceVar(&runEnvDeny, "env-deny", nil, "Never forward host environment variables matching these glob patterns to services (comma-separated)")
 	cmd.Flags().BoolVarP(&runVerbose, "verbose", "v", false, "Enable verbose logging")
-	cmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Show what would be run without starting services")
-	cmd.Flags().StringVar(&runRuntime, "runtime", runtimeModeAzd, "Runtime mode: 'azd' (azd dashboard) or 'aspire' (native Aspire with dotnet run)")
+	cmd.Flags().BoolVar(&runNoColor, "no-color", false, "Disable colored service prefixes in console output")
+	cmd.Flags().BoolVar(&runNoStream, "no-stream", false, "Don't show service output in the console (view it with azd app logs or the dashboard)")
+	cmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Show the execution plan (start order, commands, ports, health checks and dependencies) without starting services")
+	cmd.Flags().BoolVar(&runNoDeps, "no-deps", false, "Skip installing dependencies and start the services right away")
+	cmd.Flags().StringVar(&runRuntime, "runtime", runtimeModeAzd, "Runtime mode: 'azd' (azd dashboard) or 'aspire' (native Aspire with dotnet run); without it, a project whose azure.yaml only defines an Aspire AppHost runs in 'aspire' mode")
 	cmd.Flags().BoolVarP(&runWeb, "web", "w", false, "Open dashboard in browser")
 	cmd.Flags().BoolVar(&runRestartContainers, "restart-containers", false, "Restart containers even if they are already running")
+	cmd.Flags().BoolVar(&runRebuild, "rebuild", false, "Rebuild the images of Dockerfile services even if they are up to date")
+	cmd.Flags().BoolVar(&runWithDeps, "with-deps", false, "Also run the services that --service targets depend on (via 'uses')")
+	cmd.Flags().BoolVar(&runReapOrphans, "reap-orphans", false, reapOrphansFlagUsage)
+	cmd.Flags().StringSliceVar(&runForwardSignals, "forward-signals", nil, "Forward these signals to services instead of ignoring them (HUP, USR1, USR2; not supported on Windows)")
+	cmd.Flags().StringVar(&runForwardSignalsTo, "forward-signals-to", "", "Only forward --forward-signals to these service(s) (comma-separated, default: all)")
+	cmd.Flags().BoolVar(&runWatch, "watch", false, "Restart a service when files in its project directory change (skips containers and watch-mode services)")
+	cmd.Flags().StringVar(&runRestartPolicy, "restart", restartPolicyNo, "Restart services that exit on their own: 'no', 'on-failure' (non-zero exit) or 'always'")
+	cmd.Flags().IntVar(&runMaxRestarts, "max-restarts", defaultMaxRestarts, "Give up on a service after this many restarts in a row (with --restart)")
+	cmd.Flags().BoolVar(&runAutoPort, "auto-port", false, "Move services whose declared port is busy to the next free port instead of prompting")
+	cmd.Flags().StringVar(&runAutoPortRange, "auto-port-range", "", fmt.Sprintf("Port range for --auto-port, e.g. 8000-8999 (default: %d-%d)", portmanager.PortRangeStart, portmanager.PortRangeEnd))
+	cmd.Flags().DurationVar(&runReadyTimeout, "ready-timeout", service.DefaultReadyTimeout, "How long each service may take to pass its health check before it is marked failed")
+	cmd.Flags().DurationVar(&runShutdownTimeout, "shutdown-timeout", service.DefaultGracefulShutdownTimeout, "How long each service may take to exit on shutdown before it is killed")
+	cmd.Flags().BoolVar(&runFailFast, "fail-fast", false, "Stop every service and exit when a service fails to start or does not become ready within --ready-timeout")
+	cmd.Flags().BoolVar(&runProxy, "proxy", false, "Front HTTP services with a proxy on one port that routes by path or subdomain and logs every request")
+	cmd.Flags().IntVar(&runProxyPort, "proxy-port", 0, "Port for --proxy (default: a free port)")
+	cmd.Flags().StringVar(&runAttachDebugger, "attach-debugger", "", "Start this service paused until a debugger attaches (Node.js, Python and Go)")
+	cmd.Flags().StringVar(&runLogMaxSize, "log-max-size", defaultLogMaxSize, "Rotate a service's log file in .azure/logs when it reaches this size (e.g. 10MB, 512KB)")
+	cmd.Flags().IntVar(&runLogMaxFiles, "log-max-files", service.MaxLogFileBackups, "Number of rotated log files to keep per service (service.log.1, .2, ...)")
+	cmd.Flags().BoolVar(&runAttach, "attach", false, "Stream the combined output of the azd app run session already running in this project, without starting anything")
+	cmd.Flags().BoolVar(&runPrintEnv, "print-env", false, "Print the environment each service would be started with (host, .env files, azure.yaml and injected variables) without starting services")
+	cmd.Flags().BoolVar(&runReveal, "reveal", false, "Show secret values in full with --print-env instead of masking them")
+	cmd.Flags().BoolVar(&runNoCache, "no-cache", false, "Detect every service's runtime again instead of reusing results cached in .azure/app-cache")
 
 	return cmd
 }
 
 // runWithServices runs services from azure.yaml.
-func runWithServices(ctx context.Context, _ *cobra.Command, _ []string) error {
+func runWithServices(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	output.CommandHeader("run", "Run the development environment")
+	if runAttach {
+		return attachToRunningSession(ctx)
+	}
 	if err := validateRuntimeMode(runRuntime); err != nil {
 		return err
 	}
+	if !cmd.Flags().Changed("runtime") {
+		applyDetectedRuntime()
+	}
+	if _, _, err := autoPortRange(); err != nil {
+		return err
+	}
+	if _, err := logRotation(); err != nil {
+		return err
+	}
+	if err := validateServiceSelection(); err != nil {
+		return err
+	}
+	if err := validateReadyTimeout(); err != nil {
+		return err
+	}
+	if err := validateShutdownTimeout(); err != nil {
+		return err
+	}
+	if err := validateFromSnapshot(); err != nil {
+		return err
+	}
+	if err := validateForwardSignals(); err != nil {
+		return err
+	}
+	if err := validateWatch(); err != nil {
+		return err
+	}
+	if err := validateRestartPolicy(); err != nil {
+		return err
+	}
+	if err := validateProxy(); err != nil {
+		return err
+	}
+	if err := validateProfile(); err != nil {
+		return err
+	}
+	if err := validateEnvFilter(); err != nil {
+		return err
+	}
+	if err := validatePrintEnv(); err != nil {
+		return err
+	}
 
-	// Execute dependencies first (reqs -> deps -> run)
-	// The orchestrator automatically sets orchestrated mode for dependencies
-	if err := cmdOrchestrator.Run("run"); err != nil {
-		return fmt.Errorf("failed to execute command dependencies: %w", err)
+	if err := runCommandDependencies(); err != nil {
+		return err
 	}
 
 	azureYamlPath, err := findAzureYaml()
@@ -85,6 +197,26 @@ func runWithServices(ctx context.Context, _ *cobra.Command, _ []string) error {
 	return runServicesFromAzureYaml(ctx, azureYamlPath, runRuntime)
 }
 
+// runCommandDependencies executes the commands run depends on (reqs -> deps -> run) before
+// services start. A dry run or --print-env installs nothing; --no-deps checks the requirements
+// but skips installing dependencies.
+// The orchestrator automatically sets orchestrated mode for dependencies.
+func runCommandDependencies() error {
+	if previewOnly() {
+		return nil
+	}
+
+	command := "run"
+	if runNoDeps {
+		output.Info("Skipping dependency installation (--no-deps)")
+		command = "reqs"
+	}
+	if err := cmdOrchestrator.Run(command); err != nil {
+		return fmt.Errorf("failed to execute command dependencies: %w", err)
+	}
+	return nil
+}
+
 // validateRuntimeMode validates the runtime mode parameter.
 func validateRuntimeMode(mode string) error {
 	if mode != runtimeModeAzd && mode != runtimeModeAspire {
@@ -93,6 +225,129 @@ func validateRuntimeMode(mode string) error {
 	return nil
 }
 
+// autoPortRange returns the --auto-port-range bounds, or the port manager's range by default.
+func autoPortRange() (start, end int, err error) {
+	if runAutoPortRange == "" {
+		return portmanager.PortRangeStart, portmanager.PortRangeEnd, nil
+	}
+	if !runAutoPort {
+		return 0, 0, fmt.Errorf("--auto-port-range requires --auto-port")
+	}
+
+	first, last, ok := strings.Cut(runAutoPortRange, "-")
+	start, startErr := strconv.Atoi(strings.TrimSpace(first))
+	end, endErr := strconv.Atoi(strings.TrimSpace(last))
+	if !ok || startErr != nil || endErr != nil || start < 1 || end > 65535 || start > end {
+		return 0, 0, fmt.Errorf("invalid --auto-port-range value: %s (must be START-END, e.g. 8000-8999)", runAutoPortRange)
+	}
+	return start, end, nil
+}
+
+// validateServiceSelection checks that at most one way of selecting services is used.
+func validateServiceSelection() error {
+	if runOnly != "" && runExclude != "" {
+		return fmt.Errorf("--only and --exclude are mutually exclusive")
+	}
+	if runOnly != "" && runServiceFilter != "" {
+		return fmt.Errorf("--only and --service are mutually exclusive")
+	}
+	return nil
+}
+
+// validateReadyTimeout checks that --ready-timeout leaves services time to become healthy.
+func validateReadyTimeout() error {
+	if runReadyTimeout <= 0 {
+		return fmt.Errorf("invalid --ready-timeout value: %s (must be greater than 0)", runReadyTimeout)
+	}
+	return nil
+}
+
+// validateShutdownTimeout checks that --shutdown-timeout leaves services time to exit.
+func validateShutdownTimeout() error {
+	if runShutdownTimeout <= 0 {
+		return fmt.Errorf("invalid --shutdown-timeout value: %s (must be greater than 0)", runShutdownTimeout)
+	}
+	return nil
+}
+
+// validateServiceNames checks that every --only and --exclude name is a service in azure.yaml,
+// and every --service name when --with-deps expands it: the expansion drops unknown names,
+// and an empty filter would run every service.
+func validateServiceNames(services map[string]service.Service) error {
+	flags := []struct{ name, value string }{{"only", runOnly}, {"exclude", runExclude}}
+	if runWithDeps {
+		flags = append(flags, struct{ name, value string }{"service", runServiceFilter})
+	}
+	for _, flag := range flags {
+		if err := checkServiceNames(flag.name, serviceList(flag.value), services); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkServiceNames returns an error listing the valid services if any of names, given
+// with the named flag, is not a service in azure.yaml.
+func checkServiceNames(flag string, names []string, services map[string]service.Service) error {
+	for _, name := range names {
+		if _, ok := services[name]; ok {
+			continue
+		}
+		valid := make([]string, 0, len(services))
+		for svcName := range services {
+			valid = append(valid, svcName)
+		}
+		sort.Strings(valid)
+		return fmt.Errorf("invalid --%s value: unknown service %q (valid services: %s)", flag, name, strings.Join(valid, ", "))
+	}
+	return nil
+}
+
+// serviceList splits a comma-separated list of service names, dropping empty entries.
+func serviceList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validateForwardSignals checks the --forward-signals names before any service is started.
+// On platforms without reload signals the flag is ignored with a warning.
+func validateForwardSignals() error {
+	if len(runForwardSignals) == 0 {
+		if runForwardSignalsTo != "" {
+			return fmt.Errorf("--forward-signals-to requires --forward-signals")
+		}
+		return nil
+	}
+	if !service.SignalForwardingSupported {
+		output.Warning("--forward-signals is not supported on Windows (there are no SIGHUP/SIGUSR1/SIGUSR2 signals); ignoring")
+		return nil
+	}
+	if _, err := service.ParseForwardSignals(runForwardSignals); err != nil {
+		return fmt.Errorf("invalid --forward-signals value: %w", err)
+	}
+	return nil
+}
+
+// forwardSignalTargets returns the services named by --forward-signals-to, or nil for all services.
+func forwardSignalTargets() []string {
+	return serviceList(runForwardSignalsTo)
+}
+
+// validateForwardSignalTargets checks that every --forward-signals-to service is being run.
+func validateForwardSignalTargets(services map[string]service.Service) error {
+	for _, name := range forwardSignalTargets() {
+		if _, ok := services[name]; !ok {
+			return fmt.Errorf("invalid --forward-signals-to value: service %q is not being run", name)
+		}
+	}
+	return nil
+}
+
 // findAzureYaml locates the azure.yaml file.
 func findAzureYaml() (string, error) {
 	cwd, err := os.Getwd()
@@ -135,12 +390,14 @@ func runAzdMode(ctx context.Context, azureYamlPath, azureYamlDir string) error {
 	// Parse azure.yaml
 	azureYaml, err := service.ParseAzureYaml(azureYamlPath)
 	if err != nil {
-		return fmt.Errorf("failed to parse azure.yaml: %w", err)
+		return err
 	}
 
 	// Execute prerun hook before starting services
-	if err = executePrerunHook(azureYaml, azureYamlDir); err != nil {
-		return err
+	if !previewOnly() {
+		if err = executePrerunHook(azureYaml, azureYamlDir); err != nil {
+			return err
+		}
 	}
 
 	// Check if there are services defined
@@ -148,10 +405,46 @@ func runAzdMode(ctx context.Context, azureYamlPath, azureYamlDir string) error {
 		return showNoServicesMessage()
 	}
 
+	if err := validateServiceNames(azureYaml.Services); err != nil {
+		return err
+	}
+
+	// Promote inline sidecars to services that share their parent's lifecycle
+	azureYaml.Services, err = service.ExpandSidecars(azureYaml.Services)
+	if err != nil {
+		return fmt.Errorf("invalid sidecar configuration: %w", err)
+	}
+
+	// Re-run the services as the last run resolved them (--from-snapshot)
+	if runFromSnapshot {
+		runtimes, err := runtimesFromSnapshot(azureYaml.Services, azureYamlDir)
+		if err != nil {
+			return err
+		}
+		if runPrintEnv {
+			return printServiceEnvironments(runtimes, azureYamlDir)
+		}
+		if runDryRun {
+			return showDryRun(runtimes, azureYaml.Services)
+		}
+		return executeAndMonitorServices(runtimes, cwd, azureYaml, azureYamlDir)
+	}
+
 	// Filter and detect services
 	services := filterServices(azureYaml)
 	if len(services) == 0 {
-		return fmt.Errorf("no services match filter: %s", runServiceFilter)
+		if runExclude != "" {
+			return fmt.Errorf("no services left to run after --exclude %s", runExclude)
+		}
+		return fmt.Errorf("no services match filter: %s", selectedServices())
+	}
+	if err := validateForwardSignalTargets(services); err != nil {
+		return err
+	}
+
+	// Conflicting settings don't stop the run, but usually explain a service that never turns healthy
+	for _, conflict := range service.ValidateServicesConsistency(services) {
+		output.Warning("%v", conflict)
 	}
 
 	runtimes, err := detectServiceRuntimes(services, azureYamlDir, runtimeModeAzd)
@@ -159,9 +452,14 @@ func runAzdMode(ctx context.Context, azureYamlPath, azureYamlDir string) error {
 		return err
 	}
 
+	// Show the environment each service would get instead of starting them (--print-env)
+	if runPrintEnv {
+		return printServiceEnvironments(runtimes, azureYamlDir)
+	}
+
 	// Dry-run mode: show what would be executed
 	if runDryRun {
-		return showDryRun(runtimes)
+		return showDryRun(runtimes, azureYaml.Services)
 	}
 
 	// Execute and monitor services
@@ -176,13 +474,43 @@ func showNoServicesMessage() error {
 	return nil
 }
 
-// filterServices applies service filtering based on --service flag.
+// selectedServices returns the --service or --only value (they are mutually exclusive).
+func selectedServices() string {
+	if runOnly != "" {
+		return runOnly
+	}
+	return runServiceFilter
+}
+
+// filterServices applies service filtering based on the --service, --only and --exclude flags.
+// When --with-deps is set, the filter is expanded to include the dependency closure.
+// Inline sidecars of the selected services are always included, and dropped with excluded ones.
 func filterServices(azureYaml *service.AzureYaml) map[string]service.Service {
-	if runServiceFilter == "" {
-		return azureYaml.Services
+	services := azureYaml.Services
+	if selected := selectedServices(); selected != "" {
+		filterList := strings.Split(selected, ",")
+		for i := range filterList {
+			filterList[i] = strings.TrimSpace(filterList[i])
+		}
+		if runWithDeps {
+			filterList = service.ExpandDependencyClosure(azureYaml.Services, filterList)
+		}
+		filterList = service.IncludeSidecars(azureYaml.Services, filterList)
+		services = service.FilterServices(azureYaml, filterList)
+	}
+
+	excluded := serviceList(runExclude)
+	if len(excluded) == 0 {
+		return services
+	}
+	excluded = service.IncludeSidecars(azureYaml.Services, excluded)
+	remaining := make(map[string]service.Service, len(services))
+	for name, svc := range services {
+		if !slices.Contains(excluded, name) {
+			remaining[name] = svc
+		}
 	}
-	filterList := strings.Split(runServiceFilter, ",")
-	return service.FilterServices(azureYaml, filterList)
+	return remaining
 }
 
 // detectServiceRuntimes detects runtime information for all services.
@@ -199,16 +527,44 @@ func detectServiceRuntimes(services map[string]service.Service, azureYamlDir, ru
 
 	// Find azure.yaml path for updates
 	azureYamlPath := filepath.Join(azureYamlDir, "azure.yaml")
+	cache := openRuntimeCache(azureYamlPath, runNoCache)
 
 	for name, svc := range services {
-		runtime, err := service.DetectServiceRuntime(name, svc, usedPorts, azureYamlDir, runtimeMode)
+		// Move the service off its declared port if that is busy (--auto-port)
+		var remap *service.PortRemap
+		if runAutoPort {
+			start, end, err := autoPortRange()
+			if err != nil {
+				return nil, err
+			}
+			if svc, remap, err = service.RemapBusyPort(name, svc, usedPorts, start, end, service.IsPortAvailable); err != nil {
+				return nil, err
+			}
+		}
+
+		// A remapped service runs on a port azure.yaml doesn't declare, so it isn't cached
+		svcCache := cache
+		if remap != nil {
+			svcCache = nil
+		}
+
+		runtime, err := service.DetectServiceRuntimeCached(svcCache, name, svc, usedPorts, azureYamlDir, runtimeMode)
 		if err != nil {
 			return nil, fmt.Errorf("failed to detect runtime for service %s: %w", name, err)
 		}
-		usedPorts[runtime.Port] = true
+		for _, port := range runtime.HostPorts() {
+			usedPorts[port] = true
+		}
+
+		if remap != nil {
+			service.ApplyPortRemap(runtime, svc, *remap)
+			output.Warning("%s", remap)
+		}
 
 		// If we auto-assigned a port and user wants to save it, update azure.yaml
-		if runtime.ShouldUpdateAzureYaml {
+		// (inline sidecars are not top-level services, so there is no entry to update;
+		// a dry run or --print-env changes nothing)
+		if runtime.ShouldUpdateAzureYaml && svc.SidecarOf == "" && !previewOnly() {
 			if err := yamlutil.UpdateServicePort(azureYamlPath, name, runtime.Port); err != nil {
 				output.Warning("Failed to update azure.yaml for service %s: %v", name, err)
 				output.Info("   Please manually add 'ports: [\"%d\"]' to service '%s' in azure.yaml", runtime.Port, name)
@@ -220,6 +576,10 @@ func detectServiceRuntimes(services map[string]service.Service, azureYamlDir, ru
 		runtimes = append(runtimes, runtime)
 	}
 
+	if err := cache.Save(); err != nil {
+		slog.Warn("failed to save runtime cache", "path", service.RuntimeCachePath(azureYamlDir), "error", err)
+	}
+
 	return runtimes, nil
 }
 
@@ -227,19 +587,58 @@ func detectServiceRuntimes(services map[string]service.Service, azureYamlDir, ru
 func executeAndMonitorServices(runtimes []*service.ServiceRuntime, cwd string, azureYaml *service.AzureYaml, azureYamlDir string) error {
 	// Create logger
 	logger := service.NewServiceLogger(runVerbose)
+	logger.SetColor(!runNoColor && output.ColorEnabled())
 	logger.LogStartup(len(runtimes))
 
+	// Rotate the service log files in .azure/logs at the configured size
+	rotation, err := logRotation()
+	if err != nil {
+		return err
+	}
+	service.SetLogRotation(rotation)
+
+	// Show service output in the console, prefixed with the service name, unless --no-stream
+	if !runNoStream && !output.IsStructured() {
+		service.SetConsoleLogger(logger)
+		defer service.SetConsoleLogger(nil)
+	}
+
 	// Load environment variables
-	envVars, err := loadEnvironmentVariables()
+	envVars, err := loadEnvironmentVariables(azureYamlDir)
 	if err != nil {
 		return err
 	}
 
+	// Report (or reap) processes orphaned by a previous run that was killed
+	checkOrphanedProcesses(azureYamlDir, runReapOrphans, service.SystemProcessTable)
+
+	// Record the resolved configuration so this run can be diffed and reproduced
+	writeRunSnapshot(runtimes, azureYaml.Services, envVars, azureYamlDir)
+
+	// Pause the --attach-debugger service until a debugger attaches (not part of the snapshot)
+	if err := attachDebugger(runtimes); err != nil {
+		return err
+	}
+
 	// Orchestrate services with dependency ordering
-	result, err := service.OrchestrateServices(runtimes, azureYaml.Services, envVars, logger, runRestartContainers)
+	result, err := service.OrchestrateServices(runtimes, azureYaml.Services, envVars, logger, service.OrchestrateOptions{
+		RestartContainers: runRestartContainers,
+		Rebuild:           runRebuild,
+		ReadyTimeout:      runReadyTimeout,
+		FailFast:          runFailFast,
+		EnvFilter:         hostEnvFilter(),
+	})
 	if err != nil {
 		return fmt.Errorf("service orchestration failed: %w", err)
 	}
+	if len(result.Errors) > 0 {
+		names := sortedServiceNames(result.Errors)
+		output.Warning("%d service(s) failed to start: %s (use --fail-fast to stop instead)", len(names), strings.Join(names, ", "))
+	}
+	if len(result.NotReady) > 0 {
+		names := sortedServiceNames(result.NotReady)
+		output.Warning("%d service(s) did not become ready within %s: %s (use --fail-fast to stop instead)", len(names), runReadyTimeout, strings.Join(names, ", "))
+	}
 
 	// Validate that all services are ready
 	if err := service.ValidateOrchestration(result); err != nil {
@@ -247,8 +646,24 @@ func executeAndMonitorServices(runtimes []*service.ServiceRuntime, cwd string, a
 		return err
 	}
 
+	// Record started processes so they can be found if this run is killed
+	pidFile := service.NewPidFile(azureYamlDir)
+	if err := pidFile.Record(result.Processes); err != nil {
+		slog.Warn("failed to record service processes", "pidFile", pidFile.Path(), "error", err)
+	}
+
 	logger.LogReady()
 
+	// Front the services with the request-logging proxy (--proxy)
+	if runProxy {
+		proxy, err := startRequestProxy(result.Processes, cwd, logger)
+		if err != nil {
+			service.StopAllServices(result.Processes)
+			return err
+		}
+		defer stopRequestProxy(proxy)
+	}
+
 	// Execute postrun hook after all services are ready
 	if err := executePostrunHook(azureYaml, azureYamlDir); err != nil {
 		output.Warning("Postrun hook failed but services are running: %v", err)
@@ -266,21 +681,37 @@ func executeAndMonitorServices(runtimes []*service.ServiceRuntime, cwd string, a
 		}
 	}
 
+	// Restart services whose files change (--watch), that exit (--restart) or on request
+	restarter := &serviceRestarter{
+		result:      result,
+		services:    azureYaml.Services,
+		envVars:     envVars,
+		envFilter:   hostEnvFilter(),
+		logger:      logger,
+		pidFile:     pidFile,
+		projectDir:  cwd,
+		policy:      runRestartPolicy,
+		maxRestarts: runMaxRestarts,
+	}
+
 	// Start dashboard and wait for shutdown
-	return monitorServicesUntilShutdown(result, cwd)
-}
+	err = monitorServicesUntilShutdown(result, cwd, restarter)
 
-// loadEnvironmentVariables loads environment variables from --env-file if specified.
-func loadEnvironmentVariables() (map[string]string, error) {
-	if runEnvFile == "" {
-		return make(map[string]string), nil
+	// Services were stopped, so their records are no longer needed
+	if removeErr := pidFile.RemoveOwned(); removeErr != nil {
+		slog.Warn("failed to clear recorded service processes", "pidFile", pidFile.Path(), "error", removeErr)
 	}
+	return err
+}
 
-	envVars, err := service.LoadDotEnv(runEnvFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load env file: %w", err)
+// sortedServiceNames returns the names of the services in errs, sorted.
+func sortedServiceNames(errs map[string]error) []string {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
 	}
-	return envVars, nil
+	sort.Strings(names)
+	return names
 }
 
 // monitorServicesUntilShutdown monitors all services with full process isolation.
@@ -294,18 +725,26 @@ func loadEnvironmentVariables() (map[string]string, error) {
 // Lifecycle:
 //  1. Start monitoring goroutines (one per service + dashboard)
 //  2. Wait for user signal (Ctrl+C) or all services to naturally exit
-//  3. On signal: initiate graceful shutdown with 10-second timeout
-//  4. Stop all remaining services and dashboard
+//  3. On signal: initiate graceful shutdown
+//  4. Stop the dashboard, then the remaining services in reverse dependency order,
+//     each given --shutdown-timeout to exit before it is killed
 //
 // This uses sync.WaitGroup (not errgroup) because we want all goroutines to complete
 // independently rather than failing fast on first error.
-func monitorServicesUntilShutdown(result *service.OrchestrationResult, cwd string) error {
+func monitorServicesUntilShutdown(result *service.OrchestrationResult, cwd string, restarter *serviceRestarter) error {
 	// Create context that cancels on SIGINT/SIGTERM only
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	var wg sync.WaitGroup
 	dashboardServer := dashboard.GetServer(cwd)
+	if restarter != nil {
+		// Restarts requested through the dashboard (and the restart_service MCP tool)
+		// A restart stops the service, then waits up to --ready-timeout for it
+		dashboardServer.SetServiceRestarter(func(_ context.Context, name string) (*dashboard.ServiceRestartResult, error) {
+			return restarter.restartOnRequest(ctx, &wg, name)
+		}, service.DefaultStopTimeout+runReadyTimeout)
+	}
 
 	// Start notification manager for OS notifications on service issues
 	notifMgr, err := notifications.NewNotificationManager(
@@ -323,13 +762,21 @@ func monitorServicesUntilShutdown(result *service.OrchestrationResult, cwd strin
 	startDashboardMonitor(ctx, &wg, dashboardServer, notifMgr)
 
 	// Start service process monitors
-	startServiceMonitors(ctx, &wg, result.Processes, cwd)
+	startServiceMonitors(ctx, &wg, result, cwd, restarter)
+
+	// Relay reload signals (--forward-signals) to services until shutdown
+	startSignalForwarding(ctx, result)
+
+	// Restart services when their files change (--watch)
+	if restarter != nil && runWatch {
+		restarter.start(ctx, &wg)
+	}
 
 	// Wait for signal (context cancellation) or all services to complete
 	wg.Wait()
 
 	// Perform cleanup shutdown
-	return performGracefulShutdown(dashboardServer, result.Processes)
+	return performGracefulShutdown(dashboardServer, result.CurrentProcesses(), result.ShutdownOrder)
 }
 
 // startDashboardMonitor starts the dashboard server in a separate goroutine with panic recovery.
@@ -374,22 +821,77 @@ func startDashboardMonitor(ctx context.Context, wg *sync.WaitGroup, dashboardSer
 }
 
 // startServiceMonitors starts monitoring goroutines for all service processes.
-func startServiceMonitors(ctx context.Context, wg *sync.WaitGroup, processes map[string]*service.ServiceProcess, projectDir string) {
-	for name, process := range processes {
+// Inline sidecars share their parent's lifecycle: when a parent exits on its own,
+// its sidecars are stopped too. restarter restarts services as --restart allows; it may be nil.
+func startServiceMonitors(ctx context.Context, wg *sync.WaitGroup, result *service.OrchestrationResult, projectDir string, restarter *serviceRestarter) {
+	for name, process := range result.CurrentProcesses() {
 		if process.Process == nil {
 			continue
 		}
-		wg.Add(1)
-		go monitorServiceProcess(ctx, wg, name, process, projectDir)
+		startServiceMonitor(ctx, wg, name, process, result, projectDir, restarter)
 	}
 }
 
-// performGracefulShutdown stops all services and dashboard with a timeout.
-// Returns nil due to process isolation design - individual failures are logged but don't fail the command.
-func performGracefulShutdown(dashboardServer *dashboard.Server, processes map[string]*service.ServiceProcess) error {
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer shutdownCancel()
+// startServiceMonitor starts the monitoring goroutine for one service process.
+func startServiceMonitor(ctx context.Context, wg *sync.WaitGroup, name string, process *service.ServiceProcess, result *service.OrchestrationResult, projectDir string, restarter *serviceRestarter) {
+	// One count for the monitor itself, one for handling the exit after it returns
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		exit := monitorServiceProcess(ctx, wg, name, process, projectDir)
+		// Context cancellation means a coordinated shutdown will stop everything,
+		// and a --watch restart keeps the sidecars for the new process
+		if ctx.Err() != nil || exit == serviceExitWatchRestart {
+			return
+		}
+		// A --restart policy restart keeps the sidecars too
+		if restarter.handleExit(ctx, wg, name, process, exit) {
+			return
+		}
+		if len(result.Sidecars[name]) > 0 {
+			service.StopSidecars(name, result.Sidecars, result.CurrentProcesses())
+		}
+	}()
+}
 
+// startSignalForwarding relays the --forward-signals signals to the selected services
+// until ctx is cancelled. Without the flag those signals keep their default behavior.
+// Each signal goes to the services' current processes, so restarted services receive it too.
+func startSignalForwarding(ctx context.Context, result *service.OrchestrationResult) {
+	if len(runForwardSignals) == 0 || !service.SignalForwardingSupported {
+		return
+	}
+	// Already validated by validateForwardSignals and runAzdMode
+	signals, err := service.ParseForwardSignals(runForwardSignals)
+	if err != nil {
+		return
+	}
+	targets := forwardSignalTargets()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, signals...)
+	go func() {
+		defer signal.Stop(sigChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigChan:
+				delivered, err := service.NewSignalForwarder(result.CurrentProcesses(), targets).Forward(sig)
+				if err != nil {
+					output.Warning("Failed to forward %v: %v", sig, err)
+				}
+				if len(delivered) > 0 {
+					output.Info("Forwarded %v to %s", sig, strings.Join(delivered, ", "))
+				}
+			}
+		}
+	}()
+}
+
+// performGracefulShutdown stops the dashboard, then the services in shutdown order.
+// Returns nil due to process isolation design - individual failures are logged but don't fail the command.
+func performGracefulShutdown(dashboardServer *dashboard.Server, processes map[string]*service.ServiceProcess, shutdownOrder [][]string) error {
 	output.Newline()
 	output.Newline()
 	output.Plain("Shutting down...")
@@ -399,8 +901,12 @@ func performGracefulShutdown(dashboardServer *dashboard.Server, processes map[st
 		output.Warning("Failed to stop dashboard: %v", stopErr)
 	}
 
-	// Stop all services with graceful timeout
-	if stopErr := shutdownAllServices(shutdownCtx, processes); stopErr != nil {
+	// Stop dependents before the services they use, each with a graceful timeout
+	timeout := runShutdownTimeout
+	if timeout <= 0 {
+		timeout = service.DefaultGracefulShutdownTimeout
+	}
+	if stopErr := shutdownAllServices(processes, shutdownOrder, timeout); stopErr != nil {
 		output.Warning("Some services failed to stop cleanly: %v", stopErr)
 	}
 
@@ -421,7 +927,8 @@ func performGracefulShutdown(dashboardServer *dashboard.Server, processes map[st
 // monitorServiceProcess monitors a single service process for exit or cancellation.
 // This function runs in its own goroutine with panic recovery to ensure one service
 // crash doesn't affect others (process isolation).
-func monitorServiceProcess(ctx context.Context, wg *sync.WaitGroup, serviceName string, proc *service.ServiceProcess, projectDir string) {
+// Returns how the process ended.
+func monitorServiceProcess(ctx context.Context, wg *sync.WaitGroup, serviceName string, proc *service.ServiceProcess, projectDir string) (exit serviceExit) {
 	defer wg.Done()
 	defer func() {
 		if r := recover(); r != nil {
@@ -436,8 +943,10 @@ func monitorServiceProcess(ctx context.Context, wg *sync.WaitGroup, serviceName
 		err      error
 	}
 	waitDone := make(chan exitResult, 1)
+	// --watch may replace proc's process after a restart; this monitor follows the current one
+	process := proc.Process
 	go func() {
-		state, err := proc.Process.Wait()
+		state, err := process.Wait()
 		if err != nil {
 			waitDone <- exitResult{exitCode: -1, err: fmt.Errorf("service %s exited with error: %w", serviceName, err)}
 			return
@@ -452,6 +961,11 @@ func monitorServiceProcess(ctx context.Context, wg *sync.WaitGroup, serviceName
 
 	select {
 	case result := <-waitDone:
+		// A --watch restart is not a crash; the restarted process gets its own monitor
+		if stoppedForWatchRestart(process) {
+			return serviceExitWatchRestart
+		}
+
 		// Service exited - record exit info in registry
 		reg := registry.GetRegistry(projectDir)
 		endTime := time.Now()
@@ -538,55 +1052,91 @@ func monitorServiceProcess(ctx context.Context, wg *sync.WaitGroup, serviceName
 			}
 		}
 		// Intentionally don't cancel context - other services should continue
+		if result.err != nil {
+			return serviceExitFailed
+		}
+		return serviceExitClean
 	case <-ctx.Done():
 		// Context cancelled by signal - proceed to graceful shutdown
-		return
 	}
+	return serviceExitShutdown
 }
 
-// shutdownAllServices stops all services with graceful timeout.
-// Runs all shutdowns in parallel goroutines and waits for all to complete.
+// shutdownAllServices stops the running services wave by wave (see shutdownWaves), so a
+// service stops only after the services that use it. The services of a wave stop in
+// parallel, each given timeout to exit after the interrupt before it is killed.
 // Returns aggregated errors from any services that failed to stop cleanly.
-func shutdownAllServices(ctx context.Context, processes map[string]*service.ServiceProcess) error {
+func shutdownAllServices(processes map[string]*service.ServiceProcess, shutdownOrder [][]string, timeout time.Duration) error {
 	var shutdownErrors []error
 	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	for name, process := range processes {
-		wg.Add(1)
-		go func(serviceName string, proc *service.ServiceProcess) {
-			defer wg.Done()
-
-			if proc.Process == nil {
-				return
-			}
 
-			// Determine timeout from context
-			deadline, ok := ctx.Deadline()
-			timeout := service.DefaultStopTimeout
-			if ok {
-				timeout = time.Until(deadline)
-				if timeout < time.Second {
-					timeout = time.Second
+	for _, wave := range shutdownWaves(processes, shutdownOrder) {
+		var wg sync.WaitGroup
+		for _, name := range wave {
+			wg.Add(1)
+			go func(serviceName string, proc *service.ServiceProcess) {
+				defer wg.Done()
+
+				output.Item("Stopping %s...", serviceName)
+				if err := service.StopServiceGraceful(proc, timeout); err != nil {
+					output.ItemWarning("%s: %v", serviceName, err)
+					mu.Lock()
+					shutdownErrors = append(shutdownErrors, fmt.Errorf("%s: %w", serviceName, err))
+					mu.Unlock()
+					return
 				}
-			}
-
-			if err := service.StopServiceGraceful(proc, timeout); err != nil {
-				mu.Lock()
-				shutdownErrors = append(shutdownErrors, fmt.Errorf("%s: %w", serviceName, err))
-				mu.Unlock()
-			}
-		}(name, process)
+				output.ItemSuccess("%s stopped", serviceName)
+			}(name, processes[name])
+		}
+		wg.Wait()
 	}
 
-	wg.Wait()
-
 	if len(shutdownErrors) > 0 {
 		return fmt.Errorf("failed to stop %d service(s): %w", len(shutdownErrors), errors.Join(shutdownErrors...))
 	}
 	return nil
 }
 
+// shutdownWaves groups the services with a running process by shutdownOrder, dropping
+// empty groups. Running services shutdownOrder doesn't name stop with the last wave.
+func shutdownWaves(processes map[string]*service.ServiceProcess, shutdownOrder [][]string) [][]string {
+	running := func(name string) bool {
+		proc := processes[name]
+		return proc != nil && proc.Process != nil
+	}
+
+	placed := make(map[string]bool)
+	var waves [][]string
+	for _, group := range shutdownOrder {
+		var wave []string
+		for _, name := range group {
+			if running(name) && !placed[name] {
+				wave = append(wave, name)
+				placed[name] = true
+			}
+		}
+		if len(wave) > 0 {
+			waves = append(waves, wave)
+		}
+	}
+
+	var rest []string
+	for name := range processes {
+		if running(name) && !placed[name] {
+			rest = append(rest, name)
+		}
+	}
+	if len(rest) == 0 {
+		return waves
+	}
+	sort.Strings(rest)
+	if len(waves) == 0 {
+		return [][]string{rest}
+	}
+	waves[len(waves)-1] = append(waves[len(waves)-1], rest...)
+	return waves
+}
+
 // runAspireMode runs Aspire AppHost directly using dotnet run.
 func runAspireMode(ctx context.Context, rootDir string) error {
 	// Find Aspire AppHost project
@@ -596,19 +1146,42 @@ func runAspireMode(ctx context.Context, rootDir string) error {
 	}
 
 	if aspireProject == nil {
-		return fmt.Errorf("no Aspire AppHost found - --runtime aspire requires an AppHost.cs or Program.cs file in a .csproj project")
+		return fmt.Errorf("no Aspire AppHost found - --runtime aspire requires a .csproj that references Aspire.Hosting.AppHost")
+	}
+
+	// Use executor to run dotnet with proper environment inheritance
+	args := []string{"run", "--project", aspireProject.ProjectFile}
+	dashboardURL := aspireDashboardURL(aspireProject)
+
+	if runDryRun {
+		plan := map[string]string{
+			"runtime":      runtimeModeAspire,
+			"appHost":      aspireProject.ProjectFile,
+			"command":      "dotnet " + strings.Join(args, " "),
+			"dashboardUrl": dashboardURL,
+		}
+		return output.Print(plan, func() {
+			output.Section("🔍", "Dry-run mode: Showing execution plan")
+			output.Label("AppHost", aspireProject.ProjectFile)
+			output.Label("Command", plan["command"])
+			if dashboardURL != "" {
+				output.Label("Dashboard", dashboardURL)
+			}
+		})
 	}
 
 	output.Plain("Running Aspire in native mode")
 	output.Item("Directory: %s", aspireProject.Dir)
 	output.Item("Project: %s", aspireProject.ProjectFile)
 	output.Newline()
-	output.Plain("Aspire dashboard will start automatically")
+	if dashboardURL != "" {
+		output.Plain("Aspire dashboard: %s", dashboardURL)
+		output.Item("Aspire prints a login link with a token once the dashboard is ready")
+	} else {
+		output.Plain("Aspire dashboard will start automatically")
+	}
 	output.Newline()
 
-	// Use executor to run dotnet with proper environment inheritance
-	args := []string{"run", "--project", aspireProject.ProjectFile}
-
 	output.Hint("Press Ctrl+C to stop")
 	output.Newline()
 
@@ -616,23 +1189,6 @@ func runAspireMode(ctx context.Context, rootDir string) error {
 	return executor.StartCommand(ctx, "dotnet", args, aspireProject.Dir)
 }
 
-// showDryRun displays what would be executed without starting services.
-func showDryRun(runtimes []*service.ServiceRuntime) error {
-	output.Section("🔍", "Dry-run mode: Showing execution plan")
-
-	for _, runtime := range runtimes {
-		output.Newline()
-		output.Info("%s", runtime.Name)
-		output.Label("Language", runtime.Language)
-		output.Label("Framework", runtime.Framework)
-		output.Label("Port", fmt.Sprintf("%d", runtime.Port))
-		output.Label("Directory", runtime.WorkingDir)
-		output.Label("Command", fmt.Sprintf("%s %v", runtime.Command, runtime.Args))
-	}
-
-	return nil
-}
-
 // executePrerunHook executes the prerun hook if configured.
 func executePrerunHook(azureYaml *service.AzureYaml, workingDir string) error {
 	return executeHook(azureYaml, azureYaml.Hooks, azureYaml.Hooks.GetPrerun(), "prerun", workingDir)
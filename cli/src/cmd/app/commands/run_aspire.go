@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// detectAspireAppHost returns the Aspire AppHost that a run without --runtime should hand
+// orchestration to: one found below azure.yaml when azure.yaml defines no services, or only
+// the AppHost itself (as 'azd init' does for Aspire projects). Otherwise it returns nil and
+// the services are orchestrated individually.
+func detectAspireAppHost(azureYamlPath string) *types.AspireProject {
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	appHost, err := detector.FindAppHost(azureYamlDir)
+	if err != nil || appHost == nil {
+		return nil
+	}
+
+	azureYaml, err := service.ParseAzureYaml(azureYamlPath)
+	if err != nil {
+		return nil // Reported when the services are run
+	}
+	appHostDir := service.PathKey(appHost.Dir)
+	appHostFile := service.PathKey(appHost.ProjectFile)
+	for _, svc := range azureYaml.Services {
+		projectPath, err := filepath.Abs(service.ResolveProjectPath(azureYamlDir, svc.Project))
+		if err != nil {
+			return nil
+		}
+		if key := service.PathKey(projectPath); key != appHostDir && key != appHostFile {
+			return nil
+		}
+	}
+	return appHost
+}
+
+// aspireDashboardURL returns the dashboard URL of the launch profile 'dotnet run' uses for
+// the AppHost (the first "Project" profile in Properties/launchSettings.json), or "" when it
+// can't be determined.
+func aspireDashboardURL(appHost *types.AspireProject) string {
+	data, err := os.ReadFile(filepath.Join(appHost.Dir, "Properties", "launchSettings.json")) // #nosec G304 -- Path is within the AppHost project
+	if err != nil {
+		return ""
+	}
+	var settings struct {
+		Profiles json.RawMessage `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil || len(settings.Profiles) == 0 {
+		return ""
+	}
+
+	// Profiles are read in file order, which decides the one dotnet run picks
+	decoder := json.NewDecoder(bytes.NewReader(settings.Profiles))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+	for decoder.More() {
+		if _, err := decoder.Token(); err != nil {
+			return ""
+		}
+		var profile struct {
+			CommandName    string `json:"commandName"`
+			ApplicationURL string `json:"applicationUrl"`
+		}
+		if err := decoder.Decode(&profile); err != nil {
+			return ""
+		}
+		if profile.CommandName == "Project" {
+			url, _, _ := strings.Cut(profile.ApplicationURL, ";")
+			return strings.TrimSpace(url)
+		}
+	}
+	return ""
+}
+
+// applyDetectedRuntime switches a run without --runtime to the aspire runtime when
+// detectAspireAppHost finds an AppHost that orchestrates the project.
+func applyDetectedRuntime() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	azureYamlPath, err := detector.FindAzureYaml(cwd)
+	if err != nil || azureYamlPath == "" {
+		return
+	}
+	appHost := detectAspireAppHost(azureYamlPath)
+	if appHost == nil {
+		return
+	}
+	output.Info("Found Aspire AppHost %s; running it with dotnet run (use --runtime azd to run the services individually)", appHost.ProjectFile)
+	runRuntime = runtimeModeAspire
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+const testAppHostCsproj = `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Aspire.Hosting.AppHost" Version="9.0.0" />
+  </ItemGroup>
+</Project>`
+
+// writeTestFiles writes files (relative path to content) below dir.
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func TestDetectAspireAppHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		azureYaml   string
+		withAppHost bool
+		want        bool
+	}{
+		{
+			name:        "no services",
+			azureYaml:   "name: app\n",
+			withAppHost: true,
+			want:        true,
+		},
+		{
+			name:        "only the AppHost service",
+			azureYaml:   "name: app\nservices:\n  app:\n    project: ./AppHost\n    language: dotnet\n    host: containerapp\n",
+			withAppHost: true,
+			want:        true,
+		},
+		{
+			name:        "AppHost project file as the service project",
+			azureYaml:   "name: app\nservices:\n  app:\n    project: ./AppHost/AppHost.csproj\n    language: dotnet\n",
+			withAppHost: true,
+			want:        true,
+		},
+		{
+			name:        "other services",
+			azureYaml:   "name: app\nservices:\n  app:\n    project: ./AppHost\n    language: dotnet\n  web:\n    project: ./web\n    language: js\n",
+			withAppHost: true,
+			want:        false,
+		},
+		{
+			name:      "no AppHost",
+			azureYaml: "name: app\nservices:\n  api:\n    project: ./Api\n    language: dotnet\n",
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"azure.yaml":     tt.azureYaml,
+				"Api/Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+				"Api/Program.cs": "// API",
+			}
+			if tt.withAppHost {
+				files["AppHost/AppHost.csproj"] = testAppHostCsproj
+				files["AppHost/Program.cs"] = "// AppHost"
+			}
+			writeTestFiles(t, dir, files)
+
+			appHost := detectAspireAppHost(filepath.Join(dir, "azure.yaml"))
+			if got := appHost != nil; got != tt.want {
+				t.Fatalf("detectAspireAppHost() = %+v, want AppHost found: %v", appHost, tt.want)
+			}
+			if tt.want && appHost.ProjectFile != filepath.Join(dir, "AppHost", "AppHost.csproj") {
+				t.Errorf("detectAspireAppHost() ProjectFile = %q, want the AppHost csproj", appHost.ProjectFile)
+			}
+		})
+	}
+}
+
+func TestAspireDashboardURL(t *testing.T) {
+	dir := t.TempDir()
+	appHost := &types.AspireProject{Dir: dir, ProjectFile: filepath.Join(dir, "AppHost.csproj")}
+
+	if got := aspireDashboardURL(appHost); got != "" {
+		t.Errorf("aspireDashboardURL() without launchSettings.json = %q, want empty", got)
+	}
+
+	// dotnet run uses the first Project profile in file order
+	writeTestFiles(t, dir, map[string]string{
+		"Properties/launchSettings.json": `{
+  "profiles": {
+    "docker": { "commandName": "Docker" },
+    "https": { "commandName": "Project", "applicationUrl": "https://localhost:17032;http://localhost:15021" },
+    "http": { "commandName": "Project", "applicationUrl": "http://localhost:15021" }
+  }
+}`,
+	})
+	if got := aspireDashboardURL(appHost); got != "https://localhost:17032" {
+		t.Errorf("aspireDashboardURL() = %q, want https://localhost:17032", got)
+	}
+}
+
+func TestRunAspireModeDryRun(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"AppHost/AppHost.csproj": testAppHostCsproj,
+		"AppHost/Program.cs":     "// AppHost",
+	})
+
+	runDryRun = true
+	defer func() { runDryRun = false }()
+
+	// A dry run shows the plan without running dotnet
+	if err := runAspireMode(context.Background(), dir); err != nil {
+		t.Errorf("runAspireMode() dry run error = %v", err)
+	}
+}
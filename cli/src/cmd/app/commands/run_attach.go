@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jongio/azd-app/cli/src/internal/dashboard"
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// errNoRunningSession is returned by --attach when no azd app run session serves this project.
+var errNoRunningSession = errors.New("no running 'azd app run' session found for this project (start one with 'azd app run')")
+
+// newAttachClient connects to the dashboard of the session running in projectDir. A variable
+// so tests can replace it.
+var newAttachClient = func(ctx context.Context, projectDir string) (DashboardClient, error) {
+	return dashboard.NewClient(ctx, projectDir)
+}
+
+// attachToRunningSession streams the combined output of the azd app run session already
+// running in this project, in the same prefixed format, until Ctrl+C (azd app run --attach).
+// Nothing is started, and detaching leaves the services running. --service or --only limit
+// the output to some services.
+func attachToRunningSession(ctx context.Context) error {
+	if err := validateServiceSelection(); err != nil {
+		return err
+	}
+	serviceFilter, err := parseServiceList(selectedServices())
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := service.NewServiceLogger(runVerbose)
+	logger.SetColor(!runNoColor && output.ColorEnabled())
+
+	return streamSessionLogs(ctx, cwd, serviceFilter, logger, os.Stdout)
+}
+
+// streamSessionLogs connects to the session's dashboard and writes every log line of the
+// selected services (all when serviceFilter is empty) to w until ctx is cancelled or the
+// session ends.
+func streamSessionLogs(ctx context.Context, projectDir string, serviceFilter []string, logger *service.ServiceLogger, w io.Writer) error {
+	dashCtx, dashCancel := context.WithTimeout(ctx, dashboardOperationTimeout)
+	defer dashCancel()
+
+	client, err := newAttachClient(dashCtx, projectDir)
+	if err != nil {
+		return errNoRunningSession
+	}
+	if err := client.Ping(dashCtx); err != nil {
+		return errNoRunningSession
+	}
+	services, err := client.GetServices(dashCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get services from dashboard: %w", err)
+	}
+	if len(services) == 0 {
+		return errNoRunningSession
+	}
+
+	running := make(map[string]bool, len(services))
+	for _, svc := range services {
+		running[svc.Name] = true
+	}
+	selected := make(map[string]bool, len(serviceFilter))
+	for _, name := range serviceFilter {
+		if !running[name] {
+			return fmt.Errorf("service %q is not running in this session", name)
+		}
+		selected[name] = true
+	}
+
+	output.Info("Attached to %d running service(s) (Ctrl+C to detach; services keep running)", len(services))
+
+	logs := make(chan service.LogEntry, logChannelBufferSize)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- client.StreamLogs(ctx, "", logs)
+	}()
+
+	write := func(entry service.LogEntry) {
+		if len(selected) == 0 || selected[entry.Service] {
+			fmt.Fprintln(w, logger.FormatOutputLine(entry.Service, entry.Message, entry.IsStderr))
+		}
+	}
+
+	for {
+		select {
+		case entry := <-logs:
+			write(entry)
+		case err := <-errChan:
+			// Show the lines that arrived before the stream ended
+			for len(logs) > 0 {
+				write(<-logs)
+			}
+			if ctx.Err() != nil {
+				output.Info("Detached; services are still running")
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("log stream error: %w", err)
+			}
+			output.Info("The azd app run session ended")
+			return nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/jongio/azd-app/cli/src/internal/serviceinfo"
+)
+
+// endingDashboardClient streams its entries and then ends the stream, as when the session stops.
+type endingDashboardClient struct {
+	mockDashboardClient
+}
+
+func (m *endingDashboardClient) StreamLogs(ctx context.Context, serviceName string, logs chan<- service.LogEntry) error {
+	for _, entry := range m.logEntries {
+		logs <- entry
+	}
+	return nil
+}
+
+// useAttachClient replaces the dashboard client used by --attach for the duration of a test.
+func useAttachClient(t *testing.T, client DashboardClient, err error) {
+	t.Helper()
+	original := newAttachClient
+	newAttachClient = func(ctx context.Context, projectDir string) (DashboardClient, error) {
+		return client, err
+	}
+	t.Cleanup(func() { newAttachClient = original })
+}
+
+func TestStreamSessionLogs_NoSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    DashboardClient
+		clientErr error
+	}{
+		{name: "no dashboard", clientErr: errors.New("dashboard not running for project")},
+		{name: "dashboard not responding", client: &mockDashboardClient{pingErr: errors.New("connection refused")}},
+		{name: "no services", client: &mockDashboardClient{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useAttachClient(t, tt.client, tt.clientErr)
+
+			var buf bytes.Buffer
+			err := streamSessionLogs(context.Background(), t.TempDir(), nil, service.NewServiceLogger(false), &buf)
+			if !errors.Is(err, errNoRunningSession) {
+				t.Errorf("streamSessionLogs() error = %v, want %v", err, errNoRunningSession)
+			}
+		})
+	}
+}
+
+func TestStreamSessionLogs_UnknownService(t *testing.T) {
+	useAttachClient(t, &mockDashboardClient{services: []*serviceinfo.ServiceInfo{{Name: "api"}}}, nil)
+
+	var buf bytes.Buffer
+	err := streamSessionLogs(context.Background(), t.TempDir(), []string{"web"}, service.NewServiceLogger(false), &buf)
+	if err == nil || !strings.Contains(err.Error(), `"web" is not running`) {
+		t.Errorf("streamSessionLogs() error = %v, want service not running", err)
+	}
+}
+
+func TestStreamSessionLogs_StreamsPrefixedOutput(t *testing.T) {
+	client := &endingDashboardClient{mockDashboardClient{
+		services: []*serviceinfo.ServiceInfo{{Name: "api"}, {Name: "web"}},
+		logEntries: []service.LogEntry{
+			{Service: "api", Message: "listening on 3001"},
+			{Service: "web", Message: "compiled"},
+			{Service: "api", Message: "request failed", IsStderr: true},
+		},
+	}}
+	useAttachClient(t, client, nil)
+
+	tests := []struct {
+		name          string
+		serviceFilter []string
+		want          string
+	}{
+		{
+			name: "all services",
+			want: "api | listening on 3001\nweb | compiled\napi | request failed\n",
+		},
+		{
+			name:          "selected service",
+			serviceFilter: []string{"web"},
+			want:          "web | compiled\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := service.NewServiceLogger(false)
+			logger.SetColor(false)
+
+			var buf bytes.Buffer
+			if err := streamSessionLogs(context.Background(), t.TempDir(), tt.serviceFilter, logger, &buf); err != nil {
+				t.Fatalf("streamSessionLogs() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamSessionLogs_DetachLeavesServicesRunning(t *testing.T) {
+	client := &mockDashboardClient{
+		services:   []*serviceinfo.ServiceInfo{{Name: "api"}},
+		logEntries: []service.LogEntry{{Service: "api", Message: "ready"}},
+	}
+	useAttachClient(t, client, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	logger := service.NewServiceLogger(false)
+	logger.SetColor(false)
+	var buf bytes.Buffer
+	if err := streamSessionLogs(ctx, t.TempDir(), nil, logger, &buf); err != nil {
+		t.Errorf("streamSessionLogs() error = %v, want nil after detaching", err)
+	}
+	if buf.String() != "api | ready\n" {
+		t.Errorf("output = %q, want %q", buf.String(), "api | ready\n")
+	}
+}
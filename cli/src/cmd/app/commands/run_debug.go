@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// attachDebugger makes the --attach-debugger service start paused until a debugger attaches,
+// so breakpoints in its startup code are hit.
+func attachDebugger(runtimes []*service.ServiceRuntime) error {
+	if runAttachDebugger == "" {
+		return nil
+	}
+
+	for _, rt := range runtimes {
+		if rt.Name != runAttachDebugger {
+			continue
+		}
+		address, err := service.WaitForDebugger(rt)
+		if err != nil {
+			return fmt.Errorf("cannot attach a debugger to %s: %w", rt.Name, err)
+		}
+		output.Info("%s will wait for a debugger on %s before starting", rt.Name, address)
+		return nil
+	}
+	return fmt.Errorf("invalid --attach-debugger value: service %q is not being run", runAttachDebugger)
+}
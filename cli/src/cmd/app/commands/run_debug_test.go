@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestAttachDebugger(t *testing.T) {
+	defer func() { runAttachDebugger = "" }()
+
+	newRuntimes := func() []*service.ServiceRuntime {
+		return []*service.ServiceRuntime{
+			{Name: "api", Language: "JavaScript", Command: "node", Args: []string{"server.js"}},
+			{Name: "web", Language: "TypeScript", Command: "npm", Args: []string{"run", "dev"}},
+		}
+	}
+
+	runtimes := newRuntimes()
+	if err := attachDebugger(runtimes); err != nil {
+		t.Fatalf("attachDebugger() without --attach-debugger error = %v", err)
+	}
+	if runtimes[0].Args[0] != "server.js" {
+		t.Errorf("attachDebugger() without --attach-debugger changed args: %v", runtimes[0].Args)
+	}
+
+	runAttachDebugger = "api"
+	runtimes = newRuntimes()
+	if err := attachDebugger(runtimes); err != nil {
+		t.Fatalf("attachDebugger() error = %v", err)
+	}
+	if runtimes[0].Args[0] != "--inspect-brk=127.0.0.1:9229" {
+		t.Errorf("api args = %v, want --inspect-brk first", runtimes[0].Args)
+	}
+	if runtimes[1].Args[0] != "run" {
+		t.Errorf("web args changed: %v", runtimes[1].Args)
+	}
+
+	runAttachDebugger = "web"
+	if err := attachDebugger(newRuntimes()); err == nil || !strings.Contains(err.Error(), "requires a node command") {
+		t.Errorf("attachDebugger(web) error = %v, want unsupported command", err)
+	}
+
+	runAttachDebugger = "worker"
+	if err := attachDebugger(newRuntimes()); err == nil || !strings.Contains(err.Error(), "is not being run") {
+		t.Errorf("attachDebugger(worker) error = %v, want unknown service", err)
+	}
+}
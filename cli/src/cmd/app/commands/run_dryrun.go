@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// showDryRun displays what would be executed without starting services: each service in
+// start order with its resolved command, ports, health check and dependencies, as text or
+// as the service.RunPlan with --output json or yaml. services are the azure.yaml services
+// with sidecars expanded.
+func showDryRun(runtimes []*service.ServiceRuntime, services map[string]service.Service) error {
+	if err := attachDebugger(runtimes); err != nil {
+		return err
+	}
+
+	plan, err := service.NewRunPlan(runtimes, services)
+	if err != nil {
+		return err
+	}
+	if output.IsStructured() {
+		return output.PrintStructured(plan)
+	}
+
+	output.Section("🔍", "Dry-run mode: Showing execution plan")
+
+	for _, svc := range plan.Services {
+		output.Newline()
+		output.Info("%s", svc.Name)
+		if svc.Language != "" {
+			output.Label("Language", svc.Language)
+		}
+		if svc.Framework != "" {
+			output.Label("Framework", svc.Framework)
+		}
+		if svc.Port > 0 {
+			output.Label("Port", fmt.Sprintf("%d", svc.Port))
+		}
+		if len(svc.Ports) > 1 {
+			rt := &service.ServiceRuntime{Ports: svc.Ports}
+			output.Label("Ports", formatPorts(rt.RegistryPorts()))
+		}
+		if svc.WorkingDir != "" {
+			output.Label("Directory", svc.WorkingDir)
+		}
+		output.Label("Command", strings.Join(append([]string{svc.Command}, svc.Args...), " "))
+		output.Label("Health", describeHealthCheck(svc.HealthCheck, svc.Port))
+		if len(svc.DependsOn) > 0 {
+			output.Label("Depends on", strings.Join(svc.DependsOn, ", "))
+		}
+		if len(svc.Env) > 0 {
+			output.Label("Environment", "")
+			keys := make([]string, 0, len(svc.Env))
+			for key := range svc.Env {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				output.Item("   %s=%s", key, svc.Env[key])
+			}
+		}
+	}
+
+	levels := make([]string, len(plan.Levels))
+	for i, level := range plan.Levels {
+		levels[i] = strings.Join(level, ", ")
+	}
+	output.Newline()
+	output.Info("Start order: %s", strings.Join(levels, " → "))
+	output.Info("Run without --dry-run to start the services")
+	return nil
+}
+
+// describeHealthCheck summarizes how a service's readiness is checked, e.g. "http /health on port 8080".
+// Checks without a port of their own target the service's primary port.
+func describeHealthCheck(hc service.HealthCheckSnapshot, port int) string {
+	if hc.Port > 0 {
+		port = hc.Port
+	}
+	if len(hc.Test) > 0 {
+		return fmt.Sprintf("test %q", strings.Join(hc.Test, " "))
+	}
+	switch hc.Type {
+	case "", "none":
+		return "none"
+	case "http", service.HealthCheckTypeWebSocket:
+		path := hc.Path
+		if path == "" {
+			path = "/"
+		}
+		if port > 0 {
+			return fmt.Sprintf("%s %s on port %d", hc.Type, path, port)
+		}
+		return hc.Type + " " + path
+	case "tcp", "udp":
+		return fmt.Sprintf("%s port %d", hc.Type, port)
+	case "output":
+		return fmt.Sprintf("output match %q", hc.LogMatch)
+	default:
+		return hc.Type
+	}
+}
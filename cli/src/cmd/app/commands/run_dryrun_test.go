@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestDescribeHealthCheck(t *testing.T) {
+	tests := []struct {
+		hc   service.HealthCheckSnapshot
+		port int
+		want string
+	}{
+		{service.HealthCheckSnapshot{}, 3000, "none"},
+		{service.HealthCheckSnapshot{Type: "http", Path: "/health", Port: 8080}, 3000, "http /health on port 8080"},
+		{service.HealthCheckSnapshot{Type: "http"}, 3000, "http / on port 3000"},
+		{service.HealthCheckSnapshot{Type: "tcp", Port: 5432}, 5432, "tcp port 5432"},
+		{service.HealthCheckSnapshot{Type: "tcp"}, 6379, "tcp port 6379"},
+		{service.HealthCheckSnapshot{Type: "udp", Port: 53}, 0, "udp port 53"},
+		{service.HealthCheckSnapshot{Type: "ws", Path: "/socket"}, 8080, "ws /socket on port 8080"},
+		{service.HealthCheckSnapshot{Type: "process"}, 0, "process"},
+		{service.HealthCheckSnapshot{Type: "none"}, 3000, "none"},
+		{service.HealthCheckSnapshot{Type: "output", LogMatch: "Server started"}, 0, `output match "Server started"`},
+		{service.HealthCheckSnapshot{Type: "tcp", Test: []string{"CMD", "pg_isready"}}, 5432, `test "CMD pg_isready"`},
+	}
+	for _, tt := range tests {
+		if got := describeHealthCheck(tt.hc, tt.port); got != tt.want {
+			t.Errorf("describeHealthCheck(%+v, %d) = %q, want %q", tt.hc, tt.port, got, tt.want)
+		}
+	}
+}
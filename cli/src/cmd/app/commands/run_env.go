@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// profileNamePattern matches --profile names, which become part of a file name.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// validateProfile checks the --profile name.
+func validateProfile() error {
+	if runProfile != "" && !profileNamePattern.MatchString(runProfile) {
+		return fmt.Errorf("invalid --profile %q: use letters, digits, '-' and '_'", runProfile)
+	}
+	return nil
+}
+
+// hostEnvFilter returns the --env-allow and --env-deny filter for host environment variables.
+func hostEnvFilter() service.HostEnvFilter {
+	return service.HostEnvFilter{Allow: runEnvAllow, Deny: runEnvDeny}
+}
+
+// validateEnvFilter checks the --env-allow and --env-deny patterns.
+func validateEnvFilter() error {
+	if len(runEnvAllow) == 0 && len(runEnvDeny) == 0 {
+		return nil
+	}
+	if runRuntime == runtimeModeAspire {
+		return fmt.Errorf("--env-allow and --env-deny are not supported with --runtime %s", runtimeModeAspire)
+	}
+	return hostEnvFilter().Validate()
+}
+
+// loadEnvironmentVariables loads environment variables from the azd environment's .env,
+// the --profile overlay and the --env-file files. Invalid lines are skipped with a warning,
+// or fail the run with --strict-env.
+func loadEnvironmentVariables(azureYamlDir string) (map[string]string, error) {
+	paths, err := envFilePaths(azureYamlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars, issues, err := service.LoadDotEnvFiles(paths, runStrictEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env file: %w", err)
+	}
+	for _, issue := range issues {
+		output.Warning("%s, skipping", issue)
+	}
+	return envVars, nil
+}
+
+// envFilePaths returns the .env files to load, in the order they override each other:
+// the azd environment's .env when loadBaseEnvFile allows it, its .env.<profile> overlay
+// with --profile, then each --env-file as given.
+func envFilePaths(azureYamlDir string) ([]string, error) {
+	envDir, err := azdEnvironmentDir(azureYamlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if envDir != "" {
+		base := filepath.Join(envDir, ".env")
+		if _, err := os.Stat(base); err == nil && loadBaseEnvFile(base) {
+			paths = append(paths, base)
+		}
+	}
+
+	if runProfile != "" {
+		if envDir == "" {
+			return nil, fmt.Errorf("--profile requires an azd environment: run 'azd env select <name>' or set AZURE_ENV_NAME")
+		}
+		overlay := filepath.Join(envDir, ".env."+runProfile)
+		if _, err := os.Stat(overlay); err != nil {
+			return nil, fmt.Errorf("profile %q not found: %s does not exist", runProfile, overlay)
+		}
+		paths = append(paths, overlay)
+	}
+	return append(paths, runEnvFiles...), nil
+}
+
+// loadBaseEnvFile reports whether the azd environment's .env at path must be loaded here.
+// azd already injects it into the environment it runs the extension with, and values the
+// host environment has since changed must win, so it is only loaded for a --profile overlay
+// to build on, when the extension wasn't run by azd (AZURE_ENV_NAME unset), or when azd
+// dropped the file because a line in it is invalid.
+func loadBaseEnvFile(path string) bool {
+	if runProfile != "" || os.Getenv("AZURE_ENV_NAME") == "" {
+		return true
+	}
+	_, issues, err := service.ReadDotEnv(path)
+	return err == nil && len(issues) > 0
+}
+
+// azdEnvironmentDir returns the .azure/<env> directory of the azd environment in use: the one
+// azd runs the extension with (AZURE_ENV_NAME), or else the project's default environment.
+// It returns "" when there is no azd environment.
+func azdEnvironmentDir(azureYamlDir string) (string, error) {
+	name := os.Getenv("AZURE_ENV_NAME")
+	if name == "" {
+		// #nosec G304 -- path is the project's azd configuration
+		if data, err := os.ReadFile(filepath.Join(azureYamlDir, ".azure", "config.json")); err == nil {
+			var config struct {
+				DefaultEnvironment string `json:"defaultEnvironment"`
+			}
+			if json.Unmarshal(data, &config) == nil {
+				name = config.DefaultEnvironment
+			}
+		}
+	}
+
+	if name == "" {
+		return "", nil
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid azd environment name %q", name)
+	}
+	return filepath.Join(azureYamlDir, ".azure", name), nil
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadEnvironmentVariables_ProfileAndEnvFiles(t *testing.T) {
+	defer func() {
+		runEnvFiles = nil
+		runProfile = ""
+		runStrictEnv = false
+	}()
+
+	dir := t.TempDir()
+	envDir := filepath.Join(dir, ".azure", "dev")
+	if err := os.MkdirAll(envDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(dir, ".azure", "config.json"): `{"version":1,"defaultEnvironment":"dev"}`,
+		filepath.Join(envDir, ".env"):               "API_URL=https://dev\nLOG_LEVEL=info\nMY-INVALID-VAR=x\nREGION=westus\n",
+		filepath.Join(envDir, ".env.local"):         "API_URL=http://localhost:5000\nLOG_LEVEL=debug\n",
+		filepath.Join(dir, ".env.override"):         "LOG_LEVEL=trace\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("AZURE_ENV_NAME", "")
+
+	// The default environment's .env is loaded, skipping the invalid line
+	envVars, err := loadEnvironmentVariables(dir)
+	if err != nil {
+		t.Fatalf("loadEnvironmentVariables() error = %v", err)
+	}
+	if len(envVars) != 3 || envVars["REGION"] != "westus" {
+		t.Errorf("loadEnvironmentVariables() = %v, want the 3 valid variables", envVars)
+	}
+
+	// --strict-env fails on the invalid line instead
+	runStrictEnv = true
+	if _, err := loadEnvironmentVariables(dir); err == nil || !strings.Contains(err.Error(), `line 3: invalid variable name "MY-INVALID-VAR"`) {
+		t.Errorf("loadEnvironmentVariables() with --strict-env error = %v, want the invalid line", err)
+	}
+	runStrictEnv = false
+
+	// The profile overlays the default environment's .env, and --env-file overrides both
+	runProfile = "local"
+	runEnvFiles = []string{filepath.Join(dir, ".env.override")}
+	envVars, err = loadEnvironmentVariables(dir)
+	if err != nil {
+		t.Fatalf("loadEnvironmentVariables() error = %v", err)
+	}
+	want := map[string]string{"API_URL": "http://localhost:5000", "LOG_LEVEL": "trace", "REGION": "westus"}
+	for key, value := range want {
+		if envVars[key] != value {
+			t.Errorf("%s = %q, want %q", key, envVars[key], value)
+		}
+	}
+
+	// AZURE_ENV_NAME selects another environment
+	t.Setenv("AZURE_ENV_NAME", "prod")
+	if _, err := loadEnvironmentVariables(dir); err == nil {
+		t.Error("loadEnvironmentVariables() should fail when the profile file does not exist")
+	}
+}
+
+func TestLoadEnvironmentVariables_BaseFileInjectedByAzd(t *testing.T) {
+	defer func() { runProfile = "" }()
+
+	dir := t.TempDir()
+	envDir := filepath.Join(dir, ".azure", "dev")
+	if err := os.MkdirAll(envDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(envDir, ".env")
+	if err := os.WriteFile(base, []byte("API_URL=https://dev\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(envDir, ".env.local"), []byte("LOG_LEVEL=debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AZURE_ENV_NAME", "dev")
+
+	// azd injected the file, so the host environment's values are kept
+	envVars, err := loadEnvironmentVariables(dir)
+	if err != nil {
+		t.Fatalf("loadEnvironmentVariables() error = %v", err)
+	}
+	if len(envVars) != 0 {
+		t.Errorf("loadEnvironmentVariables() = %v, want the base file left to azd", envVars)
+	}
+
+	// A profile overlay builds on the base file
+	runProfile = "local"
+	envVars, err = loadEnvironmentVariables(dir)
+	if err != nil {
+		t.Fatalf("loadEnvironmentVariables() error = %v", err)
+	}
+	if envVars["API_URL"] != "https://dev" || envVars["LOG_LEVEL"] != "debug" {
+		t.Errorf("loadEnvironmentVariables() with --profile = %v, want the base file and overlay", envVars)
+	}
+	runProfile = ""
+
+	// azd drops a file with an invalid line, so its valid variables are loaded here
+	if err := os.WriteFile(base, []byte("API_URL=https://dev\nMY-INVALID-VAR=x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	envVars, err = loadEnvironmentVariables(dir)
+	if err != nil {
+		t.Fatalf("loadEnvironmentVariables() error = %v", err)
+	}
+	if envVars["API_URL"] != "https://dev" {
+		t.Errorf("loadEnvironmentVariables() = %v, want the valid variables of the dropped file", envVars)
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	defer func() { runProfile = "" }()
+
+	for profile, wantErr := range map[string]bool{"": false, "local": false, "ci_2-a": false, "../x": true, ".hidden": true} {
+		runProfile = profile
+		if err := validateProfile(); (err != nil) != wantErr {
+			t.Errorf("validateProfile(%q) error = %v, wantErr %v", profile, err, wantErr)
+		}
+	}
+}
+
+func TestValidateEnvFilter(t *testing.T) {
+	defer func() {
+		runEnvAllow, runEnvDeny = nil, nil
+		runRuntime = runtimeModeAzd
+	}()
+
+	tests := []struct {
+		allow, deny []string
+		runtime     string
+		wantErr     bool
+	}{
+		{runtime: runtimeModeAzd},
+		{allow: []string{"PATH", "NODE_*"}, deny: []string{"*_SECRET"}, runtime: runtimeModeAzd},
+		{deny: []string{"BAD["}, runtime: runtimeModeAzd, wantErr: true},
+		{allow: []string{"PATH"}, runtime: runtimeModeAspire, wantErr: true},
+	}
+	for _, tt := range tests {
+		runEnvAllow, runEnvDeny, runRuntime = tt.allow, tt.deny, tt.runtime
+		if err := validateEnvFilter(); (err != nil) != tt.wantErr {
+			t.Errorf("validateEnvFilter(allow=%v, deny=%v, runtime=%s) error = %v, wantErr %v", tt.allow, tt.deny, tt.runtime, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// defaultLogMaxSize is the --log-max-size default, matching service.MaxLogFileSize.
+const defaultLogMaxSize = "1MB"
+
+// logRotation returns how service log files in .azure/logs are rotated, from the
+// --log-max-size and --log-max-files values.
+func logRotation() (service.LogRotation, error) {
+	maxSize, err := service.ParseByteSize(runLogMaxSize)
+	if err != nil || maxSize < 1 {
+		return service.LogRotation{}, fmt.Errorf("invalid --log-max-size value: %s (must be a size such as 10MB or 512KB)", runLogMaxSize)
+	}
+	if runLogMaxFiles < 0 {
+		return service.LogRotation{}, fmt.Errorf("invalid --log-max-files value: %d (must be 0 or more)", runLogMaxFiles)
+	}
+	return service.LogRotation{MaxSize: maxSize, MaxFiles: runLogMaxFiles}, nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestLogRotation(t *testing.T) {
+	defer func(size string, files int) {
+		runLogMaxSize, runLogMaxFiles = size, files
+	}(runLogMaxSize, runLogMaxFiles)
+
+	tests := []struct {
+		size    string
+		files   int
+		want    service.LogRotation
+		wantErr bool
+	}{
+		{defaultLogMaxSize, service.MaxLogFileBackups, service.DefaultLogRotation(), false},
+		{"10MB", 3, service.LogRotation{MaxSize: 10 << 20, MaxFiles: 3}, false},
+		{"512kb", 0, service.LogRotation{MaxSize: 512 << 10}, false},
+		{"0", 3, service.LogRotation{}, true},
+		{"big", 3, service.LogRotation{}, true},
+		{"10MB", -1, service.LogRotation{}, true},
+	}
+	for _, tt := range tests {
+		runLogMaxSize, runLogMaxFiles = tt.size, tt.files
+		got, err := logRotation()
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("logRotation() with --log-max-size %s --log-max-files %d error = %v, wantErr %v", tt.size, tt.files, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("logRotation() with --log-max-size %s --log-max-files %d = %+v, want %+v", tt.size, tt.files, got, tt.want)
+		}
+	}
+}
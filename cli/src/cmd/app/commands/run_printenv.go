@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// previewOnly reports whether this run only shows what it would do (--dry-run or --print-env)
+// instead of starting services.
+func previewOnly() bool {
+	return runDryRun || runPrintEnv
+}
+
+// validatePrintEnv checks --print-env and --reveal before anything is detected or installed.
+func validatePrintEnv() error {
+	if runReveal && !runPrintEnv {
+		return fmt.Errorf("--reveal can only be used with --print-env")
+	}
+	if !runPrintEnv {
+		return nil
+	}
+	if runDryRun {
+		return fmt.Errorf("--print-env and --dry-run cannot be used together")
+	}
+	if runRuntime == runtimeModeAspire {
+		return fmt.Errorf("--print-env is not supported with --runtime %s (use --runtime %s to resolve each service's environment)", runtimeModeAspire, runtimeModeAzd)
+	}
+	return nil
+}
+
+// printServiceEnvironments prints the environment each service would be started with (azd app
+// run --print-env): the forwarded host environment, .env and --env-file variables, the
+// service's azure.yaml environment and the variables azd app injects, such as PORT. Secret
+// values are masked unless --reveal is set.
+func printServiceEnvironments(runtimes []*service.ServiceRuntime, azureYamlDir string) error {
+	envVars, err := loadEnvironmentVariables(azureYamlDir)
+	if err != nil {
+		return err
+	}
+	envs, err := resolveServiceEnvironments(runtimes, envVars, hostEnvFilter(), runReveal)
+	if err != nil {
+		return err
+	}
+	if output.IsStructured() {
+		return output.PrintStructured(envs)
+	}
+
+	if runReveal {
+		output.Section("🔍", "Resolved service environments")
+	} else {
+		output.Section("🔍", "Resolved service environments (secrets masked; use --reveal to show them)")
+	}
+	names := make([]string, 0, len(envs))
+	for name := range envs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env := envs[name]
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		output.Newline()
+		output.Info("%s", name)
+		for _, key := range keys {
+			output.Item("%s=%s", key, env[key])
+		}
+	}
+	return nil
+}
+
+// resolveServiceEnvironments returns the environment of each runtime, keyed by service name.
+// Unless reveal is true, values of variables with sensitive names are masked and secrets in
+// other values, such as URL credentials, are redacted as in azd app logs.
+func resolveServiceEnvironments(runtimes []*service.ServiceRuntime, envVars map[string]string, filter service.HostEnvFilter, reveal bool) (map[string]map[string]string, error) {
+	var redactor *service.LogRedactor
+	if !reveal {
+		var err error
+		if redactor, err = service.NewLogRedactorWithBuiltins(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	envs := make(map[string]map[string]string, len(runtimes))
+	for _, rt := range runtimes {
+		env := service.ResolveServiceEnvironment(rt, envVars, filter)
+		if !reveal {
+			for key, value := range env {
+				if service.IsSensitiveEnvKey(key) {
+					env[key] = service.RedactedValue
+				} else {
+					env[key] = redactor.Redact(value)
+				}
+			}
+		}
+		envs[rt.Name] = env
+	}
+	return envs, nil
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// proxyShutdownTimeout is how long the request proxy waits for in-flight requests on shutdown.
+const proxyShutdownTimeout = 5 * time.Second
+
+// validateProxy checks the --proxy and --proxy-port values.
+func validateProxy() error {
+	if !runProxy {
+		if runProxyPort != 0 {
+			return fmt.Errorf("--proxy-port requires --proxy")
+		}
+		return nil
+	}
+	if runRuntime == runtimeModeAspire {
+		return fmt.Errorf("--proxy is not supported with --runtime %s", runtimeModeAspire)
+	}
+	if runProxyPort < 0 || runProxyPort > 65535 {
+		return fmt.Errorf("invalid --proxy-port %d (must be between 1 and 65535, or 0 for a free port)", runProxyPort)
+	}
+	return nil
+}
+
+// proxyPorts returns the ports of the services the request proxy routes to: every
+// running service that listens on a port.
+func proxyPorts(processes map[string]*service.ServiceProcess) map[string]int {
+	ports := make(map[string]int, len(processes))
+	for name, process := range processes {
+		if process.Port > 0 {
+			ports[name] = process.Port
+		}
+	}
+	return ports
+}
+
+// startRequestProxy fronts the running services with the request-logging proxy (--proxy).
+// Requests are logged under "proxy" alongside the services' own logs.
+func startRequestProxy(processes map[string]*service.ServiceProcess, projectDir string, logger *service.ServiceLogger) (*service.RequestProxy, error) {
+	proxy, err := service.NewRequestProxy(projectDir, proxyPorts(processes))
+	if err != nil {
+		return nil, err
+	}
+	if err := proxy.Start(runProxyPort); err != nil {
+		return nil, err
+	}
+
+	services := proxy.Services()
+	if len(services) == 0 {
+		logger.LogInfo(fmt.Sprintf("Request proxy: http://localhost:%d (no services listen on a port)", proxy.Port()))
+		return proxy, nil
+	}
+	logger.LogInfo(fmt.Sprintf("Request proxy: http://localhost:%d (/<service>/ or http://<service>.localhost:%d/ for %s)",
+		proxy.Port(), proxy.Port(), strings.Join(services, ", ")))
+	return proxy, nil
+}
+
+// stopRequestProxy shuts the request proxy down.
+func stopRequestProxy(proxy *service.RequestProxy) {
+	ctx, cancel := context.WithTimeout(context.Background(), proxyShutdownTimeout)
+	defer cancel()
+	if err := proxy.Stop(ctx); err != nil {
+		slog.Warn("failed to stop request proxy", "error", err)
+	}
+}
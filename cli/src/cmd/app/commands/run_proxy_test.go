@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestValidateProxy(t *testing.T) {
+	defer func(proxy bool, port int, runtime string) {
+		runProxy, runProxyPort, runRuntime = proxy, port, runtime
+	}(runProxy, runProxyPort, runRuntime)
+
+	tests := []struct {
+		proxy   bool
+		port    int
+		runtime string
+		wantErr bool
+	}{
+		{false, 0, runtimeModeAzd, false},
+		{true, 0, runtimeModeAzd, false},
+		{true, 8080, runtimeModeAzd, false},
+		{false, 8080, runtimeModeAzd, true},
+		{true, 70000, runtimeModeAzd, true},
+		{true, 0, runtimeModeAspire, true},
+	}
+	for _, tt := range tests {
+		runProxy, runProxyPort, runRuntime = tt.proxy, tt.port, tt.runtime
+		if err := validateProxy(); (err != nil) != tt.wantErr {
+			t.Errorf("validateProxy() with --proxy=%v --proxy-port %d --runtime %s error = %v, wantErr %v", tt.proxy, tt.port, tt.runtime, err, tt.wantErr)
+		}
+	}
+}
+
+func TestProxyPorts(t *testing.T) {
+	ports := proxyPorts(map[string]*service.ServiceProcess{
+		"api":    {Name: "api", Port: 8000},
+		"web":    {Name: "web", Port: 3000},
+		"worker": {Name: "worker"},
+	})
+	if len(ports) != 2 || ports["api"] != 8000 || ports["web"] != 3000 {
+		t.Errorf("proxyPorts() = %v, want api:8000 and web:3000 only", ports)
+	}
+}
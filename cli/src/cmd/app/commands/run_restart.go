@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/constants"
+	"github.com/jongio/azd-app/cli/src/internal/dashboard"
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/registry"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// Restart policies for --restart.
+const (
+	restartPolicyNo        = "no"
+	restartPolicyOnFailure = "on-failure"
+	restartPolicyAlways    = "always"
+)
+
+const (
+	// defaultMaxRestarts is the default for --max-restarts.
+	defaultMaxRestarts = 5
+	// restartInitialDelay is the backoff before the first restart; it doubles with each attempt.
+	restartInitialDelay = time.Second
+	// restartMaxDelay caps the backoff between restarts.
+	restartMaxDelay = 30 * time.Second
+	// restartStableAfter is how long a restarted service must run before its restart count resets.
+	restartStableAfter = time.Minute
+)
+
+// serviceExit describes how a monitored service process ended.
+type serviceExit int
+
+const (
+	serviceExitShutdown     serviceExit = iota // azd app run is shutting down
+	serviceExitClean                           // The process exited with code 0
+	serviceExitFailed                          // The process exited with a non-zero code
+	serviceExitWatchRestart                    // --watch stopped the process to restart it
+)
+
+// validateRestartPolicy checks the --restart and --max-restarts values.
+func validateRestartPolicy() error {
+	switch runRestartPolicy {
+	case restartPolicyNo, restartPolicyOnFailure, restartPolicyAlways:
+	default:
+		return fmt.Errorf("invalid --restart value: %s (must be '%s', '%s' or '%s')", runRestartPolicy, restartPolicyNo, restartPolicyOnFailure, restartPolicyAlways)
+	}
+	if runMaxRestarts < 1 {
+		return fmt.Errorf("invalid --max-restarts value: %d (must be at least 1)", runMaxRestarts)
+	}
+	return nil
+}
+
+// shouldRestart reports whether the restart policy restarts a service that ended with exit.
+func shouldRestart(policy string, exit serviceExit) bool {
+	switch exit {
+	case serviceExitFailed:
+		return policy == restartPolicyOnFailure || policy == restartPolicyAlways
+	case serviceExitClean:
+		return policy == restartPolicyAlways
+	default:
+		return false
+	}
+}
+
+// restartDelay returns the backoff before the given restart attempt (starting at 1):
+// 1s, 2s, 4s and so on, capped at restartMaxDelay.
+func restartDelay(attempt int) time.Duration {
+	delay := restartInitialDelay
+	for i := 1; i < attempt && delay < restartMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, restartMaxDelay)
+}
+
+// serviceRestarter restarts services while azd app run keeps going, for --watch, --restart and
+// restarts requested through the dashboard.
+// The restarted process replaces the old one in the orchestration result (see
+// OrchestrationResult.ReplaceProcess), so shutdown, signal forwarding and sidecar handling
+// follow the new process while monitors of the old one keep their own *ServiceProcess.
+type serviceRestarter struct {
+	result      *service.OrchestrationResult
+	services    map[string]service.Service
+	envVars     map[string]string
+	envFilter   service.HostEnvFilter // --env-allow and --env-deny
+	logger      *service.ServiceLogger
+	pidFile     *service.PidFile
+	projectDir  string
+	policy      string // --restart policy
+	maxRestarts int    // --max-restarts
+
+	// mu serializes restarts, so --watch and --restart never restart a service at the same time
+	mu       sync.Mutex
+	attempts map[string]int       // Service name -> consecutive --restart attempts
+	lastRun  map[string]time.Time // Service name -> when --restart last started it
+
+	// restart, wait and waitForReady are replaced in tests; nil uses service.RestartServiceProcess,
+	// a timer and service.WaitForReady
+	restart      func(proc *service.ServiceProcess) (*service.ServiceProcess, error)
+	wait         func(ctx context.Context, d time.Duration) bool
+	waitForReady func(proc *service.ServiceProcess, svc service.Service, timeout time.Duration) error
+}
+
+// restartProcess restarts proc, replaces it in the orchestration result and starts monitoring
+// the new process, which it returns. Callers hold r.mu.
+func (r *serviceRestarter) restartProcess(ctx context.Context, wg *sync.WaitGroup, name string, proc *service.ServiceProcess) (*service.ServiceProcess, error) {
+	restart := r.restart
+	if restart == nil {
+		restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			return service.RestartServiceProcess(p, r.envVars, r.envFilter, r.logger, r.projectDir, r.result.FunctionsParser)
+		}
+	}
+	restarted, err := restart(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	r.result.ReplaceProcess(name, restarted)
+	if r.pidFile != nil {
+		if err := r.pidFile.Record(map[string]*service.ServiceProcess{name: restarted}); err != nil {
+			slog.Warn("failed to record restarted service process", "service", name, "error", err)
+		}
+	}
+	if restarted.Process != nil {
+		startServiceMonitor(ctx, wg, name, restarted, r.result, r.projectDir, r)
+	}
+	return restarted, nil
+}
+
+// restartIfCurrent restarts proc, stopped on purpose so its monitor doesn't report an exit,
+// unless another restart replaced it while the caller waited for r.mu: restarting it again
+// would stop a process that is already gone and leave the new one running untracked.
+// It returns the service's current process. Callers hold r.mu.
+func (r *serviceRestarter) restartIfCurrent(ctx context.Context, wg *sync.WaitGroup, name string, proc *service.ServiceProcess) (*service.ServiceProcess, error) {
+	if current := r.result.Process(name); current != proc {
+		return current, nil
+	}
+	// The monitor of the old process must not treat the stop as an exit
+	watchRestarts.Store(proc.Process, struct{}{})
+	return r.restartProcess(ctx, wg, name, proc)
+}
+
+// restartOnRequest restarts a running service for the dashboard's restart endpoint, which the
+// restart_service MCP tool calls: it stops the process and waits for it to exit, starts it
+// again with the same command and environment, and waits up to --ready-timeout for its health
+// check. Services that are not native processes of this run return ErrServiceNotSupervised.
+func (r *serviceRestarter) restartOnRequest(ctx context.Context, wg *sync.WaitGroup, name string) (*dashboard.ServiceRestartResult, error) {
+	if ctx.Err() != nil {
+		return nil, errors.New("azd app run is shutting down")
+	}
+	proc := r.result.Process(name)
+	if proc == nil || proc.Process == nil || proc.Runtime.Type == service.ServiceTypeContainer {
+		return nil, dashboard.ErrServiceNotSupervised
+	}
+
+	output.Info("Restarting %s on request", name)
+	start := time.Now()
+	reg := registry.GetRegistry(r.projectDir)
+
+	r.mu.Lock()
+	// A restart by --watch or --restart while we waited for the lock serves this request too
+	restarted, err := r.restartIfCurrent(ctx, wg, name, proc)
+	r.mu.Unlock()
+	if err != nil {
+		_ = reg.UpdateStatus(name, constants.StatusError)
+		return nil, fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+
+	// Wait without holding r.mu, so --watch and --restart can restart other services meanwhile
+	waitForReady := r.waitForReady
+	if waitForReady == nil {
+		waitForReady = service.WaitForReady
+	}
+	if err := waitForReady(restarted, r.services[name], runReadyTimeout); err != nil {
+		_ = reg.UpdateStatus(name, constants.StatusError)
+		return nil, fmt.Errorf("%s restarted but did not become ready within %s: %w", name, runReadyTimeout, err)
+	}
+
+	status := constants.StatusRunning
+	if entry, ok := reg.GetService(name); ok {
+		status = entry.Status
+	}
+	return &dashboard.ServiceRestartResult{Status: status, StartupDuration: time.Since(start)}, nil
+}
+
+// handleExit restarts a service that exited on its own, as the --restart policy allows,
+// retrying with exponential backoff up to --max-restarts times in a row. It returns true
+// if the service was restarted (or shutdown began while waiting), false if it stays stopped.
+// Build and task mode services are expected to exit and are never restarted.
+func (r *serviceRestarter) handleExit(ctx context.Context, wg *sync.WaitGroup, name string, proc *service.ServiceProcess, exit serviceExit) bool {
+	if r == nil || !shouldRestart(r.policy, exit) {
+		return false
+	}
+	if mode := proc.Runtime.Mode; mode == service.ServiceModeBuild || mode == service.ServiceModeTask {
+		return false
+	}
+
+	reg := registry.GetRegistry(r.projectDir)
+	for attempt := r.nextAttempt(name); ; attempt++ {
+		if attempt > r.maxRestarts {
+			output.Error("Giving up on %s after %d restarts; fix the problem and run 'azd app restart --service %s'", name, r.maxRestarts, name)
+			_ = reg.UpdateStatus(name, constants.StatusError)
+			return false
+		}
+
+		delay := restartDelay(attempt)
+		output.Warning("Restarting %s in %s (attempt %d of %d)", name, delay, attempt, r.maxRestarts)
+		_ = reg.UpdateStatus(name, constants.StatusRestarting)
+		if !r.sleep(ctx, delay) {
+			return true
+		}
+
+		r.mu.Lock()
+		if r.result.Process(name) != proc {
+			// --watch restarted the service while we were waiting
+			r.mu.Unlock()
+			return true
+		}
+		_, err := r.restartProcess(ctx, wg, name, proc)
+		if err == nil {
+			r.attempts[name] = attempt
+			r.lastRun[name] = time.Now()
+		}
+		r.mu.Unlock()
+
+		if err == nil {
+			return true
+		}
+		output.Error("Failed to restart %s: %v", name, err)
+	}
+}
+
+// nextAttempt returns the number of the next --restart attempt for a service. The count
+// starts over once the service has run for restartStableAfter since its last restart.
+func (r *serviceRestarter) nextAttempt(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.attempts == nil {
+		r.attempts = make(map[string]int)
+		r.lastRun = make(map[string]time.Time)
+	}
+	if time.Since(r.lastRun[name]) >= restartStableAfter {
+		r.attempts[name] = 0
+	}
+	return r.attempts[name] + 1
+}
+
+// sleep waits for d, returning false if ctx is cancelled first.
+func (r *serviceRestarter) sleep(ctx context.Context, d time.Duration) bool {
+	if r.wait != nil {
+		return r.wait(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/constants"
+	"github.com/jongio/azd-app/cli/src/internal/dashboard"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestValidateRestartPolicy(t *testing.T) {
+	defer func(policy string, max int) { runRestartPolicy, runMaxRestarts = policy, max }(runRestartPolicy, runMaxRestarts)
+
+	tests := []struct {
+		policy  string
+		max     int
+		wantErr bool
+	}{
+		{restartPolicyNo, defaultMaxRestarts, false},
+		{restartPolicyOnFailure, defaultMaxRestarts, false},
+		{restartPolicyAlways, 1, false},
+		{"unless-stopped", defaultMaxRestarts, true},
+		{restartPolicyOnFailure, 0, true},
+	}
+	for _, tt := range tests {
+		runRestartPolicy, runMaxRestarts = tt.policy, tt.max
+		if err := validateRestartPolicy(); (err != nil) != tt.wantErr {
+			t.Errorf("validateRestartPolicy() with --restart %s --max-restarts %d error = %v, wantErr %v", tt.policy, tt.max, err, tt.wantErr)
+		}
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	tests := []struct {
+		policy string
+		exit   serviceExit
+		want   bool
+	}{
+		{restartPolicyNo, serviceExitFailed, false},
+		{restartPolicyOnFailure, serviceExitFailed, true},
+		{restartPolicyOnFailure, serviceExitClean, false},
+		{restartPolicyAlways, serviceExitClean, true},
+		{restartPolicyAlways, serviceExitShutdown, false},
+		{restartPolicyAlways, serviceExitWatchRestart, false},
+	}
+	for _, tt := range tests {
+		if got := shouldRestart(tt.policy, tt.exit); got != tt.want {
+			t.Errorf("shouldRestart(%q, %d) = %v, want %v", tt.policy, tt.exit, got, tt.want)
+		}
+	}
+}
+
+func TestRestartDelay(t *testing.T) {
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, w := range want {
+		if got := restartDelay(i + 1); got != w {
+			t.Errorf("restartDelay(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+// newTestRestarter returns a restarter whose waits return immediately and records their delays.
+func newTestRestarter(t *testing.T, policy string, delays *[]time.Duration) *serviceRestarter {
+	return &serviceRestarter{
+		result:      &service.OrchestrationResult{Processes: map[string]*service.ServiceProcess{}},
+		projectDir:  t.TempDir(),
+		policy:      policy,
+		maxRestarts: 3,
+		wait: func(ctx context.Context, d time.Duration) bool {
+			*delays = append(*delays, d)
+			return ctx.Err() == nil
+		},
+	}
+}
+
+func TestServiceRestarterHandleExit(t *testing.T) {
+	t.Run("restarts a failed service", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyOnFailure, &delays)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			return &service.ServiceProcess{Name: p.Name, Port: 8081}, nil
+		}
+		proc := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 100}}
+		r.result.Processes["api"] = proc
+
+		if !r.handleExit(context.Background(), &sync.WaitGroup{}, "api", proc, serviceExitFailed) {
+			t.Fatal("handleExit() = false, want true")
+		}
+		if got := r.result.Process("api"); got == proc || got.Port != 8081 {
+			t.Errorf("Process(api) = %+v, want the restarted process", got)
+		}
+		if proc.Port != 0 {
+			t.Errorf("old process Port = %d, want it left unchanged for its monitor", proc.Port)
+		}
+		if len(delays) != 1 || delays[0] != time.Second {
+			t.Errorf("delays = %v, want [1s]", delays)
+		}
+	})
+
+	t.Run("retries failed restarts with backoff and gives up", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyAlways, &delays)
+		calls := 0
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			calls++
+			return nil, errors.New("port 8080 is in use")
+		}
+		proc := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 100}}
+		r.result.Processes["api"] = proc
+
+		if r.handleExit(context.Background(), &sync.WaitGroup{}, "api", proc, serviceExitClean) {
+			t.Error("handleExit() = true, want false after giving up")
+		}
+		if calls != 3 {
+			t.Errorf("restart called %d times, want 3", calls)
+		}
+		want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+		if len(delays) != len(want) || delays[0] != want[0] || delays[1] != want[1] || delays[2] != want[2] {
+			t.Errorf("delays = %v, want %v", delays, want)
+		}
+	})
+
+	t.Run("consecutive crashes count towards the cap", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyOnFailure, &delays)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			return &service.ServiceProcess{Name: p.Name}, nil
+		}
+		r.result.Processes["api"] = &service.ServiceProcess{Name: "api"}
+
+		// Each crash is of the process the previous restart started
+		for i := 0; i < 3; i++ {
+			if !r.handleExit(context.Background(), &sync.WaitGroup{}, "api", r.result.Process("api"), serviceExitFailed) {
+				t.Fatalf("handleExit() #%d = false, want true", i+1)
+			}
+		}
+		if r.handleExit(context.Background(), &sync.WaitGroup{}, "api", r.result.Process("api"), serviceExitFailed) {
+			t.Error("handleExit() after 3 restarts = true, want false")
+		}
+
+		// A service that stayed up starts counting again
+		r.lastRun["api"] = time.Now().Add(-2 * restartStableAfter)
+		if got := r.nextAttempt("api"); got != 1 {
+			t.Errorf("nextAttempt() after a stable run = %d, want 1", got)
+		}
+	})
+
+	t.Run("policy and mode skip restarts", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyOnFailure, &delays)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			t.Error("restart should not be called")
+			return p, nil
+		}
+		ctx := context.Background()
+
+		if r.handleExit(ctx, &sync.WaitGroup{}, "api", &service.ServiceProcess{Name: "api"}, serviceExitClean) {
+			t.Error("on-failure restarted a clean exit")
+		}
+		build := &service.ServiceProcess{Name: "build", Runtime: service.ServiceRuntime{Mode: service.ServiceModeBuild}}
+		if r.handleExit(ctx, &sync.WaitGroup{}, "build", build, serviceExitFailed) {
+			t.Error("build mode service was restarted")
+		}
+		var nilRestarter *serviceRestarter
+		if nilRestarter.handleExit(ctx, &sync.WaitGroup{}, "api", &service.ServiceProcess{Name: "api"}, serviceExitFailed) {
+			t.Error("nil restarter restarted a service")
+		}
+	})
+
+	t.Run("service restarted by --watch while waiting", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyAlways, &delays)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			t.Error("restart should not be called for a replaced process")
+			return p, nil
+		}
+		proc := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 100}}
+		r.result.Processes["api"] = proc
+		watched := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 101}}
+		r.wait = func(ctx context.Context, d time.Duration) bool {
+			r.result.ReplaceProcess("api", watched)
+			return true
+		}
+
+		if !r.handleExit(context.Background(), &sync.WaitGroup{}, "api", proc, serviceExitFailed) {
+			t.Error("handleExit() = false, want true")
+		}
+		if r.result.Process("api") != watched {
+			t.Error("handleExit() replaced the process --watch started")
+		}
+	})
+
+	t.Run("shutdown while waiting", func(t *testing.T) {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyAlways, &delays)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			t.Error("restart should not be called after shutdown")
+			return p, nil
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if !r.handleExit(ctx, &sync.WaitGroup{}, "api", &service.ServiceProcess{Name: "api"}, serviceExitFailed) {
+			t.Error("handleExit() = false, want true once shutdown has started")
+		}
+	})
+}
+
+func TestServiceRestarterRestartOnRequest(t *testing.T) {
+	newRestarter := func(t *testing.T, proc *service.ServiceProcess) *serviceRestarter {
+		var delays []time.Duration
+		r := newTestRestarter(t, restartPolicyNo, &delays)
+		r.result.Processes[proc.Name] = proc
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			// No process, so no monitor goroutine is needed for the fake
+			return &service.ServiceProcess{Name: p.Name, Port: 8081}, nil
+		}
+		return r
+	}
+
+	t.Run("restarts and waits for the service to become ready", func(t *testing.T) {
+		oldProcess := &os.Process{Pid: 100}
+		proc := &service.ServiceProcess{Name: "api", Process: oldProcess}
+		r := newRestarter(t, proc)
+		waited := false
+		r.waitForReady = func(p *service.ServiceProcess, svc service.Service, timeout time.Duration) error {
+			// Other restarts must not be blocked while waiting
+			if !r.mu.TryLock() {
+				t.Error("restartOnRequest() holds the restart lock while waiting for readiness")
+			} else {
+				r.mu.Unlock()
+			}
+			waited = p == r.result.Process("api") && p != proc && p.Port == 8081
+			return nil
+		}
+
+		result, err := r.restartOnRequest(context.Background(), &sync.WaitGroup{}, "api")
+		if err != nil {
+			t.Fatalf("restartOnRequest() error = %v", err)
+		}
+		if !waited {
+			t.Error("restartOnRequest() should wait for the restarted process to become ready")
+		}
+		if result.Status != constants.StatusRunning {
+			t.Errorf("Status = %q, want %q", result.Status, constants.StatusRunning)
+		}
+		if !stoppedForWatchRestart(oldProcess) {
+			t.Error("old process should be marked as stopped for a restart")
+		}
+	})
+
+	t.Run("leaves a process another restart already replaced", func(t *testing.T) {
+		oldProcess := &os.Process{Pid: 100}
+		proc := &service.ServiceProcess{Name: "api", Process: oldProcess}
+		r := newRestarter(t, proc)
+		r.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			t.Error("restart should not be called for a replaced process")
+			return p, nil
+		}
+		// --watch restarted the service while the request waited for the lock
+		replaced := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 101}}
+		r.result.ReplaceProcess("api", replaced)
+
+		got, err := r.restartIfCurrent(context.Background(), &sync.WaitGroup{}, "api", proc)
+		if err != nil {
+			t.Fatalf("restartIfCurrent() error = %v", err)
+		}
+		if got != replaced || r.result.Process("api") != replaced {
+			t.Errorf("restartIfCurrent() = %+v, want the replacing process kept", got)
+		}
+		if stoppedForWatchRestart(oldProcess) {
+			t.Error("a process that was not restarted should not be marked as stopped for a restart")
+		}
+	})
+
+	t.Run("reports a service that does not become ready", func(t *testing.T) {
+		r := newRestarter(t, &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 100}})
+		r.waitForReady = func(*service.ServiceProcess, service.Service, time.Duration) error {
+			return errors.New("connection refused")
+		}
+
+		if _, err := r.restartOnRequest(context.Background(), &sync.WaitGroup{}, "api"); err == nil || !strings.Contains(err.Error(), "did not become ready") {
+			t.Errorf("restartOnRequest() error = %v, want a readiness error", err)
+		}
+	})
+
+	t.Run("leaves other services to the dashboard", func(t *testing.T) {
+		container := &service.ServiceProcess{Name: "db", Runtime: service.ServiceRuntime{Type: service.ServiceTypeContainer}}
+		r := newRestarter(t, container)
+
+		for _, name := range []string{"db", "missing"} {
+			if _, err := r.restartOnRequest(context.Background(), &sync.WaitGroup{}, name); !errors.Is(err, dashboard.ErrServiceNotSupervised) {
+				t.Errorf("restartOnRequest(%s) error = %v, want ErrServiceNotSupervised", name, err)
+			}
+		}
+	})
+}
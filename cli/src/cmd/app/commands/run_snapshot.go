@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// validateFromSnapshot checks that --from-snapshot is not combined with flags that change
+// which services run or how they are resolved.
+func validateFromSnapshot() error {
+	if !runFromSnapshot {
+		return nil
+	}
+	if runRuntime == runtimeModeAspire {
+		return fmt.Errorf("--from-snapshot is not supported with --runtime %s", runtimeModeAspire)
+	}
+	if runServiceFilter != "" || runOnly != "" || runExclude != "" {
+		return fmt.Errorf("--from-snapshot cannot be combined with --service, --only or --exclude (the snapshot decides which services run)")
+	}
+	return nil
+}
+
+// runtimesFromSnapshot returns the service runtimes recorded by the last run in azureYamlDir.
+// services must be the azure.yaml services with sidecars expanded.
+func runtimesFromSnapshot(services map[string]service.Service, azureYamlDir string) ([]*service.ServiceRuntime, error) {
+	path := service.SnapshotPath(azureYamlDir)
+	snapshot, err := service.LoadRunSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimes, err := snapshot.Runtimes(services)
+	if err != nil {
+		return nil, fmt.Errorf("cannot re-run from %s: %w", path, err)
+	}
+
+	running := make(map[string]service.Service, len(runtimes))
+	for _, rt := range runtimes {
+		running[rt.Name] = services[rt.Name]
+	}
+	if err := validateForwardSignalTargets(running); err != nil {
+		return nil, err
+	}
+
+	output.Info("Re-running %d service(s) from the snapshot taken %s", len(runtimes), snapshot.CreatedAt.Local().Format(time.DateTime))
+	envVars, err := loadEnvironmentVariables(azureYamlDir)
+	if err != nil {
+		return nil, err
+	}
+	if service.EnvHash(runtimes, services, envVars) != snapshot.EnvHash {
+		output.Warning("The environment has changed since the snapshot was taken; services may not behave the same")
+	}
+
+	return runtimes, nil
+}
+
+// writeRunSnapshot records the resolved configuration of this run in .azure/app/last-run.json,
+// so it can be diffed against another run or reproduced with --from-snapshot.
+func writeRunSnapshot(runtimes []*service.ServiceRuntime, services map[string]service.Service, envVars map[string]string, azureYamlDir string) {
+	snapshot := service.NewRunSnapshot(runtimes, services, envVars)
+	if err := service.WriteRunSnapshot(azureYamlDir, snapshot); err != nil {
+		slog.Warn("failed to write run snapshot", "path", service.SnapshotPath(azureYamlDir), "error", err)
+	}
+}
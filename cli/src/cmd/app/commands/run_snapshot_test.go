@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestValidateFromSnapshot(t *testing.T) {
+	defer func() { runFromSnapshot, runRuntime, runOnly = false, runtimeModeAzd, "" }()
+
+	runFromSnapshot, runRuntime = true, runtimeModeAzd
+	if err := validateFromSnapshot(); err != nil {
+		t.Errorf("validateFromSnapshot() error = %v", err)
+	}
+
+	runOnly = "api"
+	if err := validateFromSnapshot(); err == nil {
+		t.Error("validateFromSnapshot() should reject --only")
+	}
+
+	runOnly, runRuntime = "", runtimeModeAspire
+	if err := validateFromSnapshot(); err == nil {
+		t.Error("validateFromSnapshot() should reject --runtime aspire")
+	}
+}
+
+// TestRunSnapshot_ReproducesRuntimes verifies that a run's snapshot is written to
+// .azure/app/last-run.json and that --from-snapshot resolves the same runtimes.
+func TestRunSnapshot_ReproducesRuntimes(t *testing.T) {
+	defer func() { runEnvFiles = nil }()
+	runEnvFiles = nil
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"api/requirements.txt": "flask\n",
+		"api/app.py":           "print('hello')\n",
+		"azure.yaml": `name: snapshot-test
+services:
+  api:
+    project: ./api
+    language: python
+    command: python app.py
+    ports: ["5000"]
+    environment:
+      API_TOKEN: s3cret
+  cache:
+    image: redis:7
+    ports: ["6379:6379"]
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	azureYaml, err := service.ParseAzureYaml(filepath.Join(dir, "azure.yaml"))
+	if err != nil {
+		t.Fatalf("ParseAzureYaml() error = %v", err)
+	}
+	runtimes, err := detectServiceRuntimes(azureYaml.Services, dir, runtimeModeAzd)
+	if err != nil {
+		t.Fatalf("detectServiceRuntimes() error = %v", err)
+	}
+
+	writeRunSnapshot(runtimes, azureYaml.Services, map[string]string{}, dir)
+	if _, err := os.Stat(filepath.Join(dir, ".azure", "app", "last-run.json")); err != nil {
+		t.Fatalf("snapshot not written: %v", err)
+	}
+
+	restored, err := runtimesFromSnapshot(azureYaml.Services, dir)
+	if err != nil {
+		t.Fatalf("runtimesFromSnapshot() error = %v", err)
+	}
+
+	byName := func(runtimes []*service.ServiceRuntime) []*service.ServiceRuntime {
+		sort.Slice(runtimes, func(i, j int) bool { return runtimes[i].Name < runtimes[j].Name })
+		return runtimes
+	}
+	want, got := byName(runtimes), byName(restored)
+	if len(got) != len(want) {
+		t.Fatalf("runtimesFromSnapshot() returned %d runtimes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("runtime %s:\n got  %+v\n want %+v", want[i].Name, *got[i], *want[i])
+		}
+	}
+}
+
+// TestDetectServiceRuntimes_Cache verifies that detected runtimes are cached in
+// .azure/app-cache unless --no-cache is set.
+func TestDetectServiceRuntimes_Cache(t *testing.T) {
+	defer func() { runNoCache = false }()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "worker"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "worker", "requirements.txt"), []byte("celery\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte("name: cache-test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	services := map[string]service.Service{
+		"worker": {Project: "./worker", Language: "python", Command: "python worker.py"},
+	}
+	cachePath := service.RuntimeCachePath(dir)
+
+	runNoCache = true
+	if _, err := detectServiceRuntimes(services, dir, runtimeModeAzd); err != nil {
+		t.Fatalf("detectServiceRuntimes() error = %v", err)
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Fatalf("--no-cache should not write %s", cachePath)
+	}
+
+	runNoCache = false
+	detected, err := detectServiceRuntimes(services, dir, runtimeModeAzd)
+	if err != nil {
+		t.Fatalf("detectServiceRuntimes() error = %v", err)
+	}
+	cache, err := service.OpenRuntimeCache(filepath.Join(dir, "azure.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, ok := cache.Lookup("worker", services["worker"], runtimeModeAzd)
+	if !ok {
+		t.Fatalf("runtime not cached in %s", cachePath)
+	}
+	if !reflect.DeepEqual(cached, detected[0]) {
+		t.Errorf("cached runtime:\n got  %+v\n want %+v", *cached, *detected[0])
+	}
+}
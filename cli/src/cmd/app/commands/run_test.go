@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/jongio/azd-app/cli/src/internal/browser"
 	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/orchestrator"
 	"github.com/jongio/azd-app/cli/src/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -162,6 +165,333 @@ func TestRunCommandFlagDefaults(t *testing.T) {
 	}
 }
 
+func TestRunCommandDependencies(t *testing.T) {
+	originalOrchestrator := cmdOrchestrator
+	defer func() {
+		cmdOrchestrator = originalOrchestrator
+		runNoDeps = false
+		runDryRun = false
+	}()
+
+	var executed []string
+	record := func(name string) orchestrator.CommandFunc {
+		return func() error {
+			executed = append(executed, name)
+			return nil
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		noDeps bool
+		dryRun bool
+		want   []string
+	}{
+		{name: "installs dependencies", want: []string{"reqs", "deps", "run"}},
+		{name: "no-deps checks reqs only", noDeps: true, want: []string{"reqs"}},
+		{name: "dry run executes nothing", dryRun: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdOrchestrator = orchestrator.NewOrchestrator()
+			for _, command := range []*orchestrator.Command{
+				{Name: "reqs", Execute: record("reqs")},
+				{Name: "deps", Dependencies: []string{"reqs"}, Execute: record("deps")},
+				{Name: "run", Dependencies: []string{"deps"}, Execute: record("run")},
+			} {
+				if err := cmdOrchestrator.Register(command); err != nil {
+					t.Fatal(err)
+				}
+			}
+			executed = nil
+			runNoDeps, runDryRun = tt.noDeps, tt.dryRun
+
+			if err := runCommandDependencies(); err != nil {
+				t.Fatalf("runCommandDependencies() error = %v", err)
+			}
+			if !reflect.DeepEqual(executed, tt.want) {
+				t.Errorf("executed = %v, want %v", executed, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateForwardSignals(t *testing.T) {
+	defer func() {
+		runForwardSignals = nil
+		runForwardSignalsTo = ""
+	}()
+
+	tests := []struct {
+		name      string
+		signals   []string
+		targets   string
+		wantError string
+	}{
+		{name: "Disabled", wantError: ""},
+		{name: "Valid signals", signals: []string{"HUP", "SIGUSR1"}, wantError: ""},
+		{name: "Invalid signal", signals: []string{"TERM"}, wantError: "invalid --forward-signals value"},
+		{name: "Targets without signals", targets: "api", wantError: "--forward-signals-to requires --forward-signals"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runForwardSignals = tt.signals
+			runForwardSignalsTo = tt.targets
+
+			err := validateForwardSignals()
+			// Windows ignores signal names with a warning
+			if tt.wantError == "" || (len(tt.signals) > 0 && !service.SignalForwardingSupported) {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantError) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestValidateForwardSignalTargets(t *testing.T) {
+	defer func() { runForwardSignalsTo = "" }()
+	services := map[string]service.Service{"api": {}, "web": {}}
+
+	runForwardSignalsTo = " api, web ,"
+	if err := validateForwardSignalTargets(services); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if got := forwardSignalTargets(); len(got) != 2 || got[0] != "api" || got[1] != "web" {
+		t.Errorf("forwardSignalTargets() = %v, want [api web]", got)
+	}
+
+	runForwardSignalsTo = "api,worker"
+	if err := validateForwardSignalTargets(services); err == nil || !contains(err.Error(), `"worker"`) {
+		t.Errorf("Expected error for unknown service worker, got: %v", err)
+	}
+
+	runForwardSignalsTo = ""
+	if got := forwardSignalTargets(); got != nil {
+		t.Errorf("forwardSignalTargets() = %v, want nil (all services)", got)
+	}
+}
+
+func TestAutoPortRange(t *testing.T) {
+	defer func() { runAutoPort, runAutoPortRange = false, "" }()
+
+	tests := []struct {
+		autoPort  bool
+		value     string
+		wantStart int
+		wantEnd   int
+		wantError string
+	}{
+		{autoPort: true, value: "", wantStart: 3000, wantEnd: 65535},
+		{autoPort: true, value: "8000-8999", wantStart: 8000, wantEnd: 8999},
+		{autoPort: false, value: "8000-8999", wantError: "--auto-port-range requires --auto-port"},
+		{autoPort: true, value: "8999-8000", wantError: "invalid --auto-port-range value"},
+		{autoPort: true, value: "8000", wantError: "invalid --auto-port-range value"},
+		{autoPort: true, value: "0-100", wantError: "invalid --auto-port-range value"},
+	}
+
+	for _, tt := range tests {
+		runAutoPort, runAutoPortRange = tt.autoPort, tt.value
+		start, end, err := autoPortRange()
+		if tt.wantError != "" {
+			if err == nil || !contains(err.Error(), tt.wantError) {
+				t.Errorf("autoPortRange(%q) error = %v, want %q", tt.value, err, tt.wantError)
+			}
+			continue
+		}
+		if err != nil || start != tt.wantStart || end != tt.wantEnd {
+			t.Errorf("autoPortRange(%q) = %d, %d, %v, want %d, %d", tt.value, start, end, err, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestValidateReadyTimeout(t *testing.T) {
+	defer func() { runReadyTimeout = service.DefaultReadyTimeout }()
+
+	cmd := NewRunCommand()
+	if flag := cmd.Flags().Lookup("ready-timeout"); flag == nil || flag.DefValue != "1m0s" {
+		t.Fatalf("--ready-timeout default = %v, want 1m0s", flag)
+	}
+
+	for _, tt := range []struct {
+		value   time.Duration
+		wantErr bool
+	}{
+		{value: 60 * time.Second},
+		{value: 500 * time.Millisecond},
+		{value: 0, wantErr: true},
+		{value: -time.Second, wantErr: true},
+	} {
+		runReadyTimeout = tt.value
+		if err := validateReadyTimeout(); (err != nil) != tt.wantErr {
+			t.Errorf("validateReadyTimeout(%s) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateShutdownTimeout(t *testing.T) {
+	defer func() { runShutdownTimeout = service.DefaultGracefulShutdownTimeout }()
+
+	cmd := NewRunCommand()
+	if flag := cmd.Flags().Lookup("shutdown-timeout"); flag == nil || flag.DefValue != "10s" {
+		t.Fatalf("--shutdown-timeout default = %v, want 10s", flag)
+	}
+
+	for _, tt := range []struct {
+		value   time.Duration
+		wantErr bool
+	}{
+		{value: 10 * time.Second},
+		{value: 0, wantErr: true},
+		{value: -time.Second, wantErr: true},
+	} {
+		runShutdownTimeout = tt.value
+		if err := validateShutdownTimeout(); (err != nil) != tt.wantErr {
+			t.Errorf("validateShutdownTimeout(%s) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestShutdownWaves(t *testing.T) {
+	running := &service.ServiceProcess{Process: &os.Process{}}
+	processes := map[string]*service.ServiceProcess{
+		"web":    running,
+		"api":    running,
+		"worker": running,
+		"db":     running,
+		"cache":  {}, // Not running
+		"extra":  running,
+	}
+	order := [][]string{{"web"}, {"api", "worker"}, {"cache", "db", "missing"}}
+
+	got := shutdownWaves(processes, order)
+	want := [][]string{{"web"}, {"api", "worker"}, {"db", "extra"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shutdownWaves() = %v, want %v", got, want)
+	}
+
+	if got := shutdownWaves(processes, nil); !reflect.DeepEqual(got, [][]string{{"api", "db", "extra", "web", "worker"}}) {
+		t.Errorf("shutdownWaves() without an order = %v, want every running service in one wave", got)
+	}
+}
+
+func TestValidateServiceSelection(t *testing.T) {
+	defer func() { runServiceFilter, runOnly, runExclude = "", "", "" }()
+
+	tests := []struct {
+		name      string
+		service   string
+		only      string
+		exclude   string
+		wantError string
+	}{
+		{name: "None"},
+		{name: "Only", only: "api,worker"},
+		{name: "Exclude with service", service: "api,cosmos", exclude: "cosmos"},
+		{name: "Only and exclude", only: "api", exclude: "cosmos", wantError: "--only and --exclude are mutually exclusive"},
+		{name: "Only and service", service: "api", only: "worker", wantError: "--only and --service are mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runServiceFilter, runOnly, runExclude = tt.service, tt.only, tt.exclude
+
+			err := validateServiceSelection()
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantError) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestValidateServiceNames(t *testing.T) {
+	defer func() { runOnly, runExclude = "", "" }()
+	services := map[string]service.Service{"web": {}, "api": {}, "cosmos": {}}
+
+	runOnly = "api, web"
+	if err := validateServiceNames(services); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	runOnly, runExclude = "", "cosmso"
+	err := validateServiceNames(services)
+	if err == nil || !contains(err.Error(), `invalid --exclude value: unknown service "cosmso" (valid services: api, cosmos, web)`) {
+		t.Errorf("Expected unknown service error listing valid names, got: %v", err)
+	}
+}
+
+func TestValidateServiceNames_ServiceWithDeps(t *testing.T) {
+	defer func() { runServiceFilter, runWithDeps = "", false }()
+	services := map[string]service.Service{"web": {}, "api": {}}
+
+	// Without --with-deps the filter is not expanded, so an unknown name selects nothing
+	runServiceFilter = "typo"
+	if err := validateServiceNames(services); err != nil {
+		t.Errorf("Unexpected error without --with-deps: %v", err)
+	}
+
+	runWithDeps = true
+	err := validateServiceNames(services)
+	if err == nil || !contains(err.Error(), `invalid --service value: unknown service "typo" (valid services: api, web)`) {
+		t.Errorf("Expected unknown service error listing valid names, got: %v", err)
+	}
+}
+
+func TestFilterServices_OnlyAndExclude(t *testing.T) {
+	defer func() { runOnly, runExclude, runWithDeps = "", "", false }()
+
+	services, err := service.ExpandSidecars(map[string]service.Service{
+		"web":    {Uses: []string{"api"}},
+		"api":    {Sidecars: map[string]service.Service{"proxy": {Image: "envoy"}}},
+		"worker": {},
+		"cosmos": {Image: "cosmos-emulator"},
+	})
+	if err != nil {
+		t.Fatalf("ExpandSidecars() error = %v", err)
+	}
+	azureYaml := &service.AzureYaml{Services: services}
+	names := func(m map[string]service.Service) []string {
+		keys := make([]string, 0, len(m))
+		for name := range m {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	tests := []struct {
+		name     string
+		only     string
+		exclude  string
+		withDeps bool
+		want     []string
+	}{
+		{name: "Only", only: "api,worker", want: []string{"api", "api-proxy", "worker"}},
+		{name: "Only with deps", only: "web", withDeps: true, want: []string{"api", "api-proxy", "web"}},
+		{name: "Exclude", exclude: "cosmos", want: []string{"api", "api-proxy", "web", "worker"}},
+		{name: "Exclude drops sidecars", exclude: "api, cosmos", want: []string{"web", "worker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runOnly, runExclude, runWithDeps = tt.only, tt.exclude, tt.withDeps
+			if got := names(filterServices(azureYaml)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterServices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunAspireMode(t *testing.T) {
 	// Create temporary directory with Aspire project
 	tmpDir, err := os.MkdirTemp("", "aspire-mode-test-*")
@@ -172,10 +502,13 @@ func TestRunAspireMode(t *testing.T) {
 
 	// Create AppHost.csproj
 	csprojPath := filepath.Join(tmpDir, "AppHost.csproj")
-	csprojContent := `<Project Sdk="Microsoft.NET.Sdk.Web">
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
   <PropertyGroup>
     <TargetFramework>net8.0</TargetFramework>
   </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Aspire.Hosting.AppHost" Version="9.0.0" />
+  </ItemGroup>
 </Project>`
 	if err := os.WriteFile(csprojPath, []byte(csprojContent), 0600); err != nil {
 		t.Fatalf("Failed to create csproj: %v", err)
@@ -265,7 +598,7 @@ func TestMonitorServicesUntilShutdown_StartupTimeout(t *testing.T) {
 	// Run monitoring in goroutine with timeout
 	done := make(chan error, 1)
 	go func() {
-		done <- monitorServicesUntilShutdown(result, tmpDir)
+		done <- monitorServicesUntilShutdown(result, tmpDir, nil)
 	}()
 
 	// Ensure cleanup if test exits early
@@ -349,7 +682,7 @@ func TestMonitorServicesUntilShutdown_SignalHandling(t *testing.T) {
 	}()
 
 	startTime := time.Now()
-	_ = monitorServicesUntilShutdown(result, tmpDir)
+	_ = monitorServicesUntilShutdown(result, tmpDir, nil)
 	elapsed := time.Since(startTime)
 
 	// Should complete reasonably quickly after signal
@@ -405,10 +738,7 @@ func TestMonitorServicesUntilShutdown_MultipleServices(t *testing.T) {
 	}
 
 	// Test that all services can be started and shutdown cleanly works
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	err := shutdownAllServices(ctx, result.Processes)
+	err := shutdownAllServices(result.Processes, nil, 2*time.Second)
 	if err != nil {
 		t.Logf("shutdownAllServices() returned: %v", err)
 	}
@@ -457,12 +787,8 @@ func TestShutdownAllServices_WithContext(t *testing.T) {
 		StartTime: time.Now(),
 	}
 
-	// Create context with timeout for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	startTime := time.Now()
-	err := shutdownAllServices(ctx, result.Processes)
+	err := shutdownAllServices(result.Processes, nil, 10*time.Second)
 	elapsed := time.Since(startTime)
 
 	// Log any shutdown errors for diagnostics
@@ -487,7 +813,7 @@ func TestShutdownAllServices_WithContext(t *testing.T) {
 	}
 }
 
-func TestShutdownAllServices_ContextTimeout(t *testing.T) {
+func TestShutdownAllServices_Timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping shutdown timeout test in short mode")
 	}
@@ -525,12 +851,9 @@ func TestShutdownAllServices_ContextTimeout(t *testing.T) {
 		StartTime: time.Now(),
 	}
 
-	// Use very short context timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
+	// Use a very short grace period
 	startTime := time.Now()
-	err = shutdownAllServices(ctx, result.Processes)
+	err = shutdownAllServices(result.Processes, nil, 2*time.Second)
 	elapsed := time.Since(startTime)
 
 	// Expect errors due to timeout
@@ -540,7 +863,7 @@ func TestShutdownAllServices_ContextTimeout(t *testing.T) {
 
 	// Should respect context timeout
 	if elapsed > 4*time.Second {
-		t.Errorf("shutdownAllServices() took %v, expected < 4s with 2s context timeout", elapsed)
+		t.Errorf("shutdownAllServices() took %v, expected < 4s with a 2s timeout", elapsed)
 	}
 }
 
@@ -604,7 +927,7 @@ func TestMonitorServices_RunsIndefinitely(t *testing.T) {
 	}()
 
 	startTime := time.Now()
-	_ = monitorServicesUntilShutdown(result, tmpDir)
+	_ = monitorServicesUntilShutdown(result, tmpDir, nil)
 	elapsed := time.Since(startTime)
 
 	// Should have run for approximately 5 seconds (not stop at 30 seconds or earlier)
@@ -844,7 +1167,7 @@ func TestProcessExit_DoesNotStopOtherServices(t *testing.T) {
 	// Run monitoring in a goroutine since it waits indefinitely for signals
 	done := make(chan error, 1)
 	go func() {
-		done <- monitorServicesUntilShutdown(result, tmpDir)
+		done <- monitorServicesUntilShutdown(result, tmpDir, nil)
 	}()
 
 	// Ensure cleanup if test exits
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+// watchRestarts holds the processes that --watch stopped on purpose, so their monitors
+// don't report the exit as a crash.
+var watchRestarts sync.Map // *os.Process -> struct{}
+
+// validateWatch checks that --watch can be used with the selected runtime.
+func validateWatch() error {
+	if runWatch && runRuntime == runtimeModeAspire {
+		return fmt.Errorf("--watch is not supported with --runtime %s", runtimeModeAspire)
+	}
+	return nil
+}
+
+// watchTargets returns the services to watch, in name order. Containers have no local
+// source to watch, and watch-mode services already reload themselves.
+func (r *serviceRestarter) watchTargets() []service.WatchTarget {
+	var targets []service.WatchTarget
+	for name, proc := range r.result.CurrentProcesses() {
+		svc, ok := r.services[name]
+		if !ok || proc.Process == nil || proc.Runtime.Type == service.ServiceTypeContainer || svc.IsWatchMode() {
+			continue
+		}
+		targets = append(targets, service.WatchTargetFor(name, svc, proc.Runtime.WorkingDir))
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Service < targets[j].Service })
+	return targets
+}
+
+// start watches the services' files until ctx is cancelled. Restarted services get a new
+// monitor goroutine, tracked by wg like the original ones.
+func (r *serviceRestarter) start(ctx context.Context, wg *sync.WaitGroup) {
+	targets := r.watchTargets()
+	if len(targets) == 0 {
+		output.Info("--watch: no services to watch (containers and watch-mode services are skipped)")
+		return
+	}
+
+	watcher, err := service.NewServiceWatcher(targets, func(name, file string) {
+		r.restartService(ctx, wg, name, file)
+	})
+	if err != nil {
+		output.Warning("File watching unavailable: %v", err)
+		return
+	}
+
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.Service
+	}
+	output.Info("Watching %s for changes", strings.Join(names, ", "))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if rec := recover(); rec != nil {
+				output.Error("File watcher panic recovered: %v", rec)
+			}
+		}()
+		if err := watcher.Run(ctx); err != nil {
+			output.Warning("File watching stopped: %v", err)
+		}
+	}()
+}
+
+// restartService stops one service and starts it again, leaving the other services running.
+func (r *serviceRestarter) restartService(ctx context.Context, wg *sync.WaitGroup, name, file string) {
+	if ctx.Err() != nil {
+		return
+	}
+	proc := r.result.Process(name)
+	if proc == nil || proc.Process == nil {
+		return
+	}
+
+	output.Info("Restarting %s due to change in %s", name, file)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A dashboard or --restart restart may have replaced proc while we waited for the lock
+	if _, err := r.restartIfCurrent(ctx, wg, name, proc); err != nil {
+		output.Error("Failed to restart %s: %v", name, err)
+		output.Info("Save a file in %s to try again", name)
+	}
+}
+
+// stoppedForWatchRestart reports, once, whether --watch stopped process to restart its service.
+func stoppedForWatchRestart(process *os.Process) bool {
+	_, ok := watchRestarts.LoadAndDelete(process)
+	return ok
+}
@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestValidateWatch(t *testing.T) {
+	defer func(watch bool, runtime string) { runWatch, runRuntime = watch, runtime }(runWatch, runRuntime)
+
+	runWatch, runRuntime = true, runtimeModeAzd
+	if err := validateWatch(); err != nil {
+		t.Errorf("validateWatch() with azd runtime error = %v", err)
+	}
+
+	runRuntime = runtimeModeAspire
+	if err := validateWatch(); err == nil {
+		t.Error("validateWatch() should reject --runtime aspire")
+	}
+
+	runWatch = false
+	if err := validateWatch(); err != nil {
+		t.Errorf("validateWatch() without --watch error = %v", err)
+	}
+}
+
+func TestServiceRestarterWatchTargets(t *testing.T) {
+	process := func(name, serviceType string) *service.ServiceProcess {
+		return &service.ServiceProcess{
+			Name:    name,
+			Process: &os.Process{Pid: 100},
+			Runtime: service.ServiceRuntime{Name: name, Type: serviceType, WorkingDir: "/project/" + name},
+		}
+	}
+	restarter := &serviceRestarter{
+		result: &service.OrchestrationResult{Processes: map[string]*service.ServiceProcess{
+			"api":    process("api", service.ServiceTypeHTTP),
+			"worker": process("worker", service.ServiceTypeProcess),
+			"tsc":    process("tsc", service.ServiceTypeProcess),
+			"redis":  process("redis", service.ServiceTypeContainer),
+			"queued": {Name: "queued"},
+		}},
+		services: map[string]service.Service{
+			"api":    {Project: "./api", Ports: []string{"8080"}, WatchPaths: []string{"src"}, WatchIgnore: []string{"*.tmp"}},
+			"worker": {Project: "./worker", Type: service.ServiceTypeProcess},
+			"tsc":    {Project: "./tsc", Type: service.ServiceTypeProcess, Mode: service.ServiceModeWatch},
+			"redis":  {Image: "redis:7"},
+			"queued": {Project: "./queued"},
+		},
+	}
+
+	want := []service.WatchTarget{
+		{Service: "api", Root: "/project/api", Paths: []string{"src"}, Ignore: []string{"*.tmp"}},
+		{Service: "worker", Root: "/project/worker"},
+	}
+	if got := restarter.watchTargets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("watchTargets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestServiceRestarterRestartService(t *testing.T) {
+	oldProcess := &os.Process{Pid: 100}
+	proc := &service.ServiceProcess{Name: "api", Process: oldProcess}
+	restarter := &serviceRestarter{
+		result: &service.OrchestrationResult{Processes: map[string]*service.ServiceProcess{"api": proc}},
+	}
+
+	t.Run("failed restart keeps the old process", func(t *testing.T) {
+		restarter.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			return nil, errors.New("port 8080 is no longer available")
+		}
+		restarter.restartService(context.Background(), &sync.WaitGroup{}, "api", "handler.go")
+
+		if proc.Process != oldProcess {
+			t.Errorf("Process = %v, want the old process", proc.Process)
+		}
+		if !stoppedForWatchRestart(oldProcess) {
+			t.Error("old process should be marked as stopped for a restart")
+		}
+	})
+
+	t.Run("restarted process replaces the old one", func(t *testing.T) {
+		var stopped *os.Process
+		restarter.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			stopped = p.Process
+			// No process, so no monitor goroutine is needed for the fake
+			return &service.ServiceProcess{Name: "api", Port: 8081}, nil
+		}
+		restarter.restartService(context.Background(), &sync.WaitGroup{}, "api", "handler.go")
+
+		if stopped != oldProcess {
+			t.Errorf("restart stopped %v, want the old process", stopped)
+		}
+		if got := restarter.result.Process("api"); got == proc || got.Port != 8081 {
+			t.Errorf("Process(api) = %+v, want the restarted process", got)
+		}
+		if proc.Process != oldProcess {
+			t.Errorf("old entry Process = %v, want it left unchanged for its monitor", proc.Process)
+		}
+		if !stoppedForWatchRestart(oldProcess) || stoppedForWatchRestart(oldProcess) {
+			t.Error("old process should be marked once as stopped for a restart")
+		}
+	})
+
+	t.Run("skips a process another restart already replaced", func(t *testing.T) {
+		stale := &service.ServiceProcess{Name: "api", Process: &os.Process{Pid: 200}}
+		current := restarter.result.Process("api")
+		restarter.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			t.Error("restart should not be called for a replaced process")
+			return p, nil
+		}
+		restarter.mu.Lock()
+		got, err := restarter.restartIfCurrent(context.Background(), &sync.WaitGroup{}, "api", stale)
+		restarter.mu.Unlock()
+
+		if err != nil || got != current {
+			t.Errorf("restartIfCurrent() = %+v, %v, want the current process kept", got, err)
+		}
+		if stoppedForWatchRestart(stale.Process) {
+			t.Error("a skipped restart should leave no watch restart marker")
+		}
+	})
+
+	t.Run("ignored after shutdown starts", func(t *testing.T) {
+		called := false
+		restarter.restart = func(p *service.ServiceProcess) (*service.ServiceProcess, error) {
+			called = true
+			return p, nil
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		restarter.result.Processes["api"] = &service.ServiceProcess{Name: "api", Process: oldProcess}
+		restarter.restartService(ctx, &sync.WaitGroup{}, "api", "handler.go")
+
+		if called {
+			t.Error("restart should not run once the context is cancelled")
+		}
+	})
+}
+
+// TestMonitorServiceProcess_WatchRestart verifies that a process stopped by --watch
+// is not reported as a crash.
+func TestMonitorServiceProcess_WatchRestart(t *testing.T) {
+	if testing.Short() || runtime.GOOS == "windows" {
+		t.Skip("skipping process test in short mode or on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	rt := &service.ServiceRuntime{
+		Name:       "watched",
+		WorkingDir: tmpDir,
+		Command:    "sleep",
+		Args:       []string{"30"},
+		Language:   "shell",
+	}
+	process, err := service.StartService(rt, map[string]string{}, tmpDir, nil)
+	if err != nil {
+		t.Fatalf("StartService() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = service.GetLogManager(tmpDir).RemoveBuffer(rt.Name)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	done := make(chan serviceExit, 1)
+	go func() {
+		done <- monitorServiceProcess(context.Background(), &wg, rt.Name, process, tmpDir)
+	}()
+
+	watchRestarts.Store(process.Process, struct{}{})
+	if err := process.Process.Kill(); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+
+	select {
+	case exit := <-done:
+		if exit != serviceExitWatchRestart {
+			t.Errorf("monitorServiceProcess() = %v, want serviceExitWatchRestart", exit)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("monitorServiceProcess did not return after the process was stopped")
+	}
+}
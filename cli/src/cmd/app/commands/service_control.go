@@ -26,6 +26,9 @@ type ServiceController struct {
 	projectDir string
 	registry   *registry.ServiceRegistry
 	opManager  *service.ServiceOperationManager
+
+	// noRuntimeCache detects service runtimes again instead of reusing cached ones (--no-cache)
+	noRuntimeCache bool
 }
 
 // ServiceControlResult contains the result of a service control operation.
@@ -36,6 +39,9 @@ type ServiceControlResult struct {
 	Status      string `json:"status,omitempty"`
 	Error       string `json:"error,omitempty"`
 	Duration    string `json:"duration,omitempty"` // Human-readable duration string
+
+	// StartupDuration is how long a restarted service took to pass its health check
+	StartupDuration string `json:"startupDuration,omitempty"`
 }
 
 // BulkServiceControlResult contains results for bulk service operations.
@@ -308,7 +314,7 @@ func (c *ServiceController) performStart(ctx context.Context, entry *registry.Se
 	// Parse azure.yaml to get service configuration
 	azureYaml, err := service.ParseAzureYaml(c.projectDir)
 	if err != nil {
-		return fmt.Errorf("failed to parse azure.yaml: %w", err)
+		return err
 	}
 
 	svcDef, exists := azureYaml.Services[serviceName]
@@ -316,11 +322,15 @@ func (c *ServiceController) performStart(ctx context.Context, entry *registry.Se
 		return fmt.Errorf("service '%s' not found in azure.yaml", serviceName)
 	}
 
-	// Detect runtime
-	runtime, err := service.DetectServiceRuntime(serviceName, svcDef, map[int]bool{}, c.projectDir, "")
+	// Detect runtime, reusing the one cached by earlier commands when the project hasn't changed
+	cache := openRuntimeCache(filepath.Join(c.projectDir, "azure.yaml"), c.noRuntimeCache)
+	runtime, err := service.DetectServiceRuntimeCached(cache, serviceName, svcDef, map[int]bool{}, c.projectDir, runtimeModeAzd)
 	if err != nil {
 		return fmt.Errorf("failed to detect service runtime: %w", err)
 	}
+	if err := cache.Save(); err != nil {
+		slog.Warn("failed to save runtime cache", "path", service.RuntimeCachePath(c.projectDir), "error", err)
+	}
 
 	// Track port for cleanup on failure (native services only)
 	assignedPort := runtime.Port
@@ -331,7 +341,13 @@ func (c *ServiceController) performStart(ctx context.Context, entry *registry.Se
 	// Start the service - use container runner for container services
 	var process *service.ServiceProcess
 	if runtime.Type == service.ServiceTypeContainer {
-		process, err = service.StartContainerService(runtime, c.projectDir, true) // restartContainers=true for restart/start ops
+		// Rebuild the image of a Dockerfile service if its build context changed
+		_, err = service.BuildContainerImage(runtime, c.projectDir, false, func(line string) {
+			slog.Debug("image build output", "service", serviceName, "line", line)
+		})
+		if err == nil {
+			process, err = service.StartContainerService(runtime, c.projectDir, true) // restartContainers=true for restart/start ops
+		}
 		if err == nil {
 			// Start container log collection
 			if logErr := service.StartContainerLogCollection(process, c.projectDir); logErr != nil {
@@ -404,6 +420,7 @@ func (c *ServiceController) performStart(ctx context.Context, entry *registry.Se
 		Name:        serviceName,
 		ProjectDir:  entry.ProjectDir,
 		Port:        runtime.Port,
+		Ports:       runtime.RegistryPorts(),
 		URL:         entry.URL,
 		AzureURL:    entry.AzureURL,
 		Language:    runtime.Language,
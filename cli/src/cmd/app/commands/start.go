@@ -11,6 +11,7 @@ import (
 var (
 	startService string
 	startAll     bool
+	startNoCache bool
 )
 
 // NewStartCommand creates the start command.
@@ -44,6 +45,7 @@ Examples:
 
 	cmd.Flags().StringVarP(&startService, "service", "s", "", "Service name(s) to start (comma-separated)")
 	cmd.Flags().BoolVar(&startAll, "all", false, "Start all stopped services")
+	cmd.Flags().BoolVar(&startNoCache, "no-cache", false, "Detect service runtimes again instead of reusing results cached in .azure/app-cache")
 
 	return cmd
 }
@@ -61,6 +63,7 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
+	ctrl.noRuntimeCache = startNoCache
 
 	// Set up context with signal handling
 	ctx, _, cleanup := setupContextWithSignalHandling()
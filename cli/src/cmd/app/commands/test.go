@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
 	"github.com/jongio/azd-app/cli/src/internal/testing"
 	"github.com/spf13/cobra"
 )
@@ -18,23 +20,27 @@ import (
 // TestOptions holds the options for the test command.
 // Using a struct instead of global variables for better testability and concurrency safety.
 type TestOptions struct {
-	Type            string
-	Coverage        bool
-	ServiceFilter   string
-	Watch           bool
-	UpdateSnapshots bool
-	FailFast        bool
-	Parallel        bool
-	Threshold       int
-	Verbose         bool
-	DryRun          bool
-	OutputFormat    string
-	OutputDir       string
-	Stream          bool
-	NoStream        bool
-	Timeout         time.Duration
-	Save            bool
-	NoSave          bool
+	Type               string
+	Coverage           bool
+	ServiceFilter      string
+	Watch              bool
+	UpdateSnapshots    bool
+	FailFast           bool
+	Parallel           bool
+	MaxParallel        int
+	Threshold          int
+	AggregateThreshold int
+	Verbose            bool
+	DryRun             bool
+	OutputFormat       string
+	OutputDir          string
+	JUnit              string
+	Stream             bool
+	NoStream           bool
+	Timeout            time.Duration
+	Save               bool
+	NoSave             bool
+	WithDeps           bool
 }
 
 // NewTestCommand creates the test command.
@@ -72,16 +78,20 @@ func NewTestCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&opts.UpdateSnapshots, "update-snapshots", "u", false, "Update test snapshots")
 	cmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "Stop on first test failure")
 	cmd.Flags().BoolVarP(&opts.Parallel, "parallel", "p", true, "Run tests for services in parallel")
+	cmd.Flags().IntVar(&opts.MaxParallel, "max-parallel", 0, "Maximum services tested at once with --parallel (0 = number of CPUs)")
 	cmd.Flags().IntVar(&opts.Threshold, "threshold", 0, "Minimum coverage threshold (0-100)")
+	cmd.Flags().IntVar(&opts.AggregateThreshold, "aggregate-threshold", 0, "Minimum coverage across all services combined, weighted by lines (0-100)")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose test output")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be tested without running tests")
 	cmd.Flags().StringVar(&opts.OutputFormat, "output-format", "default", "Output format: default, json, junit, github")
 	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", "./test-results", "Directory for test reports and coverage")
+	cmd.Flags().StringVar(&opts.JUnit, "junit", "", "Write a JUnit XML report of all services' test results to this path")
 	cmd.Flags().BoolVar(&opts.Stream, "stream", false, "Force streaming output (direct test output)")
 	cmd.Flags().BoolVar(&opts.NoStream, "no-stream", false, "Force progress bar mode instead of streaming")
 	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "Per-service test timeout (e.g., 5m, 30s, 1h)")
 	cmd.Flags().BoolVar(&opts.Save, "save", false, "Save auto-detected test config to azure.yaml without prompting")
 	cmd.Flags().BoolVar(&opts.NoSave, "no-save", false, "Don't prompt to save auto-detected test config")
+	cmd.Flags().BoolVar(&opts.WithDeps, "with-deps", false, "Also test the services that --service targets depend on (via 'uses')")
 
 	return cmd
 }
@@ -103,6 +113,12 @@ func runTests(opts *TestOptions) error {
 	if opts.Threshold < 0 || opts.Threshold > 100 {
 		return fmt.Errorf("invalid coverage threshold: %d (must be between 0 and 100)", opts.Threshold)
 	}
+	if opts.AggregateThreshold < 0 || opts.AggregateThreshold > 100 {
+		return fmt.Errorf("invalid aggregate coverage threshold: %d (must be between 0 and 100)", opts.AggregateThreshold)
+	}
+	if opts.MaxParallel < 0 {
+		return fmt.Errorf("invalid max parallel: %d (must be 0 or more)", opts.MaxParallel)
+	}
 
 	// Validate output format
 	validFormats := map[string]bool{
@@ -140,12 +156,15 @@ func runTests(opts *TestOptions) error {
 
 	// Create test configuration
 	config := &testing.TestConfig{
-		Parallel:          opts.Parallel,
-		FailFast:          opts.FailFast,
-		CoverageThreshold: float64(opts.Threshold),
-		OutputDir:         opts.OutputDir,
-		Verbose:           opts.Verbose,
-		Timeout:           opts.Timeout,
+		Parallel:           opts.Parallel,
+		MaxParallel:        opts.MaxParallel,
+		FailFast:           opts.FailFast,
+		CoverageThreshold:  float64(opts.Threshold),
+		AggregateThreshold: float64(opts.AggregateThreshold),
+		OutputDir:          opts.OutputDir,
+		JUnit:              opts.JUnit != "",
+		Verbose:            opts.Verbose,
+		Timeout:            opts.Timeout,
 	}
 
 	// Create orchestrator
@@ -157,12 +176,9 @@ func runTests(opts *TestOptions) error {
 	}
 
 	// Parse service filter
-	var serviceFilter []string
-	if opts.ServiceFilter != "" {
-		serviceFilter = strings.Split(opts.ServiceFilter, ",")
-		for i := range serviceFilter {
-			serviceFilter[i] = strings.TrimSpace(serviceFilter[i])
-		}
+	serviceFilter, err := testServiceFilter(opts, azureYamlPath)
+	if err != nil {
+		return err
 	}
 
 	// Dry run - just show configuration and validation
@@ -208,6 +224,15 @@ func runTests(opts *TestOptions) error {
 	// Display results
 	displayTestResults(result)
 
+	if opts.JUnit != "" {
+		if err := testing.WriteJUnitReport(result, opts.JUnit); err != nil {
+			return err
+		}
+		if !output.IsJSON() {
+			output.Info("JUnit report written to %s", opts.JUnit)
+		}
+	}
+
 	// Check if tests passed
 	if !result.Success {
 		return fmt.Errorf("tests failed")
@@ -237,9 +262,18 @@ func runTestDryRun(orchestrator *testing.TestOrchestrator, opts *TestOptions, se
 		if opts.Threshold > 0 {
 			output.Item("Coverage threshold: %d%%", opts.Threshold)
 		}
+		if opts.AggregateThreshold > 0 {
+			output.Item("Aggregate coverage threshold: %d%%", opts.AggregateThreshold)
+		}
 		output.Item("Parallel: %v", opts.Parallel)
+		if opts.Parallel && opts.MaxParallel > 0 {
+			output.Item("Max parallel: %d", opts.MaxParallel)
+		}
 		output.Item("Output format: %s", opts.OutputFormat)
 		output.Item("Output directory: %s", opts.OutputDir)
+		if opts.JUnit != "" {
+			output.Item("JUnit report: %s", opts.JUnit)
+		}
 		output.Item("Timeout: %s", opts.Timeout)
 		if opts.Stream {
 			output.Item("Output mode: streaming (forced)")
@@ -307,6 +341,17 @@ func createProgressCallback() testing.ProgressCallback {
 
 		case testing.ProgressEventServiceSkipped:
 			// Skipped services are shown in displayValidationSummary
+
+		case testing.ProgressEventWatchStart:
+			output.Newline()
+			output.Info("👀 Watching %s for changes... (press Ctrl+C to stop)", event.Message)
+
+		case testing.ProgressEventWatchRerun:
+			output.Newline()
+			output.Step("🔄", "%s changed, re-running %s tests", event.Message, event.Service)
+
+		case testing.ProgressEventWatchTally:
+			output.Info("Tally: %s", event.Message)
 		}
 	}
 }
@@ -381,39 +426,31 @@ func promptSaveTestConfig(opts *TestOptions, azureYamlPath string, validations [
 	return nil
 }
 
-// runWatchMode runs tests in watch mode
+// runWatchMode runs tests in watch mode, re-running a service's tests when its files change
 func runWatchMode(orchestrator *testing.TestOrchestrator, testType string, serviceFilter []string) error {
-	// Get service paths to watch
-	paths, err := orchestrator.GetServicePaths()
-	if err != nil {
-		return fmt.Errorf("failed to get service paths: %w", err)
-	}
-
-	// Create watcher
-	watcher := testing.NewFileWatcher(paths)
-
 	// Setup signal handling
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 	go func() {
-		<-sigChan
-		cancel()
+		select {
+		case <-sigChan:
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
 	// Watch and run tests
-	return watcher.Watch(ctx, func() error {
-		result, err := orchestrator.ExecuteTests(testType, serviceFilter)
+	return orchestrator.Watch(ctx, testType, serviceFilter, func(result *testing.AggregateResult, err error) {
 		if err != nil {
 			// Don't fail in watch mode, just show error
-			fmt.Printf("❌ Test execution failed: %v\n", err)
-			return nil
+			output.Error("Test execution failed: %v", err)
+			return
 		}
-
 		displayTestResults(result)
-		return nil
 	})
 }
 
@@ -449,3 +486,28 @@ func displayTestResults(result *testing.AggregateResult) {
 		result.Passed, result.Failed, result.Skipped, result.Total)
 	output.Item("Duration: %.2fs", result.Duration)
 }
+
+// testServiceFilter returns the services selected by --service, expanded to their
+// dependency closure with --with-deps. Nil selects every service.
+func testServiceFilter(opts *TestOptions, azureYamlPath string) ([]string, error) {
+	if opts.ServiceFilter == "" {
+		return nil, nil
+	}
+	serviceFilter := strings.Split(opts.ServiceFilter, ",")
+	for i := range serviceFilter {
+		serviceFilter[i] = strings.TrimSpace(serviceFilter[i])
+	}
+	if !opts.WithDeps {
+		return serviceFilter, nil
+	}
+
+	azureYaml, err := service.ParseAzureYaml(filepath.Dir(azureYamlPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve service dependencies: %w", err)
+	}
+	// Unknown names would drop out of the closure, and an empty filter tests everything
+	if err := checkServiceNames("service", serviceFilter, azureYaml.Services); err != nil {
+		return nil, err
+	}
+	return service.ExpandDependencyClosure(azureYaml.Services, serviceFilter), nil
+}
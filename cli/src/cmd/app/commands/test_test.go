@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	testrunner "github.com/jongio/azd-app/cli/src/internal/testing"
@@ -101,6 +105,17 @@ func TestThresholdValidation(t *testing.T) {
 	}
 }
 
+// TestAggregateThresholdValidation tests that an out-of-range --aggregate-threshold is rejected.
+func TestAggregateThresholdValidation(t *testing.T) {
+	for _, threshold := range []int{-1, 101} {
+		opts := &TestOptions{Type: "all", OutputFormat: "default", AggregateThreshold: threshold}
+		err := runTests(opts)
+		if err == nil || !strings.Contains(err.Error(), "invalid aggregate coverage threshold") {
+			t.Errorf("runTests() with --aggregate-threshold %d error = %v, want invalid aggregate coverage threshold", threshold, err)
+		}
+	}
+}
+
 // TestOutputFormatValidation tests validation of output format.
 func TestOutputFormatValidation(t *testing.T) {
 	tests := []struct {
@@ -314,6 +329,30 @@ func TestServiceFilterParsing(t *testing.T) {
 	}
 }
 
+// TestTestServiceFilter_WithDeps verifies that --with-deps expands --service to its
+// dependencies and rejects unknown names instead of testing every service.
+func TestTestServiceFilter_WithDeps(t *testing.T) {
+	dir := t.TempDir()
+	azureYamlPath := filepath.Join(dir, "azure.yaml")
+	content := "name: test\nservices:\n  web:\n    project: ./web\n    uses: [api]\n  api:\n    project: ./api\n  worker:\n    project: ./worker\n"
+	if err := os.WriteFile(azureYamlPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	got, err := testServiceFilter(&TestOptions{ServiceFilter: "web", WithDeps: true}, azureYamlPath)
+	if err != nil {
+		t.Fatalf("testServiceFilter() error = %v", err)
+	}
+	if want := []string{"api", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("testServiceFilter() = %v, want %v", got, want)
+	}
+
+	_, err = testServiceFilter(&TestOptions{ServiceFilter: "typo", WithDeps: true}, azureYamlPath)
+	if err == nil || !strings.Contains(err.Error(), `unknown service "typo" (valid services: api, web, worker)`) {
+		t.Errorf("testServiceFilter() error = %v, want an unknown service error listing valid names", err)
+	}
+}
+
 // TestCommandLongDescription tests that the long description is set.
 func TestCommandLongDescription(t *testing.T) {
 	cmd := NewTestCommand()
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// ValidateResult is the validate result.
+type ValidateResult struct {
+	Path     string                      `json:"path"`
+	Valid    bool                        `json:"valid"` // No error-level findings
+	Errors   int                         `json:"errors"`
+	Warnings int                         `json:"warnings"`
+	Findings []service.ValidationFinding `json:"findings"`
+}
+
+// NewValidateCommand creates the validate command.
+func NewValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate azure.yaml against the fields azd app understands",
+		Long: `Checks azure.yaml without running anything: unknown fields such as a misspelled
+healthcheck, missing or invalid service settings for the service's host and language,
+uses entries that name no service or resource, and project paths that don't exist or
+point outside the project directory.
+
+Findings are errors, warnings or info. The command fails when there are errors.
+Use --output json for machine-readable output.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			result, err := validateAzureYaml(cwd)
+			if err != nil {
+				return err
+			}
+
+			if output.IsJSON() {
+				if err := output.PrintJSON(result); err != nil {
+					return err
+				}
+			} else {
+				printValidateResult(result)
+			}
+
+			if !result.Valid {
+				return fmt.Errorf("azure.yaml has %d error(s)", result.Errors)
+			}
+			return nil
+		},
+	}
+}
+
+// validateAzureYaml validates the azure.yaml found from workingDir and counts the findings.
+func validateAzureYaml(workingDir string) (*ValidateResult, error) {
+	path, findings, err := service.ValidateAzureYaml(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ValidateResult{Path: path, Findings: findings}
+	if result.Findings == nil {
+		result.Findings = []service.ValidationFinding{}
+	}
+	for _, finding := range findings {
+		switch finding.Severity {
+		case service.SeverityError:
+			result.Errors++
+		case service.SeverityWarning:
+			result.Warnings++
+		}
+	}
+	result.Valid = result.Errors == 0
+	return result, nil
+}
+
+// printValidateResult prints the findings in default format, one per line with its location.
+func printValidateResult(result *ValidateResult) {
+	for _, finding := range result.Findings {
+		location := finding.Field
+		if finding.Line > 0 {
+			location = fmt.Sprintf("line %d: %s", finding.Line, finding.Field)
+		}
+		message := finding.Message
+		if location != "" {
+			message = location + ": " + message
+		}
+
+		switch finding.Severity {
+		case service.SeverityError:
+			output.ItemError("%s", message)
+		case service.SeverityWarning:
+			output.ItemWarning("%s", message)
+		default:
+			output.ItemInfo("%s", message)
+		}
+	}
+	if len(result.Findings) > 0 {
+		output.Newline()
+	}
+
+	switch {
+	case result.Errors > 0:
+		output.Error("%s has %d error(s) and %d warning(s)", result.Path, result.Errors, result.Warnings)
+	case result.Warnings > 0:
+		output.Warning("%s is valid with %d warning(s)", result.Path, result.Warnings)
+	default:
+		output.Success("%s is valid", result.Path)
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/service"
+)
+
+func TestValidateAzureYamlCounts(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYaml := `name: validate-test
+services:
+  api:
+    language: python
+    project: ./missing
+    helthcheck: false
+  cache:
+    image: redis:7
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	result, err := validateAzureYaml(tmpDir)
+	if err != nil {
+		t.Fatalf("validateAzureYaml() error = %v", err)
+	}
+	if result.Valid || result.Errors != 1 || result.Warnings != 1 {
+		t.Errorf("result = %+v, want 1 error and 1 warning", result)
+	}
+}
+
+func TestValidateJSONOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYaml := `name: validate-test
+services:
+  api:
+    language: python
+    project: ../outside
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatalf("failed to write azure.yaml: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(originalDir) }()
+
+	_ = output.SetFormat("json")
+	defer func() { _ = output.SetFormat("default") }()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	runErr := NewValidateCommand().RunE(nil, nil)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if runErr == nil {
+		t.Error("validate error = nil, want error for a project outside the project directory")
+	}
+
+	var result ValidateResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output should be valid JSON: %v\n%s", err, buf.String())
+	}
+	if result.Valid || len(result.Findings) != 1 || result.Findings[0].Severity != service.SeverityError || result.Findings[0].Field != "services.api.project" {
+		t.Errorf("result = %+v, want a single project error", result)
+	}
+}
+
+func TestValidateMissingAzureYaml(t *testing.T) {
+	if _, err := validateAzureYaml(t.TempDir()); err == nil {
+		t.Error("validateAzureYaml() error = nil, want error when azure.yaml is missing")
+	}
+}
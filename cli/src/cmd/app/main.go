@@ -17,6 +17,9 @@ var (
 	debugMode      bool
 	structuredLogs bool
 	cwdFlag        string
+	configFlag     string
+	progressTo     string
+	quiet          bool
 )
 
 func main() {
@@ -32,6 +35,13 @@ func main() {
 				}
 			}
 
+			// Run from the directory of the azure.yaml selected with --config (relative to --cwd)
+			if configFlag != "" {
+				if err := commands.UseAzureYaml(configFlag); err != nil {
+					return err
+				}
+			}
+
 			// Set global output format and debug mode
 			if debugMode {
 				os.Setenv("AZD_APP_DEBUG", "true")
@@ -51,21 +61,34 @@ func main() {
 					"cwd", cwdFlag,
 				)
 				// Print build info in debug mode (before command output)
-				if !output.IsJSON() {
+				if !output.IsStructured() {
 					fmt.Fprintf(os.Stderr, "%s[DEBUG]%s Build: %s (built on %s, commit: %.8s)\n",
 						output.Dim, output.Reset, commands.Version, commands.BuildTime, commands.Commit)
 				}
 			}
 
-			return output.SetFormat(outputFormat)
+			if err := output.SetProgressDestination(progressTo); err != nil {
+				return err
+			}
+
+			if err := output.SetFormat(outputFormat); err != nil {
+				return err
+			}
+
+			// Quiet mode is implied when JSON results are piped to another program
+			output.SetQuiet(quiet || (output.IsJSON() && !output.IsStdoutTerminal()))
+			return nil
 		},
 	}
 
 	// Add global flags
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "default", "Output format (default, json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "default", "Output format (default, json, yaml, table)")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&structuredLogs, "structured-logs", false, "Enable structured JSON logging to stderr")
 	rootCmd.PersistentFlags().StringVarP(&cwdFlag, "cwd", "C", "", "Sets the current working directory")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Use this azure.yaml (or the azure.yaml in this directory) instead of searching for the nearest one")
+	rootCmd.PersistentFlags().StringVar(&progressTo, "progress-to", output.ProgressToStderr, "Where to write headers and progress output (stderr, stdout)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress headers and progress output (implied by --output json when stdout is not a terminal)")
 
 	// Register all commands
 	rootCmd.AddCommand(
@@ -84,6 +107,8 @@ func main() {
 		commands.NewStopCommand(),
 		commands.NewRestartCommand(),
 		commands.NewAddCommand(),
+		commands.NewListServicesCommand(),
+		commands.NewValidateCommand(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -86,6 +86,7 @@ const (
 	StatusNotRunning = "not-running"
 	StatusError      = "error"
 	StatusStopping   = "stopping"
+	StatusRestarting = "restarting"
 )
 
 // Service health values
@@ -1,12 +1,15 @@
 package dashboard
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/registry"
 )
@@ -544,3 +547,75 @@ func TestBroadcastUpdate_NilServices(t *testing.T) {
 	// Should not panic with nil services
 	srv.BroadcastUpdate(nil)
 }
+
+func TestHandlePing_RestartTimeout(t *testing.T) {
+	srv := GetServer(t.TempDir())
+	ping := func() map[string]string {
+		t.Helper()
+		w := httptest.NewRecorder()
+		srv.handlePing(w, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+		var response map[string]string
+		if err := json.NewDecoder(w.Result().Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	if response := ping(); response["status"] != "ok" || response["restartTimeout"] != "" {
+		t.Errorf("ping = %v, want status ok without a restart timeout", response)
+	}
+
+	srv.SetServiceRestarter(func(context.Context, string) (*ServiceRestartResult, error) {
+		return nil, ErrServiceNotSupervised
+	}, 5*time.Minute)
+	if response := ping(); response["restartTimeout"] != "5m0s" {
+		t.Errorf("restartTimeout = %q, want 5m0s", response["restartTimeout"])
+	}
+}
+
+func TestRestartWithServiceRestarter(t *testing.T) {
+	tempDir := t.TempDir()
+	srv := GetServer(tempDir)
+	reg := registry.GetRegistry(tempDir)
+	if err := reg.Register(&registry.ServiceRegistryEntry{Name: "api", ProjectDir: tempDir, Status: "running"}); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+
+	restart := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/services/restart?service=api", nil)
+		newServiceOperationHandler(srv, opRestart).Handle(w, req)
+		var response map[string]interface{}
+		if err := json.NewDecoder(w.Result().Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	t.Run("responds with the new status and startup duration", func(t *testing.T) {
+		srv.SetServiceRestarter(func(_ context.Context, name string) (*ServiceRestartResult, error) {
+			return &ServiceRestartResult{Status: "running", StartupDuration: 1500 * time.Millisecond}, nil
+		}, time.Minute)
+		w := httptest.NewRecorder()
+		response := restart(w)
+
+		if w.Code != http.StatusOK || response["success"] != true {
+			t.Fatalf("restart = %d %v, want success", w.Code, response)
+		}
+		if response["status"] != "running" || response["startupDuration"] != "1.5s" {
+			t.Errorf("status = %v, startupDuration = %v, want running, 1.5s", response["status"], response["startupDuration"])
+		}
+	})
+
+	t.Run("reports a failed restart", func(t *testing.T) {
+		srv.SetServiceRestarter(func(context.Context, string) (*ServiceRestartResult, error) {
+			return nil, errors.New("api restarted but did not become ready")
+		}, time.Minute)
+		w := httptest.NewRecorder()
+		response := restart(w)
+
+		if w.Code != http.StatusInternalServerError || response["error"] != "api restarted but did not become ready" {
+			t.Errorf("restart = %d %v, want the restart error", w.Code, response)
+		}
+	})
+}
@@ -56,6 +56,9 @@ type Server struct {
 	started      bool       // Track if server was successfully started
 	startedMu    sync.Mutex // Protect started flag
 	configClient azdconfig.ConfigClient
+
+	restartService ServiceRestartFunc // Restarts services run by azd app run; see SetServiceRestarter
+	restartTimeout time.Duration      // Longest a restart through restartService may take, reported by /api/ping
 }
 
 // GetServer returns the dashboard server instance for the specified project.
@@ -174,9 +177,11 @@ func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status":"ok"}`))
+	response := map[string]string{"status": "ok"}
+	if s.restartTimeout > 0 {
+		response["restartTimeout"] = s.restartTimeout.String()
+	}
+	_ = writeJSON(w, response)
 }
 
 // handleGetEnvironment returns environment information for Codespace detection.
@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -26,6 +27,29 @@ const (
 	opRestart
 )
 
+// ErrServiceNotSupervised is returned by a ServiceRestartFunc for a service it doesn't run,
+// such as a container; the dashboard then restarts it from the registry instead.
+var ErrServiceNotSupervised = errors.New("service is not supervised by azd app run")
+
+// ServiceRestartResult is the outcome of a restart by a ServiceRestartFunc.
+type ServiceRestartResult struct {
+	Status          string        // Registry status of the restarted service
+	StartupDuration time.Duration // Time from the restart until the service passed its health check
+}
+
+// ServiceRestartFunc restarts a service with the command and environment it was started with
+// and waits for it to become healthy.
+type ServiceRestartFunc func(ctx context.Context, serviceName string) (*ServiceRestartResult, error)
+
+// SetServiceRestarter makes single-service restarts go through restart, so a service run by
+// azd app run is restarted by its supervisor, which keeps monitoring the new process. timeout
+// is the longest a restart may take; /api/ping reports it so clients know how long to wait.
+// It must be called before Start.
+func (s *Server) SetServiceRestarter(restart ServiceRestartFunc, timeout time.Duration) {
+	s.restartService = restart
+	s.restartTimeout = timeout
+}
+
 // serviceOperationHandler handles start/stop/restart operations with shared logic.
 type serviceOperationHandler struct {
 	server    *Server
@@ -120,7 +144,7 @@ func (h *serviceOperationHandler) handleSingleOperation(w http.ResponseWriter, r
 	opType := h.toServiceOperationType()
 
 	result := opMgr.ExecuteOperation(ctx, serviceName, opType, func(ctx context.Context) error {
-		return h.executeServiceOperation(w, entry, serviceName, reg)
+		return h.executeServiceOperation(ctx, w, entry, serviceName, reg)
 	})
 
 	if result.Error != nil {
@@ -129,7 +153,14 @@ func (h *serviceOperationHandler) handleSingleOperation(w http.ResponseWriter, r
 }
 
 // executeServiceOperation performs the actual service operation.
-func (h *serviceOperationHandler) executeServiceOperation(w http.ResponseWriter, entry *registry.ServiceRegistryEntry, serviceName string, reg *registry.ServiceRegistry) error {
+func (h *serviceOperationHandler) executeServiceOperation(ctx context.Context, w http.ResponseWriter, entry *registry.ServiceRegistryEntry, serviceName string, reg *registry.ServiceRegistry) error {
+	if h.operation == opRestart && h.server.restartService != nil {
+		restarted, err := h.restartSupervisedService(ctx, w, serviceName)
+		if restarted || err != nil {
+			return err
+		}
+	}
+
 	// For restart, stop the service first and wait for process exit
 	if h.operation == opRestart && entry.Status != constants.StatusStopped && entry.Status != constants.StatusNotRunning {
 		if err := h.stopService(entry, serviceName); err != nil {
@@ -148,6 +179,36 @@ func (h *serviceOperationHandler) executeServiceOperation(w http.ResponseWriter,
 	return nil
 }
 
+// restartSupervisedService restarts a service through the server's ServiceRestartFunc and
+// responds with its new status and startup duration. It returns false, without responding,
+// for a service the restart func doesn't supervise.
+func (h *serviceOperationHandler) restartSupervisedService(ctx context.Context, w http.ResponseWriter, serviceName string) (bool, error) {
+	result, err := h.server.restartService(ctx, serviceName)
+	if errors.Is(err, ErrServiceNotSupervised) {
+		return false, nil
+	}
+	if broadcastErr := h.server.BroadcastServiceUpdate(h.server.projectDir); broadcastErr != nil {
+		log.Printf("Warning: failed to broadcast update: %v", broadcastErr)
+	}
+	if err != nil {
+		return true, err
+	}
+
+	response := map[string]interface{}{
+		"success":         true,
+		"message":         fmt.Sprintf("Service '%s' restarted successfully", serviceName),
+		"status":          result.Status,
+		"startupDuration": result.StartupDuration.Round(time.Millisecond).String(),
+	}
+	if entry, exists := registry.GetRegistry(h.server.projectDir).GetService(serviceName); exists {
+		response["service"] = entry
+	}
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+	return true, nil
+}
+
 // handleBulkOperation handles operations on all applicable services.
 func (h *serviceOperationHandler) handleBulkOperation(w http.ResponseWriter, r *http.Request) {
 	reg := registry.GetRegistry(h.server.projectDir)
@@ -307,7 +368,13 @@ func (h *serviceOperationHandler) performStartBulk(entry *registry.ServiceRegist
 	// Start the service - use container runner for container services
 	var process *service.ServiceProcess
 	if runtime.Type == service.ServiceTypeContainer {
-		process, err = service.StartContainerService(runtime, h.server.projectDir, true) // restartContainers=true for restart/start ops
+		// Rebuild the image of a Dockerfile service if its build context changed
+		_, err = service.BuildContainerImage(runtime, h.server.projectDir, false, func(line string) {
+			log.Printf("[%s] %s", serviceName, line)
+		})
+		if err == nil {
+			process, err = service.StartContainerService(runtime, h.server.projectDir, true) // restartContainers=true for restart/start ops
+		}
 		if err == nil {
 			// Start container log collection
 			if logErr := service.StartContainerLogCollection(process, h.server.projectDir); logErr != nil {
@@ -357,6 +424,7 @@ func (h *serviceOperationHandler) performStartBulk(entry *registry.ServiceRegist
 		Name:        serviceName,
 		ProjectDir:  entry.ProjectDir,
 		Port:        runtime.Port,
+		Ports:       runtime.RegistryPorts(),
 		URL:         entry.URL,
 		AzureURL:    entry.AzureURL,
 		Language:    runtime.Language,
@@ -513,7 +581,13 @@ func (h *serviceOperationHandler) performStart(w http.ResponseWriter, entry *reg
 	// Start the service - use container runner for container services
 	var process *service.ServiceProcess
 	if runtime.Type == service.ServiceTypeContainer {
-		process, err = service.StartContainerService(runtime, h.server.projectDir, true) // restartContainers=true for restart/start ops
+		// Rebuild the image of a Dockerfile service if its build context changed
+		_, err = service.BuildContainerImage(runtime, h.server.projectDir, false, func(line string) {
+			log.Printf("[%s] %s", serviceName, line)
+		})
+		if err == nil {
+			process, err = service.StartContainerService(runtime, h.server.projectDir, true) // restartContainers=true for restart/start ops
+		}
 		if err == nil {
 			// Start container log collection
 			if logErr := service.StartContainerLogCollection(process, h.server.projectDir); logErr != nil {
@@ -572,6 +646,7 @@ func (h *serviceOperationHandler) performStart(w http.ResponseWriter, entry *reg
 		Name:        serviceName,
 		ProjectDir:  entry.ProjectDir,
 		Port:        runtime.Port,
+		Ports:       runtime.RegistryPorts(),
 		URL:         entry.URL,
 		AzureURL:    entry.AzureURL,
 		Language:    runtime.Language,
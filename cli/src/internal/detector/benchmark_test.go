@@ -1,6 +1,7 @@
 package detector
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -109,3 +110,71 @@ func BenchmarkFindAzureYaml(b *testing.B) {
 		}
 	}
 }
+
+// createMonorepo creates a monorepo fixture with the given number of packages, each with
+// a Node.js, a Python and a .NET project plus source directories and node_modules.
+func createMonorepo(b *testing.B, packages int) string {
+	b.Helper()
+	root := b.TempDir()
+
+	files := map[string]string{
+		"package.json":                          `{"name":"root","workspaces":["packages/*"]}`,
+		"pnpm-lock.yaml":                        "",
+		"services/shared/requirements.txt":      "requests",
+		"services/shared/venv/pyvenv.cfg":       "",
+		"services/shared/venv/lib/package.json": `{}`,
+	}
+	for i := 0; i < packages; i++ {
+		pkg := fmt.Sprintf("packages/pkg-%03d", i)
+		files[pkg+"/package.json"] = `{"name":"pkg"}`
+		files[pkg+"/src/components/index.ts"] = ""
+		files[pkg+"/src/lib/util.ts"] = ""
+		files[pkg+"/node_modules/dep/package.json"] = `{}`
+		files[pkg+"/api/pyproject.toml"] = "[tool.poetry]"
+		files[pkg+"/api/app/main.py"] = ""
+		files[pkg+"/worker/Worker.csproj"] = "<Project />"
+		files[pkg+"/worker/bin/Debug/Worker.dll"] = ""
+	}
+
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return root
+}
+
+// BenchmarkFindAllProjects compares detecting every project type with one walk on the
+// bounded worker pool against the previous approach of three sequential single-threaded walks.
+func BenchmarkFindAllProjects(b *testing.B) {
+	root := createMonorepo(b, 200)
+
+	b.Run("serial", func(b *testing.B) {
+		defer func(workers int) { walkWorkers = workers }(walkWorkers)
+		walkWorkers = 1
+
+		for i := 0; i < b.N; i++ {
+			if _, err := FindNodeProjects(root); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := FindPythonProjects(root); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := FindDotnetProjects(root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := FindAllProjects(root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
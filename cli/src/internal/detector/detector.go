@@ -14,6 +14,8 @@ const (
 	skipDirGit         = ".git"
 	skipDirNodeModules = "node_modules"
 	skipDirObj         = "obj"
+	skipDirTarget      = "target"
+	skipDirVenv        = ".venv"
 )
 
 // PackageManagerInfo contains the detected package manager and its detection source.
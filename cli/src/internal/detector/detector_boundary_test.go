@@ -263,7 +263,7 @@ func TestFindAppHostRespectsBoundary(t *testing.T) {
 		t.Fatalf("Failed to create parent apphost dir: %v", err)
 	}
 	parentCsproj := filepath.Join(parentAppHostDir, "AppHost.csproj")
-	if err := os.WriteFile(parentCsproj, []byte(`<Project Sdk="Microsoft.NET.Sdk"></Project>`), 0o644); err != nil {
+	if err := os.WriteFile(parentCsproj, []byte(`<Project Sdk="Aspire.AppHost.Sdk/13.0.0"></Project>`), 0o644); err != nil {
 		t.Fatalf("Failed to create parent csproj: %v", err)
 	}
 	parentProgram := filepath.Join(parentAppHostDir, "Program.cs")
@@ -296,7 +296,7 @@ services:
 		t.Fatalf("Failed to create apphost dir: %v", err)
 	}
 	appHostCsproj := filepath.Join(appHostDir, "AppHost.csproj")
-	if err := os.WriteFile(appHostCsproj, []byte(`<Project Sdk="Microsoft.NET.Sdk"></Project>`), 0o644); err != nil {
+	if err := os.WriteFile(appHostCsproj, []byte(`<Project Sdk="Aspire.AppHost.Sdk/13.0.0"></Project>`), 0o644); err != nil {
 		t.Fatalf("Failed to create apphost csproj: %v", err)
 	}
 	appHostProgram := filepath.Join(appHostDir, "Program.cs")
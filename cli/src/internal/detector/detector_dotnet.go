@@ -12,67 +12,24 @@ import (
 // FindDotnetProjects searches for .csproj and .sln files.
 // Only searches within rootDir and does not traverse outside it.
 func FindDotnetProjects(rootDir string) ([]types.DotnetProject, error) {
-	var dotnetProjects []types.DotnetProject
-	seen := make(map[string]bool)
+	return FindDotnetProjectsWithOptions(rootDir, SearchOptions{})
+}
 
+// FindDotnetProjectsWithOptions is FindDotnetProjects limited by opts.
+func FindDotnetProjectsWithOptions(rootDir string, opts SearchOptions) ([]types.DotnetProject, error) {
 	// Clean the root directory path
 	rootDir, err := filepath.Abs(rootDir)
 	if err != nil {
-		return dotnetProjects, err
+		return nil, err
 	}
 
-	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-
-		// Ensure we don't traverse outside rootDir
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			return nil
-		}
-		relPath, err := filepath.Rel(rootDir, absPath)
-		if err != nil || strings.HasPrefix(relPath, "..") {
-			return filepath.SkipDir
-		}
-
-		if info.IsDir() {
-			name := info.Name()
-			if name == skipDirNodeModules || name == skipDirGit || name == skipDirBin || name == skipDirObj {
-				return filepath.SkipDir
-			}
-		}
-
-		if !info.IsDir() {
-			ext := filepath.Ext(info.Name())
-			if ext == ".csproj" || ext == ".sln" {
-				// For .csproj, use the directory; for .sln, use the file itself
-				if ext == ".sln" {
-					if !seen[path] {
-						dotnetProjects = append(dotnetProjects, types.DotnetProject{
-							Path: path,
-						})
-						seen[path] = true
-					}
-				} else {
-					dir := filepath.Dir(path)
-					if !seen[dir] {
-						dotnetProjects = append(dotnetProjects, types.DotnetProject{
-							Path: path,
-						})
-						seen[dir] = true
-					}
-				}
-			}
-		}
-
-		return nil
-	})
-
-	return dotnetProjects, err
+	collector := newDotnetCollector()
+	walkProjectTree(rootDir, opts, isCommonSkipDir, collector.visit)
+	return collector.projects(), nil
 }
 
-// FindAppHost searches for AppHost.cs recursively.
+// FindAppHost searches for an Aspire AppHost project recursively: a .csproj that references
+// Aspire.Hosting.AppHost (or uses the Aspire.AppHost.Sdk). The first one in walk order is returned.
 // Only searches within rootDir and does not traverse outside it.
 func FindAppHost(rootDir string) (*types.AspireProject, error) {
 	var aspireProject *types.AspireProject
@@ -108,20 +65,12 @@ func FindAppHost(rootDir string) (*types.AspireProject, error) {
 			}
 		}
 
-		if !info.IsDir() && (info.Name() == "AppHost.cs" || info.Name() == "Program.cs") {
-			// Check if it's in a project directory (has .csproj)
-			dir := filepath.Dir(path)
-			matches, err := filepath.Glob(filepath.Join(dir, "*.csproj"))
-			if err != nil {
-				return nil // Skip on error
-			}
-			if len(matches) > 0 {
-				aspireProject = &types.AspireProject{
-					Dir:         dir,
-					ProjectFile: matches[0],
-				}
-				return filepath.SkipAll // Found it, stop walking
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".csproj") && IsAppHostProject(path) {
+			aspireProject = &types.AspireProject{
+				Dir:         filepath.Dir(path),
+				ProjectFile: path,
 			}
+			return filepath.SkipAll // Found it, stop walking
 		}
 
 		return nil
@@ -129,3 +78,14 @@ func FindAppHost(rootDir string) (*types.AspireProject, error) {
 
 	return aspireProject, err
 }
+
+// IsAppHostProject reports whether the .csproj at path is an Aspire AppHost, i.e. it references
+// the Aspire.Hosting.AppHost package or uses the Aspire.AppHost.Sdk.
+func IsAppHostProject(path string) bool {
+	data, err := os.ReadFile(path) // #nosec G304 -- Path is within project boundaries
+	if err != nil {
+		return false
+	}
+	content := string(data)
+	return strings.Contains(content, "Aspire.Hosting.AppHost") || strings.Contains(content, "Aspire.AppHost.Sdk")
+}
@@ -0,0 +1,81 @@
+package detector
+
+import (
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the file in a project search root that lists directories project
+// detection skips, one glob pattern per line (as in path.Match). Blank lines and lines
+// starting with # are ignored. A pattern without a slash matches a directory name at any
+// depth, such as examples or vendor*; a pattern with a slash matches the directory's path
+// relative to the root, such as samples/legacy or /tools.
+const IgnoreFileName = ".azdappignore"
+
+// ignorePattern is a pattern of an ignore file.
+type ignorePattern struct {
+	pattern  string
+	anchored bool // Matches the path relative to the root rather than the directory name
+}
+
+// ignorePatterns are the patterns of an ignore file.
+type ignorePatterns []ignorePattern
+
+// loadIgnoreFile reads the ignore file in rootDir. A missing or unreadable file ignores
+// nothing; invalid patterns are skipped with a warning.
+func loadIgnoreFile(rootDir string) ignorePatterns {
+	// #nosec G304 -- The ignore file name is fixed and rootDir is the detection root
+	data, err := os.ReadFile(filepath.Join(rootDir, IgnoreFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Debug("skipping ignore file due to error", "path", filepath.Join(rootDir, IgnoreFileName), "error", err)
+		}
+		return nil
+	}
+
+	var patterns ignorePatterns
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimSuffix(line, "/")
+		pattern := ignorePattern{
+			pattern:  strings.TrimPrefix(line, "/"),
+			anchored: strings.Contains(line, "/"),
+		}
+		if _, err := path.Match(pattern.pattern, ""); err != nil || pattern.pattern == "" {
+			slog.Warn("skipping invalid pattern in ignore file", "file", IgnoreFileName, "pattern", line)
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// matches reports whether the directory dir under rootDir is ignored.
+func (p ignorePatterns) matches(rootDir, dir string) bool {
+	if len(p) == 0 {
+		return false
+	}
+	relPath, err := filepath.Rel(rootDir, dir)
+	if err != nil {
+		return false
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	for _, pattern := range p {
+		target := path.Base(relPath)
+		if pattern.anchored {
+			target = relPath
+		}
+		if matched, _ := path.Match(pattern.pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -15,91 +15,20 @@ import (
 // Only searches within rootDir and does not traverse outside it.
 // Detects npm/yarn/pnpm workspace configurations and marks workspace relationships.
 func FindNodeProjects(rootDir string) ([]types.NodeProject, error) {
-	var nodeProjects []types.NodeProject
-	seen := make(map[string]bool)
+	return FindNodeProjectsWithOptions(rootDir, SearchOptions{})
+}
 
+// FindNodeProjectsWithOptions is FindNodeProjects limited by opts.
+func FindNodeProjectsWithOptions(rootDir string, opts SearchOptions) ([]types.NodeProject, error) {
 	// Clean the root directory path
 	rootDir, err := filepath.Abs(rootDir)
 	if err != nil {
-		return nodeProjects, err
-	}
-
-	// Track workspace root directories
-	workspaceRoots := make(map[string]bool)
-
-	// First pass: find all package.json files and identify workspace roots
-	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-
-		// Ensure we don't traverse outside rootDir
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			return nil
-		}
-		relPath, err := filepath.Rel(rootDir, absPath)
-		if err != nil || strings.HasPrefix(relPath, "..") {
-			return filepath.SkipDir
-		}
-
-		if info.IsDir() {
-			name := info.Name()
-			// Skip common directories
-			if name == skipDirNodeModules || name == skipDirGit || name == skipDirBin || name == skipDirObj {
-				return filepath.SkipDir
-			}
-		}
-
-		if !info.IsDir() && info.Name() == "package.json" {
-			dir := filepath.Dir(path)
-
-			if seen[dir] {
-				return nil
-			}
-
-			// Skip Logic Apps projects (they have package.json for npm scripts but aren't Node.js projects)
-			if isLogicAppsDirectory(dir) {
-				return nil
-			}
-
-			packageManager := DetectNodePackageManagerWithBoundary(dir, rootDir)
-			isWorkspaceRoot := HasNpmWorkspaces(dir)
-
-			nodeProjects = append(nodeProjects, types.NodeProject{
-				Dir:             dir,
-				PackageManager:  packageManager,
-				IsWorkspaceRoot: isWorkspaceRoot,
-			})
-			seen[dir] = true
-
-			// Track workspace roots
-			if isWorkspaceRoot {
-				workspaceRoots[dir] = true
-			}
-		}
-
-		return nil
-	})
-
-	// Second pass: identify workspace children and link them to their workspace root
-	for i := range nodeProjects {
-		if !nodeProjects[i].IsWorkspaceRoot {
-			// Check if this project is within a workspace root
-			projectDir := nodeProjects[i].Dir
-			for workspaceRoot := range workspaceRoots {
-				// Check if projectDir is within workspaceRoot
-				relPath, err := filepath.Rel(workspaceRoot, projectDir)
-				if err == nil && !strings.HasPrefix(relPath, "..") && relPath != "." {
-					// This project is a child of the workspace
-					nodeProjects[i].WorkspaceRoot = workspaceRoot
-					break
-				}
-			}
-		}
+		return nil, err
 	}
 
-	return nodeProjects, err
+	collector := newNodeCollector(rootDir)
+	walkProjectTree(rootDir, opts, isCommonSkipDir, collector.visit)
+	return collector.projects(), nil
 }
 
 // DetectNodePackageManager determines whether to use pnpm, yarn, or npm.
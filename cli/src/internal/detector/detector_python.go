@@ -1,7 +1,6 @@
 package detector
 
 import (
-	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,70 +23,28 @@ import (
 //
 // Detection Strategy:
 //   - Searches for requirements.txt, pyproject.toml, poetry.lock, or uv.lock
-//   - Skips common directories: node_modules, .git, bin, obj, venv, .venv, __pycache__
+//   - Skips common directories: node_modules, .git, bin, obj, target, venv, .venv, __pycache__
+//   - Skips directories listed in the root's .azdappignore file
 //   - Does not traverse outside rootDir (prevents directory traversal)
 //   - Package manager detection order: uv > poetry > pip
 func FindPythonProjects(rootDir string) ([]types.PythonProject, error) {
-	var pythonProjects []types.PythonProject
-	seen := make(map[string]bool)
+	return FindPythonProjectsWithOptions(rootDir, SearchOptions{})
+}
 
+// FindPythonProjectsWithOptions is FindPythonProjects limited by opts.
+func FindPythonProjectsWithOptions(rootDir string, opts SearchOptions) ([]types.PythonProject, error) {
 	// Clean the root directory path
 	rootDir, err := filepath.Abs(rootDir)
 	if err != nil {
-		return pythonProjects, err
+		return nil, err
 	}
 
-	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		// Standard error handling: log and skip problematic paths
-		// This prevents permission errors from terminating the search
-		if err != nil {
-			slog.Debug("skipping path due to error", "path", path, "error", err)
-			return nil // Skip errors but continue walking
-		}
-
-		// Ensure we don't traverse outside rootDir
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			return nil
-		}
-		relPath, err := filepath.Rel(rootDir, absPath)
-		if err != nil || strings.HasPrefix(relPath, "..") {
-			return filepath.SkipDir
-		}
-
-		// Skip common directories
-		if info.IsDir() {
-			name := info.Name()
-			if name == skipDirNodeModules || name == skipDirBin || name == skipDirObj || name == skipDirGit ||
-				name == "venv" || name == ".venv" || name == "__pycache__" || name == ".uv" {
-				return filepath.SkipDir
-			}
-		}
-
-		if !info.IsDir() {
-			dir := filepath.Dir(path)
-
-			// Skip if we've already found this directory
-			if seen[dir] {
-				return nil
-			}
-
-			// Look for Python project indicators
-			if info.Name() == "requirements.txt" || info.Name() == "pyproject.toml" ||
-				info.Name() == "poetry.lock" || info.Name() == "uv.lock" {
-				packageManager := DetectPythonPackageManager(dir)
-				pythonProjects = append(pythonProjects, types.PythonProject{
-					Dir:            dir,
-					PackageManager: packageManager,
-				})
-				seen[dir] = true
-			}
-		}
-
-		return nil
-	})
-
-	return pythonProjects, err
+	// Skip common directories plus virtual environments and caches
+	collector := newPythonCollector()
+	walkProjectTree(rootDir, opts, func(name string) bool {
+		return isCommonSkipDir(name) || isPythonEnvDir(name)
+	}, collector.visit)
+	return collector.projects(), nil
 }
 
 // DetectPythonPackageManager determines which package manager to use.
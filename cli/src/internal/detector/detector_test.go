@@ -355,12 +355,15 @@ func TestFindAppHost(t *testing.T) {
 	}
 	defer func() { _ = os.RemoveAll(tmpDir) }()
 
-	// Create test structure
+	// Create test structure; Api comes first in walk order but doesn't reference Aspire
+	appHostCsproj := `<Project Sdk="Microsoft.NET.Sdk"><ItemGroup><PackageReference Include="Aspire.Hosting.AppHost" Version="9.0.0" /></ItemGroup></Project>`
 	files := map[string]string{
+		"Api/Api.csproj":          `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+		"Api/Program.cs":          "// Not Aspire",
 		"AppHost/AppHost.cs":      "// Aspire AppHost",
-		"AppHost/AppHost.csproj":  "<Project></Project>",
+		"AppHost/AppHost.csproj":  appHostCsproj,
 		"OtherProject/Program.cs": "// Not Aspire",
-		"bin/AppHost.cs":          "// should be ignored",
+		"bin/AppHost.csproj":      appHostCsproj, // should be ignored
 	}
 
 	for path, content := range files {
@@ -388,8 +391,53 @@ func TestFindAppHost(t *testing.T) {
 		t.Errorf("FindAppHost() Dir = %q, want %q", result.Dir, expectedDir)
 	}
 
-	if result.ProjectFile == "" {
-		t.Error("FindAppHost() ProjectFile is empty, expected .csproj path")
+	if result.ProjectFile != filepath.Join(expectedDir, "AppHost.csproj") {
+		t.Errorf("FindAppHost() ProjectFile = %q, want AppHost.csproj", result.ProjectFile)
+	}
+}
+
+func TestFindAppHostNoAspire(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A .NET project with Program.cs is not an AppHost without the Aspire reference
+	if err := os.WriteFile(filepath.Join(tmpDir, "Api.csproj"), []byte(`<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`), 0600); err != nil {
+		t.Fatalf("failed to create csproj: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "Program.cs"), []byte("// Not Aspire"), 0600); err != nil {
+		t.Fatalf("failed to create Program.cs: %v", err)
+	}
+
+	result, err := FindAppHost(tmpDir)
+	if err != nil {
+		t.Fatalf("FindAppHost() error = %v", err)
+	}
+	if result != nil {
+		t.Errorf("FindAppHost() = %+v, want nil", result)
+	}
+}
+
+func TestIsAppHostProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"package reference", `<Project Sdk="Microsoft.NET.Sdk"><ItemGroup><PackageReference Include="Aspire.Hosting.AppHost" Version="9.0.0" /></ItemGroup></Project>`, true},
+		{"AppHost SDK", `<Project Sdk="Aspire.AppHost.Sdk/13.0.0"></Project>`, true},
+		{"web project", `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`, false},
+		{"service defaults", `<Project Sdk="Microsoft.NET.Sdk"><ItemGroup><PackageReference Include="Aspire.Hosting" Version="9.0.0" /></ItemGroup></Project>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "Project.csproj")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("failed to create csproj: %v", err)
+			}
+			if got := IsAppHostProject(path); got != tt.want {
+				t.Errorf("IsAppHostProject() = %v, want %v", got, tt.want)
+			}
+		})
 	}
 }
 
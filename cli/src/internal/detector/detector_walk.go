@@ -0,0 +1,343 @@
+package detector
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// walkWorkers bounds how many directories are read and classified concurrently while
+// detecting projects. A variable so tests and benchmarks can change it.
+var walkWorkers = max(4, runtime.NumCPU())
+
+// Projects holds every project found by FindAllProjects, per language.
+type Projects struct {
+	Node   []types.NodeProject
+	Python []types.PythonProject
+	Dotnet []types.DotnetProject
+}
+
+// SearchOptions limit which directories project detection searches. Directories listed in
+// the search root's .azdappignore file are skipped whatever the options.
+type SearchOptions struct {
+	// MaxDepth is how many directory levels below the search root are searched
+	// (1 searches the root and its immediate subdirectories); 0 means no limit
+	MaxDepth int
+}
+
+// FindAllProjects searches rootDir once for Node.js, Python and .NET projects, reading
+// directories with a bounded pool of workers. Each slice holds the same projects, in the
+// same order, as FindNodeProjects, FindPythonProjects and FindDotnetProjects return.
+func FindAllProjects(rootDir string) (*Projects, error) {
+	return FindAllProjectsWithOptions(rootDir, SearchOptions{})
+}
+
+// FindAllProjectsWithOptions is FindAllProjects limited by opts.
+func FindAllProjectsWithOptions(rootDir string, opts SearchOptions) (*Projects, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return &Projects{}, err
+	}
+
+	node := newNodeCollector(rootDir)
+	python := newPythonCollector()
+	dotnet := newDotnetCollector()
+
+	walkProjectTree(rootDir, opts, isCommonSkipDir, func(file walkFile) {
+		node.visit(file)
+		// Python projects inside virtual environments and caches are ignored, as FindPythonProjects skips them
+		if !file.inPythonEnv {
+			python.visit(file)
+		}
+		dotnet.visit(file)
+	})
+
+	return &Projects{
+		Node:   node.projects(),
+		Python: python.projects(),
+		Dotnet: dotnet.projects(),
+	}, nil
+}
+
+// FindAllAzureYaml returns every azure.yaml at or below rootDir in walk order, for monorepos
+// with several independent app groups. Directories skipped by project detection, and those
+// in rootDir's .azdappignore, are not searched.
+func FindAllAzureYaml(rootDir string) ([]string, error) {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var found []foundProject[string]
+	walkProjectTree(rootDir, SearchOptions{}, isCommonSkipDir, func(file walkFile) {
+		if file.name != "azure.yaml" {
+			return
+		}
+		mu.Lock()
+		found = append(found, foundProject[string]{path: file.path, project: file.path})
+		mu.Unlock()
+	})
+	return sortByWalkOrder(found), nil
+}
+
+// walkFile is a file found while walking a project tree.
+type walkFile struct {
+	path        string
+	name        string
+	dir         string
+	inPythonEnv bool // Under a Python virtual environment or cache directory
+}
+
+// isCommonSkipDir reports whether a directory is skipped by every project detector.
+func isCommonSkipDir(name string) bool {
+	switch name {
+	case skipDirNodeModules, skipDirGit, skipDirBin, skipDirObj, skipDirTarget, skipDirVenv:
+		return true
+	}
+	return false
+}
+
+// isPythonEnvDir reports whether a directory holds a Python virtual environment or cache.
+func isPythonEnvDir(name string) bool {
+	return name == "venv" || name == ".venv" || name == "__pycache__" || name == ".uv"
+}
+
+// walkProjectTree calls visit for every file under rootDir, skipping directories for which
+// skipDir returns true, directories matching rootDir's ignore file and directories deeper
+// than opts.MaxDepth. Up to walkWorkers directories are read at once, so visit is called
+// concurrently; files in the same directory are visited in name order by one goroutine.
+// Unreadable directories are skipped. Symlinks are not followed.
+func walkProjectTree(rootDir string, opts SearchOptions, skipDir func(name string) bool, visit func(file walkFile)) {
+	if skipDir(filepath.Base(rootDir)) {
+		return
+	}
+	ignore := loadIgnoreFile(rootDir)
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, walkWorkers)
+
+	var walk func(dir string, depth int, inPythonEnv bool)
+	walk = func(dir string, depth int, inPythonEnv bool) {
+		defer wg.Done()
+
+		slots <- struct{}{}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			<-slots
+			slog.Debug("skipping path due to error", "path", dir, "error", err)
+			return
+		}
+
+		var subdirs []os.DirEntry
+		for _, entry := range entries {
+			if entry.IsDir() {
+				if !skipDir(entry.Name()) {
+					subdirs = append(subdirs, entry)
+				}
+				continue
+			}
+			visit(walkFile{
+				path:        filepath.Join(dir, entry.Name()),
+				name:        entry.Name(),
+				dir:         dir,
+				inPythonEnv: inPythonEnv,
+			})
+		}
+		<-slots
+
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			return
+		}
+		for _, subdir := range subdirs {
+			subdirPath := filepath.Join(dir, subdir.Name())
+			if ignore.matches(rootDir, subdirPath) {
+				continue
+			}
+			wg.Add(1)
+			go walk(subdirPath, depth+1, inPythonEnv || isPythonEnvDir(subdir.Name()))
+		}
+	}
+
+	wg.Add(1)
+	walk(rootDir, 0, isPythonEnvDir(filepath.Base(rootDir)))
+	wg.Wait()
+}
+
+// foundProject is a project recorded by a collector with the path of the file that identified it.
+type foundProject[T any] struct {
+	path    string
+	project T
+}
+
+// sortByWalkOrder returns the projects in the order a depth-first walk visiting directory
+// entries by name (filepath.Walk) finds their files, so results don't depend on concurrency.
+func sortByWalkOrder[T any](found []foundProject[T]) []T {
+	if len(found) == 0 {
+		return nil
+	}
+
+	// Ordering by path with the separator below every other character compares paths
+	// component by component, as the walk does
+	walkKey := func(path string) string {
+		return strings.ReplaceAll(path, string(filepath.Separator), "\x00")
+	}
+	slices.SortFunc(found, func(a, b foundProject[T]) int {
+		return strings.Compare(walkKey(a.path), walkKey(b.path))
+	})
+
+	projects := make([]T, len(found))
+	for i, f := range found {
+		projects[i] = f.project
+	}
+	return projects
+}
+
+// nodeCollector records Node.js projects (package.json files) found during a walk.
+type nodeCollector struct {
+	rootDir string
+	mu      sync.Mutex
+	found   []foundProject[types.NodeProject]
+}
+
+func newNodeCollector(rootDir string) *nodeCollector {
+	return &nodeCollector{rootDir: rootDir}
+}
+
+func (c *nodeCollector) visit(file walkFile) {
+	if file.name != "package.json" {
+		return
+	}
+
+	// Skip Logic Apps projects (they have package.json for npm scripts but aren't Node.js projects)
+	if isLogicAppsDirectory(file.dir) {
+		return
+	}
+
+	project := types.NodeProject{
+		Dir:             file.dir,
+		PackageManager:  DetectNodePackageManagerWithBoundary(file.dir, c.rootDir),
+		IsWorkspaceRoot: HasNpmWorkspaces(file.dir),
+	}
+
+	c.mu.Lock()
+	c.found = append(c.found, foundProject[types.NodeProject]{path: file.path, project: project})
+	c.mu.Unlock()
+}
+
+// projects returns the Node.js projects in walk order, with workspace children linked
+// to their workspace root.
+func (c *nodeCollector) projects() []types.NodeProject {
+	nodeProjects := sortByWalkOrder(c.found)
+
+	// Track workspace root directories
+	workspaceRoots := make(map[string]bool)
+	for _, project := range nodeProjects {
+		if project.IsWorkspaceRoot {
+			workspaceRoots[project.Dir] = true
+		}
+	}
+
+	// Identify workspace children and link them to the nearest enclosing workspace root
+	for i := range nodeProjects {
+		if nodeProjects[i].IsWorkspaceRoot {
+			continue
+		}
+		for dir := filepath.Dir(nodeProjects[i].Dir); ; dir = filepath.Dir(dir) {
+			if workspaceRoots[dir] {
+				nodeProjects[i].WorkspaceRoot = dir
+				break
+			}
+			if dir == c.rootDir || dir == filepath.Dir(dir) {
+				break
+			}
+		}
+	}
+
+	return nodeProjects
+}
+
+// pythonCollector records Python projects found during a walk, one per directory.
+type pythonCollector struct {
+	mu    sync.Mutex
+	seen  map[string]bool
+	found []foundProject[types.PythonProject]
+}
+
+func newPythonCollector() *pythonCollector {
+	return &pythonCollector{seen: make(map[string]bool)}
+}
+
+func (c *pythonCollector) visit(file walkFile) {
+	// Look for Python project indicators
+	if file.name != "requirements.txt" && file.name != "pyproject.toml" &&
+		file.name != "poetry.lock" && file.name != "uv.lock" {
+		return
+	}
+
+	// A directory's files are visited in name order by one goroutine, so the first
+	// indicator claims the directory
+	c.mu.Lock()
+	if c.seen[file.dir] {
+		c.mu.Unlock()
+		return
+	}
+	c.seen[file.dir] = true
+	c.mu.Unlock()
+
+	project := types.PythonProject{
+		Dir:            file.dir,
+		PackageManager: DetectPythonPackageManager(file.dir),
+	}
+
+	c.mu.Lock()
+	c.found = append(c.found, foundProject[types.PythonProject]{path: file.path, project: project})
+	c.mu.Unlock()
+}
+
+func (c *pythonCollector) projects() []types.PythonProject {
+	return sortByWalkOrder(c.found)
+}
+
+// dotnetCollector records .NET projects found during a walk: each .sln file, and the
+// first .csproj file in each directory.
+type dotnetCollector struct {
+	mu    sync.Mutex
+	seen  map[string]bool
+	found []foundProject[types.DotnetProject]
+}
+
+func newDotnetCollector() *dotnetCollector {
+	return &dotnetCollector{seen: make(map[string]bool)}
+}
+
+func (c *dotnetCollector) visit(file walkFile) {
+	// For .csproj, use the directory; for .sln, use the file itself
+	var key string
+	switch filepath.Ext(file.name) {
+	case ".sln":
+		key = file.path
+	case ".csproj":
+		key = file.dir
+	default:
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.found = append(c.found, foundProject[types.DotnetProject]{path: file.path, project: types.DotnetProject{Path: file.path}})
+}
+
+func (c *dotnetCollector) projects() []types.DotnetProject {
+	return sortByWalkOrder(c.found)
+}
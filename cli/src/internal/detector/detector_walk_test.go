@@ -0,0 +1,229 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// writeTree creates files (slash-separated paths relative to root) with the given contents.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindAllProjects(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"package.json":                         `{"workspaces":["apps/*"]}`,
+		"apps/web/package.json":                `{}`,
+		"apps/tools/package.json":              `{"workspaces":["cli"]}`,
+		"apps/tools/cli/package.json":          `{}`,
+		"apps/web/node_modules/x/package.json": `{}`,
+		"svc/pyproject.toml":                   "[tool.poetry]",
+		"svc/requirements.txt":                 "",
+		"svc/api/requirements.txt":             "",
+		"svc/venv/lib/requirements.txt":        "",
+		"svc/venv/lib/package.json":            `{}`,
+		"svc/.venv/lib/package.json":           `{}`,
+		"java/target/classes/package.json":     `{}`,
+		"dotnet/App.sln":                       "",
+		"dotnet/api/B.csproj":                  "",
+		"dotnet/api/A.csproj":                  "",
+		"dotnet/api/bin/Debug/C.csproj":        "",
+	})
+	rel := func(path string) string { return filepath.Join(root, filepath.FromSlash(path)) }
+
+	wantNode := []types.NodeProject{
+		// Nested workspace members link to the nearest workspace root
+		{Dir: rel("apps/tools/cli"), PackageManager: "npm", WorkspaceRoot: rel("apps/tools")},
+		{Dir: rel("apps/tools"), PackageManager: "npm", IsWorkspaceRoot: true},
+		{Dir: rel("apps/web"), PackageManager: "npm", WorkspaceRoot: root},
+		{Dir: root, PackageManager: "npm", IsWorkspaceRoot: true},
+		{Dir: rel("svc/venv/lib"), PackageManager: "npm", WorkspaceRoot: root},
+	}
+	wantPython := []types.PythonProject{
+		// svc/api/requirements.txt sorts before svc/pyproject.toml, as in filepath.Walk
+		{Dir: rel("svc/api"), PackageManager: "pip"},
+		{Dir: rel("svc"), PackageManager: "poetry"},
+	}
+	wantDotnet := []types.DotnetProject{
+		{Path: rel("dotnet/App.sln")},
+		{Path: rel("dotnet/api/A.csproj")},
+	}
+
+	defer func(workers int) { walkWorkers = workers }(walkWorkers)
+	for _, workers := range []int{1, 8} {
+		walkWorkers = workers
+
+		projects, err := FindAllProjects(root)
+		if err != nil {
+			t.Fatalf("FindAllProjects() error = %v", err)
+		}
+		if !reflect.DeepEqual(projects.Node, wantNode) {
+			t.Errorf("workers=%d: Node = %+v, want %+v", workers, projects.Node, wantNode)
+		}
+		if !reflect.DeepEqual(projects.Python, wantPython) {
+			t.Errorf("workers=%d: Python = %+v, want %+v", workers, projects.Python, wantPython)
+		}
+		if !reflect.DeepEqual(projects.Dotnet, wantDotnet) {
+			t.Errorf("workers=%d: Dotnet = %+v, want %+v", workers, projects.Dotnet, wantDotnet)
+		}
+
+		// The per-language finders return the same projects
+		nodeProjects, _ := FindNodeProjects(root)
+		pythonProjects, _ := FindPythonProjects(root)
+		dotnetProjects, _ := FindDotnetProjects(root)
+		if !reflect.DeepEqual(nodeProjects, projects.Node) || !reflect.DeepEqual(pythonProjects, projects.Python) || !reflect.DeepEqual(dotnetProjects, projects.Dotnet) {
+			t.Errorf("workers=%d: per-language finders differ from FindAllProjects", workers)
+		}
+	}
+}
+
+func TestFindAllProjectsWithOptions(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"package.json":                        `{}`,
+		"api/requirements.txt":                "",
+		"apps/web/package.json":               `{}`,
+		"apps/web/examples/demo/package.json": `{}`,
+		"examples/basic/requirements.txt":     "",
+		"samples/legacy/App.csproj":           "",
+		"samples/current/App.csproj":          "",
+		"tools/samples/legacy/Tool.csproj":    "",
+		"vendor-lib/package.json":             `{}`,
+		"deep/a/b/c/package.json":             `{}`,
+		IgnoreFileName:                        "# Example projects\nexamples/\n/samples/legacy\nvendor*\n[invalid\n",
+	})
+	rel := func(path string) string { return filepath.Join(root, filepath.FromSlash(path)) }
+
+	tests := []struct {
+		name       string
+		opts       SearchOptions
+		wantNode   []string
+		wantPython []string
+		wantDotnet []string
+	}{
+		{
+			name:       "ignore file only",
+			wantNode:   []string{"apps/web", "deep/a/b/c", ""},
+			wantPython: []string{"api"},
+			wantDotnet: []string{"samples/current/App.csproj", "tools/samples/legacy/Tool.csproj"},
+		},
+		{
+			name:       "max depth 1",
+			opts:       SearchOptions{MaxDepth: 1},
+			wantNode:   []string{""},
+			wantPython: []string{"api"},
+		},
+		{
+			name:       "max depth 2",
+			opts:       SearchOptions{MaxDepth: 2},
+			wantNode:   []string{"apps/web", ""},
+			wantPython: []string{"api"},
+			wantDotnet: []string{"samples/current/App.csproj"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, err := FindAllProjectsWithOptions(root, tt.opts)
+			if err != nil {
+				t.Fatalf("FindAllProjectsWithOptions() error = %v", err)
+			}
+
+			var node, python, dotnet []string
+			for _, p := range projects.Node {
+				node = append(node, p.Dir)
+			}
+			for _, p := range projects.Python {
+				python = append(python, p.Dir)
+			}
+			for _, p := range projects.Dotnet {
+				dotnet = append(dotnet, p.Path)
+			}
+			paths := func(relPaths []string) []string {
+				var abs []string
+				for _, p := range relPaths {
+					abs = append(abs, rel(p))
+				}
+				return abs
+			}
+			if !reflect.DeepEqual(node, paths(tt.wantNode)) {
+				t.Errorf("Node = %v, want %v", node, paths(tt.wantNode))
+			}
+			if !reflect.DeepEqual(python, paths(tt.wantPython)) {
+				t.Errorf("Python = %v, want %v", python, paths(tt.wantPython))
+			}
+			if !reflect.DeepEqual(dotnet, paths(tt.wantDotnet)) {
+				t.Errorf("Dotnet = %v, want %v", dotnet, paths(tt.wantDotnet))
+			}
+		})
+	}
+}
+
+func TestFindAllProjects_EmptyAndMissing(t *testing.T) {
+	projects, err := FindAllProjects(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("FindAllProjects() error = %v", err)
+	}
+	if projects.Node != nil || projects.Python != nil || projects.Dotnet != nil {
+		t.Errorf("FindAllProjects() on a missing directory = %+v, want no projects", projects)
+	}
+}
+
+func TestFindAllAzureYaml(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"azure.yaml":                           "name: root",
+		"apps/web/azure.yaml":                  "name: web",
+		"apps/api/azure.yaml":                  "name: api",
+		"apps/api/src/main.py":                 "",
+		"apps/web/node_modules/pkg/azure.yaml": "name: dependency",
+		"legacy/azure.yaml":                    "name: legacy",
+		IgnoreFileName:                         "legacy\n",
+	})
+
+	got, err := FindAllAzureYaml(root)
+	if err != nil {
+		t.Fatalf("FindAllAzureYaml() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "apps", "api", "azure.yaml"),
+		filepath.Join(root, "apps", "web", "azure.yaml"),
+		filepath.Join(root, "azure.yaml"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAllAzureYaml() = %v, want %v", got, want)
+	}
+
+	got, err = FindAllAzureYaml(filepath.Join(root, "apps", "api", "src"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("FindAllAzureYaml() below every azure.yaml = %v, %v, want none", got, err)
+	}
+}
+
+func TestSortByWalkOrder(t *testing.T) {
+	paths := []string{"a.txt", "a/z/f", "a-b/f", "a/f", "b"}
+	found := make([]foundProject[string], len(paths))
+	for i, path := range paths {
+		found[i] = foundProject[string]{path: filepath.FromSlash(path), project: path}
+	}
+
+	// Entries in a directory are visited by name, and a directory's contents before the next entry
+	want := []string{"a/f", "a/z/f", "a-b/f", "a.txt", "b"}
+	if got := sortByWalkOrder(found); !reflect.DeepEqual(got, want) {
+		t.Errorf("sortByWalkOrder() = %v, want %v", got, want)
+	}
+}
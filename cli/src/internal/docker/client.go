@@ -12,6 +12,13 @@ type Client interface {
 	// Returns an error if the image cannot be found or downloaded.
 	Pull(image string) error
 
+	// Build builds an image from a Dockerfile, writing the build output to output.
+	// Returns an error if the build fails.
+	Build(config BuildConfig, output io.Writer) error
+
+	// ImageExists checks if an image is present locally.
+	ImageExists(image string) bool
+
 	// Run creates and starts a container with the given configuration.
 	// Returns the container ID on success.
 	Run(config ContainerConfig) (string, error)
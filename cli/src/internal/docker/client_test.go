@@ -193,6 +193,67 @@ func TestBuildRunArgs(t *testing.T) {
 	}
 }
 
+func TestBuildBuildArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   BuildConfig
+		expected []string
+	}{
+		{
+			name:     "dockerfile and context",
+			config:   BuildConfig{Image: "myapp-api", Dockerfile: "api/Dockerfile", Context: "api"},
+			expected: []string{"build", "-t", "myapp-api", "-f", "api/Dockerfile", "api"},
+		},
+		{
+			name: "platform and build args",
+			config: BuildConfig{
+				Image:      "myapp-api",
+				Dockerfile: "Dockerfile",
+				Context:    ".",
+				Platform:   "linux/amd64",
+				BuildArgs:  []string{"VERSION=1.0", "DEBUG"},
+			},
+			expected: []string{"build", "-t", "myapp-api", "-f", "Dockerfile", "--platform", "linux/amd64", "--build-arg", "VERSION=1.0", "--build-arg", "DEBUG", "."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildBuildArgs(tt.config)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("buildBuildArgs() = %v, want %v", got, tt.expected)
+			}
+			for i, arg := range tt.expected {
+				if got[i] != arg {
+					t.Errorf("buildBuildArgs()[%d] = %q, want %q", i, got[i], arg)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  BuildConfig
+		wantErr bool
+	}{
+		{"valid config", BuildConfig{Image: "myapp-api", Dockerfile: "Dockerfile", Context: "."}, false},
+		{"invalid image", BuildConfig{Image: "MyApp API", Dockerfile: "Dockerfile", Context: "."}, true},
+		{"missing dockerfile", BuildConfig{Image: "myapp-api", Context: "."}, true},
+		{"missing context", BuildConfig{Image: "myapp-api", Dockerfile: "Dockerfile"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestBuildRunArgsEnvironmentVariables(t *testing.T) {
 	config := ContainerConfig{
 		Image: "test",
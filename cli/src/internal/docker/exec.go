@@ -46,6 +46,48 @@ func (c *ExecClient) Pull(image string) error {
 	return nil
 }
 
+// Build builds an image from a Dockerfile, writing the build output to output.
+func (c *ExecClient) Build(config BuildConfig, output io.Writer) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("docker", buildBuildArgs(config)...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to build image %q: %w", config.Image, err)
+	}
+	return nil
+}
+
+// buildBuildArgs constructs the arguments for docker build.
+func buildBuildArgs(config BuildConfig) []string {
+	args := []string{"build", "-t", config.Image, "-f", config.Dockerfile}
+
+	if config.Platform != "" {
+		args = append(args, "--platform", config.Platform)
+	}
+	for _, arg := range config.BuildArgs {
+		args = append(args, "--build-arg", arg)
+	}
+
+	// Add context
+	args = append(args, config.Context)
+
+	return args
+}
+
+// ImageExists checks if an image is present locally.
+func (c *ExecClient) ImageExists(image string) bool {
+	if ValidateImageName(image) != nil {
+		return false
+	}
+	cmd := exec.Command("docker", "image", "inspect", image)
+	return cmd.Run() == nil
+}
+
 // Run creates and starts a container with the given configuration.
 func (c *ExecClient) Run(config ContainerConfig) (string, error) {
 	if err := config.Validate(); err != nil {
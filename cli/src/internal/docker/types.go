@@ -21,6 +21,24 @@ type ContainerConfig struct {
 	Environment map[string]string
 }
 
+// BuildConfig holds configuration for building an image from a Dockerfile.
+type BuildConfig struct {
+	// Image is the name and optional tag to give the built image (e.g., "myapp-api")
+	Image string
+
+	// Dockerfile is the path to the Dockerfile
+	Dockerfile string
+
+	// Context is the build context directory
+	Context string
+
+	// Platform is the target platform (e.g., "linux/amd64"), empty for the daemon's default
+	Platform string
+
+	// BuildArgs are build-time variables in KEY=value form
+	BuildArgs []string
+}
+
 // PortMapping represents a host:container port mapping.
 type PortMapping struct {
 	// HostPort is the port on the host machine (0 = auto-assign)
@@ -131,3 +149,17 @@ func (c ContainerConfig) Validate() error {
 	}
 	return nil
 }
+
+// Validate checks if the build configuration is valid.
+func (c BuildConfig) Validate() error {
+	if err := ValidateImageName(c.Image); err != nil {
+		return err
+	}
+	if c.Dockerfile == "" {
+		return fmt.Errorf("dockerfile path cannot be empty")
+	}
+	if c.Context == "" {
+		return fmt.Errorf("build context cannot be empty")
+	}
+	return nil
+}
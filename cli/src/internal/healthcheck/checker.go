@@ -295,6 +295,16 @@ func (c *HealthChecker) performServiceCheck(ctx context.Context, svc serviceInfo
 		return c.performProcessHealthCheck(ctx, svc, isInStartupGracePeriod)
 	}
 
+	// UDP services have no HTTP endpoint or TCP listener to fall back through
+	if svc.Type == service.ServiceTypeUDP || (svc.HealthCheck != nil && svc.HealthCheck.Type == service.ServiceTypeUDP) {
+		return c.performUDPHealthCheck(result, svc, isInStartupGracePeriod)
+	}
+
+	// WebSocket services may reject plain HTTP requests, so only the handshake counts
+	if svc.HealthCheck != nil && svc.HealthCheck.Type == service.HealthCheckTypeWebSocket && svc.Port > 0 {
+		return c.performWebSocketHealthCheck(result, svc, isInStartupGracePeriod)
+	}
+
 	// Check for custom healthcheck config first
 	if svc.HealthCheck != nil && len(svc.HealthCheck.Test) > 0 {
 		if httpResult := c.tryCustomHealthCheck(ctx, svc.HealthCheck, svc); httpResult != nil {
@@ -358,6 +368,62 @@ func (c *HealthChecker) performServiceCheck(ctx context.Context, svc serviceInfo
 	return result
 }
 
+// performUDPHealthCheck checks that a UDP service's port is bound, or that it answers the
+// configured probe. See service.UDPHealthCheck; the result is best-effort.
+func (c *HealthChecker) performUDPHealthCheck(result HealthCheckResult, svc serviceInfo, isInStartupGracePeriod bool) HealthCheckResult {
+	result.CheckType = HealthCheckTypeUDP
+	result.Port = svc.Port
+
+	probe := ""
+	if svc.HealthCheck != nil {
+		probe = svc.HealthCheck.Probe
+	}
+
+	start := time.Now()
+	err := service.UDPHealthCheck(svc.Port, probe)
+	result.ResponseTime = time.Since(start)
+	if err == nil {
+		result.Status = HealthStatusHealthy
+		return result
+	}
+
+	if isInStartupGracePeriod {
+		result.Status = HealthStatusStarting
+	} else {
+		result.Status = HealthStatusUnhealthy
+	}
+	result.Error = err.Error()
+	return result
+}
+
+// performWebSocketHealthCheck checks that a service completes a WebSocket handshake on its
+// healthcheck path (default "/"). See service.WebSocketHealthCheck.
+func (c *HealthChecker) performWebSocketHealthCheck(result HealthCheckResult, svc serviceInfo, isInStartupGracePeriod bool) HealthCheckResult {
+	path := svc.HealthCheck.Path
+	if path == "" {
+		path = "/"
+	}
+	result.CheckType = HealthCheckTypeWebSocket
+	result.Port = svc.Port
+	result.Endpoint = fmt.Sprintf("ws://localhost:%d%s", svc.Port, path)
+
+	start := time.Now()
+	err := service.WebSocketHealthCheck(svc.Port, path)
+	result.ResponseTime = time.Since(start)
+	if err == nil {
+		result.Status = HealthStatusHealthy
+		return result
+	}
+
+	if isInStartupGracePeriod {
+		result.Status = HealthStatusStarting
+	} else {
+		result.Status = HealthStatusUnhealthy
+	}
+	result.Error = err.Error()
+	return result
+}
+
 // buildResultFromHTTPCheck builds a HealthCheckResult from an HTTP check result.
 func (c *HealthChecker) buildResultFromHTTPCheck(result HealthCheckResult, httpResult *httpHealthCheckResult, port int, isInStartupGracePeriod bool) HealthCheckResult {
 	result.CheckType = HealthCheckTypeHTTP
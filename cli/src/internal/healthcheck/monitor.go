@@ -359,7 +359,9 @@ func parseHealthCheckConfig(svc service.Service) *healthCheckConfig {
 	config := &healthCheckConfig{
 		Retries: 3,
 		Type:    svc.Healthcheck.Type,
+		Path:    svc.Healthcheck.Path,
 		Pattern: svc.Healthcheck.Pattern,
+		Probe:   svc.Healthcheck.Probe,
 	}
 
 	switch t := svc.Healthcheck.Test.(type) {
@@ -2,6 +2,7 @@ package healthcheck
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -11,7 +12,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/coder/websocket"
 	"github.com/jongio/azd-app/cli/src/internal/registry"
+	"github.com/jongio/azd-app/cli/src/internal/service"
 )
 
 // TestCheckServiceNoPortNoPID tests service with no port or PID
@@ -803,3 +806,77 @@ func TestEndpointCacheIsolation(t *testing.T) {
 		t.Errorf("Server2 should still use /healthz, got %s", result2Again.Endpoint)
 	}
 }
+
+// TestCheckServiceUDP tests that UDP services are checked by port binding instead of HTTP/TCP
+func TestCheckServiceUDP(t *testing.T) {
+	checker := &HealthChecker{
+		timeout:         5 * time.Second,
+		defaultEndpoint: "/health",
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to bind UDP port: %v", err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	svc := serviceInfo{
+		Name: "collector",
+		Port: port,
+		Type: service.ServiceTypeUDP,
+	}
+
+	result := checker.CheckService(context.Background(), svc)
+	if result.CheckType != HealthCheckTypeUDP || result.Status != HealthStatusHealthy {
+		t.Errorf("CheckService() = %s/%s (%s), want udp/healthy", result.CheckType, result.Status, result.Error)
+	}
+
+	_ = conn.Close()
+	result = checker.CheckService(context.Background(), svc)
+	if result.Status != HealthStatusUnhealthy || result.Error == "" {
+		t.Errorf("CheckService() after the port is released = %s (%q), want unhealthy with an error", result.Status, result.Error)
+	}
+}
+
+// TestCheckServiceWebSocket tests that ws healthchecks need a completed WebSocket handshake
+func TestCheckServiceWebSocket(t *testing.T) {
+	checker := &HealthChecker{
+		timeout:         5 * time.Second,
+		defaultEndpoint: "/health",
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		_, _, _ = conn.Read(context.Background())
+	}))
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	svc := serviceInfo{
+		Name:        "chat",
+		Port:        port,
+		HealthCheck: &healthCheckConfig{Type: service.HealthCheckTypeWebSocket, Path: "/ws"},
+	}
+
+	result := checker.CheckService(context.Background(), svc)
+	if result.CheckType != HealthCheckTypeWebSocket || result.Status != HealthStatusHealthy {
+		t.Errorf("CheckService() = %s/%s (%s), want ws/healthy", result.CheckType, result.Status, result.Error)
+	}
+	if result.Endpoint != fmt.Sprintf("ws://localhost:%d/ws", port) {
+		t.Errorf("Endpoint = %q, want the ws healthcheck path", result.Endpoint)
+	}
+
+	server.Close()
+	result = checker.CheckService(context.Background(), svc)
+	if result.Status != HealthStatusUnhealthy || result.Error == "" {
+		t.Errorf("CheckService() after the server stops = %s (%q), want unhealthy with an error", result.Status, result.Error)
+	}
+}
@@ -0,0 +1,86 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/service"
+	"github.com/rs/zerolog/log"
+)
+
+// EndpointOverride replaces the endpoint a service is probed on, so a different health
+// endpoint can be tried without editing azure.yaml. Zero values keep the service's own.
+type EndpointOverride struct {
+	Path string // HTTP path, e.g. "/ready"
+	Port int    // Port on localhost
+}
+
+// ProbeService checks one service with a single HTTP probe of http://localhost:<port><path>
+// (azd app info --health). The port is the service's registered or declared port and the path
+// its healthcheck path, azure.yaml HTTP test URL or the monitor's default endpoint, unless
+// override replaces them. Circuit breakers, rate limits and caching do not apply.
+func (m *HealthMonitor) ProbeService(ctx context.Context, name string, override EndpointOverride) (HealthCheckResult, error) {
+	azureYaml, err := m.loadAzureYaml()
+	if err != nil && m.config.Verbose {
+		log.Warn().Err(err).Msg("Could not load azure.yaml")
+	}
+
+	var svc *serviceInfo
+	for _, info := range m.buildServiceList(azureYaml, m.registry.ListAll()) {
+		if info.Name == name {
+			svc = &info
+			break
+		}
+	}
+	if svc == nil {
+		return HealthCheckResult{}, fmt.Errorf("service '%s' not found in azure.yaml or the service registry", name)
+	}
+
+	port := svc.Port
+	if override.Port > 0 {
+		port = override.Port
+	}
+	if port <= 0 {
+		return HealthCheckResult{}, fmt.Errorf("service '%s' has no known port; pass --port to choose one", name)
+	}
+
+	path := override.Path
+	if path == "" {
+		path = m.configuredHealthPath(azureYaml, name, svc.HealthCheck)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	result := HealthCheckResult{
+		ServiceName: name,
+		Timestamp:   time.Now(),
+		ServiceType: svc.Type,
+		ServiceMode: svc.Mode,
+		PID:         svc.PID,
+	}
+	httpResult := m.checker.performHTTPCheck(ctx, fmt.Sprintf("http://localhost:%d%s", port, path))
+	return m.checker.buildResultFromHTTPCheck(result, httpResult, port, false), nil
+}
+
+// configuredHealthPath returns the HTTP path a service's health is checked on: the azure.yaml
+// healthcheck path, the path of an HTTP test URL, or the monitor's default endpoint.
+func (m *HealthMonitor) configuredHealthPath(azureYaml *service.AzureYaml, name string, config *healthCheckConfig) string {
+	if azureYaml != nil {
+		if svc, ok := azureYaml.Services[name]; ok && svc.Healthcheck != nil && svc.Healthcheck.Path != "" {
+			return svc.Healthcheck.Path
+		}
+	}
+	if config != nil && len(config.Test) > 0 {
+		if u, err := url.Parse(config.Test[0]); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Path != "" {
+			return u.RequestURI()
+		}
+	}
+	if m.config.DefaultEndpoint != "" {
+		return m.config.DefaultEndpoint
+	}
+	return "/health"
+}
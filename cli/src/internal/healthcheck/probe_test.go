@@ -0,0 +1,131 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// probeServer is a test server that records the paths it was probed on.
+type probeServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	paths []string
+}
+
+func newProbeServer(t *testing.T) *probeServer {
+	t.Helper()
+	s := &probeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *probeServer) port() int {
+	return s.Listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *probeServer) probedPaths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.paths...)
+}
+
+func TestProbeService(t *testing.T) {
+	declared := newProbeServer(t)
+	other := newProbeServer(t)
+
+	tempDir := t.TempDir()
+	azureYaml := fmt.Sprintf(`
+services:
+  api:
+    language: python
+    project: ./api
+    ports:
+      - "%d"
+    healthcheck:
+      path: /healthz
+  worker:
+    language: python
+    project: ./worker
+`, declared.port())
+	if err := os.WriteFile(filepath.Join(tempDir, "azure.yaml"), []byte(azureYaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	monitor, err := NewHealthMonitor(MonitorConfig{ProjectDir: tempDir, DefaultEndpoint: "/health", Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Failed to create health monitor: %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("configured endpoint", func(t *testing.T) {
+		result, err := monitor.ProbeService(ctx, "api", EndpointOverride{})
+		if err != nil {
+			t.Fatalf("ProbeService() error = %v", err)
+		}
+		want := fmt.Sprintf("http://localhost:%d/healthz", declared.port())
+		if result.Endpoint != want || result.Status != HealthStatusHealthy || result.CheckType != HealthCheckTypeHTTP {
+			t.Errorf("ProbeService() = %s %s %s, want %s healthy http", result.Endpoint, result.Status, result.CheckType, want)
+		}
+	})
+
+	t.Run("path and port override", func(t *testing.T) {
+		before := len(declared.probedPaths())
+		result, err := monitor.ProbeService(ctx, "api", EndpointOverride{Path: "/ready", Port: other.port()})
+		if err != nil {
+			t.Fatalf("ProbeService() error = %v", err)
+		}
+		if got := other.probedPaths(); len(got) != 1 || got[0] != "/ready" {
+			t.Errorf("override server probed on %v, want [/ready]", got)
+		}
+		if len(declared.probedPaths()) != before {
+			t.Error("declared port was probed despite the port override")
+		}
+		if result.Port != other.port() || result.Endpoint != fmt.Sprintf("http://localhost:%d/ready", other.port()) {
+			t.Errorf("ProbeService() port %d endpoint %s, want the override", result.Port, result.Endpoint)
+		}
+	})
+
+	t.Run("path override without leading slash", func(t *testing.T) {
+		result, err := monitor.ProbeService(ctx, "api", EndpointOverride{Path: "livez"})
+		if err != nil {
+			t.Fatalf("ProbeService() error = %v", err)
+		}
+		if !strings.HasSuffix(result.Endpoint, fmt.Sprintf(":%d/livez", declared.port())) {
+			t.Errorf("ProbeService() endpoint = %s, want the declared port with /livez", result.Endpoint)
+		}
+	})
+
+	t.Run("no port", func(t *testing.T) {
+		if _, err := monitor.ProbeService(ctx, "worker", EndpointOverride{Path: "/ready"}); err == nil || !strings.Contains(err.Error(), "--port") {
+			t.Errorf("ProbeService() error = %v, want a hint to pass --port", err)
+		}
+		result, err := monitor.ProbeService(ctx, "worker", EndpointOverride{Port: other.port()})
+		if err != nil {
+			t.Fatalf("ProbeService() error = %v", err)
+		}
+		if !strings.HasSuffix(result.Endpoint, "/health") {
+			t.Errorf("ProbeService() endpoint = %s, want the default endpoint", result.Endpoint)
+		}
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		if _, err := monitor.ProbeService(ctx, "missing", EndpointOverride{}); err == nil {
+			t.Error("expected error for unknown service")
+		}
+	})
+}
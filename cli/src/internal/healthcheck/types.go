@@ -48,9 +48,11 @@ const (
 type HealthCheckType string
 
 const (
-	HealthCheckTypeHTTP    HealthCheckType = "http"
-	HealthCheckTypeTCP     HealthCheckType = "tcp"
-	HealthCheckTypeProcess HealthCheckType = "process"
+	HealthCheckTypeHTTP      HealthCheckType = "http"
+	HealthCheckTypeWebSocket HealthCheckType = "ws"
+	HealthCheckTypeTCP       HealthCheckType = "tcp"
+	HealthCheckTypeUDP       HealthCheckType = "udp"
+	HealthCheckTypeProcess   HealthCheckType = "process"
 )
 
 // HealthCheckResult represents the result of a single health check.
@@ -67,7 +69,7 @@ type HealthCheckResult struct {
 	Port         int                    `json:"port,omitempty"`
 	PID          int                    `json:"pid,omitempty"`
 	Uptime       time.Duration          `json:"uptime,omitempty"`
-	ServiceType  string                 `json:"serviceType,omitempty"` // "http", "tcp", "process"
+	ServiceType  string                 `json:"serviceType,omitempty"` // "http", "tcp", "udp", "process"
 	ServiceMode  string                 `json:"serviceMode,omitempty"` // "watch", "build", "daemon", "task" (for type=process)
 }
 
@@ -116,7 +118,7 @@ type serviceInfo struct {
 	StartTime      time.Time
 	HealthCheck    *healthCheckConfig
 	RegistryStatus string // "running", "stopped", "starting", etc.
-	Type           string // "http", "tcp", "process"
+	Type           string // "http", "tcp", "udp", "process"
 	Mode           string // "watch", "build", "daemon", "task" (for type=process)
 	ExitCode       *int   // Exit code for completed build/task mode services (nil = still running)
 	EndTime        time.Time
@@ -125,8 +127,10 @@ type serviceInfo struct {
 // healthCheckConfig holds custom healthcheck configuration from azure.yaml.
 type healthCheckConfig struct {
 	Test          []string
-	Type          string // "http", "tcp", "process", "output", "none"
+	Type          string // "http", "ws", "tcp", "udp", "process", "output", "none"
+	Path          string // Path for ws health checks
 	Pattern       string // Regex pattern for output-based health checks
+	Probe         string // Datagram for udp health checks
 	Interval      time.Duration
 	Timeout       time.Duration
 	Retries       int
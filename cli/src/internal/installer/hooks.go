@@ -0,0 +1,69 @@
+package installer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// withInstallHooks runs the preinstall hooks, install and the postinstall hooks in order,
+// stopping at the first failure so a failed codegen step doesn't install against stale
+// sources. Without hooks it just runs install.
+func withInstallHooks(hooks *types.InstallHooks, progressWriter io.Writer, install func() error) error {
+	if hooks == nil {
+		return install()
+	}
+	if err := runInstallHooks("preinstall", hooks.Preinstall, hooks, progressWriter); err != nil {
+		return err
+	}
+	if err := install(); err != nil {
+		return err
+	}
+	return runInstallHooks("postinstall", hooks.Postinstall, hooks, progressWriter)
+}
+
+// runInstallHooks runs the commands of one hook phase in the service's directory and environment.
+// Commands run directly rather than through a shell, like the test commands in azure.yaml.
+func runInstallHooks(phase string, commands [][]string, hooks *types.InstallHooks, progressWriter io.Writer) error {
+	for _, parts := range commands {
+		if len(parts) == 0 {
+			return fmt.Errorf("%s hook is empty", phase)
+		}
+		command := strings.Join(parts, " ")
+		if !output.IsJSON() && progressWriter == nil {
+			output.Item("Running %s hook: %s", phase, command)
+		}
+
+		// #nosec G204 -- Hook commands come from the service's hooks in azure.yaml
+		cmd := exec.Command(parts[0], parts[1:]...)
+		cmd.Dir = hooks.Dir
+		cmd.Env = hooks.Env
+
+		var stderrBuf bytes.Buffer
+		if progressWriter != nil {
+			cmd.Stdout = progressWriter
+			cmd.Stderr = io.MultiWriter(progressWriter, &stderrBuf)
+		} else if output.IsJSON() {
+			cmd.Stdout = io.Discard
+			cmd.Stderr = &stderrBuf
+		} else {
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = io.MultiWriter(os.Stderr, &stderrBuf)
+		}
+
+		if err := cmd.Run(); err != nil {
+			errMsg := fmt.Sprintf("%s hook %q failed: %v", phase, command, err)
+			if details := extractErrorDetails(stderrBuf.String(), parts[0]); details != "" {
+				errMsg += ": " + details
+			}
+			return fmt.Errorf("%s\n   Directory: %s", errMsg, hooks.Dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package installer
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+func TestWithInstallHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands use sh")
+	}
+
+	newHooks := func(dir string, pre, post [][]string) *types.InstallHooks {
+		return &types.InstallHooks{Preinstall: pre, Postinstall: post, Dir: dir, Env: append(os.Environ(), "HOOK_NAME=api")}
+	}
+	record := func(step string) []string {
+		return []string{"sh", "-c", "echo " + step + "-$HOOK_NAME >> steps.log"}
+	}
+	readSteps := func(t *testing.T, dir string) string {
+		t.Helper()
+		data, _ := os.ReadFile(filepath.Join(dir, "steps.log"))
+		return strings.Join(strings.Fields(string(data)), " ")
+	}
+	install := func(dir string, err error) func() error {
+		return func() error {
+			f, openErr := os.OpenFile(filepath.Join(dir, "steps.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+			if openErr != nil {
+				return openErr
+			}
+			_, _ = f.WriteString("install\n")
+			_ = f.Close()
+			return err
+		}
+	}
+
+	t.Run("runs hooks around the install in the service environment", func(t *testing.T) {
+		dir := t.TempDir()
+		hooks := newHooks(dir, [][]string{record("pre1"), record("pre2")}, [][]string{record("post")})
+
+		if err := withInstallHooks(hooks, io.Discard, install(dir, nil)); err != nil {
+			t.Fatalf("withInstallHooks() error = %v", err)
+		}
+		if got, want := readSteps(t, dir), "pre1-api pre2-api install post-api"; got != want {
+			t.Errorf("steps = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("failed preinstall hook skips the install", func(t *testing.T) {
+		dir := t.TempDir()
+		hooks := newHooks(dir, [][]string{{"sh", "-c", "echo 'codegen error: bad schema' >&2; exit 3"}}, [][]string{record("post")})
+
+		err := withInstallHooks(hooks, io.Discard, install(dir, nil))
+		if err == nil || !strings.Contains(err.Error(), "preinstall hook") || !strings.Contains(err.Error(), "codegen error: bad schema") {
+			t.Fatalf("error = %v, want the failed preinstall hook and its stderr", err)
+		}
+		if got := readSteps(t, dir); got != "" {
+			t.Errorf("steps = %q, want nothing run after the failed hook", got)
+		}
+	})
+
+	t.Run("failed install skips postinstall hooks", func(t *testing.T) {
+		dir := t.TempDir()
+		installErr := errors.New("npm install failed")
+		hooks := newHooks(dir, nil, [][]string{record("post")})
+
+		if err := withInstallHooks(hooks, io.Discard, install(dir, installErr)); !errors.Is(err, installErr) {
+			t.Fatalf("error = %v, want %v", err, installErr)
+		}
+		if got := readSteps(t, dir); got != "install" {
+			t.Errorf("steps = %q, want only the install", got)
+		}
+	})
+
+	t.Run("empty hook command", func(t *testing.T) {
+		hooks := newHooks(t.TempDir(), nil, [][]string{{}})
+		if err := withInstallHooks(hooks, io.Discard, func() error { return nil }); err == nil || !strings.Contains(err.Error(), "postinstall hook is empty") {
+			t.Errorf("error = %v, want an empty postinstall hook error", err)
+		}
+	})
+}
@@ -18,8 +18,114 @@ import (
 )
 
 // InstallNodeDependencies installs dependencies using the detected package manager.
+// The service's preinstall and postinstall hooks run around the install.
 func InstallNodeDependencies(project types.NodeProject) error {
-	return installNodeDependenciesWithWriter(project, nil)
+	return withInstallHooks(project.Hooks, nil, func() error {
+		return installNodeDependenciesWithWriter(project, nil)
+	})
+}
+
+// nodeInstallArgs returns the install arguments for the project's package manager.
+func nodeInstallArgs(project types.NodeProject) []string {
+	var args []string
+
+	// Add non-interactive flags to prevent prompts
+	switch project.PackageManager {
+	case "npm":
+		args = []string{"install", "--no-audit", "--no-fund", "--prefer-offline"}
+		// npm ci installs exactly package-lock.json and fails if it is out of sync with package.json
+		if project.Frozen {
+			args[0] = "ci"
+		}
+		// If this is a workspace root, use --workspaces flag to install all workspace packages
+		if project.IsWorkspaceRoot {
+			args = append(args, "--workspaces")
+		}
+	case "pnpm":
+		args = []string{"install", "--prefer-offline"}
+		// If this is a workspace root, use --recursive flag to install all workspace packages
+		if project.IsWorkspaceRoot {
+			args = append(args, "--recursive")
+		}
+	case "yarn":
+		args = []string{"install", "--non-interactive", "--prefer-offline"}
+	default:
+		args = []string{"install"}
+	}
+
+	if project.Frozen {
+		switch {
+		case project.PackageManager == "pnpm":
+			args = append(args, "--frozen-lockfile")
+		case project.PackageManager == "yarn" && isYarnBerry(project):
+			args = append(args, "--immutable")
+		case project.PackageManager == "yarn":
+			args = append(args, "--frozen-lockfile")
+		}
+	}
+
+	if project.IgnoreScripts {
+		args = append(args, ignoreScriptsArg(project))
+	}
+	return args
+}
+
+// ignoreScriptsArg returns the package manager's option for skipping lifecycle scripts.
+func ignoreScriptsArg(project types.NodeProject) string {
+	if project.PackageManager == "yarn" && isYarnBerry(project) {
+		return "--mode=skip-build"
+	}
+	return "--ignore-scripts"
+}
+
+// lockFiles lists the lock files a --frozen install verifies against, by package manager.
+// Any one of them is enough; requirements.txt is pip's lock file when it pins hashes.
+var lockFiles = map[string][]string{
+	"npm":    {"package-lock.json", "npm-shrinkwrap.json"},
+	"pnpm":   {"pnpm-lock.yaml"},
+	"yarn":   {"yarn.lock"},
+	"uv":     {"uv.lock"},
+	"poetry": {"poetry.lock"},
+	"pip":    {"requirements.txt"},
+	"dotnet": {"packages.lock.json"},
+}
+
+// requireLockFile returns the first lock file of packageManager found in dirs, or an error
+// naming the missing lock file, since a --frozen install has nothing to verify against.
+func requireLockFile(packageManager string, dirs ...string) (string, error) {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		for _, name := range lockFiles[packageManager] {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("--frozen requires a committed lock file, but no %s was found in %s",
+		strings.Join(lockFiles[packageManager], " or "), dirs[0])
+}
+
+// frozenInstallError explains a failed --frozen install, which usually means the project's
+// dependencies were changed without updating the lock file.
+func frozenInstallError(lockFile string, err error) error {
+	return fmt.Errorf("frozen install failed, %s may be out of date (update and commit it, or run without --frozen): %w", lockFile, err)
+}
+
+// isYarnBerry reports whether a yarn project uses Yarn 2 or later, which is configured
+// through .yarnrc.yml (Yarn 1 uses .yarnrc).
+func isYarnBerry(project types.NodeProject) bool {
+	for _, dir := range []string{project.Dir, project.WorkspaceRoot} {
+		if dir == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".yarnrc.yml")); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
 // installNodeDependenciesWithWriter installs dependencies with optional writer for progress tracking.
@@ -33,9 +139,18 @@ func installNodeDependenciesWithWriter(project types.NodeProject, progressWriter
 		return fmt.Errorf("invalid package manager: %w", err)
 	}
 
+	// A frozen install always runs, so the lock file is verified even when node_modules exists
+	var lockFile string
+	if project.Frozen {
+		var err error
+		if lockFile, err = requireLockFile(project.PackageManager, project.Dir, project.WorkspaceRoot); err != nil {
+			return err
+		}
+	}
+
 	// Check if dependencies are already installed and up-to-date
 	nodeModulesPath := filepath.Join(project.Dir, "node_modules")
-	if _, err := os.Stat(nodeModulesPath); err == nil {
+	if _, err := os.Stat(nodeModulesPath); err == nil && !project.Frozen {
 		// node_modules exists, check if it's up-to-date
 		if isDependenciesUpToDate(project.Dir, project.PackageManager) {
 			if !output.IsJSON() && progressWriter == nil {
@@ -55,27 +170,7 @@ func installNodeDependenciesWithWriter(project types.NodeProject, progressWriter
 	// 2. Correct environment variable expansion
 	// 3. Better handling of Windows path length issues
 	var cmd *exec.Cmd
-	var args []string
-
-	// Add non-interactive flags to prevent prompts
-	switch project.PackageManager {
-	case "npm":
-		args = []string{"install", "--no-audit", "--no-fund", "--prefer-offline"}
-		// If this is a workspace root, use --workspaces flag to install all workspace packages
-		if project.IsWorkspaceRoot {
-			args = append(args, "--workspaces")
-		}
-	case "pnpm":
-		args = []string{"install", "--prefer-offline"}
-		// If this is a workspace root, use --recursive flag to install all workspace packages
-		if project.IsWorkspaceRoot {
-			args = append(args, "--recursive")
-		}
-	case "yarn":
-		args = []string{"install", "--non-interactive", "--prefer-offline"}
-	default:
-		args = []string{"install"}
-	}
+	args := nodeInstallArgs(project)
 
 	if runtime.GOOS == "windows" {
 		// Use cmd.exe /c to properly invoke .cmd files
@@ -115,7 +210,11 @@ func installNodeDependenciesWithWriter(project types.NodeProject, progressWriter
 	// Run with retry logic for Windows file locking errors
 	err := runWithRetry(cmd, &stderrBuf, 3)
 	if err != nil {
-		return formatNodeInstallError(project.PackageManager, project.Dir, cmd, err, stderrBuf.String())
+		err = formatNodeInstallError(project.PackageManager, project.Dir, cmd, err, stderrBuf.String())
+		if project.Frozen {
+			return frozenInstallError(lockFile, err)
+		}
+		return err
 	}
 
 	if !output.IsJSON() && progressWriter == nil {
@@ -125,8 +224,11 @@ func installNodeDependenciesWithWriter(project types.NodeProject, progressWriter
 }
 
 // RestoreDotnetProject runs dotnet restore on a project.
+// The service's preinstall and postinstall hooks run around the restore.
 func RestoreDotnetProject(project types.DotnetProject) error {
-	return restoreDotnetProjectWithWriter(project, nil)
+	return withInstallHooks(project.Hooks, nil, func() error {
+		return restoreDotnetProjectWithWriter(project, nil)
+	})
 }
 
 // restoreDotnetProjectWithWriter runs dotnet restore with optional progress writer.
@@ -142,7 +244,22 @@ func restoreDotnetProjectWithWriter(project types.DotnetProject, progressWriter
 
 	// Run restore with streaming output
 	dir := filepath.Dir(project.Path)
-	cmd := exec.Command("dotnet", "restore", project.Path)
+	args := []string{"restore", project.Path}
+
+	// Locked mode fails the restore if packages.lock.json would change. A solution's lock
+	// files live next to its projects, so only a single project's is checked up front.
+	var lockFile string
+	if project.Frozen {
+		lockFile = "the projects' packages.lock.json files"
+		if !strings.EqualFold(filepath.Ext(project.Path), ".sln") {
+			var err error
+			if lockFile, err = requireLockFile("dotnet", dir); err != nil {
+				return err
+			}
+		}
+		args = append(args, "--locked-mode")
+	}
+	cmd := exec.Command("dotnet", args...)
 	cmd.Dir = dir
 
 	// Capture stderr for error reporting
@@ -163,7 +280,11 @@ func restoreDotnetProjectWithWriter(project types.DotnetProject, progressWriter
 	cmd.Env = os.Environ()
 
 	if err := cmd.Run(); err != nil {
-		return formatDotnetRestoreError(project.Path, dir, cmd, err, stderrBuf.String())
+		err = formatDotnetRestoreError(project.Path, dir, cmd, err, stderrBuf.String())
+		if project.Frozen {
+			return frozenInstallError(lockFile, err)
+		}
+		return err
 	}
 
 	if !output.IsJSON() && progressWriter == nil {
@@ -173,12 +294,19 @@ func restoreDotnetProjectWithWriter(project types.DotnetProject, progressWriter
 }
 
 // SetupPythonVirtualEnv creates a virtual environment and installs dependencies.
+// The service's preinstall and postinstall hooks run around the install.
 func SetupPythonVirtualEnv(project types.PythonProject) error {
-	return setupPythonVirtualEnvWithWriter(project, nil)
+	return withInstallHooks(project.Hooks, nil, func() error {
+		return setupPythonVirtualEnvWithWriter(project, nil)
+	})
 }
 
 // setupPythonVirtualEnvWithWriter creates a virtual environment with optional progress writer.
 func setupPythonVirtualEnvWithWriter(project types.PythonProject, progressWriter io.Writer) error {
+	if project.Frozen {
+		return setupPythonFrozen(project, progressWriter)
+	}
+
 	switch project.PackageManager {
 	case "uv":
 		return setupWithUv(project.Dir, progressWriter)
@@ -191,6 +319,78 @@ func setupPythonVirtualEnvWithWriter(project types.PythonProject, progressWriter
 	}
 }
 
+// setupPythonFrozen installs a Python project's dependencies exactly as its lock file pins
+// them, failing instead of resolving anew: uv sync --locked, poetry check --lock before
+// poetry install, or pip install --require-hashes from requirements.txt. There is no
+// fallback to pip, which would ignore uv.lock and poetry.lock.
+func setupPythonFrozen(project types.PythonProject, progressWriter io.Writer) error {
+	lockFile, err := requireLockFile(project.PackageManager, project.Dir)
+	if err != nil {
+		return err
+	}
+	if project.PackageManager != "pip" {
+		if _, err := exec.LookPath(project.PackageManager); err != nil {
+			return fmt.Errorf("%s not found - --frozen installs from %s and requires %s", project.PackageManager, filepath.Base(lockFile), project.PackageManager)
+		}
+	}
+
+	if !output.IsJSON() && progressWriter == nil {
+		output.Item("Installing dependencies from %s (%s)...", filepath.Base(lockFile), project.PackageManager)
+	}
+
+	switch project.PackageManager {
+	case "uv":
+		err = runPythonTool("uv sync", project.Dir, progressWriter, "uv", "sync", "--locked", "--no-progress")
+	case "poetry":
+		err = runPythonTool("poetry check", project.Dir, progressWriter, "poetry", "check", "--lock")
+		if err == nil {
+			err = runPythonTool("poetry install", project.Dir, progressWriter, "poetry", "install", "--no-root")
+		}
+	case "pip":
+		var venvPath string
+		if venvPath, err = ensurePipVenv(project.Dir, progressWriter); err != nil {
+			return err
+		}
+		err = runPythonTool("pip install", project.Dir, progressWriter, venvPip(venvPath),
+			"install", "--require-hashes", "-r", "requirements.txt", "--disable-pip-version-check")
+	default:
+		return fmt.Errorf("unknown package manager '%s' for Python project in %s", project.PackageManager, project.Dir)
+	}
+	if err != nil {
+		return frozenInstallError(lockFile, err)
+	}
+
+	if !output.IsJSON() && progressWriter == nil {
+		output.ItemSuccess("Dependencies installed (%s, frozen)", project.PackageManager)
+	}
+	return nil
+}
+
+// runPythonTool runs a Python tool in projectDir, streaming its output like the other installers,
+// and formats a failure with formatPythonInstallError.
+func runPythonTool(tool, projectDir string, progressWriter io.Writer, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = projectDir
+	cmd.Env = os.Environ() // Inherit azd context (AZD_SERVER, AZD_ACCESS_TOKEN, AZURE_*)
+
+	var stderrBuf bytes.Buffer
+	if progressWriter != nil {
+		cmd.Stdout = progressWriter
+		cmd.Stderr = io.MultiWriter(progressWriter, &stderrBuf)
+	} else if output.IsJSON() {
+		cmd.Stdout = io.Discard
+		cmd.Stderr = &stderrBuf
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderrBuf)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return formatPythonInstallError(tool, projectDir, cmd, err, stderrBuf.String())
+	}
+	return nil
+}
+
 // setupWithUv sets up a Python project using uv.
 func setupWithUv(projectDir string, progressWriter io.Writer) error {
 	// Check if uv is installed
@@ -341,34 +541,9 @@ func setupWithPoetry(projectDir string, progressWriter io.Writer) error {
 
 // setupWithPip sets up a Python project using pip and venv.
 func setupWithPip(projectDir string, progressWriter io.Writer) error {
-	venvPath := filepath.Join(projectDir, ".venv")
-
-	// Check if venv already exists, create if not
-	if _, err := os.Stat(venvPath); err != nil {
-		if !output.IsJSON() && progressWriter == nil {
-			output.Item("Creating virtual environment at .venv...")
-		}
-
-		// Create virtual environment
-		cmd := exec.Command("python", "-m", "venv", ".venv")
-		cmd.Dir = projectDir
-		cmd.Env = os.Environ() // Inherit azd context (AZD_SERVER, AZD_ACCESS_TOKEN, AZURE_*)
-
-		var stderrBuf bytes.Buffer
-		cmd.Stderr = &stderrBuf
-		cmd.Stdout = io.Discard
-
-		if err := cmd.Run(); err != nil {
-			return formatPythonInstallError("python -m venv", projectDir, cmd, err, stderrBuf.String())
-		}
-
-		if !output.IsJSON() && progressWriter == nil {
-			output.ItemSuccess("Created .venv")
-		}
-	} else {
-		if !output.IsJSON() && progressWriter == nil {
-			output.ItemSuccess("Virtual environment exists")
-		}
+	venvPath, err := ensurePipVenv(projectDir, progressWriter)
+	if err != nil {
+		return err
 	}
 
 	// Check if requirements.txt exists and install dependencies
@@ -378,19 +553,8 @@ func setupWithPip(projectDir string, progressWriter io.Writer) error {
 			output.Item("Installing dependencies into .venv (pip)...")
 		}
 
-		// Determine the pip path based on OS
-		// Using the pip executable directly from the venv ensures packages
-		// are installed into the correct virtual environment without needing
-		// to activate it (activation is only needed for interactive shells)
-		var pipPath string
-		if runtime.GOOS == "windows" {
-			pipPath = filepath.Join(venvPath, "Scripts", "pip.exe")
-		} else {
-			pipPath = filepath.Join(venvPath, "bin", "pip")
-		}
-
 		// Run pip install with streaming output and optimizations
-		pipCmd := exec.Command(pipPath, "install", "-r", "requirements.txt", "--disable-pip-version-check", "--prefer-binary")
+		pipCmd := exec.Command(venvPip(venvPath), "install", "-r", "requirements.txt", "--disable-pip-version-check", "--prefer-binary")
 		pipCmd.Dir = projectDir
 
 		var stderrBuf bytes.Buffer
@@ -419,6 +583,62 @@ func setupWithPip(projectDir string, progressWriter io.Writer) error {
 	return nil
 }
 
+// venvPip returns the pip executable of the virtual environment at venvPath.
+// Using the pip executable directly from the venv ensures packages are installed into
+// the correct virtual environment without needing to activate it (activation is only
+// needed for interactive shells).
+func venvPip(venvPath string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(venvPath, "Scripts", "pip.exe")
+	}
+	return filepath.Join(venvPath, "bin", "pip")
+}
+
+// ensurePipVenv creates the project's .venv unless it exists and returns its path.
+func ensurePipVenv(projectDir string, progressWriter io.Writer) (string, error) {
+	venvPath := filepath.Join(projectDir, ".venv")
+
+	// Check if venv already exists, create if not
+	if _, err := os.Stat(venvPath); err != nil {
+		// Use the interpreter matching the project's .python-version or requires-python pin
+		python, err := selectPythonInterpreter(projectDir, systemPythonFinder)
+		if err != nil {
+			return "", err
+		}
+
+		if !output.IsJSON() && progressWriter == nil {
+			if python == "python" {
+				output.Item("Creating virtual environment at .venv...")
+			} else {
+				output.Item("Creating virtual environment at .venv (%s)...", python)
+			}
+		}
+
+		// Create virtual environment
+		cmd := exec.Command(python, "-m", "venv", ".venv")
+		cmd.Dir = projectDir
+		cmd.Env = os.Environ() // Inherit azd context (AZD_SERVER, AZD_ACCESS_TOKEN, AZURE_*)
+
+		var stderrBuf bytes.Buffer
+		cmd.Stderr = &stderrBuf
+		cmd.Stdout = io.Discard
+
+		if err := cmd.Run(); err != nil {
+			return "", formatPythonInstallError("python -m venv", projectDir, cmd, err, stderrBuf.String())
+		}
+
+		if !output.IsJSON() && progressWriter == nil {
+			output.ItemSuccess("Created .venv")
+		}
+	} else {
+		if !output.IsJSON() && progressWriter == nil {
+			output.ItemSuccess("Virtual environment exists")
+		}
+	}
+
+	return venvPath, nil
+}
+
 // isDependenciesUpToDate checks if node_modules is up-to-date with the lock file
 func isDependenciesUpToDate(projectDir string, packageManager string) bool {
 	nodeModulesPath := filepath.Join(projectDir, "node_modules")
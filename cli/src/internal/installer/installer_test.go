@@ -2,9 +2,11 @@ package installer
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -758,6 +760,192 @@ func TestInstallNodeDependencies_Workspace(t *testing.T) {
 	}
 }
 
+func TestNodeInstallArgs_IgnoreScripts(t *testing.T) {
+	berryDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(berryDir, ".yarnrc.yml"), []byte("nodeLinker: node-modules\n"), 0600); err != nil {
+		t.Fatalf("failed to create .yarnrc.yml: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		project types.NodeProject
+		want    []string
+	}{
+		{
+			name:    "npm",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "npm", IgnoreScripts: true},
+			want:    []string{"install", "--no-audit", "--no-fund", "--prefer-offline", "--ignore-scripts"},
+		},
+		{
+			name:    "npm workspace",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "npm", IsWorkspaceRoot: true, IgnoreScripts: true},
+			want:    []string{"install", "--no-audit", "--no-fund", "--prefer-offline", "--workspaces", "--ignore-scripts"},
+		},
+		{
+			name:    "pnpm",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "pnpm", IgnoreScripts: true},
+			want:    []string{"install", "--prefer-offline", "--ignore-scripts"},
+		},
+		{
+			name:    "yarn classic",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "yarn", IgnoreScripts: true},
+			want:    []string{"install", "--non-interactive", "--prefer-offline", "--ignore-scripts"},
+		},
+		{
+			name:    "yarn berry",
+			project: types.NodeProject{Dir: berryDir, PackageManager: "yarn", IgnoreScripts: true},
+			want:    []string{"install", "--non-interactive", "--prefer-offline", "--mode=skip-build"},
+		},
+		{
+			name:    "yarn berry workspace child",
+			project: types.NodeProject{Dir: t.TempDir(), WorkspaceRoot: berryDir, PackageManager: "yarn", IgnoreScripts: true},
+			want:    []string{"install", "--non-interactive", "--prefer-offline", "--mode=skip-build"},
+		},
+		{
+			name:    "scripts enabled",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "npm"},
+			want:    []string{"install", "--no-audit", "--no-fund", "--prefer-offline"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeInstallArgs(tt.project); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodeInstallArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeInstallArgs_Frozen(t *testing.T) {
+	berryDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(berryDir, ".yarnrc.yml"), []byte("nodeLinker: node-modules\n"), 0600); err != nil {
+		t.Fatalf("failed to create .yarnrc.yml: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		project types.NodeProject
+		want    []string
+	}{
+		{
+			name:    "npm",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "npm", Frozen: true},
+			want:    []string{"ci", "--no-audit", "--no-fund", "--prefer-offline"},
+		},
+		{
+			name:    "npm workspace with ignore scripts",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "npm", IsWorkspaceRoot: true, IgnoreScripts: true, Frozen: true},
+			want:    []string{"ci", "--no-audit", "--no-fund", "--prefer-offline", "--workspaces", "--ignore-scripts"},
+		},
+		{
+			name:    "pnpm",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "pnpm", Frozen: true},
+			want:    []string{"install", "--prefer-offline", "--frozen-lockfile"},
+		},
+		{
+			name:    "yarn classic",
+			project: types.NodeProject{Dir: t.TempDir(), PackageManager: "yarn", Frozen: true},
+			want:    []string{"install", "--non-interactive", "--prefer-offline", "--frozen-lockfile"},
+		},
+		{
+			name:    "yarn berry",
+			project: types.NodeProject{Dir: berryDir, PackageManager: "yarn", Frozen: true},
+			want:    []string{"install", "--non-interactive", "--prefer-offline", "--immutable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeInstallArgs(tt.project); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodeInstallArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrozenInstallRequiresLockFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "web"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	csproj := filepath.Join(dir, "api.csproj")
+	if err := os.WriteFile(csproj, []byte("<Project />"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		install func() error
+		want    string
+	}{
+		{
+			name: "npm",
+			install: func() error {
+				return installNodeDependenciesWithWriter(types.NodeProject{Dir: dir, PackageManager: "npm", Frozen: true}, io.Discard)
+			},
+			want: "package-lock.json or npm-shrinkwrap.json",
+		},
+		{
+			name: "uv",
+			install: func() error {
+				return setupPythonVirtualEnvWithWriter(types.PythonProject{Dir: dir, PackageManager: "uv", Frozen: true}, io.Discard)
+			},
+			want: "uv.lock",
+		},
+		{
+			name: "poetry",
+			install: func() error {
+				return setupPythonVirtualEnvWithWriter(types.PythonProject{Dir: dir, PackageManager: "poetry", Frozen: true}, io.Discard)
+			},
+			want: "poetry.lock",
+		},
+		{
+			name: "pip",
+			install: func() error {
+				return setupPythonVirtualEnvWithWriter(types.PythonProject{Dir: dir, PackageManager: "pip", Frozen: true}, io.Discard)
+			},
+			want: "requirements.txt",
+		},
+		{
+			name: "dotnet",
+			install: func() error {
+				return restoreDotnetProjectWithWriter(types.DotnetProject{Path: csproj, Frozen: true}, io.Discard)
+			},
+			want: "packages.lock.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.install()
+			if err == nil || !strings.Contains(err.Error(), "--frozen requires a committed lock file") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want a missing %s error", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireLockFile_WorkspaceRoot(t *testing.T) {
+	root := t.TempDir()
+	child := filepath.Join(root, "packages", "web")
+	if err := os.MkdirAll(child, 0750); err != nil {
+		t.Fatal(err)
+	}
+	lockFile := filepath.Join(root, "pnpm-lock.yaml")
+	if err := os.WriteFile(lockFile, []byte("lockfileVersion: '9.0'\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := requireLockFile("pnpm", child, root)
+	if err != nil || got != lockFile {
+		t.Errorf("requireLockFile() = %q, %v, want the workspace root's %s", got, err, lockFile)
+	}
+}
+
 // Helper function for case-insensitive contains check
 func containsIgnoreCase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
@@ -2,10 +2,13 @@ package installer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/jongio/azd-app/cli/src/internal/output"
@@ -32,6 +35,53 @@ type ParallelInstaller struct {
 	statusLines []output.StatusLine
 	Verbose     bool            // Show full installation output
 	ctx         context.Context // Context for cancellation
+
+	// ConcurrencyPerLanguage caps how many installs of a task type ("node", "python",
+	// "dotnet") run at once. Types without an entry run fully in parallel.
+	ConcurrencyPerLanguage map[string]int
+
+	// FailFast makes the first failed install cancel the installs that haven't started yet;
+	// they are recorded as skipped. Installs already running are left to finish, so no
+	// package manager is killed halfway through writing node_modules or a virtual env.
+	FailFast bool
+
+	// cancelRemaining cancels ctx after the first failure while a FailFast run is in progress
+	cancelRemaining context.CancelCauseFunc
+
+	// install runs a single task; nil uses executeTask. Replaced in tests.
+	install func(task ProjectInstallTask, writer io.Writer) error
+}
+
+// ErrInstallSkipped is the error of an install that FailFast skipped after another install failed.
+var ErrInstallSkipped = errors.New("skipped after another install failed")
+
+// ConcurrencyLanguages lists the task types that ConcurrencyPerLanguage can limit.
+var ConcurrencyLanguages = []string{"node", "python", "dotnet"}
+
+// ValidateConcurrencyPerLanguage checks that every language is known and every limit is positive.
+func ValidateConcurrencyPerLanguage(limits map[string]int) error {
+	languages := make([]string, 0, len(limits))
+	for language := range limits {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	for _, language := range languages {
+		known := false
+		for _, supported := range ConcurrencyLanguages {
+			if language == supported {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown language %q (supported: %s)", language, strings.Join(ConcurrencyLanguages, ", "))
+		}
+		if limits[language] < 1 {
+			return fmt.Errorf("concurrency for %s must be at least 1, got %d", language, limits[language])
+		}
+	}
+	return nil
 }
 
 // ProjectInstallResult represents the result of a project installation.
@@ -39,6 +89,7 @@ type ProjectInstallResult struct {
 	Task    ProjectInstallTask
 	Success bool
 	Error   error
+	Skipped bool // Not run because FailFast cancelled it after another install failed
 }
 
 // NewParallelInstaller creates a new parallel installer.
@@ -123,20 +174,65 @@ func (pi *ParallelInstaller) executeTask(task ProjectInstallTask, writer io.Writ
 	switch task.Type {
 	case "node":
 		if project, ok := task.Project.(types.NodeProject); ok {
-			return installNodeDependenciesWithWriter(project, writer)
+			return withInstallHooks(project.Hooks, writer, func() error {
+				return installNodeDependenciesWithWriter(project, writer)
+			})
 		}
 	case "python":
 		if project, ok := task.Project.(types.PythonProject); ok {
-			return setupPythonVirtualEnvWithWriter(project, writer)
+			return withInstallHooks(project.Hooks, writer, func() error {
+				return setupPythonVirtualEnvWithWriter(project, writer)
+			})
 		}
 	case "dotnet":
 		if project, ok := task.Project.(types.DotnetProject); ok {
-			return restoreDotnetProjectWithWriter(project, writer)
+			return withInstallHooks(project.Hooks, writer, func() error {
+				return restoreDotnetProjectWithWriter(project, writer)
+			})
 		}
 	}
 	return fmt.Errorf("unknown task type: %s", task.Type)
 }
 
+// runInstall runs a task with the configured install function.
+func (pi *ParallelInstaller) runInstall(task ProjectInstallTask, writer io.Writer) error {
+	if pi.install != nil {
+		return pi.install(task, writer)
+	}
+	return pi.executeTask(task, writer)
+}
+
+// newLanguageLimits creates a semaphore for each language with a concurrency limit.
+func (pi *ParallelInstaller) newLanguageLimits() map[string]chan struct{} {
+	limits := make(map[string]chan struct{}, len(pi.ConcurrencyPerLanguage))
+	for language, limit := range pi.ConcurrencyPerLanguage {
+		if limit > 0 {
+			limits[language] = make(chan struct{}, limit)
+		}
+	}
+	return limits
+}
+
+// acquireSlot waits for a free slot for the task's language and returns the function that
+// releases it. Languages without a limit return immediately.
+func (pi *ParallelInstaller) acquireSlot(limits map[string]chan struct{}, task ProjectInstallTask) (func(), error) {
+	sem, ok := limits[task.Type]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+	case <-pi.ctx.Done():
+		return nil, context.Cause(pi.ctx)
+	}
+	// Don't start a new install if cancellation raced with the slot being freed
+	if pi.ctx.Err() != nil {
+		<-sem
+		return nil, context.Cause(pi.ctx)
+	}
+	return func() { <-sem }, nil
+}
+
 // addResult safely adds a result to the results slice.
 func (pi *ParallelInstaller) addResult(result ProjectInstallResult) {
 	pi.mu.Lock()
@@ -152,12 +248,53 @@ func (pi *ParallelInstaller) addResult(result ProjectInstallResult) {
 		statusLine.Error = result.Error.Error()
 	}
 	pi.statusLines = append(pi.statusLines, statusLine)
+
+	if !result.Success && !result.Skipped && pi.cancelRemaining != nil {
+		pi.cancelRemaining(ErrInstallSkipped)
+	}
+}
+
+// skipTask records a task that was not run because installation was cancelled.
+func (pi *ParallelInstaller) skipTask(task ProjectInstallTask, err error) {
+	if pi.multiProg != nil {
+		if bar := pi.multiProg.GetBar(task.ID); bar != nil {
+			bar.Fail(err.Error())
+		}
+	}
+	pi.addResult(ProjectInstallResult{
+		Task:    task,
+		Success: false,
+		Error:   err,
+		Skipped: errors.Is(err, ErrInstallSkipped),
+	})
+}
+
+// startFailFast makes the first failure cancel the remaining installs when FailFast is set.
+// The returned function ends it once every task has a result.
+func (pi *ParallelInstaller) startFailFast() func() {
+	if !pi.FailFast {
+		return func() {}
+	}
+	parent := pi.ctx
+	ctx, cancel := context.WithCancelCause(parent)
+	pi.ctx = ctx
+	pi.mu.Lock()
+	pi.cancelRemaining = cancel
+	pi.mu.Unlock()
+	return func() {
+		pi.mu.Lock()
+		pi.cancelRemaining = nil
+		pi.mu.Unlock()
+		cancel(nil)
+		pi.ctx = parent
+	}
 }
 
 // Run executes all tasks with progress tracking.
 // Tasks are grouped by package manager to avoid race conditions:
 // - pnpm tasks run sequentially (shared global store causes conflicts)
 // - All other tasks (npm, yarn, pip, dotnet) run in parallel
+// ConcurrencyPerLanguage further caps how many installs of each language run at once.
 func (pi *ParallelInstaller) Run() error {
 	if len(pi.tasks) == 0 {
 		return nil
@@ -170,6 +307,9 @@ func (pi *ParallelInstaller) Run() error {
 	default:
 	}
 
+	endFailFast := pi.startFailFast()
+	defer endFailFast()
+
 	// In verbose mode, skip progress bars and show full output
 	if pi.Verbose {
 		return pi.runVerbose()
@@ -188,11 +328,12 @@ func (pi *ParallelInstaller) Run() error {
 
 	// Separate pnpm tasks from others
 	pnpmTasks, parallelTasks := pi.separateTasksByManager()
+	limits := pi.newLanguageLimits()
 
 	// Run all tasks
 	var wg sync.WaitGroup
 
-	// Run non-pnpm tasks in parallel
+	// Run non-pnpm tasks in parallel, up to the per-language limits
 	for _, task := range parallelTasks {
 		wg.Add(1)
 		go func(t ProjectInstallTask) {
@@ -206,6 +347,12 @@ func (pi *ParallelInstaller) Run() error {
 					})
 				}
 			}()
+			release, err := pi.acquireSlot(limits, t)
+			if err != nil {
+				pi.skipTask(t, err)
+				return
+			}
+			defer release()
 			pi.runTaskWithProgress(t)
 		}(task)
 	}
@@ -217,17 +364,18 @@ func (pi *ParallelInstaller) Run() error {
 			defer wg.Done()
 			for _, task := range pnpmTasks {
 				// Check for cancellation between tasks
-				select {
-				case <-pi.ctx.Done():
-					pi.addResult(ProjectInstallResult{
-						Task:    task,
-						Success: false,
-						Error:   pi.ctx.Err(),
-					})
-					return
-				default:
+				if pi.ctx.Err() != nil {
+					pi.skipTask(task, context.Cause(pi.ctx))
+					continue
+				}
+				// pnpm installs still count toward the node limit
+				release, err := pi.acquireSlot(limits, task)
+				if err != nil {
+					pi.skipTask(task, err)
+					continue
 				}
 				pi.runTaskWithProgress(task)
+				release()
 			}
 		}()
 	}
@@ -258,6 +406,10 @@ func (pi *ParallelInstaller) separateTasksByManager() (pnpmTasks, parallelTasks
 
 // runTaskWithProgress executes a task with progress bar tracking.
 func (pi *ParallelInstaller) runTaskWithProgress(task ProjectInstallTask) {
+	if pi.ctx.Err() != nil {
+		pi.skipTask(task, context.Cause(pi.ctx))
+		return
+	}
 	bar := pi.multiProg.GetBar(task.ID)
 	bar.Start()
 
@@ -268,7 +420,7 @@ func (pi *ParallelInstaller) runTaskWithProgress(task ProjectInstallTask) {
 		writer = os.Stdout
 	}
 
-	err := pi.executeTask(task, writer)
+	err := pi.runInstall(task, writer)
 
 	if err != nil {
 		bar.Fail(err.Error())
@@ -286,10 +438,11 @@ func (pi *ParallelInstaller) runTaskWithProgress(task ProjectInstallTask) {
 // runVerbose runs installations with full output instead of progress bars.
 func (pi *ParallelInstaller) runVerbose() error {
 	pnpmTasks, parallelTasks := pi.separateTasksByManager()
+	limits := pi.newLanguageLimits()
 
 	var wg sync.WaitGroup
 
-	// Run non-pnpm tasks in parallel
+	// Run non-pnpm tasks in parallel, up to the per-language limits
 	for _, task := range parallelTasks {
 		wg.Add(1)
 		go func(t ProjectInstallTask) {
@@ -303,6 +456,12 @@ func (pi *ParallelInstaller) runVerbose() error {
 					})
 				}
 			}()
+			release, err := pi.acquireSlot(limits, t)
+			if err != nil {
+				pi.skipTask(t, err)
+				return
+			}
+			defer release()
 			pi.runTaskVerbose(t)
 		}(task)
 	}
@@ -313,19 +472,20 @@ func (pi *ParallelInstaller) runVerbose() error {
 		go func() {
 			defer wg.Done()
 			for _, task := range pnpmTasks {
-				select {
-				case <-pi.ctx.Done():
-					pi.addResult(ProjectInstallResult{
-						Task:    task,
-						Success: false,
-						Error:   pi.ctx.Err(),
-					})
-					return
-				default:
+				if pi.ctx.Err() != nil {
+					pi.skipTask(task, context.Cause(pi.ctx))
+					continue
+				}
+				// pnpm installs still count toward the node limit
+				release, err := pi.acquireSlot(limits, task)
+				if err != nil {
+					pi.skipTask(task, err)
+					continue
 				}
 				// Print task header for clarity
 				fmt.Fprintf(os.Stdout, "\n=== Installing: %s ===\n", task.Description)
 				pi.runTaskVerbose(task)
+				release()
 			}
 		}()
 	}
@@ -338,7 +498,11 @@ func (pi *ParallelInstaller) runVerbose() error {
 
 // runTaskVerbose executes a single task with verbose output.
 func (pi *ParallelInstaller) runTaskVerbose(task ProjectInstallTask) {
-	err := pi.executeTask(task, os.Stdout)
+	if pi.ctx.Err() != nil {
+		pi.skipTask(task, context.Cause(pi.ctx))
+		return
+	}
+	err := pi.runInstall(task, os.Stdout)
 	pi.addResult(ProjectInstallResult{
 		Task:    task,
 		Success: err == nil,
@@ -384,16 +548,28 @@ func (pi *ParallelInstaller) HasFailures() bool {
 }
 
 // FailedProjects returns a list of project descriptions that failed installation.
+// Installs skipped by FailFast are not included; see SkippedProjects.
 func (pi *ParallelInstaller) FailedProjects() []string {
 	var failed []string
 	for _, result := range pi.results {
-		if !result.Success {
+		if !result.Success && !result.Skipped {
 			failed = append(failed, result.Task.Description)
 		}
 	}
 	return failed
 }
 
+// SkippedProjects returns the descriptions of the projects FailFast skipped after a failure.
+func (pi *ParallelInstaller) SkippedProjects() []string {
+	var skipped []string
+	for _, result := range pi.results {
+		if result.Skipped {
+			skipped = append(skipped, result.Task.Description)
+		}
+	}
+	return skipped
+}
+
 // TotalProjects returns the total number of projects that were processed.
 func (pi *ParallelInstaller) TotalProjects() int {
 	return len(pi.results)
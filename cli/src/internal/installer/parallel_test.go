@@ -2,6 +2,11 @@ package installer
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -674,3 +679,179 @@ func TestParallelInstaller_VerboseModePnpmSequencing(t *testing.T) {
 		}
 	}
 }
+
+// concurrencyRecorder is a fake installer that tracks the peak number of
+// concurrent installs for each task type.
+type concurrencyRecorder struct {
+	mu      sync.Mutex
+	current map[string]int
+	peak    map[string]int
+}
+
+func newConcurrencyRecorder() *concurrencyRecorder {
+	return &concurrencyRecorder{current: make(map[string]int), peak: make(map[string]int)}
+}
+
+func (r *concurrencyRecorder) install(task ProjectInstallTask, _ io.Writer) error {
+	r.mu.Lock()
+	r.current[task.Type]++
+	if r.current[task.Type] > r.peak[task.Type] {
+		r.peak[task.Type] = r.current[task.Type]
+	}
+	r.mu.Unlock()
+
+	// Hold the slot long enough for other installs to overlap
+	time.Sleep(20 * time.Millisecond)
+
+	r.mu.Lock()
+	r.current[task.Type]--
+	r.mu.Unlock()
+	return nil
+}
+
+func TestParallelInstaller_ConcurrencyPerLanguage(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		name := "progress"
+		if verbose {
+			name = "verbose"
+		}
+		t.Run(name, func(t *testing.T) {
+			recorder := newConcurrencyRecorder()
+			pi := NewParallelInstaller()
+			pi.Verbose = verbose
+			pi.install = recorder.install
+			pi.ConcurrencyPerLanguage = map[string]int{"node": 2, "python": 1}
+
+			for i := 0; i < 6; i++ {
+				pi.AddTask(ProjectInstallTask{ID: fmt.Sprintf("node%d", i), Type: "node", Manager: "npm"})
+				pi.AddTask(ProjectInstallTask{ID: fmt.Sprintf("python%d", i), Type: "python", Manager: "pip"})
+				pi.AddTask(ProjectInstallTask{ID: fmt.Sprintf("dotnet%d", i), Type: "dotnet", Manager: "dotnet"})
+			}
+			// pnpm installs run sequentially but still share the node limit
+			pi.AddTask(ProjectInstallTask{ID: "pnpm0", Type: "node", Manager: "pnpm"})
+			pi.AddTask(ProjectInstallTask{ID: "pnpm1", Type: "node", Manager: "pnpm"})
+
+			if err := pi.Run(); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if pi.HasFailures() || pi.TotalProjects() != 20 {
+				t.Fatalf("expected 20 successful installs, got %d (failed: %v)", pi.TotalProjects(), pi.FailedProjects())
+			}
+
+			if peak := recorder.peak["node"]; peak != 2 {
+				t.Errorf("peak node installs = %d, want 2", peak)
+			}
+			if peak := recorder.peak["python"]; peak != 1 {
+				t.Errorf("peak python installs = %d, want 1", peak)
+			}
+			// dotnet has no limit, so all of its installs overlap
+			if peak := recorder.peak["dotnet"]; peak < 2 {
+				t.Errorf("peak dotnet installs = %d, want unlimited parallelism", peak)
+			}
+		})
+	}
+}
+
+func TestParallelInstaller_ConcurrencyLimitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pi := NewParallelInstallerWithContext(ctx)
+	pi.Verbose = true
+	pi.ConcurrencyPerLanguage = map[string]int{"node": 1}
+	pi.install = func(task ProjectInstallTask, _ io.Writer) error {
+		// Cancel while the first install holds the only slot
+		cancel()
+		return nil
+	}
+	pi.AddTask(ProjectInstallTask{ID: "a", Type: "node", Manager: "npm"})
+	pi.AddTask(ProjectInstallTask{ID: "b", Type: "node", Manager: "npm"})
+
+	if err := pi.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(pi.FailedProjects()) != 1 {
+		t.Errorf("expected the waiting install to fail after cancellation, got results %+v", pi.GetResults())
+	}
+}
+
+func TestParallelInstaller_FailFast(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		for _, failFast := range []bool{false, true} {
+			t.Run(fmt.Sprintf("verbose=%v/failFast=%v", verbose, failFast), func(t *testing.T) {
+				var mu sync.Mutex
+				var installed []string
+				pi := NewParallelInstaller()
+				pi.Verbose = verbose
+				pi.FailFast = failFast
+				// One node install at a time, so the failure happens before the others start
+				pi.ConcurrencyPerLanguage = map[string]int{"node": 1}
+				pi.install = func(task ProjectInstallTask, _ io.Writer) error {
+					mu.Lock()
+					installed = append(installed, task.ID)
+					mu.Unlock()
+					if task.ID == "pnpm0" {
+						return errors.New("pnpm install failed")
+					}
+					return nil
+				}
+				pi.AddTask(ProjectInstallTask{ID: "pnpm0", Description: "pnpm0", Type: "node", Manager: "pnpm"})
+				pi.AddTask(ProjectInstallTask{ID: "pnpm1", Description: "pnpm1", Type: "node", Manager: "pnpm"})
+				pi.AddTask(ProjectInstallTask{ID: "pnpm2", Description: "pnpm2", Type: "node", Manager: "pnpm"})
+
+				if err := pi.Run(); err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				if pi.TotalProjects() != 3 {
+					t.Fatalf("expected a result for every project, got %+v", pi.GetResults())
+				}
+				if failed := pi.FailedProjects(); !reflect.DeepEqual(failed, []string{"pnpm0"}) {
+					t.Errorf("FailedProjects() = %v, want [pnpm0]", failed)
+				}
+
+				wantInstalled, wantSkipped := []string{"pnpm0", "pnpm1", "pnpm2"}, []string(nil)
+				if failFast {
+					wantInstalled, wantSkipped = []string{"pnpm0"}, []string{"pnpm1", "pnpm2"}
+				}
+				if !reflect.DeepEqual(installed, wantInstalled) {
+					t.Errorf("installed %v, want %v", installed, wantInstalled)
+				}
+				if skipped := pi.SkippedProjects(); !reflect.DeepEqual(skipped, wantSkipped) {
+					t.Errorf("SkippedProjects() = %v, want %v", skipped, wantSkipped)
+				}
+				for _, result := range pi.GetResults() {
+					if result.Skipped && !errors.Is(result.Error, ErrInstallSkipped) {
+						t.Errorf("skipped %s has error %v, want ErrInstallSkipped", result.Task.ID, result.Error)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestValidateConcurrencyPerLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  map[string]int
+		wantErr string
+	}{
+		{name: "empty"},
+		{name: "valid", limits: map[string]int{"node": 4, "python": 2, "dotnet": 1}},
+		{name: "unknown language", limits: map[string]int{"node": 4, "go": 2}, wantErr: `unknown language "go"`},
+		{name: "zero", limits: map[string]int{"node": 0}, wantErr: "at least 1"},
+		{name: "negative", limits: map[string]int{"python": -1}, wantErr: "at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConcurrencyPerLanguage(tt.limits)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateConcurrencyPerLanguage() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateConcurrencyPerLanguage() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package installer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/security"
+)
+
+// requiresPythonPattern matches the requires-python entry of a pyproject.toml [project] table.
+var requiresPythonPattern = regexp.MustCompile(`(?m)^\s*requires-python\s*=\s*["']([^"']+)["']`)
+
+// PythonVersionPin is the Python version a project asks for.
+type PythonVersionPin struct {
+	Spec   string // A version ("3.11") or PEP 440 specifier (">=3.10,<3.13")
+	Source string // File the pin was read from
+}
+
+// PinnedPythonVersion returns the Python version pinned by a project's .python-version
+// (first entry) or pyproject.toml requires-python, in that order. Entries that are not
+// version numbers, such as "system" or "pypy3.10", are ignored. Returns nil without a pin.
+func PinnedPythonVersion(projectDir string) (*PythonVersionPin, error) {
+	data, err := readProjectFile(projectDir, ".python-version")
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line[0] >= '0' && line[0] <= '9' {
+			return &PythonVersionPin{Spec: line, Source: ".python-version"}, nil
+		}
+		break
+	}
+
+	data, err = readProjectFile(projectDir, "pyproject.toml")
+	if err != nil {
+		return nil, err
+	}
+	if match := requiresPythonPattern.FindStringSubmatch(data); match != nil {
+		return &PythonVersionPin{Spec: strings.TrimSpace(match[1]), Source: "pyproject.toml requires-python"}, nil
+	}
+	return nil, nil
+}
+
+// readProjectFile reads a file in dir. A missing file reads as empty.
+func readProjectFile(dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if err := security.ValidatePath(path); err != nil {
+		return "", err
+	}
+
+	// #nosec G304 -- Path validated by security.ValidatePath
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// pythonFinder looks up Python interpreters. Its functions are swapped out in tests.
+type pythonFinder struct {
+	lookPath func(file string) (string, error)
+	output   func(name string, args ...string) (string, error)
+}
+
+// systemPythonFinder finds interpreters installed on this machine.
+var systemPythonFinder = pythonFinder{
+	lookPath: exec.LookPath,
+	output: func(name string, args ...string) (string, error) {
+		// #nosec G204 -- name is uv, pyenv or an interpreter found on PATH
+		out, err := exec.Command(name, args...).Output()
+		return strings.TrimSpace(string(out)), err
+	},
+}
+
+// selectPythonInterpreter returns the interpreter to create a project's virtual environment
+// with: "python" when the project pins no version, otherwise an interpreter matching the pin
+// found through uv, pyenv or PATH. It fails when no installed interpreter matches.
+func selectPythonInterpreter(projectDir string, finder pythonFinder) (string, error) {
+	pin, err := PinnedPythonVersion(projectDir)
+	if err != nil {
+		return "", err
+	}
+	if pin == nil {
+		return "python", nil
+	}
+	if _, err := parseVersionSpec(pin.Spec); err != nil {
+		return "", fmt.Errorf("invalid Python version %q in %s: %w", pin.Spec, pin.Source, err)
+	}
+
+	if path := finder.findPython(pin.Spec); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("python %s is required by %s but no matching interpreter was found (install it with 'uv python install %s' or 'pyenv install', or change the pin)", pin.Spec, pin.Source, pin.Spec)
+}
+
+// findPython returns the path of an installed interpreter matching spec, or "" if there is none.
+func (f pythonFinder) findPython(spec string) string {
+	// uv understands both versions and specifiers, and finds the interpreters it manages
+	if _, err := f.lookPath("uv"); err == nil {
+		if path, err := f.output("uv", "python", "find", "--no-python-downloads", spec); err == nil && path != "" {
+			return path
+		}
+	}
+
+	// pyenv: pick the newest installed version that matches
+	if _, err := f.lookPath("pyenv"); err == nil {
+		if list, err := f.output("pyenv", "versions", "--bare"); err == nil {
+			if version := newestMatchingVersion(strings.Fields(list), spec); version != "" {
+				if prefix, err := f.output("pyenv", "prefix", version); err == nil && prefix != "" {
+					if runtime.GOOS == "windows" {
+						return filepath.Join(prefix, "python.exe")
+					}
+					return filepath.Join(prefix, "bin", "python")
+				}
+			}
+		}
+	}
+
+	// PATH: a versioned executable for the pinned minor version, then the default interpreters
+	candidates := []string{"python3", "python"}
+	if version, ok := pinnedMinorVersion(spec); ok {
+		candidates = append([]string{"python" + version}, candidates...)
+	}
+	for _, name := range candidates {
+		path, err := f.lookPath(name)
+		if err != nil {
+			continue
+		}
+		version, err := f.output(path, "-c", "import sys; print('%d.%d.%d' % sys.version_info[:3])")
+		if err != nil {
+			continue
+		}
+		if ok, _ := versionMatches(version, spec); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// newestMatchingVersion returns the newest version in versions that matches spec.
+// Virtual environments listed as "<version>/envs/<name>" are skipped.
+func newestMatchingVersion(versions []string, spec string) string {
+	newest, newestParts := "", []int(nil)
+	for _, version := range versions {
+		if strings.Contains(version, "/") {
+			continue
+		}
+		if ok, _ := versionMatches(version, spec); !ok {
+			continue
+		}
+		parts, _ := parseVersion(version)
+		if newest == "" || compareVersions(parts, newestParts) > 0 {
+			newest, newestParts = version, parts
+		}
+	}
+	return newest
+}
+
+// pinnedMinorVersion returns "3.11" for specs that name a single minor version,
+// such as "3.11", "3.11.4", "==3.11.*" or "~=3.11.2".
+func pinnedMinorVersion(spec string) (string, bool) {
+	version := strings.TrimSpace(spec)
+	for _, op := range []string{"~=", "=="} {
+		version = strings.TrimPrefix(version, op)
+	}
+	if strings.ContainsAny(version, ",<>!=~") {
+		return "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimSpace(version), ".*"), ".")
+	if len(parts) < 2 {
+		return "", false
+	}
+	return parts[0] + "." + parts[1], true
+}
+
+// versionClause is one comparison in a version specifier.
+type versionClause struct {
+	op       string // "", "==", "!=", ">=", "<=", ">", "<" or "~="; "" matches a version prefix
+	version  []int
+	wildcard bool // "==3.11.*"
+}
+
+// parseVersionSpec parses a bare version ("3.11") or a comma-separated PEP 440 specifier.
+func parseVersionSpec(spec string) ([]versionClause, error) {
+	var clauses []versionClause
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		clause := versionClause{}
+		for _, op := range []string{"~=", "==", "!=", ">=", "<=", ">", "<"} {
+			if strings.HasPrefix(part, op) {
+				clause.op = op
+				part = strings.TrimSpace(strings.TrimPrefix(part, op))
+				break
+			}
+		}
+		if strings.HasSuffix(part, ".*") {
+			if clause.op != "==" && clause.op != "!=" {
+				return nil, fmt.Errorf("wildcard only allowed with == or !=: %s", spec)
+			}
+			clause.wildcard = true
+			part = strings.TrimSuffix(part, ".*")
+		}
+		version, err := parseVersion(part)
+		if err != nil {
+			return nil, err
+		}
+		if clause.op == "~=" && len(version) < 2 {
+			return nil, fmt.Errorf("~= needs at least a major and minor version: %s", spec)
+		}
+		clause.version = version
+		clauses = append(clauses, clause)
+	}
+	return clauses, nil
+}
+
+// versionMatches reports whether an interpreter version such as "3.11.4" satisfies spec.
+func versionMatches(version, spec string) (bool, error) {
+	parts, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	clauses, err := parseVersionSpec(spec)
+	if err != nil {
+		return false, err
+	}
+	for _, clause := range clauses {
+		if !clause.matches(parts) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matches reports whether version satisfies the clause.
+func (c versionClause) matches(version []int) bool {
+	cmp := compareVersions(version, c.version)
+	switch c.op {
+	case "":
+		return hasVersionPrefix(version, c.version)
+	case "==":
+		if c.wildcard {
+			return hasVersionPrefix(version, c.version)
+		}
+		return cmp == 0
+	case "!=":
+		if c.wildcard {
+			return !hasVersionPrefix(version, c.version)
+		}
+		return cmp != 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "~=":
+		// ~=3.11.2 means >=3.11.2 and ==3.11.*
+		return cmp >= 0 && hasVersionPrefix(version, c.version[:len(c.version)-1])
+	}
+	return false
+}
+
+// parseVersion parses the numeric release part of a version, e.g. "3.11.4" or "3.13.0rc1".
+func parseVersion(version string) ([]int, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil, errors.New("empty version")
+	}
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		end := 0
+		for end < len(field) && field[end] >= '0' && field[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		n, err := strconv.Atoi(field[:end])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		parts = append(parts, n)
+		if end < len(field) {
+			break // pre-release or local suffix
+		}
+	}
+	return parts, nil
+}
+
+// compareVersions compares two versions, treating missing parts as 0.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// hasVersionPrefix reports whether version starts with prefix, e.g. 3.11.4 with 3.11.
+func hasVersionPrefix(version, prefix []int) bool {
+	if len(version) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if version[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
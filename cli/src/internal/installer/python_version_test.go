@@ -0,0 +1,240 @@
+package installer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPinnedPythonVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		wantSpec   string
+		wantSource string
+	}{
+		{
+			name:  "no pin",
+			files: map[string]string{"requirements.txt": "flask\n"},
+		},
+		{
+			name:       "python-version",
+			files:      map[string]string{".python-version": "# pyenv\n3.11.4\n3.12\n"},
+			wantSpec:   "3.11.4",
+			wantSource: ".python-version",
+		},
+		{
+			name: "python-version wins over pyproject",
+			files: map[string]string{
+				".python-version": "3.12\n",
+				"pyproject.toml":  "[project]\nrequires-python = \">=3.10\"\n",
+			},
+			wantSpec:   "3.12",
+			wantSource: ".python-version",
+		},
+		{
+			name: "non-numeric python-version falls back to pyproject",
+			files: map[string]string{
+				".python-version": "system\n",
+				"pyproject.toml":  "[project]\nname = \"api\"\nrequires-python = '>=3.10,<3.13'\n",
+			},
+			wantSpec:   ">=3.10,<3.13",
+			wantSource: "pyproject.toml requires-python",
+		},
+		{
+			name:  "pyproject without requires-python",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"api\"\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pin, err := PinnedPythonVersion(writeProjectFiles(t, tt.files))
+			if err != nil {
+				t.Fatalf("PinnedPythonVersion() error = %v", err)
+			}
+			if tt.wantSpec == "" {
+				if pin != nil {
+					t.Errorf("PinnedPythonVersion() = %+v, want nil", pin)
+				}
+				return
+			}
+			if pin == nil || pin.Spec != tt.wantSpec || pin.Source != tt.wantSource {
+				t.Errorf("PinnedPythonVersion() = %+v, want {%s %s}", pin, tt.wantSpec, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		version string
+		spec    string
+		want    bool
+	}{
+		{"3.11.4", "3.11", true},
+		{"3.11.4", "3.11.4", true},
+		{"3.12.1", "3.11", false},
+		{"3.1.0", "3.11", false},
+		{"3.12.1", ">=3.10,<3.13", true},
+		{"3.13.0", ">=3.10,<3.13", false},
+		{"3.9.18", ">=3.10", false},
+		{"3.11.9", "==3.11.*", true},
+		{"3.12.0", "==3.11.*", false},
+		{"3.11.4", "~=3.11.2", true},
+		{"3.12.0", "~=3.11.2", false},
+		{"3.12.0", "~=3.11", true},
+		{"3.11.0", "!=3.11.0", false},
+		{"3.13.0rc1", ">=3.13", true},
+	}
+
+	for _, tt := range tests {
+		got, err := versionMatches(tt.version, tt.spec)
+		if err != nil {
+			t.Errorf("versionMatches(%q, %q) error = %v", tt.version, tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("versionMatches(%q, %q) = %v, want %v", tt.version, tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"latest", ">=3.11.*", "~=3"} {
+		if _, err := versionMatches("3.11.4", spec); err == nil {
+			t.Errorf("versionMatches(%q) expected error", spec)
+		}
+	}
+}
+
+// fakePythonFinder returns a finder with the onPath executables on PATH, where running a
+// command prints its entry in outputs (keyed by command line) and fails without one.
+func fakePythonFinder(onPath []string, outputs map[string]string) pythonFinder {
+	return pythonFinder{
+		lookPath: func(file string) (string, error) {
+			for _, name := range onPath {
+				if name == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+		output: func(name string, args ...string) (string, error) {
+			if out, ok := outputs[strings.Join(append([]string{filepath.Base(name)}, args...), " ")]; ok {
+				return out, nil
+			}
+			return "", errors.New("exit status 1")
+		},
+	}
+}
+
+const versionCheck = "-c import sys; print('%d.%d.%d' % sys.version_info[:3])"
+
+func TestSelectPythonInterpreter(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		finder  pythonFinder
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "no pin uses python",
+			files:  map[string]string{"requirements.txt": "flask\n"},
+			finder: fakePythonFinder(nil, nil),
+			want:   "python",
+		},
+		{
+			name:  "uv finds the pinned version",
+			files: map[string]string{".python-version": "3.11\n"},
+			finder: fakePythonFinder([]string{"uv", "python3"}, map[string]string{
+				"uv python find --no-python-downloads 3.11": "/home/me/.local/share/uv/python/cpython-3.11.9/bin/python3.11",
+				"python3 " + versionCheck:                   "3.12.1",
+			}),
+			want: "/home/me/.local/share/uv/python/cpython-3.11.9/bin/python3.11",
+		},
+		{
+			name:  "pyenv picks the newest matching version",
+			files: map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.10,<3.13\"\n"},
+			finder: fakePythonFinder([]string{"pyenv"}, map[string]string{
+				"pyenv versions --bare":   "3.9.18\n3.10.13\n3.12.2\n3.12.2/envs/tools\n3.13.0",
+				"pyenv prefix 3.12.2":     "/home/me/.pyenv/versions/3.12.2",
+				"pyenv prefix 3.10.13":    "/home/me/.pyenv/versions/3.10.13",
+				"pyenv prefix 3.13.0":     "/home/me/.pyenv/versions/3.13.0",
+				"python3 " + versionCheck: "3.9.18",
+			}),
+			want: pyenvPython("/home/me/.pyenv/versions/3.12.2"),
+		},
+		{
+			name:  "versioned interpreter on PATH",
+			files: map[string]string{".python-version": "3.11.4\n"},
+			finder: fakePythonFinder([]string{"python3.11", "python3"}, map[string]string{
+				"python3.11 " + versionCheck: "3.11.4",
+				"python3 " + versionCheck:    "3.12.1",
+			}),
+			want: "/usr/bin/python3.11",
+		},
+		{
+			name:  "default interpreter on PATH matches",
+			files: map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.10\"\n"},
+			finder: fakePythonFinder([]string{"python3"}, map[string]string{
+				"python3 " + versionCheck: "3.12.1",
+			}),
+			want: "/usr/bin/python3",
+		},
+		{
+			name:  "pinned version not installed",
+			files: map[string]string{".python-version": "3.13\n"},
+			finder: fakePythonFinder([]string{"uv", "pyenv", "python3", "python"}, map[string]string{
+				"pyenv versions --bare":   "3.11.9\n3.12.2",
+				"python3 " + versionCheck: "3.12.1",
+				"python " + versionCheck:  "3.12.1",
+			}),
+			wantErr: "python 3.13 is required by .python-version but no matching interpreter was found",
+		},
+		{
+			name:    "invalid pin",
+			files:   map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.x\"\n"},
+			finder:  fakePythonFinder([]string{"python3"}, nil),
+			wantErr: "invalid Python version \">=3.x\" in pyproject.toml requires-python",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectPythonInterpreter(writeProjectFiles(t, tt.files), tt.finder)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectPythonInterpreter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectPythonInterpreter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("selectPythonInterpreter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func pyenvPython(prefix string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(prefix, "python.exe")
+	}
+	return filepath.Join(prefix, "bin", "python")
+}
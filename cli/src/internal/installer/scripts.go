@@ -0,0 +1,88 @@
+package installer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/security"
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// installLifecycleScripts are the package.json scripts npm, pnpm and yarn run on install,
+// in the order they run.
+var installLifecycleScripts = []string{"preinstall", "install", "postinstall", "preprepare", "prepare", "postprepare"}
+
+// NodeLifecycleScripts lists the scripts installing a Node.js project would run: the project's
+// own lifecycle scripts as "postinstall: <command>", followed by the dependencies that
+// package-lock.json marks as having install scripts, as "<package> (dependency)".
+// These are the scripts deps --ignore-scripts skips.
+func NodeLifecycleScripts(project types.NodeProject) ([]string, error) {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := readProjectJSON(project.Dir, "package.json", &pkg); err != nil {
+		return nil, err
+	}
+
+	var scripts []string
+	for _, name := range installLifecycleScripts {
+		if command, ok := pkg.Scripts[name]; ok {
+			scripts = append(scripts, fmt.Sprintf("%s: %s", name, command))
+		}
+	}
+
+	var lock struct {
+		Packages map[string]struct {
+			HasInstallScript bool `json:"hasInstallScript"`
+		} `json:"packages"`
+	}
+	if err := readProjectJSON(project.Dir, "package-lock.json", &lock); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var dependencies []string
+	for path, entry := range lock.Packages {
+		// The "" entry is the project itself, whose scripts are listed above
+		if !entry.HasInstallScript || path == "" {
+			continue
+		}
+		name := path
+		if i := strings.LastIndex(path, "node_modules/"); i >= 0 {
+			name = path[i+len("node_modules/"):]
+		}
+		if !seen[name] {
+			seen[name] = true
+			dependencies = append(dependencies, name+" (dependency)")
+		}
+	}
+	sort.Strings(dependencies)
+
+	return append(scripts, dependencies...), nil
+}
+
+// readProjectJSON decodes a JSON file in dir into v. A missing file leaves v unchanged.
+func readProjectJSON(dir, name string, v interface{}) error {
+	path := filepath.Join(dir, name)
+	if err := security.ValidatePath(path); err != nil {
+		return err
+	}
+
+	// #nosec G304 -- Path validated by security.ValidatePath
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+func TestNodeLifecycleScripts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json": `{
+			"name": "web",
+			"scripts": {"build": "vite build", "prepare": "husky install", "postinstall": "node scripts/setup.js"}
+		}`,
+		"package-lock.json": `{
+			"lockfileVersion": 3,
+			"packages": {
+				"": {"name": "web", "hasInstallScript": true},
+				"node_modules/esbuild": {"hasInstallScript": true},
+				"node_modules/vite/node_modules/esbuild": {"hasInstallScript": true},
+				"node_modules/@parcel/watcher": {"hasInstallScript": true},
+				"node_modules/react": {}
+			}
+		}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := NodeLifecycleScripts(types.NodeProject{Dir: dir, PackageManager: "npm"})
+	if err != nil {
+		t.Fatalf("NodeLifecycleScripts() error = %v", err)
+	}
+	want := []string{
+		"postinstall: node scripts/setup.js",
+		"prepare: husky install",
+		"@parcel/watcher (dependency)",
+		"esbuild (dependency)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NodeLifecycleScripts() = %v, want %v", got, want)
+	}
+}
+
+func TestNodeLifecycleScripts_NoScripts(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "api"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NodeLifecycleScripts(types.NodeProject{Dir: dir, PackageManager: "pnpm"})
+	if err != nil || len(got) != 0 {
+		t.Errorf("NodeLifecycleScripts() = %v, %v, want no scripts", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NodeLifecycleScripts(types.NodeProject{Dir: dir}); err == nil || !strings.Contains(err.Error(), "failed to parse") {
+		t.Errorf("NodeLifecycleScripts() with invalid package.json error = %v", err)
+	}
+}
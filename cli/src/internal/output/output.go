@@ -4,11 +4,16 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Format represents the output format.
@@ -19,6 +24,10 @@ const (
 	FormatDefault Format = "default"
 	// FormatJSON is JSON format.
 	FormatJSON Format = "json"
+	// FormatYAML is YAML format.
+	FormatYAML Format = "yaml"
+	// FormatTable is a human-readable columnar table format.
+	FormatTable Format = "table"
 )
 
 // ANSI color codes for consistent styling
@@ -89,6 +98,17 @@ var (
 // Global output format setting
 var globalFormat Format = FormatDefault
 
+// Progress destinations for decorative output (headers, sections, steps, progress bars).
+const (
+	// ProgressToStderr routes decorative output to stderr so stdout carries only results.
+	ProgressToStderr = "stderr"
+	// ProgressToStdout routes decorative output to stdout alongside results.
+	ProgressToStdout = "stdout"
+)
+
+// progressDestination controls where decorative output is written.
+var progressDestination = ProgressToStderr
+
 // orchestratedMode indicates if running as part of command orchestration
 var orchestratedMode = false
 
@@ -103,6 +123,21 @@ func IsOrchestrated() bool {
 	return orchestratedMode
 }
 
+// quietMode suppresses decorative output for scripting
+var quietMode = false
+
+// SetQuiet sets the quiet mode flag.
+// When true, headers and progress output are not printed; results, including
+// informational items, warnings and errors still are.
+func SetQuiet(value bool) {
+	quietMode = value
+}
+
+// IsQuiet returns true if running in quiet mode.
+func IsQuiet() bool {
+	return quietMode
+}
+
 // supportsUnicode detects if the terminal supports Unicode/emojis
 var supportsUnicode = detectUnicodeSupport()
 
@@ -163,12 +198,48 @@ func SetFormat(format string) error {
 		globalFormat = FormatDefault
 	case "json":
 		globalFormat = FormatJSON
+	case "yaml":
+		globalFormat = FormatYAML
+	case "table":
+		globalFormat = FormatTable
+	default:
+		return fmt.Errorf("invalid output format: %s (valid options: default, json, yaml, table)", format)
+	}
+	return nil
+}
+
+// SetProgressDestination sets where decorative output (headers, sections, steps,
+// hints and progress bars) is written. Results such as JSON and tables always go to stdout.
+func SetProgressDestination(dest string) error {
+	switch dest {
+	case ProgressToStderr, "":
+		progressDestination = ProgressToStderr
+	case ProgressToStdout:
+		progressDestination = ProgressToStdout
 	default:
-		return fmt.Errorf("invalid output format: %s (valid options: default, json)", format)
+		return fmt.Errorf("invalid progress destination: %s (valid options: stderr, stdout)", dest)
 	}
 	return nil
 }
 
+// GetProgressDestination returns the current progress destination.
+func GetProgressDestination() string {
+	return progressDestination
+}
+
+// ProgressWriter returns the writer used for decorative output.
+// Resolved on each call so redirected os.Stdout/os.Stderr are honored.
+// Decorative output is discarded in quiet mode.
+func ProgressWriter() io.Writer {
+	if quietMode {
+		return io.Discard
+	}
+	if progressDestination == ProgressToStdout {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
 // GetFormat returns the current output format.
 func GetFormat() Format {
 	return globalFormat
@@ -179,13 +250,87 @@ func IsJSON() bool {
 	return globalFormat == FormatJSON
 }
 
+// IsYAML returns true if the output format is YAML.
+func IsYAML() bool {
+	return globalFormat == FormatYAML
+}
+
+// IsTable returns true if the output format is table.
+func IsTable() bool {
+	return globalFormat == FormatTable
+}
+
+// IsStructured returns true if the output format is machine-readable (JSON or YAML).
+// Human-readable output should be suppressed in structured modes.
+func IsStructured() bool {
+	return globalFormat == FormatJSON || globalFormat == FormatYAML
+}
+
 // PrintJSON prints data as JSON to stdout.
+// With a query set (SetQuery), only the values it selects are printed, and the results of
+// commands run as dependencies in orchestrated mode are left out.
 func PrintJSON(data interface{}) error {
+	if activeQuery != nil {
+		if orchestratedMode {
+			return nil
+		}
+		selected, err := activeQuery.Apply(data)
+		if err != nil {
+			return err
+		}
+		data = selected
+	}
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
 
+// PrintYAML prints data as YAML to stdout.
+// The data is round-tripped through JSON first so existing json tags (names,
+// omitempty, custom marshalers) apply and YAML output mirrors the JSON output.
+func PrintYAML(data interface{}) error {
+	return writeYAML(os.Stdout, data)
+}
+
+// PrintStructured prints data as YAML when the output format is YAML, otherwise as JSON.
+func PrintStructured(data interface{}) error {
+	if globalFormat == FormatYAML {
+		return PrintYAML(data)
+	}
+	return PrintJSON(data)
+}
+
+// writeYAML writes data as YAML, honoring json tags and preserving field order.
+func writeYAML(w io.Writer, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	// JSON is valid YAML, so decoding into a node keeps key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return fmt.Errorf("failed to convert data to YAML: %w", err)
+	}
+	clearYAMLStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// clearYAMLStyle resets the flow and quoting styles inherited from the JSON source
+// so the encoder emits block-style YAML, quoting only where required.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
 // PrintDefault prints data in default format using a custom formatter function.
 func PrintDefault(formatter func()) {
 	if globalFormat == FormatDefault {
@@ -195,10 +340,10 @@ func PrintDefault(formatter func()) {
 
 // Print outputs data in the configured format.
 // For default format, uses the formatter function.
-// For JSON format, marshals the data object.
+// For JSON and YAML formats, marshals the data object.
 func Print(data interface{}, formatter func()) error {
-	if globalFormat == FormatJSON {
-		return PrintJSON(data)
+	if IsStructured() {
+		return PrintStructured(data)
 	}
 	formatter()
 	return nil
@@ -208,27 +353,29 @@ func Print(data interface{}, formatter func()) error {
 
 // Header prints a bold header with a divider
 func Header(text string) {
-	fmt.Printf("\n%s%s%s\n", Bold, text, Reset)
-	fmt.Println(strings.Repeat("=", len(text)))
+	w := ProgressWriter()
+	fmt.Fprintf(w, "\n%s%s%s\n", Bold, text, Reset)
+	fmt.Fprintln(w, strings.Repeat("=", len(text)))
 }
 
 // CommandHeader prints a minimal command header.
 // Shows just the command name with a short divider.
-// Skipped when in orchestrated mode (subcommands don't print headers).
+// Skipped when in orchestrated mode (subcommands don't print headers) or quiet mode.
 func CommandHeader(command, _ string) {
-	if globalFormat == FormatJSON || orchestratedMode {
+	if IsStructured() || orchestratedMode || quietMode {
 		return
 	}
-	fmt.Println()
-	fmt.Printf("%sazd app %s%s\n", Bold, command, Reset)
-	fmt.Println(strings.Repeat("─", 30))
-	fmt.Println()
+	w := ProgressWriter()
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%sazd app %s%s\n", Bold, command, Reset)
+	fmt.Fprintln(w, strings.Repeat("─", 30))
+	fmt.Fprintln(w)
 }
 
 // Section prints a section header
 func Section(icon, text string) {
 	displayIcon := getIcon(icon, "[>]")
-	fmt.Printf("\n%s%s %s%s\n", Cyan, displayIcon, text, Reset)
+	fmt.Fprintf(ProgressWriter(), "\n%s%s %s%s\n", Cyan, displayIcon, text, Reset)
 }
 
 // Success prints a success message with green checkmark
@@ -263,7 +410,7 @@ func Info(format string, args ...interface{}) {
 func Step(icon, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	displayIcon := getIcon(icon, "[*]")
-	fmt.Printf("%s%s%s %s\n", Cyan, displayIcon, Reset, msg)
+	fmt.Fprintf(ProgressWriter(), "%s%s%s %s\n", Cyan, displayIcon, Reset, msg)
 }
 
 // Item prints an indented item
@@ -309,7 +456,7 @@ func ItemInfo(format string, args ...interface{}) {
 
 // Divider prints a horizontal divider
 func Divider() {
-	fmt.Printf("\n%s%s%s\n", Dim, strings.Repeat("─", 50), Reset)
+	fmt.Fprintf(ProgressWriter(), "\n%s%s%s\n", Dim, strings.Repeat("─", 50), Reset)
 }
 
 // Newline prints a blank line
@@ -323,12 +470,12 @@ func Hint(hints ...string) {
 	if len(hints) == 0 {
 		return
 	}
-	fmt.Printf("%s%s%s\n", Dim, strings.Join(hints, " • "), Reset)
+	fmt.Fprintf(ProgressWriter(), "%s%s%s\n", Dim, strings.Join(hints, " • "), Reset)
 }
 
 // Phase prints a phase label like "Installing dependencies..." or "Starting services..."
 func Phase(label string) {
-	fmt.Printf("%s%s%s\n", Dim, label, Reset)
+	fmt.Fprintf(ProgressWriter(), "%s%s%s\n", Dim, label, Reset)
 }
 
 // Plain prints plain text without any formatting.
@@ -337,10 +484,10 @@ func Plain(format string, args ...interface{}) {
 }
 
 // Confirm prompts the user for confirmation and returns true if they confirm.
-// Returns true immediately if in JSON mode (non-interactive).
+// Returns true immediately if in JSON or YAML mode (non-interactive).
 // The prompt displays the message and waits for y/n input.
 func Confirm(message string) bool {
-	if globalFormat == FormatJSON {
+	if IsStructured() {
 		return true // Non-interactive mode, assume yes
 	}
 	fmt.Printf("%s%s%s [y/N]: ", BrightYellow, message, Reset)
@@ -417,6 +564,53 @@ func ProgressBar(current, total int, width int) string {
 	return fmt.Sprintf("[%s] %d%%", bar, int(percent*100))
 }
 
+// ColorEnabled reports whether ANSI colors should be written to stdout.
+// Colors are disabled when NO_COLOR is set (https://no-color.org) or stdout is not a terminal.
+func ColorEnabled() bool {
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return false
+	}
+	return IsStdoutTerminal()
+}
+
+// IsStdoutTerminal reports whether stdout is a terminal rather than a pipe or file.
+func IsStdoutTerminal() bool {
+	fileInfo, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return (fileInfo.Mode() & os.ModeCharDevice) != 0
+}
+
+// PrintTable prints rows as tab-aligned columns to stdout.
+// The header row is bold when colors are enabled (see ColorEnabled).
+func PrintTable(headers []string, rows [][]string) error {
+	return writeTable(os.Stdout, headers, rows, ColorEnabled())
+}
+
+// writeTable renders the table with tabwriter. Colors are applied after alignment
+// since tabwriter counts escape sequences as visible characters.
+func writeTable(w io.Writer, headers []string, rows [][]string, color bool) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+
+	table := buf.String()
+	if color {
+		header, body, _ := strings.Cut(table, "\n")
+		table = Bold + header + Reset + "\n" + body
+	}
+
+	_, err := io.WriteString(w, table)
+	return err
+}
+
 // TableRow represents a row in a table as a map of column header to value.
 type TableRow map[string]string
 
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestSetFormat(t *testing.T) {
@@ -25,6 +27,11 @@ func TestSetFormat(t *testing.T) {
 			format:  "json",
 			wantErr: false,
 		},
+		{
+			name:    "yaml format",
+			format:  "yaml",
+			wantErr: false,
+		},
 		{
 			name:    "empty format (defaults to default)",
 			format:  "",
@@ -120,6 +127,98 @@ func TestPrintJSON(t *testing.T) {
 	}
 }
 
+func TestPrintYAML(t *testing.T) {
+	type dependency struct {
+		Name     string `json:"name"`
+		Version  string `json:"version"`
+		Optional bool   `json:"optional,omitempty"`
+	}
+	type result struct {
+		Project      string       `json:"projectDir"`
+		Dependencies []dependency `json:"dependencies"`
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintYAML(result{
+		Project:      "/app",
+		Dependencies: []dependency{{Name: "node", Version: "1.0"}},
+	})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintYAML() error = %v, want nil", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to copy output: %v", err)
+	}
+	out := buf.String()
+
+	// json tags drive key names and omitempty; output is block style
+	want := "projectDir: /app\ndependencies:\n  - name: node\n    version: \"1.0\"\n"
+	if out != want {
+		t.Errorf("PrintYAML() output =\n%s\nwant:\n%s", out, want)
+	}
+
+	var decoded map[string]interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("PrintYAML() output is not valid YAML: %v", err)
+	}
+	deps, ok := decoded["dependencies"].([]interface{})
+	if !ok || len(deps) != 1 {
+		t.Fatalf("dependencies = %v, want one entry", decoded["dependencies"])
+	}
+	if version := deps[0].(map[string]interface{})["version"]; version != "1.0" {
+		t.Errorf("version = %v (%T), want string 1.0", version, version)
+	}
+}
+
+func TestPrintStructured(t *testing.T) {
+	defer func() { _ = SetFormat("default") }()
+
+	capture := func() string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		err := PrintStructured(map[string]string{"name": "test"})
+		w.Close()
+		os.Stdout = oldStdout
+		if err != nil {
+			t.Fatalf("PrintStructured() error = %v", err)
+		}
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		return buf.String()
+	}
+
+	_ = SetFormat("yaml")
+	if !IsStructured() || !IsYAML() || IsJSON() {
+		t.Errorf("yaml format: IsStructured=%v IsYAML=%v IsJSON=%v", IsStructured(), IsYAML(), IsJSON())
+	}
+	if got := capture(); got != "name: test\n" {
+		t.Errorf("PrintStructured() in yaml mode = %q, want %q", got, "name: test\n")
+	}
+
+	_ = SetFormat("json")
+	if !IsStructured() {
+		t.Error("IsStructured() = false, want true for json")
+	}
+	if got := capture(); !strings.HasPrefix(got, "{") {
+		t.Errorf("PrintStructured() in json mode = %q, want JSON", got)
+	}
+
+	_ = SetFormat("default")
+	if IsStructured() {
+		t.Error("IsStructured() = true, want false for default")
+	}
+}
+
 func TestPrintDefault(t *testing.T) {
 	// Set to default format
 	_ = SetFormat("default")
@@ -241,16 +340,16 @@ func TestCommandHeaderInDefaultMode(t *testing.T) {
 		SetOrchestrated(false)
 	}()
 
-	// Capture stdout
-	oldStdout := os.Stdout
+	// Capture stderr (headers are decorative output, routed to stderr by default)
+	oldStderr := os.Stderr
 	r, w, _ := os.Pipe()
-	os.Stdout = w
+	os.Stderr = w
 
 	CommandHeader("test", "Test command description")
 
-	// Restore stdout
+	// Restore stderr
 	w.Close()
-	os.Stdout = oldStdout
+	os.Stderr = oldStderr
 
 	// Read captured output
 	var buf bytes.Buffer
@@ -597,6 +696,73 @@ func TestTableEmpty(t *testing.T) {
 	}
 }
 
+func TestWriteTable(t *testing.T) {
+	headers := []string{"NAME", "LANGUAGE", "PORT"}
+	rows := [][]string{
+		{"api", "python", "8000"},
+		{"frontend-web", "ts", ""},
+	}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, headers, rows, false); err != nil {
+		t.Fatalf("writeTable() error = %v", err)
+	}
+
+	want := "NAME          LANGUAGE  PORT\n" +
+		"api           python    8000\n" +
+		"frontend-web  ts        \n"
+	if buf.String() != want {
+		t.Errorf("writeTable() =\n%q\nwant:\n%q", buf.String(), want)
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Error("writeTable() without color should not contain ANSI codes")
+	}
+
+	// Colors wrap the header without changing column alignment
+	buf.Reset()
+	if err := writeTable(&buf, headers, rows, true); err != nil {
+		t.Fatalf("writeTable() error = %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if lines[0] != Bold+"NAME          LANGUAGE  PORT"+Reset {
+		t.Errorf("colored header = %q", lines[0])
+	}
+	if lines[1] != "api           python    8000" {
+		t.Errorf("colored rows should be unchanged, got %q", lines[1])
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled() {
+		t.Error("ColorEnabled() = true, want false when NO_COLOR is set")
+	}
+
+	// A pipe is never a terminal
+	os.Unsetenv("NO_COLOR")
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	enabled := ColorEnabled()
+	w.Close()
+	r.Close()
+	os.Stdout = oldStdout
+	if enabled {
+		t.Error("ColorEnabled() = true, want false when stdout is not a TTY")
+	}
+}
+
+func TestSetFormatTable(t *testing.T) {
+	defer func() { _ = SetFormat("default") }()
+
+	if err := SetFormat("table"); err != nil {
+		t.Fatalf("SetFormat(table) error = %v", err)
+	}
+	if !IsTable() || IsStructured() {
+		t.Errorf("table format: IsTable=%v IsStructured=%v, want true/false", IsTable(), IsStructured())
+	}
+}
+
 func TestLabelColored(t *testing.T) {
 	// Capture stdout
 	oldStdout := os.Stdout
@@ -647,3 +813,140 @@ func TestItemInfo(t *testing.T) {
 		t.Errorf("ItemInfo() output = %q, want to contain 'Test info item'", output)
 	}
 }
+
+func TestSetProgressDestination(t *testing.T) {
+	defer func() { _ = SetProgressDestination(ProgressToStderr) }()
+
+	tests := []struct {
+		name    string
+		dest    string
+		want    string
+		wantErr bool
+	}{
+		{name: "stderr", dest: "stderr", want: ProgressToStderr},
+		{name: "stdout", dest: "stdout", want: ProgressToStdout},
+		{name: "empty defaults to stderr", dest: "", want: ProgressToStderr},
+		{name: "invalid", dest: "file", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetProgressDestination(tt.dest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetProgressDestination(%q) error = %v, wantErr %v", tt.dest, err, tt.wantErr)
+			}
+			if !tt.wantErr && GetProgressDestination() != tt.want {
+				t.Errorf("GetProgressDestination() = %q, want %q", GetProgressDestination(), tt.want)
+			}
+		})
+	}
+}
+
+// captureStdoutAndStderr runs fn and returns what it wrote to stdout and stderr.
+func captureStdoutAndStderr(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+
+	fn()
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+
+	var outBuf, errBuf bytes.Buffer
+	if _, err := io.Copy(&outBuf, outR); err != nil {
+		t.Fatalf("failed to copy stdout: %v", err)
+	}
+	if _, err := io.Copy(&errBuf, errR); err != nil {
+		t.Fatalf("failed to copy stderr: %v", err)
+	}
+	return outBuf.String(), errBuf.String()
+}
+
+func TestProgressToStderrKeepsJSONResultsClean(t *testing.T) {
+	_ = SetFormat("default")
+	SetOrchestrated(false)
+	_ = SetProgressDestination(ProgressToStderr)
+	defer func() {
+		_ = SetFormat("default")
+		_ = SetProgressDestination(ProgressToStderr)
+	}()
+
+	stdout, stderr := captureStdoutAndStderr(t, func() {
+		CommandHeader("test", "Test command")
+		Section("📦", "Preparing")
+		Step("🔧", "Working on %s", "api")
+		Phase("Installing dependencies...")
+		Hint("Press Ctrl+C to stop")
+		Divider()
+		if err := PrintJSON(map[string]string{"status": "ok"}); err != nil {
+			t.Errorf("PrintJSON() error = %v", err)
+		}
+	})
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\nstdout: %q", err, stdout)
+	}
+	if result["status"] != "ok" {
+		t.Errorf("result[status] = %q, want %q", result["status"], "ok")
+	}
+
+	for _, want := range []string{"azd app test", "Preparing", "Working on api", "Installing dependencies...", "Press Ctrl+C to stop"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr should contain %q, got: %q", want, stderr)
+		}
+	}
+}
+
+func TestProgressToStdout(t *testing.T) {
+	_ = SetFormat("default")
+	SetOrchestrated(false)
+	_ = SetProgressDestination(ProgressToStdout)
+	defer func() { _ = SetProgressDestination(ProgressToStderr) }()
+
+	stdout, stderr := captureStdoutAndStderr(t, func() {
+		Step("🔧", "Working")
+		Plain("result")
+	})
+
+	if !strings.Contains(stdout, "Working") || !strings.Contains(stdout, "result") {
+		t.Errorf("stdout should contain progress and result, got: %q", stdout)
+	}
+	if stderr != "" {
+		t.Errorf("stderr should be empty, got: %q", stderr)
+	}
+}
+
+func TestQuietMode(t *testing.T) {
+	_ = SetFormat("default")
+	SetOrchestrated(false)
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	stdout, stderr := captureStdoutAndStderr(t, func() {
+		CommandHeader("test", "Test command")
+		Section("📦", "Preparing")
+		Step("🔧", "Working on %s", "api")
+		Info("Detected %d services", 2)
+		Item("Run 'azd app run' to start services")
+		ItemInfo("Using cached runtime")
+		Plain("result")
+		Warning("careful")
+	})
+
+	for _, wanted := range []string{"result", "careful", "Detected 2 services", "azd app run", "cached runtime"} {
+		if !strings.Contains(stdout, wanted) {
+			t.Errorf("stdout should keep %q, got: %q", wanted, stdout)
+		}
+	}
+	for _, unwanted := range []string{"azd app test", "Preparing", "Working on api"} {
+		if strings.Contains(stdout+stderr, unwanted) {
+			t.Errorf("quiet output should not contain %q, got stdout %q, stderr %q", unwanted, stdout, stderr)
+		}
+	}
+}
@@ -144,7 +144,7 @@ func (mp *MultiProgress) GetBar(id string) *ProgressSpinner {
 // Start starts the multi-progress display (renders all bars periodically).
 func (mp *MultiProgress) Start() {
 	// Hide cursor during progress display
-	fmt.Print("\033[?25l")
+	fmt.Fprint(ProgressWriter(), "\033[?25l")
 
 	// Set initial line count based on number of bars
 	mp.mu.Lock()
@@ -186,7 +186,7 @@ func (mp *MultiProgress) Stop() {
 	mp.renderFinal()
 
 	// Show cursor again
-	fmt.Print("\033[?25h")
+	fmt.Fprint(ProgressWriter(), "\033[?25h")
 }
 
 // render renders all active progress bars.
@@ -222,13 +222,13 @@ func (mp *MultiProgress) render() {
 		// Build the progress bar line
 		statusLine := mp.buildProgressLine(bar, progressPct, elapsed)
 		// Clear entire line and print
-		fmt.Print("\r\033[2K" + statusLine + "\n")
+		fmt.Fprint(ProgressWriter(), "\r\033[2K"+statusLine+"\n")
 		lineCount++
 
 		// Add error line if failed
 		if bar.status == TaskStatusFailed && bar.errorMsg != "" {
 			errorLine := mp.formatErrorLine(bar.errorMsg)
-			fmt.Print("\r\033[2K" + errorLine + "\n")
+			fmt.Fprint(ProgressWriter(), "\r\033[2K"+errorLine+"\n")
 			lineCount++
 		}
 
@@ -257,12 +257,12 @@ func (mp *MultiProgress) renderFinal() {
 		bar.mu.Lock()
 		elapsed := mp.calculateElapsed(bar, time.Now())
 		statusLine := mp.buildProgressLine(bar, bar.finalProgress, elapsed)
-		fmt.Print("\r\033[2K" + statusLine + "\n")
+		fmt.Fprint(ProgressWriter(), "\r\033[2K"+statusLine+"\n")
 		lineCount++
 
 		if bar.status == TaskStatusFailed && bar.errorMsg != "" {
 			errorLine := mp.formatErrorLine(bar.errorMsg)
-			fmt.Print("\r\033[2K" + errorLine + "\n")
+			fmt.Fprint(ProgressWriter(), "\r\033[2K"+errorLine+"\n")
 			lineCount++
 		}
 		bar.mu.Unlock()
@@ -404,18 +404,18 @@ func truncateString(s string, maxLen int) string {
 // moveCursorToStart moves cursor to the start of the progress section
 func (mp *MultiProgress) moveCursorToStart() {
 	if mp.lastLineCount > 0 {
-		fmt.Printf("\033[%dA", mp.lastLineCount)
+		fmt.Fprintf(ProgressWriter(), "\033[%dA", mp.lastLineCount)
 	}
 }
 
 // clearExtraLines clears extra lines if line count decreased
 func (mp *MultiProgress) clearExtraLines(currentLineCount int) {
 	for i := currentLineCount; i < mp.lastLineCount; i++ {
-		fmt.Print("\r\033[2K\n")
+		fmt.Fprint(ProgressWriter(), "\r\033[2K\n")
 	}
 
 	if currentLineCount < mp.lastLineCount {
-		fmt.Printf("\033[%dA", mp.lastLineCount-currentLineCount)
+		fmt.Fprintf(ProgressWriter(), "\033[%dA", mp.lastLineCount-currentLineCount)
 	}
 }
 
@@ -0,0 +1,518 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled JSONPath expression that PrintJSON applies to results (--query).
+//
+// Supported syntax: the root $ (optional), child names (.name, ['name']), array indexes
+// ([0], [-1]), wildcards (.*, [*]), recursive descent (..name) and filters comparing a
+// child of each element with a literal ([?(@.name=='api')], [?(@.port>=3000)], [?(@.url)]).
+type Query struct {
+	expr     string
+	segments []querySegment
+	// definite is true when the expression selects at most one value (no wildcards,
+	// recursive descent or filters); that value is printed instead of a list.
+	definite bool
+}
+
+// querySegment selects children of each current value.
+type querySegment struct {
+	recursive bool // .. applies the selector to the value and all its descendants
+	wildcard  bool
+	name      string
+	index     *int
+	filter    *queryFilter
+}
+
+// queryFilter keeps the elements whose child at path compares to value with op.
+// An empty op only requires the child to exist.
+type queryFilter struct {
+	path  []querySegment
+	op    string
+	value interface{}
+}
+
+// activeQuery is applied by PrintJSON when set.
+var activeQuery *Query
+
+// SetQuery sets the query applied to JSON results; nil prints results unchanged.
+func SetQuery(q *Query) {
+	activeQuery = q
+}
+
+// ParseQuery compiles a JSONPath expression.
+func ParseQuery(expr string) (*Query, error) {
+	p := &queryParser{input: strings.TrimSpace(expr)}
+	if p.input == "" {
+		return nil, fmt.Errorf("invalid query: expression is empty")
+	}
+	segments, err := p.parsePath(true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("invalid query %q: unexpected %q at position %d", expr, p.input[p.pos], p.pos)
+	}
+	definite := true
+	for _, seg := range segments {
+		if seg.recursive || seg.wildcard || seg.filter != nil {
+			definite = false
+		}
+	}
+	return &Query{expr: expr, segments: segments, definite: definite}, nil
+}
+
+// String returns the expression the query was compiled from.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Apply evaluates the query against data, as it would be marshaled to JSON. A definite
+// expression returns the selected value (nil when nothing matches); any other returns
+// the list of matches.
+func (q *Query) Apply(data interface{}) (interface{}, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	matches := selectPath([]interface{}{root}, q.segments)
+	if q.definite {
+		if len(matches) == 0 {
+			return nil, nil
+		}
+		return matches[0], nil
+	}
+	if matches == nil {
+		matches = []interface{}{}
+	}
+	return matches, nil
+}
+
+// selectPath applies the segments in turn to the current values.
+func selectPath(values []interface{}, segments []querySegment) []interface{} {
+	for _, seg := range segments {
+		var next []interface{}
+		for _, value := range values {
+			if seg.recursive {
+				for _, v := range descendants(value) {
+					next = append(next, seg.selectChildren(v)...)
+				}
+			} else {
+				next = append(next, seg.selectChildren(value)...)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// selectChildren returns the children of value the segment selects.
+func (seg querySegment) selectChildren(value interface{}) []interface{} {
+	switch {
+	case seg.index != nil:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		i := *seg.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			return nil
+		}
+		return []interface{}{arr[i]}
+	case seg.wildcard:
+		return children(value)
+	case seg.filter != nil:
+		var kept []interface{}
+		for _, child := range children(value) {
+			if seg.filter.matches(child) {
+				kept = append(kept, child)
+			}
+		}
+		return kept
+	default:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		child, ok := obj[seg.name]
+		if !ok {
+			return nil
+		}
+		return []interface{}{child}
+	}
+}
+
+// children returns the elements of an array or the values of an object, ordered by key.
+func children(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		result := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, v[key])
+		}
+		return result
+	}
+	return nil
+}
+
+// descendants returns value followed by all the values nested in it.
+func descendants(value interface{}) []interface{} {
+	result := []interface{}{value}
+	for _, child := range children(value) {
+		result = append(result, descendants(child)...)
+	}
+	return result
+}
+
+// matches reports whether the filter keeps value.
+func (f *queryFilter) matches(value interface{}) bool {
+	selected := selectPath([]interface{}{value}, f.path)
+	if len(selected) == 0 {
+		return false
+	}
+	if f.op == "" {
+		return true
+	}
+	return compareQueryValues(selected[0], f.op, f.value)
+}
+
+// compareQueryValues compares a JSON value with a filter literal. Numbers and strings
+// support all operators; other values only == and !=.
+func compareQueryValues(left interface{}, op string, right interface{}) bool {
+	if n, ok := left.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		left = f
+	}
+
+	cmp, comparable := 0, true
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return op == "!="
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return op == "!="
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		comparable = false
+	}
+
+	if !comparable {
+		equal := left == right
+		switch op {
+		case "==":
+			return equal
+		case "!=":
+			return !equal
+		}
+		return false
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// queryParser parses a JSONPath expression.
+type queryParser struct {
+	input string
+	pos   int
+}
+
+// parsePath parses segments until the end of the input, or in a filter, until a character
+// that can't continue the path. The top-level path may start with $ or a bare child name.
+func (p *queryParser) parsePath(root bool) ([]querySegment, error) {
+	if root {
+		if p.peek() == '$' {
+			p.pos++
+		} else if p.peek() != '.' && p.peek() != '[' {
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			if name == "*" {
+				return p.continuePath([]querySegment{{wildcard: true}}, root)
+			}
+			return p.continuePath([]querySegment{{name: name}}, root)
+		}
+	}
+	return p.continuePath(nil, root)
+}
+
+// continuePath parses the .child, ..descendant and [selector] segments that follow.
+func (p *queryParser) continuePath(segments []querySegment, root bool) ([]querySegment, error) {
+	for p.pos < len(p.input) {
+		switch p.peek() {
+		case '.':
+			p.pos++
+			recursive := false
+			if p.peek() == '.' {
+				if !root {
+					return nil, fmt.Errorf("recursive descent is not supported in filters")
+				}
+				p.pos++
+				recursive = true
+			}
+			if p.peek() == '[' {
+				seg, err := p.parseBracket(root)
+				if err != nil {
+					return nil, err
+				}
+				seg.recursive = recursive
+				segments = append(segments, seg)
+				continue
+			}
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			seg := querySegment{recursive: recursive, name: name}
+			if name == "*" {
+				seg = querySegment{recursive: recursive, wildcard: true}
+			}
+			if seg.wildcard && !root {
+				return nil, fmt.Errorf("wildcards are not supported in filters")
+			}
+			segments = append(segments, seg)
+		case '[':
+			seg, err := p.parseBracket(root)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		default:
+			if root {
+				return nil, fmt.Errorf("unexpected %q at position %d", p.peek(), p.pos)
+			}
+			return segments, nil
+		}
+	}
+	return segments, nil
+}
+
+// parseName parses a child name, or * for a wildcard.
+func (p *queryParser) parseName() (string, error) {
+	if p.peek() == '*' {
+		p.pos++
+		return "*", nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && isQueryNameChar(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.pos >= len(p.input) {
+			return "", fmt.Errorf("expected a name at the end of the expression")
+		}
+		return "", fmt.Errorf("expected a name at position %d", p.pos)
+	}
+	return p.input[start:p.pos], nil
+}
+
+// isQueryNameChar reports whether c can appear in an unquoted child name.
+func isQueryNameChar(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parseBracket parses [*], [index], ['name'] or [?(filter)].
+func (p *queryParser) parseBracket(root bool) (querySegment, error) {
+	p.pos++ // [
+	p.skipSpaces()
+	var seg querySegment
+	switch c := p.peek(); {
+	case c == '*':
+		if !root {
+			return seg, fmt.Errorf("wildcards are not supported in filters")
+		}
+		p.pos++
+		seg.wildcard = true
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return seg, err
+		}
+		seg.name = name
+	case c == '?':
+		if !root {
+			return seg, fmt.Errorf("nested filters are not supported")
+		}
+		filter, err := p.parseFilter()
+		if err != nil {
+			return seg, err
+		}
+		seg.filter = filter
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+			p.pos++
+		}
+		index, err := strconv.Atoi(p.input[start:p.pos])
+		if err != nil {
+			return seg, fmt.Errorf("invalid index %q", p.input[start:p.pos])
+		}
+		seg.index = &index
+	default:
+		return seg, fmt.Errorf("expected *, an index, a quoted name or a filter at position %d", p.pos)
+	}
+	p.skipSpaces()
+	if p.peek() != ']' {
+		return seg, fmt.Errorf("expected ] at position %d", p.pos)
+	}
+	p.pos++
+	return seg, nil
+}
+
+// parseFilter parses ?(@.path), or ?(@.path op literal) with op one of == != < <= > >=.
+func (p *queryParser) parseFilter() (*queryFilter, error) {
+	p.pos++ // ?
+	if p.peek() != '(' {
+		return nil, fmt.Errorf("expected ( after ? at position %d", p.pos)
+	}
+	p.pos++
+	p.skipSpaces()
+	if p.peek() != '@' {
+		return nil, fmt.Errorf("expected @ at position %d", p.pos)
+	}
+	p.pos++
+	path, err := p.parsePath(false)
+	if err != nil {
+		return nil, err
+	}
+	filter := &queryFilter{path: path}
+
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			filter.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if filter.op != "" {
+		p.skipSpaces()
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		filter.value = value
+		p.skipSpaces()
+	}
+	if p.peek() != ')' {
+		return nil, fmt.Errorf("expected ) at position %d", p.pos)
+	}
+	p.pos++
+	return filter, nil
+}
+
+// parseLiteral parses a quoted string, a number, true, false or null.
+func (p *queryParser) parseLiteral() (interface{}, error) {
+	if c := p.peek(); c == '\'' || c == '"' {
+		return p.parseString()
+	}
+	start := p.pos
+	for p.pos < len(p.input) && strings.IndexByte(" )]", p.input[p.pos]) < 0 {
+		p.pos++
+	}
+	word := p.input[start:p.pos]
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q at position %d (quote strings)", word, start)
+	}
+	return number, nil
+}
+
+// parseString parses a single- or double-quoted string; a backslash escapes the next character.
+func (p *queryParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	start := p.pos
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.input):
+			sb.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+// peek returns the current character, or 0 at the end of the input.
+func (p *queryParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// skipSpaces skips spaces inside brackets and filters.
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
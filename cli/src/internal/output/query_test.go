@@ -0,0 +1,131 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQueryApply(t *testing.T) {
+	data := map[string]interface{}{
+		"project": "/src/app",
+		"services": []map[string]interface{}{
+			{"name": "api", "port": 8080, "healthy": true, "local": map[string]interface{}{"url": "http://localhost:8080"}},
+			{"name": "web", "port": 3000, "healthy": false},
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"root", "$", `{"project":"/src/app","services":[{"healthy":true,"local":{"url":"http://localhost:8080"},"name":"api","port":8080},{"healthy":false,"name":"web","port":3000}]}`},
+		{"child", "$.project", `"/src/app"`},
+		{"without root", "services[1].name", `"web"`},
+		{"leading dot", ".services[0].port", `8080`},
+		{"quoted name", "$['services'][0][\"name\"]", `"api"`},
+		{"negative index", "$.services[-1].name", `"web"`},
+		{"missing child", "$.services[0].missing", `null`},
+		{"index out of range", "$.services[5]", `null`},
+		{"wildcard", "$.services[*].name", `["api","web"]`},
+		{"dot wildcard", "$.services[0].local.*", `["http://localhost:8080"]`},
+		{"recursive descent", "$..url", `["http://localhost:8080"]`},
+		{"filter equals", "$.services[?(@.name=='api')].port", `[8080]`},
+		{"filter double quotes", `$.services[?(@.name == "web")].port`, `[3000]`},
+		{"filter number", "$.services[?(@.port >= 5000)].name", `["api"]`},
+		{"filter bool", "$.services[?(@.healthy==false)].name", `["web"]`},
+		{"filter not equals", "$.services[?(@.name!='api')].name", `["web"]`},
+		{"filter exists", "$.services[?(@.local.url)].name", `["api"]`},
+		{"no matches", "$.services[?(@.name=='db')].port", `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error = %v", tt.expr, err)
+			}
+			got, err := q.Apply(data)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			gotJSON, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal result: %v", err)
+			}
+			var gotValue, wantValue interface{}
+			if err := json.Unmarshal(gotJSON, &gotValue); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantValue); err != nil {
+				t.Fatalf("failed to decode want: %v", err)
+			}
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Errorf("Apply(%q) = %s, want %s", tt.expr, gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"$.",
+		"$.services[",
+		"$.services[0",
+		"$.services[abc]",
+		"$.services[?(@.name=='api']",
+		"$.services[?(@.name==api)]",
+		"$.services[?(@.name=='api)]",
+		"$.services[?(name=='api')]",
+		"$.services[?(@..name)]",
+		"$ services",
+		"services name",
+	} {
+		if _, err := ParseQuery(expr); err == nil {
+			t.Errorf("ParseQuery(%q) error = nil, want error", expr)
+		}
+	}
+}
+
+func TestPrintJSONWithQuery(t *testing.T) {
+	q, err := ParseQuery("$.services[0].name")
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	SetQuery(q)
+	defer SetQuery(nil)
+
+	capture := func() string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		err := PrintJSON(map[string]interface{}{"services": []map[string]string{{"name": "api"}}})
+		w.Close()
+		os.Stdout = oldStdout
+		if err != nil {
+			t.Fatalf("PrintJSON() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, r); err != nil {
+			t.Fatalf("failed to copy output: %v", err)
+		}
+		return buf.String()
+	}
+
+	if got := strings.TrimSpace(capture()); got != `"api"` {
+		t.Errorf("PrintJSON() with query = %s, want \"api\"", got)
+	}
+
+	// The results of dependency commands are left out
+	SetOrchestrated(true)
+	defer SetOrchestrated(false)
+	if got := capture(); got != "" {
+		t.Errorf("PrintJSON() in orchestrated mode with query = %q, want no output", got)
+	}
+}
@@ -3,6 +3,7 @@ package portmanager
 import (
 	"crypto/rand"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
 	"net"
@@ -35,8 +36,20 @@ func (pm *PortManager) IsPortAvailable(port int) bool {
 //   - *PortReservation: Holds the port open. Call Release() before binding.
 //   - error: Non-nil if port cannot be reserved
 func (pm *PortManager) ReservePort(port int) (*PortReservation, error) {
+	return pm.ReservePortWithProtocol(port, ProtocolTCP)
+}
+
+// ReservePortWithProtocol is ReservePort for a port of the given protocol (ProtocolTCP or
+// ProtocolUDP). A UDP port is held with a bound UDP socket rather than a TCP listener.
+func (pm *PortManager) ReservePortWithProtocol(port int, protocol string) (*PortReservation, error) {
 	addr := fmt.Sprintf("127.0.0.1:%d", port)
-	listener, err := net.Listen("tcp", addr)
+	var listener io.Closer
+	var err error
+	if protocol == ProtocolUDP {
+		listener, err = net.ListenPacket("udp", addr)
+	} else {
+		listener, err = net.Listen("tcp", addr)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("port %d is not available: %w", port, err)
 	}
@@ -136,6 +149,18 @@ func (pm *PortManager) isPortAvailable(port int) bool {
 	return pm.defaultIsPortAvailable(port)
 }
 
+// isProtocolPortAvailable checks a port of the given protocol, probing UDP ports with a
+// UDP socket and everything else with a TCP listener.
+func (pm *PortManager) isProtocolPortAvailable(port int, protocol string) bool {
+	if protocol == ProtocolUDP {
+		if pm.udpPortChecker != nil {
+			return pm.udpPortChecker(port)
+		}
+		return pm.defaultIsUDPPortAvailable(port)
+	}
+	return pm.isPortAvailable(port)
+}
+
 // defaultIsPortAvailable is the default implementation that actually binds to check port availability.
 func (pm *PortManager) defaultIsPortAvailable(port int) bool {
 	// Bind to localhost to avoid Windows Firewall prompts
@@ -153,16 +178,32 @@ func (pm *PortManager) defaultIsPortAvailable(port int) bool {
 	return true
 }
 
+// defaultIsUDPPortAvailable is the default implementation that binds a UDP socket to check port availability.
+func (pm *PortManager) defaultIsUDPPortAvailable(port int) bool {
+	// Bind to localhost to avoid Windows Firewall prompts
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		slog.Debug("udp port bind test failed", "port", port, "error", err)
+		return false
+	}
+	if err := conn.Close(); err != nil {
+		slog.Debug("failed to close udp socket during availability check", "port", port, "error", err)
+	}
+	slog.Debug("udp port is available", "port", port)
+	return true
+}
+
 // verifyPortCleanup verifies that a port is available after cleanup, with retries.
 // This is necessary on Windows where port release can take longer, especially for system processes.
-func (pm *PortManager) verifyPortCleanup(port int) bool {
+func (pm *PortManager) verifyPortCleanup(port int, protocol string) bool {
 	for attempt := 0; attempt < portCleanupRetries; attempt++ {
 		if attempt > 0 {
 			slog.Debug("retrying port cleanup verification", "port", port, "attempt", attempt+1)
 			time.Sleep(portCleanupRetryWait)
 		}
 
-		if pm.isPortAvailable(port) {
+		if pm.isProtocolPortAvailable(port, protocol) {
 			if attempt > 0 {
 				slog.Debug("port became available after retry", "port", port, "attempts", attempt+1)
 			}
@@ -179,7 +220,7 @@ func (pm *PortManager) verifyPortCleanup(port int) bool {
 // 1. Reduce collision probability when multiple services start simultaneously
 // 2. Avoid exhaustive scanning of the entire port range
 // 3. Prevent predictable port allocation patterns
-func (pm *PortManager) findAvailablePort() (int, error) {
+func (pm *PortManager) findAvailablePort(protocol string) (int, error) {
 	// Build map of assigned ports to avoid duplicates
 	assignedPorts := make(map[int]bool)
 	for _, assignment := range pm.assignments {
@@ -210,7 +251,7 @@ func (pm *PortManager) findAvailablePort() (int, error) {
 		if assignedPorts[port] {
 			continue
 		}
-		if pm.isPortAvailable(port) {
+		if pm.isProtocolPortAvailable(port, protocol) {
 			return port, nil
 		}
 	}
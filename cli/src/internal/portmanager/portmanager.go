@@ -27,6 +27,8 @@ type PortManager struct {
 	// portChecker is a function that checks if a port is available
 	// This can be overridden in tests to avoid network binding
 	portChecker func(port int) bool
+	// udpPortChecker is portChecker for UDP ports, which a TCP bind test doesn't cover
+	udpPortChecker func(port int) bool
 	// configClient is lazily initialized for azdconfig access
 	configClient azdconfig.ConfigClient
 }
@@ -124,8 +126,10 @@ func GetPortManager(projectDir string) *PortManager {
 	// Set port checker - use global test checker if set, otherwise default
 	if globalTestPortChecker != nil {
 		manager.portChecker = globalTestPortChecker
+		manager.udpPortChecker = globalTestPortChecker
 	} else {
 		manager.portChecker = manager.defaultIsPortAvailable
+		manager.udpPortChecker = manager.defaultIsUDPPortAvailable
 	}
 
 	// Load existing assignments from azdconfig
@@ -251,6 +255,13 @@ func getPortRangeEnd() int {
 // caller binding to it. Another process could bind to the port in the interim. Callers
 // MUST handle port binding failures gracefully and may retry by calling AssignPort again.
 func (pm *PortManager) AssignPort(serviceName string, preferredPort int, isExplicit bool) (int, bool, error) {
+	return pm.AssignPortWithProtocol(serviceName, preferredPort, isExplicit, ProtocolTCP)
+}
+
+// AssignPortWithProtocol is AssignPort for a service listening on the given protocol
+// (ProtocolTCP or ProtocolUDP). UDP ports are probed with a UDP socket, since a free TCP
+// port says nothing about whether another process has bound the UDP one.
+func (pm *PortManager) AssignPortWithProtocol(serviceName string, preferredPort int, isExplicit bool, protocol string) (int, bool, error) {
 	// Validate inputs
 	if serviceName == "" {
 		return 0, false, fmt.Errorf("serviceName cannot be empty")
@@ -264,16 +275,16 @@ func (pm *PortManager) AssignPort(serviceName string, preferredPort int, isExpli
 
 	// EXPLICIT PORT MODE: Port from azure.yaml - MUST be used, prompt if in use
 	if isExplicit {
-		return pm.assignExplicitPort(serviceName, preferredPort)
+		return pm.assignExplicitPort(serviceName, preferredPort, protocol)
 	}
 
 	// FLEXIBLE PORT MODE: Port can be changed if needed, prompt user when conflicts detected
-	return pm.assignFlexiblePort(serviceName, preferredPort)
+	return pm.assignFlexiblePort(serviceName, preferredPort, protocol)
 }
 
 // assignExplicitPort handles port assignment when the port is explicit (from azure.yaml).
 // Must be called with pm.mu held. May temporarily release the lock for user input.
-func (pm *PortManager) assignExplicitPort(serviceName string, port int) (int, bool, error) {
+func (pm *PortManager) assignExplicitPort(serviceName string, port int, protocol string) (int, bool, error) {
 	// Validate port is in range
 	if port < pm.portRange.start || port > pm.portRange.end {
 		return 0, false, fmt.Errorf("explicit port %d for service '%s' is outside valid range %d-%d",
@@ -281,25 +292,25 @@ func (pm *PortManager) assignExplicitPort(serviceName string, port int) (int, bo
 	}
 
 	// Check if port is available
-	if pm.isPortAvailable(port) {
+	if pm.isProtocolPortAvailable(port, protocol) {
 		return pm.saveAssignment(serviceName, port, false)
 	}
 
 	// Port is in use - handle conflict
 	processInfo := getProcessInfoString(pm, port)
-	return pm.handleConflictAndAssign(serviceName, port, processInfo, true)
+	return pm.handleConflictAndAssign(serviceName, port, processInfo, true, protocol)
 }
 
 // assignFlexiblePort handles port assignment when the port is flexible (can be changed).
 // Must be called with pm.mu held. May temporarily release the lock for user input.
-func (pm *PortManager) assignFlexiblePort(serviceName string, preferredPort int) (int, bool, error) {
+func (pm *PortManager) assignFlexiblePort(serviceName string, preferredPort int, protocol string) (int, bool, error) {
 	// Check if we already have an assignment
 	if assignment, exists := pm.assignments[serviceName]; exists {
 		assignment.LastUsed = time.Now()
 		slog.Debug("checking assigned port", "service", serviceName, "port", assignment.Port)
 
 		// Check if assigned port is available
-		if pm.isPortAvailable(assignment.Port) {
+		if pm.isProtocolPortAvailable(assignment.Port, protocol) {
 			slog.Debug("assigned port is available", "service", serviceName, "port", assignment.Port)
 			if err := pm.save(); err != nil {
 				return 0, false, fmt.Errorf("failed to save port assignment: %w", err)
@@ -310,14 +321,14 @@ func (pm *PortManager) assignFlexiblePort(serviceName string, preferredPort int)
 		// Previously assigned port is now in use - handle conflict
 		slog.Debug("assigned port is in use", "service", serviceName, "port", assignment.Port)
 		processInfo := getProcessInfoString(pm, assignment.Port)
-		return pm.handleConflictAndAssign(serviceName, assignment.Port, processInfo, false)
+		return pm.handleConflictAndAssign(serviceName, assignment.Port, processInfo, false, protocol)
 	}
 
 	// Try preferred port first (if provided and in range)
 	if preferredPort >= pm.portRange.start && preferredPort <= pm.portRange.end {
 		slog.Debug("checking preferred port", "service", serviceName, "port", preferredPort)
 
-		if pm.isPortAvailable(preferredPort) {
+		if pm.isProtocolPortAvailable(preferredPort, protocol) {
 			slog.Debug("preferred port is available", "service", serviceName, "port", preferredPort)
 			return pm.saveAssignment(serviceName, preferredPort, false)
 		}
@@ -325,16 +336,16 @@ func (pm *PortManager) assignFlexiblePort(serviceName string, preferredPort int)
 		// Preferred port unavailable - handle conflict
 		slog.Debug("preferred port is in use", "service", serviceName, "port", preferredPort)
 		processInfo := getProcessInfoString(pm, preferredPort)
-		return pm.handleConflictAndAssign(serviceName, preferredPort, processInfo, false)
+		return pm.handleConflictAndAssign(serviceName, preferredPort, processInfo, false, protocol)
 	}
 
 	// Find an available port automatically
-	return pm.autoAssignPort(serviceName)
+	return pm.autoAssignPort(serviceName, protocol)
 }
 
 // handleConflictAndAssign handles a port conflict by prompting the user and taking action.
 // Must be called with pm.mu held. Temporarily releases the lock for user input.
-func (pm *PortManager) handleConflictAndAssign(serviceName string, port int, processInfo string, isExplicit bool) (int, bool, error) {
+func (pm *PortManager) handleConflictAndAssign(serviceName string, port int, processInfo string, isExplicit bool, protocol string) (int, bool, error) {
 	// Release mutex before blocking on user input to prevent deadlocks
 	// WARNING: TOCTOU race - state may change during user input. We re-validate after.
 	pm.mu.Unlock()
@@ -347,17 +358,17 @@ func (pm *PortManager) handleConflictAndAssign(serviceName string, port int, pro
 
 	switch action {
 	case ActionKill:
-		return pm.killAndAssign(serviceName, port)
+		return pm.killAndAssign(serviceName, port, protocol)
 
 	case ActionReassign:
-		return pm.reassignPort(serviceName, port, isExplicit)
+		return pm.reassignPort(serviceName, port, isExplicit, protocol)
 
 	case ActionAlwaysKill:
 		if err := pm.setAlwaysKillPreference(true); err != nil {
 			slog.Warn("failed to save always-kill preference", "error", err)
 		}
 		printPreferenceSavedMessage()
-		return pm.killAndAssign(serviceName, port)
+		return pm.killAndAssign(serviceName, port, protocol)
 
 	default: // ActionCancel
 		return 0, false, fmt.Errorf("operation cancelled by user")
@@ -366,9 +377,9 @@ func (pm *PortManager) handleConflictAndAssign(serviceName string, port int, pro
 
 // killAndAssign kills the process on the port and assigns it to the service.
 // Must be called with pm.mu held.
-func (pm *PortManager) killAndAssign(serviceName string, port int) (int, bool, error) {
+func (pm *PortManager) killAndAssign(serviceName string, port int, protocol string) (int, bool, error) {
 	// Re-validate port state after re-acquiring lock (state may have changed during user input)
-	if pm.isPortAvailable(port) {
+	if pm.isProtocolPortAvailable(port, protocol) {
 		// Port became available while waiting for user input - use it directly
 		result, _, err := pm.saveAssignment(serviceName, port, false)
 		if err != nil {
@@ -386,7 +397,7 @@ func (pm *PortManager) killAndAssign(serviceName string, port int) (int, bool, e
 	}
 
 	// Verify port is now available with retries
-	if !pm.verifyPortCleanup(port) {
+	if !pm.verifyPortCleanup(port, protocol) {
 		printPortStillInUseMessage(port)
 		printKillFailedTip()
 		return 0, false, fmt.Errorf("port %d is still in use after cleanup attempt", port)
@@ -402,10 +413,10 @@ func (pm *PortManager) killAndAssign(serviceName string, port int) (int, bool, e
 
 // reassignPort finds an alternative port and assigns it to the service.
 // Must be called with pm.mu held. May temporarily release the lock for user input.
-func (pm *PortManager) reassignPort(serviceName string, originalPort int, isExplicit bool) (int, bool, error) {
+func (pm *PortManager) reassignPort(serviceName string, originalPort int, isExplicit bool, protocol string) (int, bool, error) {
 	printFindingPortMessage(serviceName)
 
-	port, err := pm.findAvailablePort()
+	port, err := pm.findAvailablePort(protocol)
 	if err != nil {
 		return 0, false, err
 	}
@@ -434,8 +445,8 @@ func (pm *PortManager) reassignPort(serviceName string, originalPort int, isExpl
 
 // autoAssignPort finds and assigns an available port automatically.
 // Must be called with pm.mu held.
-func (pm *PortManager) autoAssignPort(serviceName string) (int, bool, error) {
-	port, err := pm.findAvailablePort()
+func (pm *PortManager) autoAssignPort(serviceName string, protocol string) (int, bool, error) {
+	port, err := pm.findAvailablePort(protocol)
 	if err != nil {
 		return 0, false, err
 	}
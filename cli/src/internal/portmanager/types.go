@@ -2,12 +2,17 @@
 package portmanager
 
 import (
-	"net"
+	"io"
 	"sync"
 	"time"
 )
 
 const (
+	// ProtocolTCP is the protocol of ports checked and reserved with a TCP listener (the default).
+	ProtocolTCP = "tcp"
+	// ProtocolUDP is the protocol of ports checked and reserved with a UDP socket.
+	ProtocolUDP = "udp"
+
 	// Port scan limits
 	// maxPortScanAttempts limits port scanning to prevent excessive delays.
 	// 100 attempts in a 3000-65535 range gives ~0.15% coverage which is sufficient
@@ -56,7 +61,7 @@ type PortAssignment struct {
 // Call Release() just before your service binds to the port.
 type PortReservation struct {
 	Port     int
-	listener net.Listener
+	listener io.Closer // net.Listener for TCP, net.PacketConn for UDP
 	released bool
 	mu       sync.Mutex
 }
@@ -15,22 +15,31 @@ import (
 // NOTE: Health status is NOT stored here - it is computed dynamically via health checks.
 // This prevents stale cached health data from causing issues with the dashboard.
 type ServiceRegistryEntry struct {
-	Name        string    `json:"name"`
-	ProjectDir  string    `json:"projectDir"`
-	PID         int       `json:"pid"`
-	Port        int       `json:"port"`
-	URL         string    `json:"url"`
-	AzureURL    string    `json:"azureUrl,omitempty"`
-	Language    string    `json:"language"`
-	Framework   string    `json:"framework"`
-	Status      string    `json:"status"` // "starting", "ready", "stopping", "stopped", "error", "building", "built", "completed", "failed", "watching"
-	StartTime   time.Time `json:"startTime"`
-	LastChecked time.Time `json:"lastChecked"`
-	Error       string    `json:"error,omitempty"`
-	Type        string    `json:"type,omitempty"`     // "http", "tcp", "process"
-	Mode        string    `json:"mode,omitempty"`     // "watch", "build", "daemon", "task" (for type=process)
-	ExitCode    *int      `json:"exitCode,omitempty"` // Exit code for completed build/task mode services (nil = still running)
-	EndTime     time.Time `json:"endTime,omitempty"`  // When the process exited (for build/task modes)
+	Name        string     `json:"name"`
+	ProjectDir  string     `json:"projectDir"`
+	PID         int        `json:"pid"`
+	Port        int        `json:"port"`
+	Ports       []PortInfo `json:"ports,omitempty"` // Every port mapping, primary (Port) first
+	URL         string     `json:"url"`
+	AzureURL    string     `json:"azureUrl,omitempty"`
+	Language    string     `json:"language"`
+	Framework   string     `json:"framework"`
+	Status      string     `json:"status"` // "starting", "ready", "stopping", "stopped", "error", "building", "built", "completed", "failed", "watching"
+	StartTime   time.Time  `json:"startTime"`
+	LastChecked time.Time  `json:"lastChecked"`
+	Error       string     `json:"error,omitempty"`
+	Type        string     `json:"type,omitempty"`     // "http", "tcp", "process"
+	Mode        string     `json:"mode,omitempty"`     // "watch", "build", "daemon", "task" (for type=process)
+	ExitCode    *int       `json:"exitCode,omitempty"` // Exit code for completed build/task mode services (nil = still running)
+	EndTime     time.Time  `json:"endTime,omitempty"`  // When the process exited (for build/task modes)
+}
+
+// PortInfo is one of the port mappings of a service.
+type PortInfo struct {
+	Name          string `json:"name,omitempty"`
+	HostPort      int    `json:"hostPort"`
+	ContainerPort int    `json:"containerPort,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
 }
 
 // ServiceRegistry manages the registry of running services for a project.
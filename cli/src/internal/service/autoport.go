@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortRemap records a service that was moved off its busy declared port (azd app run --auto-port).
+type PortRemap struct {
+	Service string
+	From    int // Declared port that was busy
+	To      int // Free port used instead
+}
+
+// String describes the remapping, e.g. "api: 8080 busy → using 8081".
+func (r PortRemap) String() string {
+	return fmt.Sprintf("%s: %d busy → using %d", r.Service, r.From, r.To)
+}
+
+// RemapBusyPort checks the port a service declares in azure.yaml. If another service already
+// uses it (usedPorts) or isFree reports it taken, it returns a copy of svc that declares the
+// next free port in [start, end] instead, wrapping around to start. Services without a declared
+// port, and container services (whose host and container ports are mapped one to one), are
+// returned unchanged with a nil remap.
+func RemapBusyPort(name string, svc Service, usedPorts map[int]bool, start, end int, isFree func(port int) bool) (Service, *PortRemap, error) {
+	if svc.IsContainerService() || !svc.NeedsPort() {
+		return svc, nil, nil
+	}
+	declared, _, isExplicit := svc.GetPrimaryPort()
+	if !isExplicit || (!usedPorts[declared] && isFree(declared)) {
+		return svc, nil, nil
+	}
+
+	port, err := nextFreePort(declared, usedPorts, start, end, isFree)
+	if err != nil {
+		return svc, nil, fmt.Errorf("port %d for service %s is busy: %w", declared, name, err)
+	}
+
+	ports := make([]string, len(svc.Ports))
+	copy(ports, svc.Ports)
+	ports[0] = remapPortSpec(ports[0], svc.Docker != nil, port)
+	svc.Ports = ports
+
+	return svc, &PortRemap{Service: name, From: declared, To: port}, nil
+}
+
+// ApplyPortRemap points a remapped service's runtime at its new port: PORT is set, and ${PORT}
+// and $PORT are replaced in its arguments and in its azure.yaml environment values.
+func ApplyPortRemap(rt *ServiceRuntime, svc Service, remap PortRemap) {
+	port := strconv.Itoa(remap.To)
+	expand := func(value string) string {
+		return strings.NewReplacer("${PORT}", port, "$PORT", port).Replace(value)
+	}
+
+	for i, arg := range rt.Args {
+		rt.Args[i] = expand(arg)
+	}
+
+	if rt.Env == nil {
+		rt.Env = make(map[string]string)
+	}
+	for key, value := range svc.GetEnvironment() {
+		if strings.Contains(value, "$PORT") || strings.Contains(value, "${PORT}") {
+			rt.Env[key] = expand(value)
+		}
+	}
+	rt.Env["PORT"] = port
+}
+
+// nextFreePort returns the first port after from, within [start, end], that is neither in
+// usedPorts nor taken according to isFree.
+func nextFreePort(from int, usedPorts map[int]bool, start, end int, isFree func(port int) bool) (int, error) {
+	size := end - start + 1
+	if size <= 0 {
+		return 0, fmt.Errorf("invalid port range %d-%d", start, end)
+	}
+
+	first := from + 1
+	if first < start || first > end {
+		first = start
+	}
+	for i := 0; i < size; i++ {
+		port := start + (first-start+i)%size
+		if port == from || usedPorts[port] {
+			continue
+		}
+		if isFree(port) {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free port in range %d-%d", start, end)
+}
+
+// remapPortSpec replaces the host port in a port spec, keeping its name, bind address,
+// container port and protocol.
+func remapPortSpec(spec string, isDocker bool, port int) string {
+	mapping := ParsePortSpec(spec, isDocker)
+
+	result := strconv.Itoa(port)
+	if mapping.BindIP != "" {
+		bindIP := mapping.BindIP
+		if strings.Contains(bindIP, ":") {
+			bindIP = "[" + bindIP + "]"
+		}
+		result = bindIP + ":" + result
+	}
+	// With a bind address, the container port is required to tell the parts apart
+	if mapping.ContainerPort != 0 && (mapping.ContainerPort != mapping.HostPort || mapping.BindIP != "") {
+		result += ":" + strconv.Itoa(mapping.ContainerPort)
+	}
+	if mapping.Protocol != "" && mapping.Protocol != "tcp" {
+		result += "/" + mapping.Protocol
+	}
+	if mapping.Name != "" {
+		result = mapping.Name + "=" + result
+	}
+	return result
+}
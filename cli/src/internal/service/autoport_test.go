@@ -0,0 +1,148 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRemapBusyPort(t *testing.T) {
+	busy := map[int]bool{8080: true, 8081: true}
+	isFree := func(port int) bool { return !busy[port] }
+
+	tests := []struct {
+		name      string
+		svc       Service
+		usedPorts map[int]bool
+		start     int
+		end       int
+		wantPorts []string
+		wantRemap *PortRemap
+		wantErr   string
+	}{
+		{
+			name:      "free port is kept",
+			svc:       Service{Language: "go", Ports: []string{"9000"}},
+			start:     3000,
+			end:       65535,
+			wantPorts: []string{"9000"},
+		},
+		{
+			name:      "busy port moves to the next free one",
+			svc:       Service{Language: "go", Ports: []string{"8080", "9090"}},
+			start:     3000,
+			end:       65535,
+			wantPorts: []string{"8082", "9090"},
+			wantRemap: &PortRemap{Service: "api", From: 8080, To: 8082},
+		},
+		{
+			name:      "port used by another service",
+			svc:       Service{Language: "go", Ports: []string{"9000"}},
+			usedPorts: map[int]bool{9000: true, 9001: true},
+			start:     3000,
+			end:       65535,
+			wantPorts: []string{"9002"},
+			wantRemap: &PortRemap{Service: "api", From: 9000, To: 9002},
+		},
+		{
+			name:      "search wraps around the range",
+			svc:       Service{Language: "go", Ports: []string{"8081"}},
+			start:     8079,
+			end:       8081,
+			wantPorts: []string{"8079"},
+			wantRemap: &PortRemap{Service: "api", From: 8081, To: 8079},
+		},
+		{
+			name:    "no free port in range",
+			svc:     Service{Language: "go", Ports: []string{"8080"}},
+			start:   8080,
+			end:     8081,
+			wantErr: "port 8080 for service api is busy: no free port in range 8080-8081",
+		},
+		{
+			name:      "container services are not remapped",
+			svc:       Service{Image: "redis:7", Ports: []string{"8080:6379"}},
+			start:     3000,
+			end:       65535,
+			wantPorts: []string{"8080:6379"},
+		},
+		{
+			name:  "no declared port",
+			svc:   Service{Language: "go"},
+			start: 3000,
+			end:   65535,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]string(nil), tt.svc.Ports...)
+			got, remap, err := RemapBusyPort("api", tt.svc, tt.usedPorts, tt.start, tt.end, isFree)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RemapBusyPort() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RemapBusyPort() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Ports, tt.wantPorts) {
+				t.Errorf("Ports = %v, want %v", got.Ports, tt.wantPorts)
+			}
+			if !reflect.DeepEqual(remap, tt.wantRemap) {
+				t.Errorf("remap = %+v, want %+v", remap, tt.wantRemap)
+			}
+			if !reflect.DeepEqual(tt.svc.Ports, original) {
+				t.Errorf("RemapBusyPort() modified the original ports: %v", tt.svc.Ports)
+			}
+		})
+	}
+}
+
+func TestRemapPortSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"8080", "8081"},
+		{"8080:3000", "8081:3000"},
+		{"127.0.0.1:8080:8080", "127.0.0.1:8081:8080"},
+		{"[::1]:8080:3000", "[::1]:8081:3000"},
+		{"8080/udp", "8081/udp"},
+		{"web=8080:3000", "web=8081:3000"},
+	}
+	for _, tt := range tests {
+		if got := remapPortSpec(tt.spec, false, 8081); got != tt.want {
+			t.Errorf("remapPortSpec(%q) = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestApplyPortRemap(t *testing.T) {
+	rt := &ServiceRuntime{
+		Name:    "api",
+		Command: "node",
+		Args:    []string{"server.js", "--port", "${PORT}", "--inspect=$PORT"},
+		Port:    8081,
+	}
+	svc := Service{Environment: map[string]string{
+		"API_URL":   "http://localhost:${PORT}/api",
+		"LOG_LEVEL": "debug",
+	}}
+
+	ApplyPortRemap(rt, svc, PortRemap{Service: "api", From: 8080, To: 8081})
+
+	wantArgs := []string{"server.js", "--port", "8081", "--inspect=8081"}
+	if !reflect.DeepEqual(rt.Args, wantArgs) {
+		t.Errorf("Args = %v, want %v", rt.Args, wantArgs)
+	}
+	wantEnv := map[string]string{"PORT": "8081", "API_URL": "http://localhost:8081/api"}
+	if !reflect.DeepEqual(rt.Env, wantEnv) {
+		t.Errorf("Env = %v, want %v", rt.Env, wantEnv)
+	}
+
+	if got := (PortRemap{Service: "api", From: 8080, To: 8081}).String(); got != "api: 8080 busy → using 8081" {
+		t.Errorf("String() = %q", got)
+	}
+}
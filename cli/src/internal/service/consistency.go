@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConsistencyError reports service fields in azure.yaml whose values contradict each other,
+// such as a process service that declares ports.
+type ConsistencyError struct {
+	Service string   // Service name
+	Fields  []string // Conflicting fields, e.g. ["type", "ports"]
+	Reason  string   // Why the fields conflict and how to resolve it
+}
+
+// Error implements the error interface.
+func (e *ConsistencyError) Error() string {
+	return fmt.Sprintf("service %s: conflicting %s: %s", e.Service, strings.Join(e.Fields, " and "), e.Reason)
+}
+
+// ValidateServiceConsistency checks a service's type, ports, mode and healthcheck settings
+// against each other and returns one ConsistencyError per conflict.
+func ValidateServiceConsistency(name string, svc Service) []error {
+	var errs []error
+	conflict := func(reason string, fields ...string) {
+		errs = append(errs, &ConsistencyError{Service: name, Fields: fields, Reason: reason})
+	}
+
+	serviceType := svc.GetServiceType()
+
+	// An explicit process type wins over the ports that would otherwise make it http
+	if svc.IsProcessService() && svc.NeedsPort() {
+		conflict(fmt.Sprintf("type 'process' has no network endpoint, but ports %v are declared; remove ports or use type 'http' or 'tcp'", svc.Ports), "type", "ports")
+	}
+
+	// A udp type inferred from the healthcheck is reported as a healthcheck conflict below
+	if (serviceType == ServiceTypeHTTP || serviceType == ServiceTypeTCP || svc.Type == ServiceTypeUDP) && !svc.NeedsPort() {
+		conflict(fmt.Sprintf("type '%s' needs a port, but no ports are declared; add ports or use type 'process'", serviceType), "type", "ports")
+	}
+
+	if svc.Mode != "" && serviceType != ServiceTypeProcess {
+		conflict(fmt.Sprintf("mode '%s' only applies to process services, but the service type is '%s'", svc.Mode, serviceType), "mode", "type")
+	}
+
+	if svc.Healthcheck != nil && !svc.IsHealthcheckDisabled() {
+		checkType := svc.Healthcheck.Type
+
+		if (checkType == ServiceTypeHTTP || checkType == HealthCheckTypeWebSocket || checkType == ServiceTypeTCP || checkType == ServiceTypeUDP) && !svc.NeedsPort() {
+			conflict(fmt.Sprintf("a '%s' healthcheck needs a port, but no ports are declared; add ports or use healthcheck type 'process' or 'output'", checkType), "healthcheck.type", "ports")
+		}
+
+		if svc.Healthcheck.Path != "" && checkType != "" && checkType != ServiceTypeHTTP && checkType != HealthCheckTypeWebSocket {
+			conflict(fmt.Sprintf("healthcheck path %q is only used by 'http' and 'ws' healthchecks, but the healthcheck type is '%s'", svc.Healthcheck.Path, checkType), "healthcheck.path", "healthcheck.type")
+		}
+
+		if svc.Healthcheck.ExpectStatus != 0 && checkType != "" && checkType != ServiceTypeHTTP {
+			conflict(fmt.Sprintf("healthcheck expectStatus %d is only used by 'http' healthchecks, but the healthcheck type is '%s'", svc.Healthcheck.ExpectStatus, checkType), "healthcheck.expectStatus", "healthcheck.type")
+		}
+
+		if svc.Healthcheck.ExpectBody != "" && checkType != "" && checkType != ServiceTypeHTTP {
+			conflict(fmt.Sprintf("healthcheck expectBody %q is only used by 'http' healthchecks, but the healthcheck type is '%s'", svc.Healthcheck.ExpectBody, checkType), "healthcheck.expectBody", "healthcheck.type")
+		}
+
+		if svc.Healthcheck.Pattern != "" && checkType != "" && checkType != "output" {
+			conflict(fmt.Sprintf("healthcheck pattern %q is only used by 'output' healthchecks, but the healthcheck type is '%s'", svc.Healthcheck.Pattern, checkType), "healthcheck.pattern", "healthcheck.type")
+		}
+
+		if svc.Healthcheck.Probe != "" && svc.GetHealthCheckType() != ServiceTypeUDP {
+			conflict(fmt.Sprintf("healthcheck probe is only used by 'udp' healthchecks, but the healthcheck type is '%s'", svc.GetHealthCheckType()), "healthcheck.probe", "healthcheck.type")
+		}
+	}
+
+	return errs
+}
+
+// ValidateServicesConsistency runs ValidateServiceConsistency for every service, in name order.
+func ValidateServicesConsistency(services map[string]Service) []error {
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		errs = append(errs, ValidateServiceConsistency(name, services[name])...)
+	}
+	return errs
+}
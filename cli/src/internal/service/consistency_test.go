@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateServiceConsistency(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name       string
+		svc        Service
+		wantFields [][]string
+		wantReason string
+	}{
+		{
+			name: "http service with ports",
+			svc:  Service{Project: "./api", Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Type: "http", Path: "/health"}},
+		},
+		{
+			name: "process service without ports",
+			svc:  Service{Project: "./worker", Type: ServiceTypeProcess, Mode: ServiceModeWatch, Healthcheck: &HealthcheckConfig{Type: "output", Pattern: "Found 0 errors"}},
+		},
+		{
+			name: "container without ports",
+			svc:  Service{Image: "redis:7"},
+		},
+		{
+			name:       "process service declaring ports",
+			svc:        Service{Project: "./worker", Type: ServiceTypeProcess, Ports: []string{"3000"}},
+			wantFields: [][]string{{"type", "ports"}},
+			wantReason: "type 'process' has no network endpoint, but ports [3000] are declared",
+		},
+		{
+			name:       "http type without ports",
+			svc:        Service{Project: "./api", Type: ServiceTypeHTTP},
+			wantFields: [][]string{{"type", "ports"}},
+			wantReason: "type 'http' needs a port",
+		},
+		{
+			name:       "tcp type without ports",
+			svc:        Service{Project: "./db", Type: ServiceTypeTCP},
+			wantFields: [][]string{{"type", "ports"}},
+			wantReason: "type 'tcp' needs a port",
+		},
+		{
+			name:       "udp type without ports",
+			svc:        Service{Project: "./collector", Type: ServiceTypeUDP},
+			wantFields: [][]string{{"type", "ports"}},
+			wantReason: "type 'udp' needs a port",
+		},
+		{
+			name:       "udp healthcheck on port-less service",
+			svc:        Service{Project: "./collector", Healthcheck: &HealthcheckConfig{Type: "udp"}},
+			wantFields: [][]string{{"healthcheck.type", "ports"}},
+			wantReason: "a 'udp' healthcheck needs a port",
+		},
+		{
+			name: "udp service with probe",
+			svc:  Service{Project: "./collector", Type: ServiceTypeUDP, Ports: []string{"8125"}, Healthcheck: &HealthcheckConfig{Probe: "ping"}},
+		},
+		{
+			name:       "probe with http healthcheck",
+			svc:        Service{Project: "./api", Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Probe: "ping"}},
+			wantFields: [][]string{{"healthcheck.probe", "healthcheck.type"}},
+			wantReason: "healthcheck probe is only used by 'udp' healthchecks, but the healthcheck type is 'http'",
+		},
+		{
+			name:       "http healthcheck on port-less service",
+			svc:        Service{Project: "./worker", Healthcheck: &HealthcheckConfig{Type: "http"}},
+			wantFields: [][]string{{"healthcheck.type", "ports"}},
+			wantReason: "a 'http' healthcheck needs a port, but no ports are declared",
+		},
+		{
+			name:       "tcp healthcheck on port-less service",
+			svc:        Service{Project: "./worker", Healthcheck: &HealthcheckConfig{Type: "tcp"}},
+			wantFields: [][]string{{"healthcheck.type", "ports"}},
+			wantReason: "a 'tcp' healthcheck needs a port",
+		},
+		{
+			name: "ws healthcheck with path",
+			svc:  Service{Project: "./chat", Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Type: HealthCheckTypeWebSocket, Path: "/ws"}},
+		},
+		{
+			name:       "ws healthcheck on port-less service",
+			svc:        Service{Project: "./chat", Healthcheck: &HealthcheckConfig{Type: HealthCheckTypeWebSocket}},
+			wantFields: [][]string{{"healthcheck.type", "ports"}},
+			wantReason: "a 'ws' healthcheck needs a port",
+		},
+		{
+			name:       "mode on http service",
+			svc:        Service{Project: "./api", Ports: []string{"8080"}, Mode: ServiceModeWatch},
+			wantFields: [][]string{{"mode", "type"}},
+			wantReason: "mode 'watch' only applies to process services, but the service type is 'http'",
+		},
+		{
+			name:       "healthcheck path with tcp type",
+			svc:        Service{Project: "./api", Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Type: "tcp", Path: "/health"}},
+			wantFields: [][]string{{"healthcheck.path", "healthcheck.type"}},
+			wantReason: `healthcheck path "/health" is only used by 'http' and 'ws' healthchecks, but the healthcheck type is 'tcp'`,
+		},
+		{
+			name:       "healthcheck pattern with process type",
+			svc:        Service{Project: "./worker", Type: ServiceTypeProcess, Healthcheck: &HealthcheckConfig{Type: "process", Pattern: "ready"}},
+			wantFields: [][]string{{"healthcheck.pattern", "healthcheck.type"}},
+			wantReason: `healthcheck pattern "ready" is only used by 'output' healthchecks, but the healthcheck type is 'process'`,
+		},
+		{
+			name:       "healthcheck expectations with tcp type",
+			svc:        Service{Project: "./api", Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Type: "tcp", ExpectStatus: 200, ExpectBody: "ok"}},
+			wantFields: [][]string{{"healthcheck.expectStatus", "healthcheck.type"}, {"healthcheck.expectBody", "healthcheck.type"}},
+		},
+		{
+			name:       "several conflicts",
+			svc:        Service{Project: "./worker", Type: ServiceTypeProcess, Ports: []string{"3000"}, Healthcheck: &HealthcheckConfig{Type: "tcp", Path: "/health"}},
+			wantFields: [][]string{{"type", "ports"}, {"healthcheck.path", "healthcheck.type"}},
+		},
+		{
+			name: "disabled healthcheck is not checked",
+			svc:  Service{Project: "./worker", Healthcheck: &HealthcheckConfig{Type: "http"}, HealthcheckEnabled: &disabled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateServiceConsistency("svc", tt.svc)
+
+			var gotFields [][]string
+			for _, err := range errs {
+				var conflict *ConsistencyError
+				if !errors.As(err, &conflict) {
+					t.Fatalf("error %v is not a *ConsistencyError", err)
+				}
+				if conflict.Service != "svc" {
+					t.Errorf("Service = %q, want svc", conflict.Service)
+				}
+				gotFields = append(gotFields, conflict.Fields)
+			}
+			if !reflect.DeepEqual(gotFields, tt.wantFields) {
+				t.Fatalf("conflicting fields = %v, want %v (errors: %v)", gotFields, tt.wantFields, errs)
+			}
+			if tt.wantReason != "" && !strings.Contains(errs[0].Error(), tt.wantReason) {
+				t.Errorf("error = %q, want it to contain %q", errs[0].Error(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestConsistencyErrorMessage(t *testing.T) {
+	err := &ConsistencyError{Service: "worker", Fields: []string{"type", "ports"}, Reason: "explanation"}
+	want := "service worker: conflicting type and ports: explanation"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidateServicesConsistency(t *testing.T) {
+	errs := ValidateServicesConsistency(map[string]Service{
+		"web":    {Project: "./web", Ports: []string{"3000"}},
+		"worker": {Project: "./worker", Type: ServiceTypeProcess, Ports: []string{"9000"}},
+		"api":    {Project: "./api", Type: ServiceTypeHTTP},
+	})
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 conflicts, got %d: %v", len(errs), errs)
+	}
+	// Results are ordered by service name
+	if !strings.HasPrefix(errs[0].Error(), "service api:") || !strings.HasPrefix(errs[1].Error(), "service worker:") {
+		t.Errorf("unexpected conflicts or order: %v", errs)
+	}
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jongio/azd-app/cli/src/internal/docker"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImageCacheFileName is the name of the file, in the project's .azure/app-cache directory,
+// that records the inputs of the images built for Dockerfile services.
+const ImageCacheFileName = "images.json"
+
+// ContainerBuild describes how the image of a container service is built from its Dockerfile.
+type ContainerBuild struct {
+	Dockerfile string   `json:"dockerfile"`
+	Context    string   `json:"context"`
+	Platform   string   `json:"platform,omitempty"`
+	BuildArgs  []string `json:"buildArgs,omitempty"`
+}
+
+// imageBuilder builds images for Dockerfile services; see newImageBuilder.
+type imageBuilder interface {
+	Build(config docker.BuildConfig, output io.Writer) error
+	ImageExists(image string) bool
+}
+
+// newImageBuilder returns the client that builds images. A variable so tests can replace it.
+var newImageBuilder = func() imageBuilder { return docker.NewClient() }
+
+// imageCacheMu serializes updates to the image cache, as services in a dependency level are
+// started in parallel.
+var imageCacheMu sync.Mutex
+
+// imageNameInvalidChars matches runs of characters not allowed in a built image name.
+var imageNameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// newContainerBuild resolves the Dockerfile and build context of a service with `docker.path`
+// and returns the image to build. As in azd, both paths are relative to the service's project
+// directory (or the azure.yaml directory without one), and the context defaults to it.
+// The image is named <app>-<service> after the azure.yaml name.
+func newContainerBuild(serviceName string, service Service, azureYamlDir string) (string, *ContainerBuild) {
+	baseDir := azureYamlDir
+	if service.Project != "" {
+		baseDir = ResolveProjectPath(azureYamlDir, service.Project)
+	}
+
+	build := &ContainerBuild{
+		Dockerfile: ResolveProjectPath(baseDir, service.Docker.Path),
+		Context:    baseDir,
+		Platform:   service.Docker.Platform,
+		BuildArgs:  service.Docker.BuildArgs,
+	}
+	if service.Docker.Context != "" {
+		build.Context = ResolveProjectPath(baseDir, service.Docker.Context)
+	}
+	return builtImageName(appName(azureYamlDir), serviceName), build
+}
+
+// appName returns the name of the project in azureYamlDir, falling back to the directory name.
+func appName(azureYamlDir string) string {
+	// #nosec G304 -- path is the project's azure.yaml
+	if data, err := os.ReadFile(filepath.Join(azureYamlDir, "azure.yaml")); err == nil {
+		var project struct {
+			Name string `yaml:"name"`
+		}
+		if yaml.Unmarshal(data, &project) == nil && project.Name != "" {
+			return project.Name
+		}
+	}
+	return filepath.Base(azureYamlDir)
+}
+
+// builtImageName returns the <app>-<service> image name, lowercased with any characters Docker
+// doesn't allow replaced by '-'.
+func builtImageName(app, serviceName string) string {
+	name := imageNameInvalidChars.ReplaceAllString(strings.ToLower(app+"-"+serviceName), "-")
+	return strings.Trim(name, "._-")
+}
+
+// ImageCachePath returns the location of the image cache for the given project directory.
+func ImageCachePath(projectDir string) string {
+	return filepath.Join(projectDir, ".azure", "app-cache", ImageCacheFileName)
+}
+
+// BuildContainerImage builds the image of a container service with a Dockerfile, writing
+// each line of build output to logLine, and reports whether it did. The build is skipped when
+// the image exists and its Dockerfile, build context and build options haven't changed since
+// it was last built, unless rebuild is set. Services with a prebuilt image have nothing to build.
+func BuildContainerImage(rt *ServiceRuntime, projectDir string, rebuild bool, logLine func(string)) (bool, error) {
+	if rt.Build == nil {
+		return false, nil
+	}
+	image := rt.Command
+	builder := newImageBuilder()
+
+	hash, err := hashContainerBuild(image, rt.Build)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash build context of %s: %w", rt.Name, err)
+	}
+	cachePath := ImageCachePath(projectDir)
+	if !rebuild && readImageCache(cachePath)[image] == hash && builder.ImageExists(image) {
+		logLine(fmt.Sprintf("Image %s is up to date, skipping build", image))
+		return false, nil
+	}
+
+	logLine(fmt.Sprintf("Building image %s from %s", image, rt.Build.Dockerfile))
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			logLine(scanner.Text())
+		}
+		// Keep draining so the build never blocks on a line too long to scan
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	err = builder.Build(docker.BuildConfig{
+		Image:      image,
+		Dockerfile: rt.Build.Dockerfile,
+		Context:    rt.Build.Context,
+		Platform:   rt.Build.Platform,
+		BuildArgs:  rt.Build.BuildArgs,
+	}, writer)
+	_ = writer.Close()
+	<-done
+	if err != nil {
+		return false, err
+	}
+
+	if err := writeImageCache(cachePath, image, hash); err != nil {
+		slog.Warn("failed to save image cache", "path", cachePath, "error", err)
+	}
+	return true, nil
+}
+
+// readImageCache returns the build hash of each image in the cache file. A missing or
+// corrupt file yields an empty cache.
+func readImageCache(path string) map[string]string {
+	imageCacheMu.Lock()
+	defer imageCacheMu.Unlock()
+	return readImageCacheUnsafe(path)
+}
+
+// readImageCacheUnsafe reads the image cache. Caller must hold imageCacheMu.
+func readImageCacheUnsafe(path string) map[string]string {
+	images := make(map[string]string)
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the project's cache file
+	if err != nil {
+		return images
+	}
+	if err := json.Unmarshal(data, &images); err != nil {
+		slog.Debug("ignoring corrupt image cache", "path", path, "error", err)
+		return make(map[string]string)
+	}
+	return images
+}
+
+// writeImageCache records the build hash of an image in the cache file.
+func writeImageCache(path, image, hash string) error {
+	imageCacheMu.Lock()
+	defer imageCacheMu.Unlock()
+
+	images := readImageCacheUnsafe(path)
+	images[image] = hash
+	data, err := json.MarshalIndent(images, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// hashContainerBuild returns a hash of everything that goes into building an image: its name,
+// the build options, the Dockerfile and every file in the build context that .dockerignore
+// doesn't exclude.
+func hashContainerBuild(image string, build *ContainerBuild) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", image, build.Platform, strings.Join(build.BuildArgs, "\x00"))
+
+	if err := hashFileInto(h, build.Dockerfile); err != nil {
+		return "", err
+	}
+
+	ignore := readDockerignore(build.Context)
+	err := filepath.WalkDir(build.Context, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(build.Context, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if entry.IsDir() {
+			// .azure holds azd's state, including the image cache itself
+			if entry.Name() == ".git" || entry.Name() == ".azure" || ignore.matches(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignore.matches(rel) {
+			return nil
+		}
+
+		fmt.Fprintf(h, "%s\x00", rel)
+		if entry.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s\x00", target)
+			return nil
+		}
+		return hashFileInto(h, p)
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFileInto writes the contents of a file, followed by a separator, to h.
+func hashFileInto(h io.Writer, p string) error {
+	file, err := os.Open(p) // #nosec G304 -- path is in the service's build context
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	_, err = h.Write([]byte{0})
+	return err
+}
+
+// dockerignore holds the exclude patterns of a build context's .dockerignore.
+type dockerignore []string
+
+// readDockerignore reads the .dockerignore in a build context. Exception patterns ('!') are
+// not supported, so a file with any is not used: hashing more files than Docker sends only
+// costs an occasional unneeded rebuild.
+func readDockerignore(contextDir string) dockerignore {
+	data, err := os.ReadFile(filepath.Join(contextDir, ".dockerignore")) // #nosec G304 -- path is in the service's build context
+	if err != nil {
+		return nil
+	}
+
+	var patterns dockerignore
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "!") {
+			return nil
+		}
+		patterns = append(patterns, strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(line)), "/"))
+	}
+	return patterns
+}
+
+// matches reports whether a slash-separated path relative to the build context is excluded.
+// A leading "**/" matches the rest of the pattern in any directory.
+func (d dockerignore) matches(rel string) bool {
+	for _, pattern := range d {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if rest, ok := strings.CutPrefix(pattern, "**/"); ok {
+			parts := strings.Split(rel, "/")
+			for i := range parts {
+				if ok, _ := path.Match(rest, strings.Join(parts[i:], "/")); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/docker"
+)
+
+// fakeImageBuilder records builds instead of running docker.
+type fakeImageBuilder struct {
+	builds []docker.BuildConfig
+	images map[string]bool
+}
+
+func (f *fakeImageBuilder) Build(config docker.BuildConfig, output io.Writer) error {
+	f.builds = append(f.builds, config)
+	f.images[config.Image] = true
+	_, err := fmt.Fprintf(output, "Step 1/2 : FROM scratch\nSuccessfully tagged %s\n", config.Image)
+	return err
+}
+
+func (f *fakeImageBuilder) ImageExists(image string) bool {
+	return f.images[image]
+}
+
+func TestNewContainerBuild(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte("name: My_Shop\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		svc       Service
+		wantBuild ContainerBuild
+	}{
+		{
+			name: "paths relative to the project",
+			svc: Service{
+				Project: "./src/api",
+				Docker:  &DockerConfig{Path: "./Dockerfile", Platform: "linux/amd64", BuildArgs: []string{"VERSION=1"}},
+			},
+			wantBuild: ContainerBuild{
+				Dockerfile: filepath.Join(dir, "src", "api", "Dockerfile"),
+				Context:    filepath.Join(dir, "src", "api"),
+				Platform:   "linux/amd64",
+				BuildArgs:  []string{"VERSION=1"},
+			},
+		},
+		{
+			name: "explicit context",
+			svc: Service{
+				Project: "./src/api",
+				Docker:  &DockerConfig{Path: "../Dockerfile.api", Context: ".."},
+			},
+			wantBuild: ContainerBuild{
+				Dockerfile: filepath.Join(dir, "src", "Dockerfile.api"),
+				Context:    filepath.Join(dir, "src"),
+			},
+		},
+		{
+			name: "no project",
+			svc:  Service{Docker: &DockerConfig{Path: "docker/api.Dockerfile"}},
+			wantBuild: ContainerBuild{
+				Dockerfile: filepath.Join(dir, "docker", "api.Dockerfile"),
+				Context:    dir,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, build := newContainerBuild("Api", tt.svc, dir)
+			if image != "my_shop-api" {
+				t.Errorf("image = %q, want %q", image, "my_shop-api")
+			}
+			if !reflect.DeepEqual(*build, tt.wantBuild) {
+				t.Errorf("build = %+v, want %+v", *build, tt.wantBuild)
+			}
+		})
+	}
+}
+
+func TestBuiltImageName(t *testing.T) {
+	tests := []struct {
+		app, service, want string
+	}{
+		{"shop", "api", "shop-api"},
+		{"My Shop", "Web_UI", "my-shop-web_ui"},
+		{"-shop.", "api", "shop.-api"},
+		{"shop", "api-", "shop-api"},
+	}
+	for _, tt := range tests {
+		if got := builtImageName(tt.app, tt.service); got != tt.want {
+			t.Errorf("builtImageName(%q, %q) = %q, want %q", tt.app, tt.service, got, tt.want)
+		}
+		if err := docker.ValidateImageName(builtImageName(tt.app, tt.service)); err != nil {
+			t.Errorf("builtImageName(%q, %q) is not a valid image name: %v", tt.app, tt.service, err)
+		}
+	}
+}
+
+func TestBuildContainerImage(t *testing.T) {
+	projectDir := t.TempDir()
+	contextDir := filepath.Join(projectDir, "api")
+	writeFile := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(projectDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("api/Dockerfile", "FROM scratch\n")
+	writeFile("api/main.go", "package main\n")
+	writeFile("api/.dockerignore", "# local files\n*.log\n**/node_modules\n")
+	writeFile("api/node_modules/x/index.js", "1")
+
+	builder := &fakeImageBuilder{images: make(map[string]bool)}
+	defer func(newBuilder func() imageBuilder) { newImageBuilder = newBuilder }(newImageBuilder)
+	newImageBuilder = func() imageBuilder { return builder }
+
+	rt := &ServiceRuntime{
+		Name:    "api",
+		Command: "shop-api",
+		Build:   &ContainerBuild{Dockerfile: filepath.Join(contextDir, "Dockerfile"), Context: contextDir},
+	}
+	var lines []string
+	build := func(rebuild bool) bool {
+		t.Helper()
+		lines = nil
+		built, err := BuildContainerImage(rt, projectDir, rebuild, func(line string) { lines = append(lines, line) })
+		if err != nil {
+			t.Fatalf("BuildContainerImage() error = %v", err)
+		}
+		return built
+	}
+
+	if !build(false) {
+		t.Fatal("first BuildContainerImage() did not build")
+	}
+	if len(lines) != 3 || lines[2] != "Successfully tagged shop-api" {
+		t.Errorf("build output = %q, want the build announcement and each line of docker output", lines)
+	}
+	want := docker.BuildConfig{Image: "shop-api", Dockerfile: rt.Build.Dockerfile, Context: contextDir}
+	if !reflect.DeepEqual(builder.builds[0], want) {
+		t.Errorf("Build() config = %+v, want %+v", builder.builds[0], want)
+	}
+
+	// Nothing changed
+	if build(false) {
+		t.Error("BuildContainerImage() rebuilt an up-to-date image")
+	}
+
+	// Ignored files and azd's own state don't affect the image
+	writeFile("api/debug.log", "x")
+	writeFile("api/node_modules/x/index.js", "2")
+	writeFile("api/.azure/app/last-run.json", "{}")
+	if build(false) {
+		t.Error("BuildContainerImage() rebuilt after only ignored files changed")
+	}
+
+	// Changes to the context or the Dockerfile rebuild
+	writeFile("api/main.go", "package main // changed\n")
+	if !build(false) {
+		t.Error("BuildContainerImage() skipped the build after a context file changed")
+	}
+	writeFile("api/Dockerfile", "FROM alpine\n")
+	if !build(false) {
+		t.Error("BuildContainerImage() skipped the build after the Dockerfile changed")
+	}
+
+	// --rebuild and a removed image rebuild as well
+	if !build(true) {
+		t.Error("BuildContainerImage() with rebuild skipped the build")
+	}
+	delete(builder.images, "shop-api")
+	if !build(false) {
+		t.Error("BuildContainerImage() skipped the build of a missing image")
+	}
+
+	// Prebuilt images have nothing to build
+	built, err := BuildContainerImage(&ServiceRuntime{Name: "redis", Command: "redis:7"}, projectDir, true, func(string) {})
+	if err != nil || built {
+		t.Errorf("BuildContainerImage() for a prebuilt image = %v, %v, want false, nil", built, err)
+	}
+}
+
+func TestDockerignoreMatches(t *testing.T) {
+	ignore := dockerignore{"*.log", "build", "**/node_modules", "docs/*.md"}
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"debug.log", true},
+		{"logs/debug.log", false},
+		{"build", true},
+		{"src/build", false},
+		{"node_modules", true},
+		{"web/node_modules", true},
+		{"docs/readme.md", true},
+		{"docs/api/readme.md", false},
+		{"main.go", false},
+	}
+	for _, tt := range tests {
+		if got := ignore.matches(tt.path); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestReadDockerignore(t *testing.T) {
+	dir := t.TempDir()
+	if got := readDockerignore(dir); got != nil {
+		t.Errorf("readDockerignore() without a file = %v, want nil", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("# comment\n\n/bin\n./obj/\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readDockerignore(dir), (dockerignore{"bin", "obj"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("readDockerignore() = %v, want %v", got, want)
+	}
+
+	// Exceptions aren't supported, so nothing is ignored
+	if err := os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("*.md\n!README.md\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDockerignore(dir); got != nil {
+		t.Errorf("readDockerignore() with an exception = %v, want nil", got)
+	}
+}
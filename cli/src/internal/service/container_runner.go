@@ -1,7 +1,6 @@
 package service
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"log/slog"
@@ -40,7 +39,8 @@ func validateServiceNameForContainer(name string) error {
 }
 
 // StartContainerService starts a Docker container service and returns the process handle.
-// Container services are identified by having an `image` field in azure.yaml.
+// Container services are identified by having an `image` field in azure.yaml, or a
+// `docker.path` Dockerfile whose image BuildContainerImage builds first.
 //
 // Parameters:
 //   - runtime: ServiceRuntime containing service configuration
@@ -72,13 +72,16 @@ func StartContainerService(runtime *ServiceRuntime, projectDir string, restartCo
 		slog.String("image", image),
 		slog.Int("port", runtime.Port))
 
-	// Pull image if needed (will be cached if already present)
-	slog.Debug("pulling container image", slog.String("image", image))
-	if err := client.Pull(image); err != nil {
-		// Don't fail if pull fails - image might be cached locally
-		slog.Warn("failed to pull image (continuing with cached version if available)",
-			slog.String("image", image),
-			slog.String("error", err.Error()))
+	// Pull image if needed (will be cached if already present). Images built from a
+	// Dockerfile only exist locally.
+	if runtime.Build == nil {
+		slog.Debug("pulling container image", slog.String("image", image))
+		if err := client.Pull(image); err != nil {
+			// Don't fail if pull fails - image might be cached locally
+			slog.Warn("failed to pull image (continuing with cached version if available)",
+				slog.String("image", image),
+				slog.String("error", err.Error()))
+		}
 	}
 
 	// Check if container already exists and is running
@@ -162,21 +165,39 @@ func StartContainerService(runtime *ServiceRuntime, projectDir string, restartCo
 	return process, nil
 }
 
-// buildContainerPortMappings converts ServiceRuntime port to Docker port mappings.
+// containerExecer runs commands inside running containers; see newContainerExecer.
+type containerExecer interface {
+	Exec(containerName string, command []string) (int, string, error)
+	ExecShell(containerName string, shellCommand string) (int, string, error)
+}
+
+// newContainerExecer returns the client that runs healthcheck tests inside containers. A
+// variable so tests can replace it.
+var newContainerExecer = func() containerExecer { return docker.NewClient() }
+
+// buildContainerPortMappings converts ServiceRuntime ports to Docker port mappings.
 func buildContainerPortMappings(runtime *ServiceRuntime) []docker.PortMapping {
 	var mappings []docker.PortMapping
 
-	// If runtime has a port, map it
-	if runtime.Port > 0 {
+	// Publish every declared mapping
+	for _, port := range runtime.Ports {
+		mappings = append(mappings, docker.PortMapping{
+			HostPort:      port.HostPort,
+			ContainerPort: port.ContainerPort,
+			Protocol:      port.Protocol,
+			BindIP:        port.BindIP,
+		})
+	}
+
+	// Runtimes without mappings only have a primary port, published as is
+	if len(mappings) == 0 && runtime.Port > 0 {
 		mappings = append(mappings, docker.PortMapping{
 			HostPort:      runtime.Port,
-			ContainerPort: runtime.Port, // Assume same port for now
+			ContainerPort: runtime.Port,
 			Protocol:      "tcp",
 		})
 	}
 
-	// TODO: Parse additional ports from runtime if needed
-
 	return mappings
 }
 
@@ -262,17 +283,10 @@ func StartContainerLogCollection(process *ServiceProcess, projectDir string) err
 func collectContainerLogs(reader io.ReadCloser, serviceName string, buffer *LogBuffer) {
 	defer reader.Close()
 
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		entry := LogEntry{
-			Service:   serviceName,
-			Message:   scanner.Text(),
-			Timestamp: time.Now(),
-			IsStderr:  false, // Docker logs combine stdout/stderr
-			Level:     inferLogLevel(scanner.Text()),
-		}
-		buffer.Add(entry)
-	}
+	scanLogLines(reader, func(line string, truncated bool) {
+		// Docker logs combine stdout/stderr
+		buffer.Add(newOutputLogEntry(serviceName, line, truncated, false))
+	})
 }
 
 // IsContainerRunning checks if a container service is still running.
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Default ports the supported debuggers listen on, matching what their editors attach to.
+const (
+	NodeInspectorPort = 9229
+	DebugpyPort       = 5678
+	DelvePort         = 2345
+)
+
+// debuggerHost is the address debuggers listen on; only local debuggers can attach.
+const debuggerHost = "127.0.0.1"
+
+// WaitForDebugger rewrites a service's command so the service starts paused until a debugger
+// attaches, and returns the address to attach to (azd app run --attach-debugger):
+//   - Node.js: node --inspect-brk
+//   - Python: python -m debugpy --wait-for-client
+//   - Go: dlv debug (or dlv exec for a built binary) in headless mode, which waits for a client
+//
+// The service's health check is replaced by a process check, since a paused service can't
+// answer health probes until the debugger resumes it.
+func WaitForDebugger(rt *ServiceRuntime) (string, error) {
+	command := strings.TrimSuffix(strings.ToLower(filepath.Base(rt.Command)), ".exe")
+
+	var address string
+	switch rt.Language {
+	case "JavaScript", "TypeScript":
+		if command != "node" {
+			return "", fmt.Errorf("the service runs %q; waiting for a debugger requires a node command (e.g. command: node server.js)", rt.Command)
+		}
+		address = fmt.Sprintf("%s:%d", debuggerHost, NodeInspectorPort)
+		rt.Args = append([]string{"--inspect-brk=" + address}, rt.Args...)
+
+	case "Python":
+		if !strings.HasPrefix(command, "python") {
+			return "", fmt.Errorf("the service runs %q; waiting for a debugger requires a python command (e.g. command: python -m uvicorn main:app)", rt.Command)
+		}
+		address = fmt.Sprintf("%s:%d", debuggerHost, DebugpyPort)
+		rt.Args = append([]string{"-m", "debugpy", "--listen", address, "--wait-for-client"}, rt.Args...)
+
+	case "Go":
+		address = fmt.Sprintf("%s:%d", debuggerHost, DelvePort)
+		headless := []string{"--headless", "--listen=" + address, "--api-version=2", "--accept-multiclient"}
+		if command == "go" {
+			if len(rt.Args) == 0 || rt.Args[0] != "run" {
+				return "", fmt.Errorf("the service runs %q; waiting for a debugger requires go run or a built binary", strings.Join(append([]string{rt.Command}, rt.Args...), " "))
+			}
+			// go run [build flags] <package> [args] becomes
+			// dlv debug <package> --build-flags=<build flags> -- [args]
+			buildFlags, pkg, programArgs := splitGoRunArgs(rt.Args[1:])
+			args := []string{"debug", pkg}
+			if len(buildFlags) > 0 {
+				args = append(args, "--build-flags="+strings.Join(buildFlags, " "))
+			}
+			rt.Args = append(append(args, headless...), withArgsSeparator(programArgs)...)
+		} else {
+			rt.Args = append(append([]string{"exec", rt.Command}, headless...), withArgsSeparator(rt.Args)...)
+		}
+		rt.Command = "dlv"
+
+	default:
+		return "", fmt.Errorf("waiting for a debugger is not supported for %s services (supported: Node.js, Python, Go)", rt.Language)
+	}
+
+	rt.HealthCheck.Type = "process"
+	return address, nil
+}
+
+// goBuildValueFlags are the go build flags whose value can follow as a separate argument.
+var goBuildValueFlags = map[string]bool{
+	"C": true, "asmflags": true, "buildmode": true, "compiler": true, "covermode": true,
+	"coverpkg": true, "exec": true, "gccgoflags": true, "gcflags": true, "installsuffix": true,
+	"ldflags": true, "mod": true, "modfile": true, "overlay": true, "p": true, "pgo": true,
+	"pkgdir": true, "tags": true, "toolexec": true,
+}
+
+// splitGoRunArgs splits the arguments after go run into its build flags, the package (the
+// current directory if none is given) and the program's arguments. Flag values containing
+// spaces are quoted so delve's --build-flags keeps them together.
+func splitGoRunArgs(args []string) (buildFlags []string, pkg string, programArgs []string) {
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		flag := args[i]
+		i++
+		name := strings.TrimLeft(flag, "-")
+		if !strings.Contains(name, "=") && goBuildValueFlags[name] && i < len(args) {
+			flag += "=" + args[i]
+			i++
+		}
+		if strings.ContainsAny(flag, " \t") {
+			if key, value, ok := strings.Cut(flag, "="); ok {
+				flag = key + "='" + value + "'"
+			}
+		}
+		buildFlags = append(buildFlags, flag)
+	}
+	if i == len(args) {
+		return buildFlags, ".", nil
+	}
+	return buildFlags, args[i], args[i+1:]
+}
+
+// withArgsSeparator prefixes program arguments with "--" so delve passes them to the program.
+func withArgsSeparator(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	return append([]string{"--"}, args...)
+}
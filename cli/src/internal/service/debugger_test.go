@@ -0,0 +1,105 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWaitForDebugger(t *testing.T) {
+	tests := []struct {
+		name        string
+		rt          ServiceRuntime
+		wantCommand string
+		wantArgs    []string
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name:        "node uses --inspect-brk",
+			rt:          ServiceRuntime{Language: "JavaScript", Command: "node", Args: []string{"server.js"}},
+			wantCommand: "node",
+			wantArgs:    []string{"--inspect-brk=127.0.0.1:9229", "server.js"},
+			wantAddress: "127.0.0.1:9229",
+		},
+		{
+			name:    "node package manager scripts are not supported",
+			rt:      ServiceRuntime{Language: "TypeScript", Command: "npm", Args: []string{"run", "dev"}},
+			wantErr: "requires a node command",
+		},
+		{
+			name:        "python uses debugpy --wait-for-client",
+			rt:          ServiceRuntime{Language: "Python", Command: "/app/.venv/bin/python", Args: []string{"-m", "uvicorn", "main:app"}},
+			wantCommand: "/app/.venv/bin/python",
+			wantArgs:    []string{"-m", "debugpy", "--listen", "127.0.0.1:5678", "--wait-for-client", "-m", "uvicorn", "main:app"},
+			wantAddress: "127.0.0.1:5678",
+		},
+		{
+			name:    "python without a python command is not supported",
+			rt:      ServiceRuntime{Language: "Python", Command: "uvicorn", Args: []string{"main:app"}},
+			wantErr: "requires a python command",
+		},
+		{
+			name:        "go run becomes headless dlv debug",
+			rt:          ServiceRuntime{Language: "Go", Command: "go", Args: []string{"run", "./cmd/api", "-v"}},
+			wantCommand: "dlv",
+			wantArgs:    []string{"debug", "./cmd/api", "--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient", "--", "-v"},
+			wantAddress: "127.0.0.1:2345",
+		},
+		{
+			name:        "go run build flags before the package become delve build flags",
+			rt:          ServiceRuntime{Language: "Go", Command: "go", Args: []string{"run", "-race", "-tags", "dev", "-ldflags=-s -w", "./cmd/api", "-v"}},
+			wantCommand: "dlv",
+			wantArgs:    []string{"debug", "./cmd/api", "--build-flags=-race -tags=dev -ldflags='-s -w'", "--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient", "--", "-v"},
+			wantAddress: "127.0.0.1:2345",
+		},
+		{
+			name:        "go run with only build flags debugs the current directory",
+			rt:          ServiceRuntime{Language: "Go", Command: "go", Args: []string{"run", "-mod", "vendor"}},
+			wantCommand: "dlv",
+			wantArgs:    []string{"debug", ".", "--build-flags=-mod=vendor", "--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient"},
+			wantAddress: "127.0.0.1:2345",
+		},
+		{
+			name:        "go binary becomes headless dlv exec",
+			rt:          ServiceRuntime{Language: "Go", Command: "./bin/api"},
+			wantCommand: "dlv",
+			wantArgs:    []string{"exec", "./bin/api", "--headless", "--listen=127.0.0.1:2345", "--api-version=2", "--accept-multiclient"},
+			wantAddress: "127.0.0.1:2345",
+		},
+		{
+			name:    "unsupported language",
+			rt:      ServiceRuntime{Language: "Rust", Command: "cargo", Args: []string{"run"}},
+			wantErr: "not supported for Rust services",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := tt.rt
+			rt.HealthCheck.Type = "http"
+			address, err := WaitForDebugger(&rt)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("WaitForDebugger() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WaitForDebugger() error = %v", err)
+			}
+			if address != tt.wantAddress {
+				t.Errorf("address = %q, want %q", address, tt.wantAddress)
+			}
+			if rt.Command != tt.wantCommand {
+				t.Errorf("Command = %q, want %q", rt.Command, tt.wantCommand)
+			}
+			if !reflect.DeepEqual(rt.Args, tt.wantArgs) {
+				t.Errorf("Args = %v, want %v", rt.Args, tt.wantArgs)
+			}
+			if rt.HealthCheck.Type != "process" {
+				t.Errorf("HealthCheck.Type = %q, want process", rt.HealthCheck.Type)
+			}
+		})
+	}
+}
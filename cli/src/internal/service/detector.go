@@ -37,13 +37,13 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 		return nil, fmt.Errorf("service %s has no project directory", serviceName)
 	}
 
-	// Resolve relative paths against azure.yaml directory
-	if !filepath.IsAbs(projectDir) {
-		projectDir = filepath.Join(azureYamlDir, projectDir)
+	logMode := service.GetLogMode()
+	if logMode != LogModeLine && logMode != LogModeRaw {
+		return nil, fmt.Errorf("invalid logMode %q for service %s (must be '%s' or '%s')", service.LogMode, serviceName, LogModeLine, LogModeRaw)
 	}
 
-	// Clean and normalize the path
-	projectDir = filepath.Clean(projectDir)
+	// Resolve relative paths against azure.yaml directory, accepting either separator
+	projectDir = ResolveProjectPath(azureYamlDir, projectDir)
 
 	// Validate project directory
 	if err := security.ValidatePath(projectDir); err != nil {
@@ -52,6 +52,11 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 
 	// Determine default health check type based on service configuration
 	defaultHealthCheckType := "http"
+	protocol := "http"
+	if service.GetServiceType() == ServiceTypeUDP {
+		defaultHealthCheckType = ServiceTypeUDP
+		protocol = ServiceTypeUDP
+	}
 	if service.IsHealthcheckDisabled() {
 		defaultHealthCheckType = "none"
 	} else if service.Healthcheck != nil && service.Healthcheck.Type != "" {
@@ -61,7 +66,7 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 	runtime := &ServiceRuntime{
 		Name:       serviceName,
 		WorkingDir: projectDir,
-		Protocol:   "http",
+		Protocol:   protocol,
 		Env:        make(map[string]string),
 		HealthCheck: HealthCheckConfig{
 			Type:     defaultHealthCheckType,
@@ -71,14 +76,17 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 		},
 	}
 
-	// Apply custom health check path and pattern if configured
+	// Apply custom health check path, expectations, pattern and probe if configured
 	if service.Healthcheck != nil {
 		if service.Healthcheck.Path != "" {
 			runtime.HealthCheck.Path = service.Healthcheck.Path
 		}
+		runtime.HealthCheck.ExpectStatus = service.Healthcheck.ExpectStatus
+		runtime.HealthCheck.ExpectBody = service.Healthcheck.ExpectBody
 		if service.Healthcheck.Pattern != "" {
 			runtime.HealthCheck.LogMatch = service.Healthcheck.Pattern
 		}
+		runtime.HealthCheck.Probe = service.Healthcheck.Probe
 	}
 
 	// Special handling for Azure Functions (all variants including Logic Apps)
@@ -105,20 +113,27 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 	runtime.Framework = framework
 	runtime.PackageManager = packageManager
 
-	// Port assignment: skip for services that don't need a port (e.g., build/watch services)
-	if service.NeedsPort() {
+	// Port assignment: skip for services that don't need a port (e.g., build/watch services).
+	// Vite dev servers always listen, on 5173 unless told otherwise, so they get a port
+	// even without one in azure.yaml to keep several of them from colliding
+	if service.NeedsPort() || isViteDevServer(framework) {
 		// Detect preferred port from config (and whether it's explicitly set in azure.yaml)
 		preferredPort, isExplicit, _ := DetectPort(serviceName, service, projectDir, framework, usedPorts)
 
 		// Use port manager from azure.yaml directory (not service project dir) so all services share port assignments
 		portMgr := portmanager.GetPortManager(azureYamlDir)
-		port, shouldUpdateAzureYaml, err := portMgr.AssignPort(serviceName, preferredPort, isExplicit)
+		port, shouldUpdateAzureYaml, err := portMgr.AssignPortWithProtocol(serviceName, preferredPort, isExplicit, portProtocol(runtime.Protocol))
 		if err != nil {
 			return nil, fmt.Errorf("failed to assign port: %w", err)
 		}
 		runtime.Port = port
 		runtime.ShouldUpdateAzureYaml = shouldUpdateAzureYaml // Track if user wants azure.yaml updated
 		usedPorts[port] = true
+
+		// Additional ports are listed, and reserved, alongside the primary one
+		if runtime.Ports, err = resolvePortMappings(serviceName, service, port, usedPorts); err != nil {
+			return nil, err
+		}
 	} else {
 		// No port needed - service runs without HTTP endpoint (e.g., tsc --watch)
 		runtime.Port = 0
@@ -129,14 +144,21 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 	}
 
 	// Build command and args based on framework (AFTER port assignment)
-	// Docker Compose style: entrypoint is executable, command is args
+	// Docker Compose style: entrypoint is executable, command is args.
+	// The configured mode picks between framework commands (e.g. ng build vs ng serve);
+	// for process services without one it is detected from the command below
+	runtime.Mode = service.GetServiceMode()
 	if err := buildRunCommand(runtime, projectDir, service.Entrypoint, service.Command, runtimeMode); err != nil {
 		return nil, fmt.Errorf("failed to build run command: %w", err)
 	}
 
-	// Set health check configuration based on framework (only if not explicitly disabled)
+	// Set health check configuration based on framework (only if not explicitly disabled);
+	// a healthcheck path from azure.yaml wins over the framework's
 	if !service.IsHealthcheckDisabled() {
 		configureHealthCheck(runtime)
+		if service.Healthcheck != nil && service.Healthcheck.Path != "" {
+			runtime.HealthCheck.Path = service.Healthcheck.Path
+		}
 	}
 
 	// Detect and set service type and mode
@@ -145,14 +167,20 @@ func DetectServiceRuntime(serviceName string, service Service, usedPorts map[int
 		// Detect mode from explicit config, command, or project structure
 		runtime.Mode = detectServiceMode(service, runtime, projectDir)
 	}
+	runtime.LogMode = logMode
 
 	return runtime, nil
 }
 
 // detectContainerRuntime creates a ServiceRuntime for a Docker container service.
-// Container services are identified by having an `image` field set.
+// Container services are identified by having an `image` field set, or a `docker.path`
+// Dockerfile to build their image from.
 func detectContainerRuntime(serviceName string, service Service, usedPorts map[int]bool, azureYamlDir string) (*ServiceRuntime, error) {
 	image := service.GetContainerImage()
+	var build *ContainerBuild
+	if service.NeedsImageBuild() {
+		image, build = newContainerBuild(serviceName, service, azureYamlDir)
+	}
 	if image == "" {
 		return nil, fmt.Errorf("container service %s has no image", serviceName)
 	}
@@ -178,9 +206,22 @@ func detectContainerRuntime(serviceName string, service Service, usedPorts map[i
 		},
 	}
 
+	// Apply custom health check settings. A Docker Compose-style test decides readiness by
+	// itself unless a type is set explicitly.
+	if service.Healthcheck != nil && !service.IsHealthcheckDisabled() {
+		runtime.HealthCheck.Path = service.Healthcheck.Path
+		runtime.HealthCheck.ExpectStatus = service.Healthcheck.ExpectStatus
+		runtime.HealthCheck.ExpectBody = service.Healthcheck.ExpectBody
+		runtime.HealthCheck.Probe = service.Healthcheck.Probe
+		if service.Healthcheck.Type == "" {
+			runtime.HealthCheck.Test = service.Healthcheck.GetTest()
+		}
+	}
+
 	// Store container image in the runtime (using Command field for now)
 	// TODO: Add dedicated Image field to ServiceRuntime
 	runtime.Command = image
+	runtime.Build = build
 	runtime.Language = "container"
 	runtime.Framework = "docker"
 
@@ -201,7 +242,7 @@ func detectContainerRuntime(serviceName string, service Service, usedPorts map[i
 			if hostPort == 0 {
 				// Auto-assign host port using port manager
 				portMgr := portmanager.GetPortManager(azureYamlDir)
-				assignedPort, shouldUpdate, err := portMgr.AssignPort(serviceName, containerPort, isExplicit)
+				assignedPort, shouldUpdate, err := portMgr.AssignPortWithProtocol(serviceName, containerPort, isExplicit, portProtocol(mappings[0].Protocol))
 				if err != nil {
 					return nil, fmt.Errorf("failed to assign port for container: %w", err)
 				}
@@ -214,6 +255,13 @@ func detectContainerRuntime(serviceName string, service Service, usedPorts map[i
 			// Update health check port
 			runtime.HealthCheck.Port = runtime.Port
 			usedPorts[runtime.Port] = true
+
+			// Publish every declared port, with the first as the primary
+			ports, err := resolvePortMappings(serviceName, service, runtime.Port, usedPorts)
+			if err != nil {
+				return nil, err
+			}
+			runtime.Ports = ports
 		}
 	}
 
@@ -234,8 +282,8 @@ func detectServiceMode(service Service, runtime *ServiceRuntime, projectDir stri
 		fullCmd = fullCmd + " " + strings.Join(runtime.Args, " ")
 	}
 
-	// Check command for watch indicators
-	if isWatchCommand(fullCmd) {
+	// Check command for watch indicators; Vite's dev server always watches for changes
+	if isWatchCommand(fullCmd) || isViteDevServer(runtime.Framework) {
 		return ServiceModeWatch
 	}
 
@@ -264,9 +312,15 @@ func isWatchCommand(cmd string) bool {
 		" watch",
 		"nodemon",
 		"tsx watch",
+		"ng serve",
 		"ts-node-dev",
 		"dotnet watch",
 		"cargo watch",
+		// Quarkus dev mode (Maven, Gradle), Micronaut (Maven) and Gradle continuous builds
+		"quarkus:dev",
+		"quarkusdev",
+		"mn:run",
+		"--continuous",
 		"air ", // Go live reload
 		"reflex",
 		"entr",
@@ -291,8 +345,8 @@ func isBuildCommand(cmd string, language string) bool {
 
 	// Language-specific build commands
 	buildCommands := map[string][]string{
-		"TypeScript": {"tsc", "npm run build", "pnpm build", "yarn build", "bun build"},
-		"JavaScript": {"npm run build", "pnpm build", "yarn build", "bun build", "webpack", "rollup", "esbuild"},
+		"TypeScript": {"tsc", "npm run build", "pnpm build", "yarn build", "bun build", "ng build"},
+		"JavaScript": {"npm run build", "pnpm build", "yarn build", "bun build", "webpack", "rollup", "esbuild", "ng build"},
 		"Go":         {"go build", "go install"},
 		".NET":       {"dotnet build", "dotnet publish"},
 		"Rust":       {"cargo build"},
@@ -393,3 +447,12 @@ func containsWatchDependency(projectDir string) bool {
 
 	return false
 }
+
+// portProtocol returns the portmanager protocol used to probe and reserve a port serving
+// the given protocol: UDP for "udp", TCP for everything else (http, tcp, grpc).
+func portProtocol(protocol string) string {
+	if protocol == ServiceTypeUDP {
+		return portmanager.ProtocolUDP
+	}
+	return portmanager.ProtocolTCP
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/jongio/azd-app/cli/src/internal/security"
@@ -72,13 +73,21 @@ func buildFrameworkCommand(runtime *ServiceRuntime, projectDir, runtimeMode stri
 	}
 
 	switch runtime.Framework {
-	case "Next.js", "React", "Vue", "Svelte", "SvelteKit", "Remix", "Astro", "Nuxt":
+	case "Next.js", "React", "Vue", "Svelte", "Astro", "Nuxt":
 		runtime.Command = runtime.PackageManager
 		runtime.Args = []string{"run", "dev"}
 
+	case "Vite":
+		buildViteCommand(runtime, projectDir, "vite")
+
+	case "SvelteKit":
+		buildViteCommand(runtime, projectDir, "vite", "dev")
+
+	case "Remix":
+		buildRemixCommand(runtime, projectDir)
+
 	case "Angular":
-		runtime.Command = "ng"
-		runtime.Args = []string{"serve", "--port", fmt.Sprintf("%d", runtime.Port)}
+		buildAngularCommand(runtime, projectDir)
 
 	case "NestJS":
 		runtime.Command = runtime.PackageManager
@@ -103,12 +112,8 @@ func buildFrameworkCommand(runtime *ServiceRuntime, projectDir, runtimeMode stri
 	case "ASP.NET Core", ".NET":
 		return buildDotNetCommand(runtime, projectDir, runtimeMode, false)
 
-	case "Spring Boot":
-		buildJavaCommand(runtime, true)
-		return nil
-
-	case "Java":
-		buildJavaCommand(runtime, false)
+	case "Spring Boot", "Quarkus", "Micronaut", "Java":
+		buildJavaCommand(runtime, projectDir)
 		return nil
 
 	case "Go":
@@ -134,6 +139,71 @@ func buildFrameworkCommand(runtime *ServiceRuntime, projectDir, runtimeMode stri
 	return nil
 }
 
+// buildViteCommand configures a Vite dev server: the package.json "dev" script, or the
+// fallback command run with npx without one. Vite ignores PORT and moves to another port when
+// 5173 is taken, so the assigned port is passed with --strictPort to keep it on that port.
+func buildViteCommand(runtime *ServiceRuntime, projectDir string, fallback ...string) {
+	var portArgs []string
+	if runtime.Port > 0 {
+		portArgs = []string{"--port", fmt.Sprintf("%d", runtime.Port), "--strictPort"}
+	}
+
+	if !hasScript(projectDir, "dev") {
+		runtime.Command = "npx"
+		runtime.Args = append(append([]string{}, fallback...), portArgs...)
+		return
+	}
+
+	runtime.Command = runtime.PackageManager
+	runtime.Args = []string{"run", "dev"}
+	if len(portArgs) > 0 {
+		// npm only passes arguments after -- on to the script
+		if runtime.PackageManager == "npm" {
+			runtime.Args = append(runtime.Args, "--")
+		}
+		runtime.Args = append(runtime.Args, portArgs...)
+	}
+}
+
+// buildRemixCommand configures a Remix dev server. Remix apps built on Vite run like other Vite
+// dev servers, with remix vite:dev as the fallback; the classic Remix compiler's dev script
+// starts an app server that listens on PORT.
+func buildRemixCommand(runtime *ServiceRuntime, projectDir string) {
+	if hasViteConfig(projectDir) || !fileExists(projectDir, "remix.config.js") {
+		buildViteCommand(runtime, projectDir, "remix", "vite:dev")
+		return
+	}
+
+	runtime.Command = runtime.PackageManager
+	runtime.Args = []string{"run", "dev"}
+	if runtime.Port > 0 {
+		if runtime.Env == nil {
+			runtime.Env = make(map[string]string)
+		}
+		runtime.Env["PORT"] = fmt.Sprintf("%d", runtime.Port)
+	}
+}
+
+// buildAngularCommand configures an Angular CLI service: a "dev" script in package.json
+// takes precedence, then "ng build" for services in build mode and "ng serve" otherwise.
+func buildAngularCommand(runtime *ServiceRuntime, projectDir string) {
+	if hasScript(projectDir, "dev") {
+		runtime.Command = runtime.PackageManager
+		runtime.Args = []string{"run", "dev"}
+		return
+	}
+
+	runtime.Command = "ng"
+	if runtime.Mode == ServiceModeBuild {
+		runtime.Args = []string{"build"}
+		return
+	}
+	runtime.Args = []string{"serve"}
+	if runtime.Port > 0 {
+		runtime.Args = append(runtime.Args, "--port", fmt.Sprintf("%d", runtime.Port))
+	}
+}
+
 // buildDotNetCommand configures a .NET service runtime command.
 func buildDotNetCommand(runtime *ServiceRuntime, projectDir, runtimeMode string, isAspire bool) error {
 	runtime.Command = "dotnet"
@@ -155,23 +225,73 @@ func buildDotNetCommand(runtime *ServiceRuntime, projectDir, runtimeMode string,
 	return nil
 }
 
-// buildJavaCommand configures a Java service runtime command.
-func buildJavaCommand(runtime *ServiceRuntime, isSpringBoot bool) {
-	if runtime.PackageManager == "maven" {
-		runtime.Command = "mvn"
-		if isSpringBoot {
-			runtime.Args = []string{"spring-boot:run"}
-		} else {
-			runtime.Args = []string{"exec:java"}
+// buildJavaCommand configures a Java service runtime command, run with the project's Maven or
+// Gradle wrapper when it has one. Spring Boot, Quarkus and Micronaut services are told to
+// listen on the assigned port the way each framework expects: a command-line argument, a
+// system property and the MICRONAUT_SERVER_PORT environment variable. Quarkus and Micronaut
+// run in their dev modes, which reload on changes.
+func buildJavaCommand(runtime *ServiceRuntime, projectDir string) {
+	gradle := runtime.PackageManager == "gradle"
+	runtime.Command = javaBuildTool(projectDir, gradle)
+
+	var portArg string
+	switch {
+	case runtime.Framework == "Spring Boot" && gradle:
+		runtime.Args = []string{"bootRun"}
+		portArg = "--args=--server.port=%d"
+	case runtime.Framework == "Spring Boot":
+		runtime.Args = []string{"spring-boot:run"}
+		portArg = "-Dspring-boot.run.arguments=--server.port=%d"
+	case runtime.Framework == "Quarkus" && gradle:
+		runtime.Args = []string{"quarkusDev"}
+		portArg = "-Dquarkus.http.port=%d"
+	case runtime.Framework == "Quarkus":
+		runtime.Args = []string{"quarkus:dev"}
+		portArg = "-Dquarkus.http.port=%d"
+	case runtime.Framework == "Micronaut" && gradle:
+		// Continuous build restarts the application when sources change
+		runtime.Args = []string{"run", "--continuous"}
+	case runtime.Framework == "Micronaut":
+		// The Micronaut Maven plugin's mn:run restarts the application when sources change
+		runtime.Args = []string{"mn:run"}
+	case gradle:
+		runtime.Args = []string{"run"}
+	default:
+		runtime.Args = []string{"exec:java"}
+	}
+
+	if portArg != "" && runtime.Port > 0 {
+		runtime.Args = append(runtime.Args, fmt.Sprintf(portArg, runtime.Port))
+	}
+	if runtime.Framework == "Micronaut" && runtime.Port > 0 {
+		if runtime.Env == nil {
+			runtime.Env = make(map[string]string)
 		}
-	} else {
-		runtime.Command = "gradle"
-		if isSpringBoot {
-			runtime.Args = []string{"bootRun"}
+		runtime.Env["MICRONAUT_SERVER_PORT"] = fmt.Sprintf("%d", runtime.Port)
+	}
+}
+
+// javaBuildTool returns the command that runs a Java project's build: its Maven (mvnw) or
+// Gradle (gradlew) wrapper when present, so the project's pinned version is used, and mvn or
+// gradle from PATH otherwise. Wrappers are run relative to the project directory, the
+// service's working directory.
+func javaBuildTool(projectDir string, gradle bool) string {
+	tool, wrapper := "mvn", "mvnw"
+	if gradle {
+		tool, wrapper = "gradle", "gradlew"
+	}
+	if runtime.GOOS == "windows" {
+		if gradle {
+			wrapper += ".bat"
 		} else {
-			runtime.Args = []string{"run"}
+			wrapper += ".cmd"
 		}
 	}
+
+	if fileExists(projectDir, wrapper) {
+		return "." + string(filepath.Separator) + wrapper
+	}
+	return tool
 }
 
 // getPythonVenvPath returns the path to the Python interpreter in the virtual environment.
@@ -279,6 +399,8 @@ func configureHealthCheck(runtime *ServiceRuntime) {
 		"Next.js":             {"/", "ready on"},
 		"Django":              {"/", "Starting development server"},
 		"Spring Boot":         {"/actuator/health", "Started"},
+		"Quarkus":             {"/", "Listening on"},
+		"Micronaut":           {"/", "Server Running"},
 		"FastAPI":             {"/docs", ""},
 	}
 
@@ -101,13 +101,16 @@ func detectNodeFramework(projectDir string) (string, string, error) {
 		{"Nuxt", func() bool {
 			return fileExists(projectDir, "nuxt.config.ts") || fileExists(projectDir, "nuxt.config.js")
 		}},
-		{"React", func() bool {
-			return fileExists(projectDir, "vite.config.ts") || fileExists(projectDir, "vite.config.js")
+		{"SvelteKit", func() bool {
+			return fileExists(projectDir, "svelte.config.js") || hasPackageDependency(projectDir, "@sveltejs/kit")
+		}},
+		{"Remix", func() bool {
+			return fileExists(projectDir, "remix.config.js") || hasPackageDependency(projectDir, "@remix-run/")
 		}},
-		{"SvelteKit", func() bool { return fileExists(projectDir, "svelte.config.js") }},
-		{"Remix", func() bool { return fileExists(projectDir, "remix.config.js") }},
 		{"Astro", func() bool { return fileExists(projectDir, "astro.config.mjs") }},
 		{"NestJS", func() bool { return fileExists(projectDir, "nest-cli.json") }},
+		// After the frameworks built on Vite, which have their own config and commands
+		{"Vite", func() bool { return hasViteConfig(projectDir) }},
 	}
 
 	// Check each framework rule
@@ -160,11 +163,14 @@ func detectDotNetFramework(projectDir string) (string, string, error) {
 		return "Aspire", "dotnet", nil
 	}
 
-	// Check for ASP.NET Core
+	// Check for an Aspire AppHost project, then ASP.NET Core
 	if hasFileWithExt(projectDir, ".csproj") {
-		// Read csproj to detect Web SDK
+		// Read csproj to detect the Aspire.Hosting.AppHost reference or the Web SDK
 		csprojFiles, _ := filepath.Glob(filepath.Join(projectDir, "*.csproj"))
 		for _, csprojFile := range csprojFiles {
+			if detector.IsAppHostProject(csprojFile) {
+				return "Aspire", "dotnet", nil
+			}
 			if containsText(csprojFile, "Microsoft.NET.Sdk.Web") {
 				return "ASP.NET Core", "dotnet", nil
 			}
@@ -175,27 +181,39 @@ func detectDotNetFramework(projectDir string) (string, string, error) {
 	return ".NET", "dotnet", nil
 }
 
-// detectJavaFramework detects Java framework.
+// detectJavaFramework detects Java framework. Gradle projects (build.gradle or
+// build.gradle.kts) use the "gradle" package manager and Maven projects (pom.xml) "maven".
 func detectJavaFramework(projectDir string) (string, string, error) {
 	packageManager := "maven"
 	if fileExists(projectDir, "build.gradle") || fileExists(projectDir, "build.gradle.kts") {
 		packageManager = "gradle"
 	}
 
-	// Check for Spring Boot in pom.xml
-	if fileExists(projectDir, "pom.xml") && containsText(filepath.Join(projectDir, "pom.xml"), "spring-boot") {
-		return "Spring Boot", packageManager, nil
-	}
-
-	// Check for frameworks in build.gradle
-	if fileExists(projectDir, "build.gradle") {
-		buildGradle := filepath.Join(projectDir, "build.gradle")
-		if containsText(buildGradle, "spring-boot") {
-			return "Spring Boot", packageManager, nil
+	for _, buildFile := range []string{"pom.xml", "build.gradle", "build.gradle.kts"} {
+		if !fileExists(projectDir, buildFile) {
+			continue
 		}
-		if containsText(buildGradle, "quarkus") {
+		buildPath := filepath.Join(projectDir, buildFile)
+
+		// Quarkus and Micronaut come first: their Spring compatibility libraries mention
+		// spring-boot too. io.quarkus dependencies or the quarkus-maven-plugin, and io.micronaut
+		// dependencies or plugins
+		if containsText(buildPath, "io.quarkus") || containsText(buildPath, "quarkus-maven-plugin") {
 			return "Quarkus", packageManager, nil
 		}
+		if containsText(buildPath, "io.micronaut") {
+			return "Micronaut", packageManager, nil
+		}
+		// spring-boot-starter-* dependencies and the spring-boot-maven-plugin, or the
+		// org.springframework.boot Gradle plugin
+		if containsText(buildPath, "spring-boot") || containsText(buildPath, "org.springframework.boot") {
+			return "Spring Boot", packageManager, nil
+		}
+	}
+
+	// Projects generated by the Micronaut CLI have a micronaut-cli.yml
+	if fileExists(projectDir, "micronaut-cli.yml") {
+		return "Micronaut", packageManager, nil
 	}
 
 	return "Java", packageManager, nil
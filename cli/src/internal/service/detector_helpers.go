@@ -53,6 +53,9 @@ func detectFrameworkFromPackageJSON(projectDir string) string {
 	}
 
 	content := string(data)
+	if strings.Contains(content, "\"vite\"") {
+		return "Vite"
+	}
 	if strings.Contains(content, "\"react\"") {
 		return "React"
 	}
@@ -66,6 +69,32 @@ func detectFrameworkFromPackageJSON(projectDir string) string {
 	return ""
 }
 
+// hasPackageDependency reports whether package.json mentions a package whose name starts
+// with prefix, such as "@remix-run/" for any Remix package.
+func hasPackageDependency(projectDir, prefix string) bool {
+	return containsText(filepath.Join(projectDir, "package.json"), `"`+prefix)
+}
+
+// isViteDevServer reports whether the framework's dev server is Vite's, which always listens
+// and reloads on changes: Vite itself, and SvelteKit and Remix, which are built on it.
+func isViteDevServer(framework string) bool {
+	switch framework {
+	case "Vite", "SvelteKit", "Remix":
+		return true
+	}
+	return false
+}
+
+// hasViteConfig reports whether the project has a Vite config file.
+func hasViteConfig(projectDir string) bool {
+	for _, ext := range []string{"ts", "js", "mjs", "mts", "cjs", "cts"} {
+		if fileExists(projectDir, "vite.config."+ext) {
+			return true
+		}
+	}
+	return false
+}
+
 func hasScript(projectDir string, scriptName string) bool {
 	packageJSONPath := filepath.Join(projectDir, "package.json")
 	return containsText(packageJSONPath, fmt.Sprintf(`"%s"`, scriptName))
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDetectJavaFramework(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		wantFramework string
+		wantManager   string
+	}{
+		{
+			name:          "Maven Spring Boot",
+			files:         map[string]string{"pom.xml": "<parent><artifactId>spring-boot-starter-parent</artifactId></parent>"},
+			wantFramework: "Spring Boot",
+			wantManager:   "maven",
+		},
+		{
+			name:          "Gradle Spring Boot plugin",
+			files:         map[string]string{"build.gradle": "plugins {\n  id 'org.springframework.boot' version '3.2.0'\n}"},
+			wantFramework: "Spring Boot",
+			wantManager:   "gradle",
+		},
+		{
+			name:          "Gradle Kotlin DSL Spring Boot plugin",
+			files:         map[string]string{"build.gradle.kts": "plugins {\n  id(\"org.springframework.boot\") version \"3.2.0\"\n}"},
+			wantFramework: "Spring Boot",
+			wantManager:   "gradle",
+		},
+		{
+			name:          "Gradle Kotlin DSL Quarkus",
+			files:         map[string]string{"build.gradle.kts": "plugins {\n  id(\"io.quarkus\")\n}\ndependencies { implementation(\"io.quarkus:quarkus-rest\") }"},
+			wantFramework: "Quarkus",
+			wantManager:   "gradle",
+		},
+		{
+			name:          "Maven Quarkus with Spring compatibility",
+			files:         map[string]string{"pom.xml": "<groupId>io.quarkus</groupId><artifactId>quarkus-spring-boot-properties</artifactId>"},
+			wantFramework: "Quarkus",
+			wantManager:   "maven",
+		},
+		{
+			name:          "Maven Micronaut",
+			files:         map[string]string{"pom.xml": "<parent><groupId>io.micronaut.platform</groupId><artifactId>micronaut-parent</artifactId></parent>"},
+			wantFramework: "Micronaut",
+			wantManager:   "maven",
+		},
+		{
+			name:          "Gradle Micronaut plugin",
+			files:         map[string]string{"build.gradle": "plugins {\n  id 'io.micronaut.application' version '4.4.0'\n}"},
+			wantFramework: "Micronaut",
+			wantManager:   "gradle",
+		},
+		{
+			name:          "Micronaut CLI project",
+			files:         map[string]string{"build.gradle.kts": "plugins { application }", "micronaut-cli.yml": "applicationType: default"},
+			wantFramework: "Micronaut",
+			wantManager:   "gradle",
+		},
+		{
+			name:          "plain Maven",
+			files:         map[string]string{"pom.xml": "<project></project>"},
+			wantFramework: "Java",
+			wantManager:   "maven",
+		},
+		{
+			name:          "plain Gradle",
+			files:         map[string]string{"build.gradle.kts": "plugins { application }"},
+			wantFramework: "Java",
+			wantManager:   "gradle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			framework, manager, err := detectJavaFramework(dir)
+			if err != nil {
+				t.Fatalf("detectJavaFramework() error = %v", err)
+			}
+			if framework != tt.wantFramework || manager != tt.wantManager {
+				t.Errorf("detectJavaFramework() = %s, %s, want %s, %s", framework, manager, tt.wantFramework, tt.wantManager)
+			}
+		})
+	}
+}
+
+func TestBuildJavaCommand(t *testing.T) {
+	mvnw, gradlew := "mvnw", "gradlew"
+	if runtime.GOOS == "windows" {
+		mvnw, gradlew = "mvnw.cmd", "gradlew.bat"
+	}
+	wrapper := func(name string) string { return "." + string(filepath.Separator) + name }
+
+	tests := []struct {
+		name        string
+		framework   string
+		manager     string
+		port        int
+		wrapperFile string
+		wantCommand string
+		wantArgs    []string
+		wantEnv     map[string]string
+	}{
+		{"Spring Boot with Maven wrapper", "Spring Boot", "maven", 8081, mvnw, wrapper(mvnw), []string{"spring-boot:run", "-Dspring-boot.run.arguments=--server.port=8081"}, nil},
+		{"Spring Boot with Gradle wrapper", "Spring Boot", "gradle", 8082, gradlew, wrapper(gradlew), []string{"bootRun", "--args=--server.port=8082"}, nil},
+		{"Spring Boot without wrapper", "Spring Boot", "maven", 8080, "", "mvn", []string{"spring-boot:run", "-Dspring-boot.run.arguments=--server.port=8080"}, nil},
+		{"Spring Boot without a port", "Spring Boot", "gradle", 0, "", "gradle", []string{"bootRun"}, nil},
+		{"Quarkus with Gradle", "Quarkus", "gradle", 8083, "", "gradle", []string{"quarkusDev", "-Dquarkus.http.port=8083"}, nil},
+		{"Quarkus with Maven", "Quarkus", "maven", 8084, mvnw, wrapper(mvnw), []string{"quarkus:dev", "-Dquarkus.http.port=8084"}, nil},
+		{"Micronaut with Gradle wrapper", "Micronaut", "gradle", 8085, gradlew, wrapper(gradlew), []string{"run", "--continuous"}, map[string]string{"MICRONAUT_SERVER_PORT": "8085"}},
+		{"Micronaut with Maven", "Micronaut", "maven", 8086, "", "mvn", []string{"mn:run"}, map[string]string{"MICRONAUT_SERVER_PORT": "8086"}},
+		{"Micronaut without a port", "Micronaut", "gradle", 0, "", "gradle", []string{"run", "--continuous"}, nil},
+		{"plain Maven", "Java", "maven", 8080, "", "mvn", []string{"exec:java"}, nil},
+		{"plain Gradle", "Java", "gradle", 8080, gradlew, wrapper(gradlew), []string{"run"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.wrapperFile != "" {
+				if err := os.WriteFile(filepath.Join(dir, tt.wrapperFile), []byte("#!/bin/sh\n"), 0o700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rt := &ServiceRuntime{Framework: tt.framework, PackageManager: tt.manager, Port: tt.port}
+			if err := buildFrameworkCommand(rt, dir, "azd"); err != nil {
+				t.Fatalf("buildFrameworkCommand() error = %v", err)
+			}
+			if rt.Command != tt.wantCommand || !reflect.DeepEqual(rt.Args, tt.wantArgs) {
+				t.Errorf("command = %s %v, want %s %v", rt.Command, rt.Args, tt.wantCommand, tt.wantArgs)
+			}
+			if len(rt.Env) != len(tt.wantEnv) || (len(tt.wantEnv) > 0 && !reflect.DeepEqual(rt.Env, tt.wantEnv)) {
+				t.Errorf("env = %v, want %v", rt.Env, tt.wantEnv)
+			}
+			// Dev modes reload on changes
+			wantWatch := tt.framework == "Quarkus" || tt.framework == "Micronaut"
+			if got := isWatchCommand(rt.Command + " " + strings.Join(rt.Args, " ")); got != wantWatch {
+				t.Errorf("isWatchCommand() = %v, want %v", got, wantWatch)
+			}
+		})
+	}
+}
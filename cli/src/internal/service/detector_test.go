@@ -3,6 +3,7 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -1041,6 +1042,298 @@ services:
 	}
 }
 
+func TestAngularDetection(t *testing.T) {
+	const packageJSON = `{"name":"web","scripts":{"ng":"ng","start":"ng serve","build":"ng build"},"dependencies":{"@angular/core":"^18.0.0"}}`
+
+	tests := []struct {
+		name        string
+		packageJSON string
+		serviceYaml string // Extra service properties in azure.yaml
+		wantCommand string
+		wantArgs    []string
+		wantMode    string
+	}{
+		{
+			name:        "ng serve without ports (watch mode)",
+			packageJSON: packageJSON,
+			wantCommand: "ng",
+			wantArgs:    []string{"serve"},
+			wantMode:    service.ServiceModeWatch,
+		},
+		{
+			name:        "ng serve on the service port",
+			packageJSON: packageJSON,
+			serviceYaml: "\n    ports:\n      - \"4321\"",
+			wantCommand: "ng",
+			wantArgs:    []string{"serve", "--port", "4321"},
+			wantMode:    "", // http type, no mode
+		},
+		{
+			name:        "ng build in build mode",
+			packageJSON: packageJSON,
+			serviceYaml: "\n    mode: build",
+			wantCommand: "ng",
+			wantArgs:    []string{"build"},
+			wantMode:    service.ServiceModeBuild,
+		},
+		{
+			name:        "dev script overrides ng serve",
+			packageJSON: `{"name":"web","scripts":{"dev":"ng serve --configuration development --watch"}}`,
+			wantCommand: "npm",
+			wantArgs:    []string{"run", "dev"},
+			wantMode:    service.ServiceModeWatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{
+				"angular.json":  `{"version":1,"projects":{"web":{}}}`,
+				"package.json":  tt.packageJSON,
+				"tsconfig.json": "{}",
+			}
+			for filename, content := range files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0600); err != nil {
+					t.Fatalf("Failed to create file %s: %v", filename, err)
+				}
+			}
+
+			azureYamlContent := "name: test-app\nservices:\n  web:\n    project: ." + tt.serviceYaml
+			azureYamlPath := filepath.Join(tmpDir, "azure.yaml")
+			if err := os.WriteFile(azureYamlPath, []byte(azureYamlContent), 0600); err != nil {
+				t.Fatalf("Failed to create azure.yaml: %v", err)
+			}
+			azureYaml, err := service.ParseAzureYaml(azureYamlPath)
+			if err != nil {
+				t.Fatalf("Failed to parse azure.yaml: %v", err)
+			}
+
+			runtime, err := service.DetectServiceRuntime("web", azureYaml.Services["web"], map[int]bool{}, tmpDir, "azd")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if runtime.Framework != "Angular" || runtime.Language != "TypeScript" {
+				t.Errorf("Expected Angular (TypeScript), got %s (%s)", runtime.Framework, runtime.Language)
+			}
+			if runtime.Command != tt.wantCommand || strings.Join(runtime.Args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("Expected command %s %v, got %s %v", tt.wantCommand, tt.wantArgs, runtime.Command, runtime.Args)
+			}
+			if runtime.Mode != tt.wantMode {
+				t.Errorf("Expected mode %q, got %q", tt.wantMode, runtime.Mode)
+			}
+		})
+	}
+}
+
+func TestViteDetection(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		serviceYaml string // Extra service properties in azure.yaml
+		wantCommand string
+		wantArgs    []string // Followed by the port flags
+		wantType    string
+		wantMode    string
+	}{
+		{
+			name: "vite.config with npm dev script",
+			files: map[string]string{
+				"vite.config.ts": "export default {}",
+				"package.json":   `{"name":"web","scripts":{"dev":"vite"}}`,
+			},
+			wantCommand: "npm",
+			wantArgs:    []string{"run", "dev", "--"},
+			wantType:    service.ServiceTypeProcess,
+			wantMode:    service.ServiceModeWatch,
+		},
+		{
+			name: "vite dependency with pnpm",
+			files: map[string]string{
+				"package.json":   `{"name":"web","scripts":{"dev":"vite --host"},"devDependencies":{"vite":"^5.0.0","vue":"^3.4.0"}}`,
+				"pnpm-lock.yaml": "lockfileVersion: '9.0'",
+			},
+			wantCommand: "pnpm",
+			wantArgs:    []string{"run", "dev"},
+			wantType:    service.ServiceTypeProcess,
+			wantMode:    service.ServiceModeWatch,
+		},
+		{
+			name: "no dev script",
+			files: map[string]string{
+				"vite.config.mjs": "export default {}",
+				"package.json":    `{"name":"web"}`,
+			},
+			wantCommand: "npx",
+			wantArgs:    []string{"vite"},
+			wantType:    service.ServiceTypeProcess,
+			wantMode:    service.ServiceModeWatch,
+		},
+		{
+			name: "declared port",
+			files: map[string]string{
+				"vite.config.js": "export default {}",
+				"package.json":   `{"name":"web","scripts":{"dev":"vite"}}`,
+			},
+			serviceYaml: "\n    ports:\n      - \"5299\"",
+			wantCommand: "npm",
+			wantArgs:    []string{"run", "dev", "--"},
+			wantType:    service.ServiceTypeHTTP,
+			wantMode:    "", // http type, no mode
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0600); err != nil {
+					t.Fatalf("Failed to create file %s: %v", filename, err)
+				}
+			}
+
+			azureYamlContent := "name: test-app\nservices:\n  web:\n    project: ." + tt.serviceYaml
+			azureYamlPath := filepath.Join(tmpDir, "azure.yaml")
+			if err := os.WriteFile(azureYamlPath, []byte(azureYamlContent), 0600); err != nil {
+				t.Fatalf("Failed to create azure.yaml: %v", err)
+			}
+			azureYaml, err := service.ParseAzureYaml(azureYamlPath)
+			if err != nil {
+				t.Fatalf("Failed to parse azure.yaml: %v", err)
+			}
+
+			usedPorts := map[int]bool{}
+			runtime, err := service.DetectServiceRuntime("web", azureYaml.Services["web"], usedPorts, tmpDir, "azd")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if runtime.Framework != "Vite" {
+				t.Errorf("Expected Vite, got %s", runtime.Framework)
+			}
+			if runtime.Port == 0 || !usedPorts[runtime.Port] {
+				t.Errorf("Expected an assigned port marked as used, got %d (used: %v)", runtime.Port, usedPorts)
+			}
+			wantArgs := append(tt.wantArgs, "--port", strconv.Itoa(runtime.Port), "--strictPort")
+			if runtime.Command != tt.wantCommand || strings.Join(runtime.Args, " ") != strings.Join(wantArgs, " ") {
+				t.Errorf("Expected command %s %v, got %s %v", tt.wantCommand, wantArgs, runtime.Command, runtime.Args)
+			}
+			if runtime.Type != tt.wantType || runtime.Mode != tt.wantMode {
+				t.Errorf("Expected type %q mode %q, got %q %q", tt.wantType, tt.wantMode, runtime.Type, runtime.Mode)
+			}
+		})
+	}
+}
+
+func TestSvelteKitAndRemixDetection(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		wantFramework string
+		wantCommand   string
+		wantArgs      []string
+		wantPortEnv   bool // Port passed in PORT rather than with --port
+	}{
+		{
+			name: "SvelteKit from svelte.config.js",
+			files: map[string]string{
+				"svelte.config.js": "export default {}",
+				"vite.config.ts":   "export default {}",
+				"package.json":     `{"name":"web","scripts":{"dev":"vite dev"},"devDependencies":{"@sveltejs/kit":"^2.0.0","vite":"^5.0.0"}}`,
+			},
+			wantFramework: "SvelteKit",
+			wantCommand:   "npm",
+			wantArgs:      []string{"run", "dev", "--", "--port", "{port}", "--strictPort"},
+		},
+		{
+			name: "SvelteKit dependency without a dev script",
+			files: map[string]string{
+				"package.json":   `{"name":"web","devDependencies":{"@sveltejs/kit":"^2.0.0"}}`,
+				"pnpm-lock.yaml": "lockfileVersion: '9.0'",
+			},
+			wantFramework: "SvelteKit",
+			wantCommand:   "npx",
+			wantArgs:      []string{"vite", "dev", "--port", "{port}", "--strictPort"},
+		},
+		{
+			name: "Remix on Vite",
+			files: map[string]string{
+				"vite.config.ts": "import { vitePlugin as remix } from \"@remix-run/dev\"",
+				"package.json":   `{"name":"web","scripts":{"dev":"remix vite:dev"},"dependencies":{"@remix-run/node":"^2.9.0","@remix-run/react":"^2.9.0"}}`,
+				"pnpm-lock.yaml": "lockfileVersion: '9.0'",
+			},
+			wantFramework: "Remix",
+			wantCommand:   "pnpm",
+			wantArgs:      []string{"run", "dev", "--port", "{port}", "--strictPort"},
+		},
+		{
+			name: "Remix dependency without a dev script",
+			files: map[string]string{
+				"package.json": `{"name":"web","dependencies":{"@remix-run/node":"^2.9.0"}}`,
+			},
+			wantFramework: "Remix",
+			wantCommand:   "npx",
+			wantArgs:      []string{"remix", "vite:dev", "--port", "{port}", "--strictPort"},
+		},
+		{
+			name: "classic Remix compiler",
+			files: map[string]string{
+				"remix.config.js": "module.exports = {}",
+				"package.json":    `{"name":"web","scripts":{"dev":"remix dev"},"dependencies":{"@remix-run/serve":"^1.19.0"}}`,
+			},
+			wantFramework: "Remix",
+			wantCommand:   "npm",
+			wantArgs:      []string{"run", "dev"},
+			wantPortEnv:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0600); err != nil {
+					t.Fatalf("Failed to create file %s: %v", filename, err)
+				}
+			}
+
+			azureYamlPath := filepath.Join(tmpDir, "azure.yaml")
+			if err := os.WriteFile(azureYamlPath, []byte("name: test-app\nservices:\n  web:\n    project: ."), 0600); err != nil {
+				t.Fatalf("Failed to create azure.yaml: %v", err)
+			}
+			azureYaml, err := service.ParseAzureYaml(azureYamlPath)
+			if err != nil {
+				t.Fatalf("Failed to parse azure.yaml: %v", err)
+			}
+
+			usedPorts := map[int]bool{}
+			runtime, err := service.DetectServiceRuntime("web", azureYaml.Services["web"], usedPorts, tmpDir, "azd")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if runtime.Framework != tt.wantFramework {
+				t.Errorf("Expected %s, got %s", tt.wantFramework, runtime.Framework)
+			}
+			if runtime.Port == 0 || !usedPorts[runtime.Port] {
+				t.Errorf("Expected an assigned port marked as used, got %d (used: %v)", runtime.Port, usedPorts)
+			}
+			port := strconv.Itoa(runtime.Port)
+			wantArgs := strings.ReplaceAll(strings.Join(tt.wantArgs, " "), "{port}", port)
+			if runtime.Command != tt.wantCommand || strings.Join(runtime.Args, " ") != wantArgs {
+				t.Errorf("Expected command %s %s, got %s %v", tt.wantCommand, wantArgs, runtime.Command, runtime.Args)
+			}
+			if got := runtime.Env["PORT"]; (got == port) != tt.wantPortEnv {
+				t.Errorf("PORT = %q, want it set to the assigned port: %v", got, tt.wantPortEnv)
+			}
+			if runtime.Type != service.ServiceTypeProcess || runtime.Mode != service.ServiceModeWatch {
+				t.Errorf("Expected type %q mode %q, got %q %q", service.ServiceTypeProcess, service.ServiceModeWatch, runtime.Type, runtime.Mode)
+			}
+		})
+	}
+}
+
 func TestServiceTypeConstants(t *testing.T) {
 	// Test that constants are defined correctly
 	if service.ServiceTypeHTTP != "http" {
@@ -1141,6 +1434,18 @@ func TestServiceHelperMethods(t *testing.T) {
 			isWatch:      false,
 			isBuild:      false,
 		},
+		{
+			name: "UDP healthcheck infers udp type",
+			svc: service.Service{
+				Ports:       []string{"8125"},
+				Healthcheck: &service.HealthcheckConfig{Type: "udp"},
+			},
+			expectedType: "udp",
+			expectedMode: "",
+			isProcess:    false,
+			isWatch:      false,
+			isBuild:      false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1218,6 +1523,34 @@ func TestContainerServiceDetection(t *testing.T) {
 			isContainer:   false,
 			expectedImage: "",
 		},
+		{
+			name: "service with docker.path runs natively",
+			svc: service.Service{
+				Project:  "./api",
+				Language: "python",
+				Docker:   &service.DockerConfig{Path: "./Dockerfile"},
+			},
+			isContainer:   false,
+			expectedImage: "",
+		},
+		{
+			name: "container service with docker.path is built from its Dockerfile",
+			svc: service.Service{
+				Type:    service.ServiceTypeContainer,
+				Project: "./api",
+				Docker:  &service.DockerConfig{Path: "./Dockerfile"},
+			},
+			isContainer:   true,
+			expectedImage: "",
+		},
+		{
+			name: "service with docker.path and docker.image uses the image",
+			svc: service.Service{
+				Docker: &service.DockerConfig{Path: "./Dockerfile", Image: "myregistry.azurecr.io/api:1.0"},
+			},
+			isContainer:   true,
+			expectedImage: "myregistry.azurecr.io/api:1.0",
+		},
 		{
 			name: "service with empty docker config is not container",
 			svc: service.Service{
@@ -1306,6 +1639,55 @@ services:
 	}
 }
 
+func TestContainerRuntimeDetection_HealthcheckTest(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthcheck *service.HealthcheckConfig
+		wantTest    []string
+		wantType    string
+	}{
+		{
+			name:        "test replaces tcp probe",
+			healthcheck: &service.HealthcheckConfig{Test: []any{"CMD", "pg_isready", "-U", "postgres"}},
+			wantTest:    []string{"CMD", "pg_isready", "-U", "postgres"},
+			wantType:    "tcp",
+		},
+		{
+			name:        "explicit type wins over test",
+			healthcheck: &service.HealthcheckConfig{Type: "http", Path: "/ready", Test: "pg_isready"},
+			wantType:    "http",
+		},
+		{
+			name:        "no test",
+			healthcheck: nil,
+			wantType:    "tcp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.Service{
+				Image:       "postgres:16",
+				Ports:       []string{"5432:5432"},
+				Healthcheck: tt.healthcheck,
+			}
+			runtime, err := service.DetectServiceRuntime("db", svc, make(map[int]bool), t.TempDir(), "azd")
+			if err != nil {
+				t.Fatalf("DetectServiceRuntime failed: %v", err)
+			}
+			if runtime.HealthCheck.Type != tt.wantType {
+				t.Errorf("HealthCheck.Type = %q, want %q", runtime.HealthCheck.Type, tt.wantType)
+			}
+			if strings.Join(runtime.HealthCheck.Test, " ") != strings.Join(tt.wantTest, " ") {
+				t.Errorf("HealthCheck.Test = %q, want %q", runtime.HealthCheck.Test, tt.wantTest)
+			}
+			if tt.healthcheck != nil && runtime.HealthCheck.Path != tt.healthcheck.Path {
+				t.Errorf("HealthCheck.Path = %q, want %q", runtime.HealthCheck.Path, tt.healthcheck.Path)
+			}
+		})
+	}
+}
+
 // TestAllWellKnownContainerServices tests parsing all stock container services (azurite, cosmos, redis, postgres).
 func TestAllWellKnownContainerServices(t *testing.T) {
 	// Create a temp directory for the test
@@ -1449,3 +1831,28 @@ services:
 		t.Errorf("Expected 4 services, got %d", len(parsed.Services))
 	}
 }
+
+func TestDetectServiceRuntime_WebSocketHealthcheckPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(`{"name":"chat","scripts":{"start":"node index.js"}}`), 0600); err != nil {
+		t.Fatalf("Failed to create package.json: %v", err)
+	}
+
+	svc := service.Service{
+		Language:    "js",
+		Project:     ".",
+		Ports:       []string{"8080"},
+		Healthcheck: &service.HealthcheckConfig{Type: service.HealthCheckTypeWebSocket, Path: "/ws"},
+	}
+	runtime, err := service.DetectServiceRuntime("chat", svc, make(map[int]bool), tmpDir, "azd")
+	if err != nil {
+		t.Fatalf("DetectServiceRuntime failed: %v", err)
+	}
+	if runtime.HealthCheck.Type != service.HealthCheckTypeWebSocket {
+		t.Errorf("HealthCheck.Type = %q, want %q", runtime.HealthCheck.Type, service.HealthCheckTypeWebSocket)
+	}
+	// The framework's default path must not replace the configured one
+	if runtime.HealthCheck.Path != "/ws" {
+		t.Errorf("HealthCheck.Path = %q, want /ws", runtime.HealthCheck.Path)
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"fmt"
+)
+
+// LoadEffectiveConfig returns azure.yaml as azd app uses it (azd app info --effective-config):
+// environment lists and objects are normalized to maps, healthcheck: false becomes
+// disable: true, project paths are absolute, and inline sidecars are expanded into services
+// that their parent uses.
+func LoadEffectiveConfig(workingDir string) (*AzureYaml, error) {
+	azureYaml, err := ParseAzureYaml(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	azureYaml.Services, err = ExpandSidecars(azureYaml.Services)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sidecar configuration: %w", err)
+	}
+
+	return azureYaml, nil
+}
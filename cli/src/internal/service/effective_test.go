@@ -0,0 +1,84 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadEffectiveConfig(t *testing.T) {
+	dir := t.TempDir()
+	content := `name: effective-test
+services:
+  api:
+    project: ./api
+    language: python
+    environment:
+      - LOG_LEVEL=debug
+      - name: API_KEY
+        value: local
+    healthcheck: false
+    uses: [db]
+    sidecars:
+      redis:
+        image: redis:7
+        ports: ["6379:6379"]
+resources:
+  db:
+    type: db.postgres
+`
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadEffectiveConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+
+	// Round-trip through the azure.yaml model, as info --effective-config prints it
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	var printed struct {
+		Services map[string]struct {
+			Project     string            `yaml:"project"`
+			Environment map[string]string `yaml:"environment"`
+			Healthcheck map[string]any    `yaml:"healthcheck"`
+			Uses        []string          `yaml:"uses"`
+			Image       string            `yaml:"image"`
+			Sidecars    map[string]any    `yaml:"sidecars"`
+		} `yaml:"services"`
+		Resources map[string]Resource `yaml:"resources"`
+	}
+	if err := yaml.Unmarshal(data, &printed); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v\n%s", err, data)
+	}
+
+	api := printed.Services["api"]
+	if want := filepath.Join(dir, "api"); api.Project != want {
+		t.Errorf("api project = %q, want %q", api.Project, want)
+	}
+	if want := map[string]string{"LOG_LEVEL": "debug", "API_KEY": "local"}; !reflect.DeepEqual(api.Environment, want) {
+		t.Errorf("api environment = %v, want %v", api.Environment, want)
+	}
+	if api.Healthcheck["disable"] != true {
+		t.Errorf("api healthcheck = %v, want disable: true", api.Healthcheck)
+	}
+	if want := []string{"db", "api-redis"}; !reflect.DeepEqual(api.Uses, want) {
+		t.Errorf("api uses = %v, want %v", api.Uses, want)
+	}
+	if api.Sidecars != nil {
+		t.Errorf("api sidecars = %v, want them expanded into services", api.Sidecars)
+	}
+	if redis, ok := printed.Services["api-redis"]; !ok || redis.Image != "redis:7" {
+		t.Errorf("api-redis service = %+v, want the expanded redis sidecar", redis)
+	}
+	if printed.Resources["db"].Type != "db.postgres" {
+		t.Errorf("resources = %v", printed.Resources)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/jongio/azd-app/cli/src/internal/security"
@@ -145,23 +147,57 @@ func GenerateServiceURLs(processes map[string]*ServiceProcess) map[string]string
 	return urls
 }
 
+// dotEnvKeyPattern matches valid environment variable names: letters, digits and
+// underscores, not starting with a digit.
+var dotEnvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// DotEnvIssue is a line of a .env file that was skipped because it isn't a valid variable.
+type DotEnvIssue struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+// Error formats the issue, e.g. `.env: line 3: invalid variable name "MY-VAR"`.
+func (i DotEnvIssue) Error() string {
+	return fmt.Sprintf("%s: line %d: %s", i.Path, i.Line, i.Message)
+}
+
 // LoadDotEnv loads environment variables from a .env file.
+// Invalid lines are skipped with a logged warning; see ReadDotEnv.
 func LoadDotEnv(path string) (map[string]string, error) {
+	env, issues, err := ReadDotEnv(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		slog.Warn("skipping invalid .env line", "path", issue.Path, "line", issue.Line, "error", issue.Message)
+	}
+	return env, nil
+}
+
+// ReadDotEnv loads environment variables from a .env file. Lines that aren't a valid
+// KEY=VALUE pair are skipped and returned as issues, so one bad line doesn't lose the
+// rest of the file.
+func ReadDotEnv(path string) (map[string]string, []DotEnvIssue, error) {
 	if err := security.ValidatePath(path); err != nil {
-		return nil, fmt.Errorf("invalid .env file path: %w", err)
+		return nil, nil, fmt.Errorf("invalid .env file path: %w", err)
 	}
 
 	// #nosec G304 -- Path validated by security.ValidatePath
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open .env file: %w", err)
+		return nil, nil, fmt.Errorf("failed to open .env file: %w", err)
 	}
 	defer file.Close()
 
 	env := make(map[string]string)
+	var issues []DotEnvIssue
 	scanner := bufio.NewScanner(file)
+	lineNum := 0
 
 	for scanner.Scan() {
+		lineNum++
 		line := strings.TrimSpace(scanner.Text())
 
 		// Skip empty lines and comments
@@ -169,26 +205,90 @@ func LoadDotEnv(path string) (map[string]string, error) {
 			continue
 		}
 
-		// Parse KEY=VALUE
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
+		// Parse KEY=VALUE, allowing a shell-style "export" prefix
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			issues = append(issues, DotEnvIssue{Path: path, Line: lineNum, Message: "expected KEY=VALUE"})
 			continue
 		}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		// Remove quotes if present
-		value = strings.Trim(value, `"'`)
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !dotEnvKeyPattern.MatchString(key) {
+			issues = append(issues, DotEnvIssue{Path: path, Line: lineNum, Message: fmt.Sprintf("invalid variable name %q", key)})
+			continue
+		}
 
-		env[key] = value
+		env[key] = unquoteDotEnvValue(value)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading .env file: %w", err)
+		return nil, nil, fmt.Errorf("error reading .env file: %w", err)
 	}
 
-	return env, nil
+	return env, issues, nil
+}
+
+// unquoteDotEnvValue returns the value of a .env line as dotenv tools write it: single-quoted
+// values are taken literally, double-quoted values have their backslash escapes (\n, \", \\,
+// ...) resolved, and anything after the closing quote, such as a comment, is dropped.
+// Unquoted values and values with an unterminated quote are returned as they are.
+func unquoteDotEnvValue(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+	switch value[0] {
+	case '\'':
+		if end := strings.IndexByte(value[1:], '\''); end >= 0 {
+			return value[1 : end+1]
+		}
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			switch {
+			case c == '"':
+				return b.String()
+			case c == '\\' && i+1 < len(value):
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(value[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+	}
+	return value
+}
+
+// LoadDotEnvFiles loads and merges several .env files. Variables in later files override
+// the same variables in earlier ones. Invalid lines are skipped and returned as issues,
+// or fail the load when strict is set.
+func LoadDotEnvFiles(paths []string, strict bool) (map[string]string, []DotEnvIssue, error) {
+	env := make(map[string]string)
+	var issues []DotEnvIssue
+	for _, path := range paths {
+		fileEnv, fileIssues, err := ReadDotEnv(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if strict && len(fileIssues) > 0 {
+			return nil, nil, fileIssues[0]
+		}
+		issues = append(issues, fileIssues...)
+		for k, v := range fileEnv {
+			env[k] = v
+		}
+	}
+	return env, issues, nil
 }
 
 // substituteEnvVars performs variable substitution in a string.
@@ -205,35 +305,30 @@ func substituteEnvVars(value string, env map[string]string) string {
 	return result
 }
 
+// IsSensitiveEnvKey reports whether an environment variable name looks like it holds a secret:
+// names containing SECRET, PASSWORD, TOKEN or CONNECTION_STRING, and KEY unless it is a public key.
+func IsSensitiveEnvKey(key string) bool {
+	keyUpper := strings.ToUpper(key)
+	return strings.Contains(keyUpper, "SECRET") ||
+		strings.Contains(keyUpper, "PASSWORD") ||
+		strings.Contains(keyUpper, "TOKEN") ||
+		strings.Contains(strings.ReplaceAll(keyUpper, "_", ""), "CONNECTIONSTRING") ||
+		strings.Contains(keyUpper, "KEY") && !strings.Contains(keyUpper, "PUBLIC")
+}
+
 // MaskSecrets masks secret values in environment variables for display.
 // Note: With the new Docker Compose-compatible format, secrets are handled inline
-// and we don't track which variables are secrets separately. This function is
-// kept for backward compatibility but may need updating if secret tracking is needed.
+// and we don't track which variables are secrets separately, so secrets are
+// recognized by name with IsSensitiveEnvKey.
 func MaskSecrets(service Service, env map[string]string) map[string]string {
-	masked := make(map[string]string)
-
-	// Create a set of secret variable names from common patterns
-	secrets := make(map[string]bool)
-	for key := range env {
-		keyUpper := strings.ToUpper(key)
-		// Mask common secret patterns
-		if strings.Contains(keyUpper, "SECRET") ||
-			strings.Contains(keyUpper, "PASSWORD") ||
-			strings.Contains(keyUpper, "TOKEN") ||
-			strings.Contains(keyUpper, "KEY") && !strings.Contains(keyUpper, "PUBLIC") {
-			secrets[key] = true
-		}
-	}
-
-	// Mask secrets
+	masked := make(map[string]string, len(env))
 	for k, v := range env {
-		if secrets[k] {
-			masked[k] = "***"
+		if IsSensitiveEnvKey(k) {
+			masked[k] = RedactedValue
 		} else {
 			masked[k] = v
 		}
 	}
-
 	return masked
 }
 
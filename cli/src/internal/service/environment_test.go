@@ -3,6 +3,7 @@ package service
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -629,6 +630,35 @@ func TestLoadDotEnv(t *testing.T) {
 			want:    map[string]string{},
 			wantErr: false,
 		},
+		{
+			name:    "export prefix",
+			content: "export DEBUG=true\n",
+			want: map[string]string{
+				"DEBUG": "true",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "quoted values",
+			content: "JSON=\"{\\\"a\\\":1}\"\nMULTI=\"line1\\nline2\"\nLITERAL='C:\\temp\\n'\nCOMMENTED=\"value\" # comment\nQUOTE_INSIDE=it's\n",
+			want: map[string]string{
+				"JSON":         `{"a":1}`,
+				"MULTI":        "line1\nline2",
+				"LITERAL":      `C:\temp\n`,
+				"COMMENTED":    "value",
+				"QUOTE_INSIDE": "it's",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid lines are skipped",
+			content: "API_KEY=test\nnot a variable\nMY-INVALID-VAR=x\n1API=test\nDEBUG=true\n",
+			want: map[string]string{
+				"API_KEY": "test",
+				"DEBUG":   "true",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -745,12 +775,15 @@ func TestMaskSecrets(t *testing.T) {
 		"AUTH_TOKEN":  "authtoken",
 		"NORMAL_VAR":  "normal",
 		"PUBLIC_KEY":  "pubkey123", // Should NOT be masked (has PUBLIC)
+
+		"AZURE_STORAGE_CONNECTION_STRING": "UseDevelopmentStorage=true",
+		"ConnectionStrings__Default":      "Server=db;Password=pass",
 	}
 
 	masked := MaskSecrets(service, env)
 
 	// Variables with secret-like patterns should be masked
-	secretKeys := []string{"API_KEY", "PASSWORD", "DB_PASSWORD", "TOKEN", "SECRET", "AUTH_TOKEN"}
+	secretKeys := []string{"API_KEY", "PASSWORD", "DB_PASSWORD", "TOKEN", "SECRET", "AUTH_TOKEN", "AZURE_STORAGE_CONNECTION_STRING", "ConnectionStrings__Default"}
 	for _, key := range secretKeys {
 		if masked[key] != "***" {
 			t.Errorf("MaskSecrets()[%q] = %q, want ***", key, masked[key])
@@ -799,3 +832,46 @@ func TestLoadEnvFileIfExists(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadDotEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	broken := filepath.Join(dir, ".env.broken")
+	for path, content := range map[string]string{
+		base:   "API_URL=https://prod\nLOG_LEVEL=info\n",
+		local:  "API_URL=http://localhost\n",
+		broken: "# comment\nLOG_LEVEL=debug\nMY-INVALID-VAR=x\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, issues, err := LoadDotEnvFiles([]string{base, local}, false)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("LoadDotEnvFiles() issues = %v, error = %v", issues, err)
+	}
+	if got["API_URL"] != "http://localhost" || got["LOG_LEVEL"] != "info" {
+		t.Errorf("LoadDotEnvFiles() = %v, want later files to override earlier ones", got)
+	}
+
+	// Invalid lines are skipped and reported with their file and line
+	got, issues, err = LoadDotEnvFiles([]string{base, broken}, false)
+	if err != nil {
+		t.Fatalf("LoadDotEnvFiles() error = %v", err)
+	}
+	if got["LOG_LEVEL"] != "debug" || got["API_URL"] != "https://prod" {
+		t.Errorf("LoadDotEnvFiles() = %v, want the valid variables of both files", got)
+	}
+	wantIssue := broken + `: line 3: invalid variable name "MY-INVALID-VAR"`
+	if len(issues) != 1 || issues[0].Error() != wantIssue {
+		t.Errorf("LoadDotEnvFiles() issues = %v, want [%s]", issues, wantIssue)
+	}
+
+	// Strict loading fails on the first invalid line
+	_, _, err = LoadDotEnvFiles([]string{base, broken}, true)
+	if err == nil || !strings.Contains(err.Error(), wantIssue) {
+		t.Errorf("LoadDotEnvFiles(strict) error = %v, want %s", err, wantIssue)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/constants"
@@ -57,6 +58,7 @@ func createServiceCommand(runtime *ServiceRuntime, env map[string]string) (*exec
 	// #nosec G204 -- Command and args come from azure.yaml service configuration, validated by service package
 	cmd := exec.Command(runtime.Command, runtime.Args...)
 	cmd.Dir = runtime.WorkingDir
+	setProcessGroup(cmd)
 
 	// Build environment variable list ensuring azd context is preserved.
 	// Start with os.Environ() which includes all azd context variables
@@ -154,13 +156,14 @@ func StopServiceGraceful(process *ServiceProcess, timeout time.Duration) error {
 		return nil
 	}
 
-	// On Unix/Linux/macOS, try graceful shutdown with SIGINT first
-	if err := process.Process.Signal(os.Interrupt); err != nil {
+	// On Unix/Linux/macOS, try graceful shutdown with SIGINT first. Services run in their
+	// own process group, so the whole group is signalled to reach their children too.
+	if err := signalProcessGroup(process.Process, syscall.SIGINT); err != nil {
 		slog.Warn("graceful shutdown signal failed, forcing kill",
 			slog.String("service", process.Name),
 			slog.String("error", err.Error()))
 		// If signal fails (process already dead or doesn't support signals), try kill
-		if killErr := process.Process.Kill(); killErr != nil {
+		if killErr := signalProcessGroup(process.Process, syscall.SIGKILL); killErr != nil {
 			return fmt.Errorf("failed to kill process: %w", killErr)
 		}
 		// Wait for process to exit
@@ -188,7 +191,7 @@ func StopServiceGraceful(process *ServiceProcess, timeout time.Duration) error {
 		slog.Warn("graceful shutdown timeout, forcing kill",
 			slog.String("service", process.Name),
 			slog.Duration("timeout", timeout))
-		if err := process.Process.Kill(); err != nil {
+		if err := signalProcessGroup(process.Process, syscall.SIGKILL); err != nil {
 			return fmt.Errorf("failed to force kill process after timeout: %w", err)
 		}
 		// Wait for kill to complete
@@ -249,6 +252,12 @@ func GetProcessStatus(process *ServiceProcess) string {
 	return "starting"
 }
 
+// Destinations for services in raw log mode. Variables so tests can capture output.
+var (
+	rawStdout io.Writer = os.Stdout
+	rawStderr io.Writer = os.Stderr
+)
+
 // StartLogCollection starts collecting logs from a service process.
 func StartLogCollection(process *ServiceProcess, projectDir string, parser *FunctionsOutputParser) {
 	// Get or create log manager for this project
@@ -261,6 +270,15 @@ func StartLogCollection(process *ServiceProcess, projectDir string, parser *Func
 		return
 	}
 
+	// Raw mode: pass output through to the terminal unchanged so carriage-return
+	// progress output isn't garbled by line splitting
+	if process.Runtime.LogMode == LogModeRaw {
+		buffer.Add(NewLogEntry(process.Name, "Output is passed through to the terminal (logMode: raw)", false))
+		go passthroughStream(process.Stdout, rawStdout)
+		go passthroughStream(process.Stderr, rawStderr)
+		return
+	}
+
 	// Check if this is a Functions service
 	isFunctionsService := strings.Contains(process.Runtime.Framework, "Functions") ||
 		strings.Contains(process.Runtime.Framework, "Logic Apps")
@@ -277,38 +295,27 @@ func StartLogCollection(process *ServiceProcess, projectDir string, parser *Func
 
 // collectStreamLogs reads from a stream and adds entries to the log buffer.
 func collectStreamLogs(reader io.ReadCloser, serviceName string, buffer *LogBuffer, isStderr bool) {
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		entry := LogEntry{
-			Service:   serviceName,
-			Message:   scanner.Text(),
-			Timestamp: time.Now(),
-			IsStderr:  isStderr,
-			Level:     inferLogLevel(scanner.Text()),
-		}
-		buffer.Add(entry)
-	}
+	scanLogLines(reader, func(line string, truncated bool) {
+		buffer.Add(newOutputLogEntry(serviceName, line, truncated, isStderr))
+		echoToConsole(serviceName, line, isStderr, buffer)
+	})
+}
+
+// passthroughStream copies a stream to w byte-for-byte without line buffering.
+func passthroughStream(reader io.Reader, w io.Writer) {
+	_, _ = io.Copy(w, reader)
 }
 
 // collectFunctionsStreamLogs reads from a stream, adds entries to the log buffer, and parses Functions output.
 func collectFunctionsStreamLogs(reader io.ReadCloser, serviceName string, buffer *LogBuffer, parser *FunctionsOutputParser, isStderr bool) {
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		line := scanner.Text()
-
+	scanLogLines(reader, func(line string, truncated bool) {
 		// Add to log buffer
-		entry := LogEntry{
-			Service:   serviceName,
-			Message:   line,
-			Timestamp: time.Now(),
-			IsStderr:  isStderr,
-			Level:     inferLogLevel(line),
-		}
-		buffer.Add(entry)
+		buffer.Add(newOutputLogEntry(serviceName, line, truncated, isStderr))
+		echoToConsole(serviceName, line, isStderr, buffer)
 
 		// Also parse for function endpoints
 		parser.ParseLine(serviceName, line)
-	}
+	})
 }
 
 // isAccessDeniedError checks if an error is a Windows "Access is denied" error.
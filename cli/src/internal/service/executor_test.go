@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -405,3 +408,130 @@ func TestReadServiceOutput(t *testing.T) {
 		}
 	}
 }
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from log collection goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+const progressOutput = "Downloading 10%\rDownloading 50%\rDownloading 100%\nDone\n"
+
+func TestCollectStreamLogs_LineModeSplitsOnNewlines(t *testing.T) {
+	buffer, err := NewLogBuffer("web", 100, false, "")
+	if err != nil {
+		t.Fatalf("NewLogBuffer() error = %v", err)
+	}
+
+	collectStreamLogs(io.NopCloser(strings.NewReader(progressOutput)), "web", buffer, false)
+
+	entries := buffer.GetRecent(100)
+	if len(entries) != 2 {
+		t.Fatalf("line mode entries = %d, want 2: %+v", len(entries), entries)
+	}
+	// Carriage returns are not line breaks, so the progress updates collapse into one entry
+	if entries[0].Message != "Downloading 10%\rDownloading 50%\rDownloading 100%" {
+		t.Errorf("entries[0].Message = %q", entries[0].Message)
+	}
+	if entries[1].Message != "Done" {
+		t.Errorf("entries[1].Message = %q, want %q", entries[1].Message, "Done")
+	}
+}
+
+func TestCollectStreamLogs_LineLongerThanScannerLimit(t *testing.T) {
+	buffer, err := NewLogBuffer("api", 100, false, "")
+	if err != nil {
+		t.Fatalf("NewLogBuffer() error = %v", err)
+	}
+
+	// bufio.Scanner stops at its 64KB token limit; the lines after this one must still arrive
+	long := `{"msg":"` + strings.Repeat("a", 100*1024) + `"}`
+	collectStreamLogs(io.NopCloser(strings.NewReader(long+"\nnext\n")), "api", buffer, false)
+
+	entries := buffer.GetRecent(100)
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	if entries[0].Truncated || entries[0].Message != strings.Repeat("a", 100*1024) {
+		t.Errorf("entries[0] = %d bytes (truncated %v), want the whole JSON message", len(entries[0].Message), entries[0].Truncated)
+	}
+	if entries[1].Message != "next" {
+		t.Errorf("entries[1].Message = %q, want %q", entries[1].Message, "next")
+	}
+}
+
+func TestPassthroughStream_RawModeCopiesBytes(t *testing.T) {
+	var out bytes.Buffer
+	passthroughStream(strings.NewReader(progressOutput), &out)
+
+	if out.String() != progressOutput {
+		t.Errorf("passthroughStream() = %q, want %q", out.String(), progressOutput)
+	}
+}
+
+func TestStartLogCollection_RawMode(t *testing.T) {
+	var stdout, stderr syncBuffer
+	oldStdout, oldStderr := rawStdout, rawStderr
+	rawStdout, rawStderr = &stdout, &stderr
+	t.Cleanup(func() { rawStdout, rawStderr = oldStdout, oldStderr })
+
+	projectDir := t.TempDir()
+	process := &ServiceProcess{
+		Name:    "downloader",
+		Runtime: ServiceRuntime{Name: "downloader", LogMode: LogModeRaw},
+		Stdout:  io.NopCloser(strings.NewReader(progressOutput)),
+		Stderr:  io.NopCloser(strings.NewReader("warn\r")),
+	}
+	t.Cleanup(func() { _ = GetLogManager(projectDir).RemoveBuffer("downloader") })
+
+	StartLogCollection(process, projectDir, nil)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for (stdout.String() != progressOutput || stderr.String() != "warn\r") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if stdout.String() != progressOutput {
+		t.Errorf("raw stdout = %q, want %q", stdout.String(), progressOutput)
+	}
+	if stderr.String() != "warn\r" {
+		t.Errorf("raw stderr = %q, want %q", stderr.String(), "warn\r")
+	}
+
+	// Output is not log-buffered; only the raw mode notice is recorded
+	buffer, exists := GetLogManager(projectDir).GetBuffer("downloader")
+	if !exists {
+		t.Fatal("log buffer should still be created in raw mode")
+	}
+	for _, entry := range buffer.GetRecent(100) {
+		if strings.Contains(entry.Message, "Downloading") {
+			t.Errorf("raw mode output should not be buffered, found %q", entry.Message)
+		}
+	}
+}
+
+func TestServiceGetLogMode(t *testing.T) {
+	if got := (&Service{}).GetLogMode(); got != LogModeLine {
+		t.Errorf("GetLogMode() default = %q, want %q", got, LogModeLine)
+	}
+	if got := (&Service{LogMode: LogModeRaw}).GetLogMode(); got != LogModeRaw {
+		t.Errorf("GetLogMode() = %q, want %q", got, LogModeRaw)
+	}
+
+	_, err := DetectServiceRuntime("web", Service{Project: t.TempDir(), LogMode: "bytes"}, map[int]bool{}, "", "azd")
+	if err == nil || !strings.Contains(err.Error(), "invalid logMode") {
+		t.Errorf("DetectServiceRuntime() error = %v, want invalid logMode error", err)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -64,6 +65,45 @@ func TestStopServiceGraceful_Success(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 }
 
+func TestStartService_OwnProcessGroup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are checked on Unix")
+	}
+	if testing.Short() {
+		t.Skip("skipping process test in short mode")
+	}
+
+	tmpDir := t.TempDir()
+	rt := &ServiceRuntime{
+		Name:       "test-pgid",
+		WorkingDir: tmpDir,
+		Command:    "sh",
+		Args:       []string{"-c", "sleep 30 & wait"},
+		Language:   "shell",
+	}
+	process, err := StartService(rt, map[string]string{}, tmpDir, nil)
+	if err != nil {
+		t.Fatalf("StartService() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = GetLogManager(tmpDir).RemoveBuffer(rt.Name)
+	})
+
+	// A terminal Ctrl+C must not reach the service directly
+	if pgid := processGroupID(process.Process.Pid); pgid != process.Process.Pid {
+		t.Errorf("process group = %d, want the service's own group %d", pgid, process.Process.Pid)
+	}
+
+	// Stopping signals the whole group, so the shell's child doesn't keep it waiting
+	start := time.Now()
+	if err := StopServiceGraceful(process, 5*time.Second); err != nil {
+		t.Errorf("StopServiceGraceful() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("StopServiceGraceful() took %v, want the group interrupted before the timeout", elapsed)
+	}
+}
+
 func TestStopServiceGraceful_ForcedKillAfterTimeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping forced kill test in short mode")
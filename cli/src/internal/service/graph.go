@@ -3,6 +3,7 @@ package service
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 )
 
@@ -182,6 +183,15 @@ func TopologicalSort(graph *DependencyGraph) [][]string {
 	return result
 }
 
+// ShutdownOrder returns services grouped in the order they stop: the levels of
+// TopologicalSort in reverse, so a service stops only after every service that uses it.
+// Services that use nothing stop together in the last group.
+func ShutdownOrder(graph *DependencyGraph) [][]string {
+	levels := TopologicalSort(graph)
+	slices.Reverse(levels)
+	return levels
+}
+
 // GetServiceDependencies returns the direct dependencies of a service.
 func GetServiceDependencies(serviceName string, graph *DependencyGraph) []string {
 	if edges, exists := graph.Edges[serviceName]; exists {
@@ -255,3 +265,37 @@ func addServiceAndDeps(name string, source *DependencyGraph, dest *DependencyGra
 
 	return nil
 }
+
+// ExpandDependencyClosure returns the given service names plus every service they
+// transitively depend on through 'uses'. Names that reference resources or unknown
+// services are skipped. Cycles are tolerated: each service is visited at most once.
+// The result is sorted for deterministic ordering.
+func ExpandDependencyClosure(services map[string]Service, serviceNames []string) []string {
+	visited := make(map[string]bool)
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		svc, exists := services[name]
+		if !exists {
+			return
+		}
+		visited[name] = true
+		for _, dep := range svc.Uses {
+			visit(dep)
+		}
+	}
+
+	for _, name := range serviceNames {
+		visit(name)
+	}
+
+	result := make([]string, 0, len(visited))
+	for name := range visited {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
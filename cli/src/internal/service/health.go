@@ -2,14 +2,19 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/coder/websocket"
 )
 
 // Backoff configuration constants
@@ -26,15 +31,26 @@ const (
 	HTTPClientTimeout = 5 * time.Second
 	ConnectionTimeout = 2 * time.Second
 	PortCheckTimeout  = 1 * time.Second
+
+	// udpProbeReplySize is large enough for any UDP datagram, so replies are never truncated
+	udpProbeReplySize = 64 * 1024
+
+	// maxHealthCheckBodySize caps how much of an HTTP response is read to match expectBody
+	maxHealthCheckBodySize = 64 * 1024
 )
 
 // PerformHealthCheck verifies that a service is ready with exponential backoff.
 // Supports multiple health check types:
 // - "http": Check an HTTP endpoint (default)
+// - "ws": Complete a WebSocket handshake on the healthcheck path
 // - "tcp": Check if a TCP port is listening
+// - "udp": Check if a UDP port is bound, or that the service answers a probe (best-effort)
 // - "process": Check if the process is running
 // - "output": Monitor stdout for a pattern match (requires LogMatch to be set)
 // - "none": Skip health checks (service is immediately considered ready)
+//
+// A container service with a Docker Compose-style test is checked with CommandHealthCheck
+// instead of by type.
 func PerformHealthCheck(process *ServiceProcess) error {
 	config := process.Runtime.HealthCheck
 
@@ -53,11 +69,23 @@ func PerformHealthCheck(process *ServiceProcess) error {
 	operation := func() error {
 		var err error
 
+		if len(config.Test) > 0 {
+			err = CommandHealthCheck(process, config.Test)
+			if err == nil {
+				process.Ready = true
+			}
+			return err
+		}
+
 		switch config.Type {
 		case "http":
-			err = HTTPHealthCheck(process.Port, config.Path)
+			err = HTTPHealthCheckExpecting(process.Port, config.Path, config.ExpectStatus, config.ExpectBody)
+		case HealthCheckTypeWebSocket:
+			err = WebSocketHealthCheck(process.Port, config.Path)
 		case "tcp":
 			err = PortHealthCheck(process.Port)
+		case "udp":
+			err = UDPHealthCheck(process.Port, config.Probe)
 		case "process":
 			err = ProcessHealthCheck(process)
 		case "output":
@@ -70,7 +98,7 @@ func PerformHealthCheck(process *ServiceProcess) error {
 		default:
 			// Default to HTTP health check if port is available, otherwise process check
 			if process.Port > 0 {
-				err = HTTPHealthCheck(process.Port, config.Path)
+				err = HTTPHealthCheckExpecting(process.Port, config.Path, config.ExpectStatus, config.ExpectBody)
 			} else {
 				err = ProcessHealthCheck(process)
 			}
@@ -87,6 +115,66 @@ func PerformHealthCheck(process *ServiceProcess) error {
 	return backoff.Retry(operation, b)
 }
 
+// CommandHealthCheck runs a container service's Docker Compose-style healthcheck test. An
+// http(s) URL must answer with a 2xx or 3xx status, ["CMD", args...] runs args inside the
+// container, and ["CMD-SHELL", command] or a plain command runs it with sh -c inside the
+// container; commands must exit with code 0.
+func CommandHealthCheck(process *ServiceProcess, test []string) error {
+	if len(test) == 0 {
+		return errors.New("healthcheck test is empty")
+	}
+	if strings.HasPrefix(test[0], "http://") || strings.HasPrefix(test[0], "https://") {
+		return urlHealthCheck(test[0])
+	}
+
+	containerName := process.ContainerID
+	if containerName == "" {
+		containerName = fmt.Sprintf("azd-%s", process.Name)
+	}
+
+	client := newContainerExecer()
+	var exitCode int
+	var out string
+	var err error
+	switch test[0] {
+	case "CMD", "CMD-SHELL":
+		if len(test) < 2 {
+			return fmt.Errorf("healthcheck test %s has no command", test[0])
+		}
+		if test[0] == "CMD" {
+			exitCode, out, err = client.Exec(containerName, test[1:])
+		} else {
+			exitCode, out, err = client.ExecShell(containerName, strings.Join(test[1:], " "))
+		}
+	default:
+		exitCode, out, err = client.ExecShell(containerName, strings.Join(test, " "))
+	}
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		if out != "" {
+			return fmt.Errorf("healthcheck test exited with code %d: %s", exitCode, truncateHealthCheckBody([]byte(out)))
+		}
+		return fmt.Errorf("healthcheck test exited with code %d", exitCode)
+	}
+	return nil
+}
+
+// urlHealthCheck verifies that a URL answers with a 2xx or 3xx status.
+func urlHealthCheck(url string) error {
+	resp, err := newHealthCheckClient().Get(url)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer SafeClose(resp.Body, "GET response body")
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("HTTP health check failed with status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // OutputHealthCheck checks if a specific pattern has been matched in the process output.
 // This is useful for build/watch services that log a success message but don't serve HTTP.
 // It searches the service's log buffer for the specified pattern.
@@ -122,14 +210,7 @@ func HTTPHealthCheck(port int, path string) error {
 	// Build URL
 	url := fmt.Sprintf("http://localhost:%d%s", port, path)
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: HTTPClientTimeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Don't follow redirects
-			return http.ErrUseLastResponse
-		},
-	}
+	client := newHealthCheckClient()
 
 	// Try HEAD request first (lightweight)
 	resp, err := client.Head(url)
@@ -156,6 +237,98 @@ func HTTPHealthCheck(port int, path string) error {
 	return fmt.Errorf("HTTP health check failed with status: %d", resp.StatusCode)
 }
 
+// HTTPHealthCheckExpecting is HTTPHealthCheck with assertions on the response: with
+// expectStatus set the status code must equal it, and with expectBody set the body must
+// contain it or match it as a regular expression. Without either it is HTTPHealthCheck.
+func HTTPHealthCheckExpecting(port int, path string, expectStatus int, expectBody string) error {
+	if expectStatus == 0 && expectBody == "" {
+		return HTTPHealthCheck(port, path)
+	}
+
+	url := fmt.Sprintf("http://localhost:%d%s", port, path)
+
+	// HEAD has no body and may be answered differently, so only GET is checked
+	resp, err := newHealthCheckClient().Get(url)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer SafeClose(resp.Body, "GET response body")
+
+	if expectStatus > 0 && resp.StatusCode != expectStatus {
+		return fmt.Errorf("HTTP health check returned status %d, expected %d", resp.StatusCode, expectStatus)
+	}
+	if expectStatus == 0 && (resp.StatusCode < 200 || resp.StatusCode >= 400) {
+		return fmt.Errorf("HTTP health check failed with status: %d", resp.StatusCode)
+	}
+
+	if expectBody == "" {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthCheckBodySize))
+	if err != nil {
+		return fmt.Errorf("failed to read HTTP health check response: %w", err)
+	}
+	if !MatchesExpectedBody(string(body), expectBody) {
+		return fmt.Errorf("HTTP health check response %q does not match %q", truncateHealthCheckBody(body), expectBody)
+	}
+	return nil
+}
+
+// WebSocketHealthCheck verifies that a service completes a WebSocket handshake on path, for
+// realtime services that only answer upgrade requests. The connection is closed as soon as
+// the handshake succeeds.
+func WebSocketHealthCheck(port int, path string) error {
+	url := fmt.Sprintf("ws://localhost:%d%s", port, path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), HTTPClientTimeout)
+	defer cancel()
+
+	conn, resp, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("WebSocket handshake failed with status: %d", resp.StatusCode)
+		}
+		return fmt.Errorf("WebSocket handshake failed: %w", err)
+	}
+	if closeErr := conn.CloseNow(); closeErr != nil {
+		slog.Debug("failed to close health check connection", "error", closeErr)
+	}
+	return nil
+}
+
+// MatchesExpectedBody reports whether an HTTP health check response body contains expect,
+// or matches it as a regular expression. An expect that is not a valid regular expression
+// is only matched as a substring.
+func MatchesExpectedBody(body, expect string) bool {
+	if strings.Contains(body, expect) {
+		return true
+	}
+	re, err := regexp.Compile(expect)
+	return err == nil && re.MatchString(body)
+}
+
+// truncateHealthCheckBody shortens a response body for an error message.
+func truncateHealthCheckBody(body []byte) string {
+	const maxLen = 100
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxLen {
+		return text[:maxLen] + "…"
+	}
+	return text
+}
+
+// newHealthCheckClient returns the HTTP client used by health checks, which does not
+// follow redirects.
+func newHealthCheckClient() *http.Client {
+	return &http.Client{
+		Timeout: HTTPClientTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Don't follow redirects
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
 // PortHealthCheck verifies that a port is listening.
 func PortHealthCheck(port int) error {
 	address := fmt.Sprintf("localhost:%d", port)
@@ -172,6 +345,46 @@ func PortHealthCheck(port int) error {
 	return nil
 }
 
+// UDPHealthCheck verifies that a UDP port is in use. Without a probe, the port counts as
+// bound when it can't be bound again. With a probe, the datagram is sent to the port and
+// the service must reply. UDP has no handshake, so this is best-effort: a bound port doesn't
+// prove the service handles datagrams, and binding can fail for other reasons.
+func UDPHealthCheck(port int, probe string) error {
+	address := fmt.Sprintf("127.0.0.1:%d", port)
+	if probe == "" {
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return nil // Held by the service
+		}
+		if closeErr := conn.Close(); closeErr != nil {
+			slog.Warn("failed to close health check socket", "error", closeErr)
+		}
+		return fmt.Errorf("udp port %d not bound", port)
+	}
+
+	conn, err := net.DialTimeout("udp", address, ConnectionTimeout)
+	if err != nil {
+		return fmt.Errorf("udp port %d: %w", port, err)
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			slog.Warn("failed to close health check connection", "error", closeErr)
+		}
+	}()
+
+	if err := conn.SetDeadline(time.Now().Add(ConnectionTimeout)); err != nil {
+		return fmt.Errorf("udp port %d: %w", port, err)
+	}
+	if _, err := conn.Write([]byte(probe)); err != nil {
+		return fmt.Errorf("udp probe to port %d failed: %w", port, err)
+	}
+	reply := make([]byte, udpProbeReplySize)
+	if _, err := conn.Read(reply); err != nil {
+		return fmt.Errorf("no reply to udp probe on port %d: %w", port, err)
+	}
+	return nil
+}
+
 // ProcessHealthCheck verifies that a process is running.
 func ProcessHealthCheck(process *ServiceProcess) error {
 	if process == nil {
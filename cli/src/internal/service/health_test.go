@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/coder/websocket"
 )
 
 func TestPortHealthCheck_Success(t *testing.T) {
@@ -107,6 +110,50 @@ func TestHTTPHealthCheck_PortNotListening(t *testing.T) {
 	}
 }
 
+func TestHTTPHealthCheckExpecting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/degraded":
+			_, _ = w.Write([]byte(`{"status":"degraded"}`))
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status": "healthy"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"healthy"}`))
+		}
+	}))
+	defer server.Close()
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name         string
+		path         string
+		expectStatus int
+		expectBody   string
+		wantErr      bool
+	}{
+		{"no expectations", "/degraded", 0, "", false},
+		{"body substring", "/", 0, `"status":"healthy"`, false},
+		{"body substring mismatch", "/degraded", 0, `"status":"healthy"`, true},
+		{"body regex", "/accepted", 0, `"status":\s*"healthy"`, false},
+		{"body regex mismatch", "/degraded", 0, `"status":\s*"healthy"`, true},
+		{"invalid regex matched as substring", "/", 0, `{"status"`, false},
+		{"status matches", "/accepted", http.StatusAccepted, "", false},
+		{"status mismatch", "/", http.StatusAccepted, "", true},
+		{"status and body", "/accepted", http.StatusAccepted, "healthy", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := HTTPHealthCheckExpecting(port, tt.path, tt.expectStatus, tt.expectBody)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HTTPHealthCheckExpecting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestProcessHealthCheck_Success(t *testing.T) {
 	// We can't easily create a valid process without starting one
 	// Skip this test in short mode, covered by integration tests
@@ -367,3 +414,205 @@ func TestPerformHealthCheck_ProcessType(t *testing.T) {
 		t.Error("PerformHealthCheck() process.Ready = false, want true")
 	}
 }
+
+func TestUDPHealthCheck(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to bind UDP port: %v", err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	// Answer probes like a collector's ping endpoint
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if string(buf[:n]) == "ping" {
+				_, _ = conn.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+
+	if err := UDPHealthCheck(port, ""); err != nil {
+		t.Errorf("UDPHealthCheck() on a bound port error = %v", err)
+	}
+	if err := UDPHealthCheck(port, "ping"); err != nil {
+		t.Errorf("UDPHealthCheck() with an answered probe error = %v", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := UDPHealthCheck(port, ""); err == nil {
+		t.Error("UDPHealthCheck() should fail once the port is released")
+	}
+}
+
+func TestWebSocketHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		// Wait for the health check to close the connection
+		_, _, _ = conn.Read(context.Background())
+	}))
+	defer server.Close()
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	if err := WebSocketHealthCheck(port, "/ws"); err != nil {
+		t.Errorf("WebSocketHealthCheck() error = %v, want nil", err)
+	}
+	if err := WebSocketHealthCheck(port, "/"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("WebSocketHealthCheck() on a plain HTTP path error = %v, want the 404 status", err)
+	}
+}
+
+func TestWebSocketHealthCheck_PortNotListening(t *testing.T) {
+	if err := WebSocketHealthCheck(64997, "/"); err == nil {
+		t.Error("WebSocketHealthCheck() expected error for non-listening port")
+	}
+}
+
+// fakeContainerExecer records the commands run inside containers and returns a fixed result.
+type fakeContainerExecer struct {
+	exitCode  int
+	output    string
+	container string
+	command   []string
+}
+
+func (f *fakeContainerExecer) Exec(containerName string, command []string) (int, string, error) {
+	f.container = containerName
+	f.command = command
+	return f.exitCode, f.output, nil
+}
+
+func (f *fakeContainerExecer) ExecShell(containerName string, shellCommand string) (int, string, error) {
+	return f.Exec(containerName, []string{"sh", "-c", shellCommand})
+}
+
+func TestCommandHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		test        []string
+		exitCode    int
+		output      string
+		wantCommand []string
+		wantErr     string
+	}{
+		{
+			name:        "CMD runs arguments",
+			test:        []string{"CMD", "pg_isready", "-U", "postgres"},
+			wantCommand: []string{"pg_isready", "-U", "postgres"},
+		},
+		{
+			name:        "CMD-SHELL runs with sh",
+			test:        []string{"CMD-SHELL", "redis-cli ping || exit 1"},
+			wantCommand: []string{"sh", "-c", "redis-cli ping || exit 1"},
+		},
+		{
+			name:        "plain command runs with sh",
+			test:        []string{"pg_isready"},
+			wantCommand: []string{"sh", "-c", "pg_isready"},
+		},
+		{
+			name:        "non-zero exit code",
+			test:        []string{"CMD", "pg_isready"},
+			exitCode:    2,
+			output:      "/var/run/postgresql:5432 - no response",
+			wantCommand: []string{"pg_isready"},
+			wantErr:     "exited with code 2: /var/run/postgresql:5432 - no response",
+		},
+		{
+			name:    "CMD without command",
+			test:    []string{"CMD"},
+			wantErr: "has no command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execer := &fakeContainerExecer{exitCode: tt.exitCode, output: tt.output}
+			defer func(newExecer func() containerExecer) { newContainerExecer = newExecer }(newContainerExecer)
+			newContainerExecer = func() containerExecer { return execer }
+
+			err := CommandHealthCheck(&ServiceProcess{Name: "db", ContainerID: "abc123"}, tt.test)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("CommandHealthCheck() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("CommandHealthCheck() error = %v, want nil", err)
+			}
+			if strings.Join(execer.command, " ") != strings.Join(tt.wantCommand, " ") {
+				t.Errorf("command = %q, want %q", execer.command, tt.wantCommand)
+			}
+			if tt.wantCommand != nil && execer.container != "abc123" {
+				t.Errorf("container = %q, want %q", execer.container, "abc123")
+			}
+		})
+	}
+}
+
+func TestCommandHealthCheck_URL(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	process := &ServiceProcess{Name: "api"}
+	if err := CommandHealthCheck(process, []string{server.URL + "/health"}); err == nil {
+		t.Error("CommandHealthCheck() should fail while the URL returns 503")
+	}
+	status = http.StatusOK
+	if err := CommandHealthCheck(process, []string{server.URL + "/health"}); err != nil {
+		t.Errorf("CommandHealthCheck() error = %v, want nil", err)
+	}
+}
+
+func TestPerformHealthCheck_ContainerTest(t *testing.T) {
+	execer := &fakeContainerExecer{exitCode: 1}
+	defer func(newExecer func() containerExecer) { newContainerExecer = newExecer }(newContainerExecer)
+	newContainerExecer = func() containerExecer { return execer }
+
+	// The test replaces the tcp probe, so the unused port does not matter
+	process := &ServiceProcess{
+		Name: "db",
+		Runtime: ServiceRuntime{
+			Name: "db",
+			Type: ServiceTypeContainer,
+			HealthCheck: HealthCheckConfig{
+				Type:     "tcp",
+				Test:     []string{"CMD", "pg_isready"},
+				Timeout:  300 * time.Millisecond,
+				Interval: 50 * time.Millisecond,
+			},
+		},
+		ContainerID: "abc123",
+	}
+
+	if err := PerformHealthCheck(process); err == nil {
+		t.Error("PerformHealthCheck() should fail while the test exits non-zero")
+	}
+	if process.Ready {
+		t.Error("process.Ready = true, want false")
+	}
+
+	execer.exitCode = 0
+	if err := PerformHealthCheck(process); err != nil {
+		t.Errorf("PerformHealthCheck() error = %v, want nil", err)
+	}
+	if !process.Ready {
+		t.Error("process.Ready = false, want true")
+	}
+}
@@ -2,6 +2,9 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -118,6 +121,37 @@ func TestHealthcheckConfig_GetType(t *testing.T) {
 	}
 }
 
+func TestHealthcheckConfig_GetTest(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *HealthcheckConfig
+		expected []string
+	}{
+		{name: "nil config", config: nil, expected: nil},
+		{name: "no test", config: &HealthcheckConfig{Type: "tcp"}, expected: nil},
+		{name: "string", config: &HealthcheckConfig{Test: "pg_isready"}, expected: []string{"pg_isready"}},
+		{
+			name:     "yaml list",
+			config:   &HealthcheckConfig{Test: []any{"CMD", "pg_isready", "-U", "postgres"}},
+			expected: []string{"CMD", "pg_isready", "-U", "postgres"},
+		},
+		{
+			name:     "string list",
+			config:   &HealthcheckConfig{Test: []string{"CMD-SHELL", "redis-cli ping"}},
+			expected: []string{"CMD-SHELL", "redis-cli ping"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.GetTest()
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") || len(result) != len(tt.expected) {
+				t.Errorf("GetTest() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestService_IsHealthcheckDisabled(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -169,6 +203,29 @@ func TestService_IsHealthcheckDisabled(t *testing.T) {
 	}
 }
 
+func TestService_GetHealthCheckType(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name     string
+		service  Service
+		expected string
+	}{
+		{name: "port defaults to http", service: Service{Ports: []string{"8080"}}, expected: "http"},
+		{name: "no port defaults to process", service: Service{}, expected: "process"},
+		{name: "configured type", service: Service{Ports: []string{"8080"}, Healthcheck: &HealthcheckConfig{Type: "tcp"}}, expected: "tcp"},
+		{name: "udp service defaults to udp", service: Service{Type: ServiceTypeUDP, Ports: []string{"8125"}}, expected: "udp"},
+		{name: "healthcheck false", service: Service{HealthcheckEnabled: &disabled, Healthcheck: &HealthcheckConfig{Disable: true}}, expected: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.service.GetHealthCheckType(); got != tt.expected {
+				t.Errorf("GetHealthCheckType() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestService_NeedsPort(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -318,6 +375,45 @@ project: ./api
 	}
 }
 
+func TestService_UnmarshalYAML_HealthcheckExpectations(t *testing.T) {
+	var service Service
+	err := yaml.Unmarshal([]byte(`
+project: ./api
+healthcheck:
+  type: http
+  path: /health
+  expectStatus: 200
+  expectBody: '"status":\s*"healthy"'
+`), &service)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	if service.Healthcheck == nil || service.Healthcheck.ExpectStatus != 200 || service.Healthcheck.ExpectBody != `"status":\s*"healthy"` {
+		t.Errorf("Healthcheck = %+v, want expectStatus 200 and expectBody parsed", service.Healthcheck)
+	}
+}
+
+func TestParseAzureYaml_WebSocketHealthcheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "name: test\nservices:\n  chat:\n    project: ./chat\n    ports: [\"8080\"]\n    healthcheck:\n      type: WebSocket\n      path: /ws\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	azureYaml, err := ParseAzureYaml(tmpDir)
+	if err != nil {
+		t.Fatalf("ParseAzureYaml() error = %v", err)
+	}
+	chat := azureYaml.Services["chat"]
+	if chat.Healthcheck.Type != HealthCheckTypeWebSocket {
+		t.Errorf("Healthcheck.Type = %q, want %q", chat.Healthcheck.Type, HealthCheckTypeWebSocket)
+	}
+	if got := chat.GetServiceType(); got != ServiceTypeHTTP {
+		t.Errorf("GetServiceType() = %q, want %q", got, ServiceTypeHTTP)
+	}
+}
+
 func TestPerformHealthCheck_NoneType(t *testing.T) {
 	process := &ServiceProcess{
 		Runtime: ServiceRuntime{
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// HostEnvFilter selects the host environment variables forwarded to services
+// (azd app run --env-allow and --env-deny). Patterns are globs on the variable name,
+// as in path.Match: `*` matches any run of characters and `?` a single character.
+type HostEnvFilter struct {
+	Allow []string // Forward only variables matching one of these; every variable when empty
+	Deny  []string // Never forward variables matching one of these, even if allowed
+}
+
+// Validate checks that every pattern is a valid glob.
+func (f HostEnvFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Allow...), f.Deny...) {
+		if pattern == "" {
+			return fmt.Errorf("empty environment variable pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid environment variable pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Forwards reports whether the host variable name passes the filter. Names are compared
+// case-insensitively on Windows, where environment variable names are.
+func (f HostEnvFilter) Forwards(name string) bool {
+	if len(f.Allow) > 0 && !matchesEnvPattern(name, f.Allow) {
+		return false
+	}
+	return !matchesEnvPattern(name, f.Deny)
+}
+
+// matchesEnvPattern reports whether name matches any of the glob patterns.
+func matchesEnvPattern(name string, patterns []string) bool {
+	if runtime.GOOS == "windows" {
+		name = strings.ToUpper(name)
+	}
+	for _, pattern := range patterns {
+		if runtime.GOOS == "windows" {
+			pattern = strings.ToUpper(pattern)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceEnvironment builds the environment of a native service. From lowest to highest
+// priority: the host environment that passes filter, envVars (.env and --env-file), then
+// the runtime's own variables (azure.yaml environment and detected settings).
+//
+// A host PORT or HOST describes the host's own server, not the service: HOST is dropped
+// and PORT is replaced with the port azd app assigned, unless envVars or the runtime set them.
+func serviceEnvironment(rt *ServiceRuntime, envVars map[string]string, filter HostEnvFilter) map[string]string {
+	env := make(map[string]string)
+	for _, e := range os.Environ() {
+		name, value, ok := strings.Cut(e, "=")
+		if ok && filter.Forwards(name) {
+			env[name] = value
+		}
+	}
+
+	if _, ok := env["PORT"]; ok && rt.Port > 0 {
+		env["PORT"] = strconv.Itoa(rt.Port)
+	}
+	delete(env, "HOST")
+
+	for k, v := range envVars {
+		env[k] = v
+	}
+	for k, v := range rt.Env {
+		env[k] = v
+	}
+	return env
+}
+
+// ResolveServiceEnvironment returns the environment a service is started with: for a native
+// service, the serviceEnvironment merge plus the settings InjectFunctionsWorkerRuntime adds for
+// Functions and Logic Apps. Containers only receive the runtime's own variables.
+func ResolveServiceEnvironment(rt *ServiceRuntime, envVars map[string]string, filter HostEnvFilter) map[string]string {
+	if rt.Type == ServiceTypeContainer {
+		env := make(map[string]string, len(rt.Env))
+		for k, v := range rt.Env {
+			env[k] = v
+		}
+		return env
+	}
+	return InjectFunctionsWorkerRuntime(serviceEnvironment(rt, envVars, filter), rt)
+}
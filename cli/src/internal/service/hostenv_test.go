@@ -0,0 +1,132 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestHostEnvFilterForwards(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter HostEnvFilter
+		want   map[string]bool
+	}{
+		{
+			name:   "no patterns forwards everything",
+			filter: HostEnvFilter{},
+			want:   map[string]bool{"PATH": true, "PORT": true, "AZURE_ENV_NAME": true},
+		},
+		{
+			name:   "allow list",
+			filter: HostEnvFilter{Allow: []string{"PATH", "AZURE_*"}},
+			want:   map[string]bool{"PATH": true, "AZURE_ENV_NAME": true, "PORT": false, "XPATH": false},
+		},
+		{
+			name:   "deny list",
+			filter: HostEnvFilter{Deny: []string{"*_TOKEN", "PORT"}},
+			want:   map[string]bool{"PATH": true, "GITHUB_TOKEN": false, "PORT": false, "PORTS": true},
+		},
+		{
+			name:   "deny wins over allow",
+			filter: HostEnvFilter{Allow: []string{"AZURE_*"}, Deny: []string{"AZURE_CLIENT_SECRET"}},
+			want:   map[string]bool{"AZURE_ENV_NAME": true, "AZURE_CLIENT_SECRET": false},
+		},
+		{
+			name:   "single character wildcard",
+			filter: HostEnvFilter{Allow: []string{"NODE_?"}},
+			want:   map[string]bool{"NODE_1": true, "NODE_10": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, want := range tt.want {
+				if got := tt.filter.Forwards(name); got != want {
+					t.Errorf("Forwards(%q) = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHostEnvFilterValidate(t *testing.T) {
+	if err := (HostEnvFilter{Allow: []string{"NODE_*", "PATH"}, Deny: []string{"*_SECRET"}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := (HostEnvFilter{Deny: []string{"BAD["}}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for an invalid pattern")
+	}
+	if err := (HostEnvFilter{Allow: []string{""}}).Validate(); err == nil {
+		t.Error("Validate() error = nil, want error for an empty pattern")
+	}
+}
+
+func TestServiceEnvironment(t *testing.T) {
+	t.Setenv("PORT", "8080")
+	t.Setenv("HOST", "build-agent")
+	t.Setenv("AZD_APP_TEST_KEEP", "host")
+	t.Setenv("AZD_APP_TEST_SECRET", "host")
+	t.Setenv("AZD_APP_TEST_OVERRIDE", "host")
+
+	filter := HostEnvFilter{Deny: []string{"*_SECRET"}}
+	rt := &ServiceRuntime{Name: "api", Port: 3000, Env: map[string]string{"AZD_APP_TEST_RUNTIME": "runtime"}}
+
+	env := serviceEnvironment(rt, map[string]string{"AZD_APP_TEST_OVERRIDE": "dotenv"}, filter)
+
+	for name, want := range map[string]string{
+		"PORT":                  "3000",
+		"AZD_APP_TEST_KEEP":     "host",
+		"AZD_APP_TEST_OVERRIDE": "dotenv",
+		"AZD_APP_TEST_RUNTIME":  "runtime",
+	} {
+		if env[name] != want {
+			t.Errorf("%s = %q, want %q", name, env[name], want)
+		}
+	}
+	for _, name := range []string{"HOST", "AZD_APP_TEST_SECRET"} {
+		if value, ok := env[name]; ok {
+			t.Errorf("%s = %q, want it not forwarded", name, value)
+		}
+	}
+
+	t.Run("explicit values win over the assigned port", func(t *testing.T) {
+		env := serviceEnvironment(rt, map[string]string{"PORT": "4000", "HOST": "0.0.0.0"}, HostEnvFilter{})
+		if env["PORT"] != "4000" || env["HOST"] != "0.0.0.0" {
+			t.Errorf("PORT = %q, HOST = %q, want the .env values", env["PORT"], env["HOST"])
+		}
+	})
+
+	t.Run("denied host PORT is not replaced", func(t *testing.T) {
+		env := serviceEnvironment(rt, nil, HostEnvFilter{Deny: []string{"PORT"}})
+		if value, ok := env["PORT"]; ok {
+			t.Errorf("PORT = %q, want it not set", value)
+		}
+	})
+}
+
+func TestResolveServiceEnvironment(t *testing.T) {
+	t.Setenv("AZD_APP_TEST_HOST", "host")
+	envVars := map[string]string{"AZD_APP_TEST_DOTENV": "dotenv"}
+
+	native := &ServiceRuntime{Name: "api", Port: 3000, Env: map[string]string{"AZD_APP_TEST_RUNTIME": "runtime"}}
+	env := ResolveServiceEnvironment(native, envVars, HostEnvFilter{})
+	for name, want := range map[string]string{
+		"AZD_APP_TEST_HOST":    "host",
+		"AZD_APP_TEST_DOTENV":  "dotenv",
+		"AZD_APP_TEST_RUNTIME": "runtime",
+	} {
+		if env[name] != want {
+			t.Errorf("native %s = %q, want %q", name, env[name], want)
+		}
+	}
+
+	// Containers don't inherit the host environment or .env files
+	container := &ServiceRuntime{Name: "db", Type: ServiceTypeContainer, Env: map[string]string{"AZD_APP_TEST_RUNTIME": "runtime"}}
+	env = ResolveServiceEnvironment(container, envVars, HostEnvFilter{})
+	if len(env) != 1 || env["AZD_APP_TEST_RUNTIME"] != "runtime" {
+		t.Errorf("container env = %v, want only the runtime's variables", env)
+	}
+	env["AZD_APP_TEST_RUNTIME"] = "changed"
+	if container.Env["AZD_APP_TEST_RUNTIME"] != "runtime" {
+		t.Error("ResolveServiceEnvironment() returned the runtime's own map")
+	}
+}
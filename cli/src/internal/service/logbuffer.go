@@ -3,6 +3,7 @@ package service
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
@@ -14,9 +15,9 @@ import (
 )
 
 const (
-	// MaxLogFileSize is the maximum size of a log file before rotation (1MB)
+	// MaxLogFileSize is the default maximum size of a log file before rotation (1MB)
 	MaxLogFileSize = 1 * 1024 * 1024
-	// MaxLogFileBackups is the number of backup log files to keep
+	// MaxLogFileBackups is the default number of backup log files to keep
 	MaxLogFileBackups = 2
 )
 
@@ -34,6 +35,7 @@ type LogBuffer struct {
 	fileMu          sync.Mutex
 	logFilter       *LogFilter // Optional filter for noisy log messages
 	currentFileSize int64      // Track current file size for rotation
+	rotation        LogRotation
 }
 
 // NewLogBuffer creates a new log buffer for a service.
@@ -41,26 +43,42 @@ func NewLogBuffer(serviceName string, maxSize int, enableFileLogging bool, proje
 	return NewLogBufferWithFilter(serviceName, maxSize, enableFileLogging, projectDir, nil)
 }
 
-// NewLogBufferWithFilter creates a new log buffer with optional log filtering.
+// NewLogBufferWithFilter creates a new log buffer with optional log filtering. With file
+// logging, entries are also written to .azure/logs/<service>.log in projectDir.
 func NewLogBufferWithFilter(serviceName string, maxSize int, enableFileLogging bool, projectDir string, filter *LogFilter) (*LogBuffer, error) {
+	opts := LogBufferOptions{Filter: filter}
+	if enableFileLogging {
+		opts.FilePath = DefaultLogFile(projectDir, serviceName)
+	}
+	return NewLogBufferWithOptions(serviceName, maxSize, opts)
+}
+
+// LogBufferOptions configures a log buffer created with NewLogBufferWithOptions.
+type LogBufferOptions struct {
+	FilePath string     // File entries are also written to (empty disables file logging)
+	Filter   *LogFilter // Optional filter for noisy log messages
+}
+
+// NewLogBufferWithOptions creates a new log buffer with the given options.
+func NewLogBufferWithOptions(serviceName string, maxSize int, opts LogBufferOptions) (*LogBuffer, error) {
 	lb := &LogBuffer{
 		serviceName: serviceName,
 		entries:     make([]LogEntry, 0, maxSize),
 		maxSize:     maxSize,
 		subscribers: make(map[chan LogEntry]bool),
-		logFilter:   filter,
+		logFilter:   opts.Filter,
+		rotation:    currentLogRotation(),
 	}
 
 	// Setup file logging if enabled
-	if enableFileLogging {
-		logsDir := filepath.Join(projectDir, ".azure", "logs")
+	if opts.FilePath != "" {
 		// Use 0700 for directory permissions to match file privacy intent (0600)
 		// This ensures only the owner can access log files
-		if err := os.MkdirAll(logsDir, 0700); err != nil {
+		if err := os.MkdirAll(filepath.Dir(opts.FilePath), 0700); err != nil {
 			return nil, fmt.Errorf("failed to create logs directory: %w", err)
 		}
 
-		lb.filePath = filepath.Join(logsDir, fmt.Sprintf("%s.log", serviceName))
+		lb.filePath = opts.FilePath
 		file, err := os.OpenFile(lb.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open log file: %w", err)
@@ -110,7 +128,7 @@ func (lb *LogBuffer) Add(entry LogEntry) {
 // writeToFile writes a log entry to the file (must be called with fileMu locked).
 func (lb *LogBuffer) writeToFile(entry LogEntry) {
 	// Check if rotation is needed
-	if lb.currentFileSize >= MaxLogFileSize {
+	if lb.currentFileSize >= lb.rotation.MaxSize {
 		lb.rotateLogFile()
 	}
 
@@ -121,7 +139,15 @@ func (lb *LogBuffer) writeToFile(entry LogEntry) {
 		stream = "ERR"
 	}
 
-	line := fmt.Sprintf("[%s] [%s] [%s] %s\n", timestamp, level, stream, entry.Message)
+	// Persist structured entries as their original JSON object so fields survive a re-read
+	message := entry.Message
+	if entry.Fields != nil {
+		if data, err := json.Marshal(entry.Fields); err == nil {
+			message = string(data)
+		}
+	}
+
+	line := fmt.Sprintf("[%s] [%s] [%s] %s\n", timestamp, level, stream, message)
 	n, err := lb.fileWriter.WriteString(line)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to write log entry: %v\n", err)
@@ -144,20 +170,13 @@ func (lb *LogBuffer) rotateLogFile() {
 		lb.file.Close()
 	}
 
-	// Rotate existing backup files (delete oldest, shift others)
-	for i := MaxLogFileBackups - 1; i >= 1; i-- {
-		oldPath := fmt.Sprintf("%s.%d", lb.filePath, i)
-		newPath := fmt.Sprintf("%s.%d", lb.filePath, i+1)
-		_ = os.Rename(oldPath, newPath) // Ignore errors - file may not exist
-	}
-
-	// Delete the oldest backup if it exceeds MaxLogFileBackups
-	oldestBackup := fmt.Sprintf("%s.%d", lb.filePath, MaxLogFileBackups+1)
-	os.Remove(oldestBackup) // Ignore errors
-
-	// Rename current file to .1
-	if err := os.Rename(lb.filePath, lb.filePath+".1"); err != nil {
-		slog.Debug("failed to rotate log file", "error", err)
+	// Rotate existing backup files (delete oldest, shift others), then rename current file to .1.
+	// Without backups the current file is truncated below.
+	shiftRotatedLogFiles(lb.filePath, lb.rotation.MaxFiles)
+	if lb.rotation.MaxFiles > 0 {
+		if err := os.Rename(lb.filePath, lb.filePath+".1"); err != nil {
+			slog.Debug("failed to rotate log file", "error", err)
+		}
 	}
 
 	// Open new file
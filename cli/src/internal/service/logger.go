@@ -3,27 +3,30 @@ package service
 
 import (
 	"fmt"
+	"hash/fnv"
+	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ServiceLogger handles multiplexed log output from multiple services.
 type ServiceLogger struct {
-	mu         sync.Mutex
-	verbose    bool
-	colors     map[string]string
-	colorIndex int
+	mu      sync.Mutex
+	verbose bool
+	noColor bool
+	colors  map[string]string
 }
 
-// ANSI color codes for service output
+// ANSI color codes for service output.
+// Red is left out so stderr lines (red prefix) stay distinguishable.
 var colorCodes = []string{
 	"\033[36m", // Cyan
 	"\033[33m", // Yellow
 	"\033[35m", // Magenta
 	"\033[32m", // Green
 	"\033[34m", // Blue
-	"\033[31m", // Red
 	"\033[96m", // Bright Cyan
 	"\033[93m", // Bright Yellow
 	"\033[95m", // Bright Magenta
@@ -31,20 +34,37 @@ var colorCodes = []string{
 }
 
 const (
-	colorReset = "\033[0m"
-	colorBold  = "\033[1m"
-	colorGray  = "\033[90m"
+	colorReset     = "\033[0m"
+	colorBold      = "\033[1m"
+	colorGray      = "\033[90m"
+	colorStderr    = "\033[2;31m" // Dim red prefix for stderr lines
+	colorBrightRed = "\033[91m"
 )
 
 // NewServiceLogger creates a new logger for service orchestration.
 func NewServiceLogger(verbose bool) *ServiceLogger {
 	return &ServiceLogger{
-		verbose:    verbose,
-		colors:     make(map[string]string),
-		colorIndex: 0,
+		verbose: verbose,
+		colors:  make(map[string]string),
 	}
 }
 
+// SetColor enables or disables ANSI colors (azd app run --no-color, or stdout is not a terminal).
+func (l *ServiceLogger) SetColor(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.noColor = !enabled
+}
+
+// paint wraps text in the given color, or returns it unchanged when colors are disabled.
+// Must be called with mutex already held.
+func (l *ServiceLogger) paint(color, text string) string {
+	if l.noColor || color == "" {
+		return text
+	}
+	return color + text + colorReset
+}
+
 // getServiceColor returns a consistent color for a service.
 func (l *ServiceLogger) getServiceColor(serviceName string) string {
 	l.mu.Lock()
@@ -53,46 +73,62 @@ func (l *ServiceLogger) getServiceColor(serviceName string) string {
 }
 
 // getServiceColorUnsafe returns a consistent color for a service without locking.
-// Must be called with mutex already held.
+// The color is derived from the service name, so a service keeps its color across runs
+// regardless of start order. Must be called with mutex already held.
 func (l *ServiceLogger) getServiceColorUnsafe(serviceName string) string {
 	if color, exists := l.colors[serviceName]; exists {
 		return color
 	}
 
-	color := colorCodes[l.colorIndex%len(colorCodes)]
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(serviceName))
+	color := colorCodes[hash.Sum32()%uint32(len(colorCodes))]
 	l.colors[serviceName] = color
-	l.colorIndex++
 
 	return color
 }
 
+// FormatOutputLine formats a line of service output for the aggregated console view:
+// "api | message", with the prefix in the service's color. Stderr lines get a dim red
+// prefix instead so they stand out.
+func (l *ServiceLogger) FormatOutputLine(serviceName string, line string, isStderr bool) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	color := l.getServiceColorUnsafe(serviceName)
+	if isStderr {
+		color = colorStderr
+	}
+	return l.paint(color, serviceName+" |") + " " + line
+}
+
+// LogOutput writes a line of service output to the console with its service prefix.
+func (l *ServiceLogger) LogOutput(serviceName string, line string, isStderr bool) {
+	fmt.Println(l.FormatOutputLine(serviceName, line, isStderr))
+}
+
 // FormatLogEntry formats a log line with service prefix and color.
 func (l *ServiceLogger) FormatLogEntry(serviceName string, message string) string {
-	timestamp := time.Now().Format("15:04:05")
-	color := l.getServiceColor(serviceName)
-
-	// Format: HH:MM:SS service-name │ message
-	return fmt.Sprintf("%s%s%s %s%-15s%s %s│%s %s",
-		colorGray, timestamp, colorReset,
-		color, serviceName, colorReset,
-		colorGray, colorReset,
-		message)
+	return l.formatEvent(serviceName, "│", colorGray, message)
 }
 
-// LogService logs a message from a specific service.
-func (l *ServiceLogger) LogService(serviceName string, message string) {
-	// Get the color first (this will lock and unlock the mutex)
-	color := l.getServiceColor(serviceName)
+// formatEvent formats an orchestration event line: HH:MM:SS service-name <marker> message.
+func (l *ServiceLogger) formatEvent(serviceName, marker, markerColor, message string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	// Format the message without calling getServiceColor again
 	timestamp := time.Now().Format("15:04:05")
-	formatted := fmt.Sprintf("%s%s%s %s%-15s%s %s│%s %s",
-		colorGray, timestamp, colorReset,
-		color, serviceName, colorReset,
-		colorGray, colorReset,
+	color := l.getServiceColorUnsafe(serviceName)
+	return fmt.Sprintf("%s %s %s %s",
+		l.paint(colorGray, timestamp),
+		l.paint(color, fmt.Sprintf("%-15s", serviceName)),
+		l.paint(markerColor, marker),
 		message)
+}
 
-	fmt.Println(formatted)
+// LogService logs a message from a specific service.
+func (l *ServiceLogger) LogService(serviceName string, message string) {
+	fmt.Println(l.FormatLogEntry(serviceName, message))
 }
 
 // LogInfo logs an informational message (no service prefix).
@@ -101,52 +137,22 @@ func (l *ServiceLogger) LogInfo(message string) {
 	defer l.mu.Unlock()
 
 	timestamp := time.Now().Format("15:04:05")
-	fmt.Printf("%s%s%s %s\n", colorGray, timestamp, colorReset, message)
+	fmt.Printf("%s %s\n", l.paint(colorGray, timestamp), message)
 }
 
 // LogSuccess logs a success message with green color.
 func (l *ServiceLogger) LogSuccess(serviceName string, message string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	timestamp := time.Now().Format("15:04:05")
-	color := l.getServiceColorUnsafe(serviceName)
-
-	fmt.Printf("%s%s%s %s%-15s%s %s✓%s %s\n",
-		colorGray, timestamp, colorReset,
-		color, serviceName, colorReset,
-		"\033[92m", colorReset,
-		message)
+	fmt.Println(l.formatEvent(serviceName, "✓", "\033[92m", message))
 }
 
 // LogError logs an error message with red color.
 func (l *ServiceLogger) LogError(serviceName string, message string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	timestamp := time.Now().Format("15:04:05")
-	color := l.getServiceColorUnsafe(serviceName)
-
-	fmt.Printf("%s%s%s %s%-15s%s %s✗%s %s\n",
-		colorGray, timestamp, colorReset,
-		color, serviceName, colorReset,
-		"\033[91m", colorReset,
-		message)
+	fmt.Println(l.formatEvent(serviceName, "✗", colorBrightRed, message))
 }
 
 // LogWarning logs a warning message with yellow color.
 func (l *ServiceLogger) LogWarning(serviceName string, message string) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	timestamp := time.Now().Format("15:04:05")
-	color := l.getServiceColorUnsafe(serviceName)
-
-	fmt.Printf("%s%s%s %s%-15s%s %s⚠%s  %s\n",
-		colorGray, timestamp, colorReset,
-		color, serviceName, colorReset,
-		"\033[93m", colorReset,
-		message)
+	fmt.Println(l.formatEvent(serviceName, "⚠", "\033[93m", " "+message))
 }
 
 // LogVerbose logs a verbose message (only if verbose mode is enabled).
@@ -168,11 +174,12 @@ func (l *ServiceLogger) LogStartup(serviceCount int) {
 
 // LogSummary logs the service URLs after startup.
 func (l *ServiceLogger) LogSummary(urls map[string]string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	fmt.Println()
-	if len(urls) > 0 {
-		for name, url := range urls {
-			fmt.Printf("  \033[32m✓\033[0m %-18s  %s\n", name, url)
-		}
+	for name, url := range urls {
+		fmt.Printf("  %s %-18s  %s\n", l.paint("\033[32m", "✓"), name, url)
 	}
 }
 
@@ -186,18 +193,46 @@ func (l *ServiceLogger) LogReady() {
 func StreamLogs(processes map[string]*ServiceProcess, logger *ServiceLogger) {
 	for name, process := range processes {
 		// Start goroutines to read stdout and stderr
-		go func(serviceName string, proc *ServiceProcess) {
-			outputChan := make(chan string, 100)
-
-			go ReadServiceOutput(proc.Stdout, outputChan)
-			go ReadServiceOutput(proc.Stderr, outputChan)
-
-			for line := range outputChan {
-				// Filter empty lines
-				if strings.TrimSpace(line) != "" {
-					logger.LogService(serviceName, line)
-				}
-			}
-		}(name, process)
+		go streamToConsole(process.Stdout, name, logger, false)
+		go streamToConsole(process.Stderr, name, logger, true)
+	}
+}
+
+// streamToConsole writes each non-empty line read from reader to the console view.
+func streamToConsole(reader io.Reader, serviceName string, logger *ServiceLogger, isStderr bool) {
+	outputChan := make(chan string, 100)
+	go func() {
+		ReadServiceOutput(reader, outputChan)
+		close(outputChan)
+	}()
+	for line := range outputChan {
+		// Filter empty lines
+		if strings.TrimSpace(line) != "" {
+			logger.LogOutput(serviceName, line, isStderr)
+		}
+	}
+}
+
+// consoleLogger receives collected service output for the aggregated console view.
+// Nil (the default) keeps service output out of the console; see SetConsoleLogger.
+var consoleLogger atomic.Pointer[ServiceLogger]
+
+// SetConsoleLogger echoes the output collected from services (see StartLogCollection) to the
+// console through logger, prefixed with the service name. Pass nil to stop echoing.
+// Log buffers and files are not affected.
+func SetConsoleLogger(logger *ServiceLogger) {
+	consoleLogger.Store(logger)
+}
+
+// echoToConsole writes a collected line to the console view, if one is set.
+// Lines the log buffer's filter drops and empty lines are skipped.
+func echoToConsole(serviceName string, line string, isStderr bool, buffer *LogBuffer) {
+	logger := consoleLogger.Load()
+	if logger == nil || strings.TrimSpace(line) == "" {
+		return
+	}
+	if buffer != nil && buffer.logFilter != nil && buffer.logFilter.ShouldFilter(line) {
+		return
 	}
+	logger.LogOutput(serviceName, line, isStderr)
 }
@@ -337,28 +337,74 @@ func TestServiceLogger_ColorConsistency(t *testing.T) {
 	}
 }
 
-func TestServiceLogger_ColorCycling(t *testing.T) {
+func TestServiceLogger_ColorsAreDeterministic(t *testing.T) {
+	// Colors depend only on the service name, not on the order services are seen in
+	first := NewServiceLogger(false)
+	second := NewServiceLogger(false)
+
+	names := make([]string, len(colorCodes)+5)
+	for i := range names {
+		names[i] = fmt.Sprintf("service-%d", i)
+	}
+	for _, name := range names {
+		_ = first.getServiceColor(name)
+	}
+	for i := len(names) - 1; i >= 0; i-- {
+		if got, want := second.getServiceColor(names[i]), first.getServiceColor(names[i]); got != want {
+			t.Errorf("getServiceColor(%q) = %q in a second logger, want %q", names[i], got, want)
+		}
+	}
+}
+
+func TestServiceLogger_FormatOutputLine(t *testing.T) {
 	logger := NewServiceLogger(false)
+	color := logger.getServiceColor("api")
 
-	// Create more services than available colors
-	serviceCount := len(colorCodes) + 5
-	colors := make(map[string]string)
+	if got, want := logger.FormatOutputLine("api", "listening", false), color+"api |"+colorReset+" listening"; got != want {
+		t.Errorf("FormatOutputLine(stdout) = %q, want %q", got, want)
+	}
+	if got, want := logger.FormatOutputLine("api", "boom", true), colorStderr+"api |"+colorReset+" boom"; got != want {
+		t.Errorf("FormatOutputLine(stderr) = %q, want %q", got, want)
+	}
 
-	for i := 0; i < serviceCount; i++ {
-		serviceName := fmt.Sprintf("service-%d", i)
-		colors[serviceName] = logger.getServiceColor(serviceName)
+	logger.SetColor(false)
+	for _, isStderr := range []bool{false, true} {
+		if got := logger.FormatOutputLine("api", "listening", isStderr); got != "api | listening" {
+			t.Errorf("FormatOutputLine(stderr=%v) without color = %q, want %q", isStderr, got, "api | listening")
+		}
+	}
+	if strings.Contains(logger.FormatLogEntry("api", "started"), "\033[") {
+		t.Error("FormatLogEntry() should not contain ANSI codes when colors are disabled")
 	}
+}
+
+func TestEchoToConsole(t *testing.T) {
+	logger := NewServiceLogger(false)
+	logger.SetColor(false)
 
-	// Verify all services got a color
-	if len(colors) != serviceCount {
-		t.Errorf("Got %d colors, want %d", len(colors), serviceCount)
+	// Without a console logger nothing is written
+	output := captureStdout(func() {
+		echoToConsole("api", "hidden", false, nil)
+	})
+	if output != "" {
+		t.Errorf("echoToConsole() without a console logger wrote %q", output)
 	}
 
-	// Verify colors repeat after running out of unique colors
-	firstServiceColor := colors["service-0"]
-	wrappedServiceColor := colors[fmt.Sprintf("service-%d", len(colorCodes))]
+	SetConsoleLogger(logger)
+	defer SetConsoleLogger(nil)
 
-	if firstServiceColor != wrappedServiceColor {
-		t.Error("Colors didn't cycle after exhausting colorCodes")
+	filter, err := NewLogFilter([]string{"healthz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer := &LogBuffer{logFilter: filter}
+	output = captureStdout(func() {
+		echoToConsole("api", "GET /healthz 200", false, buffer)
+		echoToConsole("api", "   ", false, buffer)
+		echoToConsole("api", "ready", false, buffer)
+		echoToConsole("api", "warning: slow", true, buffer)
+	})
+	if want := "api | ready\napi | warning: slow\n"; output != want {
+		t.Errorf("echoToConsole() wrote %q, want %q", output, want)
 	}
 }
@@ -0,0 +1,202 @@
+// Package service provides runtime detection and service orchestration capabilities.
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Common field names used by structured (JSON) loggers such as pino, zap, serilog and structlog.
+var (
+	jsonLogLevelKeys     = []string{"level", "severity"}
+	jsonLogMessageKeys   = []string{"msg", "message"}
+	jsonLogTimestampKeys = []string{"time", "timestamp"}
+)
+
+// NewLogEntry creates a log entry from a single line of service output.
+// JSON-formatted lines (one object per line) have their level, message and timestamp
+// extracted from common fields; all other lines fall back to keyword-based level inference.
+func NewLogEntry(serviceName, line string, isStderr bool) LogEntry {
+	entry := LogEntry{
+		Service:   serviceName,
+		Message:   line,
+		Timestamp: time.Now(),
+		IsStderr:  isStderr,
+	}
+	if !ApplyJSONFields(&entry) {
+		entry.Level = inferLogLevel(line)
+	}
+	return entry
+}
+
+// ApplyJSONFields parses the entry's message as a JSON log object and, if successful,
+// populates Fields, Level, Message and Timestamp from it.
+// Returns false and leaves the entry unchanged if the message is not a JSON object.
+func ApplyJSONFields(entry *LogEntry) bool {
+	fields, ok := parseJSONLogObject(entry.Message)
+	if !ok {
+		return false
+	}
+
+	entry.Fields = fields
+
+	if msg, ok := lookupJSONString(fields, jsonLogMessageKeys); ok {
+		entry.Message = msg
+	}
+
+	if level, ok := lookupJSONLevel(fields); ok {
+		entry.Level = level
+	} else {
+		entry.Level = inferLogLevel(entry.Message)
+	}
+
+	if ts, ok := lookupJSONTimestamp(fields); ok {
+		entry.Timestamp = ts
+	}
+
+	return true
+}
+
+// FieldString returns the string form of a structured field, or false if the field is absent.
+func (e LogEntry) FieldString(key string) (string, bool) {
+	if e.Fields == nil {
+		return "", false
+	}
+	value, exists := e.Fields[key]
+	if !exists {
+		return "", false
+	}
+	return jsonValueString(value), true
+}
+
+// parseJSONLogObject decodes a line that contains a single JSON object.
+// Numbers are preserved as json.Number so they round-trip without float formatting.
+func parseJSONLogObject(line string) (map[string]any, bool) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < 2 || trimmed[0] != '{' || trimmed[len(trimmed)-1] != '}' {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(trimmed)))
+	decoder.UseNumber()
+
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false
+	}
+	// Reject trailing content after the object
+	if decoder.More() {
+		return nil, false
+	}
+	return fields, true
+}
+
+// lookupJSONString returns the first string value found among the given keys.
+func lookupJSONString(fields map[string]any, keys []string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// lookupJSONLevel maps a level/severity field to a LogLevel.
+// Supports both string names and pino-style numeric levels.
+func lookupJSONLevel(fields map[string]any) (LogLevel, bool) {
+	for _, key := range jsonLogLevelKeys {
+		value, exists := fields[key]
+		if !exists {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			return parseJSONLevelName(v)
+		case json.Number:
+			n, err := v.Int64()
+			if err != nil {
+				return LogLevelInfo, false
+			}
+			return parseJSONLevelNumber(n), true
+		}
+	}
+	return LogLevelInfo, false
+}
+
+// parseJSONLevelName maps common level names to a LogLevel.
+func parseJSONLevelName(name string) (LogLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "error", "err", "fatal", "critical", "crit", "panic", "alert", "emergency", "emerg":
+		return LogLevelError, true
+	case "warn", "warning":
+		return LogLevelWarn, true
+	case "debug", "trace", "verbose":
+		return LogLevelDebug, true
+	case "info", "information", "informational", "notice":
+		return LogLevelInfo, true
+	default:
+		return LogLevelInfo, false
+	}
+}
+
+// parseJSONLevelNumber maps pino/bunyan numeric levels to a LogLevel.
+// 10=trace, 20=debug, 30=info, 40=warn, 50=error, 60=fatal.
+func parseJSONLevelNumber(level int64) LogLevel {
+	switch {
+	case level >= 50:
+		return LogLevelError
+	case level >= 40:
+		return LogLevelWarn
+	case level >= 30:
+		return LogLevelInfo
+	default:
+		return LogLevelDebug
+	}
+}
+
+// lookupJSONTimestamp parses a time/timestamp field.
+// Supports RFC 3339 strings and Unix epoch numbers (seconds or milliseconds).
+func lookupJSONTimestamp(fields map[string]any) (time.Time, bool) {
+	for _, key := range jsonLogTimestampKeys {
+		value, exists := fields[key]
+		if !exists {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if ts, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return ts, true
+			}
+		case json.Number:
+			if epoch, err := v.Float64(); err == nil && epoch > 0 {
+				// Values above 1e12 are milliseconds (pino default), otherwise seconds
+				if epoch > 1e12 {
+					return time.UnixMilli(int64(epoch)), true
+				}
+				return time.Unix(0, int64(epoch*float64(time.Second))), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// jsonValueString formats a decoded JSON value for display and comparison.
+func jsonValueString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
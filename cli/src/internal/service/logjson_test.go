@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewLogEntry_JSONLine(t *testing.T) {
+	line := `{"level":"error","msg":"database unreachable","time":"2024-01-15T10:30:00Z","userId":42}`
+
+	entry := NewLogEntry("api", line, false)
+
+	if entry.Level != LogLevelError {
+		t.Errorf("Level = %v, want %v", entry.Level, LogLevelError)
+	}
+	if entry.Message != "database unreachable" {
+		t.Errorf("Message = %q, want %q", entry.Message, "database unreachable")
+	}
+	want := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	if !entry.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", entry.Timestamp, want)
+	}
+	if got, ok := entry.FieldString("userId"); !ok || got != "42" {
+		t.Errorf("FieldString(userId) = %q, %v; want \"42\", true", got, ok)
+	}
+}
+
+func TestNewLogEntry_PlainLineFallsBack(t *testing.T) {
+	entry := NewLogEntry("api", "Error: connection refused", true)
+
+	if entry.Level != LogLevelError {
+		t.Errorf("Level = %v, want %v", entry.Level, LogLevelError)
+	}
+	if entry.Message != "Error: connection refused" {
+		t.Errorf("Message = %q, want raw line", entry.Message)
+	}
+	if entry.Fields != nil {
+		t.Errorf("Fields = %v, want nil for non-JSON line", entry.Fields)
+	}
+	if !entry.IsStderr {
+		t.Error("IsStderr should be preserved")
+	}
+}
+
+func TestApplyJSONFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		wantOK    bool
+		wantLevel LogLevel
+		wantMsg   string
+	}{
+		{"severity field", `{"severity":"WARNING","message":"disk almost full"}`, true, LogLevelWarn, "disk almost full"},
+		{"pino numeric level", `{"level":50,"msg":"boom"}`, true, LogLevelError, "boom"},
+		{"pino debug level", `{"level":20,"msg":"details"}`, true, LogLevelDebug, "details"},
+		{"no level infers from message", `{"msg":"unhandled exception"}`, true, LogLevelError, "unhandled exception"},
+		{"no message keeps raw line", `{"level":"info","event":"startup"}`, true, LogLevelInfo, `{"level":"info","event":"startup"}`},
+		{"invalid JSON", `{"level":"info"`, false, LogLevelInfo, `{"level":"info"`},
+		{"JSON array is not an object", `["a","b"]`, false, LogLevelInfo, `["a","b"]`},
+		{"trailing content", `{"msg":"a"} extra`, false, LogLevelInfo, `{"msg":"a"} extra`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := LogEntry{Message: tt.message}
+			ok := ApplyJSONFields(&entry)
+			if ok != tt.wantOK {
+				t.Fatalf("ApplyJSONFields() = %v, want %v", ok, tt.wantOK)
+			}
+			if entry.Level != tt.wantLevel {
+				t.Errorf("Level = %v, want %v", entry.Level, tt.wantLevel)
+			}
+			if entry.Message != tt.wantMsg {
+				t.Errorf("Message = %q, want %q", entry.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestApplyJSONFields_EpochTimestamp(t *testing.T) {
+	entry := LogEntry{Message: `{"level":30,"time":1705314600000,"msg":"ok"}`}
+	if !ApplyJSONFields(&entry) {
+		t.Fatal("expected JSON line to be parsed")
+	}
+	want := time.UnixMilli(1705314600000)
+	if !entry.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", entry.Timestamp, want)
+	}
+}
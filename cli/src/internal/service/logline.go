@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLogLineSize is the default maximum size of a single log line (1MB). Longer lines,
+	// such as JSON dumps, are truncated when service output is collected and when logs are read.
+	MaxLogLineSize = 1 * 1024 * 1024
+
+	// logReadBufferSize is the read buffer for service output; 64KB holds most lines whole.
+	logReadBufferSize = 64 * 1024
+)
+
+// ReadLogLine reads the next line from reader without its line ending, keeping at most
+// maxLineSize bytes (cut at a UTF-8 character boundary). Returns the line, the number of
+// bytes dropped from it, and io.EOF once there are no more lines.
+func ReadLogLine(reader *bufio.Reader, maxLineSize int) (string, int, error) {
+	var line []byte
+	dropped := 0
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			if err == io.EOF && (len(line) > 0 || dropped > 0) {
+				break
+			}
+			return "", 0, err
+		}
+		if room := maxLineSize - len(line); room > 0 {
+			kept := min(room, len(chunk))
+			line = append(line, chunk[:kept]...)
+			dropped += len(chunk) - kept
+		} else {
+			dropped += len(chunk)
+		}
+		if !isPrefix {
+			break
+		}
+	}
+
+	if dropped > 0 && len(line) > 0 {
+		// Drop a character whose encoding was cut
+		start := len(line) - 1
+		for start > 0 && !utf8.RuneStart(line[start]) {
+			start--
+		}
+		if !utf8.FullRune(line[start:]) {
+			dropped += len(line) - start
+			line = line[:start]
+		}
+	}
+	return string(line), dropped, nil
+}
+
+// TruncationMarker returns the marker ending a line that was truncated by dropped bytes.
+func TruncationMarker(dropped int) string {
+	return fmt.Sprintf("…[truncated %d bytes]", dropped)
+}
+
+// scanLogLines calls handle with each line of service output read from reader until it
+// ends or fails. Lines longer than MaxLogLineSize end with TruncationMarker and are reported
+// as truncated; unlike bufio.Scanner, a long line never stops the stream from being drained.
+func scanLogLines(reader io.Reader, handle func(line string, truncated bool)) {
+	buffered := bufio.NewReaderSize(reader, logReadBufferSize)
+	for {
+		line, dropped, err := ReadLogLine(buffered, MaxLogLineSize)
+		if err != nil {
+			return
+		}
+		if dropped > 0 {
+			line += TruncationMarker(dropped)
+		}
+		handle(line, dropped > 0)
+	}
+}
+
+// newOutputLogEntry creates a log entry from a line read by scanLogLines.
+func newOutputLogEntry(serviceName, line string, truncated, isStderr bool) LogEntry {
+	entry := NewLogEntry(serviceName, line, isStderr)
+	entry.Truncated = truncated
+	return entry
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadLogLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		maxLineSize int
+		wantLines   []string
+		wantDropped []int
+	}{
+		{"short lines", "one\ntwo\r\nthree", 10, []string{"one", "two", "three"}, []int{0, 0, 0}},
+		{"long line", "abcdefgh\nok\n", 5, []string{"abcde", "ok"}, []int{3, 0}},
+		{"line longer than the read buffer", strings.Repeat("x", 40) + "\nok", 20, []string{strings.Repeat("x", 20), "ok"}, []int{20, 0}},
+		{"multibyte character cut", "abécd\n", 3, []string{"ab"}, []int{4}},
+		{"multibyte character kept", "abécd\n", 4, []string{"abé"}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A small buffer makes long lines arrive in several chunks
+			reader := bufio.NewReaderSize(strings.NewReader(tt.input), 16)
+			var lines []string
+			var dropped []int
+			for {
+				line, n, err := ReadLogLine(reader, tt.maxLineSize)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("ReadLogLine() error = %v", err)
+				}
+				lines = append(lines, line)
+				dropped = append(dropped, n)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) || !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("ReadLogLine() = %q dropping %v, want %q dropping %v", lines, dropped, tt.wantLines, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestCollectContainerLogs_LongLine(t *testing.T) {
+	buffer, err := NewLogBuffer("db", 100, false, "")
+	if err != nil {
+		t.Fatalf("NewLogBuffer() error = %v", err)
+	}
+
+	long := strings.Repeat("x", MaxLogLineSize+100)
+	collectContainerLogs(io.NopCloser(strings.NewReader(long+"\nready\n")), "db", buffer)
+
+	entries := buffer.GetRecent(100)
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	if want := strings.Repeat("x", MaxLogLineSize) + TruncationMarker(100); entries[0].Message != want || !entries[0].Truncated {
+		t.Errorf("entries[0] = %d bytes (truncated %v), want the line cut at MaxLogLineSize with a marker", len(entries[0].Message), entries[0].Truncated)
+	}
+	if entries[1].Message != "ready" {
+		t.Errorf("entries[1].Message = %q, want the next line collected", entries[1].Message)
+	}
+}
@@ -2,6 +2,7 @@
 package service
 
 import (
+	"container/heap"
 	"errors"
 	"fmt"
 	"os"
@@ -16,6 +17,7 @@ type LogManager struct {
 	projectDir string
 	buffers    map[string]*LogBuffer // key: serviceName
 	logFilter  *LogFilter            // Optional log filter for all buffers
+	logFiles   map[string]string     // Services' azure.yaml logFile paths; set once on creation
 	mu         sync.RWMutex
 }
 
@@ -48,22 +50,27 @@ func GetLogManager(projectDir string) *LogManager {
 		return lm
 	}
 
+	azureYaml, err := ParseAzureYaml(filepath.Join(absPath, "azure.yaml"))
+	if err != nil {
+		// No azure.yaml or parse error - use built-in filters and default log files
+		azureYaml = nil
+	}
+
 	lm := &LogManager{
 		projectDir: absPath,
 		buffers:    make(map[string]*LogBuffer),
-		logFilter:  loadLogFilterForProject(absPath),
+		logFilter:  newProjectLogFilter(azureYaml),
+		logFiles:   projectLogFiles(azureYaml),
 	}
 	logManagers[absPath] = lm
 
 	return lm
 }
 
-// loadLogFilterForProject loads the log filter configuration from azure.yaml.
-func loadLogFilterForProject(projectDir string) *LogFilter {
-	azureYamlPath := filepath.Join(projectDir, "azure.yaml")
-	azureYaml, err := ParseAzureYaml(azureYamlPath)
-	if err != nil {
-		// No azure.yaml or parse error - use built-in filters only
+// newProjectLogFilter builds the log filter from azure.yaml's logs configuration, or only
+// the built-in filters without an azure.yaml.
+func newProjectLogFilter(azureYaml *AzureYaml) *LogFilter {
+	if azureYaml == nil {
 		filter, _ := NewLogFilterWithBuiltins(nil)
 		return filter
 	}
@@ -87,7 +94,36 @@ func loadLogFilterForProject(projectDir string) *LogFilter {
 	return filter
 }
 
-// CreateBuffer creates a log buffer for a service.
+// projectLogFiles returns the logFile paths set in azure.yaml by service name, including
+// sidecars under their expanded names. ParseAzureYaml has already resolved and validated them.
+func projectLogFiles(azureYaml *AzureYaml) map[string]string {
+	logFiles := make(map[string]string)
+	if azureYaml == nil {
+		return logFiles
+	}
+	services, err := ExpandSidecars(azureYaml.Services)
+	if err != nil {
+		services = azureYaml.Services
+	}
+	for name, svc := range services {
+		if svc.LogFile != "" {
+			logFiles[name] = svc.LogFile
+		}
+	}
+	return logFiles
+}
+
+// LogFile returns the file a service's output is logged to: its logFile from azure.yaml, or
+// .azure/logs/<service>.log.
+func (lm *LogManager) LogFile(serviceName string) string {
+	if logFile, ok := lm.logFiles[serviceName]; ok {
+		return logFile
+	}
+	return DefaultLogFile(lm.projectDir, serviceName)
+}
+
+// CreateBuffer creates a log buffer for a service. With file logging, output is also
+// written to the service's LogFile.
 func (lm *LogManager) CreateBuffer(serviceName string, maxSize int, enableFileLogging bool) (*LogBuffer, error) {
 	lm.mu.Lock()
 	defer lm.mu.Unlock()
@@ -98,7 +134,11 @@ func (lm *LogManager) CreateBuffer(serviceName string, maxSize int, enableFileLo
 	}
 
 	// Create new buffer with the log filter
-	buffer, err := NewLogBufferWithFilter(serviceName, maxSize, enableFileLogging, lm.projectDir, lm.logFilter)
+	opts := LogBufferOptions{Filter: lm.logFilter}
+	if enableFileLogging {
+		opts.FilePath = lm.LogFile(serviceName)
+	}
+	buffer, err := NewLogBufferWithOptions(serviceName, maxSize, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create log buffer for %s: %w", serviceName, err)
 	}
@@ -317,8 +357,74 @@ func (lm *LogManager) ClearBuffer(serviceName string) error {
 }
 
 // SortLogEntries sorts log entries by timestamp (ascending).
+// Entries with the same timestamp keep their order.
 func SortLogEntries(entries []LogEntry) {
-	sort.Slice(entries, func(i, j int) bool {
+	sort.SliceStable(entries, func(i, j int) bool {
 		return entries[i].Timestamp.Before(entries[j].Timestamp)
 	})
 }
+
+// MergeLogEntries merges per-service log streams into a single stream in timestamp order.
+// Entries with the same timestamp keep the order of their streams, so lines of a service
+// are never reordered; a stream that isn't in timestamp order is sorted first.
+func MergeLogEntries(streams [][]LogEntry) []LogEntry {
+	total := 0
+	for _, stream := range streams {
+		total += len(stream)
+		if !sort.SliceIsSorted(stream, func(i, j int) bool { return stream[i].Timestamp.Before(stream[j].Timestamp) }) {
+			SortLogEntries(stream)
+		}
+	}
+
+	// The remaining entries of each stream, ordered by the timestamp of their next entry
+	heads := make(logStreamHeap, 0, len(streams))
+	for i, stream := range streams {
+		if len(stream) > 0 {
+			heads = append(heads, logStreamHead{entries: stream, stream: i})
+		}
+	}
+	heap.Init(&heads)
+
+	merged := make([]LogEntry, 0, total)
+	for len(heads) > 0 {
+		head := &heads[0]
+		merged = append(merged, head.entries[0])
+		head.entries = head.entries[1:]
+		if len(head.entries) == 0 {
+			heap.Pop(&heads)
+		} else {
+			heap.Fix(&heads, 0)
+		}
+	}
+	return merged
+}
+
+// logStreamHead is the remaining entries of a stream being merged by MergeLogEntries.
+type logStreamHead struct {
+	entries []LogEntry
+	stream  int // Index of the stream, to break timestamp ties
+}
+
+// logStreamHeap is a min-heap of streams by the timestamp of their next entry.
+type logStreamHeap []logStreamHead
+
+func (h logStreamHeap) Len() int { return len(h) }
+
+func (h logStreamHeap) Less(i, j int) bool {
+	ti, tj := h[i].entries[0].Timestamp, h[j].entries[0].Timestamp
+	if ti.Equal(tj) {
+		return h[i].stream < h[j].stream
+	}
+	return ti.Before(tj)
+}
+
+func (h logStreamHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *logStreamHeap) Push(x any) { *h = append(*h, x.(logStreamHead)) }
+
+func (h *logStreamHeap) Pop() any {
+	old := *h
+	head := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return head
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -55,6 +58,44 @@ func TestLogManagerCreateBuffer(t *testing.T) {
 	}
 }
 
+func TestLogManagerLogFile(t *testing.T) {
+	projectDir := t.TempDir()
+	content := "name: test\nservices:\n  api:\n    project: .\n    logFile: tail/api.log\n  web:\n    project: .\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	lm := GetLogManager(projectDir)
+	apiLog := filepath.Join(projectDir, "tail", "api.log")
+	if got := lm.LogFile("api"); got != apiLog {
+		t.Errorf("LogFile(api) = %q, want %q", got, apiLog)
+	}
+	if got, want := lm.LogFile("web"), DefaultLogFile(lm.projectDir, "web"); got != want {
+		t.Errorf("LogFile(web) = %q, want %q", got, want)
+	}
+
+	// Output goes to the configured file, and is still buffered in memory
+	buffer, err := lm.CreateBuffer("api", 10, true)
+	if err != nil {
+		t.Fatalf("CreateBuffer() error = %v", err)
+	}
+	buffer.Add(NewLogEntry("api", "listening", false))
+	if err := buffer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(apiLog)
+	if err != nil {
+		t.Fatalf("reading %s: %v", apiLog, err)
+	}
+	if !strings.Contains(string(data), "listening") {
+		t.Errorf("log file = %q, want it to contain the entry", data)
+	}
+	if got := len(buffer.GetRecent(10)); got != 1 {
+		t.Errorf("buffered entries = %d, want 1", got)
+	}
+}
+
 func TestLogManagerGetBuffer(t *testing.T) {
 	lm := GetLogManager("/test/getbuffer")
 
@@ -321,6 +362,31 @@ func TestSortLogEntries(t *testing.T) {
 	}
 }
 
+func TestMergeLogEntries(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	merged := MergeLogEntries([][]LogEntry{
+		{{Service: "api", Timestamp: at(1), Message: "api 1"}, {Service: "api", Timestamp: at(3), Message: "api 3"}, {Service: "api", Timestamp: at(3), Message: "api 3b"}},
+		nil,
+		{{Service: "web", Timestamp: at(4), Message: "web 4"}, {Service: "web", Timestamp: at(0), Message: "web 0"}, {Service: "web", Timestamp: at(3), Message: "web 3"}},
+		{{Service: "db", Timestamp: at(2), Message: "db 2"}},
+	})
+
+	var got []string
+	for _, entry := range merged {
+		got = append(got, entry.Message)
+	}
+	want := []string{"web 0", "api 1", "db 2", "api 3", "api 3b", "web 3", "web 4"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("MergeLogEntries() = %v, want %v", got, want)
+	}
+
+	if merged := MergeLogEntries(nil); len(merged) != 0 {
+		t.Errorf("MergeLogEntries(nil) = %v, want no entries", merged)
+	}
+}
+
 func TestLogManagerConcurrency(t *testing.T) {
 	lm := GetLogManager("/test/concurrency")
 
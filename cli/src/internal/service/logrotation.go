@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// LogRotation controls when a service's log file is rotated and how many rotated files
+// are kept. The newest rotated file is service.log.1, the oldest service.log.<MaxFiles>.
+type LogRotation struct {
+	MaxSize  int64 // Size in bytes at which a log file is rotated
+	MaxFiles int   // Rotated files kept; 0 truncates the log file instead
+}
+
+// DefaultLogRotation returns the rotation used unless SetLogRotation is called.
+func DefaultLogRotation() LogRotation {
+	return LogRotation{MaxSize: MaxLogFileSize, MaxFiles: MaxLogFileBackups}
+}
+
+// logRotation is the rotation of log buffers created from now on; nil means DefaultLogRotation.
+var logRotation atomic.Pointer[LogRotation]
+
+// SetLogRotation sets how the log files of buffers created after the call are rotated.
+func SetLogRotation(rotation LogRotation) {
+	logRotation.Store(&rotation)
+}
+
+// currentLogRotation returns the rotation set by SetLogRotation, or DefaultLogRotation.
+func currentLogRotation() LogRotation {
+	if rotation := logRotation.Load(); rotation != nil {
+		return *rotation
+	}
+	return DefaultLogRotation()
+}
+
+// ParseByteSize parses a size such as "10MB", "512KB", "1.5GB" or "2048" (bytes).
+// Units are binary (1KB = 1024 bytes) and case-insensitive; the B is optional.
+func ParseByteSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(s, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 10MB, 512KB or a number of bytes)", value)
+	}
+	return int64(number * float64(multiplier)), nil
+}
+
+// RotatedLogFiles returns the rotated files of a log file that exist, oldest first
+// (service.log.3, service.log.2, service.log.1). The log file itself is not included.
+func RotatedLogFiles(logFile string) []string {
+	matches, err := filepath.Glob(logFile + ".*")
+	if err != nil {
+		return nil
+	}
+
+	indexes := make(map[string]int, len(matches))
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, logFile+"."))
+		if err != nil || index < 1 {
+			continue
+		}
+		indexes[match] = index
+		files = append(files, match)
+	}
+	sort.Slice(files, func(i, j int) bool { return indexes[files[i]] > indexes[files[j]] })
+	return files
+}
+
+// shiftRotatedLogFiles makes room for a new service.log.1: rotated files beyond maxFiles,
+// including the oldest kept one, are deleted and the rest renamed up by one. Left-over
+// files from a run with a higher limit are deleted too.
+func shiftRotatedLogFiles(logFile string, maxFiles int) {
+	for _, file := range RotatedLogFiles(logFile) {
+		index, _ := strconv.Atoi(strings.TrimPrefix(file, logFile+"."))
+		if index >= maxFiles {
+			_ = os.Remove(file)
+			continue
+		}
+		// Oldest first, so the target name is already free
+		_ = os.Rename(file, fmt.Sprintf("%s.%d", logFile, index+1))
+	}
+}
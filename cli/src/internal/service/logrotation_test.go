@@ -0,0 +1,127 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"2048", 2048, false},
+		{"512B", 512, false},
+		{"64KB", 64 << 10, false},
+		{"10MB", 10 << 20, false},
+		{"10mb", 10 << 20, false},
+		{"1.5GB", 3 << 29, false},
+		{"5 M", 5 << 20, false},
+		{"", 0, true},
+		{"MB", 0, true},
+		{"-1MB", 0, true},
+		{"10TB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseByteSize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseByteSize(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotatedLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "api.log")
+	for _, name := range []string{"api.log", "api.log.1", "api.log.10", "api.log.2", "api.log.old", "api.log.0", "api.log.1.tmp"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{logFile + ".10", logFile + ".2", logFile + ".1"}
+	if got := RotatedLogFiles(logFile); !reflect.DeepEqual(got, want) {
+		t.Errorf("RotatedLogFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestLogBufferRotation(t *testing.T) {
+	// Each line is about 60 bytes, so a 100 byte limit rotates every other line
+	writeLines := func(t *testing.T, rotation LogRotation, lines int) string {
+		t.Helper()
+		t.Cleanup(func() { logRotation.Store(nil) })
+		SetLogRotation(rotation)
+
+		dir := t.TempDir()
+		lb, err := NewLogBuffer("api", 100, true, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < lines; i++ {
+			lb.Add(LogEntry{Service: "api", Message: strings.Repeat("x", 20), Level: LogLevelInfo, Timestamp: time.Now()})
+		}
+		if err := lb.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(dir, ".azure", "logs", "api.log")
+	}
+
+	t.Run("keeps at most MaxFiles rotated files", func(t *testing.T) {
+		logFile := writeLines(t, LogRotation{MaxSize: 100, MaxFiles: 3}, 20)
+
+		want := []string{logFile + ".3", logFile + ".2", logFile + ".1"}
+		if got := RotatedLogFiles(logFile); !reflect.DeepEqual(got, want) {
+			t.Errorf("rotated files = %v, want %v", got, want)
+		}
+		for _, file := range append(want, logFile) {
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() > 200 {
+				t.Errorf("%s is %d bytes, want it rotated near 100", filepath.Base(file), info.Size())
+			}
+		}
+	})
+
+	t.Run("without rotated files the log is truncated", func(t *testing.T) {
+		logFile := writeLines(t, LogRotation{MaxSize: 100, MaxFiles: 0}, 20)
+
+		if got := RotatedLogFiles(logFile); len(got) != 0 {
+			t.Errorf("rotated files = %v, want none", got)
+		}
+		if info, err := os.Stat(logFile); err != nil || info.Size() > 200 {
+			t.Errorf("log file = %v, %v, want it truncated near 100 bytes", info, err)
+		}
+	})
+
+	t.Run("removes rotated files beyond a lower limit", func(t *testing.T) {
+		dir := t.TempDir()
+		logFile := filepath.Join(dir, "api.log")
+		for _, name := range []string{"api.log.1", "api.log.2", "api.log.3", "api.log.4"} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		shiftRotatedLogFiles(logFile, 2)
+
+		if got, want := RotatedLogFiles(logFile), []string{logFile + ".2"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("rotated files = %v, want %v", got, want)
+		}
+		if data, _ := os.ReadFile(logFile + ".2"); string(data) != "api.log.1" {
+			t.Errorf("api.log.2 contains %q, want the former api.log.1", data)
+		}
+	})
+}
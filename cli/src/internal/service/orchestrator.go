@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -22,11 +23,51 @@ type OrchestrationResult struct {
 	StartTime       time.Time
 	ReadyTime       time.Time
 	FunctionsParser *FunctionsOutputParser // Parser for Functions endpoints
+	Sidecars        map[string][]string    // Parent service name -> inline sidecar service names
+	NotReady        map[string]error       // Services that started but did not pass their health check in time
+	ShutdownOrder   [][]string             // Services grouped in the order they stop, dependents first (see ShutdownOrder)
+
+	// processMu guards Processes once services run, since restarts replace their entries
+	processMu sync.RWMutex
+}
+
+// Process returns the current process of a service, or nil if it has none.
+func (r *OrchestrationResult) Process(name string) *ServiceProcess {
+	r.processMu.RLock()
+	defer r.processMu.RUnlock()
+	return r.Processes[name]
+}
+
+// ReplaceProcess records the process that replaces a restarted service's previous one.
+// Holders of the previous *ServiceProcess keep seeing the process that stopped.
+func (r *OrchestrationResult) ReplaceProcess(name string, proc *ServiceProcess) {
+	r.processMu.Lock()
+	defer r.processMu.Unlock()
+	r.Processes[name] = proc
+}
+
+// CurrentProcesses returns a copy of Processes that restarts don't change, for iterating
+// while services may be restarted.
+func (r *OrchestrationResult) CurrentProcesses() map[string]*ServiceProcess {
+	r.processMu.RLock()
+	defer r.processMu.RUnlock()
+	processes := make(map[string]*ServiceProcess, len(r.Processes))
+	for name, proc := range r.Processes {
+		processes[name] = proc
+	}
+	return processes
 }
 
 // DefaultHealthWaitTimeout is the maximum time to wait for a service to become healthy.
 const DefaultHealthWaitTimeout = 2 * time.Minute
 
+// startService and stopAllServices start and tear down services for OrchestrateServices;
+// tests replace them to orchestrate without starting real processes.
+var (
+	startService    = startSingleService
+	stopAllServices = StopAllServices
+)
+
 // OrchestrateServices starts services in dependency order with parallel execution.
 //
 // This function orchestrates the startup of multiple services concurrently while ensuring
@@ -37,10 +78,10 @@ const DefaultHealthWaitTimeout = 2 * time.Minute
 //   - services: Map of service definitions from azure.yaml (for dependency information)
 //   - envVars: Additional environment variables (e.g., from --env-file)
 //   - logger: ServiceLogger for structured logging of orchestration events
-//   - restartContainers: If true, restart containers even if already running; if false, reuse existing running containers
+//   - opts: Container restarts and the readiness phase (see OrchestrateOptions)
 //
 // Environment Inheritance:
-// All services automatically inherit azd context from os.Environ() including:
+// Native services inherit azd context from os.Environ(), filtered by opts.EnvFilter, including:
 //   - AZD_SERVER: gRPC server address for azd extension framework communication
 //   - AZD_ACCESS_TOKEN: Authentication token for azd APIs
 //   - AZURE_*: All Azure environment variables from azd env
@@ -52,17 +93,31 @@ const DefaultHealthWaitTimeout = 2 * time.Minute
 //   - And so on...
 //   - Services within the same level start in parallel
 //
+// Readiness:
+// After each level starts, every service's health check must pass within opts.ReadyTimeout
+// before it is reported ready. A service that does not is marked failed and recorded in
+// NotReady, and a service that fails to start is recorded in Errors; the remaining services
+// still start (a degraded stack) unless opts.FailFast is set.
+//
 // Returns:
 //   - OrchestrationResult: Contains started processes, errors, and timing information
-//   - error: Non-nil if any service fails to start; all services are stopped on error
+//   - error: Non-nil if no service starts, or, with FailFast, if any service fails to start or
+//     become ready; all services are stopped on error
 //
 // Process Isolation:
 // Each service runs in a separate goroutine with panic recovery to prevent cascading failures.
-func OrchestrateServices(runtimes []*ServiceRuntime, services map[string]Service, envVars map[string]string, logger *ServiceLogger, restartContainers bool) (*OrchestrationResult, error) {
+func OrchestrateServices(runtimes []*ServiceRuntime, services map[string]Service, envVars map[string]string, logger *ServiceLogger, opts OrchestrateOptions) (*OrchestrationResult, error) {
 	result := &OrchestrationResult{
 		Processes: make(map[string]*ServiceProcess),
 		Errors:    make(map[string]error),
 		StartTime: time.Now(),
+		Sidecars:  SidecarMap(services),
+		NotReady:  make(map[string]error),
+	}
+
+	readyTimeout := opts.ReadyTimeout
+	if readyTimeout <= 0 {
+		readyTimeout = DefaultReadyTimeout
 	}
 
 	// Create a map of service name to runtime for quick lookup
@@ -82,6 +137,7 @@ func OrchestrateServices(runtimes []*ServiceRuntime, services map[string]Service
 	}
 
 	levels := TopologicalSort(graph)
+	result.ShutdownOrder = ShutdownOrder(graph)
 	if len(levels) == 0 {
 		// No services to start
 		return result, nil
@@ -117,7 +173,7 @@ func OrchestrateServices(runtimes []*ServiceRuntime, services map[string]Service
 			go func(rt *ServiceRuntime) {
 				defer wg.Done()
 
-				process, startErr := startSingleService(rt, envVars, reg, logger, projectDir, restartContainers, functionsParser)
+				process, startErr := startService(rt, envVars, reg, logger, projectDir, opts, functionsParser)
 
 				mu.Lock()
 				if startErr != nil {
@@ -145,49 +201,82 @@ func OrchestrateServices(runtimes []*ServiceRuntime, services map[string]Service
 			slog.Warn("service orchestration timeout at level",
 				slog.Int("level", levelIdx),
 				slog.Duration("timeout", DefaultServiceStartTimeout))
-			StopAllServices(result.Processes)
+			stopAllServices(result.Processes)
 			return result, fmt.Errorf("service orchestration timed out at level %d after %v", levelIdx, DefaultServiceStartTimeout)
 		}
 
 		// Check if any services failed to start in this level
-		if len(levelErrors) > 0 {
-			StopAllServices(result.Processes)
-			for name, err := range levelErrors {
-				return result, fmt.Errorf("failed to start service %s: %w", name, err)
-			}
+		if len(levelErrors) > 0 && opts.FailFast {
+			stopAllServices(result.Processes)
+			name := firstServiceName(levelErrors)
+			return result, fmt.Errorf("failed to start service %s (--fail-fast): %w", name, levelErrors[name])
+		}
+		for name, err := range levelErrors {
+			output.ItemError("%s%-15s%s failed to start: %v", output.Cyan, name, output.Reset, err)
 		}
 
-		// Wait for all services in this level to become healthy before starting next level
-		// (only if there are more levels to start)
-		if levelIdx < len(levels)-1 {
-			for serviceName, process := range levelProcesses {
-				svc, svcExists := services[serviceName]
-				if !svcExists {
-					continue
-				}
-
-				if err := waitForServiceHealthy(serviceName, process, &svc, DefaultHealthWaitTimeout); err != nil {
-					StopAllServices(result.Processes)
-					return result, fmt.Errorf("service %s failed health check: %w", serviceName, err)
-				}
-			}
-
-			slog.Debug("dependency level healthy, proceeding to next level",
-				slog.Int("level", levelIdx))
+		// Wait for all services in this level to become healthy before declaring them ready
+		// and starting the services that depend on them
+		notReady := waitForReady(levelProcesses, services, readyTimeout, reg)
+		for name, err := range notReady {
+			result.NotReady[name] = err
+		}
+		if len(notReady) > 0 && opts.FailFast {
+			stopAllServices(result.Processes)
+			name := firstServiceName(notReady)
+			return result, fmt.Errorf("service %s did not become ready within %s (--fail-fast): %w", name, readyTimeout, notReady[name])
 		}
+
+		slog.Debug("dependency level ready",
+			slog.Int("level", levelIdx),
+			slog.Int("not_ready", len(notReady)))
 	}
 
 	slog.Debug("service orchestration complete",
 		slog.Int("started", len(result.Processes)),
 		slog.Int("failed", len(result.Errors)))
 
+	// A degraded stack needs at least one running service
+	if len(result.Processes) == 0 && len(result.Errors) > 0 {
+		name := firstServiceName(result.Errors)
+		return result, fmt.Errorf("failed to start service %s: %w", name, result.Errors[name])
+	}
+
 	result.ReadyTime = time.Now()
 	return result, nil
 }
 
+// firstServiceName returns the alphabetically first service name in errs, so the error
+// reported for several failed services is deterministic.
+func firstServiceName(errs map[string]error) string {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// RegistryPorts returns the runtime's port mappings as listed in the service registry.
+func (rt *ServiceRuntime) RegistryPorts() []registry.PortInfo {
+	if len(rt.Ports) == 0 {
+		return nil
+	}
+	ports := make([]registry.PortInfo, len(rt.Ports))
+	for i, mapping := range rt.Ports {
+		ports[i] = registry.PortInfo{
+			Name:          mapping.Name,
+			HostPort:      mapping.HostPort,
+			ContainerPort: mapping.ContainerPort,
+			Protocol:      mapping.Protocol,
+		}
+	}
+	return ports
+}
+
 // startSingleService starts a single service and returns the process.
 // This is extracted from the original OrchestrateServices to be reused for level-based startup.
-func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *registry.ServiceRegistry, logger *ServiceLogger, projectDir string, restartContainers bool, functionsParser *FunctionsOutputParser) (*ServiceProcess, error) {
+func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *registry.ServiceRegistry, logger *ServiceLogger, projectDir string, opts OrchestrateOptions, functionsParser *FunctionsOutputParser) (*ServiceProcess, error) {
 	// Extract Azure URL from environment variables if available
 	azureURL := ""
 	serviceNameUpper := strings.ToUpper(rt.Name)
@@ -206,6 +295,7 @@ func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *regi
 		Name:       rt.Name,
 		ProjectDir: projectDir,
 		Port:       rt.Port,
+		Ports:      rt.RegistryPorts(),
 		URL:        serviceURL,
 		AzureURL:   azureURL,
 		Language:   rt.Language,
@@ -219,33 +309,17 @@ func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *regi
 	}
 
 	// Resolve environment variables for this service
-	// Start with os.Environ() to inherit azd context (AZD_SERVER, AZD_ACCESS_TOKEN, AZURE_*)
-	serviceEnv := make(map[string]string)
-	for _, e := range os.Environ() {
-		pair := strings.SplitN(e, "=", 2)
-		if len(pair) == 2 {
-			serviceEnv[pair[0]] = pair[1]
-		}
-	}
-	// Merge custom environment variables from --env-file
-	for k, v := range envVars {
-		serviceEnv[k] = v
-	}
-	// Merge runtime-specific env (highest priority)
-	for k, v := range rt.Env {
-		serviceEnv[k] = v
-	}
-
-	// Inject FUNCTIONS_WORKER_RUNTIME for Logic Apps if missing
-	// This prevents func CLI from prompting interactively
-	serviceEnv = InjectFunctionsWorkerRuntime(serviceEnv, rt)
+	// Start with os.Environ() to inherit azd context (AZD_SERVER, AZD_ACCESS_TOKEN, AZURE_*),
+	// filtered by --env-allow and --env-deny, and inject FUNCTIONS_WORKER_RUNTIME for Logic
+	// Apps if missing so the func CLI doesn't prompt interactively
+	serviceEnv := ResolveServiceEnvironment(rt, envVars, opts.EnvFilter)
 
 	// For container services, skip port reservation - the container may already
 	// be running on that port, and StartContainerService handles reuse logic.
 	// For native services, reserve port to prevent TOCTOU race condition.
 	if rt.Type != ServiceTypeContainer {
 		portMgr := portmanager.GetPortManager(projectDir)
-		reservation, portErr := portMgr.ReservePort(rt.Port)
+		reservation, portErr := portMgr.ReservePortWithProtocol(rt.Port, portProtocol(rt.Protocol))
 		if portErr != nil {
 			err := fmt.Errorf("port %d is no longer available (taken by another process): %w", rt.Port, portErr)
 			if regErr := reg.UpdateStatus(rt.Name, constants.StatusError); regErr != nil {
@@ -266,7 +340,16 @@ func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *regi
 	var process *ServiceProcess
 	var err error
 	if rt.Type == ServiceTypeContainer {
-		process, err = StartContainerService(rt, projectDir, restartContainers)
+		// Build the image of a Dockerfile service first, with the build output prefixed like
+		// the service's own
+		var built bool
+		built, err = BuildContainerImage(rt, projectDir, opts.Rebuild, func(line string) {
+			logger.LogOutput(rt.Name, line, false)
+		})
+		if err == nil {
+			// A freshly built image replaces the container running the previous one
+			process, err = StartContainerService(rt, projectDir, opts.RestartContainers || built)
+		}
 		if err == nil {
 			// Start container log collection
 			if logErr := StartContainerLogCollection(process, projectDir); logErr != nil {
@@ -311,15 +394,6 @@ func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *regi
 		}
 	}
 
-	// Log service URL immediately with modern formatting
-	// Only show URL for services with assigned ports (port > 0)
-	if process.Port > 0 {
-		url := fmt.Sprintf("http://localhost:%d", process.Port)
-		output.ItemSuccess("%s%-15s%s → %s", output.Cyan, rt.Name, output.Reset, url)
-	} else {
-		output.ItemSuccess("%s%-15s%s", output.Cyan, rt.Name, output.Reset)
-	}
-
 	// Update status to running
 	// Health will be determined dynamically by health checks
 	if regErr := reg.UpdateStatus(rt.Name, constants.StatusRunning); regErr != nil {
@@ -330,6 +404,26 @@ func startSingleService(rt *ServiceRuntime, envVars map[string]string, reg *regi
 	return process, nil
 }
 
+// RestartServiceProcess stops a running native service and starts it again from the same runtime,
+// registering the new process as OrchestrateServices does. Used by 'azd app run --watch'.
+func RestartServiceProcess(process *ServiceProcess, envVars map[string]string, envFilter HostEnvFilter, logger *ServiceLogger, projectDir string, functionsParser *FunctionsOutputParser) (*ServiceProcess, error) {
+	// A monitor waiting on the same process may reap it first, which makes Wait fail here;
+	// if the process really survived, the port check in startSingleService reports it
+	if err := StopServiceGraceful(process, DefaultStopTimeout); err != nil {
+		slog.Debug("error stopping service for restart",
+			slog.String("service", process.Name),
+			slog.String("error", err.Error()))
+	}
+
+	rt := process.Runtime
+	restarted, err := startSingleService(&rt, envVars, registry.GetRegistry(projectDir), logger, projectDir, OrchestrateOptions{EnvFilter: envFilter}, functionsParser)
+	if err != nil {
+		return nil, err
+	}
+	reportServiceReady(restarted, 0)
+	return restarted, nil
+}
+
 // waitForServiceHealthy waits for a service to become healthy before proceeding.
 // This is used to ensure dependencies are healthy before starting dependent services.
 func waitForServiceHealthy(name string, process *ServiceProcess, svc *Service, timeout time.Duration) error {
@@ -434,11 +528,12 @@ func GetServiceURLs(processes map[string]*ServiceProcess) map[string]string {
 	return urls
 }
 
-// ValidateOrchestration validates that all services started successfully and are ready.
+// ValidateOrchestration validates that the started services are ready.
 //
 // This function checks the orchestration result to ensure:
-//   - No errors occurred during service startup
-//   - All services transitioned to ready state
+//   - All started services transitioned to ready state
+//   - Services that failed to start (Errors) or to become ready (NotReady) were already
+//     reported by OrchestrateServices, so they leave a degraded stack rather than an error
 //
 // Parameters:
 //   - result: OrchestrationResult from OrchestrateServices
@@ -447,11 +542,11 @@ func GetServiceURLs(processes map[string]*ServiceProcess) map[string]string {
 //   - nil if all services are ready
 //   - error describing the validation failure
 func ValidateOrchestration(result *OrchestrationResult) error {
-	if len(result.Errors) > 0 {
-		return fmt.Errorf("orchestration failed with %d errors", len(result.Errors))
-	}
-
 	for name, process := range result.Processes {
+		// Services that did not become ready were already marked failed
+		if _, notReady := result.NotReady[name]; notReady {
+			continue
+		}
 		if !process.Ready {
 			return fmt.Errorf("service %s is not ready", name)
 		}
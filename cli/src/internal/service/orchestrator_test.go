@@ -1,9 +1,16 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/registry"
 )
 
 func TestOrchestrationResult(t *testing.T) {
@@ -84,7 +91,19 @@ func TestValidateOrchestration(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "service has generic error",
+			name: "service that did not become ready was already reported",
+			result: &OrchestrationResult{
+				Processes: map[string]*ServiceProcess{
+					"api": {Ready: false},
+					"web": {Ready: true},
+				},
+				Errors:   map[string]error{},
+				NotReady: map[string]error{"api": fmt.Errorf("health check failed")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "service that failed to start was already reported",
 			result: &OrchestrationResult{
 				Processes: map[string]*ServiceProcess{
 					"api": {Ready: true},
@@ -93,7 +112,7 @@ func TestValidateOrchestration(t *testing.T) {
 					"web": fmt.Errorf("failed to start"),
 				},
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name: "empty result",
@@ -115,6 +134,89 @@ func TestValidateOrchestration(t *testing.T) {
 	}
 }
 
+// fakeOrchestration replaces how OrchestrateServices starts and stops services: services
+// named in failing fail to start, the rest start with no health check to wait for.
+func fakeOrchestration(t *testing.T, failing ...string) (started *[]string, stopped *[]string) {
+	t.Helper()
+	origStart, origStop := startService, stopAllServices
+	t.Cleanup(func() { startService, stopAllServices = origStart, origStop })
+
+	var mu sync.Mutex
+	started, stopped = &[]string{}, &[]string{}
+	startService = func(rt *ServiceRuntime, _ map[string]string, _ *registry.ServiceRegistry, _ *ServiceLogger, _ string, _ OrchestrateOptions, _ *FunctionsOutputParser) (*ServiceProcess, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*started = append(*started, rt.Name)
+		if slices.Contains(failing, rt.Name) {
+			return nil, errors.New("exit status 1")
+		}
+		runtime := *rt
+		runtime.HealthCheck.Type = "none"
+		return &ServiceProcess{Name: rt.Name, Runtime: runtime, Ready: true}, nil
+	}
+	stopAllServices = func(processes map[string]*ServiceProcess) {
+		for name := range processes {
+			*stopped = append(*stopped, name)
+		}
+		slices.Sort(*stopped)
+	}
+	return started, stopped
+}
+
+func TestOrchestrateServices_StartFailure(t *testing.T) {
+	services := map[string]Service{
+		"api":    {Language: "python"},
+		"broken": {Language: "python"},
+		"web":    {Language: "js", Uses: []string{"api"}},
+	}
+	runtimes := []*ServiceRuntime{{Name: "api"}, {Name: "broken"}, {Name: "web"}}
+	logger := NewServiceLogger(false)
+
+	t.Run("degraded without fail-fast", func(t *testing.T) {
+		started, stopped := fakeOrchestration(t, "broken")
+
+		result, err := OrchestrateServices(runtimes, services, nil, logger, OrchestrateOptions{})
+		if err != nil {
+			t.Fatalf("OrchestrateServices() error = %v, want the stack to continue degraded", err)
+		}
+		if len(*started) != 3 {
+			t.Errorf("started = %v, want every service started", *started)
+		}
+		if len(*stopped) != 0 {
+			t.Errorf("stopped = %v, want nothing torn down", *stopped)
+		}
+		if result.Processes["api"] == nil || result.Processes["web"] == nil || result.Errors["broken"] == nil {
+			t.Errorf("Processes = %v, Errors = %v, want api and web running and broken failed", result.Processes, result.Errors)
+		}
+		if err := ValidateOrchestration(result); err != nil {
+			t.Errorf("ValidateOrchestration() error = %v", err)
+		}
+	})
+
+	t.Run("torn down with fail-fast", func(t *testing.T) {
+		started, stopped := fakeOrchestration(t, "broken")
+
+		_, err := OrchestrateServices(runtimes, services, nil, logger, OrchestrateOptions{FailFast: true})
+		if err == nil || !strings.Contains(err.Error(), "failed to start service broken") {
+			t.Fatalf("OrchestrateServices() error = %v, want broken to fail the run", err)
+		}
+		if !reflect.DeepEqual(*stopped, []string{"api"}) {
+			t.Errorf("stopped = %v, want the running services torn down", *stopped)
+		}
+		if slices.Contains(*started, "web") {
+			t.Errorf("started = %v, want no services started after the failure", *started)
+		}
+	})
+
+	t.Run("every service fails", func(t *testing.T) {
+		fakeOrchestration(t, "api", "broken", "web")
+
+		if _, err := OrchestrateServices(runtimes, services, nil, logger, OrchestrateOptions{}); err == nil {
+			t.Error("OrchestrateServices() error = nil, want an error when no service starts")
+		}
+	})
+}
+
 func TestStopAllServices(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -158,6 +260,27 @@ func TestTopologicalSort_NoDependencies(t *testing.T) {
 	}
 }
 
+func TestShutdownOrder(t *testing.T) {
+	// frontend -> api -> db, with a standalone worker
+	services := map[string]Service{
+		"frontend": {Host: "containerapp", Uses: []string{"api"}},
+		"api":      {Host: "containerapp", Uses: []string{"db"}},
+		"db":       {Host: "containerapp"},
+		"worker":   {Host: "containerapp"},
+	}
+
+	graph, err := BuildDependencyGraph(services, nil)
+	if err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+
+	got := ShutdownOrder(graph)
+	want := [][]string{{"frontend"}, {"api"}, {"db", "worker"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShutdownOrder() = %v, want %v", got, want)
+	}
+}
+
 func TestTopologicalSort_LinearDependency(t *testing.T) {
 	// Linear chain: frontend -> api -> db
 	services := map[string]Service{
@@ -464,3 +587,49 @@ func TestFilterGraphByServices(t *testing.T) {
 		t.Error("worker should not be in filtered graph")
 	}
 }
+
+func TestExpandDependencyClosure_Chain(t *testing.T) {
+	services := map[string]Service{
+		"web":    {Uses: []string{"api"}},
+		"api":    {Uses: []string{"db", "cache"}},
+		"db":     {},
+		"cache":  {},
+		"worker": {Uses: []string{"db"}},
+	}
+
+	closure := ExpandDependencyClosure(services, []string{"web"})
+
+	expected := []string{"api", "cache", "db", "web"}
+	if !reflect.DeepEqual(closure, expected) {
+		t.Errorf("Expected closure %v, got %v", expected, closure)
+	}
+}
+
+func TestExpandDependencyClosure_Cycle(t *testing.T) {
+	services := map[string]Service{
+		"a": {Uses: []string{"b"}},
+		"b": {Uses: []string{"c"}},
+		"c": {Uses: []string{"a"}},
+		"d": {},
+	}
+
+	closure := ExpandDependencyClosure(services, []string{"a"})
+
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(closure, expected) {
+		t.Errorf("Expected closure %v, got %v", expected, closure)
+	}
+}
+
+func TestExpandDependencyClosure_SkipsResourcesAndUnknown(t *testing.T) {
+	services := map[string]Service{
+		"api": {Uses: []string{"postgres"}}, // postgres is a resource, not a service
+	}
+
+	closure := ExpandDependencyClosure(services, []string{"api", "missing"})
+
+	expected := []string{"api"}
+	if !reflect.DeepEqual(closure, expected) {
+		t.Errorf("Expected closure %v, got %v", expected, closure)
+	}
+}
@@ -0,0 +1,289 @@
+// Package service provides runtime detection and service orchestration capabilities.
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// startTimeTolerance is how far a process's start time may be from the one recorded for it
+// while still being the same process. Start times from ps have a resolution of one second.
+const startTimeTolerance = 2 * time.Second
+
+// PidFileName is the name of the file, in the project's .azure directory, that records
+// the service processes started by 'azd app run'.
+const PidFileName = "pids.json"
+
+// PidRecord describes a service process started by an azd app run.
+type PidRecord struct {
+	Service   string    `json:"service"`
+	PID       int       `json:"pid"`
+	PGID      int       `json:"pgid,omitempty"` // Process group (Unix only)
+	OwnerPID  int       `json:"ownerPid"`       // PID of the azd app process that started the service
+	StartTime time.Time `json:"startTime"`      // Process start time from the process table, to detect PID reuse
+}
+
+// ProcessTable abstracts the operating system process table so orphan
+// detection can be tested without real processes.
+type ProcessTable interface {
+	// IsRunning reports whether a process with the given PID exists.
+	IsRunning(pid int) bool
+	// StartTime returns when the process with the given PID started.
+	StartTime(pid int) (time.Time, error)
+	// Kill forcefully terminates the process (or its process group when it leads one).
+	Kill(record PidRecord) error
+}
+
+// SystemProcessTable is the ProcessTable backed by the operating system.
+var SystemProcessTable ProcessTable = systemProcessTable{}
+
+type systemProcessTable struct{}
+
+func (systemProcessTable) IsRunning(pid int) bool {
+	return pid > 0 && processIsRunning(pid) == nil
+}
+
+func (systemProcessTable) StartTime(pid int) (time.Time, error) {
+	return processStartTime(pid)
+}
+
+func (systemProcessTable) Kill(record PidRecord) error {
+	return killProcessRecord(record)
+}
+
+// PidFile records the service processes started by azd app so they can be found
+// again if azd app is killed before it can stop them.
+type PidFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewPidFile returns the pid file for the given project directory.
+func NewPidFile(projectDir string) *PidFile {
+	return &PidFile{path: filepath.Join(projectDir, ".azure", PidFileName)}
+}
+
+// Path returns the location of the pid file.
+func (f *PidFile) Path() string {
+	return f.path
+}
+
+// Load returns the recorded processes.
+// A missing pid file yields no records.
+func (f *PidFile) Load() ([]PidRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load()
+}
+
+// Record adds the given service processes, owned by the current azd app process.
+// Container services are skipped since their lifecycle is managed by Docker.
+func (f *PidFile) Record(processes map[string]*ServiceProcess) error {
+	return f.record(SystemProcessTable, processes)
+}
+
+// record adds the given service processes with their start times from table.
+func (f *PidFile) record(table ProcessTable, processes map[string]*ServiceProcess) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	ownerPID := os.Getpid()
+	now := time.Now()
+	for name, proc := range processes {
+		if proc == nil || proc.Process == nil || proc.ContainerID != "" {
+			continue
+		}
+		startTime, err := table.StartTime(proc.Process.Pid)
+		if err != nil {
+			startTime = proc.StartTime
+			if startTime.IsZero() {
+				startTime = now
+			}
+		}
+		records = removeRecords(records, func(r PidRecord) bool { return r.Service == name })
+		records = append(records, PidRecord{
+			Service:   name,
+			PID:       proc.Process.Pid,
+			PGID:      processGroupID(proc.Process.Pid),
+			OwnerPID:  ownerPID,
+			StartTime: startTime,
+		})
+	}
+
+	return f.save(records)
+}
+
+// RemoveOwned removes the records owned by the current azd app process.
+// Called after a clean shutdown, when those processes have been stopped.
+func (f *PidFile) RemoveOwned() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	ownerPID := os.Getpid()
+	return f.save(removeRecords(records, func(r PidRecord) bool { return r.OwnerPID == ownerPID }))
+}
+
+// FindOrphans returns the recorded processes that are still running although the
+// azd app process that started them has exited. Records of processes that are no
+// longer running, or whose PID now belongs to another process, are pruned from the
+// pid file.
+func (f *PidFile) FindOrphans(table ProcessTable) ([]PidRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []PidRecord
+	kept := records[:0:0]
+	for _, r := range records {
+		if !isRecordedProcess(table, r) {
+			continue // Stale record: process exited, or its PID was reused
+		}
+		kept = append(kept, r)
+		if r.OwnerPID != os.Getpid() && !table.IsRunning(r.OwnerPID) {
+			orphans = append(orphans, r)
+		}
+	}
+
+	if len(kept) != len(records) {
+		if err := f.save(kept); err != nil {
+			return nil, err
+		}
+	}
+
+	return orphans, nil
+}
+
+// ReapOrphans kills the given orphaned processes and removes them from the pid file.
+// A process is only killed while it is still the recorded one; records whose PID was
+// reused are removed without killing anything. Processes that could not be killed stay
+// recorded and are reported in the returned error.
+func (f *PidFile) ReapOrphans(table ProcessTable, orphans []PidRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, orphan := range orphans {
+		if !isRecordedProcess(table, orphan) {
+			records = removeRecords(records, func(r PidRecord) bool {
+				return r.PID == orphan.PID && r.OwnerPID == orphan.OwnerPID
+			})
+			continue
+		}
+		if err := table.Kill(orphan); err != nil && table.IsRunning(orphan.PID) {
+			errs = append(errs, fmt.Errorf("failed to kill %s (pid %d): %w", orphan.Service, orphan.PID, err))
+			continue
+		}
+		records = removeRecords(records, func(r PidRecord) bool {
+			return r.PID == orphan.PID && r.OwnerPID == orphan.OwnerPID
+		})
+	}
+
+	if err := f.save(records); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// isRecordedProcess reports whether the process recorded by r is still running: a process
+// with its PID exists and started at the recorded time. A process whose start time can't
+// be read is not considered the recorded one, so it is never killed.
+func isRecordedProcess(table ProcessTable, r PidRecord) bool {
+	if !table.IsRunning(r.PID) {
+		return false
+	}
+	startTime, err := table.StartTime(r.PID)
+	if err != nil {
+		return false
+	}
+	diff := startTime.Sub(r.StartTime)
+	return diff <= startTimeTolerance && diff >= -startTimeTolerance
+}
+
+// load reads the pid file. Caller must hold f.mu.
+func (f *PidFile) load() ([]PidRecord, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pid file: %w", err)
+	}
+
+	var records []PidRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse pid file %s: %w", f.path, err)
+	}
+	return records, nil
+}
+
+// save writes the pid file atomically, removing it when there are no records. Caller must hold f.mu.
+func (f *PidFile) save(records []PidRecord) error {
+	if len(records) == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove pid file: %w", err)
+		}
+		return nil
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Service != records[j].Service {
+			return records[i].Service < records[j].Service
+		}
+		return records[i].PID < records[j].PID
+	})
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pid file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create pid file directory: %w", err)
+	}
+
+	tempPath := f.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	if err := os.Rename(tempPath, f.path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	return nil
+}
+
+// removeRecords returns records without the entries matching drop.
+func removeRecords(records []PidRecord, drop func(PidRecord) bool) []PidRecord {
+	result := records[:0:0]
+	for _, r := range records {
+		if !drop(r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeProcessTable is an in-memory process table for orphan tests.
+type fakeProcessTable struct {
+	running    map[int]bool
+	startTimes map[int]time.Time
+	killed     []int
+	killErr    map[int]error
+}
+
+func (t *fakeProcessTable) IsRunning(pid int) bool {
+	return t.running[pid]
+}
+
+func (t *fakeProcessTable) StartTime(pid int) (time.Time, error) {
+	if !t.running[pid] {
+		return time.Time{}, fmt.Errorf("process %d not found", pid)
+	}
+	return t.startTimes[pid], nil
+}
+
+func (t *fakeProcessTable) Kill(record PidRecord) error {
+	if err := t.killErr[record.PID]; err != nil {
+		return err
+	}
+	t.killed = append(t.killed, record.PID)
+	delete(t.running, record.PID)
+	return nil
+}
+
+func writePidFile(t *testing.T, projectDir string, records []PidRecord) *PidFile {
+	t.Helper()
+	pidFile := NewPidFile(projectDir)
+	data, err := json.Marshal(records)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(pidFile.Path()), 0700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(pidFile.Path(), data, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return pidFile
+}
+
+func recordPIDs(records []PidRecord) []int {
+	pids := make([]int, 0, len(records))
+	for _, r := range records {
+		pids = append(pids, r.PID)
+	}
+	return pids
+}
+
+func TestPidFile_FindOrphans(t *testing.T) {
+	const deadOwner, liveOwner = 1000, 2000
+	pidFile := writePidFile(t, t.TempDir(), []PidRecord{
+		{Service: "api", PID: 1001, OwnerPID: deadOwner},       // orphan
+		{Service: "web", PID: 1002, OwnerPID: deadOwner},       // exited with its owner
+		{Service: "worker", PID: 2001, OwnerPID: liveOwner},    // owner still running
+		{Service: "mine", PID: 3001, OwnerPID: os.Getpid()},    // owned by this process
+		{Service: "api-redis", PID: 1003, OwnerPID: deadOwner}, // orphan
+	})
+	table := &fakeProcessTable{running: map[int]bool{
+		1001: true, 1003: true, 2000: true, 2001: true, 3001: true,
+	}}
+
+	orphans, err := pidFile.FindOrphans(table)
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+
+	if got, want := recordPIDs(orphans), []int{1001, 1003}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOrphans() pids = %v, want %v", got, want)
+	}
+
+	// The exited process is pruned, everything else stays recorded
+	records, err := pidFile.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := recordPIDs(records), []int{1001, 1003, 3001, 2001}; !reflect.DeepEqual(got, want) {
+		t.Errorf("records after FindOrphans() = %v, want %v", got, want)
+	}
+}
+
+func TestPidFile_FindOrphans_NoPidFile(t *testing.T) {
+	pidFile := NewPidFile(t.TempDir())
+
+	orphans, err := pidFile.FindOrphans(&fakeProcessTable{})
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+	if len(orphans) != 0 {
+		t.Errorf("FindOrphans() = %v, want none", orphans)
+	}
+}
+
+func TestPidFile_ReapOrphans(t *testing.T) {
+	pidFile := writePidFile(t, t.TempDir(), []PidRecord{
+		{Service: "api", PID: 1001, PGID: 1001, OwnerPID: 1000},
+		{Service: "web", PID: 1002, OwnerPID: 1000},
+		{Service: "worker", PID: 2001, OwnerPID: 2000},
+	})
+	table := &fakeProcessTable{
+		running: map[int]bool{1001: true, 1002: true, 2000: true, 2001: true},
+		killErr: map[int]error{1002: errors.New("operation not permitted")},
+	}
+
+	orphans, err := pidFile.FindOrphans(table)
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("FindOrphans() = %v, want 2 orphans", orphans)
+	}
+
+	err = pidFile.ReapOrphans(table, orphans)
+	if err == nil {
+		t.Error("ReapOrphans() error = nil, want error for the process that could not be killed")
+	}
+
+	if !reflect.DeepEqual(table.killed, []int{1001}) {
+		t.Errorf("killed = %v, want [1001]", table.killed)
+	}
+
+	records, err := pidFile.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := recordPIDs(records), []int{1002, 2001}; !reflect.DeepEqual(got, want) {
+		t.Errorf("records after ReapOrphans() = %v, want %v", got, want)
+	}
+
+	// Once reaped, the orphan is no longer reported
+	orphans, err = pidFile.FindOrphans(table)
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+	if got := recordPIDs(orphans); !reflect.DeepEqual(got, []int{1002}) {
+		t.Errorf("FindOrphans() after reap = %v, want [1002]", got)
+	}
+}
+
+func TestPidFile_PIDReuse(t *testing.T) {
+	recorded := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pidFile := writePidFile(t, t.TempDir(), []PidRecord{
+		{Service: "api", PID: 1001, PGID: 1001, OwnerPID: 1000, StartTime: recorded},
+		{Service: "web", PID: 1002, OwnerPID: 1000, StartTime: recorded},
+	})
+	table := &fakeProcessTable{
+		running: map[int]bool{1001: true, 1002: true},
+		startTimes: map[int]time.Time{
+			1001: recorded.Add(time.Second),   // Same process, start time within tolerance
+			1002: recorded.Add(time.Hour * 2), // PID reused by an unrelated process
+		},
+	}
+
+	orphans, err := pidFile.FindOrphans(table)
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+	if got := recordPIDs(orphans); !reflect.DeepEqual(got, []int{1001}) {
+		t.Errorf("FindOrphans() pids = %v, want [1001]", got)
+	}
+
+	// The PID is reused between FindOrphans and ReapOrphans
+	table.startTimes[1001] = recorded.Add(time.Hour)
+	if err := pidFile.ReapOrphans(table, orphans); err != nil {
+		t.Fatalf("ReapOrphans() error = %v", err)
+	}
+	if len(table.killed) != 0 {
+		t.Errorf("killed = %v, want no process killed after PID reuse", table.killed)
+	}
+
+	records, err := pidFile.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("records = %+v, want mismatched records dropped", records)
+	}
+}
+
+func TestProcessStartTime(t *testing.T) {
+	startTime, err := processStartTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("processStartTime() error = %v", err)
+	}
+	if startTime.After(time.Now()) || time.Since(startTime) > 24*time.Hour {
+		t.Errorf("processStartTime() = %v, want a recent time", startTime)
+	}
+}
+
+func TestPidFile_RecordAndRemoveOwned(t *testing.T) {
+	projectDir := t.TempDir()
+	pidFile := writePidFile(t, projectDir, []PidRecord{
+		{Service: "api", PID: 1001, OwnerPID: 1000},
+		{Service: "web", PID: 1002, OwnerPID: 1000},
+	})
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("FindProcess() error = %v", err)
+	}
+	startTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	table := &fakeProcessTable{
+		running:    map[int]bool{os.Getpid(): true},
+		startTimes: map[int]time.Time{os.Getpid(): startTime},
+	}
+	err = pidFile.record(table, map[string]*ServiceProcess{
+		"api":   {Name: "api", Process: self, StartTime: startTime.Add(-time.Minute)},
+		"db":    {Name: "db", Process: self, ContainerID: "abc123"},
+		"nopid": {Name: "nopid"},
+	})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	records, err := pidFile.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("records = %+v, want api (replaced) and web", records)
+	}
+	api := records[0]
+	if api.Service != "api" || api.PID != os.Getpid() || api.OwnerPID != os.Getpid() || !api.StartTime.Equal(startTime) {
+		t.Errorf("api record = %+v, want current process as pid and owner with its OS start time", api)
+	}
+
+	if err := pidFile.RemoveOwned(); err != nil {
+		t.Fatalf("RemoveOwned() error = %v", err)
+	}
+	records, err = pidFile.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := recordPIDs(records); !reflect.DeepEqual(got, []int{1002}) {
+		t.Errorf("records after RemoveOwned() = %v, want [1002]", got)
+	}
+
+	// Removing the last records deletes the pid file
+	writePidFile(t, projectDir, []PidRecord{{Service: "api", PID: 1001, OwnerPID: os.Getpid()}})
+	if err := pidFile.RemoveOwned(); err != nil {
+		t.Fatalf("RemoveOwned() error = %v", err)
+	}
+	if _, err := os.Stat(pidFile.Path()); !os.IsNotExist(err) {
+		t.Errorf("pid file should be removed when empty, Stat() error = %v", err)
+	}
+}
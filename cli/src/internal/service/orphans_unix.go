@@ -0,0 +1,109 @@
+//go:build !windows
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clockTicksPerSecond is USER_HZ, the unit of start times in /proc/<pid>/stat.
+// It is 100 on all mainstream Linux architectures.
+const clockTicksPerSecond = 100
+
+// processGroupID returns the process group of pid, or 0 if it cannot be determined.
+func processGroupID(pid int) int {
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil {
+		return 0
+	}
+	return pgid
+}
+
+// killProcessRecord sends SIGKILL to a recorded process.
+// When the process leads its own process group the whole group is killed so
+// grandchildren (e.g. npm -> node) don't survive. Otherwise only the process is
+// killed, since its group is shared with the azd app session.
+func killProcessRecord(record PidRecord) error {
+	if record.PGID > 0 && record.PGID == record.PID && record.PGID != syscall.Getpgrp() {
+		if err := syscall.Kill(-record.PGID, syscall.SIGKILL); err != nil {
+			return fmt.Errorf("failed to kill process group %d: %w", record.PGID, err)
+		}
+		return nil
+	}
+
+	p, err := os.FindProcess(record.PID)
+	if err != nil {
+		return fmt.Errorf("process %d not found: %w", record.PID, err)
+	}
+	return p.Kill()
+}
+
+// processStartTime returns when pid started. Uses /proc where available and
+// falls back to ps on other Unix systems.
+func processStartTime(pid int) (time.Time, error) {
+	if stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		return procStartTime(stat)
+	}
+
+	// #nosec G204 -- PID is an integer read from the pid file
+	cmd := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid))
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read start time of process %d: %w", pid, err)
+	}
+	startTime, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", strings.TrimSpace(string(out)), time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse start time of process %d: %w", pid, err)
+	}
+	return startTime, nil
+}
+
+// procStartTime converts the starttime field of a /proc/<pid>/stat line to wall-clock time.
+func procStartTime(stat []byte) (time.Time, error) {
+	// The command name in field 2 may contain spaces, so split after its closing paren.
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return time.Time{}, fmt.Errorf("malformed /proc stat line")
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	// fields[0] is field 3 (state); starttime is field 22.
+	if len(fields) < 20 {
+		return time.Time{}, fmt.Errorf("malformed /proc stat line")
+	}
+	ticks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid process start time %q: %w", fields[19], err)
+	}
+	bootTime, err := systemBootTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	offset := time.Duration(ticks) * time.Second / clockTicksPerSecond
+	return bootTime.Add(offset), nil
+}
+
+// systemBootTime reads the boot time (btime) from /proc/stat.
+func systemBootTime() (time.Time, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read boot time: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "btime "); ok {
+			secs, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid boot time %q: %w", value, err)
+			}
+			return time.Unix(secs, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("boot time not found in /proc/stat")
+}
@@ -0,0 +1,45 @@
+//go:build windows
+
+package service
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// processQueryLimitedInformation is PROCESS_QUERY_LIMITED_INFORMATION, enough for GetProcessTimes.
+const processQueryLimitedInformation = 0x1000
+
+// processGroupID returns 0 on Windows, which has no process groups.
+func processGroupID(_ int) int {
+	return 0
+}
+
+// killProcessRecord kills a recorded process and its child processes.
+// Uses taskkill /T like StopServiceGraceful so children holding ports are terminated too.
+func killProcessRecord(record PidRecord) error {
+	// #nosec G204 -- PID is an integer read from the pid file
+	cmd := exec.Command("taskkill", "/F", "/T", "/PID", fmt.Sprintf("%d", record.PID))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("taskkill failed for process %d: %w", record.PID, err)
+	}
+	return nil
+}
+
+// processStartTime returns when pid started, using GetProcessTimes.
+func processStartTime(pid int) (time.Time, error) {
+	// #nosec G115 -- PIDs fit in uint32 on Windows
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+	defer func() { _ = syscall.CloseHandle(handle) }()
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read start time of process %d: %w", pid, err)
+	}
+	return time.Unix(0, creation.Nanoseconds()), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/jongio/azd-app/cli/src/internal/detector"
 	"github.com/jongio/azd-app/cli/src/internal/security"
@@ -38,26 +39,56 @@ func ParseAzureYaml(workingDir string) (*AzureYaml, error) {
 	// Parse YAML
 	var azureYaml AzureYaml
 	if err := yaml.Unmarshal(data, &azureYaml); err != nil {
-		return nil, fmt.Errorf("failed to parse azure.yaml: %w", err)
+		return nil, fmt.Errorf("failed to parse %s: %w", azureYamlPath, err)
 	}
 
-	// Resolve relative paths in service projects
+	// Resolve relative paths in service projects and log files
 	azureYamlDir := filepath.Dir(azureYamlPath)
 	for name, svc := range azureYaml.Services {
 		if svc.Project != "" {
-			// Convert relative path to absolute
-			if !filepath.IsAbs(svc.Project) {
-				// Clean the path and join with azure.yaml directory
-				absPath := filepath.Clean(filepath.Join(azureYamlDir, svc.Project))
-				svc.Project = absPath
-				azureYaml.Services[name] = svc
-			}
+			// Convert relative path to absolute, accepting either separator
+			svc.Project = ResolveProjectPath(azureYamlDir, svc.Project)
 		}
+		if err := resolveLogFiles(azureYamlDir, name, &svc); err != nil {
+			return nil, fmt.Errorf("%s: %w", azureYamlPath, err)
+		}
+		normalizeHealthcheckType(&svc)
+		azureYaml.Services[name] = svc
 	}
 
 	return &azureYaml, nil
 }
 
+// resolveLogFiles resolves the logFile of a service and its sidecars to absolute paths,
+// rejecting paths outside the project.
+func resolveLogFiles(azureYamlDir, name string, svc *Service) error {
+	if svc.LogFile != "" {
+		logFile, err := ResolveLogFile(azureYamlDir, svc.LogFile)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+		svc.LogFile = logFile
+	}
+	for sidecarName, sidecar := range svc.Sidecars {
+		if err := resolveLogFiles(azureYamlDir, SidecarServiceName(name, sidecarName), &sidecar); err != nil {
+			return err
+		}
+		svc.Sidecars[sidecarName] = sidecar
+	}
+	return nil
+}
+
+// normalizeHealthcheckType spells the WebSocket healthcheck type of a service and its sidecars
+// the way health checks compare it: "websocket" and "ws" in any case become "ws".
+func normalizeHealthcheckType(svc *Service) {
+	if svc.Healthcheck != nil && (strings.EqualFold(svc.Healthcheck.Type, HealthCheckTypeWebSocket) || strings.EqualFold(svc.Healthcheck.Type, "websocket")) {
+		svc.Healthcheck.Type = HealthCheckTypeWebSocket
+	}
+	for _, sidecar := range svc.Sidecars {
+		normalizeHealthcheckType(&sidecar)
+	}
+}
+
 // FilterServices returns only the services specified in the filter.
 // If filter is empty, returns all services.
 // Returns empty map if azureYaml is nil.
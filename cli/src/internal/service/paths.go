@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// caseInsensitivePaths is true where file paths are compared without regard to case.
+var caseInsensitivePaths = runtime.GOOS == "windows"
+
+// NormalizeSeparators converts both forward slashes and backslashes in an azure.yaml path to
+// the OS separator, so "project: .\api" and "project: ./api" name the same directory on
+// every platform.
+func NormalizeSeparators(path string) string {
+	return filepath.FromSlash(strings.ReplaceAll(path, `\`, "/"))
+}
+
+// ResolveProjectPath resolves a service's azure.yaml project path against the directory
+// containing azure.yaml, accepting either separator. Absolute paths are only cleaned.
+func ResolveProjectPath(azureYamlDir, project string) string {
+	project = NormalizeSeparators(project)
+	if filepath.IsAbs(project) {
+		return filepath.Clean(project)
+	}
+	return filepath.Clean(filepath.Join(azureYamlDir, project))
+}
+
+// PathKey returns a key under which equal paths compare equal: separators are normalized,
+// the path is cleaned, and on Windows it is lower-cased.
+func PathKey(path string) string {
+	key := filepath.Clean(NormalizeSeparators(path))
+	if caseInsensitivePaths {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// IsSubpath reports whether path is parent or inside it, comparing them with PathKey.
+func IsSubpath(path, parent string) bool {
+	rel, err := filepath.Rel(PathKey(parent), PathKey(path))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// DefaultLogFile returns the file a service's output is logged to without a logFile in
+// azure.yaml: .azure/logs/<service>.log in the project directory.
+func DefaultLogFile(projectDir, serviceName string) string {
+	return filepath.Join(projectDir, ".azure", "logs", serviceName+".log")
+}
+
+// logFileLinePattern matches the start of a line written to a service log file.
+var logFileLinePattern = regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}\] \[`)
+
+// ResolveLogFile resolves a service's azure.yaml logFile against the directory containing
+// azure.yaml like ResolveServiceDir, returning an absolute path. Paths outside that
+// directory are rejected, so a malicious azure.yaml can't have logs written elsewhere,
+// and so are existing files that aren't service logs (e.g. azure.yaml or source files),
+// which logging would otherwise append to.
+func ResolveLogFile(azureYamlDir, logFile string) (string, error) {
+	azureYamlDirAbs, err := filepath.Abs(azureYamlDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve azure.yaml directory: %w", err)
+	}
+	logFileAbs, err := filepath.Abs(ResolveProjectPath(azureYamlDir, logFile))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve logFile '%s': %w", logFile, err)
+	}
+	if !IsSubpath(logFileAbs, azureYamlDirAbs) || PathKey(logFileAbs) == PathKey(azureYamlDirAbs) {
+		return "", fmt.Errorf("logFile '%s' must be a file within the project", logFile)
+	}
+	if info, err := os.Stat(logFileAbs); err == nil && !isServiceLogFile(logFileAbs, info) {
+		return "", fmt.Errorf("logFile '%s' is an existing file that isn't a service log", logFile)
+	}
+	return logFileAbs, nil
+}
+
+// isServiceLogFile reports whether the existing file at path can be logged to: an empty file
+// or one whose first line was written by a service log.
+func isServiceLogFile(path string, info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if info.Size() == 0 {
+		return true
+	}
+	// #nosec G304 -- path was checked to be within the project
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	line, _ := bufio.NewReader(file).ReadString('\n')
+	return logFileLinePattern.MatchString(line)
+}
+
+// ResolveServiceDir resolves a service's azure.yaml project path like ResolveProjectPath and
+// returns it as an absolute path. Paths that escape the directory containing azure.yaml are
+// rejected, so a malicious azure.yaml can't point a service at files elsewhere.
+func ResolveServiceDir(azureYamlDir, project string) (string, error) {
+	azureYamlDirAbs, err := filepath.Abs(azureYamlDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve azure.yaml directory: %w", err)
+	}
+	projectDirAbs, err := filepath.Abs(ResolveProjectPath(azureYamlDir, project))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project path '%s': %w", project, err)
+	}
+	if !IsSubpath(projectDirAbs, azureYamlDirAbs) {
+		return "", fmt.Errorf("project path '%s' escapes project boundary", project)
+	}
+	return projectDirAbs, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveProjectPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "app")
+	api := filepath.Join(root, "services", "api")
+
+	tests := []struct {
+		name    string
+		project string
+		want    string
+	}{
+		{"forward slashes", "./services/api", api},
+		{"backslashes", `.\services\api`, api},
+		{"mixed separators", `services\api/`, api},
+		{"parent segments", `.\services\web\..\api`, api},
+		{"current directory", ".", root},
+		{"absolute path", api, api},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveProjectPath(root, tt.project); got != tt.want {
+				t.Errorf("ResolveProjectPath(%q) = %q, want %q", tt.project, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathKey(t *testing.T) {
+	original := caseInsensitivePaths
+	defer func() { caseInsensitivePaths = original }()
+
+	caseInsensitivePaths = false
+	if PathKey(`app\API`) != PathKey("app/API") {
+		t.Errorf("Expected separators to be normalized, got %q and %q", PathKey(`app\API`), PathKey("app/API"))
+	}
+	if PathKey("app/API") == PathKey("app/api") {
+		t.Error("Expected case to matter where paths are case-sensitive")
+	}
+
+	caseInsensitivePaths = true
+	if PathKey(`App\API`) != PathKey("app/api") {
+		t.Errorf("Expected case to be ignored where paths are case-insensitive, got %q and %q", PathKey(`App\API`), PathKey("app/api"))
+	}
+}
+
+func TestIsSubpath(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		parent string
+		want   bool
+	}{
+		{"same directory", "app/api", "app/api", true},
+		{"subdirectory", `app\api\src`, "app/api", true},
+		{"sibling with shared prefix", "app/api2", "app/api", false},
+		{"parent", "app", "app/api", false},
+		{"dot-dot named directory", "app/..api", "app", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSubpath(tt.path, tt.parent); got != tt.want {
+				t.Errorf("IsSubpath(%q, %q) = %v, want %v", tt.path, tt.parent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAzureYaml_BackslashProjectPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "name: test\nservices:\n  api:\n    project: .\\src\\api\n    language: python\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	azureYaml, err := ParseAzureYaml(tmpDir)
+	if err != nil {
+		t.Fatalf("ParseAzureYaml() error = %v", err)
+	}
+	want := filepath.Join(tmpDir, "src", "api")
+	if got := azureYaml.Services["api"].Project; got != want {
+		t.Errorf("Project = %q, want %q", got, want)
+	}
+}
+
+func TestResolveLogFile(t *testing.T) {
+	projectDir := t.TempDir()
+	for name, content := range map[string]string{
+		"azure.yaml":   "name: app\n",
+		"existing.log": "[2024-01-15 10:00:01.000] [INFO] [OUT] started\n",
+		"empty.log":    "",
+	} {
+		if err := os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(projectDir, "src"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	const boundaryErr, notLogErr = "must be a file within the project", "isn't a service log"
+	tests := []struct {
+		name    string
+		logFile string
+		want    string
+		wantErr string
+	}{
+		{name: "relative", logFile: "logs/api.log", want: filepath.Join(projectDir, "logs", "api.log")},
+		{name: "backslashes", logFile: `logs\api.log`, want: filepath.Join(projectDir, "logs", "api.log")},
+		{name: "absolute within project", logFile: filepath.Join(projectDir, "api.log"), want: filepath.Join(projectDir, "api.log")},
+		{name: "existing log", logFile: "existing.log", want: filepath.Join(projectDir, "existing.log")},
+		{name: "existing empty file", logFile: "empty.log", want: filepath.Join(projectDir, "empty.log")},
+		{name: "escapes project", logFile: "../api.log", wantErr: boundaryErr},
+		{name: "absolute outside project", logFile: filepath.Join(filepath.Dir(projectDir), "api.log"), wantErr: boundaryErr},
+		{name: "project directory", logFile: ".", wantErr: boundaryErr},
+		{name: "existing non-log file", logFile: "azure.yaml", wantErr: notLogErr},
+		{name: "existing directory", logFile: "src", wantErr: notLogErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveLogFile(projectDir, tt.logFile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ResolveLogFile(%q) error = %v, want %q", tt.logFile, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveLogFile(%q) error = %v", tt.logFile, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveLogFile(%q) = %q, want %q", tt.logFile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAzureYaml_LogFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "name: test\nservices:\n  api:\n    project: ./api\n    logFile: logs/api.log\n    sidecars:\n      cache:\n        image: redis:7\n        logFile: logs/cache.log\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	azureYaml, err := ParseAzureYaml(tmpDir)
+	if err != nil {
+		t.Fatalf("ParseAzureYaml() error = %v", err)
+	}
+	api := azureYaml.Services["api"]
+	if want := filepath.Join(tmpDir, "logs", "api.log"); api.LogFile != want {
+		t.Errorf("LogFile = %q, want %q", api.LogFile, want)
+	}
+	if want := filepath.Join(tmpDir, "logs", "cache.log"); api.Sidecars["cache"].LogFile != want {
+		t.Errorf("sidecar LogFile = %q, want %q", api.Sidecars["cache"].LogFile, want)
+	}
+
+	content = "name: test\nservices:\n  api:\n    project: ./api\n    logFile: ../../etc/api.log\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "azure.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAzureYaml(tmpDir); err == nil || !strings.Contains(err.Error(), "service api") {
+		t.Errorf("ParseAzureYaml() error = %v, want a logFile error for service api", err)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RunPlan is what azd app run would do without starting anything: the services to start,
+// grouped into dependency levels in start order, with their resolved runtimes.
+type RunPlan struct {
+	Levels   [][]string       `json:"levels"`
+	Services []PlannedService `json:"services"`
+}
+
+// PlannedService is the resolved runtime of one service in a RunPlan. Secret environment
+// values are masked, as in a RunSnapshot.
+type PlannedService struct {
+	RuntimeSnapshot
+	Level     int      `json:"level"`               // Index into RunPlan.Levels
+	DependsOn []string `json:"dependsOn,omitempty"` // The services in 'uses'
+}
+
+// NewRunPlan orders the runtimes the way OrchestrateServices starts them. services must
+// include every azure.yaml service (with sidecars expanded), so dependencies on services
+// that are not being run still resolve; those are listed in DependsOn but not planned.
+func NewRunPlan(runtimes []*ServiceRuntime, services map[string]Service) (*RunPlan, error) {
+	graph, err := BuildDependencyGraph(services, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	runtimeMap := make(map[string]*ServiceRuntime, len(runtimes))
+	for _, rt := range runtimes {
+		runtimeMap[rt.Name] = rt
+	}
+
+	plan := &RunPlan{Levels: [][]string{}, Services: make([]PlannedService, 0, len(runtimes))}
+	for _, levelServices := range TopologicalSort(graph) {
+		var level []string
+		for _, name := range levelServices {
+			rt, ok := runtimeMap[name]
+			if !ok {
+				continue
+			}
+			level = append(level, name)
+
+			dependsOn := slices.Clone(GetServiceDependencies(name, graph))
+			slices.Sort(dependsOn)
+			plan.Services = append(plan.Services, PlannedService{
+				RuntimeSnapshot: newRuntimeSnapshot(rt, services[name]),
+				Level:           len(plan.Levels),
+				DependsOn:       dependsOn,
+			})
+		}
+		// Levels whose services are all filtered out start nothing
+		if len(level) > 0 {
+			plan.Levels = append(plan.Levels, level)
+		}
+	}
+	return plan, nil
+}
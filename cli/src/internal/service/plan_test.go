@@ -0,0 +1,64 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRunPlan(t *testing.T) {
+	services := map[string]Service{
+		"db":     {Image: "postgres:16", Ports: []string{"5432"}},
+		"cache":  {Image: "redis:7", Ports: []string{"6379"}},
+		"api":    {Project: "./api", Uses: []string{"db", "cache"}, Environment: Environment{"DB_PASSWORD": "hunter2"}},
+		"web":    {Project: "./web", Uses: []string{"api"}},
+		"worker": {Project: "./worker", Uses: []string{"db"}},
+	}
+	// worker is filtered out of the run
+	runtimes := []*ServiceRuntime{
+		{Name: "web", Command: "npm", Args: []string{"run", "dev"}, Port: 3000, HealthCheck: HealthCheckConfig{Type: "http", Path: "/"}},
+		{Name: "api", Command: "python", Args: []string{"main.py"}, Port: 8000, Env: map[string]string{"DB_PASSWORD": "hunter2"}},
+		{Name: "db", Command: "postgres:16", Port: 5432, HealthCheck: HealthCheckConfig{Type: "port", Port: 5432}},
+		{Name: "cache", Command: "redis:7", Port: 6379},
+	}
+
+	plan, err := NewRunPlan(runtimes, services)
+	if err != nil {
+		t.Fatalf("NewRunPlan() error = %v", err)
+	}
+
+	wantLevels := [][]string{{"cache", "db"}, {"api"}, {"web"}}
+	if !reflect.DeepEqual(plan.Levels, wantLevels) {
+		t.Errorf("Levels = %v, want %v", plan.Levels, wantLevels)
+	}
+
+	var order []string
+	for _, svc := range plan.Services {
+		order = append(order, svc.Name)
+	}
+	if want := []string{"cache", "db", "api", "web"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("service order = %v, want %v", order, want)
+	}
+
+	api := plan.Services[2]
+	if api.Level != 1 || !reflect.DeepEqual(api.DependsOn, []string{"cache", "db"}) {
+		t.Errorf("api level = %d, dependsOn = %v, want 1, [cache db]", api.Level, api.DependsOn)
+	}
+	if api.Command != "python" || !reflect.DeepEqual(api.Args, []string{"main.py"}) || api.Port != 8000 {
+		t.Errorf("api runtime = %+v", api.RuntimeSnapshot)
+	}
+	if api.Env["DB_PASSWORD"] == "hunter2" {
+		t.Error("plan should mask secret environment values")
+	}
+	if web := plan.Services[3]; web.HealthCheck.Type != "http" || web.HealthCheck.Path != "/" {
+		t.Errorf("web health check = %+v", web.HealthCheck)
+	}
+}
+
+func TestNewRunPlan_InvalidDependency(t *testing.T) {
+	services := map[string]Service{
+		"api": {Project: "./api", Uses: []string{"missing"}},
+	}
+	if _, err := NewRunPlan([]*ServiceRuntime{{Name: "api"}}, services); err == nil {
+		t.Error("NewRunPlan() should fail when a service uses an unknown service")
+	}
+}
@@ -26,6 +26,7 @@ import (
 //   - "3000:8080/tcp"           - Port mapping with explicit TCP protocol
 //   - "[::1]:3000:8080"         - IPv6 address binding (brackets required)
 //   - "::1:3000:8080"           - IPv6 address binding (alternative format)
+//   - "gateway=8081:8081"       - Any of the above, named (e.g. for the dashboard and info)
 //
 // Parameters:
 //   - spec: The port specification string
@@ -33,7 +34,7 @@ import (
 //     If false, single ports mean both host and container use the same port.
 //
 // Returns:
-//   - PortMapping with HostPort, ContainerPort, BindIP, and Protocol fields.
+//   - PortMapping with Name, HostPort, ContainerPort, BindIP, and Protocol fields.
 //     HostPort of 0 means auto-assign (only in Docker mode with single port spec).
 //
 // Examples:
@@ -56,6 +57,21 @@ import (
 func ParsePortSpec(spec string, isDocker bool) PortMapping {
 	spec = strings.TrimSpace(spec)
 
+	// Handle name prefix (e.g., "gateway=8081:8081")
+	name := ""
+	if before, after, found := strings.Cut(spec, "="); found {
+		name = strings.TrimSpace(before)
+		spec = strings.TrimSpace(after)
+	}
+
+	mapping := parseUnnamedPortSpec(spec, isDocker)
+	mapping.Name = name
+	return mapping
+}
+
+// parseUnnamedPortSpec parses a port specification without a name prefix; see ParsePortSpec.
+func parseUnnamedPortSpec(spec string, isDocker bool) PortMapping {
+
 	// Handle protocol suffix (e.g., "8080/udp")
 	protocol := "tcp"
 	if strings.Contains(spec, "/") {
@@ -165,6 +181,42 @@ func ParsePortSpec(spec string, isDocker bool) PortMapping {
 	return PortMapping{Protocol: protocol}
 }
 
+// resolvePortMappings returns every port mapping a service declares, with host ports resolved:
+// the first (primary) mapping gets the service's assigned primary port, and the others keep
+// their declared host port or, when Docker would assign one, the first free port from their
+// container port. Every host port is recorded in usedPorts; a declared host port another
+// service already uses is an error.
+func resolvePortMappings(serviceName string, service Service, primaryPort int, usedPorts map[int]bool) ([]PortMapping, error) {
+	mappings, _ := service.GetPortMappings()
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+
+	mappings[0].HostPort = primaryPort
+	if mappings[0].ContainerPort == 0 {
+		mappings[0].ContainerPort = primaryPort
+	}
+	usedPorts[primaryPort] = true
+
+	// The same host port may be declared once per protocol (e.g., "53" and "53/udp")
+	own := map[int]bool{primaryPort: true}
+	for i := 1; i < len(mappings); i++ {
+		mapping := &mappings[i]
+		if mapping.HostPort == 0 {
+			port, err := findAvailablePort(mapping.ContainerPort, usedPorts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to assign host port for container port %d: %w", mapping.ContainerPort, err)
+			}
+			mapping.HostPort = port
+		} else if usedPorts[mapping.HostPort] && !own[mapping.HostPort] {
+			return nil, fmt.Errorf("port %d of service %s is already used by another service", mapping.HostPort, serviceName)
+		}
+		own[mapping.HostPort] = true
+		usedPorts[mapping.HostPort] = true
+	}
+	return mappings, nil
+}
+
 // DetectPort attempts to detect the port for a service using multiple strategies.
 //
 // Port Detection Priority (highest to lowest):
@@ -235,7 +287,7 @@ func DetectPort(serviceName string, service Service, projectDir string, framewor
 // detectPortFromFrameworkConfig reads framework-specific config files to find the port.
 func detectPortFromFrameworkConfig(projectDir string, framework string) (int, error) {
 	switch framework {
-	case "Next.js", "React", "Vue", "Angular", "Express", "NestJS":
+	case "Next.js", "React", "Vue", "Vite", "SvelteKit", "Remix", "Angular", "Express", "NestJS":
 		return detectPortFromPackageJSON(projectDir)
 	case "ASP.NET Core", "Aspire":
 		return detectPortFromLaunchSettings(projectDir)
@@ -417,10 +469,12 @@ func getFrameworkDefaultPort(framework string, language string) int {
 		"Next.js":      3000,
 		"React":        5173,
 		"Vue":          5173,
+		"Vite":         5173,
 		"Angular":      4200,
 		"Express":      3000,
 		"NestJS":       3000,
 		"Svelte":       5173,
+		"SvelteKit":    5173,
 		"Astro":        4321,
 		"Remix":        3000,
 		"Nuxt":         3000,
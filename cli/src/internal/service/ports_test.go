@@ -1,7 +1,10 @@
 package service
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/docker"
 )
 
 func TestParsePortSpec(t *testing.T) {
@@ -13,6 +16,7 @@ func TestParsePortSpec(t *testing.T) {
 		wantContainer int
 		wantBindIP    string
 		wantProtocol  string
+		wantName      string
 	}{
 		{
 			name:          "single port - non-Docker",
@@ -97,6 +101,25 @@ func TestParsePortSpec(t *testing.T) {
 			wantContainer: 8080,
 			wantProtocol:  "tcp",
 		},
+		{
+			name:          "named mapping",
+			spec:          "gateway=8081:8081",
+			isDocker:      false,
+			wantHost:      8081,
+			wantContainer: 8081,
+			wantProtocol:  "tcp",
+			wantName:      "gateway",
+		},
+		{
+			name:          "named IPv6 binding with protocol",
+			spec:          "dns = [::1]:5353:53/udp",
+			isDocker:      true,
+			wantBindIP:    "::1",
+			wantHost:      5353,
+			wantContainer: 53,
+			wantProtocol:  "udp",
+			wantName:      "dns",
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +138,9 @@ func TestParsePortSpec(t *testing.T) {
 			if mapping.Protocol != tt.wantProtocol {
 				t.Errorf("Protocol = %q, want %q", mapping.Protocol, tt.wantProtocol)
 			}
+			if mapping.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", mapping.Name, tt.wantName)
+			}
 		})
 	}
 }
@@ -363,3 +389,93 @@ func TestDetectPortWithPortsArray(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvePortMappings(t *testing.T) {
+	svc := Service{
+		Type:   ServiceTypeContainer,
+		Docker: &DockerConfig{Path: "./Dockerfile"},
+		Ports:  []string{"8081", "gateway=10250:10250", "dns=53/udp", "53"},
+	}
+
+	// 53 is taken by another service, and the container's TCP 53 moves to the next free port
+	usedPorts := map[int]bool{53: true}
+	mappings, err := resolvePortMappings("cosmos", svc, 18081, usedPorts)
+	if err != nil {
+		t.Fatalf("resolvePortMappings() error = %v", err)
+	}
+
+	if len(mappings) != 4 {
+		t.Fatalf("resolvePortMappings() = %+v, want 4 mappings", mappings)
+	}
+	if mappings[0].HostPort != 18081 || mappings[0].ContainerPort != 8081 {
+		t.Errorf("primary mapping = %+v, want host 18081 for container port 8081", mappings[0])
+	}
+	if mappings[1] != (PortMapping{Name: "gateway", HostPort: 10250, ContainerPort: 10250, Protocol: "tcp"}) {
+		t.Errorf("named mapping = %+v", mappings[1])
+	}
+	if mappings[2].HostPort == 0 || mappings[2].HostPort == 53 || mappings[3].HostPort == 0 || mappings[3].HostPort == 53 {
+		t.Errorf("auto-assigned host ports = %d, %d, want free ports other than 53", mappings[2].HostPort, mappings[3].HostPort)
+	}
+	for _, m := range mappings {
+		if !usedPorts[m.HostPort] {
+			t.Errorf("host port %d not recorded in usedPorts", m.HostPort)
+		}
+	}
+
+	// A declared host port another service already uses is a collision
+	native := Service{Ports: []string{"3000", "9229"}}
+	if _, err := resolvePortMappings("api", native, 3000, map[int]bool{9229: true}); err == nil {
+		t.Error("resolvePortMappings() should fail when another service uses a declared port")
+	}
+
+	// The same port for TCP and UDP is not a collision
+	dns := Service{Ports: []string{"53", "53/udp"}}
+	if _, err := resolvePortMappings("dns", dns, 53, map[int]bool{}); err != nil {
+		t.Errorf("resolvePortMappings() for TCP and UDP on one port error = %v", err)
+	}
+
+	// Services without ports have no mappings
+	if mappings, err := resolvePortMappings("worker", Service{}, 0, map[int]bool{}); err != nil || mappings != nil {
+		t.Errorf("resolvePortMappings() without ports = %v, %v, want nil, nil", mappings, err)
+	}
+}
+
+func TestServiceRuntimeHostPorts(t *testing.T) {
+	rt := &ServiceRuntime{
+		Port: 8081,
+		Ports: []PortMapping{
+			{HostPort: 8081, ContainerPort: 8081},
+			{HostPort: 53, ContainerPort: 53},
+			{HostPort: 53, ContainerPort: 53, Protocol: "udp"},
+		},
+	}
+	if got, want := rt.HostPorts(), []int{8081, 53}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HostPorts() = %v, want %v", got, want)
+	}
+	if got := (&ServiceRuntime{}).HostPorts(); got != nil {
+		t.Errorf("HostPorts() without ports = %v, want nil", got)
+	}
+}
+
+func TestBuildContainerPortMappings(t *testing.T) {
+	rt := &ServiceRuntime{
+		Port: 3000,
+		Ports: []PortMapping{
+			{HostPort: 3000, ContainerPort: 8080, Protocol: "tcp"},
+			{Name: "debug", HostPort: 9229, ContainerPort: 9229, Protocol: "tcp", BindIP: "127.0.0.1"},
+		},
+	}
+	want := []docker.PortMapping{
+		{HostPort: 3000, ContainerPort: 8080, Protocol: "tcp"},
+		{HostPort: 9229, ContainerPort: 9229, Protocol: "tcp", BindIP: "127.0.0.1"},
+	}
+	if got := buildContainerPortMappings(rt); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildContainerPortMappings() = %+v, want %+v", got, want)
+	}
+
+	// Runtimes cached before port mappings were recorded publish their primary port
+	want = []docker.PortMapping{{HostPort: 3000, ContainerPort: 3000, Protocol: "tcp"}}
+	if got := buildContainerPortMappings(&ServiceRuntime{Port: 3000}); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildContainerPortMappings() without mappings = %+v, want %+v", got, want)
+	}
+}
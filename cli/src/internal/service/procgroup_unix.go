@@ -0,0 +1,27 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command in its own process group, so a Ctrl+C in the
+// terminal reaches azd app run only and services are stopped in order by it.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// signalProcessGroup sends sig to the process group led by process, so children such as
+// npm -> node receive it too. Falls back to the process alone when it doesn't lead a group.
+func signalProcessGroup(process *os.Process, sig syscall.Signal) error {
+	if err := syscall.Kill(-process.Pid, sig); err == nil {
+		return nil
+	}
+	return process.Signal(sig)
+}
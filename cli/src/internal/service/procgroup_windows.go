@@ -0,0 +1,23 @@
+//go:build windows
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command in a new process group, so a Ctrl+C in the console
+// reaches azd app run only and services are stopped in order by it.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// signalProcessGroup signals process only: Windows services are stopped with taskkill /T.
+func signalProcessGroup(process *os.Process, sig syscall.Signal) error {
+	return process.Signal(sig)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ProxyLogName is the name the request proxy's log entries are recorded under.
+const ProxyLogName = "proxy"
+
+// RequestProxy is a reverse proxy on a single port in front of the running services
+// (azd app run --proxy). A request for http://<service>.localhost:<port>/path, or for
+// /<service>/path, is forwarded to /path on the service's port, and every request is
+// logged (method, path, service, status and latency) to the unified log stream.
+type RequestProxy struct {
+	routes   map[string]proxyRoute // Keyed by lower-case service name
+	buffer   *LogBuffer
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewRequestProxy creates a proxy routing to the given service ports, logging requests
+// to the project's log manager.
+func NewRequestProxy(projectDir string, ports map[string]int) (*RequestProxy, error) {
+	if _, ok := ports[ProxyLogName]; ok {
+		return nil, fmt.Errorf("service name '%s' is reserved for the request proxy", ProxyLogName)
+	}
+
+	buffer, err := GetLogManager(projectDir).CreateBuffer(ProxyLogName, 1000, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy log buffer: %w", err)
+	}
+
+	p := &RequestProxy{routes: make(map[string]proxyRoute, len(ports)), buffer: buffer}
+	for name, port := range ports {
+		if port <= 0 {
+			continue
+		}
+		target := &url.URL{Scheme: "http", Host: fmt.Sprintf("localhost:%d", port)}
+		p.routes[strings.ToLower(name)] = proxyRoute{service: name, proxy: &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(target)
+				r.SetXForwarded()
+			},
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				http.Error(w, fmt.Sprintf("%s is not responding: %v", name, err), http.StatusBadGateway)
+			},
+		}}
+	}
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	return p, nil
+}
+
+// Start listens on localhost:port (0 picks a free port) and serves in the background.
+func (p *RequestProxy) Start(port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to start request proxy on port %d: %w", port, err)
+	}
+	p.listener = listener
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.log(NewLogEntry(ProxyLogName, fmt.Sprintf("Request proxy stopped: %v", err), true))
+		}
+	}()
+	return nil
+}
+
+// Port returns the port the proxy listens on, or 0 before Start.
+func (p *RequestProxy) Port() int {
+	if p.listener == nil {
+		return 0
+	}
+	return p.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Services returns the names of the services the proxy routes to, sorted.
+func (p *RequestProxy) Services() []string {
+	names := make([]string, 0, len(p.routes))
+	for _, route := range p.routes {
+		names = append(names, route.service)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Stop shuts the proxy down, waiting for in-flight requests until ctx is done.
+func (p *RequestProxy) Stop(ctx context.Context) error {
+	return p.server.Shutdown(ctx)
+}
+
+// ServeHTTP routes a request to its service and logs it.
+func (p *RequestProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	path := r.URL.Path
+
+	name := "-"
+	if route, ok := p.route(r); ok {
+		name = route.service
+		route.proxy.ServeHTTP(recorder, r)
+	} else {
+		http.Error(recorder, fmt.Sprintf("no service for %s; use http://<service>.localhost:%d/ or /<service>/ (services: %s)",
+			path, p.Port(), strings.Join(p.Services(), ", ")), http.StatusNotFound)
+	}
+
+	latency := time.Since(start)
+	entry := NewLogEntry(ProxyLogName, fmt.Sprintf("%s %s → %s %d (%dms)", r.Method, path, name, recorder.status, latency.Milliseconds()), false)
+	entry.Fields = map[string]any{
+		"method":    r.Method,
+		"path":      path,
+		"service":   name,
+		"status":    recorder.status,
+		"latencyMs": latency.Milliseconds(),
+	}
+	switch {
+	case recorder.status >= 500:
+		entry.Level = LogLevelError
+	case recorder.status >= 400:
+		entry.Level = LogLevelWarn
+	default:
+		entry.Level = LogLevelInfo
+	}
+	p.log(entry)
+}
+
+// route finds the service a request is for: by the first label of a <service>.localhost host,
+// then by the first path segment, which is removed from the forwarded request.
+func (p *RequestProxy) route(r *http.Request) (proxyRoute, bool) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if label, _, ok := strings.Cut(host, "."); ok {
+		if route, ok := p.routes[strings.ToLower(label)]; ok {
+			return route, true
+		}
+	}
+
+	segment, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	route, ok := p.routes[strings.ToLower(segment)]
+	if !ok {
+		return proxyRoute{}, false
+	}
+	r.URL.Path = "/" + rest
+	r.URL.RawPath = ""
+	return route, true
+}
+
+// proxyRoute forwards requests to one service.
+type proxyRoute struct {
+	service string
+	proxy   *httputil.ReverseProxy
+}
+
+// log records an entry in the proxy's log buffer and echoes it to the console view.
+func (p *RequestProxy) log(entry LogEntry) {
+	p.buffer.Add(entry)
+	echoToConsole(ProxyLogName, entry.Message, entry.IsStderr, p.buffer)
+}
+
+// statusRecorder captures the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController flush and hijack the underlying writer,
+// so streamed responses and WebSocket upgrades pass through the proxy.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newFakeService starts an HTTP server that echoes its name and the request path.
+func newFakeService(t *testing.T, name string) int {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "%s %s", name, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().(*net.TCPAddr).Port
+}
+
+func startTestProxy(t *testing.T, ports map[string]int) (*RequestProxy, string) {
+	t.Helper()
+	projectDir := t.TempDir()
+	proxy, err := NewRequestProxy(projectDir, ports)
+	if err != nil {
+		t.Fatalf("NewRequestProxy() error = %v", err)
+	}
+	if err := proxy.Start(0); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = proxy.Stop(context.Background())
+		_ = GetLogManager(projectDir).Clear()
+	})
+	return proxy, projectDir
+}
+
+func proxyGet(t *testing.T, proxy *RequestProxy, host, path string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d%s", proxy.Port(), path), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "" {
+		req.Host = host
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestRequestProxy_RoutesAndLogs(t *testing.T) {
+	proxy, projectDir := startTestProxy(t, map[string]int{
+		"api": newFakeService(t, "api"),
+		"web": newFakeService(t, "web"),
+	})
+
+	tests := []struct {
+		name       string
+		host       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"path routing", "", "/api/users/1", http.StatusOK, "api /users/1"},
+		{"path routing to service root", "", "/web", http.StatusOK, "web /"},
+		{"subdomain routing", fmt.Sprintf("web.localhost:%d", proxy.Port()), "/index.html", http.StatusOK, "web /index.html"},
+		{"service error", "", "/api/fail", http.StatusInternalServerError, "boom"},
+		{"unknown service", "", "/admin/", http.StatusNotFound, "no service for /admin/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := proxyGet(t, proxy, tt.host, tt.path)
+			if status != tt.wantStatus || !strings.Contains(body, tt.wantBody) {
+				t.Errorf("GET %s = %d %q, want %d containing %q", tt.path, status, body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+
+	buffer, ok := GetLogManager(projectDir).GetBuffer(ProxyLogName)
+	if !ok {
+		t.Fatal("Expected a proxy log buffer")
+	}
+	entries := buffer.GetRecent(10)
+	if len(entries) != len(tests) {
+		t.Fatalf("Expected %d logged requests, got %d", len(tests), len(entries))
+	}
+
+	wantLogs := []struct {
+		prefix  string
+		service string
+		status  int
+		level   LogLevel
+	}{
+		{"GET /api/users/1 → api 200", "api", 200, LogLevelInfo},
+		{"GET /web → web 200", "web", 200, LogLevelInfo},
+		{"GET /index.html → web 200", "web", 200, LogLevelInfo},
+		{"GET /api/fail → api 500", "api", 500, LogLevelError},
+		{"GET /admin/ → - 404", "-", 404, LogLevelWarn},
+	}
+	for i, want := range wantLogs {
+		entry := entries[i]
+		if !strings.HasPrefix(entry.Message, want.prefix) || entry.Service != ProxyLogName {
+			t.Errorf("log %d = %s: %q, want prefix %q", i, entry.Service, entry.Message, want.prefix)
+		}
+		if entry.Fields["service"] != want.service || entry.Fields["status"] != want.status || entry.Fields["method"] != http.MethodGet {
+			t.Errorf("log %d fields = %v, want service %s and status %d", i, entry.Fields, want.service, want.status)
+		}
+		if _, ok := entry.Fields["latencyMs"]; !ok {
+			t.Errorf("log %d fields = %v, want latencyMs", i, entry.Fields)
+		}
+		if entry.Level != want.level {
+			t.Errorf("log %d level = %v, want %v", i, entry.Level, want.level)
+		}
+	}
+}
+
+func TestRequestProxy_UnreachableService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	proxy, _ := startTestProxy(t, map[string]int{"api": port})
+	if status, _ := proxyGet(t, proxy, "", "/api/"); status != http.StatusBadGateway {
+		t.Errorf("Expected 502 for a service that is not listening, got %d", status)
+	}
+}
+
+func TestNewRequestProxy_ReservedName(t *testing.T) {
+	if _, err := NewRequestProxy(t.TempDir(), map[string]int{ProxyLogName: 8080}); err == nil {
+		t.Error("Expected error for a service named like the proxy log")
+	}
+}
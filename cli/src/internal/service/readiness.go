@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/constants"
+	"github.com/jongio/azd-app/cli/src/internal/output"
+	"github.com/jongio/azd-app/cli/src/internal/registry"
+)
+
+// DefaultReadyTimeout is how long a service may take to pass its health check after it
+// starts before it is marked failed (azd app run --ready-timeout).
+const DefaultReadyTimeout = 60 * time.Second
+
+// OrchestrateOptions configures how OrchestrateServices starts services.
+type OrchestrateOptions struct {
+	RestartContainers bool          // Restart containers even if they are already running
+	Rebuild           bool          // Build the images of Dockerfile services even if they are up to date
+	ReadyTimeout      time.Duration // How long each service may take to become healthy (0 uses DefaultReadyTimeout)
+	FailFast          bool          // Stop every service and fail when one does not become ready
+	EnvFilter         HostEnvFilter // Host environment variables forwarded to native services
+}
+
+// waitForReady is the readiness phase for services that have just started: each service's
+// health check must pass within timeout before it is reported ready. Services are checked in
+// parallel, with per-service progress. Services that do not become ready are marked failed in
+// the registry and returned with the reason.
+func waitForReady(processes map[string]*ServiceProcess, services map[string]Service, timeout time.Duration, reg *registry.ServiceRegistry) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	notReady := make(map[string]error)
+
+	for name, process := range processes {
+		svc := services[name]
+		// Build-mode services exit when done, and disabled health checks have nothing to wait for
+		if svc.IsBuildMode() || svc.IsHealthcheckDisabled() || process.Runtime.HealthCheck.Type == "none" {
+			process.Ready = true
+			reportServiceReady(process, 0)
+			continue
+		}
+
+		process.Ready = false
+		output.Item("  %s%-15s%s waiting for health…", output.Cyan, name, output.Reset)
+
+		wg.Add(1)
+		go func(name string, process *ServiceProcess, svc Service) {
+			defer wg.Done()
+
+			start := time.Now()
+			if err := waitForServiceHealthy(name, process, &svc, timeout); err != nil {
+				process.Ready = false
+				output.ItemError("%s%-15s%s not ready after %s: %v", output.Cyan, name, output.Reset, timeout, err)
+				if regErr := reg.UpdateStatus(name, constants.StatusError); regErr != nil {
+					output.Warning("Failed to update status for %s: %v", name, regErr)
+				}
+				mu.Lock()
+				notReady[name] = err
+				mu.Unlock()
+				return
+			}
+			process.Ready = true
+			reportServiceReady(process, time.Since(start))
+		}(name, process, svc)
+	}
+
+	wg.Wait()
+	return notReady
+}
+
+// reportServiceReady prints a service's URL once it is ready, with the time it took to pass
+// its health check (omitted when elapsed is 0).
+func reportServiceReady(process *ServiceProcess, elapsed time.Duration) {
+	suffix := ""
+	if elapsed > 0 {
+		suffix = fmt.Sprintf("  ready (%.1fs)", elapsed.Seconds())
+	}
+
+	// Only show URL for services with assigned ports (port > 0)
+	if process.Port > 0 {
+		url := fmt.Sprintf("http://localhost:%d", process.Port)
+		output.ItemSuccess("%s%-15s%s → %s%s", output.Cyan, process.Name, output.Reset, url, suffix)
+	} else {
+		output.ItemSuccess("%s%-15s%s%s", output.Cyan, process.Name, output.Reset, suffix)
+	}
+}
+
+// WaitForReady waits up to timeout (0 uses DefaultReadyTimeout) for a service that has just
+// been started to pass its health check, as the readiness phase of azd app run does, and
+// marks it ready. Build-mode services and services without a health check are ready at once.
+func WaitForReady(process *ServiceProcess, svc Service, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultReadyTimeout
+	}
+	if svc.IsBuildMode() || svc.IsHealthcheckDisabled() || process.Runtime.HealthCheck.Type == "none" {
+		process.Ready = true
+		return nil
+	}
+
+	process.Ready = false
+	if err := waitForServiceHealthy(process.Name, process, &svc, timeout); err != nil {
+		return err
+	}
+	process.Ready = true
+	return nil
+}
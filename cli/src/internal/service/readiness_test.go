@@ -0,0 +1,70 @@
+package service
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jongio/azd-app/cli/src/internal/constants"
+	"github.com/jongio/azd-app/cli/src/internal/registry"
+)
+
+func TestWaitForReady(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	tcpProcess := func(name string, port int) *ServiceProcess {
+		return &ServiceProcess{
+			Name: name,
+			Port: port,
+			Runtime: ServiceRuntime{
+				Name:        name,
+				HealthCheck: HealthCheckConfig{Type: "tcp", Interval: 10 * time.Millisecond},
+			},
+		}
+	}
+	processes := map[string]*ServiceProcess{
+		"api":    tcpProcess("api", openPort),
+		"db":     tcpProcess("db", closedPort),
+		"worker": tcpProcess("worker", closedPort),
+	}
+	services := map[string]Service{
+		"worker": {Healthcheck: &HealthcheckConfig{Disable: true}},
+	}
+
+	reg := registry.GetRegistry(t.TempDir())
+	for name := range processes {
+		if err := reg.Register(&registry.ServiceRegistryEntry{Name: name, Status: constants.StatusRunning}); err != nil {
+			t.Fatalf("Register(%s) error = %v", name, err)
+		}
+	}
+
+	notReady := waitForReady(processes, services, 300*time.Millisecond, reg)
+
+	if len(notReady) != 1 || notReady["db"] == nil {
+		t.Fatalf("notReady = %v, want only db", notReady)
+	}
+	if !processes["api"].Ready || !processes["worker"].Ready {
+		t.Error("api and worker should be ready")
+	}
+	if processes["db"].Ready {
+		t.Error("db should not be ready")
+	}
+	if entry, ok := reg.GetService("db"); !ok || entry.Status != constants.StatusError {
+		t.Errorf("db registry status = %v, want %s", entry, constants.StatusError)
+	}
+	if processes["db"].Runtime.HealthCheck.Timeout != 0 {
+		t.Error("waitForReady() should restore the configured health check timeout")
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// RuntimeCacheFileName is the name of the file, in the project's .azure/app-cache directory,
+// that caches detected service runtimes between commands.
+const RuntimeCacheFileName = "runtimes.json"
+
+// runtimeCacheVersion is bumped when runtime detection changes, so runtimes cached by an
+// older version are detected again.
+const runtimeCacheVersion = "9"
+
+// RuntimeCache holds service runtimes detected by earlier commands. Cached runtimes are
+// discarded when azure.yaml changes, and per service when its project directory changes.
+// A nil RuntimeCache caches nothing. It is not safe for concurrent use.
+type RuntimeCache struct {
+	path          string
+	azureYamlDir  string
+	azureYamlHash string
+	entries       map[string]runtimeCacheEntry
+	dirty         bool
+}
+
+// runtimeCacheFile is the on-disk format of a RuntimeCache.
+type runtimeCacheFile struct {
+	Version       string                       `json:"version"`
+	AzureYamlHash string                       `json:"azureYamlHash"`
+	Services      map[string]runtimeCacheEntry `json:"services"`
+}
+
+// runtimeCacheEntry is the cached runtime of one service.
+type runtimeCacheEntry struct {
+	RuntimeMode    string          `json:"runtimeMode,omitempty"`
+	ProjectModTime int64           `json:"projectModTime,omitempty"` // Unix nanoseconds, see projectModTime
+	Runtime        RuntimeSnapshot `json:"runtime"`
+}
+
+// RuntimeCachePath returns the location of the runtime cache for the given project directory.
+func RuntimeCachePath(azureYamlDir string) string {
+	return filepath.Join(azureYamlDir, ".azure", "app-cache", RuntimeCacheFileName)
+}
+
+// OpenRuntimeCache loads the runtime cache for the project whose azure.yaml is at azureYamlPath.
+// A missing, unreadable or outdated cache file yields an empty cache.
+func OpenRuntimeCache(azureYamlPath string) (*RuntimeCache, error) {
+	hash, err := hashFile(azureYamlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash azure.yaml: %w", err)
+	}
+
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	cache := &RuntimeCache{
+		path:          RuntimeCachePath(azureYamlDir),
+		azureYamlDir:  azureYamlDir,
+		azureYamlHash: hash,
+		entries:       make(map[string]runtimeCacheEntry),
+	}
+
+	data, err := os.ReadFile(cache.path) // #nosec G304 -- path is the project's cache file
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Debug("ignoring unreadable runtime cache", "path", cache.path, "error", err)
+		}
+		return cache, nil
+	}
+
+	var file runtimeCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		slog.Debug("ignoring corrupt runtime cache", "path", cache.path, "error", err)
+		cache.dirty = true
+		return cache, nil
+	}
+	if file.Version != runtimeCacheVersion || file.AzureYamlHash != hash {
+		// Replace the stale file on the next Save, even if nothing new is cached
+		cache.dirty = true
+		return cache, nil
+	}
+	for name, entry := range file.Services {
+		cache.entries[name] = entry
+	}
+	return cache, nil
+}
+
+// Lookup returns the cached runtime of a service, if it was detected for the same runtime
+// mode and the service's project directory hasn't changed since.
+func (c *RuntimeCache) Lookup(serviceName string, service Service, runtimeMode string) (*ServiceRuntime, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.entries[serviceName]
+	if !ok || entry.RuntimeMode != runtimeMode {
+		return nil, false
+	}
+
+	modTime, err := c.projectModTime(service)
+	if err != nil || modTime != entry.ProjectModTime {
+		return nil, false
+	}
+
+	rt, err := entry.Runtime.runtime(service)
+	if err != nil {
+		return nil, false
+	}
+	return rt, true
+}
+
+// Store caches the detected runtime of a service.
+func (c *RuntimeCache) Store(serviceName string, service Service, runtimeMode string, rt *ServiceRuntime) {
+	if c == nil {
+		return
+	}
+	modTime, err := c.projectModTime(service)
+	if err != nil {
+		slog.Debug("not caching runtime", "service", serviceName, "error", err)
+		return
+	}
+
+	c.entries[serviceName] = runtimeCacheEntry{
+		RuntimeMode:    runtimeMode,
+		ProjectModTime: modTime,
+		Runtime:        newRuntimeSnapshot(rt, service),
+	}
+	c.dirty = true
+}
+
+// Save writes the cache to RuntimeCachePath if it changed since it was opened.
+func (c *RuntimeCache) Save() error {
+	if c == nil || !c.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create runtime cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(runtimeCacheFile{
+		Version:       runtimeCacheVersion,
+		AzureYamlHash: c.azureYamlHash,
+		Services:      c.entries,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal runtime cache: %w", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write runtime cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write runtime cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// projectModTime returns the latest modification time of a service's project directory and
+// the files directly in it, which changes when project files are added, removed or edited
+// at the top level (package.json, requirements.txt, *.csproj, ...). Container services have
+// no project directory and always return 0.
+func (c *RuntimeCache) projectModTime(service Service) (int64, error) {
+	if service.IsContainerService() {
+		return 0, nil
+	}
+
+	dir := ResolveProjectPath(c.azureYamlDir, service.Project)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return 0, err
+	}
+	latest := info.ModTime().UnixNano()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		// Subdirectories are skipped: their own mtime changes whenever anything inside
+		// them does, such as .azure when this cache is written
+		if entry.IsDir() {
+			continue
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, fileInfo.ModTime().UnixNano())
+	}
+	return latest, nil
+}
+
+// ClearRuntimeCache removes the runtime cache of the given project directory, so the next
+// command detects every service again.
+func ClearRuntimeCache(azureYamlDir string) error {
+	if err := os.Remove(RuntimeCachePath(azureYamlDir)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove runtime cache: %w", err)
+	}
+	return nil
+}
+
+// DetectServiceRuntimeCached returns the runtime of a service from cache when possible and
+// otherwise detects it with DetectServiceRuntime, caching the result. A cached runtime is
+// only used if its ports are still free, so conflicts are resolved by full detection.
+func DetectServiceRuntimeCached(cache *RuntimeCache, serviceName string, service Service, usedPorts map[int]bool, azureYamlDir string, runtimeMode string) (*ServiceRuntime, error) {
+	if rt, ok := cache.Lookup(serviceName, service, runtimeMode); ok {
+		if ports := rt.HostPorts(); cachedPortsAvailable(ports, usedPorts) {
+			slog.Debug("using cached service runtime", "service", serviceName)
+			for _, port := range ports {
+				usedPorts[port] = true
+			}
+			return rt, nil
+		}
+	}
+
+	rt, err := DetectServiceRuntime(serviceName, service, usedPorts, azureYamlDir, runtimeMode)
+	if err != nil {
+		return nil, err
+	}
+	// Saving an assigned port changes azure.yaml, which invalidates the cache anyway
+	if !rt.ShouldUpdateAzureYaml {
+		cache.Store(serviceName, service, runtimeMode, rt)
+	}
+	return rt, nil
+}
+
+// isCachedPortAvailable checks a cached runtime's port. A variable so tests can replace it.
+var isCachedPortAvailable = IsPortAvailable
+
+// cachedPortsAvailable reports whether none of a cached runtime's host ports is used by
+// another service or taken.
+func cachedPortsAvailable(ports []int, usedPorts map[int]bool) bool {
+	for _, port := range ports {
+		if usedPorts[port] || !isCachedPortAvailable(port) {
+			return false
+		}
+	}
+	return true
+}
+
+// hashFile returns the hex-encoded SHA-256 hash of a file's contents.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the project's azure.yaml
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
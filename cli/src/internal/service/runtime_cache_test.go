@@ -0,0 +1,195 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRuntimeCacheProject creates a project with a Python worker service and returns the
+// path of its azure.yaml and the service definition.
+func newRuntimeCacheProject(t *testing.T) (string, Service) {
+	t.Helper()
+	dir := t.TempDir()
+	workerDir := filepath.Join(dir, "worker")
+	if err := os.MkdirAll(workerDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workerDir, "requirements.txt"), []byte("celery\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	azureYamlPath := filepath.Join(dir, "azure.yaml")
+	if err := os.WriteFile(azureYamlPath, []byte("name: cache-test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := Service{
+		Project:     "./worker",
+		Language:    "python",
+		Command:     "python worker.py",
+		Environment: Environment{"API_TOKEN": "s3cret"},
+	}
+	return azureYamlPath, svc
+}
+
+func TestRuntimeCache_ReusesDetectedRuntime(t *testing.T) {
+	azureYamlPath, svc := newRuntimeCacheProject(t)
+	dir := filepath.Dir(azureYamlPath)
+
+	cache, err := OpenRuntimeCache(azureYamlPath)
+	if err != nil {
+		t.Fatalf("OpenRuntimeCache() error = %v", err)
+	}
+	if _, ok := cache.Lookup("worker", svc, "azd"); ok {
+		t.Fatal("Lookup() hit on an empty cache")
+	}
+
+	detected, err := DetectServiceRuntimeCached(cache, "worker", svc, map[int]bool{}, dir, "azd")
+	if err != nil {
+		t.Fatalf("DetectServiceRuntimeCached() error = %v", err)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Secrets are not written to disk
+	data, err := os.ReadFile(RuntimeCachePath(dir))
+	if err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("cache file should mask secrets:\n%s", data)
+	}
+
+	// A new command reads the runtime back, with secrets restored from azure.yaml
+	cache, err = OpenRuntimeCache(azureYamlPath)
+	if err != nil {
+		t.Fatalf("OpenRuntimeCache() error = %v", err)
+	}
+	cached, ok := cache.Lookup("worker", svc, "azd")
+	if !ok {
+		t.Fatal("Lookup() missed a cached runtime")
+	}
+	if cached.Command != detected.Command || cached.WorkingDir != detected.WorkingDir || cached.Language != detected.Language {
+		t.Errorf("cached runtime = %+v, want %+v", cached, detected)
+	}
+	if _, ok := cache.Lookup("worker", svc, "aspire"); ok {
+		t.Error("Lookup() should miss for another runtime mode")
+	}
+}
+
+func TestRuntimeCache_Invalidation(t *testing.T) {
+	azureYamlPath, svc := newRuntimeCacheProject(t)
+	dir := filepath.Dir(azureYamlPath)
+
+	store := func() {
+		t.Helper()
+		cache, err := OpenRuntimeCache(azureYamlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache.Store("worker", svc, "azd", &ServiceRuntime{Name: "worker", Command: "python"})
+		if err := cache.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hit := func() bool {
+		t.Helper()
+		cache, err := OpenRuntimeCache(azureYamlPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, ok := cache.Lookup("worker", svc, "azd")
+		return ok
+	}
+
+	store()
+	if !hit() {
+		t.Fatal("Lookup() missed a cached runtime")
+	}
+
+	// Editing a file in the project directory invalidates the service
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "worker", "requirements.txt"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if hit() {
+		t.Error("Lookup() should miss after a project file changed")
+	}
+
+	// Changing azure.yaml invalidates everything
+	store()
+	if err := os.WriteFile(azureYamlPath, []byte("name: cache-test-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if hit() {
+		t.Error("Lookup() should miss after azure.yaml changed")
+	}
+
+	// A corrupt cache file is ignored
+	if err := os.WriteFile(RuntimeCachePath(dir), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if hit() {
+		t.Error("Lookup() should miss with a corrupt cache file")
+	}
+
+	// ClearRuntimeCache removes the file, and is fine when there is none
+	store()
+	if err := ClearRuntimeCache(dir); err != nil {
+		t.Fatalf("ClearRuntimeCache() error = %v", err)
+	}
+	if hit() {
+		t.Error("Lookup() should miss after ClearRuntimeCache()")
+	}
+	if err := ClearRuntimeCache(dir); err != nil {
+		t.Errorf("ClearRuntimeCache() without a cache error = %v", err)
+	}
+}
+
+func TestDetectServiceRuntimeCached_BusyPort(t *testing.T) {
+	azureYamlPath, svc := newRuntimeCacheProject(t)
+	dir := filepath.Dir(azureYamlPath)
+
+	defer func(available func(int) bool) { isCachedPortAvailable = available }(isCachedPortAvailable)
+	portFree := true
+	isCachedPortAvailable = func(int) bool { return portFree }
+
+	cache, err := OpenRuntimeCache(azureYamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Store("worker", svc, "azd", &ServiceRuntime{Name: "worker", Command: "cached", Port: 5000})
+
+	usedPorts := map[int]bool{}
+	rt, err := DetectServiceRuntimeCached(cache, "worker", svc, usedPorts, dir, "azd")
+	if err != nil {
+		t.Fatalf("DetectServiceRuntimeCached() error = %v", err)
+	}
+	if rt.Command != "cached" || !usedPorts[5000] {
+		t.Errorf("DetectServiceRuntimeCached() = %+v (used ports %v), want the cached runtime on port 5000", rt, usedPorts)
+	}
+
+	// A cached port that is now in use is resolved by full detection
+	portFree = false
+	rt, err = DetectServiceRuntimeCached(cache, "worker", svc, map[int]bool{}, dir, "azd")
+	if err != nil {
+		t.Fatalf("DetectServiceRuntimeCached() error = %v", err)
+	}
+	if rt.Command == "cached" {
+		t.Error("DetectServiceRuntimeCached() should detect again when the cached port is busy")
+	}
+}
+
+func TestRuntimeCache_Nil(t *testing.T) {
+	var cache *RuntimeCache
+	cache.Store("worker", Service{}, "azd", &ServiceRuntime{})
+	if _, ok := cache.Lookup("worker", Service{}, "azd"); ok {
+		t.Error("nil cache should never hit")
+	}
+	if err := cache.Save(); err != nil {
+		t.Errorf("Save() on nil cache error = %v", err)
+	}
+}
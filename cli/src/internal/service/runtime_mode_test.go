@@ -326,3 +326,30 @@ services:
 		t.Error("AZD mode should have --no-launch-profile flag")
 	}
 }
+
+func TestAspireAppHostDetectedFromCsproj(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Newer Aspire templates have Program.cs and reference the AppHost package instead of AppHost.cs
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <Sdk Name="Aspire.AppHost.Sdk" Version="9.0.0" />
+  <ItemGroup>
+    <PackageReference Include="Aspire.Hosting.AppHost" Version="9.0.0" />
+  </ItemGroup>
+</Project>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "MyApp.AppHost.csproj"), []byte(csprojContent), 0600); err != nil {
+		t.Fatalf("Failed to create csproj: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "Program.cs"), []byte("// Aspire"), 0600); err != nil {
+		t.Fatalf("Failed to create Program.cs: %v", err)
+	}
+
+	svc := service.Service{Project: ".", Language: "dotnet"}
+	runtime, err := service.DetectServiceRuntime("apphost", svc, map[int]bool{}, tmpDir, "azd")
+	if err != nil {
+		t.Fatalf("Failed to detect runtime: %v", err)
+	}
+	if runtime.Framework != "Aspire" {
+		t.Errorf("Expected framework 'Aspire', got %q", runtime.Framework)
+	}
+}
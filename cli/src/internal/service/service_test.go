@@ -3,6 +3,7 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jongio/azd-app/cli/src/internal/service"
@@ -59,6 +60,23 @@ resources:
 	}
 }
 
+func TestParseAzureYamlErrorNamesFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	azureYamlPath := filepath.Join(tmpDir, "apps", "api", "azure.yaml")
+	if err := os.MkdirAll(filepath.Dir(azureYamlPath), 0o750); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(azureYamlPath, []byte("services: [invalid yaml"), 0600); err != nil {
+		t.Fatalf("Failed to create test azure.yaml: %v", err)
+	}
+
+	// In a monorepo, the error must say which azure.yaml is broken
+	_, err := service.ParseAzureYaml(azureYamlPath)
+	if err == nil || !strings.Contains(err.Error(), azureYamlPath) {
+		t.Errorf("ParseAzureYaml() error = %v, want it to name %s", err, azureYamlPath)
+	}
+}
+
 func TestFilterServices(t *testing.T) {
 	azureYaml := &service.AzureYaml{
 		Services: map[string]service.Service{
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/workspace"
+)
+
+// ServiceGraphNode is a service or resource in the service graph.
+type ServiceGraphNode struct {
+	Name      string   `json:"name"`
+	Resource  bool     `json:"resource,omitempty"` // True for azure.yaml resources, which are not run locally
+	Language  string   `json:"language,omitempty"` // Resource type for resources, "container" for image services
+	Framework string   `json:"framework,omitempty"`
+	Port      int      `json:"port,omitempty"`      // Port declared in azure.yaml
+	DependsOn []string `json:"dependsOn,omitempty"` // Services and resources this one uses
+}
+
+// ServiceGraph describes how the services and resources in azure.yaml depend on each other.
+type ServiceGraph struct {
+	// Nodes are sorted by name, services before resources.
+	Nodes []ServiceGraphNode `json:"nodes"`
+	// Cycles lists each dependency cycle as names, starting and ending with the same node.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// BuildServiceGraph builds the graph of azure.yaml services and resources and their 'uses'
+// dependencies (azd app info --graph). Languages and frameworks are detected from each
+// service's project directory when azure.yaml does not declare them. Unlike
+// BuildDependencyGraph, cycles and unknown dependencies are reported rather than rejected.
+func BuildServiceGraph(azureYaml *AzureYaml, azureYamlDir string) *ServiceGraph {
+	graph := &ServiceGraph{Nodes: make([]ServiceGraphNode, 0, len(azureYaml.Services)+len(azureYaml.Resources))}
+
+	for _, name := range slices.Sorted(maps.Keys(azureYaml.Services)) {
+		svc := azureYaml.Services[name]
+		node := ServiceGraphNode{Name: name, DependsOn: svc.Uses}
+		if port, _, isExplicit := svc.GetPrimaryPort(); isExplicit {
+			node.Port = port
+		}
+		if svc.NeedsImageBuild() {
+			node.Language, node.Framework = "container", builtImageName(appName(azureYamlDir), name)
+		} else if svc.IsContainerService() {
+			node.Language, node.Framework = "container", svc.GetContainerImage()
+		} else {
+			node.Language, node.Framework = describeServiceProject(svc, azureYamlDir)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(azureYaml.Resources)) {
+		res := azureYaml.Resources[name]
+		graph.Nodes = append(graph.Nodes, ServiceGraphNode{Name: name, Resource: true, Language: res.Type, DependsOn: res.Uses})
+	}
+
+	graph.Cycles = findGraphCycles(graph.Nodes)
+	return graph
+}
+
+// describeServiceProject returns a service's language and framework, detecting them from its
+// project directory where possible. Detection failures leave the values empty.
+func describeServiceProject(svc Service, azureYamlDir string) (string, string) {
+	projectDir := svc.Project
+	if projectDir != "" {
+		projectDir = ResolveProjectPath(azureYamlDir, projectDir)
+	}
+
+	language := svc.Language
+	if language == "" && projectDir != "" {
+		language, _ = detectLanguage(projectDir, svc.Host)
+	}
+	if language == "" {
+		return "", ""
+	}
+	language = normalizeLanguage(language)
+
+	framework := ""
+	if projectDir != "" {
+		framework, _, _ = detectFrameworkAndPackageManager(projectDir, language)
+	}
+	if framework == language {
+		framework = ""
+	}
+	return language, framework
+}
+
+// HasCycles returns true if any dependency cycle was detected.
+func (g *ServiceGraph) HasCycles() bool {
+	return len(g.Cycles) > 0
+}
+
+// DOT renders the graph in Graphviz DOT format. Node labels carry the language, framework
+// and port, resources are drawn as boxes, edges point from a service to what it uses, and
+// edges in a cycle are red with the cycle spelled out in a comment.
+func (g *ServiceGraph) DOT() string {
+	cycleEdges := make(map[[2]string]bool)
+	for _, cycle := range g.Cycles {
+		for k := 0; k+1 < len(cycle); k++ {
+			cycleEdges[[2]string{cycle[k], cycle[k+1]}] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph services {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, cycle := range g.Cycles {
+		fmt.Fprintf(&b, "  // cycle: %s\n", strings.Join(cycle, " -> "))
+	}
+	for _, node := range g.Nodes {
+		label := []string{node.Name}
+		switch {
+		case node.Language != "" && node.Framework != "":
+			label = append(label, fmt.Sprintf("%s (%s)", node.Language, node.Framework))
+		case node.Language != "":
+			label = append(label, node.Language)
+		}
+		if node.Port > 0 {
+			label = append(label, fmt.Sprintf(":%d", node.Port))
+		}
+		if node.Resource {
+			fmt.Fprintf(&b, "  %q [label=%q, shape=box];\n", node.Name, strings.Join(label, "\n"))
+		} else {
+			fmt.Fprintf(&b, "  %q [label=%q];\n", node.Name, strings.Join(label, "\n"))
+		}
+	}
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			if cycleEdges[[2]string{node.Name, dep}] {
+				fmt.Fprintf(&b, "  %q -> %q [color=red];\n", node.Name, dep)
+			} else {
+				fmt.Fprintf(&b, "  %q -> %q;\n", node.Name, dep)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// findGraphCycles returns each distinct dependency cycle between nodes, starting from the
+// alphabetically first node in the cycle. Dependencies on unknown names are ignored.
+func findGraphCycles(nodes []ServiceGraphNode) [][]string {
+	index := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		index[node.Name] = i
+	}
+	deps := make([][]int, len(nodes))
+	for i, node := range nodes {
+		for _, dep := range node.DependsOn {
+			if j, ok := index[dep]; ok {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return nodes[order[a]].Name < nodes[order[b]].Name })
+
+	var cycles [][]string
+	seen := make(map[string]bool)
+	for _, indexes := range workspace.FindCycles(deps, order) {
+		// FindCycles closes each cycle; canonicalCycle rotates the open path and closes it again
+		path := make([]string, len(indexes)-1)
+		for k, i := range indexes[:len(indexes)-1] {
+			path[k] = nodes[i].Name
+		}
+		cycle := canonicalCycle(path)
+		if key := strings.Join(cycle, "\x00"); !seen[key] {
+			seen[key] = true
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// canonicalCycle rotates a cycle to start at its alphabetically first node and closes it.
+func canonicalCycle(path []string) []string {
+	first := 0
+	for i, name := range path {
+		if name < path[first] {
+			first = i
+		}
+	}
+	cycle := make([]string, 0, len(path)+1)
+	cycle = append(cycle, path[first:]...)
+	cycle = append(cycle, path[:first]...)
+	return append(cycle, cycle[0])
+}
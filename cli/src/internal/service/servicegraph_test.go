@@ -0,0 +1,87 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildServiceGraph(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "api", "go.mod"), []byte("module api\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	azureYaml := &AzureYaml{
+		Services: map[string]Service{
+			"web":   {Language: "ts", Ports: []string{"3000"}, Uses: []string{"api"}},
+			"api":   {Project: "./api", Ports: []string{"8080"}, Uses: []string{"cache", "db"}},
+			"cache": {Image: "redis:7", Ports: []string{"6379:6379"}},
+		},
+		Resources: map[string]Resource{
+			"db": {Type: "db.postgres"},
+		},
+	}
+
+	graph := BuildServiceGraph(azureYaml, dir)
+
+	want := []ServiceGraphNode{
+		{Name: "api", Language: "Go", Port: 8080, DependsOn: []string{"cache", "db"}},
+		{Name: "cache", Language: "container", Framework: "redis:7", Port: 6379},
+		{Name: "web", Language: "TypeScript", Port: 3000, DependsOn: []string{"api"}},
+		{Name: "db", Resource: true, Language: "db.postgres"},
+	}
+	if !reflect.DeepEqual(graph.Nodes, want) {
+		t.Errorf("Nodes =\n %+v\nwant\n %+v", graph.Nodes, want)
+	}
+	if graph.HasCycles() {
+		t.Errorf("Cycles = %v, want none", graph.Cycles)
+	}
+
+	dot := graph.DOT()
+	for _, line := range []string{
+		`"api" [label="api\nGo\n:8080"];`,
+		`"db" [label="db\ndb.postgres", shape=box];`,
+		`"web" -> "api";`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT() missing %s:\n%s", line, dot)
+		}
+	}
+}
+
+func TestBuildServiceGraph_Cycles(t *testing.T) {
+	azureYaml := &AzureYaml{
+		Services: map[string]Service{
+			"web":    {Uses: []string{"api"}},
+			"api":    {Uses: []string{"worker"}},
+			"worker": {Uses: []string{"web", "api"}},
+		},
+	}
+
+	graph := BuildServiceGraph(azureYaml, t.TempDir())
+
+	wantCycles := [][]string{
+		{"api", "worker", "web", "api"},
+		{"api", "worker", "api"},
+	}
+	if !reflect.DeepEqual(graph.Cycles, wantCycles) {
+		t.Errorf("Cycles = %v, want %v", graph.Cycles, wantCycles)
+	}
+
+	dot := graph.DOT()
+	for _, line := range []string{
+		"// cycle: api -> worker -> web -> api",
+		`"worker" -> "web" [color=red];`,
+		`"worker" -> "api" [color=red];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT() missing %s:\n%s", line, dot)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package service provides runtime detection and service orchestration capabilities.
+package service
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SidecarServiceName returns the name used to run an inline sidecar of a parent service.
+func SidecarServiceName(parent, sidecar string) string {
+	return parent + "-" + sidecar
+}
+
+// ExpandSidecars returns a copy of services in which every inline sidecar is promoted
+// to a regular service named "<parent>-<sidecar>".
+//
+// Lifecycle coupling:
+//   - The parent implicitly 'uses' each of its sidecars, so sidecars start first and
+//     must be healthy before the parent starts
+//   - Each sidecar records its parent in SidecarOf so it can be stopped with the parent
+//
+// Returns an error if a sidecar declares its own sidecars or its expanded name
+// collides with an existing service.
+func ExpandSidecars(services map[string]Service) (map[string]Service, error) {
+	expanded := make(map[string]Service, len(services))
+	for name, svc := range services {
+		expanded[name] = svc
+	}
+
+	// Sort parents for deterministic collision errors
+	parents := make([]string, 0, len(services))
+	for name, svc := range services {
+		if len(svc.Sidecars) > 0 {
+			parents = append(parents, name)
+		}
+	}
+	sort.Strings(parents)
+
+	for _, parentName := range parents {
+		parent := expanded[parentName]
+
+		sidecarNames := make([]string, 0, len(parent.Sidecars))
+		for name := range parent.Sidecars {
+			sidecarNames = append(sidecarNames, name)
+		}
+		sort.Strings(sidecarNames)
+
+		uses := make([]string, len(parent.Uses), len(parent.Uses)+len(sidecarNames))
+		copy(uses, parent.Uses)
+
+		for _, sidecarName := range sidecarNames {
+			sidecar := parent.Sidecars[sidecarName]
+			if len(sidecar.Sidecars) > 0 {
+				return nil, fmt.Errorf("sidecar '%s' of service '%s' cannot declare its own sidecars", sidecarName, parentName)
+			}
+
+			fullName := SidecarServiceName(parentName, sidecarName)
+			if _, exists := expanded[fullName]; exists {
+				return nil, fmt.Errorf("sidecar '%s' of service '%s' conflicts with existing service '%s'", sidecarName, parentName, fullName)
+			}
+
+			sidecar.SidecarOf = parentName
+			expanded[fullName] = sidecar
+			uses = append(uses, fullName)
+		}
+
+		parent.Uses = uses
+		parent.Sidecars = nil
+		expanded[parentName] = parent
+	}
+
+	return expanded, nil
+}
+
+// SidecarsOf returns the sorted names of the expanded sidecars belonging to a parent service.
+func SidecarsOf(services map[string]Service, parent string) []string {
+	var sidecars []string
+	for name, svc := range services {
+		if svc.SidecarOf == parent {
+			sidecars = append(sidecars, name)
+		}
+	}
+	sort.Strings(sidecars)
+	return sidecars
+}
+
+// IncludeSidecars returns serviceNames plus the expanded sidecars of each named service,
+// so filtering to a service also runs the sidecars that share its lifecycle.
+func IncludeSidecars(services map[string]Service, serviceNames []string) []string {
+	seen := make(map[string]bool, len(serviceNames))
+	result := make([]string, 0, len(serviceNames))
+	for _, name := range serviceNames {
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	for _, name := range serviceNames {
+		for _, sidecar := range SidecarsOf(services, name) {
+			if !seen[sidecar] {
+				seen[sidecar] = true
+				result = append(result, sidecar)
+			}
+		}
+	}
+	return result
+}
+
+// SidecarMap returns a map of parent service name to its expanded sidecar names.
+func SidecarMap(services map[string]Service) map[string][]string {
+	sidecars := make(map[string][]string)
+	for name, svc := range services {
+		if svc.SidecarOf != "" {
+			sidecars[svc.SidecarOf] = append(sidecars[svc.SidecarOf], name)
+		}
+	}
+	for parent := range sidecars {
+		sort.Strings(sidecars[parent])
+	}
+	return sidecars
+}
+
+// StopSidecars stops the running sidecars of a parent service.
+// Called when the parent exits so sidecars don't outlive the service they belong to.
+func StopSidecars(parent string, sidecars map[string][]string, processes map[string]*ServiceProcess) {
+	toStop := make(map[string]*ServiceProcess)
+	for _, name := range sidecars[parent] {
+		if proc, exists := processes[name]; exists && proc != nil {
+			toStop[name] = proc
+		}
+	}
+	if len(toStop) == 0 {
+		return
+	}
+	StopAllServices(toStop)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestServiceUnmarshalSidecars(t *testing.T) {
+	data := `
+host: containerapp
+language: python
+project: ./api
+sidecars:
+  redis:
+    image: redis:7
+    ports:
+      - "6379"
+`
+	var svc Service
+	if err := yaml.Unmarshal([]byte(data), &svc); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	redis, exists := svc.Sidecars["redis"]
+	if !exists {
+		t.Fatalf("Sidecars = %v, want entry for redis", svc.Sidecars)
+	}
+	if redis.Image != "redis:7" {
+		t.Errorf("redis.Image = %q, want %q", redis.Image, "redis:7")
+	}
+	if !reflect.DeepEqual(redis.Ports, []string{"6379"}) {
+		t.Errorf("redis.Ports = %v, want [6379]", redis.Ports)
+	}
+}
+
+func TestExpandSidecars(t *testing.T) {
+	services := map[string]Service{
+		"api": {
+			Language: "python",
+			Uses:     []string{"db"},
+			Sidecars: map[string]Service{
+				"redis": {Image: "redis:7"},
+			},
+		},
+		"db": {Image: "postgres:16"},
+	}
+
+	expanded, err := ExpandSidecars(services)
+	if err != nil {
+		t.Fatalf("ExpandSidecars() error = %v", err)
+	}
+
+	sidecar, exists := expanded["api-redis"]
+	if !exists {
+		t.Fatalf("expanded services = %v, want api-redis", expanded)
+	}
+	if sidecar.SidecarOf != "api" {
+		t.Errorf("api-redis.SidecarOf = %q, want %q", sidecar.SidecarOf, "api")
+	}
+	if !reflect.DeepEqual(expanded["api"].Uses, []string{"db", "api-redis"}) {
+		t.Errorf("api.Uses = %v, want [db api-redis]", expanded["api"].Uses)
+	}
+	if expanded["api"].Sidecars != nil {
+		t.Errorf("api.Sidecars should be cleared after expansion, got %v", expanded["api"].Sidecars)
+	}
+
+	// The input map must not be mutated
+	if !reflect.DeepEqual(services["api"].Uses, []string{"db"}) {
+		t.Errorf("input api.Uses mutated to %v", services["api"].Uses)
+	}
+}
+
+func TestExpandSidecars_StartsBeforeParent(t *testing.T) {
+	services := map[string]Service{
+		"api": {
+			Sidecars: map[string]Service{
+				"redis": {Image: "redis:7"},
+			},
+		},
+	}
+
+	expanded, err := ExpandSidecars(services)
+	if err != nil {
+		t.Fatalf("ExpandSidecars() error = %v", err)
+	}
+
+	graph, err := BuildDependencyGraph(expanded, nil)
+	if err != nil {
+		t.Fatalf("BuildDependencyGraph() error = %v", err)
+	}
+
+	levels := TopologicalSort(graph)
+	want := [][]string{{"api-redis"}, {"api"}}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("TopologicalSort() = %v, want %v", levels, want)
+	}
+}
+
+func TestExpandSidecars_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		services map[string]Service
+	}{
+		{
+			name: "nested sidecars",
+			services: map[string]Service{
+				"api": {
+					Sidecars: map[string]Service{
+						"redis": {Sidecars: map[string]Service{"other": {}}},
+					},
+				},
+			},
+		},
+		{
+			name: "name collision",
+			services: map[string]Service{
+				"api":       {Sidecars: map[string]Service{"redis": {}}},
+				"api-redis": {Image: "redis:7"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExpandSidecars(tt.services); err == nil {
+				t.Error("ExpandSidecars() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestIncludeSidecars(t *testing.T) {
+	services := map[string]Service{
+		"api":       {Uses: []string{"api-redis"}},
+		"api-redis": {SidecarOf: "api"},
+		"web":       {},
+		"web-cache": {SidecarOf: "web"},
+	}
+
+	got := IncludeSidecars(services, []string{"api"})
+	want := []string{"api", "api-redis"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IncludeSidecars() = %v, want %v", got, want)
+	}
+
+	wantMap := map[string][]string{"api": {"api-redis"}, "web": {"web-cache"}}
+	if gotMap := SidecarMap(services); !reflect.DeepEqual(gotMap, wantMap) {
+		t.Errorf("SidecarMap() = %v, want %v", gotMap, wantMap)
+	}
+}
+
+func TestStopSidecars_StopsWithParent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping sidecar lifecycle test in short mode")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses sleep to simulate long-running services")
+	}
+
+	tmpDir := t.TempDir()
+	startSleep := func(name string) *ServiceProcess {
+		rt := &ServiceRuntime{
+			Name:       name,
+			WorkingDir: tmpDir,
+			Command:    "sleep",
+			Args:       []string{"30"},
+			Language:   "shell",
+		}
+		process, err := StartService(rt, map[string]string{}, tmpDir, nil)
+		if err != nil {
+			t.Fatalf("StartService(%s) error = %v", name, err)
+		}
+		return process
+	}
+
+	processes := map[string]*ServiceProcess{
+		"api":       startSleep("api"),
+		"api-redis": startSleep("api-redis"),
+		"web":       startSleep("web"),
+	}
+	t.Cleanup(func() {
+		StopAllServices(processes)
+		logMgr := GetLogManager(tmpDir)
+		for name := range processes {
+			_ = logMgr.RemoveBuffer(name)
+		}
+	})
+
+	sidecars := map[string][]string{"api": {"api-redis"}}
+
+	// Parent exits
+	if err := StopServiceGraceful(processes["api"], 5*time.Second); err != nil {
+		t.Fatalf("StopServiceGraceful(api) error = %v", err)
+	}
+
+	StopSidecars("api", sidecars, processes)
+
+	if err := processes["api-redis"].Process.Signal(os.Kill); !errors.Is(err, os.ErrProcessDone) {
+		t.Errorf("sidecar api-redis should have stopped with its parent, Signal() error = %v", err)
+	}
+	if err := processes["web"].Process.Signal(syscall.Signal(0)); err != nil {
+		t.Errorf("unrelated service web should still be running, Signal() error = %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ForwardableSignalNames lists the signals that azd app run can relay to services, in help order.
+var ForwardableSignalNames = []string{"HUP", "USR1", "USR2"}
+
+// ParseForwardSignals converts signal names such as "HUP", "sighup" or "SIGUSR1" to signals.
+// Duplicates are removed. Names outside ForwardableSignalNames are rejected, as is any name
+// when the platform cannot relay signals (see SignalForwardingSupported).
+func ParseForwardSignals(names []string) ([]os.Signal, error) {
+	signals := make([]os.Signal, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SIG")
+		if key == "" || seen[key] {
+			continue
+		}
+		sig, ok := forwardableSignals[key]
+		if !ok {
+			return nil, fmt.Errorf("cannot forward signal %q (supported: SIG%s)", name, strings.Join(ForwardableSignalNames, ", SIG"))
+		}
+		seen[key] = true
+		signals = append(signals, sig)
+	}
+	return signals, nil
+}
+
+// SignalForwarder relays signals received by azd app run to running service processes,
+// e.g. so services can reload their configuration on SIGHUP without a restart.
+type SignalForwarder struct {
+	processes map[string]*ServiceProcess
+	targets   map[string]bool // nil forwards to every service
+	send      func(process *os.Process, sig os.Signal) error
+}
+
+// NewSignalForwarder creates a forwarder for the given processes.
+// When targets is empty, signals are forwarded to every service.
+func NewSignalForwarder(processes map[string]*ServiceProcess, targets []string) *SignalForwarder {
+	forwarder := &SignalForwarder{
+		processes: processes,
+		send:      sendSignal,
+	}
+	if len(targets) > 0 {
+		forwarder.targets = make(map[string]bool, len(targets))
+		for _, name := range targets {
+			forwarder.targets[name] = true
+		}
+	}
+	return forwarder
+}
+
+// Forward sends sig to each selected service process and returns the names of the services
+// that received it, sorted. Container services and services without a process are skipped.
+// Delivery failures are joined into the returned error without stopping other deliveries.
+func (f *SignalForwarder) Forward(sig os.Signal) ([]string, error) {
+	names := make([]string, 0, len(f.processes))
+	for name := range f.processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var delivered []string
+	var errs []error
+	for _, name := range names {
+		if f.targets != nil && !f.targets[name] {
+			continue
+		}
+		process := f.processes[name]
+		if process == nil || process.Process == nil || process.Runtime.Type == ServiceTypeContainer {
+			continue
+		}
+		if err := f.send(process.Process, sig); err != nil {
+			errs = append(errs, fmt.Errorf("service %s (pid %d): %w", name, process.Process.Pid, err))
+			continue
+		}
+		delivered = append(delivered, name)
+	}
+	return delivered, errors.Join(errs...)
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSignal is a signal used to check delivery without touching real processes.
+type fakeSignal string
+
+func (s fakeSignal) String() string { return string(s) }
+func (s fakeSignal) Signal()        {}
+
+// signalRecorder captures the signals a SignalForwarder sends, keyed by pid.
+type signalRecorder struct {
+	received map[int][]os.Signal
+	failPID  int
+}
+
+func (r *signalRecorder) send(process *os.Process, sig os.Signal) error {
+	if process.Pid == r.failPID {
+		return errors.New("no such process")
+	}
+	r.received[process.Pid] = append(r.received[process.Pid], sig)
+	return nil
+}
+
+func fakeServiceProcess(name string, pid int, serviceType string) *ServiceProcess {
+	return &ServiceProcess{
+		Name:    name,
+		Process: &os.Process{Pid: pid},
+		Runtime: ServiceRuntime{Name: name, Type: serviceType},
+	}
+}
+
+func TestSignalForwarder_Forward(t *testing.T) {
+	processes := map[string]*ServiceProcess{
+		"api":    fakeServiceProcess("api", 101, ServiceTypeHTTP),
+		"web":    fakeServiceProcess("web", 102, ServiceTypeHTTP),
+		"worker": fakeServiceProcess("worker", 103, ServiceTypeProcess),
+		"redis":  fakeServiceProcess("redis", 104, ServiceTypeContainer),
+		"queued": {Name: "queued"},
+	}
+
+	tests := []struct {
+		name          string
+		targets       []string
+		wantDelivered []string
+		wantPIDs      []int
+	}{
+		{
+			name:          "all services",
+			wantDelivered: []string{"api", "web", "worker"},
+			wantPIDs:      []int{101, 102, 103},
+		},
+		{
+			name:          "selected services",
+			targets:       []string{"worker", "api", "redis"},
+			wantDelivered: []string{"api", "worker"},
+			wantPIDs:      []int{101, 103},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &signalRecorder{received: make(map[int][]os.Signal)}
+			forwarder := NewSignalForwarder(processes, tt.targets)
+			forwarder.send = recorder.send
+
+			for _, sig := range []os.Signal{fakeSignal("hup"), fakeSignal("usr1")} {
+				delivered, err := forwarder.Forward(sig)
+				if err != nil {
+					t.Fatalf("Forward(%v) error = %v", sig, err)
+				}
+				if !reflect.DeepEqual(delivered, tt.wantDelivered) {
+					t.Errorf("Forward(%v) delivered = %v, want %v", sig, delivered, tt.wantDelivered)
+				}
+			}
+
+			if len(recorder.received) != len(tt.wantPIDs) {
+				t.Errorf("signals sent to %d processes, want %d: %v", len(recorder.received), len(tt.wantPIDs), recorder.received)
+			}
+			for _, pid := range tt.wantPIDs {
+				want := []os.Signal{fakeSignal("hup"), fakeSignal("usr1")}
+				if got := recorder.received[pid]; !reflect.DeepEqual(got, want) {
+					t.Errorf("pid %d received %v, want %v", pid, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSignalForwarder_ForwardContinuesAfterError(t *testing.T) {
+	recorder := &signalRecorder{received: make(map[int][]os.Signal), failPID: 101}
+	forwarder := NewSignalForwarder(map[string]*ServiceProcess{
+		"api": fakeServiceProcess("api", 101, ServiceTypeHTTP),
+		"web": fakeServiceProcess("web", 102, ServiceTypeHTTP),
+	}, nil)
+	forwarder.send = recorder.send
+
+	delivered, err := forwarder.Forward(fakeSignal("hup"))
+	if err == nil || !strings.Contains(err.Error(), "service api (pid 101)") {
+		t.Errorf("Forward() error = %v, want failure for api", err)
+	}
+	if !reflect.DeepEqual(delivered, []string{"web"}) {
+		t.Errorf("Forward() delivered = %v, want [web]", delivered)
+	}
+}
+
+func TestParseForwardSignals(t *testing.T) {
+	if !SignalForwardingSupported {
+		if _, err := ParseForwardSignals([]string{"HUP"}); err == nil {
+			t.Error("ParseForwardSignals() should fail where signal forwarding is unsupported")
+		}
+		return
+	}
+
+	signals, err := ParseForwardSignals([]string{"hup", "SIGUSR1", " usr2 ", "SIGHUP"})
+	if err != nil {
+		t.Fatalf("ParseForwardSignals() error = %v", err)
+	}
+	want := []os.Signal{forwardableSignals["HUP"], forwardableSignals["USR1"], forwardableSignals["USR2"]}
+	if !reflect.DeepEqual(signals, want) {
+		t.Errorf("ParseForwardSignals() = %v, want %v", signals, want)
+	}
+
+	for _, name := range []string{"INT", "SIGTERM", "KILL", "bogus"} {
+		if _, err := ParseForwardSignals([]string{name}); err == nil {
+			t.Errorf("ParseForwardSignals(%q) should fail", name)
+		}
+	}
+}
+
+func TestSignalForwarder_DeliversToChildProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signal forwarding is not supported on Windows")
+	}
+
+	// The child records each trapped signal; "ready" marks the traps as installed
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "signals.log")
+	readyFile := filepath.Join(dir, "ready")
+	script := `trap 'echo HUP >> "$1"' HUP; trap 'echo USR1 >> "$1"' USR1; touch "$2"; while :; do sleep 0.05; done`
+	cmd := exec.Command("sh", "-c", script, "sh", logFile, readyFile)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start child: %v", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+	waitForFile(t, readyFile, "")
+
+	forwarder := NewSignalForwarder(map[string]*ServiceProcess{
+		"child": {Name: "child", Process: cmd.Process, Runtime: ServiceRuntime{Type: ServiceTypeProcess}},
+	}, nil)
+	names := []string{"HUP", "USR1"}
+	signals, err := ParseForwardSignals(names)
+	if err != nil {
+		t.Fatalf("ParseForwardSignals() error = %v", err)
+	}
+	for i, sig := range signals {
+		if _, err := forwarder.Forward(sig); err != nil {
+			t.Fatalf("Forward(%v) error = %v", sig, err)
+		}
+		// Wait for each trap so the shell handles the signals one at a time
+		waitForFile(t, logFile, names[i])
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.Fields(string(data)); !reflect.DeepEqual(got, []string{"HUP", "USR1"}) {
+		t.Errorf("child received %v, want [HUP USR1]", got)
+	}
+}
+
+// waitForFile waits until path exists and contains substr.
+func waitForFile(t *testing.T, path, substr string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), substr) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s to contain %q", path, substr)
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"syscall"
+)
+
+// SignalForwardingSupported reports whether reload signals can be relayed on this platform.
+const SignalForwardingSupported = true
+
+// forwardableSignals maps signal names (without the SIG prefix) to signals.
+var forwardableSignals = map[string]os.Signal{
+	"HUP":  syscall.SIGHUP,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+}
+
+// sendSignal delivers sig to the process.
+func sendSignal(process *os.Process, sig os.Signal) error {
+	return process.Signal(sig)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package service
+
+import (
+	"errors"
+	"os"
+)
+
+// SignalForwardingSupported reports whether reload signals can be relayed on this platform.
+// Windows has no SIGHUP, SIGUSR1 or SIGUSR2: consoles only raise Ctrl+C, Ctrl+Break and
+// close/logoff/shutdown events, which Go maps to SIGINT and SIGTERM and which azd app run
+// already uses for shutdown.
+const SignalForwardingSupported = false
+
+// forwardableSignals is empty because no reload signal can be received on Windows.
+var forwardableSignals = map[string]os.Signal{}
+
+// sendSignal is unsupported on Windows, where os.Process.Signal can only kill.
+func sendSignal(_ *os.Process, _ os.Signal) error {
+	return errors.New("signal forwarding is not supported on Windows")
+}
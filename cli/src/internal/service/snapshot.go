@@ -0,0 +1,288 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// SnapshotFileName is the name of the file, in the project's .azure/app directory, that records
+// the configuration of the last 'azd app run'.
+const SnapshotFileName = "last-run.json"
+
+// maskedValue replaces secret values in snapshots, as MaskSecrets does for display.
+const maskedValue = "***"
+
+// RunSnapshot is the resolved configuration of an azd app run: the services that were started,
+// with their commands and ports. Environment values are not stored in full; EnvHash changes
+// whenever any of them does, so two snapshots can be compared without exposing secrets.
+type RunSnapshot struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	EnvHash   string            `json:"envHash"`
+	Services  []RuntimeSnapshot `json:"services"`
+}
+
+// RuntimeSnapshot is the resolved runtime of one service in a RunSnapshot.
+type RuntimeSnapshot struct {
+	Name           string              `json:"name"`
+	Language       string              `json:"language,omitempty"`
+	Framework      string              `json:"framework,omitempty"`
+	PackageManager string              `json:"packageManager,omitempty"`
+	Command        string              `json:"command"`
+	Args           []string            `json:"args,omitempty"`
+	WorkingDir     string              `json:"workingDir"`
+	Port           int                 `json:"port,omitempty"`
+	Ports          []PortMapping       `json:"ports,omitempty"`
+	Protocol       string              `json:"protocol,omitempty"`
+	Type           string              `json:"type,omitempty"`
+	Mode           string              `json:"mode,omitempty"`
+	LogMode        string              `json:"logMode,omitempty"`
+	Env            map[string]string   `json:"env,omitempty"` // Secret values are masked
+	HealthCheck    HealthCheckSnapshot `json:"healthCheck"`
+	Build          *ContainerBuild     `json:"build,omitempty"`
+}
+
+// HealthCheckSnapshot is a HealthCheckConfig with readable durations.
+type HealthCheckSnapshot struct {
+	Type         string   `json:"type,omitempty"`
+	Path         string   `json:"path,omitempty"`
+	ExpectStatus int      `json:"expectStatus,omitempty"`
+	ExpectBody   string   `json:"expectBody,omitempty"`
+	Port         int      `json:"port,omitempty"`
+	Timeout      string   `json:"timeout,omitempty"`
+	Interval     string   `json:"interval,omitempty"`
+	LogMatch     string   `json:"logMatch,omitempty"`
+	Probe        string   `json:"probe,omitempty"`
+	Test         []string `json:"test,omitempty"`
+}
+
+// SnapshotPath returns the location of the run snapshot for the given project directory.
+func SnapshotPath(projectDir string) string {
+	return filepath.Join(projectDir, ".azure", "app", SnapshotFileName)
+}
+
+// NewRunSnapshot captures the resolved runtimes of a run, in service name order.
+// envVars are the variables loaded with --env-file.
+func NewRunSnapshot(runtimes []*ServiceRuntime, services map[string]Service, envVars map[string]string) *RunSnapshot {
+	snapshot := &RunSnapshot{
+		CreatedAt: time.Now().UTC(),
+		EnvHash:   EnvHash(runtimes, services, envVars),
+		Services:  make([]RuntimeSnapshot, 0, len(runtimes)),
+	}
+	for _, rt := range runtimes {
+		snapshot.Services = append(snapshot.Services, newRuntimeSnapshot(rt, services[rt.Name]))
+	}
+	sort.Slice(snapshot.Services, func(i, j int) bool { return snapshot.Services[i].Name < snapshot.Services[j].Name })
+	return snapshot
+}
+
+// Runtimes rebuilds the service runtimes captured in the snapshot. Masked environment values
+// are taken from the services' current azure.yaml environment. Every snapshot service must
+// still be defined in services.
+func (s *RunSnapshot) Runtimes(services map[string]Service) ([]*ServiceRuntime, error) {
+	runtimes := make([]*ServiceRuntime, 0, len(s.Services))
+	for _, snap := range s.Services {
+		svc, ok := services[snap.Name]
+		if !ok {
+			return nil, fmt.Errorf("service %s from the snapshot is no longer defined in azure.yaml", snap.Name)
+		}
+
+		rt, err := snap.runtime(svc)
+		if err != nil {
+			return nil, err
+		}
+		runtimes = append(runtimes, rt)
+	}
+	return runtimes, nil
+}
+
+// newRuntimeSnapshot captures a resolved runtime, masking the secret environment values of svc.
+func newRuntimeSnapshot(rt *ServiceRuntime, svc Service) RuntimeSnapshot {
+	return RuntimeSnapshot{
+		Name:           rt.Name,
+		Language:       rt.Language,
+		Framework:      rt.Framework,
+		PackageManager: rt.PackageManager,
+		Command:        rt.Command,
+		Args:           rt.Args,
+		WorkingDir:     rt.WorkingDir,
+		Port:           rt.Port,
+		Ports:          rt.Ports,
+		Protocol:       rt.Protocol,
+		Type:           rt.Type,
+		Mode:           rt.Mode,
+		LogMode:        rt.LogMode,
+		Env:            maskEnv(svc, rt.Env),
+		HealthCheck: HealthCheckSnapshot{
+			Type:         rt.HealthCheck.Type,
+			Path:         rt.HealthCheck.Path,
+			ExpectStatus: rt.HealthCheck.ExpectStatus,
+			ExpectBody:   rt.HealthCheck.ExpectBody,
+			Port:         rt.HealthCheck.Port,
+			Timeout:      formatSnapshotDuration(rt.HealthCheck.Timeout),
+			Interval:     formatSnapshotDuration(rt.HealthCheck.Interval),
+			LogMatch:     rt.HealthCheck.LogMatch,
+			Probe:        rt.HealthCheck.Probe,
+			Test:         rt.HealthCheck.Test,
+		},
+		Build: rt.Build,
+	}
+}
+
+// runtime rebuilds the captured runtime. Masked environment values are taken from the
+// current azure.yaml environment of svc.
+func (snap RuntimeSnapshot) runtime(svc Service) (*ServiceRuntime, error) {
+	timeout, err := parseSnapshotDuration(snap.HealthCheck.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: invalid health check timeout: %w", snap.Name, err)
+	}
+	interval, err := parseSnapshotDuration(snap.HealthCheck.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: invalid health check interval: %w", snap.Name, err)
+	}
+
+	env := make(map[string]string, len(snap.Env))
+	current := svc.GetEnvironment()
+	for key, value := range snap.Env {
+		if value == maskedValue {
+			value = current[key]
+		}
+		env[key] = value
+	}
+
+	return &ServiceRuntime{
+		Name:           snap.Name,
+		Language:       snap.Language,
+		Framework:      snap.Framework,
+		PackageManager: snap.PackageManager,
+		Command:        snap.Command,
+		Args:           snap.Args,
+		WorkingDir:     snap.WorkingDir,
+		Port:           snap.Port,
+		Ports:          snap.Ports,
+		Protocol:       snap.Protocol,
+		Env:            env,
+		Type:           snap.Type,
+		Mode:           snap.Mode,
+		LogMode:        snap.LogMode,
+		HealthCheck: HealthCheckConfig{
+			Type:         snap.HealthCheck.Type,
+			Path:         snap.HealthCheck.Path,
+			ExpectStatus: snap.HealthCheck.ExpectStatus,
+			ExpectBody:   snap.HealthCheck.ExpectBody,
+			Port:         snap.HealthCheck.Port,
+			Timeout:      timeout,
+			Interval:     interval,
+			LogMatch:     snap.HealthCheck.LogMatch,
+			Probe:        snap.HealthCheck.Probe,
+			Test:         snap.HealthCheck.Test,
+		},
+		Build: snap.Build,
+	}, nil
+}
+
+// WriteRunSnapshot writes the snapshot to SnapshotPath(projectDir), replacing the previous one.
+func WriteRunSnapshot(projectDir string, snapshot *RunSnapshot) error {
+	path := SnapshotPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run snapshot: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write run snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write run snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadRunSnapshot reads a run snapshot written by WriteRunSnapshot.
+func LoadRunSnapshot(path string) (*RunSnapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the project's snapshot file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no run snapshot found at %s - run 'azd app run' first", path)
+		}
+		return nil, fmt.Errorf("failed to read run snapshot: %w", err)
+	}
+
+	var snapshot RunSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse run snapshot %s: %w", path, err)
+	}
+	if len(snapshot.Services) == 0 {
+		return nil, fmt.Errorf("run snapshot %s has no services", path)
+	}
+	return &snapshot, nil
+}
+
+// EnvHash returns a SHA-256 hash of the environment a run passes to its services: the
+// --env-file variables and, per service, its azure.yaml environment and detected variables.
+func EnvHash(runtimes []*ServiceRuntime, services map[string]Service, envVars map[string]string) string {
+	h := sha256.New()
+	writeEnv := func(section string, env map[string]string) {
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(h, "[%s]\n", section)
+		for _, key := range keys {
+			fmt.Fprintf(h, "%s=%s\n", key, env[key])
+		}
+	}
+
+	writeEnv("", envVars)
+
+	sorted := make([]*ServiceRuntime, len(runtimes))
+	copy(sorted, runtimes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, rt := range sorted {
+		svc := services[rt.Name]
+		env := make(map[string]string)
+		for key, value := range svc.GetEnvironment() {
+			env[key] = value
+		}
+		for key, value := range rt.Env {
+			env[key] = value
+		}
+		writeEnv(rt.Name, env)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// maskEnv returns env with secret values masked, or nil if env is empty.
+func maskEnv(svc Service, env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	return MaskSecrets(svc, env)
+}
+
+func formatSnapshotDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func parseSnapshotDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func snapshotTestRuntimes(dir string) []*ServiceRuntime {
+	return []*ServiceRuntime{
+		{
+			Name:       "web",
+			Language:   "js",
+			Framework:  "Vite",
+			Command:    "npm",
+			Args:       []string{"run", "dev", "--", "--port", "5173"},
+			WorkingDir: filepath.Join(dir, "web"),
+			Port:       5173,
+			Protocol:   "http",
+			Env:        map[string]string{},
+			Type:       ServiceTypeHTTP,
+			HealthCheck: HealthCheckConfig{
+				Type: "http", Path: "/", Port: 5173, Timeout: 60 * time.Second, Interval: 2 * time.Second,
+			},
+		},
+		{
+			Name:       "api",
+			Language:   "python",
+			Framework:  "Flask",
+			Command:    "python",
+			Args:       []string{"-m", "flask", "run"},
+			WorkingDir: filepath.Join(dir, "api"),
+			Port:       5000,
+			Env:        map[string]string{"FLASK_APP": "app.py", "API_TOKEN": "s3cret"},
+			Type:       ServiceTypeHTTP,
+			LogMode:    "raw",
+			HealthCheck: HealthCheckConfig{
+				Type: "log", LogMatch: "Running on", Timeout: 30 * time.Second, Interval: time.Second,
+			},
+		},
+		{
+			Name:       "redis",
+			Command:    "redis:7",
+			WorkingDir: dir,
+			Port:       6379,
+			Env:        map[string]string{},
+			Type:       ServiceTypeContainer,
+			HealthCheck: HealthCheckConfig{
+				Type: "tcp", Port: 6379, Timeout: 30 * time.Second, Interval: time.Second,
+				Test: []string{"redis-cli", "ping"},
+			},
+		},
+	}
+}
+
+func TestRunSnapshot_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	runtimes := snapshotTestRuntimes(dir)
+	services := map[string]Service{
+		"web":   {Project: "./web"},
+		"api":   {Project: "./api", Environment: map[string]string{"API_TOKEN": "s3cret"}},
+		"redis": {Image: "redis:7"},
+	}
+	envVars := map[string]string{"LOG_LEVEL": "debug"}
+
+	if err := WriteRunSnapshot(dir, NewRunSnapshot(runtimes, services, envVars)); err != nil {
+		t.Fatalf("WriteRunSnapshot() error = %v", err)
+	}
+
+	path := filepath.Join(dir, ".azure", "app", "last-run.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("snapshot not written to %s: %v", path, err)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("snapshot contains a secret value:\n%s", data)
+	}
+
+	snapshot, err := LoadRunSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadRunSnapshot() error = %v", err)
+	}
+	if snapshot.Services[0].Name != "api" || snapshot.Services[1].Name != "redis" || snapshot.Services[2].Name != "web" {
+		t.Errorf("snapshot services = %v, %v, %v, want api, redis then web",
+			snapshot.Services[0].Name, snapshot.Services[1].Name, snapshot.Services[2].Name)
+	}
+
+	restored, err := snapshot.Runtimes(services)
+	if err != nil {
+		t.Fatalf("Runtimes() error = %v", err)
+	}
+	want := []*ServiceRuntime{runtimes[1], runtimes[2], runtimes[0]}
+	for i := range want {
+		if !reflect.DeepEqual(restored[i], want[i]) {
+			t.Errorf("Runtimes()[%d] =\n%+v\nwant\n%+v", i, *restored[i], *want[i])
+		}
+	}
+	if EnvHash(restored, services, envVars) != snapshot.EnvHash {
+		t.Error("EnvHash() of the restored runtimes differs from the snapshot")
+	}
+}
+
+func TestEnvHash(t *testing.T) {
+	runtimes := snapshotTestRuntimes(t.TempDir())
+	services := map[string]Service{"api": {Environment: map[string]string{"DEBUG": "1"}}}
+	envVars := map[string]string{"LOG_LEVEL": "debug"}
+	base := EnvHash(runtimes, services, envVars)
+
+	reversed := []*ServiceRuntime{runtimes[2], runtimes[1], runtimes[0]}
+	if got := EnvHash(reversed, services, envVars); got != base {
+		t.Error("EnvHash() depends on runtime order")
+	}
+	if got := EnvHash(runtimes, services, map[string]string{"LOG_LEVEL": "info"}); got == base {
+		t.Error("EnvHash() did not change with an --env-file value")
+	}
+	if got := EnvHash(runtimes, map[string]Service{"api": {Environment: map[string]string{"DEBUG": "0"}}}, envVars); got == base {
+		t.Error("EnvHash() did not change with a service environment value")
+	}
+}
+
+func TestRunSnapshot_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadRunSnapshot(SnapshotPath(dir)); err == nil || !strings.Contains(err.Error(), "run 'azd app run' first") {
+		t.Errorf("LoadRunSnapshot() without a snapshot error = %v", err)
+	}
+
+	snapshot := NewRunSnapshot(snapshotTestRuntimes(dir), nil, nil)
+	if _, err := snapshot.Runtimes(map[string]Service{"web": {}}); err == nil || !strings.Contains(err.Error(), "service api from the snapshot is no longer defined") {
+		t.Errorf("Runtimes() with a removed service error = %v", err)
+	}
+}
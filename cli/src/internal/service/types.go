@@ -20,6 +20,11 @@ const (
 	// Health checks use TCP port connectivity.
 	ServiceTypeTCP = "tcp"
 
+	// ServiceTypeUDP indicates a service that receives UDP datagrams (metrics collectors, DNS).
+	// Health checks confirm the UDP port is bound, or that the service answers a probe datagram.
+	// UDP readiness is best-effort since the protocol has no connection to establish.
+	ServiceTypeUDP = "udp"
+
 	// ServiceTypeProcess indicates a service with no network endpoint.
 	// Health checks verify the process is running. This is the default for services without ports.
 	ServiceTypeProcess = "process"
@@ -30,6 +35,11 @@ const (
 	ServiceTypeContainer = "container"
 )
 
+// HealthCheckTypeWebSocket is the healthcheck type of realtime services that only answer
+// WebSocket upgrade requests: the service is ready once a WebSocket handshake on the
+// healthcheck path completes.
+const HealthCheckTypeWebSocket = "ws"
+
 // Service mode constants define the lifecycle behavior of process-type services.
 const (
 	// ServiceModeWatch indicates a continuous process that watches for file changes.
@@ -57,6 +67,17 @@ const (
 	ServiceModeTask = "task"
 )
 
+// Log mode constants define how a native service's stdout/stderr is captured.
+const (
+	// LogModeLine splits output on newlines into log entries (buffered, filterable, shown in
+	// the dashboard and 'azd app logs'). This is the default.
+	LogModeLine = "line"
+
+	// LogModeRaw passes output bytes through to the terminal unchanged, without log buffering.
+	// Use for TTY-style progress output that redraws lines with carriage returns.
+	LogModeRaw = "raw"
+)
+
 // AzureYaml represents the parsed azure.yaml file.
 type AzureYaml struct {
 	Name      string              `yaml:"name"`
@@ -88,27 +109,40 @@ type Service struct {
 	Logs               *LogsConfig        `yaml:"logs,omitempty"`        // Service-level logging configuration
 	Healthcheck        *HealthcheckConfig `yaml:"healthcheck,omitempty"` // Docker Compose-compatible health check configuration
 	HealthcheckEnabled *bool              `yaml:"-"`                     // Internal flag: nil = use default, false = explicitly disabled, true = explicitly enabled
-	Type               string             `yaml:"type,omitempty"`        // Service type: "http", "tcp", "process". Default: "http" if ports defined, "process" otherwise.
+	Type               string             `yaml:"type,omitempty"`        // Service type: "http", "tcp", "udp", "process". Default: "http" if ports defined, "process" otherwise.
 	Mode               string             `yaml:"mode,omitempty"`        // Run mode (for type=process): "watch", "build", "daemon", "task". Default: "daemon".
+	LogMode            string             `yaml:"logMode,omitempty"`     // Output capture: "line" (default) or "raw" (pass bytes through to the terminal)
+	LogFile            string             `yaml:"logFile,omitempty"`     // File the service's output is logged to, within the project (default: .azure/logs/<service>.log)
+	Sidecars           map[string]Service `yaml:"sidecars,omitempty"`    // Inline services that start and stop with this service (e.g. a local redis used only by it)
+	SidecarOf          string             `yaml:"-"`                     // Internal: parent service name when this service was expanded from an inline sidecar
+	WatchPaths         []string           `yaml:"watchPaths,omitempty"`  // Files or directories watched by 'azd app run --watch' (default: the project directory)
+	WatchIgnore        []string           `yaml:"watchIgnore,omitempty"` // Glob patterns 'azd app run --watch' ignores
+	Hooks              *ServiceHooks      `yaml:"hooks,omitempty"`       // Commands 'azd app deps' runs around the service's dependency install
 }
 
 // serviceRaw is used to handle both boolean and object healthcheck values.
 // It duplicates all fields from Service except Healthcheck to avoid infinite recursion.
 type serviceRaw struct {
-	Host        string        `yaml:"host"`
-	Language    string        `yaml:"language,omitempty"`
-	Project     string        `yaml:"project,omitempty"`
-	Entrypoint  string        `yaml:"entrypoint,omitempty"`
-	Command     string        `yaml:"command,omitempty"`
-	Image       string        `yaml:"image,omitempty"`
-	Docker      *DockerConfig `yaml:"docker,omitempty"`
-	Ports       []string      `yaml:"ports,omitempty"`
-	Environment Environment   `yaml:"environment,omitempty"`
-	Uses        []string      `yaml:"uses,omitempty"`
-	Logs        *LogsConfig   `yaml:"logs,omitempty"`
-	Healthcheck any           `yaml:"healthcheck,omitempty"`
-	Type        string        `yaml:"type,omitempty"`
-	Mode        string        `yaml:"mode,omitempty"`
+	Host        string             `yaml:"host"`
+	Language    string             `yaml:"language,omitempty"`
+	Project     string             `yaml:"project,omitempty"`
+	Entrypoint  string             `yaml:"entrypoint,omitempty"`
+	Command     string             `yaml:"command,omitempty"`
+	Image       string             `yaml:"image,omitempty"`
+	Docker      *DockerConfig      `yaml:"docker,omitempty"`
+	Ports       []string           `yaml:"ports,omitempty"`
+	Environment Environment        `yaml:"environment,omitempty"`
+	Uses        []string           `yaml:"uses,omitempty"`
+	Logs        *LogsConfig        `yaml:"logs,omitempty"`
+	Healthcheck any                `yaml:"healthcheck,omitempty"`
+	Type        string             `yaml:"type,omitempty"`
+	Mode        string             `yaml:"mode,omitempty"`
+	LogMode     string             `yaml:"logMode,omitempty"`
+	LogFile     string             `yaml:"logFile,omitempty"`
+	Sidecars    map[string]Service `yaml:"sidecars,omitempty"`
+	WatchPaths  []string           `yaml:"watchPaths,omitempty"`
+	WatchIgnore []string           `yaml:"watchIgnore,omitempty"`
+	Hooks       *ServiceHooks      `yaml:"hooks,omitempty"`
 }
 
 // UnmarshalYAML implements custom YAML unmarshaling to handle healthcheck: false.
@@ -132,6 +166,12 @@ func (s *Service) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	s.Logs = raw.Logs
 	s.Type = raw.Type
 	s.Mode = raw.Mode
+	s.LogMode = raw.LogMode
+	s.LogFile = raw.LogFile
+	s.Sidecars = raw.Sidecars
+	s.WatchPaths = raw.WatchPaths
+	s.WatchIgnore = raw.WatchIgnore
+	s.Hooks = raw.Hooks
 
 	// Handle healthcheck field
 	switch v := raw.Healthcheck.(type) {
@@ -179,6 +219,25 @@ func (s *Service) IsHealthcheckDisabled() bool {
 	return s.Healthcheck.IsDisabled()
 }
 
+// GetHealthCheckType returns how the service's health is checked: "none" when health checks
+// are disabled, the configured healthcheck type, or by default "udp" for UDP services, "http"
+// for other services with a port and "process" for services without one.
+func (s *Service) GetHealthCheckType() string {
+	if s.IsHealthcheckDisabled() {
+		return "none"
+	}
+	if s.Healthcheck != nil && s.Healthcheck.Type != "" {
+		return s.Healthcheck.Type
+	}
+	if s.GetServiceType() == ServiceTypeUDP {
+		return ServiceTypeUDP
+	}
+	if s.NeedsPort() {
+		return "http"
+	}
+	return "process"
+}
+
 // NeedsPort returns true if this service needs a port assigned.
 // Services must explicitly define ports in azure.yaml to have a port assigned.
 // Services without ports (e.g., build/watch services like tsc --watch) will use
@@ -190,7 +249,8 @@ func (s *Service) NeedsPort() bool {
 }
 
 // GetServiceType returns the service type, inferring from configuration if not explicitly set.
-// Returns: "container" (if image is defined), "http" (default if ports defined), "tcp", or "process" (default if no ports).
+// Returns: "container" (if image is defined), "udp" (if the healthcheck type is udp),
+// "http" (default if ports defined), "tcp", or "process" (default if no ports).
 func (s *Service) GetServiceType() string {
 	// If explicitly set, use that
 	if s.Type != "" {
@@ -202,6 +262,11 @@ func (s *Service) GetServiceType() string {
 		return ServiceTypeContainer
 	}
 
+	// A UDP healthcheck only makes sense for a UDP service
+	if s.Healthcheck != nil && s.Healthcheck.Type == ServiceTypeUDP {
+		return ServiceTypeUDP
+	}
+
 	// Infer from configuration
 	if s.NeedsPort() {
 		return ServiceTypeHTTP
@@ -228,29 +293,39 @@ func (s *Service) GetServiceMode() string {
 	return ServiceModeDaemon
 }
 
+// GetLogMode returns how the service's output is captured.
+// Returns LogModeLine unless raw mode is explicitly configured.
+func (s *Service) GetLogMode() string {
+	if s.LogMode == "" {
+		return LogModeLine
+	}
+	return s.LogMode
+}
+
 // IsProcessService returns true if this is a process-type service (no network endpoint).
 func (s *Service) IsProcessService() bool {
 	return s.GetServiceType() == ServiceTypeProcess
 }
 
 // IsContainerService returns true if this service should run as a Docker container.
-// A service is a container service when it has an `image` field (direct image reference)
-// or a `docker.image` field (Docker config with image).
+// A service is a container service when it has an `image` field (direct image reference),
+// a `docker.image` field (Docker config with image) or `type: container` with a `docker.path`
+// field (image built from a Dockerfile).
 // Container services are launched via Docker rather than running as native processes.
 func (s *Service) IsContainerService() bool {
-	// Direct image reference
-	if s.Image != "" {
-		return true
-	}
-	// Docker config with image
-	if s.Docker != nil && s.Docker.Image != "" {
-		return true
-	}
-	return false
+	return s.GetContainerImage() != "" || s.NeedsImageBuild()
 }
 
-// GetContainerImage returns the Docker image for a container service.
-// Returns empty string if not a container service.
+// NeedsImageBuild returns true if this container service's image is built from its
+// `docker.path` Dockerfile rather than referenced directly.
+// Building is opt-in with `type: container`: standard azd services also have a
+// `docker.path` (used for deployment) but run natively.
+func (s *Service) NeedsImageBuild() bool {
+	return s.Type == ServiceTypeContainer && s.GetContainerImage() == "" && s.Docker != nil && s.Docker.Path != ""
+}
+
+// GetContainerImage returns the prebuilt Docker image for a container service.
+// Returns empty string if not a container service or if the image is built from a Dockerfile.
 func (s *Service) GetContainerImage() string {
 	if s.Image != "" {
 		return s.Image
@@ -285,23 +360,37 @@ type HealthcheckConfig struct {
 	//   - ["NONE"] (disable health check)
 	Test any `yaml:"test,omitempty"`
 
-	// Type specifies the health check method: "http", "tcp", "process", "output", or "none".
+	// Type specifies the health check method: "http", "ws", "tcp", "udp", "process", "output", or "none".
 	// - "http": Check an HTTP endpoint (default)
+	// - "ws": Complete a WebSocket handshake on Path ("websocket" is accepted as an alias)
 	// - "tcp": Check if a port is listening
+	// - "udp": Check if a UDP port is bound, or that the service answers Probe (best-effort)
 	// - "process": Check if the process is running
 	// - "output": Monitor stdout for a pattern match
 	// - "none": Disable health checks (service is always considered healthy)
 	Type string `yaml:"type,omitempty"`
 
-	// Path is the HTTP path for health checks (when type=http).
+	// Path is the HTTP path for health checks (when type=http or ws).
 	// Defaults to "/health".
 	Path string `yaml:"path,omitempty"`
 
+	// ExpectStatus is the exact HTTP status code the service must return (when type=http).
+	// Without it any 2xx or 3xx status is healthy.
+	ExpectStatus int `yaml:"expectStatus,omitempty"`
+
+	// ExpectBody is a substring or regex the HTTP response body must match (when type=http),
+	// e.g. '"status":"healthy"'. Useful for services that return 200 while degraded.
+	ExpectBody string `yaml:"expectBody,omitempty"`
+
 	// Pattern is a regex pattern to match in stdout (when type=output).
 	// Service is considered healthy when this pattern is matched.
 	// Examples: "Found 0 errors", "Server started", "Listening on port"
 	Pattern string `yaml:"pattern,omitempty"`
 
+	// Probe is a datagram sent to the service's port (when type=udp).
+	// Service is considered healthy when it replies. Without a probe, a bound port is enough.
+	Probe string `yaml:"probe,omitempty"`
+
 	// Interval is the time between health checks (e.g., "30s", "1m").
 	Interval string `yaml:"interval,omitempty"`
 
@@ -370,6 +459,31 @@ func (h *HealthcheckConfig) GetType() string {
 	return "http"
 }
 
+// GetTest returns the Docker Compose-style test as a command line: a string test is a single
+// element, and non-string elements of a list are dropped. Returns nil without a test.
+func (h *HealthcheckConfig) GetTest() []string {
+	if h == nil {
+		return nil
+	}
+	switch t := h.Test.(type) {
+	case string:
+		if t != "" {
+			return []string{t}
+		}
+	case []any:
+		var test []string
+		for _, item := range t {
+			if str, ok := item.(string); ok {
+				test = append(test, str)
+			}
+		}
+		return test
+	case []string:
+		return t
+	}
+	return nil
+}
+
 // DockerConfig represents Docker build configuration.
 type DockerConfig struct {
 	Path        string   `yaml:"path,omitempty"`
@@ -476,31 +590,61 @@ type ServiceRuntime struct {
 	Command               string
 	Args                  []string
 	WorkingDir            string
-	Port                  int
+	Port                  int           // Primary port: the host port of the first mapping in Ports
+	Ports                 []PortMapping // Every declared port mapping, with host ports resolved
 	Protocol              string
 	Env                   map[string]string
 	HealthCheck           HealthCheckConfig
-	ShouldUpdateAzureYaml bool   // True if user wants port added to azure.yaml
-	Type                  string // Service type: "http", "tcp", "process"
-	Mode                  string // Run mode (for type=process): "watch", "build", "daemon", "task"
+	ShouldUpdateAzureYaml bool            // True if user wants port added to azure.yaml
+	Type                  string          // Service type: "http", "tcp", "udp", "process"
+	Mode                  string          // Run mode (for type=process): "watch", "build", "daemon", "task"
+	LogMode               string          // Output capture: "line" or "raw"
+	Build                 *ContainerBuild // For container services built from a Dockerfile, nil otherwise
+}
+
+// HostPorts returns the distinct host ports of the runtime, the primary port first.
+func (rt *ServiceRuntime) HostPorts() []int {
+	var ports []int
+	seen := make(map[int]bool)
+	for _, port := range append([]int{rt.Port}, mappedHostPorts(rt.Ports)...) {
+		if port > 0 && !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// mappedHostPorts returns the host port of each mapping.
+func mappedHostPorts(mappings []PortMapping) []int {
+	ports := make([]int, len(mappings))
+	for i, mapping := range mappings {
+		ports[i] = mapping.HostPort
+	}
+	return ports
 }
 
 // PortMapping represents a port mapping (Docker Compose style).
 type PortMapping struct {
-	HostPort      int    // Port on host machine (0 = auto-assign)
-	ContainerPort int    // Port inside container (for Docker) or app port (for non-Docker)
-	BindIP        string // IP to bind to (e.g., "127.0.0.1"), empty = all interfaces
-	Protocol      string // "tcp" or "udp", defaults to "tcp"
+	Name          string `json:"name,omitempty"`   // Optional name (e.g., "gateway" in "gateway=8081:8081")
+	HostPort      int    `json:"hostPort"`         // Port on host machine (0 = auto-assign)
+	ContainerPort int    `json:"containerPort"`    // Port inside container (for Docker) or app port (for non-Docker)
+	BindIP        string `json:"bindIp,omitempty"` // IP to bind to (e.g., "127.0.0.1"), empty = all interfaces
+	Protocol      string `json:"protocol"`         // "tcp" or "udp", defaults to "tcp"
 }
 
 // HealthCheckConfig defines how to check if a service is ready.
 type HealthCheckConfig struct {
-	Type     string        // "http", "port", "process", "log"
-	Path     string        // For HTTP health checks (e.g., "/health")
-	Port     int           // Port to check
-	Timeout  time.Duration // How long to wait for service to be ready
-	Interval time.Duration // How often to retry
-	LogMatch string        // For log-based checks (e.g., "Server started")
+	Type         string        // "http", "ws", "tcp", "udp", "process", "output", "none"
+	Path         string        // For HTTP and WebSocket health checks (e.g., "/health")
+	ExpectStatus int           // For HTTP checks: exact status code required (0 accepts any 2xx or 3xx)
+	ExpectBody   string        // For HTTP checks: substring or regex the response body must match
+	Port         int           // Port to check
+	Timeout      time.Duration // How long to wait for service to be ready
+	Interval     time.Duration // How often to retry
+	LogMatch     string        // For output checks: pattern to wait for (e.g., "Server started")
+	Probe        string        // For UDP checks: datagram the service must reply to
+	Test         []string      // For container services: Docker Compose-style test that replaces Type
 }
 
 // ServiceProcess represents a running service process.
@@ -549,11 +693,13 @@ type OrchestrationOptions struct {
 
 // LogEntry represents a log entry from a service.
 type LogEntry struct {
-	Service   string    `json:"service"`
-	Message   string    `json:"message"`
-	Level     LogLevel  `json:"level"`
-	Timestamp time.Time `json:"timestamp"`
-	IsStderr  bool      `json:"isStderr"`
+	Service   string         `json:"service"`
+	Message   string         `json:"message"`
+	Level     LogLevel       `json:"level"`
+	Timestamp time.Time      `json:"timestamp"`
+	IsStderr  bool           `json:"isStderr"`
+	Fields    map[string]any `json:"fields,omitempty"`    // Parsed fields for structured (JSON) log lines
+	Truncated bool           `json:"truncated,omitempty"` // Message was cut at the maximum line size
 }
 
 // LogLevel represents the severity of a log message.
@@ -821,6 +967,13 @@ func (h *Hooks) GetPostrun() *Hook {
 	return h.Postrun
 }
 
+// ServiceHooks represents the dependency install hooks of a service. Each entry is a command
+// with arguments, run in the service's project directory with its environment.
+type ServiceHooks struct {
+	Preinstall  []string `yaml:"preinstall,omitempty"`  // Run before the package manager install (e.g. codegen)
+	Postinstall []string `yaml:"postinstall,omitempty"` // Run after a successful install (e.g. a build)
+}
+
 // Hook represents a lifecycle hook configuration.
 type Hook struct {
 	Run             string        `yaml:"run"`                       // Script or command to execute
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/detector"
+	"github.com/jongio/azd-app/cli/src/internal/security"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity levels of azure.yaml validation findings
+const (
+	SeverityError   = "error"   // azd app can't run the project as configured
+	SeverityWarning = "warning" // Likely a mistake, such as a misspelled field
+	SeverityInfo    = "info"    // Worth knowing, but fine for local development
+)
+
+// ValidationFinding is a problem found in azure.yaml by ValidateAzureYaml.
+type ValidationFinding struct {
+	Severity string `json:"severity"`
+	Service  string `json:"service,omitempty"`
+	Field    string `json:"field,omitempty"` // Dotted path of the field, e.g. services.api.healthcheck
+	Line     int    `json:"line,omitempty"`  // Line in azure.yaml, when known
+	Message  string `json:"message"`
+}
+
+// Fields azd itself understands, which azd app ignores but must not report as unknown
+var (
+	azdTopLevelFields = []string{"resourceGroup", "infra", "pipeline", "requiredVersions", "state", "platform", "workflows", "cloud"}
+	azdServiceFields  = []string{"dist", "module", "k8s", "resourceName", "resourceGroup", "apiVersion", "env", "config", "remoteBuild", "condition"}
+)
+
+// Fields azd app reads with types outside this package: reqs (azd app reqs) and test (azd app test)
+var (
+	appTopLevelFields = []string{"reqs", "test"}
+	appServiceFields  = []string{"test"}
+)
+
+// knownHosts are the azd service hosts.
+var knownHosts = []string{"containerapp", "appservice", "function", "staticwebapp", "aks", "springapp", "ai.endpoint", "azure.ai.agent"}
+
+// ValidateAzureYaml checks the azure.yaml found from workingDir against the fields azd app
+// understands: unknown (often misspelled) fields, missing or invalid service settings, uses
+// that name nothing, and project paths that don't exist or escape the project directory.
+// It returns the path of azure.yaml and the findings, service by service in file order.
+// Only a missing or unreadable azure.yaml is returned as an error.
+func ValidateAzureYaml(workingDir string) (string, []ValidationFinding, error) {
+	azureYamlPath, err := detector.FindAzureYaml(workingDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find azure.yaml: %w", err)
+	}
+	if azureYamlPath == "" {
+		return "", nil, fmt.Errorf("azure.yaml not found in %s or parent directories", workingDir)
+	}
+	if err := security.ValidatePath(azureYamlPath); err != nil {
+		return "", nil, fmt.Errorf("invalid azure.yaml path: %w", err)
+	}
+	// #nosec G304 -- Path validated by security.ValidatePath
+	data, err := os.ReadFile(azureYamlPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read azure.yaml: %w", err)
+	}
+
+	azureYamlDir := filepath.Dir(azureYamlPath)
+	azureYaml, err := ParseAzureYaml(azureYamlDir)
+	if err != nil {
+		return azureYamlPath, []ValidationFinding{{Severity: SeverityError, Message: err.Error()}}, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return azureYamlPath, []ValidationFinding{{Severity: SeverityError, Message: "azure.yaml is empty"}}, nil
+	}
+
+	v := &azureYamlValidator{azureYaml: azureYaml, azureYamlDir: azureYamlDir}
+	v.validate(root.Content[0])
+	return azureYamlPath, v.findings, nil
+}
+
+// azureYamlValidator collects the findings of ValidateAzureYaml.
+type azureYamlValidator struct {
+	azureYaml    *AzureYaml
+	azureYamlDir string
+	findings     []ValidationFinding
+}
+
+// add records a finding.
+func (v *azureYamlValidator) add(severity, serviceName, field string, line int, format string, args ...any) {
+	v.findings = append(v.findings, ValidationFinding{
+		Severity: severity,
+		Service:  serviceName,
+		Field:    field,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// validate checks the document's top-level fields and then each service in file order.
+func (v *azureYamlValidator) validate(doc *yaml.Node) {
+	if doc.Kind != yaml.MappingNode {
+		v.add(SeverityError, "", "", doc.Line, "azure.yaml must be a mapping of fields such as name and services")
+		return
+	}
+
+	topLevelFields := append(yamlFieldNames(reflect.TypeOf(AzureYaml{})), appTopLevelFields...)
+	v.checkUnknownFields(doc, "", "", append(topLevelFields, azdTopLevelFields...))
+
+	if v.azureYaml.Name == "" {
+		v.add(SeverityWarning, "", "name", doc.Line, "name is not set; azd uses it to name the project's environments and resources")
+	}
+
+	services := mappingValue(doc, "services")
+	if services == nil || services.Kind != yaml.MappingNode || len(services.Content) == 0 {
+		v.add(SeverityWarning, "", "services", doc.Line, "no services are defined, so azd app has nothing to run")
+		return
+	}
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name := services.Content[i].Value
+		v.validateService(name, v.azureYaml.Services[name], services.Content[i+1], "services."+name)
+	}
+}
+
+// validateService checks one service, and its sidecars as services of their own.
+func (v *azureYamlValidator) validateService(name string, svc Service, node *yaml.Node, path string) {
+	if node.Kind != yaml.MappingNode {
+		v.add(SeverityError, name, path, node.Line, "service %s must be a mapping of fields such as language and project", name)
+		return
+	}
+
+	serviceFields := append(yamlFieldNames(reflect.TypeOf(Service{})), appServiceFields...)
+	v.checkUnknownFields(node, name, path, append(serviceFields, azdServiceFields...))
+	if healthcheck := mappingValue(node, "healthcheck"); healthcheck != nil && healthcheck.Kind == yaml.MappingNode {
+		v.checkUnknownFields(healthcheck, name, path+".healthcheck", yamlFieldNames(reflect.TypeOf(HealthcheckConfig{})))
+	}
+
+	line := func(field string) int {
+		if value := mappingValue(node, field); value != nil {
+			return value.Line
+		}
+		return node.Line
+	}
+
+	// The raw project path, before ParseAzureYaml resolved it
+	project := ""
+	if value := mappingValue(node, "project"); value != nil {
+		project = value.Value
+	}
+
+	if svc.Host != "" && !slices.Contains(knownHosts, svc.Host) {
+		v.add(SeverityWarning, name, path+".host", line("host"), "unknown host %q (azd supports %s)", svc.Host, strings.Join(knownHosts, ", "))
+	}
+
+	codeHost := svc.Host == "appservice" || svc.Host == "function" || svc.Host == "staticwebapp"
+	switch {
+	case codeHost && project == "":
+		v.add(SeverityError, name, path+".project", node.Line, "host %s deploys code, so service %s needs a project path", svc.Host, name)
+	case svc.Type == ServiceTypeContainer && !svc.IsContainerService():
+		v.add(SeverityError, name, path+".type", line("type"), "container service %s needs an image or a docker.path", name)
+	case svc.IsContainerService():
+		// Runs from its image or Dockerfile; project and language are optional
+	case project == "":
+		v.add(SeverityError, name, path+".project", node.Line, "service %s needs a project path, an image, or type container with a docker.path to run", name)
+	case svc.Language == "":
+		v.add(SeverityInfo, name, path+".language", node.Line, "language is not set, so azd app detects it from the project files")
+	}
+	if svc.Language != "" && normalizeLanguage(svc.Language) == svc.Language && !slices.Contains(knownLanguageNames, svc.Language) {
+		v.add(SeverityWarning, name, path+".language", line("language"), "unknown language %q (azd app runs js, ts, python, csharp, java, go, rust, php, docker and logicapps)", svc.Language)
+	}
+
+	if project != "" {
+		if dir, err := ResolveServiceDir(v.azureYamlDir, project); err != nil {
+			v.add(SeverityError, name, path+".project", line("project"), "%v", err)
+		} else if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			v.add(SeverityError, name, path+".project", line("project"), "project path '%s' does not exist", project)
+		}
+	}
+
+	switch svc.Type {
+	case "", ServiceTypeHTTP, ServiceTypeTCP, ServiceTypeUDP, ServiceTypeProcess, ServiceTypeContainer:
+	default:
+		v.add(SeverityError, name, path+".type", line("type"), "invalid type %q (must be http, tcp, udp, process or container)", svc.Type)
+	}
+	switch svc.Mode {
+	case "", ServiceModeWatch, ServiceModeBuild, ServiceModeDaemon, ServiceModeTask:
+	default:
+		v.add(SeverityError, name, path+".mode", line("mode"), "invalid mode %q (must be watch, build, daemon or task)", svc.Mode)
+	}
+	if logMode := svc.GetLogMode(); logMode != LogModeLine && logMode != LogModeRaw {
+		v.add(SeverityError, name, path+".logMode", line("logMode"), "invalid logMode %q (must be '%s' or '%s')", svc.LogMode, LogModeLine, LogModeRaw)
+	}
+
+	for _, dependency := range svc.Uses {
+		_, isService := v.azureYaml.Services[dependency]
+		_, isResource := v.azureYaml.Resources[dependency]
+		if !isService && !isResource {
+			v.add(SeverityError, name, path+".uses", line("uses"), "uses %q, which is neither a service nor a resource in azure.yaml", dependency)
+		}
+	}
+
+	for _, err := range ValidateServiceConsistency(name, svc) {
+		var conflict *ConsistencyError
+		if errors.As(err, &conflict) {
+			v.add(SeverityError, name, path+"."+conflict.Fields[0], line(strings.SplitN(conflict.Fields[0], ".", 2)[0]), "%s", conflict.Reason)
+		}
+	}
+
+	if sidecars := mappingValue(node, "sidecars"); sidecars != nil && sidecars.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(sidecars.Content); i += 2 {
+			sidecarName := sidecars.Content[i].Value
+			v.validateService(name+"-"+sidecarName, svc.Sidecars[sidecarName], sidecars.Content[i+1], path+".sidecars."+sidecarName)
+		}
+	}
+}
+
+// checkUnknownFields warns about the keys of a mapping that aren't in known, suggesting the
+// closest known field for likely typos such as helthcheck.
+func (v *azureYamlValidator) checkUnknownFields(node *yaml.Node, serviceName, path string, known []string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if slices.Contains(known, key.Value) {
+			continue
+		}
+		field := key.Value
+		if path != "" {
+			field = path + "." + key.Value
+		}
+		if suggestion := closestField(key.Value, known); suggestion != "" {
+			v.add(SeverityWarning, serviceName, field, key.Line, "unknown field %q, did you mean %q?", key.Value, suggestion)
+		} else {
+			v.add(SeverityWarning, serviceName, field, key.Line, "unknown field %q is ignored", key.Value)
+		}
+	}
+}
+
+// knownLanguageNames are the display names normalizeLanguage returns.
+var knownLanguageNames = []string{"JavaScript", "TypeScript", "Python", ".NET", "Java", "Go", "Rust", "PHP", "Docker", "Logic Apps"}
+
+// yamlFieldNames returns the yaml keys of a struct's fields.
+func yamlFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// closestField returns the known field closest to name when it is a likely misspelling:
+// the same ignoring case, or at most two edits away (one for short names).
+func closestField(name string, known []string) string {
+	sorted := append([]string(nil), known...)
+	sort.Strings(sorted)
+
+	best, bestDistance := "", 0
+	for _, candidate := range sorted {
+		if strings.EqualFold(candidate, name) {
+			return candidate
+		}
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if best == "" || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	maxDistance := 2
+	if len(name) <= 4 {
+		maxDistance = 1
+	}
+	if best == "" || bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
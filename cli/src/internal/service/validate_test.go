@@ -0,0 +1,114 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateAzureYaml(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	azureYaml := `name: demo
+infra:
+  provider: bicep
+services:
+  api:
+    language: python
+    project: ./api
+    host: containerapp
+    helthcheck:
+      path: /health
+    uses: [db, cache]
+    test:
+      unit:
+        command: pytest
+  web:
+    language: ts
+    project: ../outside
+    ports: ["3000"]
+    healthcheck:
+      typ: http
+  worker:
+    language: cobol
+    project: ./worker
+    mode: forever
+  site:
+    host: staticwebapp
+    image: nginx
+  cache:
+    image: redis:7
+  queue:
+    type: container
+resources:
+  db:
+    type: db.postgres
+`
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte(azureYaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	path, findings, err := ValidateAzureYaml(dir)
+	if err != nil {
+		t.Fatalf("ValidateAzureYaml() error = %v", err)
+	}
+	if path != filepath.Join(dir, "azure.yaml") {
+		t.Errorf("path = %s, want the azure.yaml in %s", path, dir)
+	}
+
+	want := []ValidationFinding{
+		{Severity: SeverityWarning, Service: "api", Field: "services.api.helthcheck", Line: 9, Message: `unknown field "helthcheck", did you mean "healthcheck"?`},
+		{Severity: SeverityWarning, Service: "web", Field: "services.web.healthcheck.typ", Line: 20, Message: `unknown field "typ", did you mean "type"?`},
+		{Severity: SeverityError, Service: "web", Field: "services.web.project", Line: 17, Message: "project path '../outside' escapes project boundary"},
+		{Severity: SeverityWarning, Service: "worker", Field: "services.worker.language", Line: 22, Message: `unknown language "cobol" (azd app runs js, ts, python, csharp, java, go, rust, php, docker and logicapps)`},
+		{Severity: SeverityError, Service: "worker", Field: "services.worker.project", Line: 23, Message: "project path './worker' does not exist"},
+		{Severity: SeverityError, Service: "worker", Field: "services.worker.mode", Line: 24, Message: `invalid mode "forever" (must be watch, build, daemon or task)`},
+		{Severity: SeverityError, Service: "site", Field: "services.site.project", Line: 26, Message: "host staticwebapp deploys code, so service site needs a project path"},
+		{Severity: SeverityError, Service: "queue", Field: "services.queue.type", Line: 31, Message: "container service queue needs an image or a docker.path"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("findings = %+v, want %d findings", findings, len(want))
+	}
+	for i := range want {
+		if findings[i] != want[i] {
+			t.Errorf("finding %d = %+v, want %+v", i, findings[i], want[i])
+		}
+	}
+}
+
+func TestValidateAzureYamlMissingProject(t *testing.T) {
+	dir := t.TempDir()
+	azureYaml := "services:\n  api:\n    language: python\n"
+	if err := os.WriteFile(filepath.Join(dir, "azure.yaml"), []byte(azureYaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, findings, err := ValidateAzureYaml(dir)
+	if err != nil {
+		t.Fatalf("ValidateAzureYaml() error = %v", err)
+	}
+	if len(findings) != 2 || findings[0].Field != "name" || findings[1].Field != "services.api.project" || findings[1].Severity != SeverityError {
+		t.Errorf("findings = %+v, want a missing name warning and a missing project error", findings)
+	}
+}
+
+func TestClosestField(t *testing.T) {
+	known := []string{"healthcheck", "language", "project", "ports", "uses", "type"}
+	tests := map[string]string{
+		"helthcheck": "healthcheck",
+		"Language":   "language",
+		"projct":     "project",
+		"port":       "ports",
+		"typ":        "type",
+		"use":        "uses",
+		"xyz":        "",
+		"framework":  "",
+	}
+	for name, want := range tests {
+		if got := closestField(name, known); got != want {
+			t.Errorf("closestField(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is how long a service's files must stay quiet before it is restarted,
+// so that saving several files at once causes a single restart.
+const DefaultWatchDebounce = 300 * time.Millisecond
+
+// DefaultWatchIgnore lists dependency, cache and build output directories that are never watched.
+// .azure holds azd app's own logs, caches and pid file, which change while services run.
+var DefaultWatchIgnore = []string{
+	".git",
+	".azure",
+	"node_modules",
+	"__pycache__",
+	"*.pyc",
+	".venv",
+	"venv",
+	"bin",
+	"obj",
+	"dist",
+	".DS_Store",
+}
+
+// WatchTarget describes the files watched for one service.
+type WatchTarget struct {
+	Service string   // Service name
+	Root    string   // Service project directory; Paths and Ignore are relative to it
+	Paths   []string // Files or directories to watch (default: the whole Root)
+	Ignore  []string // Glob patterns to skip, in addition to DefaultWatchIgnore
+}
+
+// WatchTargetFor builds the watch target for a service from its watchPaths and watchIgnore settings.
+// A logFile within root is ignored with its rotations, since the service writes to it while running.
+func WatchTargetFor(name string, svc Service, root string) WatchTarget {
+	ignore := svc.WatchIgnore
+	if svc.LogFile != "" && isWithin(root, svc.LogFile) {
+		if rel, err := filepath.Rel(root, svc.LogFile); err == nil && rel != "." {
+			rel = filepath.ToSlash(rel)
+			ignore = append(slices.Clip(ignore), rel, rel+".*")
+		}
+	}
+	return WatchTarget{Service: name, Root: root, Paths: svc.WatchPaths, Ignore: ignore}
+}
+
+// ChangeHandler is called once a service's files have settled after a change.
+// file is the last changed file, relative to the service's Root.
+type ChangeHandler func(serviceName, file string)
+
+// ServiceWatcher watches service source files and reports debounced changes per service.
+type ServiceWatcher struct {
+	watcher  *fsnotify.Watcher
+	targets  []watchScope
+	debounce time.Duration
+	onChange ChangeHandler
+
+	mu      sync.Mutex
+	pending map[string]*pendingChange // service name -> change waiting for the debounce delay
+	fired   chan string
+}
+
+// watchScope is a WatchTarget with its paths resolved to absolute paths.
+type watchScope struct {
+	WatchTarget
+	paths []string
+}
+
+type pendingChange struct {
+	file  string
+	timer *time.Timer
+}
+
+// NewServiceWatcher creates a watcher for the given targets. Directories are watched recursively,
+// except for those matching DefaultWatchIgnore or a target's Ignore patterns.
+func NewServiceWatcher(targets []WatchTarget, onChange ChangeHandler) (*ServiceWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	w := &ServiceWatcher{
+		watcher:  fsw,
+		debounce: DefaultWatchDebounce,
+		onChange: onChange,
+		pending:  make(map[string]*pendingChange),
+		fired:    make(chan string),
+	}
+
+	for _, target := range targets {
+		scope, err := w.addTarget(target)
+		if err != nil {
+			_ = fsw.Close()
+			return nil, fmt.Errorf("service %s: %w", target.Service, err)
+		}
+		w.targets = append(w.targets, scope)
+	}
+
+	return w, nil
+}
+
+// addTarget resolves a target's paths and registers their directories with the watcher.
+func (w *ServiceWatcher) addTarget(target WatchTarget) (watchScope, error) {
+	scope := watchScope{WatchTarget: target}
+
+	paths := target.Paths
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	for _, p := range paths {
+		abs := p
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(target.Root, p)
+		}
+		abs = filepath.Clean(abs)
+
+		info, err := os.Stat(abs)
+		if err != nil {
+			return scope, fmt.Errorf("invalid watch path %q: %w", p, err)
+		}
+		scope.paths = append(scope.paths, abs)
+
+		if !info.IsDir() {
+			// Watch the parent so files replaced by editors (write to temp, then rename) stay watched
+			if err := w.watcher.Add(filepath.Dir(abs)); err != nil {
+				return scope, fmt.Errorf("failed to watch %q: %w", p, err)
+			}
+			continue
+		}
+		if err := w.addDirs(scope, abs); err != nil {
+			return scope, err
+		}
+	}
+
+	return scope, nil
+}
+
+// addDirs watches dir and every directory below it that is not ignored for scope.
+func (w *ServiceWatcher) addDirs(scope watchScope, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Directories can disappear while walking; skip them
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != dir && scope.ignores(p) {
+			return filepath.SkipDir
+		}
+		if err := w.watcher.Add(p); err != nil {
+			return fmt.Errorf("failed to watch %q: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// Run delivers debounced changes to the ChangeHandler until ctx is cancelled, then closes the watcher.
+// The handler runs on the Run goroutine, so restarts never overlap.
+func (w *ServiceWatcher) Run(ctx context.Context) error {
+	defer func() {
+		w.mu.Lock()
+		for _, change := range w.pending {
+			change.timer.Stop()
+		}
+		w.mu.Unlock()
+		_ = w.watcher.Close()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(ctx, event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("file watcher error", "error", err)
+		case name := <-w.fired:
+			w.mu.Lock()
+			change := w.pending[name]
+			delete(w.pending, name)
+			w.mu.Unlock()
+			if change != nil {
+				w.onChange(name, change.file)
+			}
+		}
+	}
+}
+
+// handleEvent schedules a restart for each service whose watched paths contain the event's path.
+func (w *ServiceWatcher) handleEvent(ctx context.Context, event fsnotify.Event) {
+	// Permission and timestamp changes don't change the source
+	if event.Op == fsnotify.Chmod {
+		return
+	}
+
+	for _, scope := range w.targets {
+		if !scope.contains(event.Name) || scope.ignores(event.Name) {
+			continue
+		}
+
+		// New directories must be added explicitly because fsnotify is not recursive
+		if event.Has(fsnotify.Create) {
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				if err := w.addDirs(scope, event.Name); err != nil {
+					slog.Debug("failed to watch new directory", "path", event.Name, "error", err)
+				}
+			}
+		}
+
+		w.schedule(ctx, scope.Service, scope.relative(event.Name))
+	}
+}
+
+// schedule (re)starts the debounce timer for a service, remembering the latest changed file.
+func (w *ServiceWatcher) schedule(ctx context.Context, serviceName, file string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if change, ok := w.pending[serviceName]; ok {
+		change.file = file
+		change.timer.Reset(w.debounce)
+		return
+	}
+
+	w.pending[serviceName] = &pendingChange{
+		file: file,
+		timer: time.AfterFunc(w.debounce, func() {
+			select {
+			case w.fired <- serviceName:
+			case <-ctx.Done():
+			}
+		}),
+	}
+}
+
+// contains reports whether p is one of the scope's watched paths or below one of them.
+func (s watchScope) contains(p string) bool {
+	_, ok := s.watchedPath(p)
+	return ok
+}
+
+// watchedPath returns the watched path that p is, or is below.
+func (s watchScope) watchedPath(p string) (string, bool) {
+	for _, watched := range s.paths {
+		if isWithin(watched, p) {
+			return watched, true
+		}
+	}
+	return "", false
+}
+
+// ignores reports whether p matches DefaultWatchIgnore or the scope's Ignore patterns.
+// Patterns are matched against each file or directory name in p and against each leading
+// part of p relative to Root, so "*.log", "tmp" and "data/cache" all work.
+func (s watchScope) ignores(p string) bool {
+	// Paths outside Root (e.g. watchPaths: ["../shared"]) are matched relative to their watched path
+	base := s.Root
+	if !isWithin(s.Root, p) {
+		if watched, ok := s.watchedPath(p); ok {
+			base = filepath.Dir(watched)
+		}
+	}
+	rel, err := filepath.Rel(base, p)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	for _, patterns := range [][]string{DefaultWatchIgnore, s.Ignore} {
+		for _, pattern := range patterns {
+			pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+			for i, part := range parts {
+				if matched, _ := path.Match(pattern, part); matched {
+					return true
+				}
+				if matched, _ := path.Match(pattern, strings.Join(parts[:i+1], "/")); matched {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// relative returns p relative to the scope's Root, with forward slashes.
+func (s watchScope) relative(p string) string {
+	if rel, err := filepath.Rel(s.Root, p); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(p)
+}
+
+// isWithin reports whether p is dir or below it.
+func isWithin(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// changeRecorder collects the changes a ServiceWatcher reports.
+type changeRecorder struct {
+	mu      sync.Mutex
+	changes []string // "service:file"
+	notify  chan struct{}
+}
+
+func newChangeRecorder() *changeRecorder {
+	return &changeRecorder{notify: make(chan struct{}, 16)}
+}
+
+func (r *changeRecorder) onChange(serviceName, file string) {
+	r.mu.Lock()
+	r.changes = append(r.changes, serviceName+":"+file)
+	r.mu.Unlock()
+	r.notify <- struct{}{}
+}
+
+func (r *changeRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.changes...)
+}
+
+// wait waits for the next reported change.
+func (r *changeRecorder) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.notify:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for a change, got %v", r.snapshot())
+	}
+}
+
+// expectQuiet fails if a change is reported within a few debounce periods.
+func (r *changeRecorder) expectQuiet(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.notify:
+		t.Fatalf("unexpected change reported: %v", r.snapshot())
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+// startWatcher runs a ServiceWatcher with a short debounce until the test ends.
+func startWatcher(t *testing.T, targets []WatchTarget, recorder *changeRecorder) {
+	t.Helper()
+	w, err := NewServiceWatcher(targets, recorder.onChange)
+	if err != nil {
+		t.Fatalf("NewServiceWatcher() error = %v", err)
+	}
+	w.debounce = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = w.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func writeFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(time.Now().String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServiceWatcher_DebouncesChangesPerService(t *testing.T) {
+	api := t.TempDir()
+	worker := t.TempDir()
+	recorder := newChangeRecorder()
+	startWatcher(t, []WatchTarget{
+		{Service: "api", Root: api},
+		{Service: "worker", Root: worker},
+	}, recorder)
+
+	// A burst of saves restarts the service once, naming the last file
+	writeFile(t, filepath.Join(api, "main.go"))
+	writeFile(t, filepath.Join(api, "handler.go"))
+	recorder.wait(t)
+	recorder.expectQuiet(t)
+
+	if got := recorder.snapshot(); len(got) != 1 || got[0] != "api:handler.go" {
+		t.Fatalf("changes = %v, want [api:handler.go]", got)
+	}
+
+	writeFile(t, filepath.Join(worker, "app.py"))
+	recorder.wait(t)
+	if got := recorder.snapshot(); len(got) != 2 || got[1] != "worker:app.py" {
+		t.Errorf("changes = %v, want worker:app.py second", got)
+	}
+}
+
+func TestServiceWatcher_IgnoresPatterns(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"node_modules/pkg", ".azure/logs", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recorder := newChangeRecorder()
+	startWatcher(t, []WatchTarget{{Service: "api", Root: root, Ignore: []string{"tmp", "*.log"}}}, recorder)
+
+	writeFile(t, filepath.Join(root, "node_modules", "pkg", "index.js"))
+	writeFile(t, filepath.Join(root, ".azure", "logs", "api.log"))
+	writeFile(t, filepath.Join(root, "tmp", "cache.txt"))
+	writeFile(t, filepath.Join(root, "server.log"))
+	recorder.expectQuiet(t)
+
+	writeFile(t, filepath.Join(root, "server.py"))
+	recorder.wait(t)
+	if got := recorder.snapshot(); len(got) != 1 || got[0] != "api:server.py" {
+		t.Errorf("changes = %v, want [api:server.py]", got)
+	}
+}
+
+func TestServiceWatcher_WatchPaths(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "src", "main.go"))
+	writeFile(t, filepath.Join(root, "config.yaml"))
+	writeFile(t, filepath.Join(root, "README.md"))
+	recorder := newChangeRecorder()
+	startWatcher(t, []WatchTarget{{Service: "api", Root: root, Paths: []string{"src", "config.yaml"}}}, recorder)
+
+	writeFile(t, filepath.Join(root, "README.md"))
+	recorder.expectQuiet(t)
+
+	writeFile(t, filepath.Join(root, "config.yaml"))
+	recorder.wait(t)
+
+	// Directories created after startup are watched too
+	writeFile(t, filepath.Join(root, "src", "handlers", "users.go"))
+	recorder.wait(t)
+	writeFile(t, filepath.Join(root, "src", "handlers", "users.go"))
+	recorder.wait(t)
+
+	got := recorder.snapshot()
+	if got[0] != "api:config.yaml" || got[len(got)-1] != "api:src/handlers/users.go" {
+		t.Errorf("changes = %v, want config.yaml then src/handlers/users.go", got)
+	}
+}
+
+func TestNewServiceWatcher_InvalidWatchPath(t *testing.T) {
+	_, err := NewServiceWatcher([]WatchTarget{{Service: "api", Root: t.TempDir(), Paths: []string{"missing"}}}, func(string, string) {})
+	if err == nil || !strings.Contains(err.Error(), `service api: invalid watch path "missing"`) {
+		t.Errorf("NewServiceWatcher() error = %v, want invalid watch path", err)
+	}
+}
+
+func TestWatchScopeIgnores(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "project", "api")
+	shared := filepath.Join(string(filepath.Separator), "project", "shared")
+	scope := watchScope{
+		WatchTarget: WatchTarget{Service: "api", Root: root, Ignore: []string{"*.log", "data/cache", "coverage/"}},
+		paths:       []string{root, shared},
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{filepath.Join(root, "main.py"), false},
+		{filepath.Join(root, ".venv", "lib", "site.py"), true},
+		{filepath.Join(root, "src", "__pycache__", "main.pyc"), true},
+		{filepath.Join(root, "logs", "app.log"), true},
+		{filepath.Join(root, "data", "cache", "entry"), true},
+		{filepath.Join(root, "data", "seed.json"), false},
+		{filepath.Join(root, "coverage", "index.html"), true},
+		{filepath.Join(shared, "models.py"), false},
+		{filepath.Join(shared, "node_modules", "x.js"), true},
+	}
+	for _, tt := range tests {
+		if got := scope.ignores(tt.path); got != tt.want {
+			t.Errorf("ignores(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestWatchTargetFor(t *testing.T) {
+	data := `
+host: containerapp
+language: go
+project: ./api
+watchPaths:
+  - cmd
+  - internal
+watchIgnore:
+  - "*_test.go"
+`
+	var svc Service
+	if err := yaml.Unmarshal([]byte(data), &svc); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	got := WatchTargetFor("api", svc, "/project/api")
+	want := WatchTarget{Service: "api", Root: "/project/api", Paths: []string{"cmd", "internal"}, Ignore: []string{"*_test.go"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WatchTargetFor() = %+v, want %+v", got, want)
+	}
+
+	// The service's own log file and its rotations are never watched
+	root := filepath.FromSlash("/project/api")
+	svc.LogFile = filepath.Join(root, "logs", "api.log")
+	got = WatchTargetFor("api", svc, root)
+	if want := []string{"*_test.go", "logs/api.log", "logs/api.log.*"}; !reflect.DeepEqual(got.Ignore, want) {
+		t.Errorf("WatchTargetFor().Ignore = %v, want %v", got.Ignore, want)
+	}
+	if !reflect.DeepEqual(svc.WatchIgnore, []string{"*_test.go"}) {
+		t.Errorf("WatchTargetFor() modified the service's watchIgnore: %v", svc.WatchIgnore)
+	}
+
+	// A log file outside the service directory doesn't add patterns
+	svc.LogFile = filepath.FromSlash("/project/logs/api.log")
+	if got := WatchTargetFor("api", svc, root); !reflect.DeepEqual(got.Ignore, []string{"*_test.go"}) {
+		t.Errorf("WatchTargetFor().Ignore = %v, want only watchIgnore", got.Ignore)
+	}
+}
@@ -80,15 +80,16 @@ type ServiceInfo struct {
 
 // LocalServiceInfo contains local development information.
 type LocalServiceInfo struct {
-	Status      string     `json:"status"` // "running", "not-running", "unknown"
-	Health      string     `json:"health"` // "healthy", "unhealthy", "unknown"
-	URL         string     `json:"url,omitempty"`
-	Port        int        `json:"port,omitempty"`
-	PID         int        `json:"pid,omitempty"`
-	StartTime   *time.Time `json:"startTime,omitempty"`
-	LastChecked *time.Time `json:"lastChecked,omitempty"`
-	ServiceType string     `json:"serviceType,omitempty"` // "http", "tcp", "process", "container"
-	ServiceMode string     `json:"serviceMode,omitempty"` // "watch", "build", "daemon", "task" (for type=process)
+	Status      string              `json:"status"` // "running", "not-running", "unknown"
+	Health      string              `json:"health"` // "healthy", "unhealthy", "unknown"
+	URL         string              `json:"url,omitempty"`
+	Port        int                 `json:"port,omitempty"`
+	Ports       []registry.PortInfo `json:"ports,omitempty"` // Every port mapping, primary (Port) first
+	PID         int                 `json:"pid,omitempty"`
+	StartTime   *time.Time          `json:"startTime,omitempty"`
+	LastChecked *time.Time          `json:"lastChecked,omitempty"`
+	ServiceType string              `json:"serviceType,omitempty"` // "http", "tcp", "process", "container"
+	ServiceMode string              `json:"serviceMode,omitempty"` // "watch", "build", "daemon", "task" (for type=process)
 }
 
 // AzureServiceInfo contains Azure-specific service information.
@@ -295,6 +296,7 @@ func mergeServiceInfo(azureYaml *service.AzureYaml, runningServices []*registry.
 				Health:      "", // Health is computed dynamically via health checks, not stored in registry
 				URL:         runningSvc.URL,
 				Port:        runningSvc.Port,
+				Ports:       runningSvc.Ports,
 				PID:         runningSvc.PID,
 				StartTime:   &runningSvc.StartTime,
 				LastChecked: &runningSvc.LastChecked,
@@ -151,6 +151,8 @@ func (a *CoverageAggregator) GenerateReport(format string) error {
 		return a.generateCoberturaReport(aggregate)
 	case "html":
 		return a.generateHTMLReport(aggregate)
+	case "lcov":
+		return a.generateLcovReport(aggregate)
 	default:
 		return fmt.Errorf("unsupported coverage format: %s", format)
 	}
@@ -158,7 +160,7 @@ func (a *CoverageAggregator) GenerateReport(format string) error {
 
 // GenerateAllReports generates all coverage report formats
 func (a *CoverageAggregator) GenerateAllReports() error {
-	formats := []string{"json", "cobertura", "html"}
+	formats := []string{"json", "cobertura", "html", "lcov"}
 	for _, format := range formats {
 		if err := a.GenerateReport(format); err != nil {
 			return err
@@ -388,6 +390,39 @@ func (a *CoverageAggregator) generateCoberturaReport(aggregate *AggregateCoverag
 	return nil
 }
 
+// generateLcovReport generates an lcov tracefile of the merged file coverage
+func (a *CoverageAggregator) generateLcovReport(aggregate *AggregateCoverage) error {
+	outputPath := filepath.Join(a.outputDir, "lcov.info")
+
+	files := make([]*FileCoverage, len(aggregate.Aggregate.Files))
+	copy(files, aggregate.Aggregate.Files)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	var sb strings.Builder
+	for _, file := range files {
+		sb.WriteString("SF:" + file.Path + "\n")
+
+		lineNums := make([]int, 0, len(file.LineHits))
+		for lineNum := range file.LineHits {
+			lineNums = append(lineNums, lineNum)
+		}
+		sort.Ints(lineNums)
+		for _, lineNum := range lineNums {
+			sb.WriteString(fmt.Sprintf("DA:%d,%d\n", lineNum, file.LineHits[lineNum]))
+		}
+
+		sb.WriteString(fmt.Sprintf("LF:%d\nLH:%d\nend_of_record\n", file.Lines.Total, file.Lines.Covered))
+	}
+
+	if err := os.WriteFile(outputPath, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write lcov report: %w", err)
+	}
+
+	return nil
+}
+
 // generateHTMLReport generates an HTML coverage report with source linking
 func (a *CoverageAggregator) generateHTMLReport(aggregate *AggregateCoverage) error {
 	// Generate main index page
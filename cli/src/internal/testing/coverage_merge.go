@@ -0,0 +1,216 @@
+package testing
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// coverageReportPatterns are where test frameworks write coverage reports, relative to the
+// service directory: Jest/Vitest (lcov), pytest-cov/coverage.py (Cobertura XML),
+// go test -coverprofile and dotnet test --collect "XPlat Code Coverage".
+var coverageReportPatterns = []string{
+	"coverage/lcov.info",
+	"lcov.info",
+	"coverage/cobertura-coverage.xml",
+	"coverage.xml",
+	"coverage.out",
+	"TestResults/*/coverage.cobertura.xml",
+}
+
+// FindCoverageReports returns the coverage reports in a service directory that were written
+// at or after since, so reports left over from earlier runs are ignored.
+func FindCoverageReports(serviceDir string, since time.Time) []string {
+	var reports []string
+	for _, pattern := range coverageReportPatterns {
+		matches, err := filepath.Glob(filepath.Join(serviceDir, filepath.FromSlash(pattern)))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() || info.ModTime().Before(since) {
+				continue
+			}
+			reports = append(reports, path)
+		}
+	}
+	return reports
+}
+
+// CollectServiceCoverage parses a service's coverage reports into one CoverageData, with file
+// paths normalized to slash-separated paths relative to the service directory. Returns nil
+// when there are no reports.
+func CollectServiceCoverage(serviceDir string, reports []string) (*CoverageData, error) {
+	if len(reports) == 0 {
+		return nil, nil
+	}
+
+	files := make(map[string]*FileCoverage)
+	for _, report := range reports {
+		data, err := ParseCoverageReport(report)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range data.Files {
+			path := normalizeCoveragePath(serviceDir, file.Path)
+			existing, ok := files[path]
+			if !ok {
+				existing = &FileCoverage{Path: path, LineHits: make(map[int]int)}
+				files[path] = existing
+			}
+			for line, hits := range file.LineHits {
+				existing.LineHits[line] += hits
+			}
+			// Reports without line detail (e.g. lcov LF/LH only) keep their own counts
+			if len(file.LineHits) == 0 {
+				existing.Lines.Total += file.Lines.Total
+				existing.Lines.Covered += file.Lines.Covered
+			}
+		}
+	}
+
+	coverage := &CoverageData{Files: make([]*FileCoverage, 0, len(files))}
+	for _, file := range files {
+		if len(file.LineHits) > 0 {
+			file.Lines.Total, file.Lines.Covered = len(file.LineHits), 0
+			for _, hits := range file.LineHits {
+				if hits > 0 {
+					file.Lines.Covered++
+				}
+			}
+		}
+		file.Lines.Percent = coveragePercent(file.Lines.Covered, file.Lines.Total)
+		coverage.Lines.Total += file.Lines.Total
+		coverage.Lines.Covered += file.Lines.Covered
+		coverage.Files = append(coverage.Files, file)
+	}
+	sort.Slice(coverage.Files, func(i, j int) bool {
+		return coverage.Files[i].Path < coverage.Files[j].Path
+	})
+	coverage.Lines.Percent = coveragePercent(coverage.Lines.Covered, coverage.Lines.Total)
+	return coverage, nil
+}
+
+// ParseCoverageReport parses an lcov, Cobertura XML or Go coverage profile report,
+// detected from its contents.
+func ParseCoverageReport(path string) (*CoverageData, error) {
+	// #nosec G304 -- path is a coverage report found in a service directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coverage report: %w", err)
+	}
+
+	content := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(content, "mode:"):
+		return NewGoTestRunner(filepath.Dir(path), nil).ParseCoverageProfile(path)
+	case strings.HasPrefix(content, "<"):
+		return parseCobertura(data, path)
+	case strings.HasPrefix(content, "TN:") || strings.HasPrefix(content, "SF:"):
+		return parseLcov(content), nil
+	default:
+		return nil, fmt.Errorf("unrecognized coverage report format: %s", path)
+	}
+}
+
+// parseLcov parses an lcov tracefile. Line hits come from DA records; files without them
+// use their LF/LH totals.
+func parseLcov(content string) *CoverageData {
+	coverage := &CoverageData{}
+	var file *FileCoverage
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, ":")
+		switch key {
+		case "SF":
+			file = &FileCoverage{Path: value, LineHits: make(map[int]int)}
+		case "DA":
+			if file == nil {
+				continue
+			}
+			// DA:<line>,<hits>[,<checksum>]
+			fields := strings.Split(value, ",")
+			if len(fields) < 2 {
+				continue
+			}
+			lineNum, err1 := strconv.Atoi(fields[0])
+			hits, err2 := strconv.Atoi(fields[1])
+			if err1 == nil && err2 == nil {
+				file.LineHits[lineNum] += hits
+			}
+		case "LF":
+			if file != nil {
+				file.Lines.Total, _ = strconv.Atoi(value)
+			}
+		case "LH":
+			if file != nil {
+				file.Lines.Covered, _ = strconv.Atoi(value)
+			}
+		case "end_of_record":
+			if file != nil {
+				coverage.Files = append(coverage.Files, file)
+				file = nil
+			}
+		}
+	}
+	return coverage
+}
+
+// parseCobertura parses a Cobertura XML report. Relative file names are resolved against
+// the report's first source directory.
+func parseCobertura(data []byte, path string) (*CoverageData, error) {
+	var report CoberturaCoverage
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse Cobertura report %s: %w", path, err)
+	}
+
+	source := ""
+	if len(report.Sources) > 0 {
+		source = strings.TrimSpace(report.Sources[0])
+	}
+
+	coverage := &CoverageData{}
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			filename := class.Filename
+			if source != "" && !filepath.IsAbs(filename) {
+				filename = filepath.Join(source, filename)
+			}
+			file := &FileCoverage{Path: filename, LineHits: make(map[int]int)}
+			for _, line := range class.Lines {
+				file.LineHits[line.Number] += line.Hits
+			}
+			coverage.Files = append(coverage.Files, file)
+		}
+	}
+	return coverage, nil
+}
+
+// normalizeCoveragePath makes a report's file path relative to the service directory with
+// forward slashes. Paths outside the service directory, and relative paths such as Go
+// import paths, are kept as they are.
+func normalizeCoveragePath(serviceDir, path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(serviceDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "./")
+}
+
+// coveragePercent returns covered as a percentage of total, or 0 when total is 0.
+func coveragePercent(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) / float64(total) * 100.0
+}
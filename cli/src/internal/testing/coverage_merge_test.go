@@ -0,0 +1,196 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const webLcov = `TN:
+SF:/repo/web/src/app.js
+DA:1,1
+DA:2,0
+DA:3,5
+DA:4,0
+LF:4
+LH:2
+end_of_record
+`
+
+const apiCobertura = `<?xml version="1.0" ?>
+<coverage line-rate="0.83" version="7.4">
+  <sources><source>/repo/api</source></sources>
+  <packages>
+    <package name="." line-rate="0.83">
+      <classes>
+        <class name="main.py" filename="main.py" line-rate="0.83">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="1"/>
+            <line number="3" hits="1"/>
+            <line number="4" hits="1"/>
+            <line number="5" hits="1"/>
+            <line number="6" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>
+`
+
+func writeCoverageReport(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseCoverageReport(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		file        string
+		content     string
+		wantPath    string
+		wantTotal   int // Lines after normalization
+		wantCovered int
+	}{
+		{"lcov", "lcov.info", webLcov, "/repo/web/src/app.js", 4, 2},
+		{"cobertura", "coverage.xml", apiCobertura, filepath.Join("/repo/api", "main.py"), 6, 5},
+		{"go coverprofile", "coverage.out", "mode: set\nexample.com/api/main.go:3.10,5.2 2 1\nexample.com/api/main.go:7.10,9.2 1 0\n", "example.com/api/main.go", 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeCoverageReport(t, path, tt.content)
+
+			data, err := ParseCoverageReport(path)
+			if err != nil {
+				t.Fatalf("ParseCoverageReport() error = %v", err)
+			}
+			if len(data.Files) != 1 {
+				t.Fatalf("expected 1 file, got %d", len(data.Files))
+			}
+			if data.Files[0].Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", data.Files[0].Path, tt.wantPath)
+			}
+
+			coverage, err := CollectServiceCoverage(dir, []string{path})
+			if err != nil {
+				t.Fatalf("CollectServiceCoverage() error = %v", err)
+			}
+			// Coverage is normalized to lines, so Go statement blocks count once per start line
+			if coverage.Lines.Total != tt.wantTotal || coverage.Lines.Covered != tt.wantCovered {
+				t.Errorf("lines = %d/%d, want %d/%d", coverage.Lines.Covered, coverage.Lines.Total, tt.wantCovered, tt.wantTotal)
+			}
+		})
+	}
+
+	path := filepath.Join(dir, "unknown.txt")
+	writeCoverageReport(t, path, "not a coverage report")
+	if _, err := ParseCoverageReport(path); err == nil {
+		t.Error("expected error for unrecognized report")
+	}
+}
+
+func TestCollectServiceCoverage_NormalizesPaths(t *testing.T) {
+	serviceDir := t.TempDir()
+	lcov := "SF:" + filepath.Join(serviceDir, "src", "app.js") + "\nDA:1,1\nDA:2,0\nend_of_record\n"
+	writeCoverageReport(t, filepath.Join(serviceDir, "coverage", "lcov.info"), lcov)
+
+	coverage, err := CollectServiceCoverage(serviceDir, []string{filepath.Join(serviceDir, "coverage", "lcov.info")})
+	if err != nil {
+		t.Fatalf("CollectServiceCoverage() error = %v", err)
+	}
+	if len(coverage.Files) != 1 || coverage.Files[0].Path != "src/app.js" {
+		t.Fatalf("expected src/app.js relative to the service, got %+v", coverage.Files)
+	}
+	if coverage.Lines.Percent != 50.0 {
+		t.Errorf("percent = %.1f, want 50.0", coverage.Lines.Percent)
+	}
+
+	if coverage, err := CollectServiceCoverage(serviceDir, nil); err != nil || coverage != nil {
+		t.Errorf("CollectServiceCoverage() with no reports = %v, %v; want nil, nil", coverage, err)
+	}
+}
+
+func TestFindCoverageReports_IgnoresStaleReports(t *testing.T) {
+	serviceDir := t.TempDir()
+	stale := filepath.Join(serviceDir, "coverage.out")
+	writeCoverageReport(t, stale, "mode: set\n")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+	fresh := filepath.Join(serviceDir, "TestResults", "run-1", "coverage.cobertura.xml")
+	writeCoverageReport(t, fresh, apiCobertura)
+
+	reports := FindCoverageReports(serviceDir, time.Now().Add(-time.Minute))
+	if len(reports) != 1 || reports[0] != fresh {
+		t.Errorf("FindCoverageReports() = %v, want [%s]", reports, fresh)
+	}
+}
+
+func TestAggregateThreshold_WeightsCoverageByLines(t *testing.T) {
+	root := t.TempDir()
+	outputDir := filepath.Join(root, "results")
+	started := time.Now().Add(-time.Minute)
+
+	webDir := filepath.Join(root, "web")
+	writeCoverageReport(t, filepath.Join(webDir, "coverage", "lcov.info"),
+		strings.Replace(webLcov, "/repo/web/src/app.js", filepath.Join(webDir, "src", "app.js"), 1))
+	apiDir := filepath.Join(root, "api")
+	writeCoverageReport(t, filepath.Join(apiDir, "coverage.xml"), strings.Replace(apiCobertura, "/repo/api", apiDir, 1))
+
+	tests := []struct {
+		threshold   float64
+		wantSuccess bool
+	}{
+		// web 2/4 and api 5/6 lines merge to 7/10 = 70%, not the 66.7% average of the services
+		{70, true},
+		{75, false},
+	}
+
+	for _, tt := range tests {
+		o := NewTestOrchestrator(&TestConfig{AggregateThreshold: tt.threshold, OutputDir: outputDir})
+		aggregator := o.newCoverageAggregator()
+		if aggregator == nil {
+			t.Fatal("expected --aggregate-threshold to enable coverage")
+		}
+
+		result := &AggregateResult{Success: true}
+		for _, svc := range []ServiceInfo{{Name: "web", Dir: webDir}, {Name: "api", Dir: apiDir}} {
+			// Coverage parsed from output has no line counts; the reports replace it
+			testResult := &TestResult{Service: svc.Name, Success: true, Coverage: &CoverageData{Lines: CoverageMetric{Percent: 99}}}
+			o.addServiceCoverage(aggregator, svc, testResult, started)
+		}
+		o.finishCoverage(result, aggregator)
+
+		if got := result.Coverage.Aggregate.Lines.Percent; got != 70.0 {
+			t.Errorf("aggregate coverage = %.1f%%, want 70.0%%", got)
+		}
+		if result.Success != tt.wantSuccess {
+			t.Errorf("threshold %.0f: Success = %v, want %v (error %q)", tt.threshold, result.Success, tt.wantSuccess, result.Error)
+		}
+		if !tt.wantSuccess && !strings.Contains(result.Error, "Aggregate coverage 70.0% is below threshold 75.0%") {
+			t.Errorf("unexpected error: %q", result.Error)
+		}
+	}
+
+	lcov, err := os.ReadFile(filepath.Join(outputDir, "lcov.info"))
+	if err != nil {
+		t.Fatalf("expected merged lcov report: %v", err)
+	}
+	for _, want := range []string{"SF:api/main.py", "SF:web/src/app.js", "DA:3,5"} {
+		if !strings.Contains(string(lcov), want) {
+			t.Errorf("merged lcov report missing %q:\n%s", want, lcov)
+		}
+	}
+}
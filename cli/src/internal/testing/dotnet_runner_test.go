@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -301,7 +302,7 @@ func TestDotnetRunnerRunTests_Integration(t *testing.T) {
 	}
 
 	runner := NewDotnetTestRunner(tmpDir, config)
-	result, err := runner.RunTests("unit", false)
+	result, err := runner.RunTests(context.Background(), "unit", false)
 
 	// The command might fail if dotnet isn't installed, that's ok
 	if err != nil {
@@ -0,0 +1,77 @@
+package testing
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// junitRunner is implemented by test runners whose framework can write a JUnit XML report
+// of individual test results (pytest, Jest with jest-junit).
+type junitRunner interface {
+	// requestJUnitReport asks for a JUnit report on the next run, preferably at path, and
+	// returns where it will be written, or "" when the runner can't write one.
+	requestJUnitReport(path string) string
+}
+
+// ParseJUnitReport reads the test cases from a JUnit XML report, with either a <testsuites>
+// or a single <testsuite> root. Nested suites are not supported.
+func ParseJUnitReport(path string) ([]TestCase, error) {
+	// #nosec G304 -- path is a JUnit report written by a test framework for this run
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JUnit report: %w", err)
+	}
+
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		var suite JUnitTestSuite
+		if suiteErr := xml.Unmarshal(data, &suite); suiteErr != nil {
+			return nil, fmt.Errorf("failed to parse JUnit report %s: %w", path, err)
+		}
+		suites.TestSuites = []JUnitTestSuite{suite}
+	}
+
+	var cases []TestCase
+	for _, suite := range suites.TestSuites {
+		for _, tc := range suite.TestCases {
+			testCase := TestCase{
+				Name:      tc.Name,
+				ClassName: tc.ClassName,
+				Duration:  tc.Time,
+				Status:    TestCasePassed,
+			}
+			switch {
+			case tc.Failure != nil:
+				testCase.Status = TestCaseFailed
+				testCase.Message = tc.Failure.Message
+				testCase.Details = strings.TrimSpace(tc.Failure.Content)
+			case tc.Error != nil:
+				testCase.Status = TestCaseError
+				testCase.Message = tc.Error.Message
+				testCase.Details = strings.TrimSpace(tc.Error.Content)
+			case tc.Skipped != nil:
+				testCase.Status = TestCaseSkipped
+				testCase.Message = tc.Skipped.Message
+			}
+			cases = append(cases, testCase)
+		}
+	}
+	return cases, nil
+}
+
+// readJUnitReport returns the test cases from a JUnit report written at or after since,
+// or nil when there is no such report.
+func readJUnitReport(path string, since time.Time) []TestCase {
+	info, err := os.Stat(path)
+	if err != nil || info.ModTime().Before(since) {
+		return nil
+	}
+	cases, err := ParseJUnitReport(path)
+	if err != nil {
+		return nil
+	}
+	return cases
+}
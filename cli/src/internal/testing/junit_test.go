@@ -0,0 +1,160 @@
+package testing
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const pytestJUnitReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites><testsuite name="pytest" errors="1" failures="1" skipped="1" tests="4" time="0.120">
+<testcase classname="tests.test_api" name="test_health" time="0.010" />
+<testcase classname="tests.test_api" name="test_create" time="0.020"><failure message="assert 500 == 201">def test_create():
+&gt;       assert response.status_code == 201</failure></testcase>
+<testcase classname="tests.test_api" name="test_db" time="0.001"><error message="failed on setup">fixture 'db' not found</error></testcase>
+<testcase classname="tests.test_api" name="test_slow" time="0.000"><skipped type="pytest.skip" message="slow" /></testcase>
+</testsuite></testsuites>`
+
+// Older pytest versions and some reporters write a single <testsuite> root
+const jestJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="src/app.test.js" tests="2" failures="1" time="0.5">
+  <testcase classname="App renders" name="App renders" time="0.3"></testcase>
+  <testcase classname="App fails" name="App fails" time="0.2">
+    <failure>Error: expect(received).toBe(expected)</failure>
+  </testcase>
+</testsuite>`
+
+func writeJUnitFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseJUnitReport_Pytest(t *testing.T) {
+	cases, err := ParseJUnitReport(writeJUnitFixture(t, pytestJUnitReport))
+	if err != nil {
+		t.Fatalf("ParseJUnitReport() error = %v", err)
+	}
+	if len(cases) != 4 {
+		t.Fatalf("Expected 4 test cases, got %d", len(cases))
+	}
+
+	want := []struct {
+		name   string
+		status TestCaseStatus
+	}{
+		{"test_health", TestCasePassed},
+		{"test_create", TestCaseFailed},
+		{"test_db", TestCaseError},
+		{"test_slow", TestCaseSkipped},
+	}
+	for i, w := range want {
+		if cases[i].Name != w.name || cases[i].Status != w.status {
+			t.Errorf("case %d = %s (%s), want %s (%s)", i, cases[i].Name, cases[i].Status, w.name, w.status)
+		}
+	}
+	if cases[0].ClassName != "tests.test_api" || cases[0].Duration != 0.010 {
+		t.Errorf("Expected class name and duration, got %+v", cases[0])
+	}
+	if cases[1].Message != "assert 500 == 201" || cases[1].Details == "" {
+		t.Errorf("Expected failure message and details, got %+v", cases[1])
+	}
+}
+
+func TestParseJUnitReport_SingleSuiteRoot(t *testing.T) {
+	cases, err := ParseJUnitReport(writeJUnitFixture(t, jestJUnitReport))
+	if err != nil {
+		t.Fatalf("ParseJUnitReport() error = %v", err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("Expected 2 test cases, got %d", len(cases))
+	}
+	if cases[1].Status != TestCaseFailed || cases[1].Details != "Error: expect(received).toBe(expected)" {
+		t.Errorf("Expected failed case with details, got %+v", cases[1])
+	}
+}
+
+func TestParseJUnitReport_Invalid(t *testing.T) {
+	if _, err := ParseJUnitReport(writeJUnitFixture(t, "not xml")); err == nil {
+		t.Error("Expected error for invalid report")
+	}
+}
+
+func TestReadJUnitReport_IgnoresStaleReport(t *testing.T) {
+	path := writeJUnitFixture(t, pytestJUnitReport)
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if cases := readJUnitReport(path, time.Now()); cases != nil {
+		t.Errorf("Expected stale report to be ignored, got %d cases", len(cases))
+	}
+	if cases := readJUnitReport(path, old.Add(-time.Minute)); len(cases) != 4 {
+		t.Errorf("Expected 4 cases from a current report, got %d", len(cases))
+	}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	results := &AggregateResult{
+		Services: []*TestResult{
+			{
+				Service:  "api",
+				Passed:   1,
+				Failed:   1,
+				Total:    2,
+				Duration: 0.5,
+				TestCases: []TestCase{
+					{Name: "test_ok", ClassName: "tests.test_api", Duration: 0.1, Status: TestCasePassed},
+					{Name: "test_bad", ClassName: "tests.test_api", Duration: 0.4, Status: TestCaseFailed, Message: "boom"},
+				},
+			},
+			{Service: "web", Passed: 3, Total: 3, Duration: 0.3},
+			{Service: "worker", Error: "test execution failed: exit status 2"},
+		},
+		Duration: 1.2,
+	}
+
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	if err := WriteJUnitReport(results, path); err != nil {
+		t.Fatalf("WriteJUnitReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+
+	if len(suites.TestSuites) != 3 {
+		t.Fatalf("Expected 3 suites, got %d", len(suites.TestSuites))
+	}
+	if suites.Tests != 6 || suites.Failures != 1 || suites.Errors != 1 {
+		t.Errorf("Expected 6 tests, 1 failure, 1 error; got %d, %d, %d", suites.Tests, suites.Failures, suites.Errors)
+	}
+
+	api := suites.TestSuites[0]
+	if api.Name != "api" || len(api.TestCases) != 2 || api.TestCases[1].Name != "test_bad" || api.TestCases[1].Failure == nil {
+		t.Errorf("Expected api suite from parsed test cases, got %+v", api)
+	}
+	if api.TestCases[0].ClassName != "tests.test_api" || api.TestCases[0].Time != 0.1 {
+		t.Errorf("Expected parsed class name and timing, got %+v", api.TestCases[0])
+	}
+
+	web := suites.TestSuites[1]
+	if web.Tests != 3 || len(web.TestCases) != 3 || web.TestCases[0].Time <= 0 {
+		t.Errorf("Expected 3 synthesized web test cases, got %+v", web)
+	}
+
+	worker := suites.TestSuites[2]
+	if worker.Errors != 1 || len(worker.TestCases) != 1 || worker.TestCases[0].Error == nil {
+		t.Errorf("Expected an errored worker test case, got %+v", worker)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -27,6 +28,7 @@ type NodeTestRunner struct {
 	projectDir     string
 	config         *ServiceTestConfig
 	packageManager string
+	jestJUnit      bool // Add the jest-junit reporter to Jest runs
 }
 
 // NewNodeTestRunner creates a new Node.js test runner.
@@ -45,7 +47,7 @@ func NewNodeTestRunner(projectDir string, config *ServiceTestConfig) *NodeTestRu
 }
 
 // RunTests executes tests for the Node.js project.
-func (r *NodeTestRunner) RunTests(testType string, coverage bool) (*TestResult, error) {
+func (r *NodeTestRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
 	result := &TestResult{
 		TestType: testType,
 		Success:  false,
@@ -55,7 +57,6 @@ func (r *NodeTestRunner) RunTests(testType string, coverage bool) (*TestResult,
 	command, args := r.buildTestCommand(testType, coverage)
 
 	// Execute the command
-	ctx := context.Background()
 	output, err := executor.RunCommandWithOutput(ctx, command, args, r.projectDir)
 
 	// Parse the output to extract results
@@ -110,6 +111,12 @@ func (r *NodeTestRunner) buildTestCommand(testType string, coverage bool) (strin
 		if coverage {
 			args = append(args, "--coverage")
 		}
+		if r.jestJUnit {
+			if !slices.Contains(args, "--") {
+				args = append(args, "--")
+			}
+			args = append(args, "--reporters=default", "--reporters=jest-junit")
+		}
 
 	case "vitest":
 		args = []string{"test", "--run"}
@@ -135,6 +142,20 @@ func (r *NodeTestRunner) buildTestCommand(testType string, coverage bool) (strin
 	return r.packageManager, args
 }
 
+// requestJUnitReport adds the jest-junit reporter to Jest runs when the project has it
+// installed, and returns where it writes its report (junit.xml in the project directory,
+// unless the project configures jest-junit otherwise). Other frameworks are not supported.
+func (r *NodeTestRunner) requestJUnitReport(_ string) string {
+	if r.config == nil || r.config.Framework != "jest" {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(r.projectDir, "node_modules", "jest-junit")); err != nil {
+		return ""
+	}
+	r.jestJUnit = true
+	return filepath.Join(r.projectDir, "junit.xml")
+}
+
 // parseCommand parses a command string into command and args.
 func (r *NodeTestRunner) parseCommand(cmdStr string) (string, []string) {
 	parts := ParseCommandString(cmdStr)
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -266,7 +267,7 @@ func TestNodeRunnerRunTests_Integration(t *testing.T) {
 	}
 
 	runner := NewNodeTestRunner(tmpDir, config)
-	result, err := runner.RunTests("unit", false)
+	result, err := runner.RunTests(context.Background(), "unit", false)
 
 	// The command should execute (even if it's just echo)
 	if err != nil {
@@ -306,6 +307,41 @@ func TestNodeRunnerBuildTestCommand_Coverage(t *testing.T) {
 	}
 }
 
+// TestNodeRunnerRequestJUnitReport tests that jest-junit is only used when it is installed
+func TestNodeRunnerRequestJUnitReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	runner := NewNodeTestRunner(tmpDir, &ServiceTestConfig{Framework: "jest"})
+
+	if got := runner.requestJUnitReport("ignored.xml"); got != "" {
+		t.Errorf("Expected no JUnit report without jest-junit, got %q", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "node_modules", "jest-junit"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(tmpDir, "junit.xml")
+	if got := runner.requestJUnitReport("ignored.xml"); got != want {
+		t.Errorf("Expected JUnit report at %q, got %q", want, got)
+	}
+
+	_, args := runner.buildTestCommand("all", false)
+	expected := []string{"test", "--", "--reporters=default", "--reporters=jest-junit"}
+	if len(args) != len(expected) {
+		t.Fatalf("Expected args %v, got %v", expected, args)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("Expected args %v, got %v", expected, args)
+			break
+		}
+	}
+
+	vitest := NewNodeTestRunner(tmpDir, &ServiceTestConfig{Framework: "vitest"})
+	if got := vitest.requestJUnitReport("ignored.xml"); got != "" {
+		t.Errorf("Expected no JUnit report for vitest, got %q", got)
+	}
+}
+
 // TestNodeRunnerBuildTestCommand_AllTypes tests different test types
 func TestNodeRunnerBuildTestCommand_AllTypes(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -3,16 +3,21 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jongio/azd-app/cli/src/internal/detector"
 	"github.com/jongio/azd-app/cli/src/internal/logging"
 	"github.com/jongio/azd-app/cli/src/internal/security"
+	"github.com/jongio/azd-app/cli/src/internal/service"
 	"gopkg.in/yaml.v3"
 )
 
@@ -50,6 +55,12 @@ const (
 	ProgressEventTestComplete
 	// ProgressEventServiceSkipped indicates a service was skipped
 	ProgressEventServiceSkipped
+	// ProgressEventWatchStart indicates watch mode is waiting for changes (Message lists the services)
+	ProgressEventWatchStart
+	// ProgressEventWatchRerun indicates a service's tests are re-running after a change (Message is the file)
+	ProgressEventWatchRerun
+	// ProgressEventWatchTally reports the running tally after a run in watch mode (Message is the summary)
+	ProgressEventWatchTally
 )
 
 // TestOrchestrator manages test execution across services.
@@ -57,6 +68,8 @@ type TestOrchestrator struct {
 	config           *TestConfig
 	services         []ServiceInfo
 	progressCallback ProgressCallback
+	progressMu       sync.Mutex // Serializes progress events from services running in parallel
+	newRunner        func(service ServiceInfo, config *ServiceTestConfig) (TestRunner, error)
 }
 
 // ServiceInfo represents a service with its test configuration.
@@ -73,6 +86,7 @@ func NewTestOrchestrator(config *TestConfig) *TestOrchestrator {
 		config:           config,
 		services:         make([]ServiceInfo, 0),
 		progressCallback: nil,
+		newRunner:        newTestRunner,
 	}
 }
 
@@ -84,6 +98,8 @@ func (o *TestOrchestrator) SetProgressCallback(callback ProgressCallback) {
 // emitProgress emits a progress event if a callback is set.
 func (o *TestOrchestrator) emitProgress(event ProgressEvent) {
 	if o.progressCallback != nil {
+		o.progressMu.Lock()
+		defer o.progressMu.Unlock()
 		o.progressCallback(event)
 	}
 }
@@ -154,31 +170,14 @@ func (o *TestOrchestrator) LoadServicesFromAzureYaml(azureYamlPath string) error
 
 	// Convert to ServiceInfo
 	azureYamlDir := filepath.Dir(azureYamlPath)
-	azureYamlDirAbs, err := filepath.Abs(azureYamlDir)
-	if err != nil {
-		return fmt.Errorf("failed to resolve azure.yaml directory: %w", err)
-	}
-
 	for name, svc := range azureYaml.Services {
-		// Resolve project directory
-		projectDir := svc.Project
-		if !filepath.IsAbs(projectDir) {
-			projectDir = filepath.Join(azureYamlDir, projectDir)
-		}
-
-		// Normalize the path
-		projectDir = filepath.Clean(projectDir)
+		// Resolve project directory, accepting either separator
+		projectDir := service.ResolveProjectPath(azureYamlDir, svc.Project)
 
 		// Security: Validate project directory stays within azure.yaml directory
 		// This prevents path traversal attacks via malicious azure.yaml
-		projectDirAbs, err := filepath.Abs(projectDir)
-		if err != nil {
-			return fmt.Errorf("failed to resolve project directory for service %s: %w", name, err)
-		}
-
-		// Check that the project directory is under the azure.yaml directory
-		if !strings.HasPrefix(projectDirAbs, azureYamlDirAbs) {
-			return fmt.Errorf("service %s project path '%s' escapes project boundary", name, svc.Project)
+		if _, err := service.ResolveServiceDir(azureYamlDir, svc.Project); err != nil {
+			return fmt.Errorf("service %s %w", name, err)
 		}
 
 		o.services = append(o.services, ServiceInfo{
@@ -231,6 +230,20 @@ func (o *TestOrchestrator) DetectTestConfig(service ServiceInfo) (*ServiceTestCo
 		}
 		config.Framework = framework
 
+	case "php":
+		framework, err := detectPhpTestFramework(service.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect PHP test framework: %w", err)
+		}
+		config.Framework = framework
+
+	case "rust", "rs":
+		framework, err := detectRustTestFramework(service.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect Rust test framework: %w", err)
+		}
+		config.Framework = framework
+
 	default:
 		return nil, fmt.Errorf("unsupported language: %s", service.Language)
 	}
@@ -263,11 +276,6 @@ func (o *TestOrchestrator) GetAvailableTestTypes() map[string][]string {
 
 // ExecuteTests runs tests for all services.
 func (o *TestOrchestrator) ExecuteTests(testType string, serviceFilter []string) (*AggregateResult, error) {
-	result := &AggregateResult{
-		Services: make([]*TestResult, 0),
-		Success:  true,
-	}
-
 	// Filter services if needed
 	services := o.services
 	if len(serviceFilter) > 0 {
@@ -278,88 +286,7 @@ func (o *TestOrchestrator) ExecuteTests(testType string, serviceFilter []string)
 		return nil, fmt.Errorf("no services to test")
 	}
 
-	// Initialize coverage aggregator if coverage is enabled
-	var coverageAggregator *CoverageAggregator
-	if o.config != nil && o.config.CoverageThreshold > 0 {
-		outputDir := o.config.OutputDir
-		if outputDir == "" {
-			outputDir = "./coverage"
-		}
-		coverageAggregator = NewCoverageAggregator(o.config.CoverageThreshold, outputDir)
-	}
-
-	// Execute tests for each service
-	for _, service := range services {
-		// Emit test start progress event
-		o.emitProgress(ProgressEvent{
-			Type:    ProgressEventTestStart,
-			Service: service.Name,
-		})
-
-		testResult, err := o.executeServiceTests(service, testType)
-		if err != nil {
-			if o.config != nil && o.config.FailFast {
-				return nil, fmt.Errorf("test failed for service %s: %w", service.Name, err)
-			}
-			// Continue with other services
-			testResult = &TestResult{
-				Service: service.Name,
-				Success: false,
-				Error:   err.Error(),
-			}
-		}
-
-		// Emit test complete progress event
-		o.emitProgress(ProgressEvent{
-			Type:    ProgressEventTestComplete,
-			Service: service.Name,
-		})
-
-		result.Services = append(result.Services, testResult)
-		result.Passed += testResult.Passed
-		result.Failed += testResult.Failed
-		result.Skipped += testResult.Skipped
-		result.Total += testResult.Total
-		result.Duration += testResult.Duration
-
-		if !testResult.Success {
-			result.Success = false
-		}
-
-		// Add coverage if available
-		if coverageAggregator != nil && testResult.Coverage != nil {
-			if err := coverageAggregator.AddCoverage(service.Name, testResult.Coverage); err != nil {
-				log := logging.NewLogger("test")
-				log.Warn("failed to add coverage data", "service", service.Name, "error", err.Error())
-			}
-		}
-	}
-
-	// Aggregate coverage and check threshold
-	if coverageAggregator != nil {
-		result.Coverage = coverageAggregator.Aggregate()
-
-		// Check threshold
-		meetsThreshold, percentage := coverageAggregator.CheckThreshold()
-		if !meetsThreshold {
-			result.Success = false
-			result.Error = fmt.Sprintf("Coverage %.1f%% is below threshold %.1f%%", percentage, o.config.CoverageThreshold)
-		}
-
-		// Generate coverage reports in multiple formats
-		log := logging.NewLogger("test")
-		if err := coverageAggregator.GenerateReport("json"); err != nil {
-			log.Warn("failed to generate JSON coverage report", "error", err.Error())
-		}
-		if err := coverageAggregator.GenerateReport("html"); err != nil {
-			log.Warn("failed to generate HTML coverage report", "error", err.Error())
-		}
-		if err := coverageAggregator.GenerateReport("cobertura"); err != nil {
-			log.Warn("failed to generate Cobertura coverage report", "error", err.Error())
-		}
-	}
-
-	return result, nil
+	return o.runServiceTests(services, testType, nil)
 }
 
 // ExecuteTestsWithValidation validates services and runs tests only for testable services.
@@ -422,53 +349,101 @@ func (o *TestOrchestrator) ExecuteTestsWithValidation(testType string, serviceFi
 		return result, validations, nil
 	}
 
-	// Initialize coverage aggregator if coverage is enabled
-	var coverageAggregator *CoverageAggregator
-	if o.config != nil && o.config.CoverageThreshold > 0 {
-		outputDir := o.config.OutputDir
-		if outputDir == "" {
-			outputDir = "./coverage"
-		}
-		coverageAggregator = NewCoverageAggregator(o.config.CoverageThreshold, outputDir)
+	// Pass each service's framework on to its test start event
+	frameworks := make(map[string]string, len(validations))
+	for _, v := range validations {
+		frameworks[v.Name] = v.Framework
 	}
 
-	// Execute tests for each testable service
-	for _, service := range testableServices {
-		// Find the validation for this service to get framework info
-		var framework string
-		for _, v := range validations {
-			if v.Name == service.Name {
-				framework = v.Framework
-				break
-			}
+	result, err := o.runServiceTests(testableServices, testType, frameworks)
+	if err != nil {
+		return nil, validations, err
+	}
+	return result, validations, nil
+}
+
+// serviceRun is the outcome of running one service's tests.
+type serviceRun struct {
+	result  *TestResult
+	started time.Time
+}
+
+// runServiceTests runs the tests of services, up to maxParallel at a time, and aggregates
+// their results and coverage in service order. With FailFast, the first service that fails
+// to run cancels the services still running and no further services are started.
+func (o *TestOrchestrator) runServiceTests(services []ServiceInfo, testType string, frameworks map[string]string) (*AggregateResult, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make([]*serviceRun, len(services))
+	slots := make(chan struct{}, o.maxParallel())
+	var wg sync.WaitGroup
+	var failOnce sync.Once
+	var failErr error
+
+	for i, service := range services {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
 		}
 
-		// Emit test start progress event
-		o.emitProgress(ProgressEvent{
-			Type:      ProgressEventTestStart,
-			Service:   service.Name,
-			Framework: framework,
-		})
+		wg.Add(1)
+		go func(i int, service ServiceInfo) {
+			defer wg.Done()
+			defer func() { <-slots }()
 
-		testResult, err := o.executeServiceTests(service, testType)
-		if err != nil {
-			if o.config != nil && o.config.FailFast {
-				return nil, validations, fmt.Errorf("test failed for service %s: %w", service.Name, err)
+			// Emit test start progress event
+			o.emitProgress(ProgressEvent{
+				Type:      ProgressEventTestStart,
+				Service:   service.Name,
+				Framework: frameworks[service.Name],
+			})
+
+			started := time.Now()
+			testResult, err := o.executeServiceTests(ctx, service, testType)
+			if err != nil {
+				if o.config != nil && o.config.FailFast {
+					failOnce.Do(func() {
+						failErr = fmt.Errorf("test failed for service %s: %w", service.Name, err)
+						cancel()
+					})
+				}
+				// Continue with other services
+				testResult = &TestResult{
+					Service: service.Name,
+					Success: false,
+					Error:   err.Error(),
+				}
 			}
-			// Continue with other services
-			testResult = &TestResult{
+
+			// Emit test complete progress event
+			o.emitProgress(ProgressEvent{
+				Type:    ProgressEventTestComplete,
 				Service: service.Name,
-				Success: false,
-				Error:   err.Error(),
-			}
-		}
+			})
 
-		// Emit test complete progress event
-		o.emitProgress(ProgressEvent{
-			Type:    ProgressEventTestComplete,
-			Service: service.Name,
-		})
+			runs[i] = &serviceRun{result: testResult, started: started}
+		}(i, service)
+	}
+	wg.Wait()
+
+	if failErr != nil {
+		return nil, failErr
+	}
+
+	result := &AggregateResult{
+		Services: make([]*TestResult, 0, len(services)),
+		Success:  true,
+	}
+
+	// Initialize coverage aggregator if coverage is enabled
+	coverageAggregator := o.newCoverageAggregator()
 
+	for i, run := range runs {
+		testResult := run.result
 		result.Services = append(result.Services, testResult)
 		result.Passed += testResult.Passed
 		result.Failed += testResult.Failed
@@ -481,43 +456,114 @@ func (o *TestOrchestrator) ExecuteTestsWithValidation(testType string, serviceFi
 		}
 
 		// Add coverage if available
-		if coverageAggregator != nil && testResult.Coverage != nil {
-			if err := coverageAggregator.AddCoverage(service.Name, testResult.Coverage); err != nil {
-				log := logging.NewLogger("test")
-				log.Warn("failed to add coverage data", "service", service.Name, "error", err.Error())
-			}
+		if coverageAggregator != nil {
+			o.addServiceCoverage(coverageAggregator, services[i], testResult, run.started)
 		}
 	}
 
-	// Aggregate coverage and check threshold
+	// Aggregate coverage and check thresholds
 	if coverageAggregator != nil {
-		result.Coverage = coverageAggregator.Aggregate()
+		o.finishCoverage(result, coverageAggregator)
+	}
 
-		// Check threshold
-		meetsThreshold, percentage := coverageAggregator.CheckThreshold()
-		if !meetsThreshold {
-			result.Success = false
-			result.Error = fmt.Sprintf("Coverage %.1f%% is below threshold %.1f%%", percentage, o.config.CoverageThreshold)
-		}
+	return result, nil
+}
 
-		// Generate coverage reports in multiple formats
-		log := logging.NewLogger("test")
-		if err := coverageAggregator.GenerateReport("json"); err != nil {
-			log.Warn("failed to generate JSON coverage report", "error", err.Error())
-		}
-		if err := coverageAggregator.GenerateReport("html"); err != nil {
-			log.Warn("failed to generate HTML coverage report", "error", err.Error())
-		}
-		if err := coverageAggregator.GenerateReport("cobertura"); err != nil {
-			log.Warn("failed to generate Cobertura coverage report", "error", err.Error())
+// maxParallel returns how many services run tests at once: 1 unless Parallel is set, then
+// MaxParallel, defaulting to GOMAXPROCS.
+func (o *TestOrchestrator) maxParallel() int {
+	if o.config == nil || !o.config.Parallel {
+		return 1
+	}
+	if o.config.MaxParallel > 0 {
+		return o.config.MaxParallel
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// coverageEnabled reports whether tests collect coverage, which a coverage threshold or an
+// aggregate threshold turns on.
+func (o *TestOrchestrator) coverageEnabled() bool {
+	return o.config != nil && (o.config.CoverageThreshold > 0 || o.config.AggregateThreshold > 0)
+}
+
+// newCoverageAggregator returns the aggregator that merges coverage across services,
+// or nil when coverage is not enabled.
+func (o *TestOrchestrator) newCoverageAggregator() *CoverageAggregator {
+	if !o.coverageEnabled() {
+		return nil
+	}
+	outputDir := o.config.OutputDir
+	if outputDir == "" {
+		outputDir = "./coverage"
+	}
+	return NewCoverageAggregator(o.config.CoverageThreshold, outputDir)
+}
+
+// addServiceCoverage adds a service's coverage to the aggregator. Coverage reports the test
+// framework wrote since the tests started (lcov, Cobertura or a Go coverage profile) replace
+// the coverage parsed from test output, since they carry line counts to weight the total by.
+// Relative file paths are prefixed with the service name so files from different services
+// stay apart.
+func (o *TestOrchestrator) addServiceCoverage(aggregator *CoverageAggregator, service ServiceInfo, testResult *TestResult, started time.Time) {
+	log := logging.NewLogger("test")
+
+	if reports := FindCoverageReports(service.Dir, started); len(reports) > 0 {
+		coverage, err := CollectServiceCoverage(service.Dir, reports)
+		if err != nil {
+			log.Warn("failed to read coverage reports", "service", service.Name, "error", err.Error())
+		} else {
+			for _, file := range coverage.Files {
+				if !filepath.IsAbs(file.Path) {
+					file.Path = service.Name + "/" + file.Path
+				}
+			}
+			testResult.Coverage = coverage
 		}
 	}
 
-	return result, validations, nil
+	if testResult.Coverage == nil {
+		return
+	}
+	if err := aggregator.AddCoverage(service.Name, testResult.Coverage); err != nil {
+		log.Warn("failed to add coverage data", "service", service.Name, "error", err.Error())
+	}
+}
+
+// finishCoverage merges the services' coverage into result, fails the result when coverage is
+// below the coverage or aggregate threshold, and writes the merged reports to the output directory.
+func (o *TestOrchestrator) finishCoverage(result *AggregateResult, aggregator *CoverageAggregator) {
+	result.Coverage = aggregator.Aggregate()
+	percentage := result.Coverage.Aggregate.Lines.Percent
+
+	// Check thresholds
+	if o.config.CoverageThreshold > 0 && !result.Coverage.Met {
+		result.Success = false
+		result.Error = fmt.Sprintf("Coverage %.1f%% is below threshold %.1f%%", percentage, o.config.CoverageThreshold)
+	}
+	if o.config.AggregateThreshold > 0 && percentage < o.config.AggregateThreshold {
+		result.Success = false
+		result.Error = fmt.Sprintf("Aggregate coverage %.1f%% is below threshold %.1f%%", percentage, o.config.AggregateThreshold)
+	}
+
+	// Generate coverage reports in multiple formats
+	log := logging.NewLogger("test")
+	if err := aggregator.GenerateReport("json"); err != nil {
+		log.Warn("failed to generate JSON coverage report", "error", err.Error())
+	}
+	if err := aggregator.GenerateReport("html"); err != nil {
+		log.Warn("failed to generate HTML coverage report", "error", err.Error())
+	}
+	if err := aggregator.GenerateReport("cobertura"); err != nil {
+		log.Warn("failed to generate Cobertura coverage report", "error", err.Error())
+	}
+	if err := aggregator.GenerateReport("lcov"); err != nil {
+		log.Warn("failed to generate lcov coverage report", "error", err.Error())
+	}
 }
 
 // executeServiceTests runs tests for a single service.
-func (o *TestOrchestrator) executeServiceTests(service ServiceInfo, testType string) (*TestResult, error) {
+func (o *TestOrchestrator) executeServiceTests(ctx context.Context, service ServiceInfo, testType string) (*TestResult, error) {
 	// Detect test configuration
 	config, err := o.DetectTestConfig(service)
 	if err != nil {
@@ -555,25 +601,26 @@ func (o *TestOrchestrator) executeServiceTests(service ServiceInfo, testType str
 	}()
 
 	// Create appropriate test runner based on language
-	var runner TestRunner
-	switch strings.ToLower(service.Language) {
-	case "js", "javascript", "typescript", "ts":
-		runner = NewNodeTestRunner(service.Dir, config)
-	case "python", "py":
-		runner = NewPythonTestRunner(service.Dir, config)
-	case "csharp", "dotnet", "fsharp", "cs", "fs":
-		runner = NewDotnetTestRunner(service.Dir, config)
-	case "go", "golang":
-		runner = NewGoTestRunner(service.Dir, config)
-	default:
-		return nil, fmt.Errorf("unsupported language: %s", service.Language)
+	runner, err := o.newRunner(service, config)
+	if err != nil {
+		return nil, err
 	}
 
-	// Execute tests (coverage flag from config)
-	coverageEnabled := false
-	if o.config != nil && o.config.CoverageThreshold > 0 {
-		coverageEnabled = true
+	// Request a JUnit report of individual test results from frameworks that can write one
+	junitReport := ""
+	if o.config != nil && o.config.JUnit {
+		if jr, ok := runner.(junitRunner); ok {
+			if tmp, err := os.CreateTemp("", "azd-app-junit-*.xml"); err == nil {
+				_ = tmp.Close()
+				defer func() { _ = os.Remove(tmp.Name()) }()
+				junitReport = jr.requestJUnitReport(tmp.Name())
+			}
+		}
 	}
+	started := time.Now()
+
+	// Execute tests (coverage flag from config)
+	coverageEnabled := o.coverageEnabled()
 
 	// Determine timeout
 	timeout := DefaultTestTimeout
@@ -582,47 +629,72 @@ func (o *TestOrchestrator) executeServiceTests(service ServiceInfo, testType str
 	}
 
 	// Execute tests with timeout
-	result, testErr = o.executeWithTimeout(runner, testType, coverageEnabled, timeout)
+	result, testErr = o.executeWithTimeout(ctx, runner, testType, coverageEnabled, timeout)
 	if testErr != nil {
 		return nil, testErr
 	}
 
 	result.Service = service.Name
+	if junitReport != "" {
+		result.TestCases = readJUnitReport(junitReport, started)
+	}
 	return result, nil
 }
 
-// executeWithTimeout runs tests with a timeout.
+// executeWithTimeout runs tests with a timeout, stopping them when ctx is cancelled.
 // Returns a clear error message if the timeout is exceeded.
-func (o *TestOrchestrator) executeWithTimeout(runner TestRunner, testType string, coverage bool, timeout time.Duration) (*TestResult, error) {
+func (o *TestOrchestrator) executeWithTimeout(ctx context.Context, runner TestRunner, testType string, coverage bool, timeout time.Duration) (*TestResult, error) {
 	type runResult struct {
 		result *TestResult
 		err    error
 	}
 
 	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Run tests in a goroutine
 	resultChan := make(chan runResult, 1)
 	go func() {
-		result, err := runner.RunTests(testType, coverage)
+		result, err := runner.RunTests(ctx, testType, coverage)
 		resultChan <- runResult{result: result, err: err}
 	}()
 
-	// Wait for either completion or timeout
+	// Wait for either completion, timeout or cancellation
 	select {
 	case <-ctx.Done():
-		// Timeout exceeded
-		return nil, fmt.Errorf("test execution timed out after %s", timeout)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("test execution timed out after %s", timeout)
+		}
+		return nil, fmt.Errorf("test execution cancelled")
 	case res := <-resultChan:
 		return res.result, res.err
 	}
 }
 
+// newTestRunner creates the test runner for a service's language.
+func newTestRunner(service ServiceInfo, config *ServiceTestConfig) (TestRunner, error) {
+	switch strings.ToLower(service.Language) {
+	case "js", "javascript", "typescript", "ts":
+		return NewNodeTestRunner(service.Dir, config), nil
+	case "python", "py":
+		return NewPythonTestRunner(service.Dir, config), nil
+	case "csharp", "dotnet", "fsharp", "cs", "fs":
+		return NewDotnetTestRunner(service.Dir, config), nil
+	case "go", "golang":
+		return NewGoTestRunner(service.Dir, config), nil
+	case "php":
+		return NewPhpTestRunner(service.Dir, config), nil
+	case "rust", "rs":
+		return NewRustTestRunner(service.Dir, config), nil
+	default:
+		return nil, fmt.Errorf("unsupported language: %s", service.Language)
+	}
+}
+
 // TestRunner interface for language-specific test runners.
 type TestRunner interface {
-	RunTests(testType string, coverage bool) (*TestResult, error)
+	RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error)
 }
 
 // GetServicePaths returns the paths of all services for file watching.
@@ -771,6 +843,54 @@ func detectGoTestFramework(dir string) (string, error) {
 	return "gotest", nil
 }
 
+// detectPhpTestFramework detects the PHP test framework: Pest, which runs on top of
+// PHPUnit and so is checked first, or PHPUnit.
+func detectPhpTestFramework(dir string) (string, error) {
+	// Check for Pest's bootstrap file
+	if _, err := os.Stat(filepath.Join(dir, "tests", "Pest.php")); err == nil {
+		return "pest", nil
+	}
+
+	// Check composer.json dependencies
+	// #nosec G304 -- Path is constructed safely
+	if data, err := os.ReadFile(filepath.Join(dir, "composer.json")); err == nil {
+		content := string(data)
+		if strings.Contains(content, `"pestphp/pest"`) {
+			return "pest", nil
+		}
+		if strings.Contains(content, `"phpunit/phpunit"`) {
+			return "phpunit", nil
+		}
+	}
+
+	// Check for PHPUnit configuration
+	for _, file := range []string{"phpunit.xml", "phpunit.xml.dist"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return "phpunit", nil
+		}
+	}
+
+	return "phpunit", nil // Default to PHPUnit
+}
+
+// detectRustTestFramework detects the Rust test framework. Cargo's built-in test
+// harness is the only one, so this checks that the crate has tests to run.
+func detectRustTestFramework(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "Cargo.toml")); err != nil {
+		return "", fmt.Errorf("no Cargo.toml found in %s", dir)
+	}
+
+	// Integration tests live in tests/, unit tests in #[test] functions under src
+	if info, err := os.Stat(filepath.Join(dir, "tests")); err == nil && info.IsDir() {
+		return "cargotest", nil
+	}
+	if countRustTestFiles(filepath.Join(dir, "src")) == 0 {
+		return "", fmt.Errorf("no tests found in %s", dir)
+	}
+
+	return "cargotest", nil
+}
+
 // filterServices filters services by name.
 func filterServices(services []ServiceInfo, filter []string) []ServiceInfo {
 	if len(filter) == 0 {
@@ -805,23 +925,33 @@ func FindAzureYaml() (string, error) {
 // executeCommands executes a list of commands in the specified directory.
 func (o *TestOrchestrator) executeCommands(dir string, commands []string, stage string) error {
 	log := logging.NewLogger("test").WithOperation(stage)
+	stdout, stderr := o.commandOutput()
 	for i, cmd := range commands {
 		if !logging.IsStructured() {
-			fmt.Printf("Running %s command %d/%d: %s\n", stage, i+1, len(commands), cmd)
+			fmt.Fprintf(stdout, "Running %s command %d/%d: %s\n", stage, i+1, len(commands), cmd)
 		}
 		log.Debug("executing command", "stage", stage, "index", i+1, "total", len(commands), "command", cmd)
 
 		// Execute command using os/exec
-		if err := runCommand(dir, cmd); err != nil {
+		if err := runCommand(dir, cmd, stdout, stderr); err != nil {
 			return fmt.Errorf("command '%s' failed: %w", cmd, err)
 		}
 	}
 	return nil
 }
 
+// commandOutput returns the writers for setup and teardown command output.
+// Without a configured CommandOutput, commands write to the terminal.
+func (o *TestOrchestrator) commandOutput() (stdout, stderr io.Writer) {
+	if o.config != nil && o.config.CommandOutput != nil {
+		return o.config.CommandOutput, o.config.CommandOutput
+	}
+	return os.Stdout, os.Stderr
+}
+
 // runCommand executes a command in the specified directory.
 // Parses the command string into command and arguments to avoid shell injection.
-func runCommand(dir, cmd string) error {
+func runCommand(dir, cmd string, stdout, stderr io.Writer) error {
 	parts := parseCommandString(cmd)
 	if len(parts) == 0 {
 		return fmt.Errorf("empty command")
@@ -830,11 +960,17 @@ func runCommand(dir, cmd string) error {
 	// #nosec G204 -- Command parts are validated and from azure.yaml
 	command := exec.Command(parts[0], parts[1:]...)
 	command.Dir = dir
-	command.Stdout = os.Stdout
-	command.Stderr = os.Stderr
+	command.Stdout = stdout
+	command.Stderr = stderr
 	return command.Run()
 }
 
+// SplitCommand splits a command string from azure.yaml into the command and its arguments
+// the way test commands are run, for other commands configured there such as install hooks.
+func SplitCommand(cmd string) []string {
+	return parseCommandString(cmd)
+}
+
 // parseCommandString parses a command string into command and arguments.
 // Handles quoted strings and basic shell-style arguments.
 func parseCommandString(cmd string) []string {
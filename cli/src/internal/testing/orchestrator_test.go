@@ -1,8 +1,13 @@
 package testing
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -384,6 +389,133 @@ func TestDetectPythonTestFramework_FromTestsDir(t *testing.T) {
 	}
 }
 
+func TestDetectTestConfig_PhpLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "phpunit.xml"), []byte("<phpunit/>"), 0644); err != nil {
+		t.Fatalf("Failed to create phpunit.xml: %v", err)
+	}
+
+	orchestrator := NewTestOrchestrator(&TestConfig{})
+	testConfig, err := orchestrator.DetectTestConfig(ServiceInfo{
+		Name:     "laravel",
+		Language: "php",
+		Dir:      tmpDir,
+	})
+	if err != nil {
+		t.Fatalf("DetectTestConfig failed: %v", err)
+	}
+
+	if testConfig.Framework != "phpunit" {
+		t.Errorf("Expected framework 'phpunit', got '%s'", testConfig.Framework)
+	}
+}
+
+func TestDetectPhpTestFramework(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"phpunit.xml", map[string]string{"phpunit.xml": "<phpunit/>"}, "phpunit"},
+		{"phpunit.xml.dist", map[string]string{"phpunit.xml.dist": "<phpunit/>"}, "phpunit"},
+		{"phpunit dev-dependency", map[string]string{"composer.json": `{"require-dev": {"phpunit/phpunit": "^11.0"}}`}, "phpunit"},
+		{"Pest bootstrap", map[string]string{"phpunit.xml": "<phpunit/>", "tests/Pest.php": "<?php"}, "pest"},
+		{"pest dev-dependency", map[string]string{"composer.json": `{"require-dev": {"pestphp/pest": "^3.0", "phpunit/phpunit": "^11.0"}}`}, "pest"},
+		{"default", nil, "phpunit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create %s: %v", name, err)
+				}
+			}
+
+			framework, err := detectPhpTestFramework(tmpDir)
+			if err != nil {
+				t.Fatalf("detectPhpTestFramework failed: %v", err)
+			}
+			if framework != tt.want {
+				t.Errorf("Expected framework '%s', got '%s'", tt.want, framework)
+			}
+		})
+	}
+}
+
+func TestDetectTestConfig_RustLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte("[package]\nname = \"api\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create Cargo.toml: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "tests"), 0755); err != nil {
+		t.Fatalf("Failed to create tests dir: %v", err)
+	}
+
+	orchestrator := NewTestOrchestrator(&TestConfig{})
+	for _, language := range []string{"rust", "Rust", "rs"} {
+		testConfig, err := orchestrator.DetectTestConfig(ServiceInfo{
+			Name:     "api",
+			Language: language,
+			Dir:      tmpDir,
+		})
+		if err != nil {
+			t.Fatalf("DetectTestConfig(%s) failed: %v", language, err)
+		}
+		if testConfig.Framework != "cargotest" {
+			t.Errorf("Expected framework 'cargotest' for %s, got '%s'", language, testConfig.Framework)
+		}
+	}
+}
+
+func TestDetectRustTestFramework(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{"unit tests in src", map[string]string{"Cargo.toml": "[package]", "src/lib.rs": "#[cfg(test)]\nmod tests {}"}, false},
+		{"test function in src", map[string]string{"Cargo.toml": "[package]", "src/main.rs": "#[test]\nfn it_works() {}"}, false},
+		{"tests directory", map[string]string{"Cargo.toml": "[package]", "src/main.rs": "fn main() {}", "tests/api.rs": "#[test]\nfn api() {}"}, false},
+		{"no tests", map[string]string{"Cargo.toml": "[package]", "src/main.rs": "fn main() {}"}, true},
+		{"no Cargo.toml", map[string]string{"src/lib.rs": "#[test]\nfn it_works() {}"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create %s: %v", name, err)
+				}
+			}
+
+			framework, err := detectRustTestFramework(tmpDir)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got framework '%s'", framework)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("detectRustTestFramework failed: %v", err)
+			}
+			if framework != "cargotest" {
+				t.Errorf("Expected framework 'cargotest', got '%s'", framework)
+			}
+		})
+	}
+}
+
 func TestDetectGoTestFramework_NoGoMod(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1474,7 +1606,7 @@ type mockTestRunner struct {
 	started chan struct{}
 }
 
-func (m *mockTestRunner) RunTests(testType string, coverage bool) (*TestResult, error) {
+func (m *mockTestRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
 	if m.started != nil {
 		close(m.started)
 	}
@@ -1514,7 +1646,7 @@ func TestExecuteWithTimeout_Success(t *testing.T) {
 		},
 	}
 
-	result, err := orchestrator.executeWithTimeout(runner, "unit", false, config.Timeout)
+	result, err := orchestrator.executeWithTimeout(context.Background(), runner, "unit", false, config.Timeout)
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -1542,7 +1674,7 @@ func TestExecuteWithTimeout_Error(t *testing.T) {
 		err:    &testError{msg: expectedErr},
 	}
 
-	result, err := orchestrator.executeWithTimeout(runner, "unit", false, config.Timeout)
+	result, err := orchestrator.executeWithTimeout(context.Background(), runner, "unit", false, config.Timeout)
 	if err == nil {
 		t.Fatal("Expected error, got nil")
 	}
@@ -1562,3 +1694,163 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+// concurrencyRunner records how many test runs are in flight at once
+type concurrencyRunner struct {
+	mu       *sync.Mutex
+	running  *int
+	maxSeen  *int
+	delay    time.Duration
+	fail     bool
+	canceled *int
+}
+
+func (r *concurrencyRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
+	r.mu.Lock()
+	*r.running++
+	if *r.running > *r.maxSeen {
+		*r.maxSeen = *r.running
+	}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		*r.running--
+		r.mu.Unlock()
+	}()
+
+	if r.fail {
+		return nil, &testError{msg: "runner failed"}
+	}
+	select {
+	case <-time.After(r.delay):
+		return &TestResult{Success: true, Passed: 1, Total: 1}, nil
+	case <-ctx.Done():
+		r.mu.Lock()
+		*r.canceled++
+		r.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// concurrencyStats reads a concurrencyRunner's counters
+type concurrencyStats struct {
+	mu       sync.Mutex
+	running  int
+	maxSeen  int
+	canceled int
+}
+
+func (s *concurrencyStats) get() (maxSeen, canceled int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxSeen, s.canceled
+}
+
+func newConcurrencyOrchestrator(config *TestConfig, serviceCount int, delay time.Duration, failing string) (*TestOrchestrator, *concurrencyStats) {
+	stats := &concurrencyStats{}
+
+	orchestrator := NewTestOrchestrator(config)
+	for i := 0; i < serviceCount; i++ {
+		orchestrator.services = append(orchestrator.services, ServiceInfo{
+			Name:     fmt.Sprintf("svc%d", i),
+			Language: "go",
+			Config:   &ServiceTestConfig{Framework: "gotest"},
+		})
+	}
+	orchestrator.newRunner = func(service ServiceInfo, config *ServiceTestConfig) (TestRunner, error) {
+		return &concurrencyRunner{
+			mu: &stats.mu, running: &stats.running, maxSeen: &stats.maxSeen, canceled: &stats.canceled,
+			delay: delay, fail: service.Name == failing,
+		}, nil
+	}
+	return orchestrator, stats
+}
+
+func TestExecuteTests_MaxParallel(t *testing.T) {
+	orchestrator, stats := newConcurrencyOrchestrator(&TestConfig{Parallel: true, MaxParallel: 2}, 6, 50*time.Millisecond, "")
+
+	var mu sync.Mutex
+	events := make(map[string][]ProgressEventType)
+	orchestrator.SetProgressCallback(func(event ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events[event.Service] = append(events[event.Service], event.Type)
+	})
+
+	result, err := orchestrator.ExecuteTests("unit", nil)
+	if err != nil {
+		t.Fatalf("ExecuteTests() error = %v", err)
+	}
+	if maxSeen, _ := stats.get(); maxSeen != 2 {
+		t.Errorf("Expected at most 2 services at once, saw %d", maxSeen)
+	}
+	if result.Total != 6 || !result.Success {
+		t.Errorf("Expected 6 passing tests, got %+v", result)
+	}
+	for i, svc := range result.Services {
+		if svc.Service != fmt.Sprintf("svc%d", i) {
+			t.Errorf("Expected results in service order, got %s at %d", svc.Service, i)
+		}
+		got := events[svc.Service]
+		if len(got) != 2 || got[0] != ProgressEventTestStart || got[1] != ProgressEventTestComplete {
+			t.Errorf("Expected start then complete for %s, got %v", svc.Service, got)
+		}
+	}
+}
+
+func TestExecuteTests_SequentialWithoutParallel(t *testing.T) {
+	orchestrator, stats := newConcurrencyOrchestrator(&TestConfig{MaxParallel: 4}, 3, 10*time.Millisecond, "")
+
+	if _, err := orchestrator.ExecuteTests("unit", nil); err != nil {
+		t.Fatalf("ExecuteTests() error = %v", err)
+	}
+	if maxSeen, _ := stats.get(); maxSeen != 1 {
+		t.Errorf("Expected services to run one at a time, saw %d", maxSeen)
+	}
+}
+
+func TestExecuteTests_FailFastCancelsInFlight(t *testing.T) {
+	config := &TestConfig{Parallel: true, MaxParallel: 3, FailFast: true}
+	orchestrator, stats := newConcurrencyOrchestrator(config, 6, 10*time.Second, "svc2")
+
+	start := time.Now()
+	_, err := orchestrator.ExecuteTests("unit", nil)
+	if err == nil || !strings.Contains(err.Error(), "svc2") {
+		t.Fatalf("Expected fail-fast error for svc2, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected in-flight services to be cancelled, took %s", elapsed)
+	}
+	// Runners see the cancellation shortly after ExecuteTests returns
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, canceled := stats.get()
+		if canceled == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the 2 other in-flight services to be cancelled, got %d", canceled)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMaxParallel(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *TestConfig
+		want   int
+	}{
+		{"nil config", nil, 1},
+		{"not parallel", &TestConfig{MaxParallel: 8}, 1},
+		{"explicit limit", &TestConfig{Parallel: true, MaxParallel: 3}, 3},
+		{"default limit", &TestConfig{Parallel: true}, runtime.GOMAXPROCS(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTestOrchestrator(tt.config).maxParallel(); got != tt.want {
+				t.Errorf("maxParallel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package testing provides test execution and coverage aggregation for multi-language projects.
+package testing
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/executor"
+)
+
+var (
+	// phpunitOKRe matches PHPUnit's all-passed summary: "OK (5 tests, 10 assertions)".
+	phpunitOKRe = regexp.MustCompile(`^OK \((\d+) tests?`)
+	// phpunitCountRe matches the counts in PHPUnit's "Tests: 5, Assertions: 8, Failures: 1." summary.
+	phpunitCountRe = regexp.MustCompile(`(Tests|Failures|Errors|Skipped|Incomplete): (\d+)`)
+	// pestCountRe matches the counts in Pest's "Tests:    1 failed, 4 passed (10 assertions)" summary.
+	pestCountRe = regexp.MustCompile(`(\d+) (passed|failed|skipped|todos?|incomplete|risky)`)
+	// phpunitTimeRe matches PHPUnit's "Time: 00:01.234" line.
+	phpunitTimeRe = regexp.MustCompile(`^Time: (\d+):([\d.]+)`)
+	// pestDurationRe matches Pest's "Duration: 1.23s" line.
+	pestDurationRe = regexp.MustCompile(`^Duration:\s+([\d.]+)s`)
+	// phpCoverageRe matches the line total of PHPUnit's text coverage ("Lines:   85.00% (17/20)")
+	// and Pest's coverage summary ("Total: 85.0 %").
+	phpCoverageRe = regexp.MustCompile(`^(?:Lines|Total):\s+([\d.]+)\s?%`)
+)
+
+// PhpTestRunner runs tests for PHP projects with PHPUnit or Pest.
+type PhpTestRunner struct {
+	projectDir string
+	config     *ServiceTestConfig
+	junitFile  string // Where the framework writes a JUnit XML report, when requested
+}
+
+// NewPhpTestRunner creates a new PHP test runner.
+func NewPhpTestRunner(projectDir string, config *ServiceTestConfig) *PhpTestRunner {
+	return &PhpTestRunner{
+		projectDir: projectDir,
+		config:     config,
+	}
+}
+
+// RunTests executes tests for the PHP project.
+func (r *PhpTestRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
+	result := &TestResult{
+		TestType: testType,
+		Success:  false,
+	}
+
+	// Build test command
+	command, args := r.buildTestCommand(testType, coverage)
+
+	// Execute the command
+	output, err := executor.RunCommandWithOutput(ctx, command, args, r.projectDir)
+
+	// Parse the output to extract results
+	r.parseTestOutput(string(output), result)
+
+	if err != nil {
+		result.Success = false
+		result.Error = err.Error()
+		// Don't return error if we got some results
+		if result.Total > 0 {
+			return result, nil
+		}
+		return result, fmt.Errorf("test execution failed: %w", err)
+	}
+
+	result.Success = result.Failed == 0
+	return result, nil
+}
+
+// buildTestCommand builds the test command based on framework and options.
+func (r *PhpTestRunner) buildTestCommand(testType string, coverage bool) (string, []string) {
+	framework := "phpunit"
+	if r.config != nil {
+		// Check if explicit command is configured
+		switch testType {
+		case "unit":
+			if r.config.Unit != nil && r.config.Unit.Command != "" {
+				return r.parseCommand(r.config.Unit.Command)
+			}
+		case "integration":
+			if r.config.Integration != nil && r.config.Integration.Command != "" {
+				return r.parseCommand(r.config.Integration.Command)
+			}
+		case "e2e":
+			if r.config.E2E != nil && r.config.E2E.Command != "" {
+				return r.parseCommand(r.config.E2E.Command)
+			}
+		}
+		if r.config.Framework == "pest" {
+			framework = "pest"
+		}
+	}
+
+	// Run the Composer-installed binary through php so it works on every platform
+	args := []string{filepath.Join("vendor", "bin", framework)}
+
+	// Filter by test type with groups (@group unit, ->group('unit'))
+	if testType != "all" {
+		args = append(args, "--group", testType)
+	}
+
+	// Add coverage flag
+	if coverage {
+		if framework == "pest" {
+			args = append(args, "--coverage")
+		} else {
+			args = append(args, "--coverage-text")
+		}
+	}
+
+	// Add JUnit report for individual test results
+	if r.junitFile != "" {
+		args = append(args, "--log-junit", r.junitFile)
+	}
+
+	return "php", args
+}
+
+// requestJUnitReport has PHPUnit or Pest write a JUnit XML report to path.
+func (r *PhpTestRunner) requestJUnitReport(path string) string {
+	r.junitFile = path
+	return path
+}
+
+// parseCommand parses a command string into command and args.
+func (r *PhpTestRunner) parseCommand(cmdStr string) (string, []string) {
+	parts := ParseCommandString(cmdStr)
+	if len(parts) == 0 {
+		return "php", []string{filepath.Join("vendor", "bin", "phpunit")}
+	}
+	if len(parts) == 1 {
+		return parts[0], []string{}
+	}
+	return parts[0], parts[1:]
+}
+
+// parseTestOutput parses PHPUnit or Pest output to extract results.
+func (r *PhpTestRunner) parseTestOutput(output string, result *TestResult) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case phpunitOKRe.MatchString(line):
+			// Example: "OK (5 tests, 10 assertions)"
+			if num, err := strconv.Atoi(phpunitOKRe.FindStringSubmatch(line)[1]); err == nil {
+				result.Total = num
+				result.Passed = num
+			}
+		case strings.HasPrefix(line, "Tests:"):
+			if pestCountRe.MatchString(line) {
+				r.parsePestSummary(line, result)
+			} else {
+				r.parsePhpunitSummary(line, result)
+			}
+		case phpunitTimeRe.MatchString(line):
+			// Example: "Time: 00:01.234, Memory: 10.00 MB"
+			match := phpunitTimeRe.FindStringSubmatch(line)
+			minutes, _ := strconv.Atoi(match[1])
+			seconds, _ := strconv.ParseFloat(match[2], 64)
+			result.Duration = float64(minutes)*60 + seconds
+		case pestDurationRe.MatchString(line):
+			// Example: "Duration: 1.23s"
+			if duration, err := strconv.ParseFloat(pestDurationRe.FindStringSubmatch(line)[1], 64); err == nil {
+				result.Duration = duration
+			}
+		case phpCoverageRe.MatchString(line):
+			if percent, err := strconv.ParseFloat(phpCoverageRe.FindStringSubmatch(line)[1], 64); err == nil {
+				if result.Coverage == nil {
+					result.Coverage = &CoverageData{}
+				}
+				result.Coverage.Lines.Percent = percent
+			}
+		}
+	}
+}
+
+// parsePhpunitSummary parses PHPUnit's summary after failures, skips or other issues.
+// Example: "Tests: 5, Assertions: 8, Failures: 1, Errors: 1, Skipped: 1."
+func (r *PhpTestRunner) parsePhpunitSummary(line string, result *TestResult) {
+	failed, skipped := 0, 0
+	for _, match := range phpunitCountRe.FindAllStringSubmatch(line, -1) {
+		num, err := strconv.Atoi(match[2])
+		if err != nil {
+			continue
+		}
+		switch match[1] {
+		case "Tests":
+			result.Total = num
+		case "Failures", "Errors":
+			failed += num
+		case "Skipped", "Incomplete":
+			skipped += num
+		}
+	}
+	result.Failed = failed
+	result.Skipped = skipped
+	result.Passed = result.Total - failed - skipped
+}
+
+// parsePestSummary parses Pest's summary line.
+// Example: "Tests:    1 failed, 1 skipped, 4 passed (10 assertions)"
+func (r *PhpTestRunner) parsePestSummary(line string, result *TestResult) {
+	for _, match := range pestCountRe.FindAllStringSubmatch(line, -1) {
+		num, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		switch match[2] {
+		case "passed", "risky":
+			result.Passed += num
+		case "failed":
+			result.Failed += num
+		default:
+			result.Skipped += num
+		}
+	}
+	result.Total = result.Passed + result.Failed + result.Skipped
+}
@@ -0,0 +1,117 @@
+package testing
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPhpRunnerBuildTestCommand(t *testing.T) {
+	phpunit := filepath.Join("vendor", "bin", "phpunit")
+	pest := filepath.Join("vendor", "bin", "pest")
+
+	tests := []struct {
+		name     string
+		config   *ServiceTestConfig
+		testType string
+		coverage bool
+		junit    string
+		wantCmd  string
+		wantArgs []string
+	}{
+		{"phpunit all", &ServiceTestConfig{Framework: "phpunit"}, "all", false, "", "php", []string{phpunit}},
+		{"phpunit unit with coverage", &ServiceTestConfig{Framework: "phpunit"}, "unit", true, "", "php", []string{phpunit, "--group", "unit", "--coverage-text"}},
+		{"pest with coverage", &ServiceTestConfig{Framework: "pest"}, "all", true, "", "php", []string{pest, "--coverage"}},
+		{"junit report", &ServiceTestConfig{Framework: "pest"}, "all", false, "report.xml", "php", []string{pest, "--log-junit", "report.xml"}},
+		{"nil config", nil, "integration", false, "", "php", []string{phpunit, "--group", "integration"}},
+		{"explicit command", &ServiceTestConfig{Framework: "phpunit", Unit: &TestTypeConfig{Command: "php artisan test --testsuite=Unit"}}, "unit", false, "", "php", []string{"artisan", "test", "--testsuite=Unit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewPhpTestRunner(t.TempDir(), tt.config)
+			if tt.junit != "" {
+				runner.requestJUnitReport(tt.junit)
+			}
+			command, args := runner.buildTestCommand(tt.testType, tt.coverage)
+			if command != tt.wantCmd || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("buildTestCommand() = %s %v, want %s %v", command, args, tt.wantCmd, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPhpRunnerParseTestOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		wantPassed   int
+		wantFailed   int
+		wantSkipped  int
+		wantDuration float64
+	}{
+		{
+			name:         "phpunit passed",
+			output:       "PHPUnit 11.0.0 by Sebastian Bergmann and contributors.\n\n.....  5 / 5 (100%)\n\nTime: 00:01.500, Memory: 10.00 MB\n\nOK (5 tests, 10 assertions)\n",
+			wantPassed:   5,
+			wantDuration: 1.5,
+		},
+		{
+			name:         "phpunit failures",
+			output:       "Time: 01:02.000, Memory: 10.00 MB\n\nFAILURES!\nTests: 6, Assertions: 8, Failures: 1, Errors: 1, Skipped: 1.\n",
+			wantPassed:   3,
+			wantFailed:   2,
+			wantSkipped:  1,
+			wantDuration: 62,
+		},
+		{
+			name:         "pest",
+			output:       "  PASS  Tests\\Unit\\ExampleTest\n\n  Tests:    1 failed, 1 skipped, 4 passed (10 assertions)\n  Duration: 0.25s\n",
+			wantPassed:   4,
+			wantFailed:   1,
+			wantSkipped:  1,
+			wantDuration: 0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewPhpTestRunner(t.TempDir(), &ServiceTestConfig{})
+			result := &TestResult{}
+			runner.parseTestOutput(tt.output, result)
+
+			if result.Passed != tt.wantPassed || result.Failed != tt.wantFailed || result.Skipped != tt.wantSkipped {
+				t.Errorf("Got passed=%d failed=%d skipped=%d, want %d/%d/%d",
+					result.Passed, result.Failed, result.Skipped, tt.wantPassed, tt.wantFailed, tt.wantSkipped)
+			}
+			if result.Total != tt.wantPassed+tt.wantFailed+tt.wantSkipped {
+				t.Errorf("Expected total %d, got %d", tt.wantPassed+tt.wantFailed+tt.wantSkipped, result.Total)
+			}
+			if result.Duration != tt.wantDuration {
+				t.Errorf("Expected duration %v, got %v", tt.wantDuration, result.Duration)
+			}
+		})
+	}
+}
+
+func TestPhpRunnerParseTestOutput_Coverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{"phpunit text coverage", "Code Coverage Report:\n  Summary:\n    Classes: 50.00% (1/2)\n    Methods: 75.00% (3/4)\n    Lines:   85.00% (17/20)\n"},
+		{"pest coverage", "  Http/Controllers/Controller ........ 100.0 %\n  ──────────\n  Total: 85.0 %\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewPhpTestRunner(t.TempDir(), &ServiceTestConfig{})
+			result := &TestResult{}
+			runner.parseTestOutput(tt.output, result)
+
+			if result.Coverage == nil || result.Coverage.Lines.Percent != 85 {
+				t.Errorf("Expected 85%% line coverage, got %+v", result.Coverage)
+			}
+		})
+	}
+}
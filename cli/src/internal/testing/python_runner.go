@@ -18,6 +18,7 @@ type PythonTestRunner struct {
 	projectDir     string
 	config         *ServiceTestConfig
 	packageManager string
+	junitFile      string // Where pytest writes a JUnit XML report, when requested
 }
 
 // NewPythonTestRunner creates a new Python test runner.
@@ -33,7 +34,7 @@ func NewPythonTestRunner(projectDir string, config *ServiceTestConfig) *PythonTe
 }
 
 // RunTests executes tests for the Python project.
-func (r *PythonTestRunner) RunTests(testType string, coverage bool) (*TestResult, error) {
+func (r *PythonTestRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
 	result := &TestResult{
 		TestType: testType,
 		Success:  false,
@@ -43,7 +44,6 @@ func (r *PythonTestRunner) RunTests(testType string, coverage bool) (*TestResult
 	command, args := r.buildTestCommand(testType, coverage)
 
 	// Execute the command
-	ctx := context.Background()
 	output, err := executor.RunCommandWithOutput(ctx, command, args, r.projectDir)
 
 	// Parse the output to extract results
@@ -157,12 +157,23 @@ func (r *PythonTestRunner) buildPytestCommand(testType string, coverage bool) (s
 		}
 	}
 
+	// Add JUnit report for individual test results
+	if r.junitFile != "" {
+		args = append(args, "--junitxml="+r.junitFile)
+	}
+
 	// Add verbose flag for better output parsing
 	args = append(args, "-v")
 
 	return command, args
 }
 
+// requestJUnitReport has pytest write a JUnit XML report to path.
+func (r *PythonTestRunner) requestJUnitReport(path string) string {
+	r.junitFile = path
+	return path
+}
+
 // buildUnittestCommand builds a unittest command.
 func (r *PythonTestRunner) buildUnittestCommand(testType string, coverage bool) (string, []string) {
 	var command string
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -96,6 +97,33 @@ func TestPythonRunnerBuildPytestCommand_WithCoverage(t *testing.T) {
 	}
 }
 
+func TestPythonRunnerBuildPytestCommand_WithJUnit(t *testing.T) {
+	tmpDir := t.TempDir()
+	runner := NewPythonTestRunner(tmpDir, &ServiceTestConfig{Framework: "pytest"})
+
+	_, args := runner.buildPytestCommand("all", false)
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--junitxml") {
+			t.Fatalf("Expected no --junitxml before a report is requested, got %v", args)
+		}
+	}
+
+	reportPath := filepath.Join(tmpDir, "junit.xml")
+	if got := runner.requestJUnitReport(reportPath); got != reportPath {
+		t.Errorf("Expected report path %q, got %q", reportPath, got)
+	}
+	_, args = runner.buildPytestCommand("all", false)
+	found := false
+	for _, arg := range args {
+		if arg == "--junitxml="+reportPath {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected --junitxml=%s in args, got %v", reportPath, args)
+	}
+}
+
 func TestPythonRunnerParseCommand(t *testing.T) {
 	tmpDir := t.TempDir()
 	config := &ServiceTestConfig{}
@@ -325,7 +353,7 @@ func TestPythonRunnerRunTests_Integration(t *testing.T) {
 	}
 
 	runner := NewPythonTestRunner(tmpDir, config)
-	result, err := runner.RunTests("unit", false)
+	result, err := runner.RunTests(context.Background(), "unit", false)
 
 	// The command might fail if pytest isn't installed, that's ok
 	if err != nil {
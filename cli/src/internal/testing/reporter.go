@@ -123,73 +123,32 @@ type JUnitSkipped struct {
 
 // generateJUnitReport generates a JUnit XML test report.
 func (g *ReportGenerator) generateJUnitReport(results *AggregateResult) error {
-	outputPath := filepath.Join(g.outputDir, "test-results.xml")
+	return WriteJUnitReport(results, filepath.Join(g.outputDir, "test-results.xml"))
+}
 
+// WriteJUnitReport writes results to path as a single JUnit XML file (azd app test --junit),
+// with a testsuite per service. Services whose framework wrote a JUnit report list its
+// individual testcases; for the others, testcases are synthesized from the failures and
+// the passed and skipped counts.
+func WriteJUnitReport(results *AggregateResult, path string) error {
 	suites := JUnitTestSuites{
 		Name:       "azd app test",
-		Tests:      results.Total,
-		Failures:   results.Failed,
-		Errors:     0,
-		Skipped:    results.Skipped,
 		Time:       results.Duration,
 		Timestamp:  time.Now().Format(time.RFC3339),
 		TestSuites: make([]JUnitTestSuite, 0, len(results.Services)),
 	}
 
 	for _, svcResult := range results.Services {
-		suite := JUnitTestSuite{
-			Name:      svcResult.Service,
-			Tests:     svcResult.Total,
-			Failures:  svcResult.Failed,
-			Errors:    0,
-			Skipped:   svcResult.Skipped,
-			Time:      svcResult.Duration,
-			Timestamp: time.Now().Format(time.RFC3339),
-			TestCases: make([]JUnitTestCase, 0),
-		}
-
-		// Add test cases for failures
-		for _, failure := range svcResult.Failures {
-			testCase := JUnitTestCase{
-				Name:      failure.Name,
-				ClassName: svcResult.Service,
-				Time:      0, // Individual test time not available
-				Failure: &JUnitFailure{
-					Message: failure.Message,
-					Type:    "AssertionError",
-					Content: failure.StackTrace,
-				},
-			}
-			suite.TestCases = append(suite.TestCases, testCase)
+		var suite JUnitTestSuite
+		if len(svcResult.TestCases) > 0 {
+			suite = junitSuiteFromTestCases(svcResult)
+		} else {
+			suite = synthesizeJUnitSuite(svcResult)
 		}
-
-		// Add placeholder test cases for passed tests
-		passedCount := svcResult.Passed
-		for i := 0; i < passedCount; i++ {
-			var testTime float64
-			if svcResult.Total > 0 {
-				testTime = svcResult.Duration / float64(svcResult.Total)
-			}
-			testCase := JUnitTestCase{
-				Name:      fmt.Sprintf("test_%d", i+1),
-				ClassName: svcResult.Service,
-				Time:      testTime,
-			}
-			suite.TestCases = append(suite.TestCases, testCase)
-		}
-
-		// Add placeholder test cases for skipped tests
-		skippedCount := svcResult.Skipped
-		for i := 0; i < skippedCount; i++ {
-			testCase := JUnitTestCase{
-				Name:      fmt.Sprintf("skipped_test_%d", i+1),
-				ClassName: svcResult.Service,
-				Time:      0,
-				Skipped:   &JUnitSkipped{Message: "Test skipped"},
-			}
-			suite.TestCases = append(suite.TestCases, testCase)
-		}
-
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
 		suites.TestSuites = append(suites.TestSuites, suite)
 	}
 
@@ -201,13 +160,126 @@ func (g *ReportGenerator) generateJUnitReport(results *AggregateResult) error {
 	// Add XML header
 	xmlData := append([]byte(xml.Header), data...)
 
-	if err := os.WriteFile(outputPath, xmlData, 0o644); err != nil {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create JUnit report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, xmlData, 0o644); err != nil {
 		return fmt.Errorf("failed to write JUnit report: %w", err)
 	}
 
 	return nil
 }
 
+// junitSuiteFromTestCases builds a service's testsuite from the test cases its framework reported.
+func junitSuiteFromTestCases(svcResult *TestResult) JUnitTestSuite {
+	suite := JUnitTestSuite{
+		Name:      svcResult.Service,
+		Time:      svcResult.Duration,
+		Timestamp: time.Now().Format(time.RFC3339),
+		TestCases: make([]JUnitTestCase, 0, len(svcResult.TestCases)),
+	}
+
+	for _, tc := range svcResult.TestCases {
+		className := tc.ClassName
+		if className == "" {
+			className = svcResult.Service
+		}
+		testCase := JUnitTestCase{
+			Name:      tc.Name,
+			ClassName: className,
+			Time:      tc.Duration,
+		}
+		switch tc.Status {
+		case TestCaseFailed:
+			testCase.Failure = &JUnitFailure{Message: tc.Message, Type: "AssertionError", Content: tc.Details}
+			suite.Failures++
+		case TestCaseError:
+			testCase.Error = &JUnitError{Message: tc.Message, Content: tc.Details}
+			suite.Errors++
+		case TestCaseSkipped:
+			testCase.Skipped = &JUnitSkipped{Message: tc.Message}
+			suite.Skipped++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	return suite
+}
+
+// synthesizeJUnitSuite builds a service's testsuite from its failures and pass/skip counts, for
+// frameworks that don't report individual tests. A service that failed without running any
+// tests gets a single errored testcase, so the failure shows up in CI.
+func synthesizeJUnitSuite(svcResult *TestResult) JUnitTestSuite {
+	suite := JUnitTestSuite{
+		Name:      svcResult.Service,
+		Tests:     svcResult.Total,
+		Failures:  svcResult.Failed,
+		Errors:    0,
+		Skipped:   svcResult.Skipped,
+		Time:      svcResult.Duration,
+		Timestamp: time.Now().Format(time.RFC3339),
+		TestCases: make([]JUnitTestCase, 0),
+	}
+
+	if svcResult.Total == 0 && svcResult.Error != "" {
+		suite.Tests, suite.Errors = 1, 1
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{
+			Name:      "test execution",
+			ClassName: svcResult.Service,
+			Time:      svcResult.Duration,
+			Error:     &JUnitError{Message: svcResult.Error},
+		})
+		return suite
+	}
+
+	// Add test cases for failures
+	for _, failure := range svcResult.Failures {
+		testCase := JUnitTestCase{
+			Name:      failure.Name,
+			ClassName: svcResult.Service,
+			Time:      0, // Individual test time not available
+			Failure: &JUnitFailure{
+				Message: failure.Message,
+				Type:    "AssertionError",
+				Content: failure.StackTrace,
+			},
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// Add placeholder test cases for passed tests
+	passedCount := svcResult.Passed
+	for i := 0; i < passedCount; i++ {
+		var testTime float64
+		if svcResult.Total > 0 {
+			testTime = svcResult.Duration / float64(svcResult.Total)
+		}
+		testCase := JUnitTestCase{
+			Name:      fmt.Sprintf("test_%d", i+1),
+			ClassName: svcResult.Service,
+			Time:      testTime,
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// Add placeholder test cases for skipped tests
+	skippedCount := svcResult.Skipped
+	for i := 0; i < skippedCount; i++ {
+		testCase := JUnitTestCase{
+			Name:      fmt.Sprintf("skipped_test_%d", i+1),
+			ClassName: svcResult.Service,
+			Time:      0,
+			Skipped:   &JUnitSkipped{Message: "Test skipped"},
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return suite
+}
+
 // generateGitHubReport generates GitHub Actions specific output.
 func (g *ReportGenerator) generateGitHubReport(results *AggregateResult) error {
 	// Output annotations for failed tests
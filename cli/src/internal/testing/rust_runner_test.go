@@ -0,0 +1,100 @@
+package testing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestRustRunner(t *testing.T, config *ServiceTestConfig, hasLlvmCov bool, files ...string) *RustTestRunner {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(""), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	runner := NewRustTestRunner(dir, config)
+	runner.lookPath = func(file string) (string, error) {
+		if hasLlvmCov && file == "cargo-llvm-cov" {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	return runner
+}
+
+func TestRustRunnerBuildTestCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *ServiceTestConfig
+		files      []string
+		hasLlvmCov bool
+		testType   string
+		coverage   bool
+		wantCmd    string
+		wantArgs   []string
+	}{
+		{"all", nil, nil, false, "all", false, "cargo", []string{"test"}},
+		{"unit library", nil, []string{"src/lib.rs"}, false, "unit", false, "cargo", []string{"test", "--lib"}},
+		{"unit library and binary", nil, []string{"src/lib.rs", "src/main.rs"}, false, "unit", false, "cargo", []string{"test", "--lib", "--bins"}},
+		{"integration", nil, nil, false, "integration", false, "cargo", []string{"test", "--test", "*"}},
+		{"e2e", nil, nil, false, "e2e", false, "cargo", []string{"test", "--test", "*", "e2e"}},
+		{"pattern", &ServiceTestConfig{Integration: &TestTypeConfig{Pattern: "db_"}}, nil, false, "integration", false, "cargo", []string{"test", "--test", "*", "db_"}},
+		{"coverage with llvm-cov", nil, nil, true, "all", true, "cargo", []string{"llvm-cov", "--summary-only"}},
+		{"coverage without llvm-cov", nil, nil, false, "all", true, "cargo", []string{"test"}},
+		{"explicit command", &ServiceTestConfig{Unit: &TestTypeConfig{Command: "cargo nextest run"}}, nil, false, "unit", false, "cargo", []string{"nextest", "run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := newTestRustRunner(t, tt.config, tt.hasLlvmCov, tt.files...)
+			command, args := runner.buildTestCommand(tt.testType, tt.coverage)
+			if command != tt.wantCmd || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("buildTestCommand() = %s %v, want %s %v", command, args, tt.wantCmd, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRustRunnerParseTestOutput(t *testing.T) {
+	output := `
+running 3 tests
+test tests::adds ... ok
+test tests::subtracts ... FAILED
+test tests::slow ... ignored
+
+test result: FAILED. 1 passed; 1 failed; 1 ignored; 0 measured; 0 filtered out; finished in 0.50s
+
+     Running tests/api.rs (target/debug/deps/api-1234)
+
+running 2 tests
+test api_works ... ok
+test api_lists ... ok
+
+test result: ok. 2 passed; 0 failed; 0 ignored; 0 measured; 0 filtered out; finished in 0.25s
+
+Filename                      Regions    Missed Regions     Cover   Functions  Missed Functions  Executed       Lines      Missed Lines     Cover
+TOTAL                             120                18    85.00%          14                 2    85.71%         200                30    85.00%
+`
+	runner := NewRustTestRunner(t.TempDir(), &ServiceTestConfig{})
+	result := &TestResult{}
+	runner.parseTestOutput(output, result)
+
+	if result.Passed != 3 || result.Failed != 1 || result.Skipped != 1 || result.Total != 5 {
+		t.Errorf("Got passed=%d failed=%d skipped=%d total=%d, want 3/1/1/5", result.Passed, result.Failed, result.Skipped, result.Total)
+	}
+	if result.Duration != 0.75 {
+		t.Errorf("Expected duration 0.75, got %v", result.Duration)
+	}
+	if result.Coverage == nil || result.Coverage.Lines.Percent != 85 {
+		t.Errorf("Expected 85%% line coverage, got %+v", result.Coverage)
+	}
+}
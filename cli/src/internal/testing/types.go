@@ -1,7 +1,10 @@
 // Package testing provides test execution and coverage aggregation for multi-language projects.
 package testing
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 // Coverage threshold constants for UI display.
 const (
@@ -15,14 +18,6 @@ const (
 	MaxCoverageThreshold = 100.0
 )
 
-// File watcher timing constants.
-const (
-	// DefaultPollInterval is the default interval for file system polling
-	DefaultPollInterval = 500 * time.Millisecond
-	// DefaultDebounceDelay is the default delay for debouncing file change events
-	DefaultDebounceDelay = 300 * time.Millisecond
-)
-
 // File permission constants.
 const (
 	// DirPermissions is the default permission mode for directories
@@ -35,10 +30,16 @@ const (
 type TestConfig struct {
 	// Parallel indicates whether to run tests for services in parallel
 	Parallel bool
+	// MaxParallel is the most services tested at once when Parallel is set
+	// Default is GOMAXPROCS if not set
+	MaxParallel int
 	// FailFast indicates whether to stop on first test failure
 	FailFast bool
 	// CoverageThreshold is the minimum coverage percentage required (0-100)
 	CoverageThreshold float64
+	// AggregateThreshold is the minimum coverage percentage required across all services
+	// combined, weighted by line count (0-100)
+	AggregateThreshold float64
 	// OutputDir is the directory for test reports and coverage
 	OutputDir string
 	// Verbose enables verbose test output
@@ -46,6 +47,12 @@ type TestConfig struct {
 	// Timeout is the per-service test timeout duration
 	// Default is 10 minutes if not set
 	Timeout time.Duration
+	// JUnit has test frameworks that can write JUnit XML (pytest, Jest with jest-junit) report
+	// individual test cases in TestResult.TestCases
+	JUnit bool
+	// CommandOutput receives the output of setup and teardown commands
+	// Default is the terminal (os.Stdout and os.Stderr) if not set
+	CommandOutput io.Writer
 }
 
 // ServiceTestConfig represents test configuration for a service.
@@ -114,6 +121,8 @@ type TestResult struct {
 	Duration float64
 	// Failures contains details of failed tests
 	Failures []TestFailure
+	// TestCases are the individual test outcomes, when the framework wrote a JUnit report
+	TestCases []TestCase
 	// Coverage data (if coverage was enabled)
 	Coverage *CoverageData
 	// Success indicates whether all tests passed
@@ -136,6 +145,32 @@ type TestFailure struct {
 	Line int
 }
 
+// TestCaseStatus is the outcome of a single test.
+type TestCaseStatus string
+
+const (
+	TestCasePassed  TestCaseStatus = "passed"
+	TestCaseFailed  TestCaseStatus = "failed"
+	TestCaseError   TestCaseStatus = "error"
+	TestCaseSkipped TestCaseStatus = "skipped"
+)
+
+// TestCase represents a single test, read from the JUnit report a test framework wrote.
+type TestCase struct {
+	// Name is the test name
+	Name string
+	// ClassName is the test's class, module or file
+	ClassName string
+	// Duration is the test execution time in seconds
+	Duration float64
+	// Status is the test outcome
+	Status TestCaseStatus
+	// Message is the failure, error or skip message
+	Message string
+	// Details is the failure or error output, such as a stack trace
+	Details string
+}
+
 // CoverageData represents coverage data for a service.
 type CoverageData struct {
 	// Lines coverage metric
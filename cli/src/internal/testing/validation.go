@@ -55,6 +55,10 @@ func ValidateService(service ServiceInfo) ServiceValidation {
 		return validateGoService(service, validation)
 	case "csharp", "dotnet", "fsharp", "cs", "fs":
 		return validateDotnetService(service, validation)
+	case "php":
+		return validatePhpService(service, validation)
+	case "rust", "rs":
+		return validateRustService(service, validation)
 	default:
 		validation.SkipReason = "Unsupported language: " + service.Language
 		return validation
@@ -287,6 +291,90 @@ func validateDotnetService(service ServiceInfo, validation ServiceValidation) Se
 	return validation
 }
 
+// validatePhpService validates a PHP service for testability.
+func validatePhpService(service ServiceInfo, validation ServiceValidation) ServiceValidation {
+	validation.Framework, _ = detectPhpTestFramework(service.Dir)
+
+	// Check for PHPUnit configuration (Pest reads it too)
+	hasConfig := false
+	for _, file := range []string{"phpunit.xml", "phpunit.xml.dist"} {
+		if _, err := os.Stat(filepath.Join(service.Dir, file)); err == nil {
+			hasConfig = true
+			break
+		}
+	}
+
+	// Count test files
+	testPatterns := []string{
+		"**/*Test.php",
+	}
+
+	testFileCount := countTestFiles(service.Dir, testPatterns)
+	validation.TestFiles = testFileCount
+
+	// Determine if service can be tested
+	if testFileCount > 0 || hasConfig {
+		validation.CanTest = true
+	} else {
+		validation.SkipReason = "No phpunit.xml and no *Test.php files found"
+	}
+
+	return validation
+}
+
+// validateRustService validates a Rust service for testability.
+func validateRustService(service ServiceInfo, validation ServiceValidation) ServiceValidation {
+	// Check for Cargo.toml
+	if _, err := os.Stat(filepath.Join(service.Dir, "Cargo.toml")); os.IsNotExist(err) {
+		validation.SkipReason = "No Cargo.toml file found"
+		return validation
+	}
+
+	testFileCount := countRustTestFiles(service.Dir)
+	validation.TestFiles = testFileCount
+	validation.Framework = "cargotest"
+
+	if testFileCount > 0 {
+		validation.CanTest = true
+	} else {
+		validation.SkipReason = "No #[test] functions or tests/ directory found"
+	}
+
+	return validation
+}
+
+// countRustTestFiles counts the Rust files in a directory tree that contain tests:
+// files under a tests/ directory and files with #[test] or #[cfg(test)].
+func countRustTestFiles(dir string) int {
+	count := 0
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		// Skip build output
+		if d.IsDir() && (d.Name() == "target" || d.Name() == ".git") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".rs") {
+			return nil
+		}
+
+		if rel, err := filepath.Rel(dir, path); err == nil && strings.HasPrefix(rel, "tests"+string(filepath.Separator)) {
+			count++
+			return nil
+		}
+		// #nosec G304 -- Path is from filepath.WalkDir of a validated service directory
+		if data, err := os.ReadFile(path); err == nil {
+			content := string(data)
+			if strings.Contains(content, "#[test]") || strings.Contains(content, "#[cfg(test)]") {
+				count++
+			}
+		}
+		return nil
+	})
+	return count
+}
+
 // countTestFiles counts files matching the given glob patterns in a directory.
 func countTestFiles(dir string, patterns []string) int {
 	count := 0
@@ -216,6 +216,111 @@ func TestValidateService_Python_NoTests(t *testing.T) {
 	}
 }
 
+func TestValidateService_Php_WithPest(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Create a Pest bootstrap and a test file
+	testsDir := filepath.Join(tmpDir, "tests", "Feature")
+	if err := os.MkdirAll(testsDir, 0755); err != nil {
+		t.Fatalf("Failed to create tests dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "tests", "Pest.php"), []byte("<?php"), 0644); err != nil {
+		t.Fatalf("Failed to create Pest.php: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testsDir, "ExampleTest.php"), []byte("<?php"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	validation := ValidateService(ServiceInfo{
+		Name:     "laravel",
+		Language: "php",
+		Dir:      tmpDir,
+	})
+
+	if !validation.CanTest {
+		t.Errorf("Expected CanTest to be true, got false. SkipReason: %s", validation.SkipReason)
+	}
+	if validation.Framework != "pest" {
+		t.Errorf("Expected framework 'pest', got '%s'", validation.Framework)
+	}
+	if validation.TestFiles != 1 {
+		t.Errorf("Expected 1 test file, got %d", validation.TestFiles)
+	}
+}
+
+func TestValidateService_Php_NoTests(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "index.php"), []byte("<?php echo 'hello';"), 0644); err != nil {
+		t.Fatalf("Failed to create index.php: %v", err)
+	}
+
+	validation := ValidateService(ServiceInfo{
+		Name:     "service",
+		Language: "php",
+		Dir:      tmpDir,
+	})
+
+	if validation.CanTest {
+		t.Error("Expected CanTest to be false")
+	}
+	if validation.SkipReason == "" {
+		t.Error("Expected SkipReason to be set")
+	}
+}
+
+func TestValidateService_Rust_WithTests(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"Cargo.toml":          "[package]\nname = \"api\"\n",
+		"src/lib.rs":          "pub fn add() {}\n\n#[cfg(test)]\nmod tests {}\n",
+		"src/main.rs":         "fn main() {}\n",
+		"tests/api.rs":        "#[test]\nfn api() {}\n",
+		"target/debug/gen.rs": "#[test]\nfn generated() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	validation := ValidateService(ServiceInfo{
+		Name:     "api",
+		Language: "rust",
+		Dir:      tmpDir,
+	})
+
+	if !validation.CanTest {
+		t.Errorf("Expected CanTest to be true, got false. SkipReason: %s", validation.SkipReason)
+	}
+	if validation.Framework != "cargotest" {
+		t.Errorf("Expected framework 'cargotest', got '%s'", validation.Framework)
+	}
+	if validation.TestFiles != 2 {
+		t.Errorf("Expected 2 test files (target/ skipped), got %d", validation.TestFiles)
+	}
+}
+
+func TestValidateService_Rust_NoCargoToml(t *testing.T) {
+	validation := ValidateService(ServiceInfo{
+		Name:     "api",
+		Language: "rs",
+		Dir:      t.TempDir(),
+	})
+
+	if validation.CanTest {
+		t.Error("Expected CanTest to be false")
+	}
+	if validation.SkipReason == "" {
+		t.Error("Expected SkipReason to be set")
+	}
+}
+
 func TestValidateService_Go_WithTests(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -390,7 +495,7 @@ func TestValidateService_UnsupportedLanguage(t *testing.T) {
 
 	service := ServiceInfo{
 		Name:     "service",
-		Language: "rust",
+		Language: "java",
 		Dir:      tmpDir,
 	}
 
@@ -399,8 +504,8 @@ func TestValidateService_UnsupportedLanguage(t *testing.T) {
 	if validation.CanTest {
 		t.Error("Expected CanTest to be false")
 	}
-	if validation.SkipReason != "Unsupported language: rust" {
-		t.Errorf("Expected SkipReason 'Unsupported language: rust', got '%s'", validation.SkipReason)
+	if validation.SkipReason != "Unsupported language: java" {
+		t.Errorf("Expected SkipReason 'Unsupported language: java', got '%s'", validation.SkipReason)
 	}
 }
 
@@ -1,435 +1,182 @@
 package testing
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 )
 
-func TestNewFileWatcher(t *testing.T) {
-	tmpDir := t.TempDir()
-	paths := []string{tmpDir}
-
-	watcher := NewFileWatcher(paths)
-	if watcher == nil {
-		t.Fatal("Expected watcher to be created")
-	}
-	if len(watcher.paths) != 1 {
-		t.Errorf("Expected 1 path, got %d", len(watcher.paths))
-	}
-	if watcher.pollInterval != 500*time.Millisecond {
-		t.Errorf("Expected interval 500ms, got %v", watcher.pollInterval)
-	}
-	if len(watcher.ignorePatterns) == 0 {
-		t.Error("Expected ignore patterns to be set")
-	}
+// watchRunner records which services' tests ran
+type watchRunner struct {
+	service string
+	runs    *watchRuns
 }
 
-func TestNewFileWatcher_WithOptions(t *testing.T) {
-	tmpDir := t.TempDir()
-	serviceMap := map[string]string{
-		"/path/to/service": "api",
-	}
-
-	watcher := NewFileWatcher([]string{tmpDir},
-		WithDebounceDelay(500*time.Millisecond),
-		WithClearConsole(true),
-		WithShowElapsedTime(false),
-		WithServicePathMap(serviceMap),
-	)
-
-	if watcher.debounceDelay != 500*time.Millisecond {
-		t.Errorf("Expected debounce delay 500ms, got %v", watcher.debounceDelay)
-	}
-	if !watcher.clearConsole {
-		t.Error("Expected clearConsole to be true")
-	}
-	if watcher.showElapsedTime {
-		t.Error("Expected showElapsedTime to be false")
-	}
-	if len(watcher.servicePathMap) != 1 {
-		t.Errorf("Expected 1 service in path map, got %d", len(watcher.servicePathMap))
-	}
+func (r *watchRunner) RunTests(ctx context.Context, testType string, coverage bool) (*TestResult, error) {
+	r.runs.mu.Lock()
+	r.runs.services = append(r.runs.services, r.service)
+	r.runs.mu.Unlock()
+	return &TestResult{Service: r.service, TestType: testType, Passed: 1, Total: 1, Success: r.service != "web"}, nil
 }
 
-func TestFileWatcherScanFiles(t *testing.T) {
-	tmpDir := t.TempDir()
-
-	// Create some test files
-	testFile1 := filepath.Join(tmpDir, "test1.js")
-	testFile2 := filepath.Join(tmpDir, "test2.py")
-	if err := os.WriteFile(testFile1, []byte("content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file 1: %v", err)
-	}
-	if err := os.WriteFile(testFile2, []byte("content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file 2: %v", err)
-	}
-
-	watcher := NewFileWatcher([]string{tmpDir})
-	err := watcher.scanFiles()
-	if err != nil {
-		t.Fatalf("scanFiles failed: %v", err)
-	}
-
-	if len(watcher.lastCheck) < 2 {
-		t.Errorf("Expected at least 2 files tracked, got %d", len(watcher.lastCheck))
-	}
-
-	// Check that both files are tracked
-	_, foundFile1 := watcher.lastCheck[testFile1]
-	_, foundFile2 := watcher.lastCheck[testFile2]
-
-	if !foundFile1 || !foundFile2 {
-		t.Error("Expected both test files to be tracked")
-	}
+type watchRuns struct {
+	mu       sync.Mutex
+	services []string
 }
 
-func TestFileWatcherShouldIgnore(t *testing.T) {
-	watcher := NewFileWatcher([]string{})
-
-	tests := []struct {
-		name     string
-		path     string
-		expected bool
-	}{
-		{
-			name:     "Should ignore node_modules",
-			path:     "/path/to/node_modules/file.js",
-			expected: true,
-		},
-		{
-			name:     "Should ignore .git",
-			path:     "/path/to/.git/config",
-			expected: true,
-		},
-		{
-			name:     "Should ignore coverage",
-			path:     "/path/to/coverage/lcov.info",
-			expected: true,
-		},
-		{
-			name:     "Should ignore test-results",
-			path:     "/path/to/test-results/report.xml",
-			expected: true,
-		},
-		{
-			name:     "Should ignore __pycache__",
-			path:     "/path/to/__pycache__/file.pyc",
-			expected: true,
-		},
-		{
-			name:     "Should not ignore regular files",
-			path:     "/path/to/src/index.js",
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := watcher.shouldIgnore(tt.path)
-			if result != tt.expected {
-				t.Errorf("Expected shouldIgnore(%s) to be %v, got %v", tt.path, tt.expected, result)
-			}
-		})
-	}
+func (r *watchRuns) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.services)
 }
 
-func TestFileWatcherIsRelevantFile(t *testing.T) {
-	watcher := NewFileWatcher([]string{})
-
-	tests := []struct {
-		name     string
-		filename string
-		expected bool
-	}{
-		{
-			name:     "JavaScript file",
-			filename: "index.js",
-			expected: true,
-		},
-		{
-			name:     "TypeScript file",
-			filename: "component.ts",
-			expected: true,
-		},
-		{
-			name:     "Python file",
-			filename: "main.py",
-			expected: true,
-		},
-		{
-			name:     "C# file",
-			filename: "Program.cs",
-			expected: true,
-		},
-		{
-			name:     "Go file",
-			filename: "main.go",
-			expected: true,
-		},
-		{
-			name:     "JSX file",
-			filename: "Component.jsx",
-			expected: true,
-		},
-		{
-			name:     "Binary file",
-			filename: "file.exe",
-			expected: false,
-		},
-		{
-			name:     "Image file",
-			filename: "logo.png",
-			expected: false,
-		},
-		{
-			name:     "JSON file",
-			filename: "package.json",
-			expected: false,
-		},
-		{
-			name:     "YAML file",
-			filename: "config.yaml",
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := watcher.isRelevantFile(tt.filename)
-			if result != tt.expected {
-				t.Errorf("Expected isRelevantFile(%s) to be %v, got %v", tt.filename, tt.expected, result)
-			}
+// startWatch runs Watch for an api and a web service in the background and returns the
+// services' directories, the recorded runs and the progress events.
+func startWatch(t *testing.T, serviceFilter []string) (dirs map[string]string, runs *watchRuns, events chan ProgressEvent) {
+	t.Helper()
+	dirs = map[string]string{"api": t.TempDir(), "web": t.TempDir()}
+	runs = &watchRuns{}
+	events = make(chan ProgressEvent, 100)
+
+	orchestrator := NewTestOrchestrator(&TestConfig{})
+	for _, name := range []string{"api", "web"} {
+		orchestrator.services = append(orchestrator.services, ServiceInfo{
+			Name:     name,
+			Language: "go",
+			Dir:      dirs[name],
+			Config:   &ServiceTestConfig{Framework: "gotest"},
 		})
 	}
-}
-
-func TestFileWatcherCheckForChanges_NoChanges(t *testing.T) {
-	tmpDir := t.TempDir()
-	testFile := filepath.Join(tmpDir, "test.js")
-	if err := os.WriteFile(testFile, []byte("content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	watcher := NewFileWatcher([]string{tmpDir})
-
-	// First scan
-	if err := watcher.scanFiles(); err != nil {
-		t.Fatalf("scanFiles failed: %v", err)
+	orchestrator.newRunner = func(service ServiceInfo, config *ServiceTestConfig) (TestRunner, error) {
+		return &watchRunner{service: service.Name, runs: runs}, nil
 	}
+	orchestrator.SetProgressCallback(func(event ProgressEvent) {
+		if event.Type >= ProgressEventWatchStart {
+			events <- event
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- orchestrator.Watch(ctx, "all", serviceFilter, func(*AggregateResult, error) {})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch() error = %v", err)
+		}
+	})
 
-	// Check for changes (should be none)
-	changedFiles, err := watcher.checkForChanges()
-	if err != nil {
-		t.Fatalf("checkForChanges failed: %v", err)
-	}
-	if len(changedFiles) > 0 {
-		t.Errorf("Expected no changes on first check, got %d changed files", len(changedFiles))
-	}
+	waitForWatchEvent(t, events, ProgressEventWatchStart)
+	return dirs, runs, events
 }
 
-func TestFileWatcherCheckForChanges_WithChanges(t *testing.T) {
-	tmpDir := t.TempDir()
-	testFile := filepath.Join(tmpDir, "test.js")
-	if err := os.WriteFile(testFile, []byte("initial content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	watcher := NewFileWatcher([]string{tmpDir})
-
-	// First scan
-	if err := watcher.scanFiles(); err != nil {
-		t.Fatalf("scanFiles failed: %v", err)
-	}
-
-	// Modify the file
-	time.Sleep(10 * time.Millisecond) // Ensure different timestamp
-	if err := os.WriteFile(testFile, []byte("modified content"), 0o644); err != nil {
-		t.Fatalf("Failed to modify test file: %v", err)
-	}
-
-	// Check for changes
-	changedFiles, err := watcher.checkForChanges()
-	if err != nil {
-		t.Fatalf("checkForChanges failed: %v", err)
-	}
-	if len(changedFiles) == 0 {
-		t.Error("Expected changes to be detected")
-	}
-	// Verify the changed file is in the list
-	found := false
-	for _, f := range changedFiles {
-		if f == testFile {
-			found = true
-			break
+func waitForWatchEvent(t *testing.T, events chan ProgressEvent, eventType ProgressEventType) ProgressEvent {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == eventType {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for progress event %d", eventType)
+			return ProgressEvent{}
 		}
 	}
-	if !found {
-		t.Errorf("Expected %s in changed files list", testFile)
-	}
 }
 
-func TestFileWatcherCheckForChanges_NewFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	testFile1 := filepath.Join(tmpDir, "test1.js")
-	if err := os.WriteFile(testFile1, []byte("content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-
-	watcher := NewFileWatcher([]string{tmpDir})
+func TestWatch_RerunsChangedService(t *testing.T) {
+	dirs, runs, events := startWatch(t, nil)
 
-	// First scan
-	if err := watcher.scanFiles(); err != nil {
-		t.Fatalf("scanFiles failed: %v", err)
+	if got := runs.get(); !reflect.DeepEqual(got, []string{"api", "web"}) {
+		t.Fatalf("Initial runs = %v, want api and web", got)
 	}
 
-	// Add a new file
-	testFile2 := filepath.Join(tmpDir, "test2.js")
-	if err := os.WriteFile(testFile2, []byte("new file"), 0o644); err != nil {
-		t.Fatalf("Failed to create new test file: %v", err)
+	// Test output is ignored, then a source change re-runs only its service
+	if err := os.WriteFile(filepath.Join(dirs["api"], "coverage.out"), []byte("mode: set"), 0o644); err != nil {
+		t.Fatal(err)
 	}
-
-	// Check for changes
-	changedFiles, err := watcher.checkForChanges()
-	if err != nil {
-		t.Fatalf("checkForChanges failed: %v", err)
-	}
-	if len(changedFiles) == 0 {
-		t.Error("Expected new file to be detected as change")
+	if err := os.WriteFile(filepath.Join(dirs["api"], "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
 	}
-}
 
-func TestFileWatcherCheckForChanges_IgnoresIrrelevantFiles(t *testing.T) {
-	tmpDir := t.TempDir()
-	testFile1 := filepath.Join(tmpDir, "test1.js")
-	if err := os.WriteFile(testFile1, []byte("content"), 0o644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
+	rerun := waitForWatchEvent(t, events, ProgressEventWatchRerun)
+	if rerun.Service != "api" || rerun.Message != "main.go" {
+		t.Errorf("Re-run event = %s %q, want api main.go", rerun.Service, rerun.Message)
 	}
-
-	watcher := NewFileWatcher([]string{tmpDir})
-
-	// First scan
-	if err := watcher.scanFiles(); err != nil {
-		t.Fatalf("scanFiles failed: %v", err)
+	tally := waitForWatchEvent(t, events, ProgressEventWatchTally)
+	if tally.Message != "2 run(s) · 1/2 services passing (failing: web)" {
+		t.Errorf("Tally = %q", tally.Message)
 	}
-
-	// Add an irrelevant file (PNG image)
-	imgFile := filepath.Join(tmpDir, "logo.png")
-	if err := os.WriteFile(imgFile, []byte("fake image"), 0o644); err != nil {
-		t.Fatalf("Failed to create image file: %v", err)
-	}
-
-	// Check for changes - should not detect irrelevant file
-	changedFiles, err := watcher.checkForChanges()
-	if err != nil {
-		t.Fatalf("checkForChanges failed: %v", err)
-	}
-	if len(changedFiles) > 0 {
-		t.Errorf("Expected irrelevant file to be ignored, got %d changed files", len(changedFiles))
+	if got := runs.get(); !reflect.DeepEqual(got, []string{"api", "web", "api"}) {
+		t.Errorf("Runs = %v, want only api re-run", got)
 	}
 }
 
-func TestFileWatcherGetAffectedServices(t *testing.T) {
-	tmpDir := t.TempDir()
-	apiDir := filepath.Join(tmpDir, "api")
-	webDir := filepath.Join(tmpDir, "web")
+func TestWatch_ServiceFilter(t *testing.T) {
+	dirs, runs, events := startWatch(t, []string{"web"})
 
-	if err := os.MkdirAll(apiDir, 0o755); err != nil {
-		t.Fatalf("Failed to create api dir: %v", err)
+	if err := os.WriteFile(filepath.Join(dirs["api"], "main.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
 	}
-	if err := os.MkdirAll(webDir, 0o755); err != nil {
-		t.Fatalf("Failed to create web dir: %v", err)
+	if err := os.WriteFile(filepath.Join(dirs["web"], "app.go"), []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
 	}
 
-	watcher := NewFileWatcher([]string{tmpDir},
-		WithServicePathMap(map[string]string{
-			apiDir: "api-service",
-			webDir: "web-service",
-		}),
-	)
-
-	tests := []struct {
-		name     string
-		changes  map[string]time.Time
-		expected []string
-	}{
-		{
-			name: "single service change",
-			changes: map[string]time.Time{
-				filepath.Join(apiDir, "handler.go"): time.Now(),
-			},
-			expected: []string{"api-service"},
-		},
-		{
-			name: "multiple services change",
-			changes: map[string]time.Time{
-				filepath.Join(apiDir, "handler.go"): time.Now(),
-				filepath.Join(webDir, "index.ts"):   time.Now(),
-			},
-			expected: []string{"api-service", "web-service"},
-		},
-		{
-			name:     "no changes",
-			changes:  map[string]time.Time{},
-			expected: []string{},
-		},
+	if rerun := waitForWatchEvent(t, events, ProgressEventWatchRerun); rerun.Service != "web" {
+		t.Errorf("Re-run service = %s, want web", rerun.Service)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := watcher.getAffectedServices(tt.changes)
-
-			if len(result) != len(tt.expected) {
-				t.Errorf("Expected %d affected services, got %d", len(tt.expected), len(result))
-				return
-			}
-
-			// Create map for easier lookup
-			resultMap := make(map[string]bool)
-			for _, s := range result {
-				resultMap[s] = true
-			}
-
-			for _, exp := range tt.expected {
-				if !resultMap[exp] {
-					t.Errorf("Expected service %s to be in affected list", exp)
-				}
-			}
-		})
+	waitForWatchEvent(t, events, ProgressEventWatchTally)
+	if got := runs.get(); !reflect.DeepEqual(got, []string{"web", "web"}) {
+		t.Errorf("Runs = %v, want only web", got)
 	}
 }
 
-func TestFileWatcherAddIgnorePattern(t *testing.T) {
-	watcher := NewFileWatcher([]string{})
-	initialCount := len(watcher.ignorePatterns)
-
-	watcher.AddIgnorePattern("*.log")
-
-	if len(watcher.ignorePatterns) != initialCount+1 {
-		t.Errorf("Expected %d ignore patterns, got %d", initialCount+1, len(watcher.ignorePatterns))
+func TestWatch_NoServices(t *testing.T) {
+	orchestrator := NewTestOrchestrator(&TestConfig{})
+	if err := orchestrator.Watch(context.Background(), "all", []string{"missing"}, func(*AggregateResult, error) {}); err == nil {
+		t.Error("Expected error when no services match the filter")
 	}
 }
 
-func TestFileWatcherSetPollInterval(t *testing.T) {
-	watcher := NewFileWatcher([]string{})
-	watcher.SetPollInterval(1 * time.Second)
+func TestWatchTally(t *testing.T) {
+	tally := &WatchTally{Latest: make(map[string]*TestResult)}
+	tally.record(&AggregateResult{Services: []*TestResult{
+		{Service: "api", Success: false},
+		{Service: "web", Success: true},
+	}})
+	tally.record(nil)
+	tally.record(&AggregateResult{Services: []*TestResult{{Service: "api", Success: true}}})
 
-	if watcher.pollInterval != 1*time.Second {
-		t.Errorf("Expected poll interval 1s, got %v", watcher.pollInterval)
+	if tally.Runs != 3 {
+		t.Errorf("Runs = %d, want 3", tally.Runs)
+	}
+	if failing := tally.Failing(); len(failing) != 0 {
+		t.Errorf("Failing() = %v, want none after api was fixed", failing)
+	}
+	if got := tally.String(); got != "3 run(s) · 2/2 services passing" {
+		t.Errorf("String() = %q", got)
 	}
 }
 
-func TestFileWatcherSetServicePathMap(t *testing.T) {
-	watcher := NewFileWatcher([]string{})
-	serviceMap := map[string]string{
-		"/path/to/api": "api",
-		"/path/to/web": "web",
-	}
+func TestWatchIgnore_OutputDir(t *testing.T) {
+	dir := t.TempDir()
 
-	watcher.SetServicePathMap(serviceMap)
+	orchestrator := NewTestOrchestrator(&TestConfig{OutputDir: filepath.Join(dir, "reports", "tests")})
+	if ignore := orchestrator.watchIgnore(dir); !slices.Contains(ignore, "reports/tests") {
+		t.Errorf("watchIgnore() = %v, want the output directory ignored", ignore)
+	}
 
-	if len(watcher.servicePathMap) != 2 {
-		t.Errorf("Expected 2 services in map, got %d", len(watcher.servicePathMap))
+	orchestrator = NewTestOrchestrator(&TestConfig{OutputDir: t.TempDir()})
+	if ignore := orchestrator.watchIgnore(dir); len(ignore) != len(testWatchIgnore) {
+		t.Errorf("watchIgnore() = %v, want only the defaults for an output directory elsewhere", ignore)
 	}
 }
@@ -5,6 +5,8 @@ type PythonProject struct {
 	Dir            string
 	PackageManager string // "uv", "poetry", or "pip"
 	Entrypoint     string // Optional: entry point file specified in azure.yaml
+	Frozen         bool   // Install exactly what the lock file pins (deps --frozen)
+	Hooks          *InstallHooks
 }
 
 // NodeProject represents a detected Node.js project.
@@ -13,11 +15,25 @@ type NodeProject struct {
 	PackageManager  string // "npm", "pnpm", or "yarn"
 	IsWorkspaceRoot bool   // True if this project defines npm/yarn/pnpm workspaces
 	WorkspaceRoot   string // Path to the workspace root if this is a workspace child
+	IgnoreScripts   bool   // Install without running lifecycle scripts (deps --ignore-scripts)
+	Frozen          bool   // Install exactly what the lock file pins (deps --frozen)
+	Hooks           *InstallHooks
 }
 
 // DotnetProject represents a detected .NET project.
 type DotnetProject struct {
-	Path string // Path to .csproj or .sln file
+	Path   string // Path to .csproj or .sln file
+	Frozen bool   // Restore exactly what packages.lock.json pins (deps --frozen)
+	Hooks  *InstallHooks
+}
+
+// InstallHooks are the commands run before and after a project's dependencies are
+// installed, from the preinstall and postinstall hooks of its service in azure.yaml.
+type InstallHooks struct {
+	Preinstall  [][]string // Each command split into the executable and its arguments
+	Postinstall [][]string // Run only after a successful install
+	Dir         string     // Working directory: the service's project directory
+	Env         []string   // The current environment plus the service's environment
 }
 
 // AspireProject represents a detected Aspire project.
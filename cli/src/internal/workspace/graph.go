@@ -0,0 +1,253 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// PackageNode is a local Node.js package in the install graph.
+type PackageNode struct {
+	Name      string   `json:"name"`
+	Dir       string   `json:"dir"`
+	DependsOn []string `json:"dependsOn,omitempty"` // Names of other local packages this package depends on
+}
+
+// InstallGraph describes the dependencies between local Node.js packages and the
+// order in which they are installed.
+type InstallGraph struct {
+	// Nodes are listed in install order: every package comes after the local packages it depends on.
+	// Packages that are part of a cycle keep their detection order after all acyclic packages.
+	Nodes []PackageNode `json:"nodes"`
+	// Cycles lists each detected dependency cycle as package names, starting and ending with the same package.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// packageManifest holds the package.json fields used to build the install graph.
+type packageManifest struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// BuildInstallGraph computes the install order of the given Node.js projects from the
+// dependencies declared in their package.json files. A dependency creates an edge only when
+// it names another project in the list (e.g. "workspace:*" or "file:../shared" references).
+// Projects without a readable package.json or name are identified by their directory name.
+func BuildInstallGraph(projects []types.NodeProject) *InstallGraph {
+	nodes := make([]PackageNode, len(projects))
+	manifests := make([]packageManifest, len(projects))
+	byName := make(map[string]int, len(projects))
+
+	for i, project := range projects {
+		manifest := readPackageManifest(project.Dir)
+		name := manifest.Name
+		if name == "" {
+			name = filepath.Base(project.Dir)
+		}
+		nodes[i] = PackageNode{Name: name, Dir: project.Dir}
+		manifests[i] = manifest
+		if _, exists := byName[name]; !exists {
+			byName[name] = i
+		}
+	}
+
+	// deps[i] holds the indexes of the local packages node i depends on
+	deps := make([][]int, len(nodes))
+	for i, manifest := range manifests {
+		seen := make(map[int]bool)
+		for _, depName := range manifest.dependencyNames() {
+			j, isLocal := byName[depName]
+			if !isLocal || j == i || seen[j] {
+				continue
+			}
+			seen[j] = true
+			deps[i] = append(deps[i], j)
+			nodes[i].DependsOn = append(nodes[i].DependsOn, nodes[j].Name)
+		}
+	}
+
+	order, remaining := topologicalOrder(deps)
+	graph := &InstallGraph{Nodes: make([]PackageNode, 0, len(nodes))}
+	for _, i := range append(order, remaining...) {
+		graph.Nodes = append(graph.Nodes, nodes[i])
+	}
+	for _, cycle := range FindCycles(deps, remaining) {
+		names := make([]string, len(cycle))
+		for k, i := range cycle {
+			names[k] = nodes[i].Name
+		}
+		graph.Cycles = append(graph.Cycles, names)
+	}
+
+	return graph
+}
+
+// OrderNodeProjects returns projects sorted so that each project is installed after the
+// local packages it depends on. Projects in a dependency cycle keep their original order.
+func OrderNodeProjects(projects []types.NodeProject) []types.NodeProject {
+	graph := BuildInstallGraph(projects)
+	byDir := make(map[string]types.NodeProject, len(projects))
+	for _, project := range projects {
+		byDir[project.Dir] = project
+	}
+
+	ordered := make([]types.NodeProject, 0, len(projects))
+	for _, node := range graph.Nodes {
+		ordered = append(ordered, byDir[node.Dir])
+	}
+	return ordered
+}
+
+// HasCycles returns true if any dependency cycle was detected.
+func (g *InstallGraph) HasCycles() bool {
+	return len(g.Cycles) > 0
+}
+
+// DOT renders the graph in Graphviz DOT format. Node labels carry the install position,
+// edges point from a package to the package it depends on, and edges in a cycle are red
+// with the cycle spelled out in a comment.
+func (g *InstallGraph) DOT() string {
+	cycleEdges := make(map[[2]string]bool)
+	for _, cycle := range g.Cycles {
+		for k := 0; k+1 < len(cycle); k++ {
+			cycleEdges[[2]string{cycle[k], cycle[k+1]}] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph install_order {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, cycle := range g.Cycles {
+		fmt.Fprintf(&b, "  // cycle: %s\n", strings.Join(cycle, " -> "))
+	}
+	for i, node := range g.Nodes {
+		fmt.Fprintf(&b, "  %q [label=%q];\n", node.Name, fmt.Sprintf("%d. %s", i+1, node.Name))
+	}
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			if cycleEdges[[2]string{node.Name, dep}] {
+				fmt.Fprintf(&b, "  %q -> %q [color=red];\n", node.Name, dep)
+			} else {
+				fmt.Fprintf(&b, "  %q -> %q;\n", node.Name, dep)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// readPackageManifest reads package.json from dir. Missing or invalid files yield an empty manifest.
+func readPackageManifest(dir string) packageManifest {
+	var manifest packageManifest
+	// #nosec G304 -- dir comes from project detection
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return manifest
+	}
+	_ = json.Unmarshal(data, &manifest)
+	return manifest
+}
+
+// dependencyNames returns every dependency name declared in the manifest, sorted.
+func (m packageManifest) dependencyNames() []string {
+	var names []string
+	for _, deps := range []map[string]string{m.Dependencies, m.DevDependencies, m.OptionalDependencies, m.PeerDependencies} {
+		for name := range deps {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// topologicalOrder orders node indexes so dependencies come first, preferring the lowest
+// index among ready nodes to keep detection order stable. Nodes that can never become
+// ready (they are in or depend on a cycle) are returned separately in index order.
+func topologicalOrder(deps [][]int) (order []int, remaining []int) {
+	pending := make([]int, len(deps))
+	dependents := make([][]int, len(deps))
+	for i, nodeDeps := range deps {
+		pending[i] = len(nodeDeps)
+		for _, j := range nodeDeps {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	done := make([]bool, len(deps))
+	for len(order) < len(deps) {
+		next := -1
+		for i := range deps {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			break
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+
+	for i := range deps {
+		if !done[i] {
+			remaining = append(remaining, i)
+		}
+	}
+	return order, remaining
+}
+
+// FindCycles returns one cycle per back edge found by a depth-first search over the
+// given nodes, where deps[i] lists the indexes node i depends on. Each cycle starts and
+// ends with the same node index.
+func FindCycles(deps [][]int, nodes []int) [][]int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(deps))
+	var stack []int
+	var cycles [][]int
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, j := range deps[i] {
+			switch state[j] {
+			case unvisited:
+				visit(j)
+			case visiting:
+				// Back edge: the stack from j to i forms a cycle
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k] == j {
+						cycle := append([]int{}, stack[k:]...)
+						cycles = append(cycles, append(cycle, j))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+	}
+
+	for _, i := range nodes {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+	return cycles
+}
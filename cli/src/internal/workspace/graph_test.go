@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jongio/azd-app/cli/src/internal/types"
+)
+
+// writePackage creates dir/<name>/package.json and returns the project for it.
+func writePackage(t *testing.T, root, name, packageJSON string) types.NodeProject {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(packageJSON), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return types.NodeProject{Dir: dir, PackageManager: "pnpm"}
+}
+
+func nodeNames(nodes []PackageNode) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+	return names
+}
+
+func TestBuildInstallGraph_Order(t *testing.T) {
+	root := t.TempDir()
+	// Detection order is deliberately the reverse of the install order
+	projects := []types.NodeProject{
+		writePackage(t, root, "web", `{"name": "@app/web", "dependencies": {"@app/ui": "workspace:*", "react": "^18.0.0"}}`),
+		writePackage(t, root, "ui", `{"name": "@app/ui", "peerDependencies": {"@app/shared": "workspace:*"}}`),
+		writePackage(t, root, "api", `{"name": "@app/api", "devDependencies": {"@app/shared": "file:../shared"}}`),
+		writePackage(t, root, "shared", `{"name": "@app/shared"}`),
+		writePackage(t, root, "tools", `{}`),
+	}
+
+	graph := BuildInstallGraph(projects)
+
+	if graph.HasCycles() {
+		t.Fatalf("unexpected cycles: %v", graph.Cycles)
+	}
+	want := []string{"@app/shared", "@app/ui", "@app/web", "@app/api", "tools"}
+	if got := nodeNames(graph.Nodes); !reflect.DeepEqual(got, want) {
+		t.Errorf("install order = %v, want %v", got, want)
+	}
+	// External packages (react) are not part of the graph
+	if got := graph.Nodes[2].DependsOn; !reflect.DeepEqual(got, []string{"@app/ui"}) {
+		t.Errorf("@app/web DependsOn = %v, want [@app/ui]", got)
+	}
+
+	ordered := OrderNodeProjects(projects)
+	if ordered[0].Dir != filepath.Join(root, "shared") || ordered[4].Dir != filepath.Join(root, "tools") {
+		t.Errorf("OrderNodeProjects() = %v, want shared first and tools last", ordered)
+	}
+}
+
+func TestBuildInstallGraph_Cycle(t *testing.T) {
+	root := t.TempDir()
+	projects := []types.NodeProject{
+		writePackage(t, root, "a", `{"name": "a", "dependencies": {"b": "workspace:*"}}`),
+		writePackage(t, root, "b", `{"name": "b", "dependencies": {"c": "workspace:*"}}`),
+		writePackage(t, root, "c", `{"name": "c", "dependencies": {"a": "workspace:*"}}`),
+		writePackage(t, root, "d", `{"name": "d", "dependencies": {"a": "workspace:*"}}`),
+		writePackage(t, root, "e", `{"name": "e"}`),
+	}
+
+	graph := BuildInstallGraph(projects)
+
+	if want := [][]string{{"a", "b", "c", "a"}}; !reflect.DeepEqual(graph.Cycles, want) {
+		t.Errorf("Cycles = %v, want %v", graph.Cycles, want)
+	}
+	// Acyclic packages first, then the cycle and its dependents in detection order
+	if got, want := nodeNames(graph.Nodes), []string{"e", "a", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("install order = %v, want %v", got, want)
+	}
+}
+
+func TestInstallGraph_DOT(t *testing.T) {
+	graph := &InstallGraph{
+		Nodes: []PackageNode{
+			{Name: "shared"},
+			{Name: "a", DependsOn: []string{"shared", "b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		},
+		Cycles: [][]string{{"a", "b", "a"}},
+	}
+
+	dot := graph.DOT()
+
+	for _, want := range []string{
+		"digraph install_order {",
+		"// cycle: a -> b -> a",
+		`"shared" [label="1. shared"];`,
+		`"a" -> "shared";`,
+		`"a" -> "b" [color=red];`,
+		`"b" -> "a" [color=red];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT() missing %q:\n%s", want, dot)
+		}
+	}
+}